@@ -0,0 +1,33 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"strings"
+)
+
+var (
+	//go:embed 12/cockroach/notification.sql
+	//go:embed 12/postgres/notification.sql
+	createNotificationLogsTable12 embed.FS
+)
+
+type LogstoreNotificationTable struct {
+	dbClient *sql.DB
+	username string
+	dbType   string
+}
+
+func (mig *LogstoreNotificationTable) Execute(ctx context.Context) error {
+	stmt, err := readStmt(createNotificationLogsTable12, "12", mig.dbType, "notification.sql")
+	if err != nil {
+		return err
+	}
+	_, err = mig.dbClient.ExecContext(ctx, strings.ReplaceAll(stmt, "%[1]s", mig.username))
+	return err
+}
+
+func (mig *LogstoreNotificationTable) String() string {
+	return "12_logstore_notification"
+}
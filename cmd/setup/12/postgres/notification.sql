@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS logstore.notification (
+    log_date TIMESTAMPTZ NOT NULL
+    , channel INT NOT NULL
+    , event_type TEXT NOT NULL
+    , instance_id TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS notification_log_date_desc ON logstore.notification (instance_id, log_date DESC);
+
+GRANT ALL ON TABLE logstore.notification TO %[1]s;
@@ -0,0 +1,31 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+)
+
+var (
+	//go:embed 13/cockroach/execution.sql
+	//go:embed 13/postgres/execution.sql
+	executionRunColumns13 embed.FS
+)
+
+type LogstoreExecutionRuns struct {
+	dbClient *sql.DB
+	dbType   string
+}
+
+func (mig *LogstoreExecutionRuns) Execute(ctx context.Context) error {
+	stmt, err := readStmt(executionRunColumns13, "13", mig.dbType, "execution.sql")
+	if err != nil {
+		return err
+	}
+	_, err = mig.dbClient.ExecContext(ctx, stmt)
+	return err
+}
+
+func (mig *LogstoreExecutionRuns) String() string {
+	return "13_logstore_execution_runs"
+}
@@ -0,0 +1,9 @@
+ALTER TABLE logstore.execution
+    ADD COLUMN IF NOT EXISTS resource_owner TEXT
+    , ADD COLUMN IF NOT EXISTS flow_type INT
+    , ADD COLUMN IF NOT EXISTS trigger_type INT
+    , ADD COLUMN IF NOT EXISTS user_id TEXT
+    , ADD COLUMN IF NOT EXISTS error_message TEXT
+    , ADD COLUMN IF NOT EXISTS logs JSONB;
+
+CREATE INDEX IF NOT EXISTS execution_runs_log_date_desc ON logstore.execution (instance_id, resource_owner, log_date DESC) WHERE took IS NOT NULL;
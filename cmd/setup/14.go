@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 14.sql
+	pushedAuthRequestsTable string
+)
+
+type PushedAuthRequestsTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *PushedAuthRequestsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, pushedAuthRequestsTable)
+	return err
+}
+
+func (mig *PushedAuthRequestsTable) String() string {
+	return "14_pushed_auth_requests_table"
+}
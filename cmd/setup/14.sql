@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS auth.pushed_auth_requests (
+    id TEXT NOT NULL,
+    instance_id TEXT NOT NULL,
+    client_id TEXT NOT NULL,
+    parameters JSONB NOT NULL,
+    creation_date TIMESTAMPTZ NOT NULL,
+    expiration TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, id)
+);
+
+CREATE INDEX IF NOT EXISTS pushed_auth_requests_expiration_idx ON auth.pushed_auth_requests (expiration);
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 15.sql
+	tokenJWKThumbprint string
+)
+
+type TokenJWKThumbprint struct {
+	dbClient *sql.DB
+}
+
+func (mig *TokenJWKThumbprint) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, tokenJWKThumbprint)
+	return err
+}
+
+func (mig *TokenJWKThumbprint) String() string {
+	return "15_token_jwk_thumbprint"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS jwk_thumbprint TEXT;
+ALTER TABLE auth.refresh_tokens ADD COLUMN IF NOT EXISTS jwk_thumbprint TEXT;
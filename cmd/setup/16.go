@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 16.sql
+	userLockedUntil string
+)
+
+type UserLockedUntil struct {
+	dbClient *sql.DB
+}
+
+func (mig *UserLockedUntil) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, userLockedUntil)
+	return err
+}
+
+func (mig *UserLockedUntil) String() string {
+	return "16_user_locked_until"
+}
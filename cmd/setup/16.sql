@@ -0,0 +1 @@
+ALTER TABLE auth.users2 ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 17.sql
+	tokenActor string
+)
+
+type TokenActor struct {
+	dbClient *sql.DB
+}
+
+func (mig *TokenActor) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, tokenActor)
+	return err
+}
+
+func (mig *TokenActor) String() string {
+	return "17_token_actor"
+}
@@ -56,17 +56,23 @@ func MustNewConfig(v *viper.Viper) *Config {
 }
 
 type Steps struct {
-	s1ProjectionTable    *ProjectionTable
-	s2AssetsTable        *AssetTable
-	FirstInstance        *FirstInstance
-	s4EventstoreIndexes  *EventstoreIndexesNew
-	s5LastFailed         *LastFailed
-	s6OwnerRemoveColumns *OwnerRemoveColumns
-	s7LogstoreTables     *LogstoreTables
-	s8AuthTokens         *AuthTokenIndexes
-	s9EventstoreIndexes2 *EventstoreIndexesNew
-	CorrectCreationDate  *CorrectCreationDate
-	AddEventCreatedAt    *AddEventCreatedAt
+	s1ProjectionTable        *ProjectionTable
+	s2AssetsTable            *AssetTable
+	FirstInstance            *FirstInstance
+	s4EventstoreIndexes      *EventstoreIndexesNew
+	s5LastFailed             *LastFailed
+	s6OwnerRemoveColumns     *OwnerRemoveColumns
+	s7LogstoreTables         *LogstoreTables
+	s8AuthTokens             *AuthTokenIndexes
+	s9EventstoreIndexes2     *EventstoreIndexesNew
+	CorrectCreationDate      *CorrectCreationDate
+	AddEventCreatedAt        *AddEventCreatedAt
+	s12LogstoreNotification  *LogstoreNotificationTable
+	s13LogstoreExecutionRuns *LogstoreExecutionRuns
+	s14PushedAuthRequests    *PushedAuthRequestsTable
+	s15TokenJWKThumbprint    *TokenJWKThumbprint
+	s16UserLockedUntil       *UserLockedUntil
+	s17TokenActor            *TokenActor
 }
 
 type encryptionKeyConfig struct {
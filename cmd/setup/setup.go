@@ -94,6 +94,12 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.CorrectCreationDate.dbClient = dbClient
 	steps.AddEventCreatedAt.dbClient = dbClient
 	steps.AddEventCreatedAt.step10 = steps.CorrectCreationDate
+	steps.s12LogstoreNotification = &LogstoreNotificationTable{dbClient: dbClient.DB, username: config.Database.Username(), dbType: config.Database.Type()}
+	steps.s13LogstoreExecutionRuns = &LogstoreExecutionRuns{dbClient: dbClient.DB, dbType: config.Database.Type()}
+	steps.s14PushedAuthRequests = &PushedAuthRequestsTable{dbClient: dbClient.DB}
+	steps.s15TokenJWKThumbprint = &TokenJWKThumbprint{dbClient: dbClient.DB}
+	steps.s16UserLockedUntil = &UserLockedUntil{dbClient: dbClient.DB}
+	steps.s17TokenActor = &TokenActor{dbClient: dbClient.DB}
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -134,6 +140,18 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.OnError(err).Fatal("unable to migrate step 10")
 	err = migration.Migrate(ctx, eventstoreClient, steps.AddEventCreatedAt)
 	logging.OnError(err).Fatal("unable to migrate step 11")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s12LogstoreNotification)
+	logging.OnError(err).Fatal("unable to migrate step 12")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s13LogstoreExecutionRuns)
+	logging.OnError(err).Fatal("unable to migrate step 13")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s14PushedAuthRequests)
+	logging.OnError(err).Fatal("unable to migrate step 14")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s15TokenJWKThumbprint)
+	logging.OnError(err).Fatal("unable to migrate step 15")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s16UserLockedUntil)
+	logging.OnError(err).Fatal("unable to migrate step 16")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s17TokenActor)
+	logging.OnError(err).Fatal("unable to migrate step 17")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
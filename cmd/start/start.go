@@ -34,6 +34,7 @@ import (
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/admin"
 	"github.com/zitadel/zitadel/internal/api/grpc/auth"
+	execution_v2 "github.com/zitadel/zitadel/internal/api/grpc/execution/v2"
 	"github.com/zitadel/zitadel/internal/api/grpc/management"
 	oidc_v2 "github.com/zitadel/zitadel/internal/api/grpc/oidc/v2"
 	"github.com/zitadel/zitadel/internal/api/grpc/org/v2"
@@ -47,6 +48,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/oidc"
 	"github.com/zitadel/zitadel/internal/api/robots_txt"
 	"github.com/zitadel/zitadel/internal/api/saml"
+	"github.com/zitadel/zitadel/internal/api/scim"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
@@ -59,13 +61,18 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/file"
+	notification_logstore "github.com/zitadel/zitadel/internal/logstore/emitters/notification"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/stdout"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/webhook"
 	"github.com/zitadel/zitadel/internal/notification"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/static"
 	"github.com/zitadel/zitadel/internal/webauthn"
 	"github.com/zitadel/zitadel/openapi"
@@ -212,16 +219,43 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 	if err != nil {
 		return err
 	}
-	actionsExecutionDBEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Execution.Database, execution.NewDatabaseLogStorage(dbClient))
+	actionsExecutionDBEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Execution.Database, execution.NewDatabaseLogStorage(dbClient), logstorePruning("execution", dbClient, queries))
+	if err != nil {
+		return err
+	}
+
+	actionsExecutionFileEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Execution.File.Emitter(), file.NewFileEmitter(config.LogStore.Execution.File))
+	if err != nil {
+		return err
+	}
+	actionsExecutionHTTPEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Execution.HTTP.Emitter(), webhook.NewHTTPEmitter(config.LogStore.Execution.HTTP))
 	if err != nil {
 		return err
 	}
 
 	usageReporter := logstore.UsageReporterFunc(commands.ReportQuotaUsage)
-	actionsLogstoreSvc := logstore.New(queries, usageReporter, actionsExecutionDBEmitter, actionsExecutionStdoutEmitter)
+	actionsLogstoreSvc := logstore.New(queries, usageReporter, commands, actionsExecutionDBEmitter, actionsExecutionStdoutEmitter, actionsExecutionFileEmitter, actionsExecutionHTTPEmitter)
 	actions.SetLogstoreService(actionsLogstoreSvc)
 
-	notification.Start(ctx, config.Projections.Customizations["notifications"], config.Projections.Customizations["notificationsquotas"], config.Projections.Customizations["telemetry"], *config.Telemetry, config.ExternalDomain, config.ExternalPort, config.ExternalSecure, commands, queries, eventstoreClient, assets.AssetAPIFromDomain(config.ExternalSecure, config.ExternalPort), config.SystemDefaults.Notifications.FileSystemPath, keys.User, keys.SMTP, keys.SMS)
+	notificationStdoutEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Notification.Stdout, stdout.NewStdoutEmitter())
+	if err != nil {
+		return err
+	}
+	notificationDBEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Notification.Database, notification_logstore.NewDatabaseLogStorage(dbClient), logstorePruning("notification", dbClient, queries))
+	if err != nil {
+		return err
+	}
+	notificationFileEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Notification.File.Emitter(), file.NewFileEmitter(config.LogStore.Notification.File))
+	if err != nil {
+		return err
+	}
+	notificationHTTPEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Notification.HTTP.Emitter(), webhook.NewHTTPEmitter(config.LogStore.Notification.HTTP))
+	if err != nil {
+		return err
+	}
+	notificationLogstoreSvc := logstore.New(queries, usageReporter, commands, notificationDBEmitter, notificationStdoutEmitter, notificationFileEmitter, notificationHTTPEmitter)
+
+	notification.Start(ctx, config.Projections.Customizations["notifications"], config.Projections.Customizations["notificationsquotas"], config.Projections.Customizations["telemetry"], config.Projections.Customizations["notificationsbackchannellogout"], config.Projections.Customizations["notificationseventexecutions"], *config.Telemetry, config.OIDC.DefaultAccessTokenLifetime, config.OIDC.DefaultRefreshTokenExpiration, config.ExternalDomain, config.ExternalPort, config.ExternalSecure, commands, queries, eventstoreClient, notificationLogstoreSvc, assets.AssetAPIFromDomain(config.ExternalSecure, config.ExternalPort), config.SystemDefaults.Notifications.FileSystemPath, keys.User, keys.SMTP, keys.SMS, keys.OIDC)
 
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
@@ -243,6 +277,8 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		queries,
 		usageReporter,
 		permissionCheck,
+		actionsLogstoreSvc,
+		notificationLogstoreSvc,
 	)
 	if err != nil {
 		return err
@@ -288,6 +324,7 @@ func startAPIs(
 	quotaQuerier logstore.QuotaQuerier,
 	usageReporter logstore.UsageReporter,
 	permissionCheck domain.PermissionCheck,
+	usageServices ...*logstore.Service,
 ) error {
 	repo := struct {
 		authz_repo.Repository
@@ -306,12 +343,21 @@ func startAPIs(
 	if err != nil {
 		return err
 	}
-	accessDBEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Access.Database, access.NewDatabaseLogStorage(dbClient))
+	accessDBEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Access.Database, access.NewDatabaseLogStorage(dbClient), logstorePruning("access", dbClient, quotaQuerier))
+	if err != nil {
+		return err
+	}
+
+	accessFileEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Access.File.Emitter(), file.NewFileEmitter(config.LogStore.Access.File))
+	if err != nil {
+		return err
+	}
+	accessHTTPEmitter, err := logstore.NewEmitter(ctx, clock, config.LogStore.Access.HTTP.Emitter(), webhook.NewHTTPEmitter(config.LogStore.Access.HTTP))
 	if err != nil {
 		return err
 	}
 
-	accessSvc := logstore.New(quotaQuerier, usageReporter, accessDBEmitter, accessStdoutEmitter)
+	accessSvc := logstore.New(quotaQuerier, usageReporter, commands, accessDBEmitter, accessStdoutEmitter, accessFileEmitter, accessHTTPEmitter)
 	exhaustedCookieHandler := http_util.NewCookieHandler(
 		http_util.WithUnsecure(),
 		http_util.WithNonHttpOnly(),
@@ -330,7 +376,7 @@ func startAPIs(
 	if err != nil {
 		return fmt.Errorf("error starting admin repo: %w", err)
 	}
-	if err := apis.RegisterServer(ctx, system.CreateServer(commands, queries, adminRepo, config.Database.DatabaseName(), config.DefaultInstance, config.ExternalDomain)); err != nil {
+	if err := apis.RegisterServer(ctx, system.CreateServer(commands, queries, adminRepo, config.Database.DatabaseName(), config.DefaultInstance, config.ExternalDomain, append(usageServices, accessSvc)...)); err != nil {
 		return err
 	}
 	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.DatabaseName(), commands, queries, config.SystemDefaults, adminRepo, config.ExternalSecure, keys.User, config.AuditLogRetention)); err != nil {
@@ -342,7 +388,7 @@ func startAPIs(
 	if err := apis.RegisterServer(ctx, auth.CreateServer(commands, queries, authRepo, config.SystemDefaults, keys.User, config.ExternalSecure, config.AuditLogRetention)); err != nil {
 		return err
 	}
-	if err := apis.RegisterService(ctx, user.CreateServer(commands, queries, keys.User, keys.IDPConfig, idp.CallbackURL(config.ExternalSecure))); err != nil {
+	if err := apis.RegisterService(ctx, user.CreateServer(commands, queries, keys.User, keys.IDPConfig, idp.CallbackURL(config.ExternalSecure), idp.SAMLRootURL(config.ExternalSecure))); err != nil {
 		return err
 	}
 	if err := apis.RegisterService(ctx, session.CreateServer(commands, queries, permissionCheck)); err != nil {
@@ -355,13 +401,18 @@ func startAPIs(
 	if err := apis.RegisterService(ctx, org.CreateServer(commands, queries, permissionCheck)); err != nil {
 		return err
 	}
+	if err := apis.RegisterService(ctx, execution_v2.CreateServer(commands, queries)); err != nil {
+		return err
+	}
 	instanceInterceptor := middleware.InstanceInterceptor(queries, config.HTTP1HostHeader, login.IgnoreInstanceEndpoints...)
 	assetsCache := middleware.AssetsCacheInterceptor(config.AssetStorage.Cache.MaxAge, config.AssetStorage.Cache.SharedMaxAge)
 	apis.RegisterHandlerOnPrefix(assets.HandlerPrefix, assets.NewHandler(commands, verifier, config.InternalAuthZ, id.SonyFlakeGenerator(), store, queries, middleware.CallDurationHandler, instanceInterceptor.Handler, assetsCache.Handler, limitingAccessInterceptor.Handle))
 
-	apis.RegisterHandlerOnPrefix(idp.HandlerPrefix, idp.NewHandler(commands, queries, keys.IDPConfig, config.ExternalSecure, instanceInterceptor.Handler))
+	apis.RegisterHandlerOnPrefix(scim.HandlerPrefix, scim.NewHandler(commands, queries, verifier, config.InternalAuthZ, config.ExternalSecure, middleware.CallDurationHandler, instanceInterceptor.Handler, limitingAccessInterceptor.Handle))
+
+	apis.RegisterHandlerOnPrefix(idp.HandlerPrefix, idp.NewHandler(commands, queries, keys.IDPConfig, config.ExternalSecure, login.SAMLCallbackURL(config.ExternalSecure), instanceInterceptor.Handler))
 
-	userAgentInterceptor, err := middleware.NewUserAgentHandler(config.UserAgentCookie, keys.UserAgentCookieKey, id.SonyFlakeGenerator(), config.ExternalSecure, login.EndpointResources)
+	userAgentInterceptor, err := middleware.NewUserAgentHandler(config.UserAgentCookie, keys.UserAgentCookieKey, id.SonyFlakeGenerator(), config.ExternalSecure, login.EndpointResources, login.EndpointExternalLoginSAMLCallback, login.EndpointExternalLoginFormCallback)
 	if err != nil {
 		return err
 	}
@@ -380,7 +431,7 @@ func startAPIs(
 	}
 	apis.RegisterHandlerOnPrefix(openapi.HandlerPrefix, openAPIHandler)
 
-	oidcProvider, err := oidc.NewProvider(config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, limitingAccessInterceptor.Handle)
+	oidcProvider, err := oidc.NewProvider(config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, crypto.NewBCrypt(config.SystemDefaults.SecretGenerators.PasswordSaltCost), keys.OIDCKey, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, limitingAccessInterceptor.Handle)
 	if err != nil {
 		return fmt.Errorf("unable to start oidc provider: %w", err)
 	}
@@ -398,7 +449,7 @@ func startAPIs(
 	}
 	apis.RegisterHandlerOnPrefix(console.HandlerPrefix, c)
 
-	l, err := login.CreateLogin(config.Login, commands, queries, authRepo, store, console.HandlerPrefix+"/", op.AuthCallbackURL(oidcProvider), provider.AuthCallbackURL(samlProvider), config.ExternalSecure, userAgentInterceptor, op.NewIssuerInterceptor(oidcProvider.IssuerFromRequest).Handler, provider.NewIssuerInterceptor(samlProvider.IssuerFromRequest).Handler, instanceInterceptor.Handler, assetsCache.Handler, limitingAccessInterceptor.Handle, keys.User, keys.IDPConfig, keys.CSRFCookieKey)
+	l, err := login.CreateLogin(config.Login, commands, queries, authRepo, store, console.HandlerPrefix+"/", op.AuthCallbackURL(oidcProvider), provider.AuthCallbackURL(samlProvider.Provider), saml.PropagateLogoutURLFromContext, config.ExternalSecure, userAgentInterceptor, op.NewIssuerInterceptor(oidcProvider.IssuerFromRequest).Handler, provider.NewIssuerInterceptor(samlProvider.IssuerFromRequest).Handler, instanceInterceptor.Handler, assetsCache.Handler, limitingAccessInterceptor.Handle, keys.User, keys.IDPConfig, keys.CSRFCookieKey)
 	if err != nil {
 		return fmt.Errorf("unable to start login: %w", err)
 	}
@@ -415,6 +466,11 @@ func startAPIs(
 	return nil
 }
 
+// logstorePruning prunes the logs of the database emitter under the projection locks
+func logstorePruning(records string, dbClient *database.DB, quotaQuerier logstore.QuotaQuerier) logstore.EmitterOption {
+	return logstore.WithPruning(records, crdb.NewLocker(dbClient.DB, projection.LocksTable, "logstore."+records), quotaQuerier)
+}
+
 func listen(ctx context.Context, router *mux.Router, port uint16, tlsConfig *tls.Config, shutdown <-chan os.Signal) error {
 	http2Server := &http2.Server{}
 	http1Server := &http.Server{Handler: h2c.NewHandler(router, http2Server), TLSConfig: tlsConfig}
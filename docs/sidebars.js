@@ -106,6 +106,7 @@ module.exports = {
           label: "Users",
           items: [
             "guides/manage/user/reg-create-user",
+            "guides/manage/user/scim",
             "guides/manage/customize/user-metadata",
           ],
         },
@@ -566,6 +567,10 @@ module.exports = {
         "apis/actions/internal-authentication",
         "apis/actions/external-authentication",
         "apis/actions/complement-token",
+        "apis/actions/password-reset",
+        "apis/actions/self-registration",
+        "apis/actions/token-exchange",
+        "apis/actions/customise-saml-response",
         "apis/actions/objects",
       ]
     },
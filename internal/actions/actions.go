@@ -10,6 +10,7 @@ import (
 
 	z_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/quota"
 )
 
 type Config struct {
@@ -18,7 +19,7 @@ type Config struct {
 
 var ErrHalt = errors.New("interrupt")
 
-type jsAction func(fields, fields) error
+type jsAction func(fields, interface{}) error
 
 const (
 	actionStartedMessage   = "action run started"
@@ -35,20 +36,16 @@ func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script
 		return z_errs.ThrowInternal(nil, "ACTIO-uCpCx", "Errrors.Internal")
 	}
 
-	remaining := logstoreService.Limit(ctx, config.instanceID)
+	remaining := logstoreService.Limit(ctx, config.instanceID, quota.ActionsAllRunsSeconds)
 	config.cutTimeouts(remaining)
 
-	config.logger.Log(actionStartedMessage)
+	config.logger.log(actionStartedMessage, logrus.InfoLevel)
 	if remaining != nil && *remaining == 0 {
 		return z_errs.ThrowResourceExhausted(nil, "ACTIO-f19Ii", "Errors.Quota.Execution.Exhausted")
 	}
 
 	defer func() {
-		if err != nil {
-			config.logger.log(actionFailedMessage(err), logrus.ErrorLevel, true)
-		} else {
-			config.logger.log(actionSucceededMessage, logrus.InfoLevel, true)
-		}
+		config.logger.logRun(err)
 		if config.allowedToFail {
 			err = nil
 		}
@@ -126,14 +123,19 @@ func executeFn(config *runConfig, fn jsAction) (err error) {
 		err = fmt.Errorf("unknown error occurred: %v", r)
 	}()
 
-	if err = fn(config.ctxParam.fields, config.apiParam.fields); err != nil {
+	var api interface{} = config.apiParam.fields
+	if config.api != nil {
+		api = config.api(config.vm)
+	}
+	if err = fn(config.ctxParam.fields, api); err != nil {
 		return err
 	}
 	return nil
 }
 
 func ActionToOptions(a *query.Action) []Option {
-	opts := make([]Option, 0, 1)
+	opts := make([]Option, 0, 2)
+	opts = append(opts, withAction(a.ID, a.ResourceOwner))
 	if a.AllowedToFail {
 		opts = append(opts, WithAllowedToFail())
 	}
@@ -11,7 +11,7 @@ import (
 )
 
 func TestRun(t *testing.T) {
-	SetLogstoreService(logstore.New(nil, nil, nil))
+	SetLogstoreService(logstore.New(nil, nil, nil, nil))
 	type args struct {
 		timeout time.Duration
 		api     apiFields
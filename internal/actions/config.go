@@ -7,6 +7,9 @@ import (
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
 )
 
 const (
@@ -21,13 +24,41 @@ func WithAllowedToFail() Option {
 	}
 }
 
+// WithTrigger defines the flow and trigger the action runs for
+// and the user it runs for, if any.
+// They are stored in the execution history of the action.
+func WithTrigger(flowType domain.FlowType, triggerType domain.TriggerType, userID string) Option {
+	return func(c *runConfig) {
+		c.flowType = flowType
+		c.triggerType = triggerType
+		c.userID = userID
+	}
+}
+
+func withAction(actionID, resourceOwner string) Option {
+	return func(c *runConfig) {
+		c.actionID = actionID
+		c.resourceOwner = resourceOwner
+	}
+}
+
 type runConfig struct {
 	allowedToFail bool
 	functionTimeout,
 	scriptTimeout time.Duration
-	modules    map[string]require.ModuleLoader
-	logger     *logger
-	instanceID string
+	modules       map[string]require.ModuleLoader
+	logger        *logger
+	instanceID    string
+	actionID      string
+	resourceOwner string
+	flowType      domain.FlowType
+	triggerType   domain.TriggerType
+	userID        string
+	// api replaces the api fields if set
+	api func(*goja.Runtime) goja.Value
+	// observeRun is called with the record of the finished run if set,
+	// the records of an observed run are not stored
+	observeRun func(*execution.Record)
 	vm         *goja.Runtime
 	ctxParam   *ctxConfig
 	apiParam   *apiConfig
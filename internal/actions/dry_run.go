@@ -0,0 +1,171 @@
+package actions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
+)
+
+const maxDryRunTimeout = 20 * time.Second
+
+// DryRunFixture defines the fields the function of a dry run is called with.
+type DryRunFixture struct {
+	// Ctx contains the readable fields passed as ctx
+	Ctx map[string]interface{}
+	// API contains values which are returned instead of capturing a mutation
+	// when the function reads them from api, e.g. {"v1": {"providerInfo": {...}}}
+	API map[string]interface{}
+	// HTTPMocks answer the requests of the zitadel/http module.
+	// If no mock is defined, the requests are sent.
+	HTTPMocks []*HTTPMock
+}
+
+// HTTPMock is the response to a request with the Method and URL,
+// if no Method is defined, requests with any method are answered
+type HTTPMock struct {
+	Method  string
+	URL     string
+	Status  int
+	Headers map[string][]string
+	Body    string
+}
+
+// Mutation is a call of a function on api, e.g. api.v1.user.appendMetadata("key", "value")
+type Mutation struct {
+	Method string
+	Args   []interface{}
+}
+
+type DryRunResult struct {
+	Mutations []*Mutation
+	Logs      []*execution.Log
+	Took      time.Duration
+	Err       error
+}
+
+// DryRun runs the function name of the script like it would be run for the flow and trigger type.
+// The function is called with the fields of the fixture,
+// calls of functions on api are captured as mutations instead of being applied.
+// The run is neither stored in the execution history of an action nor counted in the quota.
+func DryRun(ctx context.Context, script, name string, flowType domain.FlowType, triggerType domain.TriggerType, fixture *DryRunFixture, timeout time.Duration) (*DryRunResult, error) {
+	if !flowType.Valid() || !flowType.HasTrigger(triggerType) {
+		return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Ieph4", "Errors.Flow.WrongTriggerType")
+	}
+	if timeout <= 0 || timeout > maxDryRunTimeout {
+		timeout = maxDryRunTimeout
+	}
+	if fixture == nil {
+		fixture = new(DryRunFixture)
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := new(DryRunResult)
+	mutations := new(mutationRecorder)
+	opts := []Option{
+		WithTrigger(flowType, triggerType, ""),
+		withAPIRecorder(mutations, fixture.API),
+		withRunObserver(func(record *execution.Record) {
+			result.Logs = record.Logs
+			result.Took = record.Took
+		}),
+	}
+	if len(fixture.HTTPMocks) > 0 {
+		opts = append(opts, withHTTPTransport(runCtx, &mockTransport{mocks: fixture.HTTPMocks}))
+	} else {
+		opts = append(opts, WithHTTP(runCtx))
+	}
+	result.Err = Run(
+		runCtx,
+		SetContextFields(fixtureFields(fixture.Ctx)...),
+		nil,
+		script,
+		name,
+		opts...,
+	)
+	result.Mutations = mutations.mutations
+	return result, nil
+}
+
+func fixtureFields(fixture map[string]interface{}) []FieldOption {
+	opts := make([]FieldOption, 0, len(fixture))
+	for key, value := range fixture {
+		opts = append(opts, SetFields(key, value))
+	}
+	return opts
+}
+
+func withAPIRecorder(recorder *mutationRecorder, fixture map[string]interface{}) Option {
+	return func(c *runConfig) {
+		c.api = func(vm *goja.Runtime) goja.Value {
+			return recorder.proxy(vm, "", fixture)
+		}
+	}
+}
+
+func withRunObserver(observe func(*execution.Record)) Option {
+	return func(c *runConfig) {
+		c.observeRun = observe
+	}
+}
+
+type mutationRecorder struct {
+	mutations []*Mutation
+}
+
+// proxy returns an object which captures calls of itself and all of its properties as mutations.
+// Properties defined by the fixture are returned as they are.
+func (r *mutationRecorder) proxy(vm *goja.Runtime, path string, fixture map[string]interface{}) goja.Value {
+	target := vm.ToValue(func(goja.FunctionCall) goja.Value { return goja.Undefined() }).(*goja.Object)
+	return vm.ToValue(vm.NewProxy(target, &goja.ProxyTrapConfig{
+		Get: func(_ *goja.Object, property string, _ goja.Value) goja.Value {
+			value, ok := fixture[property]
+			if !ok {
+				return r.proxy(vm, strings.TrimPrefix(path+"."+property, "."), nil)
+			}
+			if sub, ok := value.(map[string]interface{}); ok {
+				return r.proxy(vm, strings.TrimPrefix(path+"."+property, "."), sub)
+			}
+			return vm.ToValue(value)
+		},
+		Apply: func(_ *goja.Object, _ goja.Value, arguments []goja.Value) goja.Value {
+			args := make([]interface{}, len(arguments))
+			for i, arg := range arguments {
+				args[i] = arg.Export()
+			}
+			r.mutations = append(r.mutations, &Mutation{Method: path, Args: args})
+			return goja.Undefined()
+		},
+	}))
+}
+
+type mockTransport struct {
+	mocks []*HTTPMock
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, mock := range t.mocks {
+		if (mock.Method != "" && !strings.EqualFold(mock.Method, req.Method)) || mock.URL != req.URL.String() {
+			continue
+		}
+		status := mock.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     mock.Headers,
+			Body:       io.NopCloser(strings.NewReader(mock.Body)),
+			Request:    req,
+		}, nil
+	}
+	return nil, z_errs.ThrowNotFound(nil, "ACTIO-aeM4o", "no mock for request")
+}
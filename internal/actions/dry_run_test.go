@@ -0,0 +1,156 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestDryRun(t *testing.T) {
+	var emitted []logstore.LogRecord
+	emitter, err := logstore.NewEmitter(context.Background(), clock.New(), &logstore.EmitterConfig{Enabled: true}, logstore.LogEmitterFunc(func(_ context.Context, bulk []logstore.LogRecord) error {
+		emitted = append(emitted, bulk...)
+		return nil
+	}))
+	require.NoError(t, err)
+	SetLogstoreService(logstore.New(nil, nil, nil, nil, emitter))
+	type args struct {
+		script      string
+		triggerType domain.TriggerType
+		fixture     *DryRunFixture
+	}
+	type want struct {
+		mutations []*Mutation
+		logs      []string
+		runErr    bool
+		err       func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "invalid trigger type",
+			args: args{
+				script:      "function test(ctx, api) {}",
+				triggerType: domain.TriggerTypePreUserinfoCreation,
+			},
+			want: want{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "mutations captured",
+			args: args{
+				script: `function test(ctx, api) {
+	api.setFirstName(ctx.v1.externalUser.firstName + "!");
+	api.v1.user.appendMetadata("key", {nested: true});
+}`,
+				triggerType: domain.TriggerTypePostAuthentication,
+				fixture: &DryRunFixture{
+					Ctx: map[string]interface{}{
+						"v1": map[string]interface{}{
+							"externalUser": map[string]interface{}{
+								"firstName": "Gigi",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				mutations: []*Mutation{
+					{Method: "setFirstName", Args: []interface{}{"Gigi!"}},
+					{Method: "v1.user.appendMetadata", Args: []interface{}{"key", map[string]interface{}{"nested": true}}},
+				},
+			},
+		},
+		{
+			name: "api fixture returned",
+			args: args{
+				script: `function test(ctx, api) {
+	api.setDisplayName(api.v1.defaults.displayName);
+}`,
+				triggerType: domain.TriggerTypePostAuthentication,
+				fixture: &DryRunFixture{
+					API: map[string]interface{}{
+						"v1": map[string]interface{}{
+							"defaults": map[string]interface{}{
+								"displayName": "Gigi Giraffe",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				mutations: []*Mutation{
+					{Method: "setDisplayName", Args: []interface{}{"Gigi Giraffe"}},
+				},
+			},
+		},
+		{
+			name: "http mocked and logs captured",
+			args: args{
+				script: `let http = require('zitadel/http');
+let logger = require('zitadel/log');
+function test(ctx, api) {
+	let res = http.fetch('https://example.com/users', {method: 'GET'});
+	logger.log(res.json().nickName);
+}`,
+				triggerType: domain.TriggerTypePostAuthentication,
+				fixture: &DryRunFixture{
+					HTTPMocks: []*HTTPMock{
+						{
+							Method: "GET",
+							URL:    "https://example.com/users",
+							Body:   `{"nickName": "gigi"}`,
+						},
+					},
+				},
+			},
+			want: want{
+				logs: []string{"gigi"},
+			},
+		},
+		{
+			name: "script error returned",
+			args: args{
+				script:      "function test(ctx, api) { throw 'typo' }",
+				triggerType: domain.TriggerTypePostAuthentication,
+			},
+			want: want{
+				runErr: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DryRun(context.Background(), tt.args.script, "test", domain.FlowTypeExternalAuthentication, tt.args.triggerType, tt.args.fixture, 0)
+			if tt.want.err != nil {
+				assert.True(t, tt.want.err(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.runErr, got.Err != nil, "run error: %v", got.Err)
+			assert.Equal(t, tt.want.mutations, got.Mutations)
+			logs := make([]string, len(got.Logs))
+			for i, log := range got.Logs {
+				logs[i] = log.Message
+			}
+			if tt.want.logs == nil {
+				tt.want.logs = []string{}
+			}
+			assert.Equal(t, tt.want.logs, logs)
+			assert.NotZero(t, got.Took)
+			// dry runs are not stored
+			assert.Empty(t, emitted)
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func TestSetFields(t *testing.T) {
-	SetLogstoreService(logstore.New(nil, nil, nil))
+	SetLogstoreService(logstore.New(nil, nil, nil, nil))
 	primitveFn := func(a string) { fmt.Println(a) }
 	complexFn := func(*FieldConfig) interface{} {
 		return primitveFn
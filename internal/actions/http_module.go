@@ -17,9 +17,13 @@ import (
 )
 
 func WithHTTP(ctx context.Context) Option {
+	return withHTTPTransport(ctx, new(transport))
+}
+
+func withHTTPTransport(ctx context.Context, roundTripper http.RoundTripper) Option {
 	return func(c *runConfig) {
 		c.modules["zitadel/http"] = func(runtime *goja.Runtime, module *goja.Object) {
-			requireHTTP(ctx, &http.Client{Transport: new(transport)}, runtime, module)
+			requireHTTP(ctx, &http.Client{Transport: roundTripper}, runtime, module)
 		}
 	}
 }
@@ -170,6 +174,20 @@ func parseHeaders(headers *goja.Object) http.Header {
 	return h
 }
 
+// DenyListTransport returns a [http.RoundTripper], which rejects requests to hosts of the deny list of the [HTTPConfig].
+// It can be used for any request to an address provided by a user, e.g. the back-channel logout uri of a client.
+func DenyListTransport() http.RoundTripper {
+	return new(transport)
+}
+
+// IsHostDenied returns true if the host of the address is on the deny list of the [HTTPConfig]
+func IsHostDenied(address *url.URL) bool {
+	if httpConfig == nil {
+		return false
+	}
+	return isHostBlocked(httpConfig.DenyList, address)
+}
+
 type transport struct{}
 
 func (*transport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -15,7 +15,7 @@ import (
 )
 
 func Test_isHostBlocked(t *testing.T) {
-	SetLogstoreService(logstore.New(nil, nil, nil))
+	SetLogstoreService(logstore.New(nil, nil, nil, nil))
 	var denyList = []AddressChecker{
 		mustNewIPChecker(t, "192.168.5.0/24"),
 		mustNewIPChecker(t, "127.0.0.1"),
@@ -67,6 +67,30 @@ func Test_isHostBlocked(t *testing.T) {
 	}
 }
 
+func TestIsHostDenied(t *testing.T) {
+	SetHTTPConfig(&HTTPConfig{
+		DenyList: []AddressChecker{
+			mustNewIPChecker(t, "127.0.0.1"),
+			&DomainChecker{Domain: "localhost"},
+		},
+	})
+	defer SetHTTPConfig(nil)
+
+	if !IsHostDenied(mustNewURL(t, "http://127.0.0.1:8080/logout")) {
+		t.Error("IsHostDenied() = false, want true for denied ip")
+	}
+	if !IsHostDenied(mustNewURL(t, "http://localhost/logout")) {
+		t.Error("IsHostDenied() = false, want true for denied domain")
+	}
+	if IsHostDenied(mustNewURL(t, "https://rp.example.com/logout")) {
+		t.Error("IsHostDenied() = true, want false for allowed domain")
+	}
+	_, err := DenyListTransport().RoundTrip(&http.Request{URL: mustNewURL(t, "http://localhost/logout")})
+	if !errors.IsErrorInvalidArgument(err) {
+		t.Errorf("RoundTrip() error = %v, want invalid argument", err)
+	}
+}
+
 func mustNewIPChecker(t *testing.T, ip string) AddressChecker {
 	t.Helper()
 	checker, err := NewIPChecker(ip)
@@ -26,55 +26,106 @@ type logger struct {
 	ctx        context.Context
 	started    time.Time
 	instanceID string
+	run        *execution.Record
+	observeRun func(*execution.Record)
 }
 
 // newLogger returns a *logger instance that should only be used for a single action run.
-// The first log call sets the started field for subsequent log calls
-func newLogger(ctx context.Context, instanceID string) *logger {
+// The first log call sets the started field for subsequent log calls.
+// The messages logged by the action are collected in the record of the run, which is emitted by logRun
+func newLogger(ctx context.Context, instanceID string, run *execution.Record) *logger {
 	return &logger{
 		ctx:        ctx,
 		started:    time.Time{},
 		instanceID: instanceID,
+		run:        run,
 	}
 }
 
 func (l *logger) Log(msg string) {
-	l.log(msg, logrus.InfoLevel, false)
+	l.collect(l.log(msg, logrus.InfoLevel))
 }
 
 func (l *logger) Warn(msg string) {
-	l.log(msg, logrus.WarnLevel, false)
+	l.collect(l.log(msg, logrus.WarnLevel))
 }
 
 func (l *logger) Error(msg string) {
-	l.log(msg, logrus.ErrorLevel, false)
+	l.collect(l.log(msg, logrus.ErrorLevel))
 }
 
-func (l *logger) log(msg string, level logrus.Level, last bool) {
+func (l *logger) log(msg string, level logrus.Level) *execution.Record {
+	record := l.record(msg, level)
+	l.emit(record)
+	return record
+}
+
+// logRun emits the record of the finished action run
+// including the time it took, its result and the messages the action logged
+func (l *logger) logRun(err error) {
+	msg, level := actionSucceededMessage, logrus.InfoLevel
+	if err != nil {
+		msg, level = actionFailedMessage(err), logrus.ErrorLevel
+	}
+	record := l.record(msg, level)
+	if err != nil {
+		record.Error = err.Error()
+	}
+	record.Took = record.LogDate.Sub(l.started)
+	record.ResourceOwner = l.run.ResourceOwner
+	record.FlowType = l.run.FlowType
+	record.TriggerType = l.run.TriggerType
+	record.UserID = l.run.UserID
+	record.Logs = l.run.Logs
+	if l.observeRun != nil {
+		l.observeRun(record)
+	}
+	l.emit(record)
+}
+
+// emit stores the record in the logstore,
+// unless the run is observed (e.g. a dry run), which must not be stored or counted in the quota
+func (l *logger) emit(record *execution.Record) {
+	if l.observeRun != nil {
+		return
+	}
+	logstoreService.Handle(l.ctx, record)
+}
+
+func (l *logger) record(msg string, level logrus.Level) *execution.Record {
 	ts := time.Now()
 	if l.started.IsZero() {
 		l.started = ts
 	}
-
-	record := &execution.Record{
+	return &execution.Record{
 		LogDate:    ts,
 		InstanceID: l.instanceID,
+		ActionID:   l.run.ActionID,
 		Message:    msg,
 		LogLevel:   level,
 	}
+}
 
-	if last {
-		record.Took = ts.Sub(l.started)
-	}
-
-	logstoreService.Handle(l.ctx, record)
+func (l *logger) collect(record *execution.Record) {
+	l.run.Logs = append(l.run.Logs, &execution.Log{
+		LogDate:  record.LogDate,
+		LogLevel: record.LogLevel,
+		Message:  record.Message,
+	})
 }
 
 func withLogger(ctx context.Context) Option {
 	instance := authz.GetInstance(ctx)
 	instanceID := instance.InstanceID()
 	return func(c *runConfig) {
-		c.logger = newLogger(ctx, instanceID)
+		c.logger = newLogger(ctx, instanceID, &execution.Record{
+			ActionID:      c.actionID,
+			ResourceOwner: c.resourceOwner,
+			FlowType:      c.flowType,
+			TriggerType:   c.triggerType,
+			UserID:        c.userID,
+		})
+		c.logger.observeRun = c.observeRun
 		c.instanceID = instanceID
 		c.modules["zitadel/log"] = func(runtime *goja.Runtime, module *goja.Object) {
 			console.RequireWithPrinter(c.logger)(runtime, module)
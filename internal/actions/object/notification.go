@@ -0,0 +1,74 @@
+package object
+
+import (
+	"github.com/dop251/goja"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// NotificationField provides the notification which is about to be sent
+func NotificationField(notificationType domain.NotificationType, messageType string) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		n := &notification{
+			MessageType: messageType,
+			Type:        "email",
+		}
+		if notificationType == domain.NotificationTypeSms {
+			n.Type = "sms"
+		}
+		return c.Runtime.ToValue(n)
+	}
+}
+
+type notification struct {
+	Type        string
+	MessageType string
+}
+
+// NotificationChanges contains the changes an action made to a notification
+type NotificationChanges struct {
+	// Denied is true if the notification must not be sent
+	Denied bool
+	// Texts contains the overwritten message texts by their key (e.g. Subject or Text)
+	Texts map[string]string
+}
+
+// DenyFunc provides the `deny()` function, which prevents the notification from being sent
+func (n *NotificationChanges) DenyFunc() {
+	n.Denied = true
+}
+
+// SetMessageTextFunc provides the `setMessageText(key, text)` function,
+// which overwrites a text (Title, PreHeader, Subject, Greeting, Text, ButtonText or Footer) of the message
+func (n *NotificationChanges) SetMessageTextFunc(c *actions.FieldConfig) interface{} {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 2 {
+			panic("exactly two arguments expected")
+		}
+		key := call.Argument(0).String()
+		if !isMessageTextKey(key) {
+			panic("invalid message text key")
+		}
+		if n.Texts == nil {
+			n.Texts = make(map[string]string)
+		}
+		n.Texts[key] = call.Argument(1).String()
+		return nil
+	}
+}
+
+func isMessageTextKey(key string) bool {
+	switch key {
+	case domain.MessageTitle,
+		domain.MessagePreHeader,
+		domain.MessageSubject,
+		domain.MessageGreeting,
+		domain.MessageText,
+		domain.MessageButtonText,
+		domain.MessageFooterText:
+		return true
+	default:
+		return false
+	}
+}
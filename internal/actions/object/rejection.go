@@ -0,0 +1,78 @@
+package object
+
+import (
+	"github.com/dop251/goja"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// Rejection is set by the `reject` function of an action
+// to reject the current request with a message for the user
+type Rejection struct {
+	rejected bool
+	messages map[string]string
+}
+
+// RejectFunc provides the `reject(message)` function,
+// the message is either a string or an object with language tags as keys and the messages as values
+func (r *Rejection) RejectFunc(c *actions.FieldConfig) interface{} {
+	return func(call goja.FunctionCall) goja.Value {
+		r.rejected = true
+		r.messages = make(map[string]string)
+		if len(call.Arguments) == 0 {
+			return nil
+		}
+		switch message := call.Argument(0).Export().(type) {
+		case string:
+			r.messages[""] = message
+		case map[string]interface{}:
+			for lang, value := range message {
+				text, ok := value.(string)
+				if !ok {
+					panic("message must be a string")
+				}
+				r.messages[lang] = text
+			}
+		default:
+			panic("message must be a string or an object of messages by language")
+		}
+		return nil
+	}
+}
+
+// Rejected returns if an action rejected the request
+func (r *Rejection) Rejected() bool {
+	return r.rejected
+}
+
+// Message returns the message of the first matching language,
+// if none matches the message without language or the english message is returned
+func (r *Rejection) Message(langs ...language.Tag) string {
+	for _, lang := range langs {
+		if message, ok := r.messages[lang.String()]; ok {
+			return message
+		}
+		base, _ := lang.Base()
+		if message, ok := r.messages[base.String()]; ok {
+			return message
+		}
+	}
+	if message, ok := r.messages[""]; ok {
+		return message
+	}
+	return r.messages[language.English.String()]
+}
+
+// Error returns an error with the message if an action rejected the request
+func (r *Rejection) Error(langs ...language.Tag) error {
+	if !r.rejected {
+		return nil
+	}
+	message := r.Message(langs...)
+	if message == "" {
+		message = "Errors.Action.Rejected"
+	}
+	return errors.ThrowPreconditionFailed(nil, "OBJEC-Rej3c", message)
+}
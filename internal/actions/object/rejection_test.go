@@ -0,0 +1,90 @@
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestRejection(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil, nil))
+	tests := []struct {
+		name         string
+		script       string
+		langs        []language.Tag
+		wantRejected bool
+		wantMessage  string
+	}{
+		{
+			name:         "not rejected",
+			script:       "function test(ctx, api) {}",
+			wantRejected: false,
+			wantMessage:  "",
+		},
+		{
+			name:         "rejected without message",
+			script:       "function test(ctx, api) { api.v1.reject() }",
+			wantRejected: true,
+			wantMessage:  "",
+		},
+		{
+			name:         "rejected with message",
+			script:       "function test(ctx, api) { api.v1.reject('not allowed') }",
+			langs:        []language.Tag{language.German},
+			wantRejected: true,
+			wantMessage:  "not allowed",
+		},
+		{
+			name:         "rejected with messages, exact language",
+			script:       "function test(ctx, api) { api.v1.reject({'en': 'not allowed', 'de-CH': 'nöd erlaubt', 'de': 'nicht erlaubt'}) }",
+			langs:        []language.Tag{language.MustParse("de-CH")},
+			wantRejected: true,
+			wantMessage:  "nöd erlaubt",
+		},
+		{
+			name:         "rejected with messages, base language",
+			script:       "function test(ctx, api) { api.v1.reject({'en': 'not allowed', 'de': 'nicht erlaubt'}) }",
+			langs:        []language.Tag{language.MustParse("de-CH")},
+			wantRejected: true,
+			wantMessage:  "nicht erlaubt",
+		},
+		{
+			name:         "rejected with messages, english fallback",
+			script:       "function test(ctx, api) { api.v1.reject({'en': 'not allowed', 'de': 'nicht erlaubt'}) }",
+			langs:        []language.Tag{language.French},
+			wantRejected: true,
+			wantMessage:  "not allowed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejection := new(Rejection)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := actions.Run(ctx,
+				actions.SetContextFields(),
+				actions.WithAPIFields(actions.SetFields("v1", actions.SetFields("reject", rejection.RejectFunc))),
+				tt.script,
+				"test",
+			)
+			if err != nil {
+				t.Fatalf("Run() unexpected error = %v", err)
+			}
+			if rejection.Rejected() != tt.wantRejected {
+				t.Errorf("Rejected() = %v, want %v", rejection.Rejected(), tt.wantRejected)
+			}
+			if message := rejection.Message(tt.langs...); message != tt.wantMessage {
+				t.Errorf("Message() = %q, want %q", message, tt.wantMessage)
+			}
+			if err = rejection.Error(tt.langs...); tt.wantRejected != errors.IsPreconditionFailed(err) {
+				t.Errorf("Error() = %v, want rejected %v", err, tt.wantRejected)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package object
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/actions"
+)
+
+// TokenRequest is the request tokens are about to be issued for
+type TokenRequest struct {
+	UserID      string
+	ClientID    string
+	Scopes      []string
+	Audience    []string
+	AuthTime    time.Time
+	AuthMethods []string
+}
+
+// TokenRequestField accepts the TokenRequest by value, so it's not mutated
+func TokenRequestField(request *TokenRequest) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return c.Runtime.ToValue(&tokenRequest{
+			UserId:      request.UserID,
+			ClientId:    request.ClientID,
+			Scopes:      append([]string(nil), request.Scopes...),
+			Audience:    append([]string(nil), request.Audience...),
+			AuthTime:    request.AuthTime,
+			AuthMethods: append([]string(nil), request.AuthMethods...),
+		})
+	}
+}
+
+type tokenRequest struct {
+	UserId      string
+	ClientId    string
+	Scopes      []string
+	Audience    []string
+	AuthTime    time.Time
+	AuthMethods []string
+}
+
+// TokenExchangeRequest is the token exchange (RFC 8693) a token is about to be issued for
+type TokenExchangeRequest struct {
+	TokenRequest
+	SubjectTokenType   string
+	RequestedTokenType string
+	ActorUserID        string
+	Impersonation      bool
+}
+
+// TokenExchangeRequestField accepts the TokenExchangeRequest by value, so it's not mutated
+func TokenExchangeRequestField(request *TokenExchangeRequest) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return c.Runtime.ToValue(&tokenExchangeRequest{
+			UserId:             request.UserID,
+			ClientId:           request.ClientID,
+			Scopes:             append([]string(nil), request.Scopes...),
+			Audience:           append([]string(nil), request.Audience...),
+			AuthTime:           request.AuthTime,
+			AuthMethods:        append([]string(nil), request.AuthMethods...),
+			SubjectTokenType:   request.SubjectTokenType,
+			RequestedTokenType: request.RequestedTokenType,
+			ActorUserId:        request.ActorUserID,
+			Impersonation:      request.Impersonation,
+		})
+	}
+}
+
+type tokenExchangeRequest struct {
+	UserId             string
+	ClientId           string
+	Scopes             []string
+	Audience           []string
+	AuthTime           time.Time
+	AuthMethods        []string
+	SubjectTokenType   string
+	RequestedTokenType string
+	ActorUserId        string
+	Impersonation      bool
+}
@@ -0,0 +1,78 @@
+package actions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// SigningHeader is the header containing the signature of the payload sent to a target
+	SigningHeader = "ZITADEL-Signature"
+
+	signingTimestamp = "t"
+	signingVersion   = "v1"
+)
+
+// ComputeSignatureHeader returns the value of the [SigningHeader] for the payload,
+// the signature is the HMAC-SHA256 of "<unix timestamp>.<payload>" using the signing key of the target
+func ComputeSignatureHeader(t time.Time, payload []byte, signingKey string) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("%s=%s,%s=%s", signingTimestamp, timestamp, signingVersion, computeSignature(timestamp, payload, signingKey))
+}
+
+func computeSignature(timestamp string, payload []byte, signingKey string) string {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidatePayload checks the [SigningHeader] value of a received payload,
+// the timestamp must not be older than the tolerance
+func ValidatePayload(payload []byte, header, signingKey string, tolerance time.Duration) error {
+	timestamp, signatures, err := parseSignatureHeader(header)
+	if err != nil {
+		return err
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return z_errs.ThrowInvalidArgument(err, "ACTIO-Gb2oz", "invalid timestamp")
+	}
+	if tolerance > 0 && time.Since(time.Unix(unix, 0)) > tolerance {
+		return z_errs.ThrowInvalidArgument(nil, "ACTIO-Jf8ew", "timestamp outside of tolerance")
+	}
+	expected := computeSignature(timestamp, payload, signingKey)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return z_errs.ThrowInvalidArgument(nil, "ACTIO-Ks9sa", "signature invalid")
+}
+
+func parseSignatureHeader(header string) (timestamp string, signatures []string, err error) {
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case signingTimestamp:
+			timestamp = value
+		case signingVersion:
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return "", nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Ap1bO", "signature header invalid")
+	}
+	return timestamp, signatures, nil
+}
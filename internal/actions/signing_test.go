@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestValidatePayload(t *testing.T) {
+	payload := []byte(`{"userID":"user1"}`)
+	now := time.Now()
+	type args struct {
+		payload    []byte
+		header     string
+		signingKey string
+		tolerance  time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			args: args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload, "key"),
+				signingKey: "key",
+				tolerance:  time.Minute,
+			},
+		},
+		{
+			name: "multiple signatures",
+			args: args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload, "key") + ",v1=" + computeSignature("0", payload, "old"),
+				signingKey: "key",
+				tolerance:  time.Minute,
+			},
+		},
+		{
+			name: "wrong key",
+			args: args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now, payload, "key"),
+				signingKey: "other",
+				tolerance:  time.Minute,
+			},
+			wantErr: true,
+		},
+		{
+			name: "changed payload",
+			args: args{
+				payload:    []byte(`{"userID":"user2"}`),
+				header:     ComputeSignatureHeader(now, payload, "key"),
+				signingKey: "key",
+				tolerance:  time.Minute,
+			},
+			wantErr: true,
+		},
+		{
+			name: "outside tolerance",
+			args: args{
+				payload:    payload,
+				header:     ComputeSignatureHeader(now.Add(-time.Hour), payload, "key"),
+				signingKey: "key",
+				tolerance:  time.Minute,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid header",
+			args: args{
+				payload:    payload,
+				header:     "invalid",
+				signingKey: "key",
+				tolerance:  time.Minute,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePayload(tt.args.payload, tt.args.header, tt.args.signingKey, tt.args.tolerance)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.IsErrorInvalidArgument(err))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
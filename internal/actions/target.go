@@ -0,0 +1,256 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// maxTargetResponseSize limits the size of the response body read from a target
+	maxTargetResponseSize = 1 << 20
+	// targetResponseTolerance is the maximum age of the signature of a target response
+	targetResponseTolerance = 5 * time.Minute
+)
+
+// ExecutionQueries resolves the targets which have to be called for an execution
+type ExecutionQueries interface {
+	ExecutionTargetsByIDs(ctx context.Context, ids []string) ([]*query.ExecutionTarget, error)
+}
+
+// EventTargetsRecorder keeps track of the targets called for an event,
+// so that they are not called again if the event is retried after a target failed
+type EventTargetsRecorder interface {
+	// CalledEventTargets returns the ids of the targets already called for the event
+	CalledEventTargets(ctx context.Context, event eventstore.Event) ([]string, error)
+	// EventTargetsCalled records the ids of the targets of the execution called for the event
+	EventTargetsCalled(ctx context.Context, executionID string, event eventstore.Event, targetIDs []string) error
+}
+
+// FunctionPayload is sent to the targets of a function execution (flow and trigger type)
+type FunctionPayload struct {
+	FlowType      string `json:"flowType"`
+	TriggerType   string `json:"triggerType"`
+	InstanceID    string `json:"instanceID"`
+	ResourceOwner string `json:"resourceOwner,omitempty"`
+	UserID        string `json:"userID,omitempty"`
+	AuthRequestID string `json:"authRequestID,omitempty"`
+	ClientID      string `json:"clientID,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// EventPayload is sent to the targets of an event execution
+type EventPayload struct {
+	AggregateID   string          `json:"aggregateID"`
+	AggregateType string          `json:"aggregateType"`
+	ResourceOwner string          `json:"resourceOwner"`
+	InstanceID    string          `json:"instanceID"`
+	Version       string          `json:"version"`
+	Sequence      uint64          `json:"sequence"`
+	EventType     string          `json:"eventType"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	UserID        string          `json:"userID"`
+	EventPayload  json.RawMessage `json:"eventPayload,omitempty"`
+}
+
+// CallEventTargets calls the targets set on the most specific execution of the event (type, aggregate or all events).
+// Executions set after the event was created are ignored, so that targets are not called for past events.
+// If a target with InterruptOnError fails, the targets called before are recorded
+// and skipped when the event is retried.
+func CallEventTargets(ctx context.Context, queries ExecutionQueries, recorder EventTargetsRecorder, event eventstore.Event) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	targets, err := queries.ExecutionTargetsByIDs(ctx, domain.ExecutionIDsForEvent(string(event.Aggregate().Type), string(event.Type())))
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+	if event.CreationDate().Before(targets[0].ExecutionCreationDate) {
+		return nil
+	}
+	targets, err = uncalledEventTargets(ctx, recorder, event, targets)
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+	called, err := callTargets(ctx, targets, &EventPayload{
+		AggregateID:   event.Aggregate().ID,
+		AggregateType: string(event.Aggregate().Type),
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		InstanceID:    event.Aggregate().InstanceID,
+		Version:       string(event.Aggregate().Version),
+		Sequence:      event.Sequence(),
+		EventType:     string(event.Type()),
+		CreatedAt:     event.CreationDate(),
+		UserID:        event.EditorUser(),
+		EventPayload:  event.DataAsBytes(),
+	}, nil)
+	if err != nil && called > 0 {
+		recordErr := recorder.EventTargetsCalled(ctx, targets[0].ExecutionID, event, targetIDs(targets[:called]))
+		logging.WithFields("execution", targets[0].ExecutionID).OnError(recordErr).Warn("unable to record called targets")
+	}
+	return err
+}
+
+// uncalledEventTargets removes the targets already called for the event.
+// They are only looked up if a target can interrupt, as the event isn't retried otherwise.
+func uncalledEventTargets(ctx context.Context, recorder EventTargetsRecorder, event eventstore.Event, targets []*query.ExecutionTarget) ([]*query.ExecutionTarget, error) {
+	if !canInterrupt(targets) {
+		return targets, nil
+	}
+	calledIDs, err := recorder.CalledEventTargets(ctx, event)
+	if err != nil || len(calledIDs) == 0 {
+		return targets, err
+	}
+	uncalled := make([]*query.ExecutionTarget, 0, len(targets))
+	for _, target := range targets {
+		if !containsID(calledIDs, target.TargetID) {
+			uncalled = append(uncalled, target)
+		}
+	}
+	return uncalled, nil
+}
+
+func canInterrupt(targets []*query.ExecutionTarget) bool {
+	for _, target := range targets {
+		if !target.Async && target.InterruptOnError {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func targetIDs(targets []*query.ExecutionTarget) []string {
+	ids := make([]string, len(targets))
+	for i, target := range targets {
+		ids[i] = target.TargetID
+	}
+	return ids
+}
+
+// CallFunctionTargets calls the targets set on the execution of the flow and trigger type
+func CallFunctionTargets(ctx context.Context, queries ExecutionQueries, flowType domain.FlowType, triggerType domain.TriggerType, payload *FunctionPayload) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	targets, err := queries.ExecutionTargetsByIDs(ctx, []string{domain.ExecutionIDForFunction(flowType, triggerType)})
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+	payload.FlowType = flowType.ID()
+	payload.TriggerType = triggerType.ID()
+	payload.InstanceID = authz.GetInstance(ctx).InstanceID()
+	return CallTargets(ctx, targets, payload)
+}
+
+// CallTargets sends the JSON encoded payload to all targets in the provided order.
+// Async targets are called in the background and errors are only logged.
+// An error of a target with InterruptOnError is returned and stops calling the remaining targets.
+func CallTargets(ctx context.Context, targets []*query.ExecutionTarget, payload interface{}) error {
+	return CallTargetsWithResponse(ctx, targets, payload, nil)
+}
+
+// CallTargetsWithResponse sends the payload to all targets like [CallTargets].
+// The non-empty response body of every synchronous target is passed to handleResponse,
+// which can change the payload sent to the following targets.
+// The response must be signed with the signing key of the target in the [SigningHeader],
+// an unsigned response or an error of handleResponse is treated like a failed call of the target.
+func CallTargetsWithResponse(ctx context.Context, targets []*query.ExecutionTarget, payload interface{}, handleResponse func(body []byte) error) error {
+	_, err := callTargets(ctx, targets, payload, handleResponse)
+	return err
+}
+
+// callTargets calls the targets like [CallTargetsWithResponse]
+// and returns the number of targets called before a target with InterruptOnError failed
+func callTargets(ctx context.Context, targets []*query.ExecutionTarget, payload interface{}, handleResponse func(body []byte) error) (called int, err error) {
+	for i, target := range targets {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return i, z_errs.ThrowInternal(err, "ACTIO-Fnw2s", "Errors.Internal")
+		}
+		if target.Async {
+			go func(target *query.ExecutionTarget) {
+				_, _, err := callTarget(context.Background(), target, body)
+				logging.WithFields("execution", target.ExecutionID, "target", target.TargetID).OnError(err).Info("async target call failed")
+			}(target)
+			continue
+		}
+		response, signature, err := callTarget(ctx, target, body)
+		if err == nil && handleResponse != nil && len(response) > 0 {
+			err = handleTargetResponse(target, response, signature, handleResponse)
+		}
+		if err == nil {
+			continue
+		}
+		if target.InterruptOnError {
+			return i, err
+		}
+		logging.WithFields("execution", target.ExecutionID, "target", target.TargetID).WithError(err).Info("target call failed")
+	}
+	return len(targets), nil
+}
+
+// handleTargetResponse verifies the signature of the response before it's passed to handleResponse
+func handleTargetResponse(target *query.ExecutionTarget, response []byte, signature string, handleResponse func(body []byte) error) error {
+	if err := ValidatePayload(response, signature, target.SigningKey, targetResponseTolerance); err != nil {
+		return z_errs.ThrowPreconditionFailed(err, "ACTIO-Vd4tu", "Errors.Execution.InvalidResponseSignature")
+	}
+	return handleResponse(bytes.TrimSpace(response))
+}
+
+// callTarget posts the signed body to the target and returns the body of a successful response and its signature.
+// The body is returned unchanged, as the signature is computed over it, or empty if it only contains whitespaces.
+func callTarget(ctx context.Context, target *query.ExecutionTarget, body []byte) (response []byte, signature string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, target.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", z_errs.ThrowInternal(err, "ACTIO-Ba9qe", "Errors.Execution.Failed")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SigningHeader, ComputeSignatureHeader(time.Now(), body, target.SigningKey))
+
+	client := &http.Client{Transport: new(transport), Timeout: target.Timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", z_errs.ThrowUnavailable(err, "ACTIO-Gi3kp", "Errors.Execution.Failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, "", z_errs.ThrowPreconditionFailed(nil, "ACTIO-Wo1ck", "Errors.Execution.Failed")
+	}
+	response, err = io.ReadAll(io.LimitReader(resp.Body, maxTargetResponseSize+1))
+	if err != nil {
+		return nil, "", z_errs.ThrowUnavailable(err, "ACTIO-Lk3xa", "Errors.Execution.Failed")
+	}
+	if len(response) > maxTargetResponseSize {
+		return nil, "", z_errs.ThrowPreconditionFailed(nil, "ACTIO-Pq8vn", "Errors.Execution.ResponseTooLarge")
+	}
+	if len(bytes.TrimSpace(response)) == 0 {
+		return nil, "", nil
+	}
+	return response, resp.Header.Get(SigningHeader), nil
+}
@@ -0,0 +1,214 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func TestCallTargets(t *testing.T) {
+	type payload struct {
+		UserID string `json:"userID"`
+	}
+	tests := []struct {
+		name      string
+		status    int
+		sleep     time.Duration
+		target    query.ExecutionTarget
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			target: query.ExecutionTarget{
+				Timeout:          time.Second,
+				InterruptOnError: true,
+				SigningKey:       "key",
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "error, interrupt",
+			status: http.StatusInternalServerError,
+			target: query.ExecutionTarget{
+				Timeout:          time.Second,
+				InterruptOnError: true,
+				SigningKey:       "key",
+			},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:   "error, no interrupt",
+			status: http.StatusInternalServerError,
+			target: query.ExecutionTarget{
+				Timeout:    time.Second,
+				SigningKey: "key",
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "timeout, interrupt",
+			status: http.StatusOK,
+			sleep:  time.Second,
+			target: query.ExecutionTarget{
+				Timeout:          100 * time.Millisecond,
+				InterruptOnError: true,
+				SigningKey:       "key",
+			},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := make(chan error, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err == nil {
+					err = ValidatePayload(body, r.Header.Get(SigningHeader), tt.target.SigningKey, time.Minute)
+				}
+				calls <- err
+				time.Sleep(tt.sleep)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			target := tt.target
+			target.Endpoint = server.URL
+			err := CallTargets(context.Background(), []*query.ExecutionTarget{&target}, &payload{UserID: "user1"})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.Len(t, calls, tt.wantCalls)
+			assert.NoError(t, <-calls)
+		})
+	}
+}
+
+func TestCallTargets_async(t *testing.T) {
+	calls := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		calls <- struct{}{}
+	}))
+	defer server.Close()
+
+	err := CallTargets(context.Background(), []*query.ExecutionTarget{
+		{
+			Endpoint:   server.URL,
+			Timeout:    time.Second,
+			Async:      true,
+			SigningKey: "key",
+		},
+	}, struct{}{})
+	require.NoError(t, err)
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("async target not called")
+	}
+}
+
+func TestCallTargetsWithResponse(t *testing.T) {
+	type payload struct {
+		Value string `json:"value"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		// every target appends to the value it receives
+		response := []byte(strings.TrimSuffix(string(body), `"}`) + `+target"}`)
+		w.Header().Set(SigningHeader, ComputeSignatureHeader(time.Now(), response, "key"))
+		_, _ = w.Write(response)
+	}))
+	defer server.Close()
+
+	p := &payload{Value: "start"}
+	target := &query.ExecutionTarget{Endpoint: server.URL, Timeout: time.Second, InterruptOnError: true, SigningKey: "key"}
+	err := CallTargetsWithResponse(context.Background(), []*query.ExecutionTarget{target, target}, p, func(body []byte) error {
+		return json.Unmarshal(body, p)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "start+target+target", p.Value)
+
+	err = CallTargetsWithResponse(context.Background(), []*query.ExecutionTarget{target}, p, func([]byte) error {
+		return errors.New("invalid")
+	})
+	assert.Error(t, err)
+}
+
+func TestCallTargetsWithResponse_signature(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature func(response []byte) string
+		wantErr   bool
+	}{
+		{
+			name: "unsigned",
+			signature: func([]byte) string {
+				return ""
+			},
+			wantErr: true,
+		},
+		{
+			name: "signed with other key",
+			signature: func(response []byte) string {
+				return ComputeSignatureHeader(time.Now(), response, "other")
+			},
+			wantErr: true,
+		},
+		{
+			name: "signature expired",
+			signature: func(response []byte) string {
+				return ComputeSignatureHeader(time.Now().Add(-targetResponseTolerance-time.Minute), response, "key")
+			},
+			wantErr: true,
+		},
+		{
+			name: "signed",
+			signature: func(response []byte) string {
+				return ComputeSignatureHeader(time.Now(), response, "key")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := []byte(`{"value":"changed"}` + "\n")
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if signature := tt.signature(response); signature != "" {
+					w.Header().Set(SigningHeader, signature)
+				}
+				_, _ = w.Write(response)
+			}))
+			defer server.Close()
+
+			handled := false
+			target := &query.ExecutionTarget{Endpoint: server.URL, Timeout: time.Second, InterruptOnError: true, SigningKey: "key"}
+			err := CallTargetsWithResponse(context.Background(), []*query.ExecutionTarget{target}, struct{}{}, func(body []byte) error {
+				handled = true
+				assert.Equal(t, `{"value":"changed"}`, string(body))
+				return nil
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, !tt.wantErr, handled)
+		})
+	}
+}
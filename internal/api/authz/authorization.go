@@ -54,6 +54,15 @@ func CheckUserAuthorization(ctx context.Context, req interface{}, token, orgID,
 	}, nil
 }
 
+// CheckRequestPermissions checks the permissions required for the request (e.g. changed after the authorization)
+// against the permissions of the user resolved on the authorization of the call and stored in the context
+func CheckRequestPermissions(ctx context.Context, req interface{}, requiredAuthOption Option) error {
+	if requiredAuthOption.Permission == authenticated {
+		return nil
+	}
+	return checkUserPermissions(req, GetRequestPermissionsFromCtx(ctx), requiredAuthOption)
+}
+
 func checkUserPermissions(req interface{}, userPerms []string, authOpt Option) error {
 	if len(userPerms) == 0 {
 		return errors.ThrowPermissionDenied(nil, "AUTH-5mWD2", "No matching permissions found")
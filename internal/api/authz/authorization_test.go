@@ -1,6 +1,7 @@
 package authz
 
 import (
+	"context"
 	"testing"
 
 	"github.com/zitadel/zitadel/internal/errors"
@@ -84,6 +85,67 @@ func Test_CheckUserPermissions(t *testing.T) {
 	}
 }
 
+func Test_CheckRequestPermissions(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		req     *TestRequest
+		authOpt Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "authenticated only",
+			args: args{
+				ctx:     context.Background(),
+				req:     &TestRequest{Test: "Hodor"},
+				authOpt: Option{Permission: authenticated},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no permissions in context",
+			args: args{
+				ctx:     context.Background(),
+				req:     &TestRequest{Test: "Test"},
+				authOpt: Option{Permission: "project.read", CheckParam: "Test"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "context requested and has specific permission",
+			args: args{
+				ctx:     NewMockContextWithPermissions("instance", "org", "user", []string{"project.read:Test"}),
+				req:     &TestRequest{Test: "Test"},
+				authOpt: Option{Permission: "project.read", CheckParam: "Test"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "context requested and has no permission",
+			args: args{
+				ctx:     NewMockContextWithPermissions("instance", "org", "user", []string{"project.read:Test"}),
+				req:     &TestRequest{Test: "Hodor"},
+				authOpt: Option{Permission: "project.read", CheckParam: "Test"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRequestPermissions(tt.args.ctx, tt.args.req, tt.args.authOpt)
+			if !tt.wantErr && err != nil {
+				t.Errorf("shouldn't get err: %v ", err)
+			}
+			if tt.wantErr && !errors.IsPermissionDenied(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
 func Test_SplitPermission(t *testing.T) {
 	type args struct {
 		perm string
@@ -17,6 +17,7 @@ const (
 	dataKey               key = 2
 	allPermissionsKey     key = 3
 	instanceKey           key = 4
+	dpopProofKey          key = 5
 )
 
 type CtxData struct {
@@ -0,0 +1,161 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/cache/replay"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	DPoPPrefix    = "DPoP "
+	DPoPProofType = "dpop+jwt"
+
+	// dpopProofMaxAge is the maximum time difference between the issuance of a proof and its verification
+	dpopProofMaxAge = time.Minute
+)
+
+var (
+	// DPoPSigningAlgorithms are the asymmetric algorithms accepted for the signature of DPoP proofs
+	DPoPSigningAlgorithms = []string{
+		string(jose.RS256), string(jose.RS384), string(jose.RS512),
+		string(jose.PS256), string(jose.PS384), string(jose.PS512),
+		string(jose.ES256), string(jose.ES384), string(jose.ES512),
+		string(jose.EdDSA),
+	}
+)
+
+// DPoPProof is a DPoP proof (RFC 9449) sent by the client
+// together with the method and path of the request it was sent with
+type DPoPProof struct {
+	Proof  string
+	Method string
+	Path   string
+}
+
+type dpopProofClaims struct {
+	ID              string           `json:"jti"`
+	Method          string           `json:"htm"`
+	URI             string           `json:"htu"`
+	IssuedAt        *jwt.NumericDate `json:"iat"`
+	AccessTokenHash string           `json:"ath,omitempty"`
+}
+
+// WithDPoPProof sets the DPoP proof of the request into the context,
+// so the proof of possession of DPoP-bound access tokens can be checked
+func WithDPoPProof(ctx context.Context, proof *DPoPProof) context.Context {
+	return context.WithValue(ctx, dpopProofKey, proof)
+}
+
+func DPoPProofFromCtx(ctx context.Context) *DPoPProof {
+	proof, _ := ctx.Value(dpopProofKey).(*DPoPProof)
+	return proof
+}
+
+// VerifyDPoPProof verifies the DPoP proof (RFC 9449 section 4.3) for a request with the provided method and uri.
+// If an access token is provided, the proof must contain its hash (ath).
+// It returns the JWK thumbprint (RFC 7638) of the public key the proof was signed with.
+func VerifyDPoPProof(proof, method, uri, accessToken string, now time.Time) (string, error) {
+	token, err := jwt.ParseSigned(proof)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Wd9pq", "invalid dpop proof")
+	}
+	if len(token.Headers) != 1 {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Hq2mz", "invalid dpop proof")
+	}
+	header := token.Headers[0]
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != DPoPProofType {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Ls8vn", "invalid dpop proof type")
+	}
+	if !isAsymmetricAlgorithm(header.Algorithm) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Ob3kt", "invalid dpop proof algorithm")
+	}
+	key := header.JSONWebKey
+	if key == nil || !key.Valid() || !key.IsPublic() {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Ec7jr", "invalid dpop proof key")
+	}
+	claims := new(dpopProofClaims)
+	if err = token.Claims(key, claims); err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Tf4nw", "invalid dpop proof signature")
+	}
+	if claims.ID == "" || claims.IssuedAt == nil {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Ur1xa", "invalid dpop proof claims")
+	}
+	if claims.Method != method || !dpopURIMatches(claims.URI, uri) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Pz6ce", "dpop proof does not match the request")
+	}
+	if age := now.Sub(claims.IssuedAt.Time()); age > dpopProofMaxAge || age < -dpopProofMaxAge {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Ak5gy", "dpop proof expired")
+	}
+	if accessToken != "" && claims.AccessTokenHash != AccessTokenHash(accessToken) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Yn0sb", "dpop proof does not match the access token")
+	}
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTHZ-Jv2lo", "invalid dpop proof key")
+	}
+	jkt := base64.RawURLEncoding.EncodeToString(thumbprint)
+	if !seenDPoPProofs.Add(seenDPoPProofKey{id: claims.ID, thumbprint: jkt}, claims.IssuedAt.Time().Add(dpopProofMaxAge), now) {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTHZ-Rp4ux", "dpop proof already used")
+	}
+	return jkt, nil
+}
+
+// AccessTokenHash returns the hash of the access token, a DPoP proof must contain when the token is used (RFC 9449 section 4.2)
+func AccessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func isAsymmetricAlgorithm(algorithm string) bool {
+	for _, alg := range DPoPSigningAlgorithms {
+		if alg == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// dpopURIMatches compares the htu claim to the uri of the request without query and fragment.
+// The scheme and its default port are ignored, as TLS might be terminated in front of ZITADEL.
+func dpopURIMatches(htu, uri string) bool {
+	proofURI, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	requestURI, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(proofURI.Hostname(), requestURI.Hostname()) &&
+		dpopPort(proofURI) == dpopPort(requestURI) &&
+		proofURI.Path == requestURI.Path
+}
+
+func dpopPort(uri *url.URL) string {
+	port := uri.Port()
+	if port == "80" || port == "443" {
+		return ""
+	}
+	return port
+}
+
+// seenDPoPProofs contains the jti of the proofs per key verified by the process,
+// so a proof can not be replayed (RFC 9449 section 11.1) as long as it's accepted by its issuance time.
+// The proofs are not shared between processes, so with multiple processes a proof can be used once per process
+// within the maximum age of one minute.
+var seenDPoPProofs = replay.New[seenDPoPProofKey](dpopProofMaxAge)
+
+type seenDPoPProofKey struct {
+	id         string
+	thumbprint string
+}
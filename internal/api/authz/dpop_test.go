@@ -0,0 +1,270 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/cache/replay"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	testDPoPURI         = "https://zitadel.cloud/oauth/v2/token"
+	testDPoPAccessToken = "accessToken"
+)
+
+func newTestDPoPKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	thumbprint, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	return key, base64.RawURLEncoding.EncodeToString(thumbprint)
+}
+
+func newTestDPoPProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims *dpopProofClaims) string {
+	options := (&jose.SignerOptions{EmbedJWK: true}).WithType(jose.ContentType(typ))
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, options)
+	require.NoError(t, err)
+	proof, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
+
+func TestVerifyDPoPProof(t *testing.T) {
+	key, thumbprint := newTestDPoPKey(t)
+	now := time.Now()
+	type args struct {
+		typ         string
+		claims      *dpopProofClaims
+		method      string
+		uri         string
+		accessToken string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "invalid type, error",
+			args: args{
+				typ:    "JWT",
+				claims: &dpopProofClaims{ID: "id", Method: "POST", URI: testDPoPURI, IssuedAt: jwt.NewNumericDate(now)},
+				method: "POST",
+				uri:    testDPoPURI,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing jti, error",
+			args: args{
+				typ:    DPoPProofType,
+				claims: &dpopProofClaims{Method: "POST", URI: testDPoPURI, IssuedAt: jwt.NewNumericDate(now)},
+				method: "POST",
+				uri:    testDPoPURI,
+			},
+			wantErr: true,
+		},
+		{
+			name: "other method, error",
+			args: args{
+				typ:    DPoPProofType,
+				claims: &dpopProofClaims{ID: "id", Method: "GET", URI: testDPoPURI, IssuedAt: jwt.NewNumericDate(now)},
+				method: "POST",
+				uri:    testDPoPURI,
+			},
+			wantErr: true,
+		},
+		{
+			name: "other uri, error",
+			args: args{
+				typ:    DPoPProofType,
+				claims: &dpopProofClaims{ID: "id", Method: "POST", URI: "https://zitadel.cloud/oauth/v2/introspect", IssuedAt: jwt.NewNumericDate(now)},
+				method: "POST",
+				uri:    testDPoPURI,
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired, error",
+			args: args{
+				typ:    DPoPProofType,
+				claims: &dpopProofClaims{ID: "id", Method: "POST", URI: testDPoPURI, IssuedAt: jwt.NewNumericDate(now.Add(-2 * time.Minute))},
+				method: "POST",
+				uri:    testDPoPURI,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing access token hash, error",
+			args: args{
+				typ:         DPoPProofType,
+				claims:      &dpopProofClaims{ID: "id", Method: "POST", URI: testDPoPURI, IssuedAt: jwt.NewNumericDate(now)},
+				method:      "POST",
+				uri:         testDPoPURI,
+				accessToken: testDPoPAccessToken,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid, ok",
+			args: args{
+				typ:    DPoPProofType,
+				claims: &dpopProofClaims{ID: "id", Method: "POST", URI: testDPoPURI, IssuedAt: jwt.NewNumericDate(now)},
+				method: "POST",
+				uri:    testDPoPURI,
+			},
+			want: thumbprint,
+		},
+		{
+			name: "valid with query and default port, ok",
+			args: args{
+				typ:    DPoPProofType,
+				claims: &dpopProofClaims{ID: "id", Method: "POST", URI: testDPoPURI + "?key=value", IssuedAt: jwt.NewNumericDate(now)},
+				method: "POST",
+				uri:    "//zitadel.cloud:443/oauth/v2/token",
+			},
+			want: thumbprint,
+		},
+		{
+			name: "valid with access token hash, ok",
+			args: args{
+				typ:         DPoPProofType,
+				claims:      &dpopProofClaims{ID: "id", Method: "POST", URI: testDPoPURI, IssuedAt: jwt.NewNumericDate(now), AccessTokenHash: AccessTokenHash(testDPoPAccessToken)},
+				method:      "POST",
+				uri:         testDPoPURI,
+				accessToken: testDPoPAccessToken,
+			},
+			want: thumbprint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seenDPoPProofs = replay.New[seenDPoPProofKey](dpopProofMaxAge)
+			proof := newTestDPoPProof(t, key, tt.args.typ, tt.args.claims)
+			got, err := VerifyDPoPProof(proof, tt.args.method, tt.args.uri, tt.args.accessToken, now)
+			if tt.wantErr {
+				assert.True(t, errors.IsUnauthenticated(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVerifyDPoPProof_replay(t *testing.T) {
+	seenDPoPProofs = replay.New[seenDPoPProofKey](dpopProofMaxAge)
+	key, thumbprint := newTestDPoPKey(t)
+	otherKey, otherThumbprint := newTestDPoPKey(t)
+	now := time.Now()
+	claims := &dpopProofClaims{ID: "id", Method: "POST", URI: testDPoPURI, IssuedAt: jwt.NewNumericDate(now)}
+	proof := newTestDPoPProof(t, key, DPoPProofType, claims)
+
+	got, err := VerifyDPoPProof(proof, "POST", testDPoPURI, "", now)
+	require.NoError(t, err)
+	assert.Equal(t, thumbprint, got)
+
+	_, err = VerifyDPoPProof(proof, "POST", testDPoPURI, "", now)
+	assert.True(t, errors.IsUnauthenticated(err), "replayed proof must be rejected")
+
+	got, err = VerifyDPoPProof(newTestDPoPProof(t, otherKey, DPoPProofType, claims), "POST", testDPoPURI, "", now)
+	require.NoError(t, err, "same jti of another key must be accepted")
+	assert.Equal(t, otherThumbprint, got)
+}
+
+func TestCheckProofOfPossession(t *testing.T) {
+	key, thumbprint := newTestDPoPKey(t)
+	_, otherThumbprint := newTestDPoPKey(t)
+	proof := newTestDPoPProof(t, key, DPoPProofType, &dpopProofClaims{
+		ID:              "id",
+		Method:          "GET",
+		URI:             "https://zitadel.cloud/auth/v1/users/me",
+		IssuedAt:        jwt.NewNumericDate(time.Now()),
+		AccessTokenHash: AccessTokenHash(testDPoPAccessToken),
+	})
+	ctx := WithInstance(context.Background(), &mockInstance{})
+	type args struct {
+		ctx           context.Context
+		jwkThumbprint string
+		dpop          bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "bearer token, ok",
+			args: args{
+				ctx: ctx,
+			},
+		},
+		{
+			name: "bound token with bearer scheme, error",
+			args: args{
+				ctx:           ctx,
+				jwkThumbprint: thumbprint,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token without proof, error",
+			args: args{
+				ctx:           ctx,
+				jwkThumbprint: thumbprint,
+				dpop:          true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof of other key, error",
+			args: args{
+				ctx:           WithDPoPProof(ctx, &DPoPProof{Proof: proof, Method: "GET", Path: "/auth/v1/users/me"}),
+				jwkThumbprint: otherThumbprint,
+				dpop:          true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof for other request, error",
+			args: args{
+				ctx:           WithDPoPProof(ctx, &DPoPProof{Proof: proof, Method: "POST", Path: "/auth/v1/users/me"}),
+				jwkThumbprint: thumbprint,
+				dpop:          true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof, ok",
+			args: args{
+				ctx:           WithDPoPProof(ctx, &DPoPProof{Proof: proof, Method: "GET", Path: "/auth/v1/users/me"}),
+				jwkThumbprint: thumbprint,
+				dpop:          true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seenDPoPProofs = replay.New[seenDPoPProofKey](dpopProofMaxAge)
+			err := CheckProofOfPossession(tt.args.ctx, testDPoPAccessToken, tt.args.jwkThumbprint, tt.args.dpop)
+			if tt.wantErr {
+				assert.True(t, errors.IsUnauthenticated(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
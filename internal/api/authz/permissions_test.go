@@ -12,11 +12,12 @@ func getTestCtx(userID, orgID string) context.Context {
 }
 
 type testVerifier struct {
-	memberships []*Membership
+	memberships   []*Membership
+	jwkThumbprint string
 }
 
-func (v *testVerifier) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, error) {
-	return "userID", "agentID", "clientID", "de", "orgID", nil
+func (v *testVerifier) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, string, error) {
+	return "userID", "agentID", "clientID", "de", "orgID", v.jwkThumbprint, nil
 }
 func (v *testVerifier) SearchMyMemberships(ctx context.Context, orgID string) ([]*Membership, error) {
 	return v.memberships, nil
@@ -5,6 +5,7 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -36,7 +37,7 @@ type MembershipsResolver interface {
 }
 
 type authZRepo interface {
-	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner string, err error)
+	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner, jwkThumbprint string, err error)
 	VerifierClientID(ctx context.Context, name string) (clientID, projectID string, err error)
 	SearchMyMemberships(ctx context.Context, orgID string) ([]*Membership, error)
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
@@ -58,16 +59,16 @@ func Start(authZRepo authZRepo, issuer string, keys map[string]*SystemAPIUser) (
 	}
 }
 
-func (v *TokenVerifier) VerifyAccessToken(ctx context.Context, token string, method string) (userID, clientID, agentID, prefLang, resourceOwner string, err error) {
+func (v *TokenVerifier) VerifyAccessToken(ctx context.Context, token string, method string) (userID, clientID, agentID, prefLang, resourceOwner, jwkThumbprint string, err error) {
 	if strings.HasPrefix(method, "/zitadel.system.v1.SystemService") {
 		userID, err := v.verifySystemToken(ctx, token)
 		if err != nil {
-			return "", "", "", "", "", err
+			return "", "", "", "", "", "", err
 		}
-		return userID, "", "", "", "", nil
+		return userID, "", "", "", "", "", nil
 	}
-	userID, agentID, clientID, prefLang, resourceOwner, err = v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID())
-	return userID, clientID, agentID, prefLang, resourceOwner, err
+	userID, agentID, clientID, prefLang, resourceOwner, jwkThumbprint, err = v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID())
+	return userID, clientID, agentID, prefLang, resourceOwner, jwkThumbprint, err
 }
 
 func (v *TokenVerifier) verifySystemToken(ctx context.Context, token string) (string, error) {
@@ -163,11 +164,47 @@ func verifyAccessToken(ctx context.Context, token string, t *TokenVerifier, meth
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	parts := strings.Split(token, BearerPrefix)
-	if len(parts) != 2 {
-		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "AUTH-7fs1e", "invalid auth header")
+	accessToken, dpop := strings.CutPrefix(token, DPoPPrefix)
+	if !dpop {
+		parts := strings.Split(token, BearerPrefix)
+		if len(parts) != 2 {
+			return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "AUTH-7fs1e", "invalid auth header")
+		}
+		accessToken = parts[1]
+	}
+	userID, clientID, agentID, prefLan, resourceOwner, jwkThumbprint, err := t.VerifyAccessToken(ctx, accessToken, method)
+	if err != nil {
+		return "", "", "", "", "", err
+	}
+	if err = CheckProofOfPossession(ctx, accessToken, jwkThumbprint, dpop); err != nil {
+		return "", "", "", "", "", err
+	}
+	return userID, clientID, agentID, prefLan, resourceOwner, nil
+}
+
+// CheckProofOfPossession ensures that DPoP-bound access tokens (RFC 9449) are only used with the DPoP authorization scheme
+// and a valid proof of the key they are bound to, and that the DPoP scheme is only used with DPoP-bound access tokens.
+// The proof is read from the context, see [WithDPoPProof].
+func CheckProofOfPossession(ctx context.Context, accessToken, jwkThumbprint string, dpop bool) error {
+	if !dpop {
+		if jwkThumbprint != "" {
+			return caos_errs.ThrowUnauthenticated(nil, "AUTH-Kx7de", "dpop bound token requires the DPoP authorization scheme")
+		}
+		return nil
+	}
+	proof := DPoPProofFromCtx(ctx)
+	if proof == nil || proof.Proof == "" {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTH-Gm3wa", "dpop proof missing")
+	}
+	uri := &url.URL{Host: GetInstance(ctx).RequestedHost(), Path: proof.Path}
+	thumbprint, err := VerifyDPoPProof(proof.Proof, proof.Method, uri.String(), accessToken, time.Now())
+	if err != nil {
+		return err
+	}
+	if thumbprint != jwkThumbprint {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTH-Bv8ir", "dpop proof does not match the token binding")
 	}
-	return t.VerifyAccessToken(ctx, parts[1], method)
+	return nil
 }
 
 func SessionTokenVerifier(algorithm crypto.EncryptionAlgorithm) func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error) {
@@ -2,9 +2,14 @@ package action
 
 import (
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
 	"github.com/zitadel/zitadel/internal/query"
 	action_pb "github.com/zitadel/zitadel/pkg/grpc/action"
 	message_pb "github.com/zitadel/zitadel/pkg/grpc/message"
@@ -19,6 +24,14 @@ func FlowTypeToDomain(flowType string) domain.FlowType {
 		return domain.FlowTypeCustomiseToken
 	case domain.FlowTypeInternalAuthentication.ID():
 		return domain.FlowTypeInternalAuthentication
+	case domain.FlowTypePasswordReset.ID():
+		return domain.FlowTypePasswordReset
+	case domain.FlowTypeSelfRegistration.ID():
+		return domain.FlowTypeSelfRegistration
+	case domain.FlowTypeTokenExchange.ID():
+		return domain.FlowTypeTokenExchange
+	case domain.FlowTypeCustomiseSAMLResponse.ID():
+		return domain.FlowTypeCustomiseSAMLResponse
 	default:
 		return domain.FlowTypeUnspecified
 	}
@@ -47,6 +60,14 @@ func TriggerTypeToDomain(triggerType string) domain.TriggerType {
 		return domain.TriggerTypePreAccessTokenCreation
 	case domain.TriggerTypePreUserinfoCreation.ID():
 		return domain.TriggerTypePreUserinfoCreation
+	case domain.TriggerTypePreNotification.ID():
+		return domain.TriggerTypePreNotification
+	case domain.TriggerTypePreRefreshTokenCreation.ID():
+		return domain.TriggerTypePreRefreshTokenCreation
+	case domain.TriggerTypePreSAMLResponseCreation.ID():
+		return domain.TriggerTypePreSAMLResponseCreation
+	case domain.TriggerTypePreTokenExchange.ID():
+		return domain.TriggerTypePreTokenExchange
 	default:
 		return domain.TriggerTypeUnspecified
 	}
@@ -145,3 +166,89 @@ func ActionStateToDomain(state action_pb.ActionState) domain.ActionState {
 		return domain.ActionStateUnspecified
 	}
 }
+
+func ActionExecutionsToPb(executions []*query.ActionExecution) []*action_pb.ActionExecution {
+	list := make([]*action_pb.ActionExecution, len(executions))
+	for i, execution := range executions {
+		list[i] = ActionExecutionToPb(execution)
+	}
+	return list
+}
+
+func ActionExecutionToPb(execution *query.ActionExecution) *action_pb.ActionExecution {
+	return &action_pb.ActionExecution{
+		ActionId:    execution.ActionID,
+		FlowType:    FlowTypeToPb(execution.FlowType),
+		TriggerType: TriggerTypeToPb(execution.TriggerType),
+		UserId:      execution.UserID,
+		FinishDate:  timestamppb.New(execution.FinishDate),
+		Took:        durationpb.New(execution.Took),
+		Succeeded:   execution.Succeeded,
+		Error:       execution.Error,
+		Logs:        ActionExecutionLogsToPb(execution.Logs),
+	}
+}
+
+func ActionExecutionLogsToPb(logs []*execution.Log) []*action_pb.ActionExecutionLog {
+	list := make([]*action_pb.ActionExecutionLog, len(logs))
+	for i, log := range logs {
+		list[i] = &action_pb.ActionExecutionLog{
+			LogDate: timestamppb.New(log.LogDate),
+			Level:   log.LogLevel.String(),
+			Message: log.Message,
+		}
+	}
+	return list
+}
+
+func ActionExecutionActionIDQuery(q *action_pb.ActionIDQuery) (query.SearchQuery, error) {
+	return query.NewActionExecutionActionIDSearchQuery(q.Id)
+}
+
+func ActionExecutionFlowTypeQuery(q *action_pb.ActionExecutionFlowTypeQuery) (query.SearchQuery, error) {
+	return query.NewActionExecutionFlowTypeSearchQuery(FlowTypeToDomain(q.FlowType))
+}
+
+func ActionExecutionTriggerTypeQuery(q *action_pb.ActionExecutionTriggerTypeQuery) (query.SearchQuery, error) {
+	return query.NewActionExecutionTriggerTypeSearchQuery(TriggerTypeToDomain(q.TriggerType))
+}
+
+func ActionExecutionUserIDQuery(q *action_pb.ActionExecutionUserIDQuery) (query.SearchQuery, error) {
+	return query.NewActionExecutionUserIDSearchQuery(q.UserId)
+}
+
+func ActionExecutionResultQuery(q *action_pb.ActionExecutionResultQuery) (query.SearchQuery, error) {
+	return query.NewActionExecutionSucceededSearchQuery(q.Succeeded)
+}
+
+func HTTPMocksToDomain(mocks []*action_pb.HTTPMock) []*actions.HTTPMock {
+	list := make([]*actions.HTTPMock, len(mocks))
+	for i, mock := range mocks {
+		headers := make(map[string][]string, len(mock.Headers))
+		for key, value := range mock.Headers {
+			headers[key] = []string{value}
+		}
+		list[i] = &actions.HTTPMock{
+			Method:  mock.Method,
+			URL:     mock.Url,
+			Status:  int(mock.Status),
+			Headers: headers,
+			Body:    mock.Body,
+		}
+	}
+	return list
+}
+
+func MutationsToPb(mutations []*actions.Mutation) (_ []*action_pb.ActionMutation, err error) {
+	list := make([]*action_pb.ActionMutation, len(mutations))
+	for i, mutation := range mutations {
+		list[i] = &action_pb.ActionMutation{
+			Method: mutation.Method,
+		}
+		list[i].Args, err = structpb.NewList(mutation.Args)
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "ACTIO-Shoo4", "Errors.Internal")
+		}
+	}
+	return list, nil
+}
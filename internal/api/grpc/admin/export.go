@@ -497,6 +497,10 @@ func (s *Server) getLockoutPolicy(ctx context.Context, orgID string) (_ *managem
 	if !queriedLockout.IsDefault {
 		return &management_pb.AddCustomLockoutPolicyRequest{
 			MaxPasswordAttempts: uint32(queriedLockout.MaxPasswordAttempts),
+			MaxOtpAttempts:      uint32(queriedLockout.MaxOTPAttempts),
+			LockoutDuration:     durationpb.New(queriedLockout.LockoutDuration),
+			AttemptResetWindow:  durationpb.New(queriedLockout.AttemptResetWindow),
+			ProgressiveLockout:  queriedLockout.ProgressiveLockout,
 		}, nil
 	}
 	return nil, nil
@@ -794,23 +798,26 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 				oidcApps = append(oidcApps, &v1_pb.DataOIDCApplication{
 					AppId: app.ID,
 					App: &management_pb.AddOIDCAppRequest{
-						ProjectId:                app.ProjectID,
-						Name:                     app.Name,
-						RedirectUris:             app.OIDCConfig.RedirectURIs,
-						ResponseTypes:            responseTypes,
-						GrantTypes:               grantTypes,
-						AppType:                  app_pb.OIDCAppType(app.OIDCConfig.AppType),
-						AuthMethodType:           app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
-						PostLogoutRedirectUris:   app.OIDCConfig.PostLogoutRedirectURIs,
-						Version:                  app_pb.OIDCVersion(app.OIDCConfig.Version),
-						DevMode:                  app.OIDCConfig.IsDevMode,
-						AccessTokenType:          app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
-						AccessTokenRoleAssertion: app.OIDCConfig.AssertAccessTokenRole,
-						IdTokenRoleAssertion:     app.OIDCConfig.AssertIDTokenRole,
-						IdTokenUserinfoAssertion: app.OIDCConfig.AssertIDTokenUserinfo,
-						ClockSkew:                durationpb.New(app.OIDCConfig.ClockSkew),
-						AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
-						SkipNativeAppSuccessPage: app.OIDCConfig.SkipNativeAppSuccessPage,
+						ProjectId:                 app.ProjectID,
+						Name:                      app.Name,
+						RedirectUris:              app.OIDCConfig.RedirectURIs,
+						ResponseTypes:             responseTypes,
+						GrantTypes:                grantTypes,
+						AppType:                   app_pb.OIDCAppType(app.OIDCConfig.AppType),
+						AuthMethodType:            app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
+						PostLogoutRedirectUris:    app.OIDCConfig.PostLogoutRedirectURIs,
+						Version:                   app_pb.OIDCVersion(app.OIDCConfig.Version),
+						DevMode:                   app.OIDCConfig.IsDevMode,
+						AccessTokenType:           app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
+						AccessTokenRoleAssertion:  app.OIDCConfig.AssertAccessTokenRole,
+						IdTokenRoleAssertion:      app.OIDCConfig.AssertIDTokenRole,
+						IdTokenUserinfoAssertion:  app.OIDCConfig.AssertIDTokenUserinfo,
+						ClockSkew:                 durationpb.New(app.OIDCConfig.ClockSkew),
+						AdditionalOrigins:         app.OIDCConfig.AdditionalOrigins,
+						SkipNativeAppSuccessPage:  app.OIDCConfig.SkipNativeAppSuccessPage,
+						BackChannelLogoutUri:      app.OIDCConfig.BackChannelLogoutURI,
+						RequirePushedAuthRequests: app.OIDCConfig.RequirePushedAuthRequests,
+						RequireDpop:               app.OIDCConfig.RequireDPoP,
 					},
 				})
 			}
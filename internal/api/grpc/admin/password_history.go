@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) AddPasswordHistoryPolicy(ctx context.Context, req *admin_pb.AddPasswordHistoryPolicyRequest) (*admin_pb.AddPasswordHistoryPolicyResponse, error) {
+	result, err := s.command.AddDefaultPasswordHistoryPolicy(ctx, uint64(req.GetHistoryCount()))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddPasswordHistoryPolicyResponse{
+		Details: object.AddToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetPasswordHistoryPolicy(ctx context.Context, req *admin_pb.GetPasswordHistoryPolicyRequest) (*admin_pb.GetPasswordHistoryPolicyResponse, error) {
+	policy, err := s.query.DefaultPasswordHistoryPolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetPasswordHistoryPolicyResponse{
+		Policy: policy_grpc.ModelPasswordHistoryPolicyToPb(policy),
+	}, nil
+}
+
+func (s *Server) UpdatePasswordHistoryPolicy(ctx context.Context, req *admin_pb.UpdatePasswordHistoryPolicyRequest) (*admin_pb.UpdatePasswordHistoryPolicyResponse, error) {
+	result, err := s.command.ChangeDefaultPasswordHistoryPolicy(ctx, UpdatePasswordHistoryPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdatePasswordHistoryPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.ChangeDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
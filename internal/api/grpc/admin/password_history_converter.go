@@ -0,0 +1,12 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func UpdatePasswordHistoryPolicyToDomain(policy *admin_pb.UpdatePasswordHistoryPolicyRequest) *domain.PasswordHistoryPolicy {
+	return &domain.PasswordHistoryPolicy{
+		HistoryCount: uint64(policy.HistoryCount),
+	}
+}
@@ -0,0 +1,182 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	execution "github.com/zitadel/zitadel/pkg/grpc/execution/v2alpha"
+)
+
+func (s *Server) SetExecution(ctx context.Context, req *execution.SetExecutionRequest) (*execution.SetExecutionResponse, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	set := &command.SetExecution{
+		Targets: req.GetTargets(),
+	}
+	var (
+		details *domain.ObjectDetails
+		err     error
+	)
+	switch condition := req.GetCondition().GetConditionType().(type) {
+	case *execution.Condition_Request:
+		details, err = s.command.SetExecutionRequest(ctx, apiConditionToCommand(condition.Request), set, instanceID)
+	case *execution.Condition_Response:
+		details, err = s.command.SetExecutionResponse(ctx, apiConditionToCommand(condition.Response), set, instanceID)
+	case *execution.Condition_Event:
+		details, err = s.command.SetExecutionEvent(ctx, eventConditionToCommand(condition.Event), set, instanceID)
+	case *execution.Condition_Function:
+		details, err = s.command.SetExecutionFunction(ctx, functionConditionToCommand(condition.Function), set, instanceID)
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "GRPC-Bw3kq", "Errors.Execution.Invalid")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &execution.SetExecutionResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) DeleteExecution(ctx context.Context, req *execution.DeleteExecutionRequest) (*execution.DeleteExecutionResponse, error) {
+	id, err := conditionToID(req.GetCondition())
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.DeleteExecution(ctx, id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &execution.DeleteExecutionResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListExecutions(ctx context.Context, req *execution.ListExecutionsRequest) (*execution.ListExecutionsResponse, error) {
+	queries, err := listExecutionsRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := s.query.SearchExecutions(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &execution.ListExecutionsResponse{
+		Details: object.ToListDetails(executions.SearchResponse),
+		Result:  executionsToPb(executions.Executions),
+	}, nil
+}
+
+type apiCondition interface {
+	GetMethod() string
+	GetService() string
+	GetAll() bool
+}
+
+func apiConditionToCommand(condition apiCondition) *command.ExecutionAPICondition {
+	return &command.ExecutionAPICondition{
+		Method:  condition.GetMethod(),
+		Service: condition.GetService(),
+		All:     condition.GetAll(),
+	}
+}
+
+func eventConditionToCommand(condition *execution.EventExecution) *command.ExecutionEventCondition {
+	return &command.ExecutionEventCondition{
+		Event: condition.GetEvent(),
+		Group: condition.GetGroup(),
+		All:   condition.GetAll(),
+	}
+}
+
+func functionConditionToCommand(condition *execution.FunctionExecution) *command.ExecutionFunctionCondition {
+	return &command.ExecutionFunctionCondition{
+		FlowType:    action_grpc.FlowTypeToDomain(condition.GetFlowType()),
+		TriggerType: action_grpc.TriggerTypeToDomain(condition.GetTriggerType()),
+	}
+}
+
+func conditionToID(condition *execution.Condition) (string, error) {
+	switch c := condition.GetConditionType().(type) {
+	case *execution.Condition_Request:
+		cond := apiConditionToCommand(c.Request)
+		return cond.ID(domain.ExecutionTypeRequest), cond.IsValid()
+	case *execution.Condition_Response:
+		cond := apiConditionToCommand(c.Response)
+		return cond.ID(domain.ExecutionTypeResponse), cond.IsValid()
+	case *execution.Condition_Event:
+		cond := eventConditionToCommand(c.Event)
+		return cond.ID(), cond.IsValid()
+	case *execution.Condition_Function:
+		cond := functionConditionToCommand(c.Function)
+		return cond.ID(), cond.IsValid()
+	default:
+		return "", caos_errs.ThrowInvalidArgument(nil, "GRPC-Ag9ts", "Errors.Execution.Invalid")
+	}
+}
+
+func listExecutionsRequestToQuery(req *execution.ListExecutionsRequest) (*query.ExecutionSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToQuery(req.GetQuery())
+	queries, err := executionQueriesToQuery(req.GetQueries())
+	if err != nil {
+		return nil, err
+	}
+	return &query.ExecutionSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func executionQueriesToQuery(queries []*execution.ExecutionSearchQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = executionQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func executionQueryToQuery(q *execution.ExecutionSearchQuery) (query.SearchQuery, error) {
+	switch q := q.Query.(type) {
+	case *execution.ExecutionSearchQuery_InConditionsQuery:
+		ids := make([]string, len(q.InConditionsQuery.GetConditions()))
+		for i, condition := range q.InConditionsQuery.GetConditions() {
+			id, err := conditionToID(condition)
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = id
+		}
+		return query.NewExecutionInIDsSearchQuery(ids)
+	case *execution.ExecutionSearchQuery_TargetQuery:
+		return query.NewExecutionTargetSearchQuery(q.TargetQuery.GetTargetId())
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "GRPC-Dn8ws", "List.Query.Invalid")
+	}
+}
+
+func executionsToPb(executions []*query.Execution) []*execution.Execution {
+	e := make([]*execution.Execution, len(executions))
+	for i, exec := range executions {
+		e[i] = &execution.Execution{
+			ExecutionId: exec.ID,
+			Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+				Sequence:      exec.Sequence,
+				EventDate:     exec.ChangeDate,
+				ResourceOwner: exec.ResourceOwner,
+			}),
+			Targets: exec.Targets,
+		}
+	}
+	return e
+}
@@ -0,0 +1,169 @@
+package execution
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	execution "github.com/zitadel/zitadel/pkg/grpc/execution/v2alpha"
+)
+
+func (s *Server) CreateTarget(ctx context.Context, req *execution.CreateTargetRequest) (*execution.CreateTargetResponse, error) {
+	add := createTargetToCommand(req)
+	details, err := s.command.AddTarget(ctx, add, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &execution.CreateTargetResponse{
+		Id:         add.AggregateID,
+		Details:    object.DomainToDetailsPb(details),
+		SigningKey: add.SigningKey,
+	}, nil
+}
+
+func (s *Server) UpdateTarget(ctx context.Context, req *execution.UpdateTargetRequest) (*execution.UpdateTargetResponse, error) {
+	change := updateTargetToCommand(req)
+	details, err := s.command.ChangeTarget(ctx, change, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &execution.UpdateTargetResponse{
+		Details:    object.DomainToDetailsPb(details),
+		SigningKey: change.SigningKey,
+	}, nil
+}
+
+func (s *Server) DeleteTarget(ctx context.Context, req *execution.DeleteTargetRequest) (*execution.DeleteTargetResponse, error) {
+	details, err := s.command.DeleteTarget(ctx, req.GetTargetId(), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &execution.DeleteTargetResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListTargets(ctx context.Context, req *execution.ListTargetsRequest) (*execution.ListTargetsResponse, error) {
+	queries, err := listTargetsRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := s.query.SearchTargets(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &execution.ListTargetsResponse{
+		Details: object.ToListDetails(targets.SearchResponse),
+		Result:  targetsToPb(targets.Targets),
+	}, nil
+}
+
+func (s *Server) GetTargetByID(ctx context.Context, req *execution.GetTargetByIDRequest) (*execution.GetTargetByIDResponse, error) {
+	target, err := s.query.GetTargetByID(ctx, req.GetTargetId())
+	if err != nil {
+		return nil, err
+	}
+	return &execution.GetTargetByIDResponse{
+		Target: targetToPb(target),
+	}, nil
+}
+
+func createTargetToCommand(req *execution.CreateTargetRequest) *command.AddTarget {
+	return &command.AddTarget{
+		Name:             req.GetName(),
+		Endpoint:         req.GetEndpoint(),
+		Timeout:          req.GetTimeout().AsDuration(),
+		Async:            req.GetAsync(),
+		InterruptOnError: req.GetInterruptOnError(),
+	}
+}
+
+func updateTargetToCommand(req *execution.UpdateTargetRequest) *command.ChangeTarget {
+	change := &command.ChangeTarget{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.GetTargetId(),
+		},
+		Name:                 req.Name,
+		Endpoint:             req.Endpoint,
+		RegenerateSigningKey: req.GetRegenerateSigningKey(),
+	}
+	if req.GetTimeout() != nil {
+		timeout := req.GetTimeout().AsDuration()
+		change.Timeout = &timeout
+	}
+	if req.GetExecutionType() != nil {
+		async, interruptOnError := req.GetAsync(), req.GetInterruptOnError()
+		change.Async = &async
+		change.InterruptOnError = &interruptOnError
+	}
+	return change
+}
+
+func listTargetsRequestToQuery(req *execution.ListTargetsRequest) (*query.TargetSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToQuery(req.GetQuery())
+	queries, err := targetQueriesToQuery(req.GetQueries())
+	if err != nil {
+		return nil, err
+	}
+	return &query.TargetSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func targetQueriesToQuery(queries []*execution.TargetSearchQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = targetQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func targetQueryToQuery(q *execution.TargetSearchQuery) (query.SearchQuery, error) {
+	switch q := q.Query.(type) {
+	case *execution.TargetSearchQuery_TargetNameQuery:
+		return query.NewTargetNameSearchQuery(query.TextEquals, q.TargetNameQuery.GetTargetName())
+	case *execution.TargetSearchQuery_InTargetIdsQuery:
+		return query.NewTargetInIDsSearchQuery(q.InTargetIdsQuery.GetTargetIds())
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "GRPC-Cf2mp", "List.Query.Invalid")
+	}
+}
+
+func targetsToPb(targets []*query.Target) []*execution.Target {
+	t := make([]*execution.Target, len(targets))
+	for i, target := range targets {
+		t[i] = targetToPb(target)
+	}
+	return t
+}
+
+func targetToPb(t *query.Target) *execution.Target {
+	return &execution.Target{
+		TargetId: t.ID,
+		Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+			Sequence:      t.Sequence,
+			EventDate:     t.ChangeDate,
+			ResourceOwner: t.ResourceOwner,
+		}),
+		Name:             t.Name,
+		Endpoint:         t.Endpoint,
+		Timeout:          durationpb.New(t.Timeout),
+		Async:            t.Async,
+		InterruptOnError: t.InterruptOnError,
+	}
+}
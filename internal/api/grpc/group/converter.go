@@ -0,0 +1,105 @@
+package group
+
+import (
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	group_pb "github.com/zitadel/zitadel/pkg/grpc/group"
+)
+
+func GroupsToPb(groups []*query.Group) []*group_pb.Group {
+	list := make([]*group_pb.Group, len(groups))
+	for i, group := range groups {
+		list[i] = GroupToPb(group)
+	}
+	return list
+}
+
+func GroupToPb(group *query.Group) *group_pb.Group {
+	return &group_pb.Group{
+		Id:          group.ID,
+		Details:     object_grpc.ToViewDetailsPb(group.Sequence, group.CreationDate, group.ChangeDate, group.ResourceOwner),
+		State:       GroupStateToPb(group.State),
+		Name:        group.Name,
+		Description: group.Description,
+	}
+}
+
+func GroupStateToPb(state domain.GroupState) group_pb.GroupState {
+	switch state {
+	case domain.GroupStateActive:
+		return group_pb.GroupState_GROUP_STATE_ACTIVE
+	default:
+		return group_pb.GroupState_GROUP_STATE_UNSPECIFIED
+	}
+}
+
+func GroupQueriesToQuery(queries []*group_pb.GroupQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = GroupQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func GroupQueryToQuery(q *group_pb.GroupQuery) (query.SearchQuery, error) {
+	switch q := q.Query.(type) {
+	case *group_pb.GroupQuery_NameQuery:
+		return query.NewGroupNameSearchQuery(object_grpc.TextMethodToQuery(q.NameQuery.Method), q.NameQuery.Name)
+	case *group_pb.GroupQuery_IdsQuery:
+		return query.NewGroupIDsSearchQuery(q.IdsQuery.Ids)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "GRPC-Tz4qb", "List.Query.Invalid")
+	}
+}
+
+func GroupGrantsToPb(grants []*query.GroupGrant) []*group_pb.GroupGrant {
+	list := make([]*group_pb.GroupGrant, len(grants))
+	for i, grant := range grants {
+		list[i] = GroupGrantToPb(grant)
+	}
+	return list
+}
+
+func GroupGrantToPb(grant *query.GroupGrant) *group_pb.GroupGrant {
+	return &group_pb.GroupGrant{
+		Id:             grant.ID,
+		Details:        object_grpc.ToViewDetailsPb(grant.Sequence, grant.CreationDate, grant.ChangeDate, grant.ResourceOwner),
+		GroupId:        grant.GroupID,
+		GroupName:      grant.GroupName,
+		RoleKeys:       grant.Roles,
+		ProjectId:      grant.ProjectID,
+		ProjectGrantId: grant.GrantID,
+		ProjectName:    grant.ProjectName,
+		OrgName:        grant.OrgName,
+		OrgDomain:      grant.OrgPrimaryDomain,
+	}
+}
+
+func GroupGrantQueriesToQuery(queries []*group_pb.GroupGrantQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = GroupGrantQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func GroupGrantQueryToQuery(q *group_pb.GroupGrantQuery) (query.SearchQuery, error) {
+	switch q := q.Query.(type) {
+	case *group_pb.GroupGrantQuery_ProjectIdQuery:
+		return query.NewGroupGrantProjectIDSearchQuery(q.ProjectIdQuery.ProjectId)
+	case *group_pb.GroupGrantQuery_ProjectGrantIdQuery:
+		return query.NewGroupGrantGrantIDSearchQuery(q.ProjectGrantIdQuery.ProjectGrantId)
+	case *group_pb.GroupGrantQuery_RoleKeyQuery:
+		return query.NewGroupGrantRoleQuery(q.RoleKeyQuery.RoleKey)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "GRPC-Fb7kp", "List.Query.Invalid")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	iam_model "github.com/zitadel/zitadel/internal/iam/model"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	idp_pb "github.com/zitadel/zitadel/pkg/grpc/idp"
@@ -359,6 +360,19 @@ func azureADTenantTypeToCommand(tenantType idp_pb.AzureADTenantType) azuread.Ten
 	}
 }
 
+func SAMLBindingToCommand(binding idp_pb.SAMLBinding) domain.SAMLBinding {
+	switch binding {
+	case idp_pb.SAMLBinding_SAML_BINDING_POST:
+		return domain.SAMLBindingPost
+	case idp_pb.SAMLBinding_SAML_BINDING_REDIRECT:
+		return domain.SAMLBindingRedirect
+	case idp_pb.SAMLBinding_SAML_BINDING_UNSPECIFIED:
+		return domain.SAMLBindingUnspecified
+	default:
+		return domain.SAMLBindingUnspecified
+	}
+}
+
 func ProvidersToPb(providers []*query.IDPTemplate) []*idp_pb.Provider {
 	list := make([]*idp_pb.Provider, len(providers))
 	for i, provider := range providers {
@@ -414,6 +428,10 @@ func providerTypeToPb(idpType domain.IDPType) idp_pb.ProviderType {
 		return idp_pb.ProviderType_PROVIDER_TYPE_GITLAB_SELF_HOSTED
 	case domain.IDPTypeGoogle:
 		return idp_pb.ProviderType_PROVIDER_TYPE_GOOGLE
+	case domain.IDPTypeSAML:
+		return idp_pb.ProviderType_PROVIDER_TYPE_SAML
+	case domain.IDPTypeApple:
+		return idp_pb.ProviderType_PROVIDER_TYPE_APPLE
 	case domain.IDPTypeUnspecified:
 		return idp_pb.ProviderType_PROVIDER_TYPE_UNSPECIFIED
 	default:
@@ -470,6 +488,14 @@ func configToPb(config *query.IDPTemplate) *idp_pb.ProviderConfig {
 		ldapConfigToPb(providerConfig, config.LDAPIDPTemplate)
 		return providerConfig
 	}
+	if config.SAMLIDPTemplate != nil {
+		samlConfigToPb(providerConfig, config.SAMLIDPTemplate)
+		return providerConfig
+	}
+	if config.AppleIDPTemplate != nil {
+		appleConfigToPb(providerConfig, config.AppleIDPTemplate)
+		return providerConfig
+	}
 	return providerConfig
 }
 
@@ -583,6 +609,38 @@ func googleConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.Goo
 	}
 }
 
+func samlConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.SAMLIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Saml{
+		Saml: &idp_pb.SAMLConfig{
+			MetadataXml:       template.Metadata,
+			Binding:           samlBindingToPb(template.Binding),
+			WithSignedRequest: template.WithSignedRequest,
+		},
+	}
+}
+
+func samlBindingToPb(binding string) idp_pb.SAMLBinding {
+	switch binding {
+	case saml.PostBinding:
+		return idp_pb.SAMLBinding_SAML_BINDING_POST
+	case saml.RedirectBinding:
+		return idp_pb.SAMLBinding_SAML_BINDING_REDIRECT
+	default:
+		return idp_pb.SAMLBinding_SAML_BINDING_UNSPECIFIED
+	}
+}
+
+func appleConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.AppleIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Apple{
+		Apple: &idp_pb.AppleConfig{
+			ClientId: template.ClientID,
+			TeamId:   template.TeamID,
+			KeyId:    template.KeyID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
 func ldapConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.LDAPIDPTemplate) {
 	var timeout *durationpb.Duration
 	if template.Timeout != 0 {
@@ -2,7 +2,9 @@ package management
 
 import (
 	"context"
+	"time"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
@@ -24,6 +26,37 @@ func (s *Server) ListActions(ctx context.Context, req *mgmt_pb.ListActionsReques
 	}, nil
 }
 
+func (s *Server) ListActionExecutions(ctx context.Context, req *mgmt_pb.ListActionExecutionsRequest) (*mgmt_pb.ListActionExecutionsResponse, error) {
+	query, err := listActionExecutionsToQuery(authz.GetCtxData(ctx).OrgID, req)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := s.query.SearchActionExecutions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionExecutionsResponse{
+		Details: obj_grpc.ToListDetails(executions.Count, 0, time.Time{}),
+		Result:  action_grpc.ActionExecutionsToPb(executions.Executions),
+	}, nil
+}
+
+func (s *Server) DryRunAction(ctx context.Context, req *mgmt_pb.DryRunActionRequest) (*mgmt_pb.DryRunActionResponse, error) {
+	result, err := actions.DryRun(
+		ctx,
+		req.Script,
+		req.Name,
+		action_grpc.FlowTypeToDomain(req.FlowType),
+		action_grpc.TriggerTypeToDomain(req.TriggerType),
+		dryRunActionRequestToFixture(req),
+		req.Timeout.AsDuration(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return dryRunResultToPb(result)
+}
+
 func (s *Server) GetAction(ctx context.Context, req *mgmt_pb.GetActionRequest) (*mgmt_pb.GetActionResponse, error) {
 	action, err := s.query.GetActionByID(ctx, req.Id, authz.GetCtxData(ctx).OrgID, false)
 	if err != nil {
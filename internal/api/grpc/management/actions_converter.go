@@ -1,6 +1,9 @@
 package management
 
 import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/actions"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -65,3 +68,68 @@ func ActionQueryToQuery(query interface{}) (query.SearchQuery, error) {
 	}
 	return nil, errors.ThrowInvalidArgument(nil, "MGMT-dsg3z", "Errors.Query.InvalidRequest")
 }
+
+func listActionExecutionsToQuery(orgID string, req *mgmt_pb.ListActionExecutionsRequest) (_ *query.ActionExecutionSearchQueries, err error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries := make([]query.SearchQuery, len(req.Queries)+1)
+	queries[0], err = query.NewActionExecutionResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	for i, executionQuery := range req.Queries {
+		queries[i+1], err = actionExecutionQueryToQuery(executionQuery.Query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &query.ActionExecutionSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.ActionExecutionColumnLogDate,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func actionExecutionQueryToQuery(query interface{}) (query.SearchQuery, error) {
+	switch q := query.(type) {
+	case *mgmt_pb.ActionExecutionQuery_ActionIdQuery:
+		return action_grpc.ActionExecutionActionIDQuery(q.ActionIdQuery)
+	case *mgmt_pb.ActionExecutionQuery_FlowTypeQuery:
+		return action_grpc.ActionExecutionFlowTypeQuery(q.FlowTypeQuery)
+	case *mgmt_pb.ActionExecutionQuery_TriggerTypeQuery:
+		return action_grpc.ActionExecutionTriggerTypeQuery(q.TriggerTypeQuery)
+	case *mgmt_pb.ActionExecutionQuery_UserIdQuery:
+		return action_grpc.ActionExecutionUserIDQuery(q.UserIdQuery)
+	case *mgmt_pb.ActionExecutionQuery_ResultQuery:
+		return action_grpc.ActionExecutionResultQuery(q.ResultQuery)
+	}
+	return nil, errors.ThrowInvalidArgument(nil, "MGMT-Ahm3e", "Errors.Query.InvalidRequest")
+}
+
+func dryRunActionRequestToFixture(req *mgmt_pb.DryRunActionRequest) *actions.DryRunFixture {
+	return &actions.DryRunFixture{
+		Ctx:       req.GetCtx().AsMap(),
+		API:       req.GetApi().AsMap(),
+		HTTPMocks: action_grpc.HTTPMocksToDomain(req.HttpMocks),
+	}
+}
+
+func dryRunResultToPb(result *actions.DryRunResult) (*mgmt_pb.DryRunActionResponse, error) {
+	mutations, err := action_grpc.MutationsToPb(result.Mutations)
+	if err != nil {
+		return nil, err
+	}
+	res := &mgmt_pb.DryRunActionResponse{
+		Mutations: mutations,
+		Logs:      action_grpc.ActionExecutionLogsToPb(result.Logs),
+		Succeeded: result.Err == nil,
+		Took:      durationpb.New(result.Took),
+	}
+	if result.Err != nil {
+		res.Error = result.Err.Error()
+	}
+	return res, nil
+}
@@ -0,0 +1,113 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/group"
+	member_grpc "github.com/zitadel/zitadel/internal/api/grpc/member"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func listGroupsRequestToQuery(orgID string, req *mgmt_pb.ListGroupsRequest) (*query.GroupSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := group.GroupQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewGroupResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.GroupSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, ownerQuery),
+	}, nil
+}
+
+func addGroupRequestToCommand(req *mgmt_pb.AddGroupRequest) *command.AddGroup {
+	return &command.AddGroup{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+func updateGroupRequestToCommand(req *mgmt_pb.UpdateGroupRequest) *command.ChangeGroup {
+	return &command.ChangeGroup{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.Id,
+		},
+		Name:        &req.Name,
+		Description: &req.Description,
+	}
+}
+
+func listGroupMembersRequestToQuery(req *mgmt_pb.ListGroupMembersRequest) (*query.GroupMembersQuery, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := member_grpc.MemberQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.GroupMembersQuery{
+		MembersQuery: query.MembersQuery{
+			SearchRequest: query.SearchRequest{
+				Offset: offset,
+				Limit:  limit,
+				Asc:    asc,
+			},
+			Queries: queries,
+		},
+		GroupID: req.GroupId,
+	}, nil
+}
+
+func listGroupGrantsRequestToQuery(orgID string, req *mgmt_pb.ListGroupGrantsRequest) (*query.GroupGrantSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := group.GroupGrantQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	groupQuery, err := query.NewGroupGrantGroupIDSearchQuery(req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewGroupGrantResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.GroupGrantSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, groupQuery, ownerQuery),
+	}, nil
+}
+
+func addGroupGrantRequestToDomain(req *mgmt_pb.AddGroupGrantRequest) *domain.GroupGrant {
+	return &domain.GroupGrant{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.GroupId,
+		},
+		ProjectID:      req.ProjectId,
+		ProjectGrantID: req.ProjectGrantId,
+		RoleKeys:       req.RoleKeys,
+	}
+}
+
+func updateGroupGrantRequestToDomain(req *mgmt_pb.UpdateGroupGrantRequest) *domain.GroupGrant {
+	return &domain.GroupGrant{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.GroupId,
+		},
+		GrantID:  req.GrantId,
+		RoleKeys: req.RoleKeys,
+	}
+}
@@ -8,11 +8,19 @@ import (
 func AddLockoutPolicyToDomain(p *mgmt.AddCustomLockoutPolicyRequest) *domain.LockoutPolicy {
 	return &domain.LockoutPolicy{
 		MaxPasswordAttempts: uint64(p.MaxPasswordAttempts),
+		MaxOTPAttempts:      uint64(p.MaxOtpAttempts),
+		LockoutDuration:     p.LockoutDuration.AsDuration(),
+		AttemptResetWindow:  p.AttemptResetWindow.AsDuration(),
+		ProgressiveLockout:  p.ProgressiveLockout,
 	}
 }
 
 func UpdateLockoutPolicyToDomain(p *mgmt.UpdateCustomLockoutPolicyRequest) *domain.LockoutPolicy {
 	return &domain.LockoutPolicy{
 		MaxPasswordAttempts: uint64(p.MaxPasswordAttempts),
+		MaxOTPAttempts:      uint64(p.MaxOtpAttempts),
+		LockoutDuration:     p.LockoutDuration.AsDuration(),
+		AttemptResetWindow:  p.AttemptResetWindow.AsDuration(),
+		ProgressiveLockout:  p.ProgressiveLockout,
 	}
 }
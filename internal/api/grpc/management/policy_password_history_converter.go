@@ -0,0 +1,18 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func AddPasswordHistoryPolicyToDomain(policy *mgmt_pb.AddCustomPasswordHistoryPolicyRequest) *domain.PasswordHistoryPolicy {
+	return &domain.PasswordHistoryPolicy{
+		HistoryCount: uint64(policy.HistoryCount),
+	}
+}
+
+func UpdatePasswordHistoryPolicyToDomain(policy *mgmt_pb.UpdateCustomPasswordHistoryPolicyRequest) *domain.PasswordHistoryPolicy {
+	return &domain.PasswordHistoryPolicy{
+		HistoryCount: uint64(policy.HistoryCount),
+	}
+}
@@ -0,0 +1,32 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/command"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetTokenExchangePolicy(ctx context.Context, req *mgmt_pb.GetTokenExchangePolicyRequest) (*mgmt_pb.GetTokenExchangePolicyResponse, error) {
+	policy, err := s.query.TokenExchangePolicyByOrg(ctx, true, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetTokenExchangePolicyResponse{Policy: policy_grpc.ModelTokenExchangePolicyToPb(policy)}, nil
+}
+
+func (s *Server) SetTokenExchangePolicy(ctx context.Context, req *mgmt_pb.SetTokenExchangePolicyRequest) (*mgmt_pb.SetTokenExchangePolicyResponse, error) {
+	details, err := s.command.SetOrgTokenExchangePolicy(ctx, authz.GetCtxData(ctx).OrgID, &command.OrgTokenExchangePolicy{
+		DisableDelegation:    req.GetDisableDelegation(),
+		DisableImpersonation: req.GetDisableImpersonation(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetTokenExchangePolicyResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,20 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelTokenExchangePolicyToPb(policy *query.TokenExchangePolicy) *policy_pb.TokenExchangePolicy {
+	return &policy_pb.TokenExchangePolicy{
+		DisableDelegation:    policy.DisableDelegation,
+		DisableImpersonation: policy.DisableImpersonation,
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ID,
+		),
+	}
+}
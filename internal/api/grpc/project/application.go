@@ -42,25 +42,28 @@ func AppConfigToPb(app *query.App) app_pb.AppConfig {
 func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 	return &app_pb.App_OidcConfig{
 		OidcConfig: &app_pb.OIDCConfig{
-			RedirectUris:             app.RedirectURIs,
-			ResponseTypes:            OIDCResponseTypesFromModel(app.ResponseTypes),
-			GrantTypes:               OIDCGrantTypesFromModel(app.GrantTypes),
-			AppType:                  OIDCApplicationTypeToPb(app.AppType),
-			ClientId:                 app.ClientID,
-			AuthMethodType:           OIDCAuthMethodTypeToPb(app.AuthMethodType),
-			PostLogoutRedirectUris:   app.PostLogoutRedirectURIs,
-			Version:                  OIDCVersionToPb(domain.OIDCVersion(app.Version)),
-			NoneCompliant:            len(app.ComplianceProblems) != 0,
-			ComplianceProblems:       ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
-			DevMode:                  app.IsDevMode,
-			AccessTokenType:          oidcTokenTypeToPb(app.AccessTokenType),
-			AccessTokenRoleAssertion: app.AssertAccessTokenRole,
-			IdTokenRoleAssertion:     app.AssertIDTokenRole,
-			IdTokenUserinfoAssertion: app.AssertIDTokenUserinfo,
-			ClockSkew:                durationpb.New(app.ClockSkew),
-			AdditionalOrigins:        app.AdditionalOrigins,
-			AllowedOrigins:           app.AllowedOrigins,
-			SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+			RedirectUris:              app.RedirectURIs,
+			ResponseTypes:             OIDCResponseTypesFromModel(app.ResponseTypes),
+			GrantTypes:                OIDCGrantTypesFromModel(app.GrantTypes),
+			AppType:                   OIDCApplicationTypeToPb(app.AppType),
+			ClientId:                  app.ClientID,
+			AuthMethodType:            OIDCAuthMethodTypeToPb(app.AuthMethodType),
+			PostLogoutRedirectUris:    app.PostLogoutRedirectURIs,
+			Version:                   OIDCVersionToPb(domain.OIDCVersion(app.Version)),
+			NoneCompliant:             len(app.ComplianceProblems) != 0,
+			ComplianceProblems:        ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
+			DevMode:                   app.IsDevMode,
+			AccessTokenType:           oidcTokenTypeToPb(app.AccessTokenType),
+			AccessTokenRoleAssertion:  app.AssertAccessTokenRole,
+			IdTokenRoleAssertion:      app.AssertIDTokenRole,
+			IdTokenUserinfoAssertion:  app.AssertIDTokenUserinfo,
+			ClockSkew:                 durationpb.New(app.ClockSkew),
+			AdditionalOrigins:         app.AdditionalOrigins,
+			AllowedOrigins:            app.AllowedOrigins,
+			SkipNativeAppSuccessPage:  app.SkipNativeAppSuccessPage,
+			BackChannelLogoutUri:      app.BackChannelLogoutURI,
+			RequirePushedAuthRequests: app.RequirePushedAuthRequests,
+			RequireDpop:               app.RequireDPoP,
 		},
 	}
 }
@@ -68,7 +71,9 @@ func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 func AppSAMLConfigToPb(app *query.SAMLApp) app_pb.AppConfig {
 	return &app_pb.App_SamlConfig{
 		SamlConfig: &app_pb.SAMLConfig{
-			Metadata: &app_pb.SAMLConfig_MetadataXml{MetadataXml: app.Metadata},
+			Metadata:          &app_pb.SAMLConfig_MetadataXml{MetadataXml: app.Metadata},
+			RoleAssertion:     app.RoleAssertion,
+			MetadataAssertion: app.MetadataAssertion,
 		},
 	}
 }
@@ -138,6 +143,8 @@ func OIDCGrantTypesFromModel(grantTypes []domain.OIDCGrantType) []app_pb.OIDCGra
 			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_REFRESH_TOKEN
 		case domain.OIDCGrantTypeDeviceCode:
 			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_DEVICE_CODE
+		case domain.OIDCGrantTypeTokenExchange:
+			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_TOKEN_EXCHANGE
 		}
 	}
 	return oidcGrantTypes
@@ -158,6 +165,8 @@ func OIDCGrantTypesToDomain(grantTypes []app_pb.OIDCGrantType) []domain.OIDCGran
 			oidcGrantTypes[i] = domain.OIDCGrantTypeRefreshToken
 		case app_pb.OIDCGrantType_OIDC_GRANT_TYPE_DEVICE_CODE:
 			oidcGrantTypes[i] = domain.OIDCGrantTypeDeviceCode
+		case app_pb.OIDCGrantType_OIDC_GRANT_TYPE_TOKEN_EXCHANGE:
+			oidcGrantTypes[i] = domain.OIDCGrantTypeTokenExchange
 		}
 	}
 	return oidcGrantTypes
@@ -27,6 +27,7 @@ const (
 var (
 	customHeaders = []string{
 		"x-zitadel-",
+		"dpop",
 	}
 	jsonMarshaler = &runtime.JSONPb{
 		UnmarshalOptions: protojson.UnmarshalOptions{
@@ -182,6 +183,8 @@ func http1Host(next http.Handler, http1HostName string) http.Handler {
 			return
 		}
 		r.Header.Set(middleware.HTTP1Host, host)
+		r.Header.Set(middleware.HTTP1Method, r.Method)
+		r.Header.Set(middleware.HTTP1Path, r.URL.Path)
 		next.ServeHTTP(w, r)
 	})
 }
@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -33,6 +34,7 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	if authToken == "" {
 		return nil, status.Error(codes.Unauthenticated, "auth header missing")
 	}
+	authCtx = authz.WithDPoPProof(authCtx, dpopProof(authCtx, info.FullMethod))
 
 	var orgDomain string
 	orgID := grpc_util.GetHeader(authCtx, http.ZitadelOrgID)
@@ -49,6 +51,28 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	return handler(ctxSetter(ctx), req)
 }
 
+// dpopProof returns the DPoP proof of the call together with the method and path it was sent to.
+// Calls through the gRPC gateway are proven for the original HTTP/1 request,
+// all others for a POST to the full gRPC method.
+func dpopProof(ctx context.Context, fullMethod string) *authz.DPoPProof {
+	proof := &authz.DPoPProof{
+		Proof:  grpc_util.GetHeader(ctx, http.DPoP),
+		Method: "POST",
+		Path:   fullMethod,
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || !isAllowedToSendHTTP1Header(md) {
+		return proof
+	}
+	if method := md.Get(HTTP1Method); len(method) == 1 {
+		proof.Method = method[0]
+	}
+	if path := md.Get(HTTP1Path); len(path) == 1 {
+		proof.Path = path[0]
+	}
+	return proof
+}
+
 type OrganisationFromRequest interface {
 	OrganisationFromRequest() *object.Organisation
 }
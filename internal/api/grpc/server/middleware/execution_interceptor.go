@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ExecutionHandler calls the targets of the request executions before and the targets of the response executions after the handler.
+// As it runs after the validation and authorization, a request changed by a target is validated and authorized again.
+func ExecutionHandler(queries actions.ExecutionQueries, verifier *authz.TokenVerifier, ignoreService ...string) grpc.UnaryServerInterceptor {
+	prunedIgnoredServices := make([]string, len(ignoreService))
+	for idx, service := range ignoreService {
+		if !strings.HasPrefix(service, "/") {
+			service = "/" + service
+		}
+		prunedIgnoredServices[idx] = service
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+		for _, service := range prunedIgnoredServices {
+			if strings.HasPrefix(info.FullMethod, service) {
+				return handler(ctx, req)
+			}
+		}
+		if authz.GetInstance(ctx).InstanceID() == "" {
+			return handler(ctx, req)
+		}
+
+		changedReq, err := callExecutionTargets(ctx, queries, domain.ExecutionTypeRequest, info.FullMethod, req, nil)
+		if err != nil {
+			return nil, err
+		}
+		if changedReq != req {
+			if err = checkChangedRequest(ctx, verifier, info.FullMethod, req, changedReq); err != nil {
+				return nil, err
+			}
+			req = changedReq
+		}
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return callExecutionTargets(ctx, queries, domain.ExecutionTypeResponse, info.FullMethod, req, resp)
+	}
+}
+
+// checkChangedRequest validates the request changed by a target and checks the permissions of the user for it.
+// The organisation of the request must not be changed, as the permissions were resolved for the original one.
+func checkChangedRequest(ctx context.Context, verifier *authz.TokenVerifier, fullMethod string, req, changedReq interface{}) error {
+	if validate, ok := changedReq.(validator); ok {
+		if err := validate.Validate(); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	authOpt, needsToken := verifier.CheckAuthMethod(fullMethod)
+	if !needsToken {
+		return nil
+	}
+	if o, ok := req.(OrganisationFromRequest); ok {
+		org := o.OrganisationFromRequest()
+		changedOrg := changedReq.(OrganisationFromRequest).OrganisationFromRequest()
+		if org.GetOrgId() != changedOrg.GetOrgId() || org.GetOrgDomain() != changedOrg.GetOrgDomain() {
+			return errors.ThrowPermissionDenied(nil, "MIDDL-Qs5nf", "Errors.Execution.OrganisationChanged")
+		}
+	}
+	return authz.CheckRequestPermissions(ctx, changedReq, authOpt)
+}
+
+// ContextInfo is sent to the targets of request and response executions.
+// A target can change the request (of a request execution) or the response (of a response execution)
+// by returning the changed message in the respective field of the same structure,
+// signed with the signing key of the target in the [actions.SigningHeader].
+type ContextInfo struct {
+	FullMethod string          `json:"fullMethod"`
+	InstanceID string          `json:"instanceID"`
+	OrgID      string          `json:"orgID"`
+	ProjectID  string          `json:"projectID"`
+	UserID     string          `json:"userID"`
+	Request    json.RawMessage `json:"request"`
+	Response   json.RawMessage `json:"response,omitempty"`
+}
+
+// callExecutionTargets calls the targets of the execution and returns the request (for request executions)
+// or the response (for response executions), changed by the targets if they returned a new message
+func callExecutionTargets(ctx context.Context, queries actions.ExecutionQueries, executionType domain.ExecutionType, fullMethod string, req, resp interface{}) (_ interface{}, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	message := req
+	if executionType == domain.ExecutionTypeResponse {
+		message = resp
+	}
+	targets, err := queries.ExecutionTargetsByIDs(ctx, domain.ExecutionIDsForMethod(executionType, fullMethod))
+	if err != nil || len(targets) == 0 {
+		return message, err
+	}
+
+	ctxData := authz.GetCtxData(ctx)
+	info := &ContextInfo{
+		FullMethod: fullMethod,
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+		OrgID:      ctxData.OrgID,
+		ProjectID:  ctxData.ProjectID,
+		UserID:     ctxData.UserID,
+	}
+	if info.Request, err = marshalMessage(req); err != nil {
+		return nil, err
+	}
+	if resp != nil {
+		if info.Response, err = marshalMessage(resp); err != nil {
+			return nil, err
+		}
+	}
+	changed := false
+	err = actions.CallTargetsWithResponse(ctx, targets, info, func(body []byte) error {
+		targetInfo := new(ContextInfo)
+		if err := json.Unmarshal(body, targetInfo); err != nil {
+			return errors.ThrowPreconditionFailed(err, "MIDDL-Hd8wq", "Errors.Execution.InvalidResponse")
+		}
+		switch {
+		case executionType == domain.ExecutionTypeRequest && len(targetInfo.Request) > 0:
+			info.Request = targetInfo.Request
+		case executionType == domain.ExecutionTypeResponse && len(targetInfo.Response) > 0:
+			info.Response = targetInfo.Response
+		default:
+			return nil
+		}
+		changed = true
+		return nil
+	})
+	if err != nil || !changed {
+		return message, err
+	}
+	if executionType == domain.ExecutionTypeResponse {
+		return unmarshalMessage(info.Response, message)
+	}
+	return unmarshalMessage(info.Request, message)
+}
+
+func marshalMessage(message interface{}) (json.RawMessage, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if protoMessage, ok := message.(proto.Message); ok {
+		data, err = protojson.Marshal(protoMessage)
+	} else {
+		data, err = json.Marshal(message)
+	}
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "MIDDL-Ra2ov", "Errors.Internal")
+	}
+	return data, nil
+}
+
+// unmarshalMessage returns a new message of the same type as the provided one, filled with the data returned by a target
+func unmarshalMessage(data json.RawMessage, message interface{}) (interface{}, error) {
+	if protoMessage, ok := message.(proto.Message); ok {
+		changed := protoMessage.ProtoReflect().New().Interface()
+		if err := protojson.Unmarshal(data, changed); err != nil {
+			return nil, errors.ThrowPreconditionFailed(err, "MIDDL-Zu1xc", "Errors.Execution.InvalidResponse")
+		}
+		return changed, nil
+	}
+	messageType := reflect.TypeOf(message)
+	if messageType == nil || messageType.Kind() != reflect.Pointer {
+		return nil, errors.ThrowInternal(nil, "MIDDL-Gq4pe", "Errors.Internal")
+	}
+	changed := reflect.New(messageType.Elem()).Interface()
+	if err := json.Unmarshal(data, changed); err != nil {
+		return nil, errors.ThrowPreconditionFailed(err, "MIDDL-Jr7sn", "Errors.Execution.InvalidResponse")
+	}
+	return changed, nil
+}
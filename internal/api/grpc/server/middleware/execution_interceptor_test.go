@@ -0,0 +1,324 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockExecutionQueries struct {
+	targets map[string][]*query.ExecutionTarget
+}
+
+func (m *mockExecutionQueries) ExecutionTargetsByIDs(_ context.Context, ids []string) ([]*query.ExecutionTarget, error) {
+	for _, id := range ids {
+		if targets, ok := m.targets[id]; ok {
+			return targets, nil
+		}
+	}
+	return nil, nil
+}
+
+type mockExecutionReq struct {
+	Name string `json:"name"`
+}
+
+type mockValidatedExecutionReq struct {
+	Name string `json:"name"`
+}
+
+func (r *mockValidatedExecutionReq) Validate() error {
+	if r.Name == "" {
+		return errors.New("name missing")
+	}
+	return nil
+}
+
+func Test_ExecutionHandler(t *testing.T) {
+	type res struct {
+		want      interface{}
+		wantErr   bool
+		wantCalls []string
+	}
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		unsigned    bool
+		req         interface{}
+		targets     map[string][]*query.ExecutionTarget
+		method      string
+		authMethods authz.MethodMapping
+		permissions []string
+		instance    authz.Instance
+		handler     func(context.Context, interface{}) (interface{}, error)
+		res         res
+	}{
+		{
+			name:     "no executions",
+			status:   http.StatusOK,
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  emptyMockHandler,
+			res: res{
+				want: &mockReq{},
+			},
+		},
+		{
+			name:   "no instance",
+			status: http.StatusOK,
+			targets: map[string][]*query.ExecutionTarget{
+				"request": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.system.v1.SystemService/ListInstances",
+			instance: nil,
+			handler:  emptyMockHandler,
+			res: res{
+				want: &mockReq{},
+			},
+		},
+		{
+			name:   "request and response",
+			status: http.StatusOK,
+			targets: map[string][]*query.ExecutionTarget{
+				"request/zitadel.session.v2alpha.SessionService":                {{Timeout: time.Second, InterruptOnError: true}},
+				"response/zitadel.session.v2alpha.SessionService/CreateSession": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  emptyMockHandler,
+			res: res{
+				want:      &mockReq{},
+				wantCalls: []string{"", "{}"},
+			},
+		},
+		{
+			name:   "request changed by target",
+			status: http.StatusOK,
+			body:   `{"request":{"name":"changed"}}`,
+			req:    &mockExecutionReq{Name: "name"},
+			targets: map[string][]*query.ExecutionTarget{
+				"request": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  emptyMockHandler,
+			res: res{
+				want:      &mockExecutionReq{Name: "changed"},
+				wantCalls: []string{""},
+			},
+		},
+		{
+			name:     "unsigned response, interrupted",
+			status:   http.StatusOK,
+			body:     `{"request":{"name":"changed"}}`,
+			unsigned: true,
+			req:      &mockExecutionReq{Name: "name"},
+			targets: map[string][]*query.ExecutionTarget{
+				"request": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  emptyMockHandler,
+			res: res{
+				wantErr:   true,
+				wantCalls: []string{""},
+			},
+		},
+		{
+			name:   "changed request invalid",
+			status: http.StatusOK,
+			body:   `{"request":{"name":""}}`,
+			req:    &mockValidatedExecutionReq{Name: "name"},
+			targets: map[string][]*query.ExecutionTarget{
+				"request": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  emptyMockHandler,
+			res: res{
+				wantErr:   true,
+				wantCalls: []string{""},
+			},
+		},
+		{
+			name:   "changed request permitted",
+			status: http.StatusOK,
+			body:   `{"request":{"name":"changed"}}`,
+			req:    &mockExecutionReq{Name: "name"},
+			targets: map[string][]*query.ExecutionTarget{
+				"request": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method: "/zitadel.session.v2alpha.SessionService/CreateSession",
+			authMethods: authz.MethodMapping{
+				"/zitadel.session.v2alpha.SessionService/CreateSession": authz.Option{Permission: "session.write", CheckParam: "Name"},
+			},
+			permissions: []string{"session.write:name", "session.write:changed"},
+			instance:    &mockInstance{},
+			handler:     emptyMockHandler,
+			res: res{
+				want:      &mockExecutionReq{Name: "changed"},
+				wantCalls: []string{""},
+			},
+		},
+		{
+			name:   "changed request not permitted",
+			status: http.StatusOK,
+			body:   `{"request":{"name":"changed"}}`,
+			req:    &mockExecutionReq{Name: "name"},
+			targets: map[string][]*query.ExecutionTarget{
+				"request": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method: "/zitadel.session.v2alpha.SessionService/CreateSession",
+			authMethods: authz.MethodMapping{
+				"/zitadel.session.v2alpha.SessionService/CreateSession": authz.Option{Permission: "session.write", CheckParam: "Name"},
+			},
+			permissions: []string{"session.write:name"},
+			instance:    &mockInstance{},
+			handler:     emptyMockHandler,
+			res: res{
+				wantErr:   true,
+				wantCalls: []string{""},
+			},
+		},
+		{
+			name:   "response changed by target",
+			status: http.StatusOK,
+			body:   `{"response":{"name":"changed"}}`,
+			req:    &mockExecutionReq{Name: "name"},
+			targets: map[string][]*query.ExecutionTarget{
+				"response": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  emptyMockHandler,
+			res: res{
+				want:      &mockExecutionReq{Name: "changed"},
+				wantCalls: []string{`{"name":"name"}`},
+			},
+		},
+		{
+			name:   "response of other execution type ignored",
+			status: http.StatusOK,
+			body:   `{"response":{"name":"changed"}}`,
+			req:    &mockExecutionReq{Name: "name"},
+			targets: map[string][]*query.ExecutionTarget{
+				"request": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  emptyMockHandler,
+			res: res{
+				want:      &mockExecutionReq{Name: "name"},
+				wantCalls: []string{""},
+			},
+		},
+		{
+			name:   "invalid response, interrupted",
+			status: http.StatusOK,
+			body:   `{"request":{"name":1}}`,
+			req:    &mockExecutionReq{Name: "name"},
+			targets: map[string][]*query.ExecutionTarget{
+				"request": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  emptyMockHandler,
+			res: res{
+				wantErr:   true,
+				wantCalls: []string{""},
+			},
+		},
+		{
+			name:   "request interrupted",
+			status: http.StatusInternalServerError,
+			targets: map[string][]*query.ExecutionTarget{
+				"request": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  emptyMockHandler,
+			res: res{
+				wantErr:   true,
+				wantCalls: []string{""},
+			},
+		},
+		{
+			name:   "handler error, no response call",
+			status: http.StatusOK,
+			targets: map[string][]*query.ExecutionTarget{
+				"response": {{Timeout: time.Second, InterruptOnError: true}},
+			},
+			method:   "/zitadel.session.v2alpha.SessionService/CreateSession",
+			instance: &mockInstance{},
+			handler:  errorMockHandler,
+			res: res{
+				wantErr: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := make(chan string, 2)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				info := new(ContextInfo)
+				if err := json.Unmarshal(body, info); err != nil {
+					calls <- err.Error()
+				} else {
+					calls <- string(info.Response)
+				}
+				if !tt.unsigned {
+					w.Header().Set(actions.SigningHeader, actions.ComputeSignatureHeader(time.Now(), []byte(tt.body), ""))
+				}
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+			for _, targets := range tt.targets {
+				for _, target := range targets {
+					target.Endpoint = server.URL
+				}
+			}
+
+			ctx := context.Background()
+			if tt.permissions != nil {
+				ctx = authz.NewMockContextWithPermissions("", "", "", tt.permissions)
+			}
+			if tt.instance != nil {
+				ctx = authz.WithInstance(ctx, tt.instance)
+			}
+			req := tt.req
+			if req == nil {
+				req = &mockReq{}
+			}
+			verifier := authz.Start(&verifierMock{}, "", nil)
+			verifier.RegisterServer("test", "test", tt.authMethods)
+			got, err := ExecutionHandler(&mockExecutionQueries{targets: tt.targets}, verifier)(ctx, req, mockInfo(tt.method), tt.handler)
+			if tt.res.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.res.want, got)
+			}
+			close(calls)
+			gotCalls := make([]string, 0, 2)
+			for call := range calls {
+				gotCalls = append(gotCalls, call)
+			}
+			assert.ElementsMatch(t, tt.res.wantCalls, gotCalls)
+		})
+	}
+}
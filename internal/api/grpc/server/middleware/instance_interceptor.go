@@ -20,7 +20,9 @@ import (
 )
 
 const (
-	HTTP1Host = "x-zitadel-http1-host"
+	HTTP1Host   = "x-zitadel-http1-host"
+	HTTP1Method = "x-zitadel-http1-method"
+	HTTP1Path   = "x-zitadel-http1-path"
 )
 
 func InstanceInterceptor(verifier authz.InstanceVerifier, headerName string, explicitInstanceIdServices ...string) grpc.UnaryServerInterceptor {
@@ -9,10 +9,13 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
-func QuotaExhaustedInterceptor(svc *logstore.Service, ignoreService ...string) grpc.UnaryServerInterceptor {
+// QuotaExhaustedInterceptor rejects requests if a quota of the request is exhausted,
+// in the degraded mode of a soft quota, only requests to methods prefixed by one of the degradedModeAllowList are served
+func QuotaExhaustedInterceptor(svc *logstore.Service, degradedModeAllowList []string, ignoreService ...string) grpc.UnaryServerInterceptor {
 
 	prunedIgnoredServices := make([]string, len(ignoreService))
 	for idx, service := range ignoreService {
@@ -36,11 +39,26 @@ func QuotaExhaustedInterceptor(svc *logstore.Service, ignoreService ...string) g
 		}
 
 		instance := authz.GetInstance(ctx)
-		remaining := svc.Limit(interceptorCtx, instance.InstanceID())
-		if remaining != nil && *remaining == 0 {
+		for _, unit := range access.QuotaUnits(info.FullMethod) {
+			remaining, degraded := svc.LimitDegraded(interceptorCtx, instance.InstanceID(), unit)
+			if remaining == nil || *remaining > 0 {
+				continue
+			}
+			if degraded && hasAnyPrefix(info.FullMethod, degradedModeAllowList) {
+				continue
+			}
 			return nil, errors.ThrowResourceExhausted(nil, "QUOTA-vjAy8", "Quota.Access.Exhausted")
 		}
 		span.End()
 		return handler(ctx, req)
 	}
 }
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
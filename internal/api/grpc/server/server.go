@@ -40,6 +40,7 @@ func CreateServer(
 	hostHeaderName string,
 	tlsConfig *tls.Config,
 	accessSvc *logstore.Service,
+	degradedModeAllowList []string,
 ) *grpc.Server {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeTotalCount, metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode}
 	serverOptions := []grpc.ServerOption{
@@ -56,7 +57,8 @@ func CreateServer(
 				middleware.TranslationHandler(),
 				middleware.ValidationHandler(),
 				middleware.ServiceHandler(),
-				middleware.QuotaExhaustedInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName),
+				middleware.QuotaExhaustedInterceptor(accessSvc, degradedModeAllowList, system_pb.SystemService_ServiceDesc.ServiceName),
+				middleware.ExecutionHandler(queries, verifier, system_pb.SystemService_ServiceDesc.ServiceName),
 			),
 		),
 	}
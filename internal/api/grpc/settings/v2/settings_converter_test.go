@@ -236,6 +236,25 @@ func Test_passwordSettingsToPb(t *testing.T) {
 	}
 }
 
+func Test_passwordExpirySettingsToPb(t *testing.T) {
+	arg := &query.PasswordAgePolicy{
+		MaxAgeDays:     90,
+		ExpireWarnDays: 10,
+		IsDefault:      true,
+	}
+	want := &settings.PasswordExpirySettings{
+		MaxAgeDays:        90,
+		ExpireWarnDays:    10,
+		ResourceOwnerType: settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE,
+	}
+
+	got := passwordExpirySettingsToPb(arg)
+	grpc.AllFieldsSet(t, got.ProtoReflect(), ignoreTypes...)
+	if !proto.Equal(got, want) {
+		t.Errorf("passwordExpirySettingsToPb() =\n%v\nwant\n%v", got, want)
+	}
+}
+
 func Test_brandingSettingsToPb(t *testing.T) {
 	arg := &query.LabelPolicy{
 		Light: query.Theme{
@@ -335,10 +354,18 @@ func Test_legalSettingsToPb(t *testing.T) {
 func Test_lockoutSettingsToPb(t *testing.T) {
 	arg := &query.LockoutPolicy{
 		MaxPasswordAttempts: 22,
+		MaxOTPAttempts:      5,
+		LockoutDuration:     time.Hour,
+		AttemptResetWindow:  time.Minute,
+		ProgressiveLockout:  true,
 		IsDefault:           true,
 	}
 	want := &settings.LockoutSettings{
 		MaxPasswordAttempts: 22,
+		MaxOtpAttempts:      5,
+		LockoutDuration:     durationpb.New(time.Hour),
+		AttemptResetWindow:  durationpb.New(time.Minute),
+		ProgressiveLockout:  true,
 		ResourceOwnerType:   settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE,
 	}
 	got := lockoutSettingsToPb(arg)
@@ -435,6 +462,14 @@ func Test_idpTypeToPb(t *testing.T) {
 			args: args{domain.IDPTypeGoogle},
 			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_GOOGLE,
 		},
+		{
+			args: args{domain.IDPTypeSAML},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_SAML,
+		},
+		{
+			args: args{domain.IDPTypeApple},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_APPLE,
+		},
 		{
 			args: args{99},
 			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_UNSPECIFIED,
@@ -4,6 +4,10 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+	quota_pb "github.com/zitadel/zitadel/pkg/grpc/quota"
 	"github.com/zitadel/zitadel/pkg/grpc/system"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
 )
@@ -30,3 +34,70 @@ func (s *Server) RemoveQuota(ctx context.Context, req *system.RemoveQuotaRequest
 		Details: object.ChangeToDetailsPb(details.Sequence, details.EventDate, details.ResourceOwner),
 	}, nil
 }
+
+const defaultQuotaUsagePeriods = 12
+
+func (s *Server) GetQuotaUsage(ctx context.Context, req *system.GetQuotaUsageRequest) (*system.GetQuotaUsageResponse, error) {
+	usage, err := s.quotaUsage(ctx, req.InstanceId, req.Unit, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.GetQuotaUsageResponse{
+		Usage: quotaUsageToPb(usage),
+	}, nil
+}
+
+func (s *Server) ListQuotaUsageHistory(ctx context.Context, req *system.ListQuotaUsageHistoryRequest) (*system.ListQuotaUsageHistoryResponse, error) {
+	periods := int(req.Periods)
+	if periods == 0 {
+		periods = defaultQuotaUsagePeriods
+	}
+	usage, err := s.quotaUsage(ctx, req.InstanceId, req.Unit, periods)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.ListQuotaUsageHistoryResponse{
+		Result: quotaUsageToPb(usage),
+	}, nil
+}
+
+// quotaUsage returns the usage of the unit or of all queryable units if the unit is unspecified
+func (s *Server) quotaUsage(ctx context.Context, instanceID string, unit quota_pb.Unit, periods int) ([]*logstore.Usage, error) {
+	units := []quota.Unit{instanceQuotaUnitPbToDomain(unit)}
+	if unit == quota_pb.Unit_UNIT_UNIMPLEMENTED {
+		units = s.queryableQuotaUnits()
+	}
+	usage := make([]*logstore.Usage, 0, len(units)*periods)
+	for _, u := range units {
+		svc := s.usageService(u)
+		if svc == nil {
+			return nil, errors.ThrowPreconditionFailed(nil, "SYSTEM-Ahd3o", "Errors.Quota.Usage.NotQueryable")
+		}
+		history, err := svc.UsageHistory(ctx, instanceID, u, periods)
+		if err != nil {
+			return nil, err
+		}
+		usage = append(usage, history...)
+	}
+	return usage, nil
+}
+
+func (s *Server) usageService(unit quota.Unit) *logstore.Service {
+	for _, svc := range s.usageServices {
+		if svc.SupportsUnit(unit) {
+			return svc
+		}
+	}
+	return nil
+}
+
+func (s *Server) queryableQuotaUnits() []quota.Unit {
+	units := make([]quota.Unit, 0, len(quota_pb.Unit_name))
+	for i := 1; i < len(quota_pb.Unit_name); i++ {
+		unit := instanceQuotaUnitPbToDomain(quota_pb.Unit(i))
+		if s.usageService(unit) != nil {
+			units = append(units, unit)
+		}
+	}
+	return units
+}
@@ -1,7 +1,11 @@
 package system
 
 import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/logstore"
+	quota_repo "github.com/zitadel/zitadel/internal/repository/quota"
 	"github.com/zitadel/zitadel/pkg/grpc/quota"
 	"github.com/zitadel/zitadel/pkg/grpc/system"
 )
@@ -13,6 +17,8 @@ func instanceQuotaPbToCommand(req *system.AddQuotaRequest) *command.AddQuota {
 		ResetInterval: req.ResetInterval.AsDuration(),
 		Amount:        req.Amount,
 		Limit:         req.Limit,
+		GracePercent:  uint16(req.GracePercent),
+		GracePeriod:   req.GracePeriod.AsDuration(),
 		Notifications: instanceQuotaNotificationsPbToCommand(req.Notifications),
 	}
 }
@@ -23,6 +29,12 @@ func instanceQuotaUnitPbToCommand(unit quota.Unit) command.QuotaUnit {
 		return command.QuotaRequestsAllAuthenticated
 	case quota.Unit_UNIT_ACTIONS_ALL_RUN_SECONDS:
 		return command.QuotaActionsAllRunsSeconds
+	case quota.Unit_UNIT_REQUESTS_TOKEN_ENDPOINT:
+		return command.QuotaRequestsTokenEndpoint
+	case quota.Unit_UNIT_REQUESTS_MANAGEMENT_API:
+		return command.QuotaRequestsManagementAPI
+	case quota.Unit_UNIT_NOTIFICATIONS_ALL_SENT:
+		return command.QuotaNotificationsAllSent
 	case quota.Unit_UNIT_UNIMPLEMENTED:
 		fallthrough
 	default:
@@ -41,3 +53,40 @@ func instanceQuotaNotificationsPbToCommand(req []*quota.Notification) command.Qu
 	}
 	return notifications
 }
+
+func instanceQuotaUnitPbToDomain(unit quota.Unit) quota_repo.Unit {
+	commandUnit := instanceQuotaUnitPbToCommand(unit)
+	return commandUnit.Enum()
+}
+
+func quotaUnitToPb(unit quota_repo.Unit) quota.Unit {
+	switch unit {
+	case quota_repo.RequestsAllAuthenticated:
+		return quota.Unit_UNIT_REQUESTS_ALL_AUTHENTICATED
+	case quota_repo.ActionsAllRunsSeconds:
+		return quota.Unit_UNIT_ACTIONS_ALL_RUN_SECONDS
+	case quota_repo.RequestsTokenEndpoint:
+		return quota.Unit_UNIT_REQUESTS_TOKEN_ENDPOINT
+	case quota_repo.RequestsManagementAPI:
+		return quota.Unit_UNIT_REQUESTS_MANAGEMENT_API
+	case quota_repo.NotificationsAllSent:
+		return quota.Unit_UNIT_NOTIFICATIONS_ALL_SENT
+	default:
+		return quota.Unit_UNIT_UNIMPLEMENTED
+	}
+}
+
+func quotaUsageToPb(usage []*logstore.Usage) []*quota.Usage {
+	result := make([]*quota.Usage, len(usage))
+	for i, u := range usage {
+		result[i] = &quota.Usage{
+			Unit:        quotaUnitToPb(u.Unit),
+			PeriodStart: timestamppb.New(u.PeriodStart),
+			PeriodEnd:   timestamppb.New(u.PeriodEnd),
+			Used:        u.Used,
+			Amount:      u.Amount,
+			Limit:       u.Limit,
+		}
+	}
+	return result
+}
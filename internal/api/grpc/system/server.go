@@ -8,6 +8,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/pkg/grpc/system"
 )
@@ -26,6 +27,7 @@ type Server struct {
 	administrator   repository.AdministratorRepository
 	defaultInstance command.InstanceSetup
 	externalDomain  string
+	usageServices   []*logstore.Service
 }
 
 type Config struct {
@@ -39,6 +41,7 @@ func CreateServer(
 	database string,
 	defaultInstance command.InstanceSetup,
 	externalDomain string,
+	usageServices ...*logstore.Service,
 ) *Server {
 	return &Server{
 		command:         command,
@@ -47,6 +50,7 @@ func CreateServer(
 		database:        database,
 		defaultInstance: defaultInstance,
 		externalDomain:  externalDomain,
+		usageServices:   usageServices,
 	}
 }
 
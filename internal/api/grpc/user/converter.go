@@ -1,6 +1,10 @@
 package user
 
 import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -23,6 +27,7 @@ func UserToPb(user *query.User, assetPrefix string) *user_pb.User {
 		LoginNames:         user.LoginNames,
 		PreferredLoginName: user.PreferredLoginName,
 		Type:               UserTypeToPb(user, assetPrefix),
+		LockedUntil:        lockedUntilToPb(user.LockedUntil),
 		Details: object.ToViewDetailsPb(
 			user.Sequence,
 			user.CreationDate,
@@ -32,6 +37,13 @@ func UserToPb(user *query.User, assetPrefix string) *user_pb.User {
 	}
 }
 
+func lockedUntilToPb(lockedUntil time.Time) *timestamppb.Timestamp {
+	if lockedUntil.IsZero() {
+		return nil
+	}
+	return timestamppb.New(lockedUntil)
+}
+
 func UserTypeToPb(user *query.User, assetPrefix string) user_pb.UserType {
 	if user.Human != nil {
 		return &user_pb.User_Human{
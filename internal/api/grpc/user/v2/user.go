@@ -1,7 +1,9 @@
 package user
 
 import (
+	"bytes"
 	"context"
+	"html/template"
 	"io"
 
 	"golang.org/x/text/language"
@@ -14,6 +16,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/idp"
 	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2alpha"
 	user "github.com/zitadel/zitadel/pkg/grpc/user/v2alpha"
 )
@@ -130,16 +133,54 @@ func (s *Server) StartIdentityProviderFlow(ctx context.Context, req *user.StartI
 	if err != nil {
 		return nil, err
 	}
-	authURL, err := s.command.AuthURLFromProvider(ctx, req.GetIdpId(), id, s.idpCallback(ctx))
+	session, err := s.command.AuthFromProvider(ctx, req.GetIdpId(), id, s.idpCallback(ctx), s.samlRootURL(ctx, req.GetIdpId()))
 	if err != nil {
 		return nil, err
 	}
+	if formPost, ok := session.(idp.SessionSupportsFormPost); ok {
+		postForm, err := renderPostForm(formPost.GetAuthForm())
+		if err != nil {
+			return nil, err
+		}
+		return &user.StartIdentityProviderFlowResponse{
+			Details:  object.DomainToDetailsPb(details),
+			NextStep: &user.StartIdentityProviderFlowResponse_PostForm{PostForm: postForm},
+		}, nil
+	}
 	return &user.StartIdentityProviderFlowResponse{
 		Details:  object.DomainToDetailsPb(details),
-		NextStep: &user.StartIdentityProviderFlowResponse_AuthUrl{AuthUrl: authURL},
+		NextStep: &user.StartIdentityProviderFlowResponse_AuthUrl{AuthUrl: session.GetAuthURL()},
 	}, nil
 }
 
+var postFormTemplate = template.Must(template.New("post_form").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<form method="POST" action="{{ .Action }}">
+{{- range $key, $value := .Fields }}
+<input type="hidden" name="{{ $key }}" value="{{ $value }}"/>
+{{- end }}
+<noscript><button type="submit">Continue</button></noscript>
+</form>
+</body>
+</html>`))
+
+// renderPostForm renders an HTML page, which automatically posts the form to the identity provider
+func renderPostForm(action string, fields map[string]string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := postFormTemplate.Execute(buf, struct {
+		Action string
+		Fields map[string]string
+	}{
+		Action: action,
+		Fields: fields,
+	})
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USERv2-3Lz2f", "Errors.Internal")
+	}
+	return buf.Bytes(), nil
+}
+
 func (s *Server) RetrieveIdentityProviderInformation(ctx context.Context, req *user.RetrieveIdentityProviderInformationRequest) (_ *user.RetrieveIdentityProviderInformationResponse, err error) {
 	intent, err := s.command.GetIntentWriteModel(ctx, req.GetIntentId(), authz.GetCtxData(ctx).OrgID)
 	if err != nil {
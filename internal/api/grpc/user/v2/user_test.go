@@ -235,3 +235,21 @@ func Test_authMethodTypeToPb(t *testing.T) {
 		})
 	}
 }
+
+func Test_renderPostForm(t *testing.T) {
+	got, err := renderPostForm("https://idp.example.com/sso?a=b&c=d", map[string]string{
+		"SAMLRequest": "request+/=",
+		"RelayState":  `state"<>`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<form method="POST" action="https://idp.example.com/sso?a=b&amp;c=d">
+<input type="hidden" name="RelayState" value="state&#34;&lt;&gt;"/>
+<input type="hidden" name="SAMLRequest" value="request&#43;/="/>
+<noscript><button type="submit">Continue</button></noscript>
+</form>
+</body>
+</html>`, string(got))
+}
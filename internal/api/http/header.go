@@ -38,6 +38,7 @@ const (
 	PermissionsPolicy       = "permissions-policy"
 
 	ZitadelOrgID = "x-zitadel-orgid"
+	DPoP         = "dpop"
 )
 
 type key int
@@ -28,6 +28,9 @@ type AccessInterceptor struct {
 type AccessConfig struct {
 	ExhaustedCookieKey    string
 	ExhaustedCookieMaxAge time.Duration
+	// DegradedModeAllowList contains the path prefixes which are still served
+	// when an instance is in the degraded mode of a soft quota and the grace is exceeded
+	DegradedModeAllowList []string
 }
 
 // NewAccessInterceptor intercepts all requests and stores them to the logstore.
@@ -54,13 +57,42 @@ func (a *AccessInterceptor) AccessService() *logstore.Service {
 	return a.svc
 }
 
-func (a *AccessInterceptor) Limit(ctx context.Context) bool {
+func (a *AccessInterceptor) DegradedModeAllowList() []string {
+	if a.limitConfig == nil {
+		return nil
+	}
+	return a.limitConfig.DegradedModeAllowList
+}
+
+// Limit checks if the quota of any of the units a request to the path is counted for is exhausted.
+// If the instance is in the degraded mode of a soft quota, degraded is true
+// and requests to paths of the DegradedModeAllowList are not limited.
+func (a *AccessInterceptor) Limit(ctx context.Context, path string) (limited, degraded bool) {
 	if !a.svc.Enabled() || a.storeOnly {
-		return false
+		return false, false
 	}
 	instance := authz.GetInstance(ctx)
-	remaining := a.svc.Limit(ctx, instance.InstanceID())
-	return remaining != nil && *remaining <= 0
+	for _, unit := range access.QuotaUnits(path) {
+		remaining, unitDegraded := a.svc.LimitDegraded(ctx, instance.InstanceID(), unit)
+		degraded = degraded || unitDegraded
+		if remaining == nil || *remaining > 0 {
+			continue
+		}
+		if unitDegraded && a.allowedInDegradedMode(path) {
+			continue
+		}
+		limited = true
+	}
+	return limited, degraded
+}
+
+func (a *AccessInterceptor) allowedInDegradedMode(path string) bool {
+	for _, prefix := range a.DegradedModeAllowList() {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 func (a *AccessInterceptor) SetExhaustedCookie(writer http.ResponseWriter, request *http.Request) {
@@ -89,13 +121,16 @@ func (a *AccessInterceptor) Handle(next http.Handler) http.Handler {
 		ctx := request.Context()
 		tracingCtx, checkSpan := tracing.NewNamedSpan(ctx, "checkAccess")
 		wrappedWriter := &statusRecorder{ResponseWriter: writer, status: 0}
-		limited := a.Limit(tracingCtx)
+		limited, degraded := a.Limit(tracingCtx, request.URL.Path)
 		checkSpan.End()
-		if limited {
+		// in degraded mode, the cookie is not set, as the allowed paths must still be reachable
+		if limited && !degraded {
 			a.SetExhaustedCookie(wrappedWriter, request)
+		}
+		if limited {
 			http.Error(wrappedWriter, "quota for authenticated requests is exhausted", http.StatusTooManyRequests)
 		}
-		if !limited && !a.storeOnly {
+		if !limited && !degraded && !a.storeOnly {
 			a.DeleteExhaustedCookie(wrappedWriter)
 		}
 		if !limited {
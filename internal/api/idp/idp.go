@@ -16,6 +16,7 @@ import (
 	z_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/form"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
@@ -24,12 +25,17 @@ import (
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
 const (
 	HandlerPrefix = "/idps"
 	callbackPath  = "/callback"
+	metadataPath  = "/{" + varIDPID + "}/saml/metadata"
+	acsPath       = "/{" + varIDPID + "}/saml/acs"
+
+	varIDPID = "idpid"
 
 	paramIntentID         = "id"
 	paramToken            = "token"
@@ -44,6 +50,8 @@ type Handler struct {
 	parser              *form.Parser
 	encryptionAlgorithm crypto.EncryptionAlgorithm
 	callbackURL         func(ctx context.Context) string
+	samlRootURL         func(ctx context.Context, idpID string) string
+	loginSAMLACSURL     func(ctx context.Context) string
 }
 
 type externalIDPCallbackData struct {
@@ -51,6 +59,14 @@ type externalIDPCallbackData struct {
 	Code             string `schema:"code"`
 	Error            string `schema:"error"`
 	ErrorDescription string `schema:"error_description"`
+
+	// Apple returns a user on first registration
+	User string `schema:"user"`
+}
+
+type samlACSData struct {
+	SAMLResponse string `schema:"SAMLResponse"`
+	RelayState   string `schema:"RelayState"`
 }
 
 // CallbackURL generates the instance specific URL to the IDP callback handler
@@ -60,11 +76,19 @@ func CallbackURL(externalSecure bool) func(ctx context.Context) string {
 	}
 }
 
+// SAMLRootURL generates the instance specific root URL of the SAML service provider endpoints (metadata and acs) of an IDP
+func SAMLRootURL(externalSecure bool) func(ctx context.Context, idpID string) string {
+	return func(ctx context.Context, idpID string) string {
+		return http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), externalSecure) + HandlerPrefix + "/" + idpID + "/saml/"
+	}
+}
+
 func NewHandler(
 	commands *command.Commands,
 	queries *query.Queries,
 	encryptionAlgorithm crypto.EncryptionAlgorithm,
 	externalSecure bool,
+	loginSAMLACSURL func(ctx context.Context) string,
 	instanceInterceptor func(next http.Handler) http.Handler,
 ) http.Handler {
 	h := &Handler{
@@ -73,14 +97,81 @@ func NewHandler(
 		parser:              form.NewParser(),
 		encryptionAlgorithm: encryptionAlgorithm,
 		callbackURL:         CallbackURL(externalSecure),
+		samlRootURL:         SAMLRootURL(externalSecure),
+		loginSAMLACSURL:     loginSAMLACSURL,
 	}
 
 	router := mux.NewRouter()
 	router.Use(instanceInterceptor)
 	router.HandleFunc(callbackPath, h.handleCallback)
+	router.HandleFunc(metadataPath, h.handleMetadata).Methods(http.MethodGet)
+	router.HandleFunc(acsPath, h.handleACS).Methods(http.MethodPost)
 	return router
 }
 
+// handleMetadata returns the SAML metadata of ZITADEL as service provider for the IDP,
+// including the assertion consumer service of the login UI.
+func (h *Handler) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idpID := mux.Vars(r)[varIDPID]
+	provider, err := h.commands.GetSAMLProvider(ctx, idpID, h.samlRootURL(ctx, idpID), h.loginSAMLACSURL(ctx))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	metadata, err := provider.Metadata()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, err = w.Write(metadata)
+	logging.WithFields("idp", idpID).OnError(err).Error("failed to write saml metadata")
+}
+
+// handleACS is the assertion consumer service, where the IDP sends the SAMLResponse of an intent to.
+// The RelayState contains the ID of the intent.
+func (h *Handler) handleACS(w http.ResponseWriter, r *http.Request) {
+	data, err := h.parseACSRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	intent := h.getActiveIntent(w, r, data.RelayState)
+	if intent == nil {
+		// if we didn't get an active intent the error was already handled (either redirected or display directly)
+		return
+	}
+
+	ctx := r.Context()
+	idpID := mux.Vars(r)[varIDPID]
+	if intent.IDPID != idpID {
+		err = z_errs.ThrowInvalidArgument(nil, "IDP-3Wfsq", "Errors.Intent.IDPInvalid")
+		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+
+	provider, err := h.commands.GetSAMLProvider(ctx, idpID, h.samlRootURL(ctx, idpID))
+	if err != nil {
+		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+
+	session := &saml.Session{Provider: provider, RelayState: data.RelayState, Response: data.SAMLResponse}
+	idpUser, err := session.FetchUser(ctx)
+	if err != nil {
+		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+	h.succeedIntent(w, r, intent, idpUser, session)
+}
+
 func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 	data, err := h.parseCallbackRequest(r)
 	if err != nil {
@@ -102,7 +193,7 @@ func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	provider, err := h.commands.GetProvider(ctx, intent.IDPID, h.callbackURL(ctx))
+	provider, err := h.commands.GetProvider(ctx, intent.IDPID, h.callbackURL(ctx), h.samlRootURL(ctx, intent.IDPID))
 	if err != nil {
 		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
 		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
@@ -110,13 +201,18 @@ func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	idpUser, idpSession, err := h.fetchIDPUser(ctx, provider, data.Code)
+	idpUser, idpSession, err := h.fetchIDPUser(ctx, provider, data.Code, data.User)
 	if err != nil {
 		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
 		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
 		redirectToFailureURLErr(w, r, intent, err)
 		return
 	}
+	h.succeedIntent(w, r, intent, idpUser, idpSession)
+}
+
+func (h *Handler) succeedIntent(w http.ResponseWriter, r *http.Request, intent *command.IDPIntentWriteModel, idpUser idp.User, idpSession idp.Session) {
+	ctx := r.Context()
 	userID, err := h.checkExternalUser(ctx, intent.IDPID, idpUser.GetID())
 	logging.WithFields("intent", intent.AggregateID).OnError(err).Error("could not check if idp user already exists")
 
@@ -161,6 +257,18 @@ func (h *Handler) parseCallbackRequest(r *http.Request) (*externalIDPCallbackDat
 	return data, nil
 }
 
+func (h *Handler) parseACSRequest(r *http.Request) (*samlACSData, error) {
+	data := new(samlACSData)
+	err := h.parser.Parse(r, data)
+	if err != nil {
+		return nil, err
+	}
+	if data.RelayState == "" {
+		return nil, z_errs.ThrowInvalidArgument(nil, "IDP-4bLqs", "Errors.Intent.StateMissing")
+	}
+	return data, nil
+}
+
 func (h *Handler) getActiveIntent(w http.ResponseWriter, r *http.Request, state string) *command.IDPIntentWriteModel {
 	intent, err := h.commands.GetIntentWriteModel(r.Context(), state, "")
 	if err != nil {
@@ -209,7 +317,7 @@ func redirectToFailureURL(w http.ResponseWriter, r *http.Request, i *command.IDP
 	http.Redirect(w, r, i.FailureURL.String(), http.StatusFound)
 }
 
-func (h *Handler) fetchIDPUser(ctx context.Context, identityProvider idp.Provider, code string) (user idp.User, idpTokens idp.Session, err error) {
+func (h *Handler) fetchIDPUser(ctx context.Context, identityProvider idp.Provider, code, appleUser string) (user idp.User, idpTokens idp.Session, err error) {
 	var session idp.Session
 	switch provider := identityProvider.(type) {
 	case *oauth.Provider:
@@ -224,7 +332,9 @@ func (h *Handler) fetchIDPUser(ctx context.Context, identityProvider idp.Provide
 		session = &openid.Session{Provider: provider.Provider, Code: code}
 	case *google.Provider:
 		session = &openid.Session{Provider: provider.Provider, Code: code}
-	case *jwt.Provider, *ldap.Provider:
+	case *apple.Provider:
+		session = &apple.Session{Session: &openid.Session{Provider: provider.Provider, Code: code}, UserFormValue: appleUser}
+	case *jwt.Provider, *ldap.Provider, *saml.Provider:
 		return nil, nil, z_errs.ThrowInvalidArgument(nil, "IDP-52jmn", "Errors.ExternalIDP.IDPTypeNotImplemented")
 	default:
 		return nil, nil, z_errs.ThrowUnimplemented(nil, "IDP-SSDg", "Errors.ExternalIDP.IDPTypeNotImplemented")
@@ -3,6 +3,7 @@ package idp
 import (
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -218,3 +219,53 @@ func Test_parseCallbackRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseACSRequest(t *testing.T) {
+	type args struct {
+		form url.Values
+	}
+	type res struct {
+		want *samlACSData
+		err  bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			"no relay state",
+			args{
+				form: url.Values{"SAMLResponse": {"response"}},
+			},
+			res{
+				err: true,
+			},
+		},
+		{
+			"parse",
+			args{
+				form: url.Values{"SAMLResponse": {"response"}, "RelayState": {"state"}},
+			},
+			res{
+				want: &samlACSData{
+					SAMLResponse: "response",
+					RelayState:   "state",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "https://example.com/idps/idp/saml/acs", strings.NewReader(tt.args.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			handler := Handler{parser: form.NewParser()}
+
+			data, err := handler.parseACSRequest(req)
+			if tt.res.err {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, tt.res.want, data)
+		})
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
 
+	"github.com/zitadel/zitadel/internal/actions/object"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -29,6 +31,10 @@ func (o *OPStorage) CreateAuthRequest(ctx context.Context, req *oidc.AuthRequest
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	if err = o.checkPushedAuthRequest(ctx, req.ClientID); err != nil {
+		return nil, err
+	}
+
 	headers, _ := http_utils.HeadersFromCtx(ctx)
 	if loginClient := headers.Get(LoginClientHeader); loginClient != "" {
 		return o.createAuthRequestLoginClient(ctx, req, userID, loginClient)
@@ -189,14 +195,29 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	var userAgentID, applicationID, userOrgID string
+	jwkThumbprint, err := o.dpopThumbprint(ctx, tokenRequestFromOP(req).ClientID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var (
+		userAgentID, applicationID, userOrgID string
+		actor                                 map[string]interface{}
+	)
 	switch authReq := req.(type) {
 	case *AuthRequest:
 		userAgentID = authReq.AgentID
 		applicationID = authReq.ApplicationID
 		userOrgID = authReq.UserOrgID
+	case *TokenExchangeRequest:
+		applicationID = authReq.GetClientID()
+		userOrgID = authReq.subject.resourceOwner
+		// the `act` claim is stored on the opaque token, so it can be returned on introspection
+		if authReq.delegation() {
+			actor = authReq.actorClaim(op.IssuerFromContext(ctx))
+		}
 	case *AuthRequestV2:
-		return o.command.AddOIDCSessionAccessToken(setContextUserSystem(ctx), authReq.GetID())
+		return o.command.AddOIDCSessionAccessToken(setContextUserSystem(ctx), authReq.GetID(), jwkThumbprint)
 	}
 
 	accessTokenLifetime, _, _, _, err := o.getOIDCSettings(ctx)
@@ -204,7 +225,7 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 		return "", time.Time{}, err
 	}
 
-	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime) //PLANNED: lifetime from client
+	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime, jwkThumbprint, actor) //PLANNED: lifetime from client
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -215,12 +236,21 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	tokenRequest := tokenRequestFromOP(req)
+	if err = o.tokenExchangeFlows(ctx, tokenRequest); err != nil {
+		return "", "", time.Time{}, err
+	}
+	jwkThumbprint, err := o.dpopThumbprint(ctx, tokenRequest.ClientID)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
 	// handle V2 request directly
 	switch tokenReq := req.(type) {
 	case *AuthRequestV2:
-		return o.command.AddOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.GetID())
+		return o.command.AddOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.GetID(), jwkThumbprint)
 	case *RefreshTokenRequestV2:
-		return o.command.ExchangeOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.OIDCSessionWriteModel.AggregateID, refreshToken, tokenReq.RequestedScopes)
+		return o.command.ExchangeOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.OIDCSessionWriteModel.AggregateID, refreshToken, tokenReq.RequestedScopes, jwkThumbprint)
 	}
 
 	userAgentID, applicationID, userOrgID, authTime, authMethodsReferences := getInfoFromRequest(req)
@@ -239,7 +269,7 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 
 	resp, token, err := o.command.AddAccessAndRefreshToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(),
 		refreshToken, req.GetAudience(), scopes, authMethodsReferences, accessTokenLifetime,
-		refreshTokenIdleExpiration, refreshTokenExpiration, authTime) //PLANNED: lifetime from client
+		refreshTokenIdleExpiration, refreshTokenExpiration, authTime, jwkThumbprint) //PLANNED: lifetime from client
 	if err != nil {
 		if errors.IsErrorInvalidArgument(err) {
 			err = oidc.ErrInvalidGrant().WithParent(err)
@@ -249,6 +279,25 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	return resp.TokenID, token, resp.Expiration, nil
 }
 
+// tokenRequestFromOP maps the V1 and V2 token requests to the request passed to the actions
+func tokenRequestFromOP(req op.TokenRequest) *object.TokenRequest {
+	request := &object.TokenRequest{
+		UserID:   req.GetSubject(),
+		Scopes:   req.GetScopes(),
+		Audience: req.GetAudience(),
+	}
+	if clientReq, ok := req.(interface {
+		GetClientID() string
+		GetAuthTime() time.Time
+		GetAMR() []string
+	}); ok {
+		request.ClientID = clientReq.GetClientID()
+		request.AuthTime = clientReq.GetAuthTime()
+		request.AuthMethods = clientReq.GetAMR()
+	}
+	return request
+}
+
 func getInfoFromRequest(req op.TokenRequest) (string, string, string, time.Time, []string) {
 	authReq, ok := req.(*AuthRequest)
 	if ok {
@@ -316,7 +365,10 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 	// and if not provided, terminate the session using the V1 method
 	headers, _ := http_utils.HeadersFromCtx(ctx)
 	if loginClient := headers.Get(LoginClientHeader); loginClient == "" {
-		return endSessionRequest.RedirectURI, o.TerminateSession(ctx, endSessionRequest.UserID, endSessionRequest.ClientID)
+		if err = o.TerminateSession(ctx, endSessionRequest.UserID, endSessionRequest.ClientID); err != nil {
+			return endSessionRequest.RedirectURI, err
+		}
+		return o.samlLogoutRedirectURI(ctx, endSessionRequest)
 	}
 
 	// in case there are not id_token_hint, redirect to the UI and let it decide which session to terminate
@@ -332,6 +384,23 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 	return endSessionRequest.RedirectURI, nil
 }
 
+// samlLogoutRedirectURI returns the url of the SAML provider propagating the logout to the service providers,
+// if the user agent signed into any. The SAML provider will redirect to the RedirectURI of the request afterwards.
+func (o *OPStorage) samlLogoutRedirectURI(ctx context.Context, endSessionRequest *op.EndSessionRequest) (string, error) {
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return endSessionRequest.RedirectURI, nil
+	}
+	sessions, err := o.query.SAMLSessionsByUserAgentID(ctx, false, userAgentID)
+	if err != nil {
+		return "", err
+	}
+	if len(sessions) == 0 {
+		return endSessionRequest.RedirectURI, nil
+	}
+	return saml.PropagateLogoutURL(op.IssuerFromContext(ctx)+saml.HandlerPrefix, endSessionRequest.ClientID, endSessionRequest.RedirectURI), nil
+}
+
 func (o *OPStorage) RevokeToken(ctx context.Context, token, userID, clientID string) (err *oidc.Error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() {
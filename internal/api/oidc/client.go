@@ -12,6 +12,7 @@ import (
 	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
+	"golang.org/x/text/language"
 	"gopkg.in/square/go-jose.v2"
 
 	"github.com/zitadel/zitadel/internal/actions"
@@ -127,22 +128,28 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 		if err != nil {
 			return err
 		}
+		if err = checkUserinfoProofOfPossession(ctx, token.JWKThumbprint); err != nil {
+			return err
+		}
 		if err = o.isOriginAllowed(ctx, token.ClientID, origin); err != nil {
 			return err
 		}
-		return o.setUserinfo(ctx, userInfo, token.UserID, token.ClientID, token.Scope, nil)
+		return o.setCachedUserinfo(ctx, userInfo, "userinfo", tokenID, token.UserID, token.ClientID, token.Scope, nil, token.AccessTokenExpiration)
 	}
 
 	token, err := o.repo.TokenByIDs(ctx, subject, tokenID)
 	if err != nil {
 		return errors.ThrowPermissionDenied(nil, "OIDC-Dsfb2", "token is not valid or has expired")
 	}
+	if err = checkUserinfoProofOfPossession(ctx, token.JWKThumbprint); err != nil {
+		return err
+	}
 	if token.ApplicationID != "" {
 		if err = o.isOriginAllowed(ctx, token.ApplicationID, origin); err != nil {
 			return err
 		}
 	}
-	return o.setUserinfo(ctx, userInfo, token.UserID, token.ApplicationID, token.Scopes, nil)
+	return o.setCachedUserinfo(ctx, userInfo, "userinfo", token.ID, token.UserID, token.ApplicationID, token.Scopes, nil, token.Expiration)
 }
 
 func (o *OPStorage) SetUserinfoFromScopes(ctx context.Context, userInfo *oidc.UserInfo, userID, applicationID string, scopes []string) (err error) {
@@ -164,9 +171,18 @@ func (o *OPStorage) SetUserinfoFromScopes(ctx context.Context, userInfo *oidc.Us
 }
 
 // SetUserinfoFromRequest extends the SetUserinfoFromScopes during the id_token generation.
-// This is required for V2 tokens to be able to set the sessionID (`sid`) claim.
+// This is required to be able to set the sessionID (`sid`) claim, which is used for back-channel logout.
+// For V1 tokens the session is the user signed in on the user agent.
 func (o *OPStorage) SetUserinfoFromRequest(ctx context.Context, userinfo *oidc.UserInfo, request op.IDTokenRequest, _ []string) error {
 	switch t := request.(type) {
+	case *AuthRequest:
+		if t.AgentID != "" {
+			userinfo.AppendClaims("sid", domain.UserAgentSessionID(t.AgentID, t.UserID))
+		}
+	case *RefreshTokenRequest:
+		if t.UserAgentID != "" {
+			userinfo.AppendClaims("sid", domain.UserAgentSessionID(t.UserAgentID, t.UserID))
+		}
 	case *AuthRequestV2:
 		userinfo.AppendClaims("sid", t.SessionID)
 	case *RefreshTokenRequestV2:
@@ -191,7 +207,8 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 		return o.introspect(ctx, introspection,
 			tokenID, token.UserID, token.ClientID, clientID, projectID,
 			token.Audience, token.Scope,
-			token.AccessTokenCreation, token.AccessTokenExpiration)
+			token.AccessTokenCreation, token.AccessTokenExpiration,
+			token.JWKThumbprint, nil)
 	}
 
 	token, err := o.repo.TokenByIDs(ctx, subject, tokenID)
@@ -211,7 +228,8 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 	return o.introspect(ctx, introspection,
 		token.ID, token.UserID, token.ApplicationID, clientID, projectID,
 		token.Audience, token.Scopes,
-		token.CreationDate, token.Expiration)
+		token.CreationDate, token.Expiration,
+		token.JWKThumbprint, token.Actor)
 }
 
 func (o *OPStorage) ClientCredentialsTokenRequest(ctx context.Context, clientID string, scope []string) (op.TokenRequest, error) {
@@ -275,6 +293,8 @@ func (o *OPStorage) introspect(
 	tokenID, subject, tokenClientID, introspectionClientID, introspectionProjectID string,
 	audience, scope []string,
 	tokenCreation, tokenExpiration time.Time,
+	jwkThumbprint string,
+	actor map[string]interface{},
 ) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -282,7 +302,7 @@ func (o *OPStorage) introspect(
 	for _, aud := range audience {
 		if aud == introspectionClientID || aud == introspectionProjectID {
 			userInfo := new(oidc.UserInfo)
-			err = o.setUserinfo(ctx, userInfo, subject, introspectionClientID, scope, []string{introspectionProjectID})
+			err = o.setCachedUserinfo(ctx, userInfo, "introspection", tokenID, subject, introspectionClientID, scope, []string{introspectionProjectID}, tokenExpiration)
 			if err != nil {
 				return err
 			}
@@ -296,6 +316,13 @@ func (o *OPStorage) introspect(
 			introspection.Audience = audience
 			introspection.Issuer = op.IssuerFromContext(ctx)
 			introspection.JWTID = tokenID
+			if jwkThumbprint != "" {
+				introspection.TokenType = TokenTypeDPoP
+				introspection.Claims = appendClaim(introspection.Claims, ClaimConfirmation, dpopConfirmation(jwkThumbprint))
+			}
+			if len(actor) > 0 {
+				introspection.Claims = appendClaim(introspection.Claims, ClaimActor, actor)
+			}
 			return nil
 		}
 	}
@@ -568,7 +595,7 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, resourceOwner string, use
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, userInfo.Subject))...,
 		)
 		cancel()
 		if err != nil {
@@ -579,7 +606,10 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, resourceOwner string, use
 		}
 	}
 
-	return nil
+	return actions.CallFunctionTargets(ctx, o.query, domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, &actions.FunctionPayload{
+		ResourceOwner: resourceOwner,
+		UserID:        userInfo.Subject,
+	})
 }
 
 func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clientID string, scopes []string) (claims map[string]interface{}, err error) {
@@ -645,7 +675,14 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 		}
 	}
 
-	return o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	claims, err = o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	if err != nil {
+		return nil, err
+	}
+	if thumbprint := dpopThumbprintFromCtx(ctx); thumbprint != "" {
+		claims = appendClaim(claims, ClaimConfirmation, dpopConfirmation(thumbprint))
+	}
+	return claims, nil
 }
 
 func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userGrants *query.UserGrants, claims map[string]interface{}) (map[string]interface{}, error) {
@@ -752,7 +789,7 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, userID))...,
 		)
 		cancel()
 		if err != nil {
@@ -764,9 +801,93 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 		}
 	}
 
+	err = actions.CallFunctionTargets(ctx, o.query, domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, &actions.FunctionPayload{
+		ResourceOwner: user.ResourceOwner,
+		UserID:        userID,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return claims, nil
 }
 
+// tokenExchangeFlows runs the actions of the token exchange flow before a refresh token is issued,
+// the actions can reject the request, which results in an access_denied error
+func (o *OPStorage) tokenExchangeFlows(ctx context.Context, request *object.TokenRequest) error {
+	// the user is only queried if there is anything to run, as the flow is triggered on every token request
+	hasActions, err := o.query.HasActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeTokenExchange, domain.TriggerTypePreRefreshTokenCreation)
+	if err != nil {
+		return err
+	}
+	targets, err := o.query.ExecutionTargetsByIDs(ctx, []string{domain.ExecutionIDForFunction(domain.FlowTypeTokenExchange, domain.TriggerTypePreRefreshTokenCreation)})
+	if err != nil {
+		return err
+	}
+	if !hasActions && len(targets) == 0 {
+		return nil
+	}
+	user, err := o.query.GetUserByID(ctx, true, request.UserID, false)
+	if err != nil {
+		return err
+	}
+	var queriedActions []*query.Action
+	if hasActions {
+		queriedActions, err = o.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeTokenExchange, domain.TriggerTypePreRefreshTokenCreation, user.ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("tokenRequest", object.TokenRequestField(request)),
+			actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+				return func(call goja.FunctionCall) goja.Value {
+					return object.UserFromQuery(c, user)
+				}
+			}),
+		),
+	)
+	rejection := new(object.Rejection)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("reject", rejection.RejectFunc),
+		),
+	)
+
+	for _, action := range queriedActions {
+		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			action.Script,
+			action.Name,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypeTokenExchange, domain.TriggerTypePreRefreshTokenCreation, user.ID))...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if rejection.Rejected() {
+			var lang language.Tag
+			if user.Human != nil {
+				lang = user.Human.PreferredLanguage
+			}
+			return oidc.ErrAccessDenied().WithDescription(rejection.Message(lang)).WithParent(rejection.Error(lang))
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+	return actions.CallFunctionTargets(ctx, o.query, domain.FlowTypeTokenExchange, domain.TriggerTypePreRefreshTokenCreation, &actions.FunctionPayload{
+		ResourceOwner: user.ResourceOwner,
+		UserID:        request.UserID,
+		ClientID:      request.ClientID,
+	})
+}
+
 func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID string, requestedRoles, roleAudience []string) (*query.UserGrants, *projectsRoles, error) {
 	if (applicationID == "" || len(requestedRoles) == 0) && len(roleAudience) == 0 {
 		return nil, nil, nil
@@ -797,6 +918,17 @@ func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID strin
 	if err != nil {
 		return nil, nil, err
 	}
+	groupProjectQuery, err := query.NewGroupGrantProjectIDsSearchQuery(roleAudience)
+	if err != nil {
+		return nil, nil, err
+	}
+	// roles granted to groups are inherited by all members of the group
+	groupGrants, err := o.query.GroupGrantsByUserID(ctx, userID, &query.GroupGrantSearchQueries{
+		Queries: []query.SearchQuery{groupProjectQuery},
+	}, true)
+	if err != nil {
+		return nil, nil, err
+	}
 	roles := new(projectsRoles)
 	// if specific roles where requested, check if they are granted and append them in the roles list
 	if len(requestedRoles) > 0 {
@@ -804,6 +936,9 @@ func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID strin
 			for _, grant := range grants.UserGrants {
 				checkGrantedRoles(roles, grant, requestedRole, grant.ProjectID == projectID)
 			}
+			for _, grant := range groupGrants.GroupGrants {
+				checkGroupGrantedRoles(roles, grant, requestedRole, grant.ProjectID == projectID)
+			}
 		}
 		return grants, roles, nil
 	}
@@ -813,6 +948,11 @@ func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID strin
 			roles.Add(grant.ProjectID, role, grant.ResourceOwner, grant.OrgPrimaryDomain, grant.ProjectID == projectID)
 		}
 	}
+	for _, grant := range groupGrants.GroupGrants {
+		for _, role := range grant.Roles {
+			roles.Add(grant.ProjectID, role, grant.ResourceOwner, grant.OrgPrimaryDomain, grant.ProjectID == projectID)
+		}
+	}
 	return grants, roles, nil
 }
 
@@ -853,6 +993,14 @@ func checkGrantedRoles(roles *projectsRoles, grant *query.UserGrant, requestedRo
 	}
 }
 
+func checkGroupGrantedRoles(roles *projectsRoles, grant *query.GroupGrant, requestedRole string, isRequested bool) {
+	for _, grantedRole := range grant.Roles {
+		if requestedRole == grantedRole {
+			roles.Add(grant.ProjectID, grantedRole, grant.ResourceOwner, grant.OrgPrimaryDomain, isRequested)
+		}
+	}
+}
+
 // projectsRoles contains all projects with all their roles for a user
 type projectsRoles struct {
 	// key is projectID
@@ -0,0 +1,460 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	defaultClientRegistrationEndpoint = "/oauth/v2/register"
+
+	applicationTypeWeb    = "web"
+	applicationTypeNative = "native"
+
+	// clientMetadataMaxSize limits the size of the request body of the client registration and update
+	clientMetadataMaxSize = 64 << 10
+)
+
+// clientMetadata is the client metadata of the dynamic client registration (RFC 7591 section 2)
+type clientMetadata struct {
+	RedirectURIs                       []string            `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs             []string            `json:"post_logout_redirect_uris,omitempty"`
+	ClientName                         string              `json:"client_name,omitempty"`
+	TokenEndpointAuthMethod            oidc.AuthMethod     `json:"token_endpoint_auth_method,omitempty"`
+	GrantTypes                         []oidc.GrantType    `json:"grant_types,omitempty"`
+	ResponseTypes                      []oidc.ResponseType `json:"response_types,omitempty"`
+	ApplicationType                    string              `json:"application_type,omitempty"`
+	BackChannelLogoutURI               string              `json:"backchannel_logout_uri,omitempty"`
+	RequirePushedAuthorizationRequests bool                `json:"require_pushed_authorization_requests,omitempty"`
+	DPoPBoundAccessTokens              bool                `json:"dpop_bound_access_tokens,omitempty"`
+}
+
+// clientUpdateRequest is the request of the client update (RFC 7592 section 2.2)
+type clientUpdateRequest struct {
+	ClientID string `json:"client_id"`
+	clientMetadata
+}
+
+// clientInformationResponse is the response of the client registration (RFC 7591 section 3.2.1)
+// and of the client read and update (RFC 7592 section 3)
+type clientInformationResponse struct {
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at,omitempty"`
+	ClientSecretExpiresAt   int64  `json:"client_secret_expires_at"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+	clientMetadata
+}
+
+// clientRegistrationHandler handles the dynamic client registration (RFC 7591) and management (RFC 7592),
+// which are not supported by the OP.
+// Clients are registered as oidc applications of the project, the initial access token was issued for.
+// Registered clients are read, updated and deleted using the registration access token returned on registration.
+type clientRegistrationHandler struct {
+	storage         *OPStorage
+	endpoint        op.Endpoint
+	passwordHashAlg crypto.HashAlgorithm
+}
+
+func newClientRegistrationHandler(config Config, storage *OPStorage, passwordHashAlg crypto.HashAlgorithm) *clientRegistrationHandler {
+	return &clientRegistrationHandler{
+		storage:         storage,
+		endpoint:        clientRegistrationEndpoint(config),
+		passwordHashAlg: passwordHashAlg,
+	}
+}
+
+func clientRegistrationEndpoint(config Config) op.Endpoint {
+	if config.CustomEndpoints != nil && config.CustomEndpoints.Registration != nil {
+		return op.NewEndpointWithURL(config.CustomEndpoints.Registration.Path, config.CustomEndpoints.Registration.URL)
+	}
+	return op.NewEndpoint(defaultClientRegistrationEndpoint)
+}
+
+// register adds the client registration endpoints to the router of the provider,
+// so all interceptors of the OP are applied to them as well
+func (h *clientRegistrationHandler) register(provider *op.Provider) {
+	if router, ok := provider.HttpHandler().(*mux.Router); ok {
+		router.HandleFunc(h.endpoint.Relative(), h.registerClient)
+		router.HandleFunc(h.endpoint.Relative()+"/{client_id}", h.manageClient)
+	}
+}
+
+// registerClient registers a new client using the initial access token (RFC 7591 section 3)
+func (h *clientRegistrationHandler) registerClient(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.NewSpan(r.Context())
+	var err error
+	defer func() { span.EndWithError(err) }()
+
+	if r.Method != http.MethodPost {
+		err = oidc.ErrInvalidRequest().WithDescription("clients must be registered using POST")
+		clientRegistrationError(w, r, err)
+		return
+	}
+	initialAccessToken, ok := bearerToken(r)
+	if !ok {
+		err = errInvalidToken().WithDescription("initial access token missing")
+		clientRegistrationError(w, r, err)
+		return
+	}
+	// the request is authenticated before its body is read
+	if err = h.storage.command.VerifyInitialAccessToken(ctx, initialAccessToken); err != nil {
+		err = clientRegistrationCommandError(err, "unable to register client")
+		clientRegistrationError(w, r, err)
+		return
+	}
+	metadata := new(clientMetadata)
+	if err = json.NewDecoder(http.MaxBytesReader(w, r.Body, clientMetadataMaxSize)).Decode(metadata); err != nil {
+		err = errInvalidClientMetadata().WithDescription("unable to parse client metadata").WithParent(err)
+		clientRegistrationError(w, r, err)
+		return
+	}
+	app := &domain.OIDCApp{
+		OIDCVersion:     domain.OIDCVersionV1,
+		AccessTokenType: domain.OIDCTokenTypeBearer,
+	}
+	if err = metadata.applyTo(app); err != nil {
+		clientRegistrationError(w, r, err)
+		return
+	}
+	appSecretGenerator, err := h.storage.query.InitHashGenerator(ctx, domain.SecretGeneratorTypeAppSecret, h.passwordHashAlg)
+	if err != nil {
+		err = oidc.DefaultToServerError(err, "unable to register client")
+		clientRegistrationError(w, r, err)
+		return
+	}
+	app, registrationToken, err := h.storage.command.RegisterOIDCApplication(setContextUserSystem(ctx), initialAccessToken, app, appSecretGenerator)
+	if err != nil {
+		err = clientRegistrationCommandError(err, "unable to register client")
+		clientRegistrationError(w, r, err)
+		return
+	}
+	response := h.clientInformation(ctx, app, time.Now())
+	response.ClientSecret = app.ClientSecretString
+	response.RegistrationAccessToken = registrationToken
+	httphelper.MarshalJSONWithStatus(w, response, http.StatusCreated)
+}
+
+// manageClient reads, updates or deletes a registered client using its registration access token (RFC 7592 section 2)
+func (h *clientRegistrationHandler) manageClient(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.NewSpan(r.Context())
+	var err error
+	defer func() { span.EndWithError(err) }()
+
+	registrationToken, ok := bearerToken(r)
+	if !ok {
+		err = errInvalidToken().WithDescription("registration access token missing")
+		clientRegistrationError(w, r, err)
+		return
+	}
+	app, err := h.storage.query.AppByOIDCClientID(ctx, mux.Vars(r)["client_id"], false)
+	if err != nil {
+		// the existence of the client must not be disclosed (RFC 7592 section 2)
+		err = errInvalidToken().WithDescription("registration access token invalid").WithParent(err)
+		clientRegistrationError(w, r, err)
+		return
+	}
+	if err = h.storage.command.VerifyOIDCRegistrationToken(ctx, app.ProjectID, app.ID, registrationToken); err != nil {
+		err = errInvalidToken().WithDescription("registration access token invalid").WithParent(err)
+		clientRegistrationError(w, r, err)
+		return
+	}
+	ctx = setContextUserSystem(ctx)
+	switch r.Method {
+	case http.MethodGet:
+		httphelper.MarshalJSON(w, h.clientInformation(ctx, oidcAppFromQuery(app), app.CreationDate))
+	case http.MethodPut:
+		err = h.updateClient(ctx, w, r, app)
+	case http.MethodDelete:
+		if _, err = h.storage.command.RemoveApplication(ctx, app.ProjectID, app.ID, app.ResourceOwner); err != nil {
+			err = oidc.DefaultToServerError(err, "unable to delete client")
+			clientRegistrationError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		err = oidc.ErrInvalidRequest().WithDescription("method not allowed")
+		clientRegistrationError(w, r, err)
+	}
+}
+
+// updateClient replaces the registered metadata of the client (RFC 7592 section 2.2).
+// Configuration which is not part of the client metadata is kept.
+func (h *clientRegistrationHandler) updateClient(ctx context.Context, w http.ResponseWriter, r *http.Request, existing *query.App) (err error) {
+	request := new(clientUpdateRequest)
+	if err = json.NewDecoder(http.MaxBytesReader(w, r.Body, clientMetadataMaxSize)).Decode(request); err != nil {
+		err = errInvalidClientMetadata().WithDescription("unable to parse client metadata").WithParent(err)
+		clientRegistrationError(w, r, err)
+		return err
+	}
+	if request.ClientID != existing.OIDCConfig.ClientID {
+		err = oidc.ErrInvalidRequest().WithDescription("client_id does not match")
+		clientRegistrationError(w, r, err)
+		return err
+	}
+	app := oidcAppFromQuery(existing)
+	if err = request.applyTo(app); err != nil {
+		clientRegistrationError(w, r, err)
+		return err
+	}
+	if app.AppName != existing.Name {
+		_, err = h.storage.command.ChangeApplication(ctx, existing.ProjectID, &domain.ChangeApp{AppID: existing.ID, AppName: app.AppName}, existing.ResourceOwner)
+		if err != nil {
+			err = clientRegistrationCommandError(err, "unable to update client")
+			clientRegistrationError(w, r, err)
+			return err
+		}
+	}
+	_, err = h.storage.command.ChangeOIDCApplication(ctx, app, existing.ResourceOwner)
+	if err != nil && !errors.IsPreconditionFailed(err) {
+		err = clientRegistrationCommandError(err, "unable to update client")
+		clientRegistrationError(w, r, err)
+		return err
+	}
+	httphelper.MarshalJSON(w, h.clientInformation(ctx, app, existing.CreationDate))
+	return nil
+}
+
+func (h *clientRegistrationHandler) clientInformation(ctx context.Context, app *domain.OIDCApp, issuedAt time.Time) *clientInformationResponse {
+	return &clientInformationResponse{
+		ClientID:              app.ClientID,
+		ClientIDIssuedAt:      issuedAt.Unix(),
+		RegistrationClientURI: h.endpoint.Absolute(op.IssuerFromContext(ctx)) + "/" + url.PathEscape(app.ClientID),
+		clientMetadata:        clientMetadataFromOIDCApp(app),
+	}
+}
+
+// applyTo validates the client metadata and sets it on the app.
+// Omitted grant types, response types and auth method are set to their defaults (RFC 7591 section 2).
+func (m *clientMetadata) applyTo(app *domain.OIDCApp) error {
+	if m.ClientName == "" {
+		return errInvalidClientMetadata().WithDescription("client_name is required")
+	}
+	authMethod, err := authMethodFromOIDC(m.TokenEndpointAuthMethod)
+	if err != nil {
+		return err
+	}
+	grantTypes, err := grantTypesFromOIDC(m.GrantTypes)
+	if err != nil {
+		return err
+	}
+	responseTypes, err := responseTypesFromOIDC(m.ResponseTypes)
+	if err != nil {
+		return err
+	}
+	if !domain.ContainsRequiredGrantTypes(responseTypes, grantTypes) {
+		return errInvalidClientMetadata().WithDescription("grant_types do not match response_types")
+	}
+	appType, err := applicationTypeFromOIDC(m.ApplicationType, authMethod)
+	if err != nil {
+		return err
+	}
+	if len(domain.RequiredOIDCGrantTypes(responseTypes)) > 0 && len(m.RedirectURIs) == 0 {
+		return errInvalidRedirectURI().WithDescription("redirect_uris are required")
+	}
+	for _, uri := range append(m.RedirectURIs, m.PostLogoutRedirectURIs...) {
+		if !domain.IsRedirectURIValid(uri, appType) {
+			return errInvalidRedirectURI().WithDescription("redirect uri %s is invalid", uri)
+		}
+	}
+	if !domain.IsBackChannelLogoutURIValid(m.BackChannelLogoutURI) {
+		return errInvalidClientMetadata().WithDescription("backchannel_logout_uri is invalid")
+	}
+	app.AppName = m.ClientName
+	app.RedirectUris = m.RedirectURIs
+	app.PostLogoutRedirectUris = m.PostLogoutRedirectURIs
+	app.AuthMethodType = authMethod
+	app.GrantTypes = grantTypes
+	app.ResponseTypes = responseTypes
+	app.ApplicationType = appType
+	app.BackChannelLogoutURI = m.BackChannelLogoutURI
+	app.RequirePushedAuthRequests = m.RequirePushedAuthorizationRequests
+	app.RequireDPoP = m.DPoPBoundAccessTokens
+	return nil
+}
+
+func clientMetadataFromOIDCApp(app *domain.OIDCApp) clientMetadata {
+	applicationType := applicationTypeWeb
+	if app.ApplicationType == domain.OIDCApplicationTypeNative {
+		applicationType = applicationTypeNative
+	}
+	return clientMetadata{
+		RedirectURIs:                       app.RedirectUris,
+		PostLogoutRedirectURIs:             app.PostLogoutRedirectUris,
+		ClientName:                         app.AppName,
+		TokenEndpointAuthMethod:            authMethodToOIDC(app.AuthMethodType),
+		GrantTypes:                         grantTypesToOIDC(app.GrantTypes),
+		ResponseTypes:                      responseTypesToOIDC(app.ResponseTypes),
+		ApplicationType:                    applicationType,
+		BackChannelLogoutURI:               app.BackChannelLogoutURI,
+		RequirePushedAuthorizationRequests: app.RequirePushedAuthRequests,
+		DPoPBoundAccessTokens:              app.RequireDPoP,
+	}
+}
+
+func oidcAppFromQuery(app *query.App) *domain.OIDCApp {
+	return &domain.OIDCApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   app.ProjectID,
+			ResourceOwner: app.ResourceOwner,
+		},
+		AppID:                     app.ID,
+		AppName:                   app.Name,
+		ClientID:                  app.OIDCConfig.ClientID,
+		RedirectUris:              app.OIDCConfig.RedirectURIs,
+		ResponseTypes:             app.OIDCConfig.ResponseTypes,
+		GrantTypes:                app.OIDCConfig.GrantTypes,
+		ApplicationType:           app.OIDCConfig.AppType,
+		AuthMethodType:            app.OIDCConfig.AuthMethodType,
+		PostLogoutRedirectUris:    app.OIDCConfig.PostLogoutRedirectURIs,
+		OIDCVersion:               app.OIDCConfig.Version,
+		DevMode:                   app.OIDCConfig.IsDevMode,
+		AccessTokenType:           app.OIDCConfig.AccessTokenType,
+		AccessTokenRoleAssertion:  app.OIDCConfig.AssertAccessTokenRole,
+		IDTokenRoleAssertion:      app.OIDCConfig.AssertIDTokenRole,
+		IDTokenUserinfoAssertion:  app.OIDCConfig.AssertIDTokenUserinfo,
+		ClockSkew:                 app.OIDCConfig.ClockSkew,
+		AdditionalOrigins:         app.OIDCConfig.AdditionalOrigins,
+		SkipNativeAppSuccessPage:  app.OIDCConfig.SkipNativeAppSuccessPage,
+		BackChannelLogoutURI:      app.OIDCConfig.BackChannelLogoutURI,
+		RequirePushedAuthRequests: app.OIDCConfig.RequirePushedAuthRequests,
+		RequireDPoP:               app.OIDCConfig.RequireDPoP,
+		State:                     app.State,
+	}
+}
+
+func authMethodFromOIDC(authMethod oidc.AuthMethod) (domain.OIDCAuthMethodType, error) {
+	switch authMethod {
+	case "", oidc.AuthMethodBasic:
+		return domain.OIDCAuthMethodTypeBasic, nil
+	case oidc.AuthMethodPost:
+		return domain.OIDCAuthMethodTypePost, nil
+	case oidc.AuthMethodNone:
+		return domain.OIDCAuthMethodTypeNone, nil
+	default:
+		return 0, errInvalidClientMetadata().WithDescription("token_endpoint_auth_method %s is not supported", authMethod)
+	}
+}
+
+func grantTypesFromOIDC(grantTypes []oidc.GrantType) ([]domain.OIDCGrantType, error) {
+	if len(grantTypes) == 0 {
+		return []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode}, nil
+	}
+	domainTypes := make([]domain.OIDCGrantType, len(grantTypes))
+	for i, grantType := range grantTypes {
+		switch grantType {
+		case oidc.GrantTypeCode:
+			domainTypes[i] = domain.OIDCGrantTypeAuthorizationCode
+		case oidc.GrantTypeImplicit:
+			domainTypes[i] = domain.OIDCGrantTypeImplicit
+		case oidc.GrantTypeRefreshToken:
+			domainTypes[i] = domain.OIDCGrantTypeRefreshToken
+		case oidc.GrantTypeDeviceCode:
+			domainTypes[i] = domain.OIDCGrantTypeDeviceCode
+		case oidc.GrantTypeTokenExchange:
+			domainTypes[i] = domain.OIDCGrantTypeTokenExchange
+		default:
+			return nil, errInvalidClientMetadata().WithDescription("grant_type %s is not supported", grantType)
+		}
+	}
+	return domainTypes, nil
+}
+
+func responseTypesFromOIDC(responseTypes []oidc.ResponseType) ([]domain.OIDCResponseType, error) {
+	if len(responseTypes) == 0 {
+		return []domain.OIDCResponseType{domain.OIDCResponseTypeCode}, nil
+	}
+	domainTypes := make([]domain.OIDCResponseType, len(responseTypes))
+	for i, responseType := range responseTypes {
+		switch responseType {
+		case oidc.ResponseTypeCode:
+			domainTypes[i] = domain.OIDCResponseTypeCode
+		case oidc.ResponseTypeIDToken:
+			domainTypes[i] = domain.OIDCResponseTypeIDTokenToken
+		case oidc.ResponseTypeIDTokenOnly:
+			domainTypes[i] = domain.OIDCResponseTypeIDToken
+		default:
+			return nil, errInvalidClientMetadata().WithDescription("response_type %s is not supported", responseType)
+		}
+	}
+	return domainTypes, nil
+}
+
+// applicationTypeFromOIDC maps the application_type (OpenID Connect Dynamic Client Registration 1.0 section 2).
+// Public web clients are registered as user agent applications.
+func applicationTypeFromOIDC(applicationType string, authMethod domain.OIDCAuthMethodType) (domain.OIDCApplicationType, error) {
+	switch applicationType {
+	case "", applicationTypeWeb:
+		if authMethod == domain.OIDCAuthMethodTypeNone {
+			return domain.OIDCApplicationTypeUserAgent, nil
+		}
+		return domain.OIDCApplicationTypeWeb, nil
+	case applicationTypeNative:
+		return domain.OIDCApplicationTypeNative, nil
+	default:
+		return 0, errInvalidClientMetadata().WithDescription("application_type %s is not supported", applicationType)
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("authorization"), oidc.PrefixBearer)
+	return token, ok && token != ""
+}
+
+func clientRegistrationCommandError(err error, description string) error {
+	switch {
+	case errors.IsUnauthenticated(err):
+		return errInvalidToken().WithDescription("initial access token invalid").WithParent(err)
+	case errors.IsErrorInvalidArgument(err):
+		return errInvalidClientMetadata().WithDescription("client metadata invalid").WithParent(err)
+	default:
+		return oidc.DefaultToServerError(err, description)
+	}
+}
+
+// clientRegistrationError writes the error response (RFC 7591 section 3.2.2),
+// invalid tokens are responded with status 401 (RFC 6750 section 3.1)
+func clientRegistrationError(w http.ResponseWriter, r *http.Request, err error) {
+	e := oidc.DefaultToServerError(err, err.Error())
+	if e.ErrorType != errInvalidToken().ErrorType {
+		op.RequestError(w, r, e)
+		return
+	}
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	httphelper.MarshalJSONWithStatus(w, e, http.StatusUnauthorized)
+}
+
+func errInvalidClientMetadata() *oidc.Error {
+	return &oidc.Error{
+		ErrorType: "invalid_client_metadata",
+	}
+}
+
+func errInvalidRedirectURI() *oidc.Error {
+	return &oidc.Error{
+		ErrorType: "invalid_redirect_uri",
+	}
+}
+
+func errInvalidToken() *oidc.Error {
+	return &oidc.Error{
+		ErrorType: "invalid_token",
+	}
+}
@@ -0,0 +1,201 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func Test_clientMetadata_applyTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *clientMetadata
+		want     *domain.OIDCApp
+		wantErr  *oidc.Error
+	}{
+		{
+			name:     "missing client name",
+			metadata: &clientMetadata{RedirectURIs: []string{"https://client.com/callback"}},
+			wantErr:  errInvalidClientMetadata(),
+		},
+		{
+			name: "unsupported auth method",
+			metadata: &clientMetadata{
+				ClientName:              "client",
+				RedirectURIs:            []string{"https://client.com/callback"},
+				TokenEndpointAuthMethod: oidc.AuthMethodPrivateKeyJWT,
+			},
+			wantErr: errInvalidClientMetadata(),
+		},
+		{
+			name: "unsupported grant type",
+			metadata: &clientMetadata{
+				ClientName:   "client",
+				RedirectURIs: []string{"https://client.com/callback"},
+				GrantTypes:   []oidc.GrantType{oidc.GrantTypeCode, oidc.GrantTypeBearer},
+			},
+			wantErr: errInvalidClientMetadata(),
+		},
+		{
+			name: "grant types not matching response types",
+			metadata: &clientMetadata{
+				ClientName:    "client",
+				RedirectURIs:  []string{"https://client.com/callback"},
+				ResponseTypes: []oidc.ResponseType{oidc.ResponseTypeIDTokenOnly},
+			},
+			wantErr: errInvalidClientMetadata(),
+		},
+		{
+			name: "missing redirect uris",
+			metadata: &clientMetadata{
+				ClientName: "client",
+			},
+			wantErr: errInvalidRedirectURI(),
+		},
+		{
+			name: "redirect uri with fragment",
+			metadata: &clientMetadata{
+				ClientName:   "client",
+				RedirectURIs: []string{"https://client.com/callback#fragment"},
+			},
+			wantErr: errInvalidRedirectURI(),
+		},
+		{
+			name: "javascript redirect uri",
+			metadata: &clientMetadata{
+				ClientName:   "client",
+				RedirectURIs: []string{"javascript:alert(1)"},
+			},
+			wantErr: errInvalidRedirectURI(),
+		},
+		{
+			name: "data post logout redirect uri",
+			metadata: &clientMetadata{
+				ClientName:             "client",
+				RedirectURIs:           []string{"https://client.com/callback"},
+				PostLogoutRedirectURIs: []string{"data:text/html,<script>alert(1)</script>"},
+			},
+			wantErr: errInvalidRedirectURI(),
+		},
+		{
+			name: "custom scheme of web client",
+			metadata: &clientMetadata{
+				ClientName:   "client",
+				RedirectURIs: []string{"com.client.app:/callback"},
+			},
+			wantErr: errInvalidRedirectURI(),
+		},
+		{
+			name: "http redirect uri of public web client",
+			metadata: &clientMetadata{
+				ClientName:              "client",
+				RedirectURIs:            []string{"http://client.com/callback"},
+				TokenEndpointAuthMethod: oidc.AuthMethodNone,
+			},
+			wantErr: errInvalidRedirectURI(),
+		},
+		{
+			name: "defaults",
+			metadata: &clientMetadata{
+				ClientName:   "client",
+				RedirectURIs: []string{"https://client.com/callback"},
+			},
+			want: &domain.OIDCApp{
+				AppName:         "client",
+				RedirectUris:    []string{"https://client.com/callback"},
+				AuthMethodType:  domain.OIDCAuthMethodTypeBasic,
+				GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				ApplicationType: domain.OIDCApplicationTypeWeb,
+			},
+		},
+		{
+			name: "public web client",
+			metadata: &clientMetadata{
+				ClientName:                         "client",
+				RedirectURIs:                       []string{"https://client.com/callback"},
+				PostLogoutRedirectURIs:             []string{"https://client.com/logout"},
+				TokenEndpointAuthMethod:            oidc.AuthMethodNone,
+				GrantTypes:                         []oidc.GrantType{oidc.GrantTypeCode, oidc.GrantTypeRefreshToken},
+				ResponseTypes:                      []oidc.ResponseType{oidc.ResponseTypeCode},
+				RequirePushedAuthorizationRequests: true,
+				DPoPBoundAccessTokens:              true,
+			},
+			want: &domain.OIDCApp{
+				AppName:                   "client",
+				RedirectUris:              []string{"https://client.com/callback"},
+				PostLogoutRedirectUris:    []string{"https://client.com/logout"},
+				AuthMethodType:            domain.OIDCAuthMethodTypeNone,
+				GrantTypes:                []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+				ResponseTypes:             []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				ApplicationType:           domain.OIDCApplicationTypeUserAgent,
+				RequirePushedAuthRequests: true,
+				RequireDPoP:               true,
+			},
+		},
+		{
+			name: "device code without authorization code",
+			metadata: &clientMetadata{
+				ClientName:              "client",
+				ApplicationType:         applicationTypeNative,
+				TokenEndpointAuthMethod: oidc.AuthMethodNone,
+				GrantTypes:              []oidc.GrantType{oidc.GrantTypeDeviceCode},
+			},
+			wantErr: errInvalidClientMetadata(),
+		},
+		{
+			name: "native client",
+			metadata: &clientMetadata{
+				ClientName:              "client",
+				RedirectURIs:            []string{"http://localhost/callback", "com.client.app:/callback"},
+				ApplicationType:         applicationTypeNative,
+				TokenEndpointAuthMethod: oidc.AuthMethodNone,
+				GrantTypes:              []oidc.GrantType{oidc.GrantTypeCode, oidc.GrantTypeDeviceCode},
+			},
+			want: &domain.OIDCApp{
+				AppName:         "client",
+				RedirectUris:    []string{"http://localhost/callback", "com.client.app:/callback"},
+				AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+				GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeDeviceCode},
+				ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				ApplicationType: domain.OIDCApplicationTypeNative,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(domain.OIDCApp)
+			err := tt.metadata.applyTo(got)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_clientMetadataFromOIDCApp(t *testing.T) {
+	got := clientMetadataFromOIDCApp(&domain.OIDCApp{
+		AppName:         "client",
+		RedirectUris:    []string{"http://localhost/callback"},
+		AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+		GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+		ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+		ApplicationType: domain.OIDCApplicationTypeNative,
+		RequireDPoP:     true,
+	})
+	assert.Equal(t, clientMetadata{
+		ClientName:              "client",
+		RedirectURIs:            []string{"http://localhost/callback"},
+		TokenEndpointAuthMethod: oidc.AuthMethodNone,
+		GrantTypes:              []oidc.GrantType{oidc.GrantTypeCode},
+		ResponseTypes:           []oidc.ResponseType{oidc.ResponseTypeCode},
+		ApplicationType:         applicationTypeNative,
+		DPoPBoundAccessTokens:   true,
+	}, got)
+}
@@ -0,0 +1,182 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// TokenTypeDPoP is the token_type of DPoP-bound access tokens (RFC 9449 section 5)
+	TokenTypeDPoP = "DPoP"
+	// ClaimConfirmation is the confirmation claim (RFC 7800), which contains the JWK thumbprint of DPoP-bound tokens
+	ClaimConfirmation = "cnf"
+)
+
+type dpopThumbprintKey struct{}
+
+type dpopAccessTokenKey struct{}
+
+// dpopInterceptor verifies the DPoP proof (RFC 9449) sent to the token endpoint, which is not supported by the OP.
+// The JWK thumbprint of the proof is passed in the context, so the issued tokens are bound to it,
+// and the token_type of the response is set to DPoP.
+// On the userinfo endpoint it accepts the DPoP authorization scheme, see [dpopInterceptor.userinfo].
+type dpopInterceptor struct {
+	provider *op.Provider
+}
+
+func (i *dpopInterceptor) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if i.provider != nil && r.URL.Path == i.provider.UserinfoEndpoint().Relative() {
+			i.userinfo(next, w, r)
+			return
+		}
+		if i.provider == nil || r.Method != http.MethodPost || r.URL.Path != i.provider.TokenEndpoint().Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		proofs := r.Header.Values(http_utils.DPoP)
+		if len(proofs) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(proofs) > 1 {
+			op.RequestError(w, r, errInvalidDPoPProof().WithDescription("only one DPoP proof is allowed"))
+			return
+		}
+		thumbprint, err := authz.VerifyDPoPProof(proofs[0], http.MethodPost, i.provider.TokenEndpoint().Absolute(op.IssuerFromContext(r.Context())), "", time.Now())
+		if err != nil {
+			op.RequestError(w, r, errInvalidDPoPProof().WithDescription("DPoP proof is invalid").WithParent(err))
+			return
+		}
+		writer := &dpopResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(writer, r.WithContext(context.WithValue(r.Context(), dpopThumbprintKey{}, thumbprint)))
+		writer.flush()
+	})
+}
+
+// userinfo passes the access token sent with the DPoP authorization scheme as bearer token to the OP,
+// which only supports the latter. The proof and the access token are passed in the context,
+// so the proof of possession can be checked against the binding of the token, see [checkUserinfoProofOfPossession].
+func (i *dpopInterceptor) userinfo(next http.Handler, w http.ResponseWriter, r *http.Request) {
+	authorization := r.Header.Get(http_utils.Authorization)
+	if !strings.HasPrefix(authorization, authz.DPoPPrefix) {
+		next.ServeHTTP(w, r)
+		return
+	}
+	if len(r.Header.Values(http_utils.DPoP)) > 1 {
+		w.Header().Set("WWW-Authenticate", `DPoP error="invalid_dpop_proof"`)
+		http.Error(w, "only one DPoP proof is allowed", http.StatusUnauthorized)
+		return
+	}
+	accessToken := strings.TrimPrefix(authorization, authz.DPoPPrefix)
+	r.Header.Set(http_utils.Authorization, oidc.PrefixBearer+accessToken)
+	ctx := authz.WithDPoPProof(r.Context(), &authz.DPoPProof{
+		Proof:  r.Header.Get(http_utils.DPoP),
+		Method: r.Method,
+		Path:   r.URL.Path,
+	})
+	next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, dpopAccessTokenKey{}, accessToken)))
+}
+
+// checkUserinfoProofOfPossession checks the proof of possession of a DPoP-bound access token used on the userinfo endpoint,
+// the same way as for the APIs. DPoP-bound tokens can therefore not be used as bearer token.
+func checkUserinfoProofOfPossession(ctx context.Context, jwkThumbprint string) error {
+	accessToken, dpop := ctx.Value(dpopAccessTokenKey{}).(string)
+	return authz.CheckProofOfPossession(ctx, accessToken, jwkThumbprint, dpop)
+}
+
+func dpopThumbprintFromCtx(ctx context.Context) string {
+	thumbprint, _ := ctx.Value(dpopThumbprintKey{}).(string)
+	return thumbprint
+}
+
+func errInvalidDPoPProof() *oidc.Error {
+	return &oidc.Error{
+		ErrorType: "invalid_dpop_proof",
+	}
+}
+
+// dpopThumbprint returns the JWK thumbprint of the DPoP proof sent to the token endpoint, the issued tokens are bound to.
+// Clients requiring DPoP must send a proof.
+func (o *OPStorage) dpopThumbprint(ctx context.Context, clientID string) (string, error) {
+	if thumbprint := dpopThumbprintFromCtx(ctx); thumbprint != "" {
+		return thumbprint, nil
+	}
+	if clientID == "" {
+		return "", nil
+	}
+	app, err := o.query.AppByOIDCClientID(ctx, clientID, false)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if app.OIDCConfig != nil && app.OIDCConfig.RequireDPoP {
+		return "", errInvalidDPoPProof().WithDescription("DPoP proof required")
+	}
+	return "", nil
+}
+
+// dpopConfirmation returns the confirmation claim of a DPoP-bound token (RFC 9449 section 6)
+func dpopConfirmation(thumbprint string) map[string]interface{} {
+	return map[string]interface{}{
+		"jkt": thumbprint,
+	}
+}
+
+// dpopResponseWriter buffers the response of the token endpoint,
+// so the token_type can be changed before it is sent
+type dpopResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *dpopResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *dpopResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *dpopResponseWriter) flush() {
+	body := w.body.Bytes()
+	if w.status == http.StatusOK {
+		body = dpopTokenResponse(body)
+		w.Header().Set(http_utils.ContentLength, strconv.Itoa(len(body)))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// dpopTokenResponse sets the token_type of a token response with a bearer token to DPoP
+func dpopTokenResponse(body []byte) []byte {
+	response := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body
+	}
+	var tokenType string
+	if err := json.Unmarshal(response["token_type"], &tokenType); err != nil || tokenType != oidc.BearerToken {
+		return body
+	}
+	response["token_type"] = json.RawMessage(strconv.Quote(TokenTypeDPoP))
+	dpopBody, err := json.Marshal(response)
+	if err != nil {
+		return body
+	}
+	return dpopBody
+}
@@ -0,0 +1,139 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_dpopTokenResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "bearer token",
+			body: `{"access_token":"token","token_type":"Bearer","expires_in":300}`,
+			want: `{"access_token":"token","expires_in":300,"token_type":"DPoP"}`,
+		},
+		{
+			name: "id token of token exchange",
+			body: `{"access_token":"token","issued_token_type":"urn:ietf:params:oauth:token-type:id_token","token_type":"N_A"}`,
+			want: `{"access_token":"token","issued_token_type":"urn:ietf:params:oauth:token-type:id_token","token_type":"N_A"}`,
+		},
+		{
+			name: "error",
+			body: `{"error":"invalid_grant"}`,
+			want: `{"error":"invalid_grant"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dpopTokenResponse([]byte(tt.body))
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func Test_dpopInterceptor_userinfo(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jkt, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	thumbprint := base64.RawURLEncoding.EncodeToString(jkt)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{EmbedJWK: true}).WithType(authz.DPoPProofType))
+	require.NoError(t, err)
+	newProof := func(id string) string {
+		proof, err := jwt.Signed(signer).Claims(map[string]interface{}{
+			"jti": id,
+			"htm": http.MethodGet,
+			"htu": "https://zitadel.cloud/oidc/v1/userinfo",
+			"iat": time.Now().Unix(),
+			"ath": authz.AccessTokenHash("accessToken"),
+		}).CompactSerialize()
+		require.NoError(t, err)
+		return proof
+	}
+	tests := []struct {
+		name          string
+		authorization string
+		proof         string
+		jwkThumbprint string
+		wantErr       bool
+	}{
+		{
+			name:          "bearer token, ok",
+			authorization: "Bearer accessToken",
+		},
+		{
+			name:          "bound token as bearer token, error",
+			authorization: "Bearer accessToken",
+			jwkThumbprint: thumbprint,
+			wantErr:       true,
+		},
+		{
+			name:          "bound token without proof, error",
+			authorization: "DPoP accessToken",
+			jwkThumbprint: thumbprint,
+			wantErr:       true,
+		},
+		{
+			name:          "bound token with proof of other key, error",
+			authorization: "DPoP accessToken",
+			proof:         newProof("id1"),
+			jwkThumbprint: "otherThumbprint",
+			wantErr:       true,
+		},
+		{
+			name:          "unbound token with dpop scheme, error",
+			authorization: "DPoP accessToken",
+			proof:         newProof("id2"),
+			wantErr:       true,
+		},
+		{
+			name:          "bound token with proof, ok",
+			authorization: "DPoP accessToken",
+			proof:         newProof("id3"),
+			jwkThumbprint: thumbprint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/oidc/v1/userinfo", nil)
+			r = r.WithContext(authz.WithRequestedDomain(r.Context(), "zitadel.cloud"))
+			r.Header.Set(http_utils.Authorization, tt.authorization)
+			if tt.proof != "" {
+				r.Header.Set(http_utils.DPoP, tt.proof)
+			}
+			var called bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				assert.Equal(t, "Bearer accessToken", r.Header.Get(http_utils.Authorization))
+				err := checkUserinfoProofOfPossession(r.Context(), tt.jwkThumbprint)
+				if tt.wantErr {
+					assert.True(t, errors.IsUnauthenticated(err))
+					return
+				}
+				assert.NoError(t, err)
+			})
+			new(dpopInterceptor).userinfo(next, httptest.NewRecorder(), r)
+			assert.True(t, called)
+		})
+	}
+}
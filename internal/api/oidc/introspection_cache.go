@@ -0,0 +1,274 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/bigcache"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/oidcsession"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+type IntrospectionCacheConfig struct {
+	// Lifetime of the cached responses, the cache is disabled if not set
+	Lifetime         time.Duration
+	MaxCacheSizeInMB int
+}
+
+// introspectionCache caches the userinfo returned on the introspection and userinfo endpoint per token and scopes.
+// The token itself is still verified on every request, so revoked tokens are never answered from the cache.
+//
+// Every key contains the dependencies of the userinfo (see [userinfoDependencies]) and the latest sequence of their events:
+// the events of the user and the oidc session and the events of the grants, groups, projects (roles), organizations
+// and the actions of the userinfo flow of the user.
+// The sequence is read from the eventstore on every lookup, so changes through any instance of ZITADEL invalidate the entry immediately.
+// Outdated entries are never read again and are removed by the cache after the lifetime.
+type introspectionCache struct {
+	cache    cache.Cache
+	lifetime time.Duration
+	es       sequenceQuerier
+}
+
+type sequenceQuerier interface {
+	LatestSequence(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) (uint64, error)
+}
+
+// cachedUserinfo is stored in the cache as JSON, because the gob encoding of the cache
+// does not support the arbitrary types of the claims
+type cachedUserinfo struct {
+	Userinfo   []byte
+	Expiration time.Time
+}
+
+func newIntrospectionCache(config *IntrospectionCacheConfig, es sequenceQuerier) (*introspectionCache, error) {
+	if config == nil || config.Lifetime <= 0 {
+		return nil, nil
+	}
+	c, err := bigcache.NewBigcache(&bigcache.Config{
+		MaxCacheSizeInMB: config.MaxCacheSizeInMB,
+		CacheLifetime:    config.Lifetime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &introspectionCache{
+		cache:    c,
+		lifetime: config.Lifetime,
+		es:       es,
+	}, nil
+}
+
+// key returns the key of the token and scopes with the dependencies and the latest sequence of their events
+func (c *introspectionCache) key(ctx context.Context, endpoint, tokenID, userID, clientID string, scopes []string, dependencies *userinfoDependencies) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	sequence, err := c.es.LatestSequence(ctx, userinfoSequenceQuery(ctx, tokenID, userID, dependencies))
+	if err != nil {
+		return "", err
+	}
+	sortedScopes := make([]string, len(scopes))
+	copy(sortedScopes, scopes)
+	sort.Strings(sortedScopes)
+	return strings.Join([]string{
+		endpoint,
+		authz.GetInstance(ctx).InstanceID(),
+		tokenID,
+		clientID,
+		strings.Join(sortedScopes, " "),
+		dependencies.hash(),
+		strconv.FormatUint(sequence, 10),
+	}, "|"), nil
+}
+
+// userinfoDependencies are the aggregates of the instance, besides the user and the oidc session,
+// whose events might change the userinfo of the user
+type userinfoDependencies struct {
+	orgIDs     []string
+	grantIDs   []string
+	groupIDs   []string
+	projectIDs []string
+	actionIDs  []string
+}
+
+// hash identifies the dependencies in the key, so an entry is invalidated as soon as a dependency is removed,
+// e.g. a user grant, even if the latest sequence of the remaining ones does not change
+func (d *userinfoDependencies) hash() string {
+	if d == nil {
+		return ""
+	}
+	h := sha256.New()
+	for _, ids := range [][]string{d.orgIDs, d.grantIDs, d.groupIDs, d.projectIDs, d.actionIDs} {
+		sorted := make([]string, len(ids))
+		copy(sorted, ids)
+		sort.Strings(sorted)
+		h.Write([]byte(strings.Join(sorted, ",") + "|"))
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// userinfoSequenceQuery returns the query of the events, which might change the userinfo of the token:
+// the events of the user, the oidc session and the dependencies of the userinfo.
+// Flows are part of the organization, so changes of the userinfo flow are covered by the events of the organization of the user.
+func userinfoSequenceQuery(ctx context.Context, tokenID, userID string, dependencies *userinfoDependencies) *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsMaxSequence).
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID)
+	if sessionID, ok := tokenSessionID(tokenID); ok {
+		query = query.Or().
+			AggregateTypes(oidcsession.AggregateType).
+			AggregateIDs(sessionID)
+	}
+	if dependencies == nil {
+		return query.Builder()
+	}
+	for aggregateType, ids := range map[eventstore.AggregateType][]string{
+		org.AggregateType:       dependencies.orgIDs,
+		usergrant.AggregateType: dependencies.grantIDs,
+		group.AggregateType:     dependencies.groupIDs,
+		project.AggregateType:   dependencies.projectIDs,
+		action.AggregateType:    dependencies.actionIDs,
+	} {
+		// a query without ids would match all aggregates of the type
+		if len(ids) == 0 {
+			continue
+		}
+		query = query.Or().
+			AggregateTypes(aggregateType).
+			AggregateIDs(ids...)
+	}
+	return query.Builder()
+}
+
+func (c *introspectionCache) get(key string, userInfo *oidc.UserInfo) bool {
+	if c == nil || key == "" {
+		return false
+	}
+	cached := new(cachedUserinfo)
+	if err := c.cache.Get(key, cached); err != nil || !cached.Expiration.After(time.Now()) {
+		return false
+	}
+	return json.Unmarshal(cached.Userinfo, userInfo) == nil
+}
+
+// set caches the userinfo for the lifetime of the cache, but at most until the token expires
+func (c *introspectionCache) set(key string, userInfo *oidc.UserInfo, tokenExpiration time.Time) {
+	if c == nil || key == "" {
+		return
+	}
+	expiration := time.Now().Add(c.lifetime)
+	if tokenExpiration.Before(expiration) {
+		expiration = tokenExpiration
+	}
+	data, err := json.Marshal(userInfo)
+	if err != nil {
+		logging.WithError(err).Warn("unable to marshal userinfo for cache")
+		return
+	}
+	err = c.cache.Set(key, &cachedUserinfo{
+		Userinfo:   data,
+		Expiration: expiration,
+	})
+	logging.OnError(err).Warn("unable to cache userinfo")
+}
+
+// tokenSessionID returns the id of the oidc session of (V2) access tokens
+func tokenSessionID(tokenID string) (string, bool) {
+	if !strings.HasPrefix(tokenID, command.IDPrefixV2) {
+		return "", false
+	}
+	sessionID, _, _ := strings.Cut(tokenID, "-")
+	return sessionID, true
+}
+
+// setCachedUserinfo sets the userinfo of the token from the cache or queries and caches it
+func (o *OPStorage) setCachedUserinfo(ctx context.Context, userInfo *oidc.UserInfo, endpoint, tokenID, userID, applicationID string, scopes, roleAudience []string, tokenExpiration time.Time) error {
+	// without key the userinfo is neither read from nor written to the cache
+	var key string
+	if o.introspectionCache != nil {
+		dependencies, err := o.userinfoDependencies(ctx, userID)
+		if err == nil {
+			key, err = o.introspectionCache.key(ctx, endpoint, tokenID, userID, applicationID, scopes, dependencies)
+		}
+		logging.OnError(err).Warn("unable to query dependencies of userinfo cache")
+	}
+	if o.introspectionCache.get(key, userInfo) {
+		return nil
+	}
+	if err := o.setUserinfo(ctx, userInfo, userID, applicationID, scopes, roleAudience); err != nil {
+		return err
+	}
+	o.introspectionCache.set(key, userInfo, tokenExpiration)
+	return nil
+}
+
+// userinfoDependencies returns the organizations, grants, groups, projects and actions the userinfo of the user depends on,
+// see [OPStorage.setUserinfo]. They're read from the projections, which are indexed by the user, respectively the organization.
+func (o *OPStorage) userinfoDependencies(ctx context.Context, userID string) (*userinfoDependencies, error) {
+	user, err := o.query.GetUserByID(ctx, true, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := o.query.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	groupGrants, err := o.query.GroupGrantsByUserID(ctx, userID, &query.GroupGrantSearchQueries{}, true)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := o.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, user.ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+	dependencies := &userinfoDependencies{
+		orgIDs: []string{user.ResourceOwner},
+	}
+	for _, grant := range grants.UserGrants {
+		dependencies.grantIDs = append(dependencies.grantIDs, grant.ID)
+		dependencies.projectIDs = appendUnique(dependencies.projectIDs, grant.ProjectID)
+		dependencies.orgIDs = appendUnique(dependencies.orgIDs, grant.ResourceOwner)
+	}
+	for _, grant := range groupGrants.GroupGrants {
+		dependencies.groupIDs = appendUnique(dependencies.groupIDs, grant.GroupID)
+		dependencies.projectIDs = appendUnique(dependencies.projectIDs, grant.ProjectID)
+		dependencies.orgIDs = appendUnique(dependencies.orgIDs, grant.ResourceOwner)
+	}
+	for _, action := range actions {
+		dependencies.actionIDs = append(dependencies.actionIDs, action.ID)
+	}
+	return dependencies, nil
+}
+
+func appendUnique(list []string, s string) []string {
+	if containsString(list, s) {
+		return list
+	}
+	return append(list, s)
+}
@@ -0,0 +1,232 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache/bigcache"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/oidcsession"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+// mockSequenceQuerier returns the sequence of the events matching the query,
+// so the latest sequence changes as soon as a matching event is pushed
+type mockSequenceQuerier struct {
+	events []eventstore.Event
+	err    error
+}
+
+func (m *mockSequenceQuerier) LatestSequence(_ context.Context, query *eventstore.SearchQueryBuilder) (uint64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	var sequence uint64
+	for i, event := range m.events {
+		if query.Matches(event, 0) {
+			sequence = uint64(i + 1)
+		}
+	}
+	return sequence, nil
+}
+
+func newTestIntrospectionCache(t *testing.T, es sequenceQuerier) *introspectionCache {
+	c, err := bigcache.NewBigcache(&bigcache.Config{CacheLifetime: time.Minute})
+	require.NoError(t, err)
+	return &introspectionCache{
+		cache:    c,
+		lifetime: time.Minute,
+		es:       es,
+	}
+}
+
+func testUserinfoDependencies() *userinfoDependencies {
+	return &userinfoDependencies{
+		orgIDs:     []string{"org1"},
+		grantIDs:   []string{"grant1"},
+		groupIDs:   []string{"group1"},
+		projectIDs: []string{"project1"},
+		actionIDs:  []string{"action1"},
+	}
+}
+
+func Test_introspectionCache(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	tests := []struct {
+		name         string
+		tokenID      string
+		event        eventstore.Event
+		dependencies *userinfoDependencies
+		wantHit      bool
+	}{
+		{
+			name:    "cached",
+			tokenID: "token1",
+			wantHit: true,
+		},
+		{
+			name:    "other user changed",
+			tokenID: "token1",
+			event:   user.NewUserDeactivatedEvent(ctx, &user.NewAggregate("user2", "org1").Aggregate),
+			wantHit: true,
+		},
+		{
+			name:    "user deactivated",
+			tokenID: "token1",
+			event:   user.NewUserDeactivatedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate),
+		},
+		{
+			name:    "user token removed",
+			tokenID: "token1",
+			event:   user.NewUserTokenRemovedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate, "token1"),
+		},
+		{
+			name:    "user metadata set",
+			tokenID: "token1",
+			event:   user.NewMetadataSetEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate, "key", []byte("value")),
+		},
+		{
+			name:    "user grant removed",
+			tokenID: "token1",
+			event:   usergrant.NewUserGrantRemovedEvent(ctx, &usergrant.NewAggregate("grant1", "org1").Aggregate, "user1", "project1", ""),
+		},
+		{
+			name:    "other user grant removed",
+			tokenID: "token1",
+			event:   usergrant.NewUserGrantRemovedEvent(ctx, &usergrant.NewAggregate("grant2", "org1").Aggregate, "user2", "project1", ""),
+			wantHit: true,
+		},
+		{
+			name:    "user grant removed from dependencies",
+			tokenID: "token1",
+			dependencies: &userinfoDependencies{
+				orgIDs:     []string{"org1"},
+				groupIDs:   []string{"group1"},
+				projectIDs: []string{"project1"},
+				actionIDs:  []string{"action1"},
+			},
+		},
+		{
+			name:    "group member added",
+			tokenID: "token1",
+			event:   group.NewMemberAddedEvent(ctx, &group.NewAggregate("group1", "org1").Aggregate, "user1"),
+		},
+		{
+			name:    "project role removed",
+			tokenID: "token1",
+			event:   project.NewRoleRemovedEvent(ctx, &project.NewAggregate("project1", "org1").Aggregate, "role1"),
+		},
+		{
+			name:    "other project role removed",
+			tokenID: "token1",
+			event:   project.NewRoleRemovedEvent(ctx, &project.NewAggregate("project2", "org1").Aggregate, "role1"),
+			wantHit: true,
+		},
+		{
+			name:    "org changed",
+			tokenID: "token1",
+			event:   org.NewOrgChangedEvent(ctx, &org.NewAggregate("org1").Aggregate, "org", "org2"),
+		},
+		{
+			name:    "other org changed",
+			tokenID: "token1",
+			event:   org.NewOrgChangedEvent(ctx, &org.NewAggregate("org2").Aggregate, "org", "org3"),
+			wantHit: true,
+		},
+		{
+			name:    "userinfo flow changed",
+			tokenID: "token1",
+			event:   org.NewTriggerActionsSetEvent(ctx, &org.NewAggregate("org1").Aggregate, domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, []string{"action1", "action2"}),
+		},
+		{
+			name:    "action deactivated",
+			tokenID: "token1",
+			event:   action.NewDeactivatedEvent(ctx, &action.NewAggregate("action1", "org1").Aggregate),
+		},
+		{
+			name:    "other action deactivated",
+			tokenID: "token1",
+			event:   action.NewDeactivatedEvent(ctx, &action.NewAggregate("action2", "org1").Aggregate),
+			wantHit: true,
+		},
+		{
+			name:    "access token revoked",
+			tokenID: "V2_session1-at_token1",
+			event:   oidcsession.NewAccessTokenRevokedEvent(ctx, &oidcsession.NewAggregate("V2_session1", "org1").Aggregate),
+		},
+		{
+			name:    "other access token revoked",
+			tokenID: "V2_session1-at_token1",
+			event:   oidcsession.NewAccessTokenRevokedEvent(ctx, &oidcsession.NewAggregate("V2_session2", "org1").Aggregate),
+			wantHit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &mockSequenceQuerier{
+				events: []eventstore.Event{
+					user.NewUserDeactivatedEvent(ctx, &user.NewAggregate("user1", "org1").Aggregate),
+				},
+			}
+			c := newTestIntrospectionCache(t, es)
+			key, err := c.key(ctx, "introspection", tt.tokenID, "user1", "client1", []string{"openid", "profile"}, testUserinfoDependencies())
+			require.NoError(t, err)
+			c.set(key, &oidc.UserInfo{Subject: "user1"}, time.Now().Add(time.Hour))
+			if tt.event != nil {
+				// the event might be pushed by any instance of ZITADEL
+				es.events = append(es.events, tt.event)
+			}
+
+			dependencies := testUserinfoDependencies()
+			if tt.dependencies != nil {
+				dependencies = tt.dependencies
+			}
+			key, err = c.key(ctx, "introspection", tt.tokenID, "user1", "client1", []string{"profile", "openid"}, dependencies)
+			require.NoError(t, err)
+			got := new(oidc.UserInfo)
+			hit := c.get(key, got)
+			assert.Equal(t, tt.wantHit, hit)
+			if tt.wantHit {
+				assert.Equal(t, "user1", got.Subject)
+			}
+		})
+	}
+}
+
+func Test_introspectionCache_expiration(t *testing.T) {
+	c := newTestIntrospectionCache(t, new(mockSequenceQuerier))
+	key, err := c.key(context.Background(), "userinfo", "token1", "user1", "client1", nil, nil)
+	require.NoError(t, err)
+	c.set(key, &oidc.UserInfo{Subject: "user1"}, time.Now().Add(-time.Second))
+	assert.False(t, c.get(key, new(oidc.UserInfo)))
+}
+
+func Test_introspectionCache_sequenceError(t *testing.T) {
+	c := newTestIntrospectionCache(t, &mockSequenceQuerier{err: errors.ThrowInternal(nil, "TEST-Pq3ds", "error")})
+	key, err := c.key(context.Background(), "userinfo", "token1", "user1", "client1", nil, nil)
+	assert.Error(t, err)
+	c.set(key, &oidc.UserInfo{Subject: "user1"}, time.Now().Add(time.Hour))
+	assert.False(t, c.get(key, new(oidc.UserInfo)))
+}
+
+func Test_introspectionCache_disabled(t *testing.T) {
+	c, err := newIntrospectionCache(&IntrospectionCacheConfig{}, nil)
+	require.NoError(t, err)
+	key, err := c.key(context.Background(), "userinfo", "token1", "user1", "client1", nil, nil)
+	require.NoError(t, err)
+	c.set(key, &oidc.UserInfo{Subject: "user1"}, time.Now().Add(time.Hour))
+	assert.False(t, c.get(key, new(oidc.UserInfo)))
+}
@@ -43,6 +43,8 @@ type Config struct {
 	DeviceAuth                        *DeviceAuthorizationConfig
 	DefaultLoginURLV2                 string
 	DefaultLogoutURLV2                string
+	PushedAuthRequestLifetime         time.Duration
+	IntrospectionCache                *IntrospectionCacheConfig
 }
 
 type EndpointConfig struct {
@@ -54,6 +56,10 @@ type EndpointConfig struct {
 	EndSession    *Endpoint
 	Keys          *Endpoint
 	DeviceAuth    *Endpoint
+	// PushedAuthRequest is not provided by the OP itself, see [pushedAuthRequestInterceptor]
+	PushedAuthRequest *Endpoint
+	// Registration is not provided by the OP itself, see [clientRegistrationHandler]
+	Registration *Endpoint
 }
 
 type Endpoint struct {
@@ -77,15 +83,24 @@ type OPStorage struct {
 	encAlg                            crypto.EncryptionAlgorithm
 	locker                            crdb.Locker
 	assetAPIPrefix                    func(ctx context.Context) string
+	introspectionCache                *introspectionCache
 }
 
-func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *database.DB, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
+func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, passwordHashAlg crypto.HashAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *database.DB, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
 	opConfig, err := createOPConfig(config, defaultLogoutRedirectURI, cryptoKey)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-EGrqd", "cannot create op config: %w")
 	}
-	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure)
-	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler, accessHandler)
+	storage, err := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "OIDC-Kx8oe", "cannot create storage")
+	}
+	dpop := &dpopInterceptor{}
+	tokenExchange := &tokenExchangeInterceptor{storage: storage}
+	pushedAuthRequest := newPushedAuthRequestInterceptor(config, storage)
+	clientRegistration := newClientRegistrationHandler(config, storage, passwordHashAlg)
+	pushedAuthRequest.registrationEndpoint = clientRegistration.endpoint
+	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler, accessHandler, dpop.Handler, tokenExchange.Handler, pushedAuthRequest.Handler)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-D3gq1", "cannot create options: %w")
 	}
@@ -98,6 +113,10 @@ func NewProvider(config Config, defaultLogoutRedirectURI string, externalSecure
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-DAtg3", "cannot create provider")
 	}
+	dpop.provider = provider
+	tokenExchange.provider = provider
+	pushedAuthRequest.register(provider)
+	clientRegistration.register(provider)
 	return provider, nil
 }
 
@@ -123,7 +142,7 @@ func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []
 	return opConfig, nil
 }
 
-func createOptions(config Config, externalSecure bool, userAgentCookie, instanceHandler, accessHandler func(http.Handler) http.Handler) ([]op.Option, error) {
+func createOptions(config Config, externalSecure bool, userAgentCookie, instanceHandler, accessHandler, dpopHandler, tokenExchangeHandler, pushedAuthRequestHandler func(http.Handler) http.Handler) ([]op.Option, error) {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 	options := []op.Option{
 		op.WithHttpInterceptors(
@@ -134,6 +153,9 @@ func createOptions(config Config, externalSecure bool, userAgentCookie, instance
 			userAgentCookie,
 			http_utils.CopyHeadersToContext,
 			accessHandler,
+			dpopHandler,
+			tokenExchangeHandler,
+			pushedAuthRequestHandler,
 		),
 	}
 	if !externalSecure {
@@ -178,7 +200,11 @@ func customEndpoints(endpointConfig *EndpointConfig) []op.Option {
 	return options
 }
 
-func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, db *database.DB, externalSecure bool) *OPStorage {
+func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, db *database.DB, externalSecure bool) (*OPStorage, error) {
+	introspectionCache, err := newIntrospectionCache(config.IntrospectionCache, es)
+	if err != nil {
+		return nil, err
+	}
 	return &OPStorage{
 		repo:                              repo,
 		command:                           command,
@@ -195,7 +221,8 @@ func newStorage(config Config, command *command.Commands, query *query.Queries,
 		encAlg:                            encAlg,
 		locker:                            crdb.NewLocker(db.DB, locksTable, signingKey),
 		assetAPIPrefix:                    assets.AssetAPI(externalSecure),
-	}
+		introspectionCache:                introspectionCache,
+	}, nil
 }
 
 func (o *OPStorage) Health(ctx context.Context) error {
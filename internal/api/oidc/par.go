@@ -0,0 +1,257 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	defaultPushedAuthRequestEndpoint = "/oauth/v2/par"
+	defaultPushedAuthRequestLifetime = time.Minute
+)
+
+type pushedAuthRequestKey struct{}
+
+// pushedAuthRequestResponse is the response of the pushed authorization request endpoint (RFC 9126 section 2.2)
+type pushedAuthRequestResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int64  `json:"expires_in"`
+}
+
+// discoveryConfiguration extends the discovery configuration of the OP
+// with the metadata of pushed authorization requests (RFC 9126 section 5), DPoP (RFC 9449 section 5.1),
+// dynamic client registration (RFC 8414 section 2) and back-channel logout (OpenID Connect Back-Channel Logout 1.0 section 2.1)
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	PushedAuthorizationRequestEndpoint string   `json:"pushed_authorization_request_endpoint,omitempty"`
+	DPoPSigningAlgValuesSupported      []string `json:"dpop_signing_alg_values_supported,omitempty"`
+	RegistrationEndpoint               string   `json:"registration_endpoint,omitempty"`
+	BackChannelLogoutSupported         bool     `json:"backchannel_logout_supported,omitempty"`
+	// BackChannelLogoutSessionSupported is set, because the logout tokens always contain the `sid` claim
+	BackChannelLogoutSessionSupported bool `json:"backchannel_logout_session_supported,omitempty"`
+}
+
+// pushedAuthRequestInterceptor handles pushed authorization requests (RFC 9126), which are not supported by the OP.
+// It serves the pushed authorization request endpoint, replaces the request_uri of a pushed request on the
+// authorization endpoint by the pushed parameters and adds the endpoint to the discovery configuration.
+type pushedAuthRequestInterceptor struct {
+	storage  *OPStorage
+	provider *op.Provider
+	endpoint op.Endpoint
+	lifetime time.Duration
+	// registrationEndpoint of the [clientRegistrationHandler] is added to the discovery configuration as well
+	registrationEndpoint op.Endpoint
+}
+
+func newPushedAuthRequestInterceptor(config Config, storage *OPStorage) *pushedAuthRequestInterceptor {
+	endpoint := op.NewEndpoint(defaultPushedAuthRequestEndpoint)
+	if config.CustomEndpoints != nil && config.CustomEndpoints.PushedAuthRequest != nil {
+		endpoint = op.NewEndpointWithURL(config.CustomEndpoints.PushedAuthRequest.Path, config.CustomEndpoints.PushedAuthRequest.URL)
+	}
+	lifetime := config.PushedAuthRequestLifetime
+	if lifetime <= 0 {
+		lifetime = defaultPushedAuthRequestLifetime
+	}
+	return &pushedAuthRequestInterceptor{
+		storage:  storage,
+		endpoint: endpoint,
+		lifetime: lifetime,
+	}
+}
+
+// register adds the pushed authorization request endpoint to the router of the provider,
+// so all interceptors of the OP are applied to it as well
+func (i *pushedAuthRequestInterceptor) register(provider *op.Provider) {
+	i.provider = provider
+	if router, ok := provider.HttpHandler().(*mux.Router); ok {
+		router.HandleFunc(i.endpoint.Relative(), i.pushAuthRequest)
+	}
+}
+
+func (i *pushedAuthRequestInterceptor) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if i.provider == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		switch r.URL.Path {
+		case i.provider.AuthorizationEndpoint().Relative():
+			i.resolveRequestURI(w, r, next)
+		case oidc.DiscoveryEndpoint:
+			httphelper.MarshalJSON(w, &discoveryConfiguration{
+				DiscoveryConfiguration:             op.CreateDiscoveryConfig(r, i.provider, i.provider.Storage()),
+				PushedAuthorizationRequestEndpoint: i.endpoint.Absolute(op.IssuerFromContext(r.Context())),
+				DPoPSigningAlgValuesSupported:      authz.DPoPSigningAlgorithms,
+				RegistrationEndpoint:               i.registrationEndpoint.Absolute(op.IssuerFromContext(r.Context())),
+				BackChannelLogoutSupported:         true,
+				BackChannelLogoutSessionSupported:  true,
+			})
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// pushAuthRequest validates the authorization request of an authenticated (or public) client
+// and stores it for the lifetime of the returned request_uri (RFC 9126 section 2)
+func (i *pushedAuthRequestInterceptor) pushAuthRequest(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.NewSpan(r.Context())
+	var err error
+	defer func() { span.EndWithError(err) }()
+
+	if r.Method != http.MethodPost {
+		err = oidc.ErrInvalidRequest().WithDescription("pushed authorization requests must be sent using POST")
+		op.RequestError(w, r, err)
+		return
+	}
+	client, err := i.authenticateClient(ctx, r)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	authReq, err := i.validateAuthRequest(ctx, r, client)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	now := time.Now()
+	pushed, err := i.storage.repo.PushAuthRequest(ctx, &domain.PushedAuthRequest{
+		ClientID:     authReq.ClientID,
+		Parameters:   pushedAuthRequestParameters(r.PostForm),
+		CreationDate: now,
+		Expiration:   now.Add(i.lifetime),
+	})
+	if err != nil {
+		err = oidc.DefaultToServerError(err, "unable to save pushed authorization request")
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, &pushedAuthRequestResponse{
+		RequestURI: pushed.RequestURI(),
+		ExpiresIn:  int64(i.lifetime / time.Second),
+	}, http.StatusCreated)
+}
+
+// authenticateClient authenticates the client the same way as on the token endpoint.
+// Only public clients (auth method none) are allowed to push requests without authentication.
+func (i *pushedAuthRequestInterceptor) authenticateClient(ctx context.Context, r *http.Request) (op.Client, error) {
+	clientID, authenticated, err := op.ClientIDFromRequest(r, i.provider)
+	if err != nil {
+		return nil, err
+	}
+	if clientSecret := r.PostForm.Get("client_secret"); !authenticated && clientSecret != "" {
+		if err = i.storage.AuthorizeClientIDSecret(ctx, clientID, clientSecret); err != nil {
+			return nil, oidc.ErrUnauthorizedClient().WithParent(err)
+		}
+		authenticated = true
+	}
+	client, err := i.storage.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	if !authenticated && client.AuthMethod() != oidc.AuthMethodNone {
+		return nil, oidc.ErrInvalidClient().WithDescription("client must be authenticated")
+	}
+	return client, nil
+}
+
+// validateAuthRequest validates the pushed parameters like the authorization endpoint does,
+// including the signature of the request object (RFC 9101) against the keys of the client
+func (i *pushedAuthRequestInterceptor) validateAuthRequest(ctx context.Context, r *http.Request, client op.Client) (*oidc.AuthRequest, error) {
+	if r.PostForm.Has("request_uri") {
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri must not be pushed")
+	}
+	authReq, err := op.ParseAuthorizeRequest(r, i.provider.Decoder())
+	if err != nil {
+		return nil, err
+	}
+	if authReq.ClientID != client.GetID() {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id does not match the authenticated client")
+	}
+	if authReq.RequestParam != "" {
+		if !i.provider.RequestObjectSupported() {
+			return nil, oidc.ErrRequestNotSupported()
+		}
+		authReq, err = op.ParseRequestObject(ctx, authReq, i.provider.Storage(), op.IssuerFromContext(ctx))
+		if err != nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("invalid request object").WithParent(err)
+		}
+	}
+	if authReq.RedirectURI == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("auth request is missing redirect_uri")
+	}
+	if _, err = op.ValidateAuthRequest(ctx, authReq, i.provider.Storage(), i.provider.IDTokenHintVerifier(ctx)); err != nil {
+		return nil, err
+	}
+	return authReq, nil
+}
+
+// resolveRequestURI replaces the parameters of an authorization request referencing a pushed request
+// by the pushed ones, so the OP handles it as if the client had sent them directly (RFC 9126 section 4)
+func (i *pushedAuthRequestInterceptor) resolveRequestURI(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	if err := r.ParseForm(); err != nil || !strings.HasPrefix(r.Form.Get("request_uri"), domain.PushedAuthRequestURIPrefix) {
+		next.ServeHTTP(w, r)
+		return
+	}
+	ctx := r.Context()
+	pushed, err := i.storage.repo.PushedAuthRequestByURI(ctx, r.Form.Get("request_uri"), r.Form.Get("client_id"))
+	if err != nil {
+		op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().WithDescription("request_uri is invalid or expired").WithParent(err), i.provider.Encoder())
+		return
+	}
+	r = r.WithContext(context.WithValue(ctx, pushedAuthRequestKey{}, pushed))
+	r.Form = pushed.Parameters
+	r.PostForm = url.Values{}
+	r.URL.RawQuery = pushed.Parameters.Encode()
+	next.ServeHTTP(w, r)
+}
+
+func pushedAuthRequestFromCtx(ctx context.Context) *domain.PushedAuthRequest {
+	pushed, _ := ctx.Value(pushedAuthRequestKey{}).(*domain.PushedAuthRequest)
+	return pushed
+}
+
+// checkPushedAuthRequest ensures that clients requiring pushed authorization requests
+// only use the request_uri of a request pushed by themselves
+func (o *OPStorage) checkPushedAuthRequest(ctx context.Context, clientID string) error {
+	if pushed := pushedAuthRequestFromCtx(ctx); pushed != nil {
+		if pushed.ClientID != clientID {
+			return oidc.ErrInvalidRequest().WithDescription("request_uri was not issued to the client")
+		}
+		return nil
+	}
+	app, err := o.query.AppByOIDCClientID(ctx, clientID, false)
+	if err != nil {
+		return err
+	}
+	if app.OIDCConfig != nil && app.OIDCConfig.RequirePushedAuthRequests {
+		return oidc.ErrInvalidRequest().WithDescription("pushed authorization request required")
+	}
+	return nil
+}
+
+// pushedAuthRequestParameters returns the authorization request parameters,
+// without the credentials used for the authentication of the client
+func pushedAuthRequestParameters(form url.Values) url.Values {
+	parameters := make(url.Values, len(form))
+	for key, values := range form {
+		switch key {
+		case "client_secret", "client_assertion", "client_assertion_type":
+			continue
+		}
+		parameters[key] = values
+	}
+	return parameters
+}
@@ -0,0 +1,35 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func Test_pushedAuthRequestParameters(t *testing.T) {
+	got := pushedAuthRequestParameters(url.Values{
+		"client_id":             {"client1"},
+		"client_secret":         {"secret"},
+		"client_assertion":      {"assertion"},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"redirect_uri":          {"https://client.com/callback"},
+		"scope":                 {"openid profile"},
+	})
+	assert.Equal(t, url.Values{
+		"client_id":    {"client1"},
+		"redirect_uri": {"https://client.com/callback"},
+		"scope":        {"openid profile"},
+	}, got)
+}
+
+func TestOPStorage_checkPushedAuthRequest(t *testing.T) {
+	ctx := context.WithValue(context.Background(), pushedAuthRequestKey{}, &domain.PushedAuthRequest{ID: "123", ClientID: "client1"})
+	o := &OPStorage{}
+	assert.NoError(t, o.checkPushedAuthRequest(ctx, "client1"))
+	assert.ErrorIs(t, o.checkPushedAuthRequest(ctx, "client2"), oidc.ErrInvalidRequest())
+}
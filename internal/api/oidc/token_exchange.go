@@ -0,0 +1,657 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// UserIDTokenType is used as subject_token_type for impersonation, where the subject_token is the id of the user
+	UserIDTokenType oidc.TokenType = "urn:zitadel:params:oauth:token-type:user_id"
+	// ClaimActor is the actor claim (RFC 8693 section 4.1) added to tokens issued by delegation
+	ClaimActor = "act"
+)
+
+// exchangeToken is a verified subject or actor token of a token exchange
+type exchangeToken struct {
+	tokenIDOrToken string
+	userID         string
+	resourceOwner  string
+	audience       []string
+	scopes         []string
+	authTime       time.Time
+	authMethods    []string
+	claims         map[string]interface{}
+	// jwkThumbprint is the thumbprint of the DPoP key (RFC 9449) the token is bound to
+	jwkThumbprint string
+}
+
+// TokenExchangeRequest implements the [op.TokenExchangeRequest] for the
+// token exchange grant (RFC 8693) handled by the [tokenExchangeInterceptor]
+type TokenExchangeRequest struct {
+	subject            *exchangeToken
+	subjectTokenType   oidc.TokenType
+	actor              *exchangeToken
+	actorTokenType     oidc.TokenType
+	client             *Client
+	resource           []string
+	audience           []string
+	scopes             []string
+	requestedTokenType oidc.TokenType
+	impersonation      bool
+}
+
+func (r *TokenExchangeRequest) GetAMR() []string {
+	return r.subject.authMethods
+}
+
+func (r *TokenExchangeRequest) GetAudience() []string {
+	return r.audience
+}
+
+func (r *TokenExchangeRequest) GetResourses() []string {
+	return r.resource
+}
+
+func (r *TokenExchangeRequest) GetAuthTime() time.Time {
+	return r.subject.authTime
+}
+
+func (r *TokenExchangeRequest) GetClientID() string {
+	return r.client.GetID()
+}
+
+func (r *TokenExchangeRequest) GetScopes() []string {
+	return r.scopes
+}
+
+func (r *TokenExchangeRequest) GetSubject() string {
+	return r.subject.userID
+}
+
+func (r *TokenExchangeRequest) GetRequestedTokenType() oidc.TokenType {
+	return r.requestedTokenType
+}
+
+func (r *TokenExchangeRequest) GetExchangeSubject() string {
+	return r.subject.userID
+}
+
+func (r *TokenExchangeRequest) GetExchangeSubjectTokenType() oidc.TokenType {
+	return r.subjectTokenType
+}
+
+func (r *TokenExchangeRequest) GetExchangeSubjectTokenIDOrToken() string {
+	return r.subject.tokenIDOrToken
+}
+
+func (r *TokenExchangeRequest) GetExchangeSubjectTokenClaims() map[string]interface{} {
+	return r.subject.claims
+}
+
+func (r *TokenExchangeRequest) GetExchangeActor() string {
+	if r.actor == nil {
+		return ""
+	}
+	return r.actor.userID
+}
+
+func (r *TokenExchangeRequest) GetExchangeActorTokenType() oidc.TokenType {
+	return r.actorTokenType
+}
+
+func (r *TokenExchangeRequest) GetExchangeActorTokenIDOrToken() string {
+	if r.actor == nil {
+		return ""
+	}
+	return r.actor.tokenIDOrToken
+}
+
+func (r *TokenExchangeRequest) GetExchangeActorTokenClaims() map[string]interface{} {
+	if r.actor == nil {
+		return nil
+	}
+	return r.actor.claims
+}
+
+func (r *TokenExchangeRequest) SetCurrentScopes(scopes []string) {
+	r.scopes = scopes
+}
+
+func (r *TokenExchangeRequest) SetRequestedTokenType(tokenType oidc.TokenType) {
+	r.requestedTokenType = tokenType
+}
+
+func (r *TokenExchangeRequest) SetSubject(subject string) {
+	r.subject.userID = subject
+}
+
+// delegation is true if the token is issued to an actor, which will be mentioned in the `act` claim
+func (r *TokenExchangeRequest) delegation() bool {
+	return r.actor != nil && !r.impersonation
+}
+
+// actorClaim returns the `act` claim of the issued token,
+// previous actors of the subject token are nested as defined in RFC 8693 section 4.1
+func (r *TokenExchangeRequest) actorClaim(issuer string) map[string]interface{} {
+	act := map[string]interface{}{
+		"iss": issuer,
+		"sub": r.actor.userID,
+	}
+	if previous, ok := r.subject.claims[ClaimActor]; ok {
+		act[ClaimActor] = previous
+	}
+	return act
+}
+
+// tokenExchangeInterceptor handles the token exchange grant on the token endpoint.
+// The grant is handled by ZITADEL itself, because the verification of the subject and actor tokens
+// differs between opaque (V1 and V2) and JWT tokens; all other requests are passed to the OP.
+type tokenExchangeInterceptor struct {
+	storage  *OPStorage
+	provider *op.Provider
+}
+
+func (i *tokenExchangeInterceptor) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if i.provider == nil || r.Method != http.MethodPost || r.URL.Path != i.provider.TokenEndpoint().Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != string(oidc.GrantTypeTokenExchange) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		i.tokenExchange(w, r)
+	})
+}
+
+func (i *tokenExchangeInterceptor) tokenExchange(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.NewSpan(r.Context())
+	var err error
+	defer func() { span.EndWithError(err) }()
+
+	exchangeRequest, clientID, clientSecret, err := op.ParseTokenExchangeRequest(r, i.provider.Decoder())
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	opClient, err := op.AuthorizeTokenExchangeClient(ctx, clientID, clientSecret, i.provider)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	request, err := i.tokenExchangeRequest(ctx, exchangeRequest, opClient.(*Client))
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if err = i.storage.ValidateTokenExchangeRequest(ctx, request); err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if err = i.storage.CreateTokenExchangeRequest(ctx, request); err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	resp, err := op.CreateTokenExchangeResponse(ctx, request, opClient, i.provider)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+// tokenExchangeRequest verifies the subject and actor token of the request
+func (i *tokenExchangeInterceptor) tokenExchangeRequest(ctx context.Context, req *oidc.TokenExchangeRequest, client *Client) (*TokenExchangeRequest, error) {
+	if !op.ValidateGrantType(client, oidc.GrantTypeTokenExchange) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("client is not allowed to use the token exchange grant")
+	}
+	if req.SubjectToken == "" || req.SubjectTokenType == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject_token and subject_token_type are required")
+	}
+	if req.ActorToken != "" && req.ActorTokenType == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("actor_token_type is required for the actor_token")
+	}
+	request := &TokenExchangeRequest{
+		subjectTokenType:   req.SubjectTokenType,
+		actorTokenType:     req.ActorTokenType,
+		client:             client,
+		resource:           req.Resource,
+		audience:           req.Audience,
+		scopes:             req.Scopes,
+		requestedTokenType: req.RequestedTokenType,
+		impersonation:      req.SubjectTokenType == UserIDTokenType,
+	}
+	if request.impersonation && req.ActorToken == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("actor_token is required for impersonation")
+	}
+	var err error
+	if req.ActorToken != "" {
+		request.actor, err = i.verifyExchangeToken(ctx, req.ActorToken, req.ActorTokenType, client.GetID())
+		if err != nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("actor_token is invalid").WithParent(err)
+		}
+		if err = checkExchangeTokenProofOfPossession(ctx, request.actor); err != nil {
+			return nil, err
+		}
+	}
+	if request.impersonation {
+		request.subject = &exchangeToken{
+			tokenIDOrToken: req.SubjectToken,
+			userID:         req.SubjectToken,
+			authTime:       request.actor.authTime,
+			authMethods:    request.actor.authMethods,
+		}
+	} else {
+		request.subject, err = i.verifyExchangeToken(ctx, req.SubjectToken, req.SubjectTokenType, client.GetID())
+		if err != nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("subject_token is invalid").WithParent(err)
+		}
+		if err = checkExchangeTokenProofOfPossession(ctx, request.subject); err != nil {
+			return nil, err
+		}
+	}
+	user, err := i.storage.query.GetUserByID(ctx, true, request.subject.userID, false)
+	if err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject is invalid").WithParent(err)
+	}
+	if user.State != domain.UserStateActive {
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject is not active")
+	}
+	request.subject.resourceOwner = user.ResourceOwner
+	return request, nil
+}
+
+// verifyExchangeToken verifies the subject or actor token based on its type
+// and returns the user, audience and scopes it was issued for.
+// Refresh tokens can only be exchanged by the client they were issued to.
+func (i *tokenExchangeInterceptor) verifyExchangeToken(ctx context.Context, token string, tokenType oidc.TokenType, clientID string) (*exchangeToken, error) {
+	switch tokenType {
+	case oidc.AccessTokenType:
+		return i.verifyAccessToken(ctx, token)
+	case oidc.RefreshTokenType:
+		refreshToken, err := i.storage.TokenRequestByRefreshToken(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return refreshExchangeToken(refreshToken, token, clientID)
+	case oidc.IDTokenType:
+		claims, err := op.VerifyIDTokenHint[*oidc.IDTokenClaims](ctx, token, i.provider.IDTokenHintVerifier(ctx))
+		if err != nil {
+			return nil, err
+		}
+		// the id_token_hint verifier does not check the expiration
+		if time.Now().After(claims.GetExpiration()) {
+			return nil, errors.ThrowPermissionDenied(nil, "OIDC-Rj4gs", "id_token has expired")
+		}
+		return &exchangeToken{
+			tokenIDOrToken: token,
+			userID:         claims.GetSubject(),
+			audience:       claims.GetAudience(),
+			scopes:         []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopePhone, oidc.ScopeAddress},
+			authTime:       claims.GetAuthTime(),
+			authMethods:    claims.AuthenticationMethodsReferences,
+			claims:         claims.Claims,
+		}, nil
+	default:
+		return nil, oidc.ErrInvalidRequest().WithDescription("token type %s is not supported", tokenType)
+	}
+}
+
+// refreshExchangeToken returns the exchange token of a refresh token,
+// which must have been issued to the client of the token exchange
+func refreshExchangeToken(refreshToken op.RefreshTokenRequest, token, clientID string) (*exchangeToken, error) {
+	if refreshToken.GetClientID() != clientID {
+		return nil, errors.ThrowPermissionDenied(nil, "OIDC-Gt4ub", "refresh_token was not issued to the client")
+	}
+	return &exchangeToken{
+		tokenIDOrToken: token,
+		userID:         refreshToken.GetSubject(),
+		audience:       refreshToken.GetAudience(),
+		scopes:         refreshToken.GetScopes(),
+		authTime:       refreshToken.GetAuthTime(),
+		authMethods:    refreshToken.GetAMR(),
+		jwkThumbprint:  refreshTokenJWKThumbprint(refreshToken),
+	}, nil
+}
+
+func refreshTokenJWKThumbprint(refreshToken op.RefreshTokenRequest) string {
+	switch token := refreshToken.(type) {
+	case *RefreshTokenRequest:
+		return token.JWKThumbprint
+	case *RefreshTokenRequestV2:
+		return token.JWKThumbprint
+	default:
+		return ""
+	}
+}
+
+// checkExchangeTokenProofOfPossession ensures that a subject or actor token bound to a DPoP key (RFC 9449)
+// is only exchanged with a proof of the same key. The issued token is then bound to this key as well,
+// see [OPStorage.dpopThumbprint].
+func checkExchangeTokenProofOfPossession(ctx context.Context, token *exchangeToken) error {
+	if token.jwkThumbprint == "" || token.jwkThumbprint == dpopThumbprintFromCtx(ctx) {
+		return nil
+	}
+	return errInvalidDPoPProof().WithDescription("DPoP proof of the key the token is bound to is required")
+}
+
+func (i *tokenExchangeInterceptor) verifyAccessToken(ctx context.Context, token string) (*exchangeToken, error) {
+	var (
+		tokenID, subject string
+		claims           map[string]interface{}
+	)
+	if tokenIDSubject, err := i.provider.Crypto().Decrypt(token); err == nil {
+		var ok bool
+		tokenID, subject, ok = strings.Cut(tokenIDSubject, ":")
+		if !ok {
+			return nil, errors.ThrowPermissionDenied(nil, "OIDC-Wq3ra", "token is not valid or has expired")
+		}
+	} else {
+		accessTokenClaims, err := op.VerifyAccessToken[*oidc.AccessTokenClaims](ctx, token, i.provider.AccessTokenVerifier(ctx))
+		if err != nil {
+			return nil, err
+		}
+		tokenID, subject, claims = accessTokenClaims.JWTID, accessTokenClaims.Subject, accessTokenClaims.Claims
+	}
+
+	if strings.HasPrefix(tokenID, command.IDPrefixV2) {
+		accessToken, err := i.storage.query.ActiveAccessTokenByToken(ctx, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		return &exchangeToken{
+			tokenIDOrToken: tokenID,
+			userID:         accessToken.UserID,
+			audience:       accessToken.Audience,
+			scopes:         accessToken.Scope,
+			authTime:       accessToken.AuthTime,
+			authMethods:    AuthMethodTypesToAMR(accessToken.AuthMethods),
+			claims:         claims,
+			jwkThumbprint:  accessToken.JWKThumbprint,
+		}, nil
+	}
+	accessToken, err := i.storage.repo.TokenByIDs(ctx, subject, tokenID)
+	if err != nil {
+		return nil, errors.ThrowPermissionDenied(nil, "OIDC-Dsfb2", "token is not valid or has expired")
+	}
+	return &exchangeToken{
+		tokenIDOrToken: tokenID,
+		userID:         accessToken.UserID,
+		audience:       accessToken.Audience,
+		scopes:         accessToken.Scopes,
+		authTime:       accessToken.CreationDate,
+		claims:         claims,
+		jwkThumbprint:  accessToken.JWKThumbprint,
+	}, nil
+}
+
+// ValidateTokenExchangeRequest downscopes the requested token:
+// the audience must be known in the instance and the scopes must be part of the subject token.
+// Requests not created by the [tokenExchangeInterceptor] are not supported.
+func (o *OPStorage) ValidateTokenExchangeRequest(ctx context.Context, req op.TokenExchangeRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	request, ok := req.(*TokenExchangeRequest)
+	if !ok {
+		return oidc.ErrInvalidRequest().WithDescription("token exchange request is not supported")
+	}
+	switch request.requestedTokenType {
+	case "":
+		request.requestedTokenType = oidc.AccessTokenType
+	case oidc.AccessTokenType, oidc.IDTokenType:
+	default:
+		return oidc.ErrInvalidRequest().WithDescription("requested_token_type %s is not supported", request.requestedTokenType)
+	}
+	request.audience, err = o.tokenExchangeAudience(ctx, request)
+	if err != nil {
+		return err
+	}
+	request.scopes, err = o.tokenExchangeScopes(ctx, request)
+	return err
+}
+
+// tokenExchangeAudience returns the requested audience, which must consist of projects or clients of the instance.
+// Without requested audience, the audience of the subject token is kept, respectively the project of the client is used for impersonation.
+func (o *OPStorage) tokenExchangeAudience(ctx context.Context, request *TokenExchangeRequest) (audience []string, err error) {
+	audience = request.audience
+	if len(audience) == 0 {
+		if request.impersonation {
+			audience, err = o.audienceFromProjectID(ctx, request.client.app.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			audience = request.subject.audience
+		}
+	}
+	for _, aud := range request.audience {
+		if containsString(request.subject.audience, aud) {
+			continue
+		}
+		if _, err := o.query.ProjectIDFromClientID(ctx, aud, false); err == nil {
+			continue
+		}
+		if _, err := o.query.ProjectByID(ctx, false, aud, false); err != nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("audience %s is invalid", aud)
+		}
+	}
+	// the id_token is always issued for the client
+	if request.requestedTokenType == oidc.IDTokenType && !containsString(audience, request.GetClientID()) {
+		audience = append(audience, request.GetClientID())
+	}
+	return audience, nil
+}
+
+// tokenExchangeScopes returns the requested scopes, which must be part of the subject token,
+// respectively defaults to them. On impersonation the actor may request any scope.
+func (o *OPStorage) tokenExchangeScopes(ctx context.Context, request *TokenExchangeRequest) ([]string, error) {
+	scopes := request.scopes
+	switch {
+	case request.impersonation:
+		if len(scopes) == 0 {
+			scopes = []string{oidc.ScopeOpenID}
+		}
+	case len(scopes) == 0:
+		scopes = request.subject.scopes
+	default:
+		for _, scope := range scopes {
+			if !containsString(request.subject.scopes, scope) {
+				return nil, oidc.ErrInvalidScope().WithDescription("scope %s is not granted to the subject_token", scope)
+			}
+		}
+	}
+	return o.assertProjectRoleScopes(ctx, request.GetClientID(), scopes)
+}
+
+// CreateTokenExchangeRequest runs the actions of the token exchange flow and
+// records the exchange on the user, which checks the delegation and impersonation policy and permissions
+func (o *OPStorage) CreateTokenExchangeRequest(ctx context.Context, req op.TokenExchangeRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	request, ok := req.(*TokenExchangeRequest)
+	if !ok {
+		return oidc.ErrInvalidRequest().WithDescription("token exchange request is not supported")
+	}
+	if err = o.preTokenExchangeFlows(ctx, request); err != nil {
+		return err
+	}
+	exchange := &command.TokenExchange{
+		UserID:             request.subject.userID,
+		ResourceOwner:      request.subject.resourceOwner,
+		ClientID:           request.GetClientID(),
+		SubjectTokenType:   string(request.subjectTokenType),
+		RequestedTokenType: string(request.requestedTokenType),
+		Audience:           request.audience,
+		Scopes:             request.scopes,
+		Impersonation:      request.impersonation,
+	}
+	if request.actor != nil {
+		actor, err := o.query.GetUserByID(ctx, true, request.actor.userID, false)
+		if err != nil {
+			return err
+		}
+		exchange.Actor = &command.TokenActor{
+			UserID:        actor.ID,
+			ResourceOwner: actor.ResourceOwner,
+		}
+	}
+	err = o.command.ExchangeUserToken(setContextUserSystem(ctx), exchange)
+	if errors.IsPermissionDenied(err) {
+		return oidc.ErrAccessDenied().WithDescription("token exchange is not allowed").WithParent(err)
+	}
+	return err
+}
+
+// GetPrivateClaimsFromTokenExchangeRequest returns the private claims of a JWT access token
+// issued by token exchange, including the `act` claim on delegation
+func (o *OPStorage) GetPrivateClaimsFromTokenExchangeRequest(ctx context.Context, req op.TokenExchangeRequest) (claims map[string]interface{}, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	request, ok := req.(*TokenExchangeRequest)
+	if !ok {
+		return nil, oidc.ErrInvalidRequest().WithDescription("token exchange request is not supported")
+	}
+	scopes := request.client.RestrictAdditionalAccessTokenScopes()(request.scopes)
+	claims, err = o.GetPrivateClaimsFromScopes(ctx, request.GetSubject(), request.GetClientID(), scopes)
+	if err != nil {
+		return nil, err
+	}
+	if request.delegation() {
+		claims = appendClaim(claims, ClaimActor, request.actorClaim(op.IssuerFromContext(ctx)))
+	}
+	return claims, nil
+}
+
+// SetUserinfoFromTokenExchangeRequest sets the claims of an id_token
+// issued by token exchange, including the `act` claim on delegation
+func (o *OPStorage) SetUserinfoFromTokenExchangeRequest(ctx context.Context, userInfo *oidc.UserInfo, req op.TokenExchangeRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	request, ok := req.(*TokenExchangeRequest)
+	if !ok {
+		return oidc.ErrInvalidRequest().WithDescription("token exchange request is not supported")
+	}
+	scopes := request.client.RestrictAdditionalIdTokenScopes()(request.scopes)
+	if !request.client.IDTokenUserinfoClaimsAssertion() {
+		scopes = removeScopes(scopes, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopePhone, oidc.ScopeAddress)
+	}
+	if err = o.setUserinfo(ctx, userInfo, request.GetSubject(), request.GetClientID(), scopes, nil); err != nil {
+		return err
+	}
+	if request.delegation() {
+		userInfo.AppendClaims(ClaimActor, request.actorClaim(op.IssuerFromContext(ctx)))
+	}
+	return nil
+}
+
+// preTokenExchangeFlows runs the actions of the token exchange flow before a token is issued by token exchange,
+// the actions can reject the request, which results in an access_denied error
+func (o *OPStorage) preTokenExchangeFlows(ctx context.Context, request *TokenExchangeRequest) error {
+	user, err := o.query.GetUserByID(ctx, true, request.GetSubject(), false)
+	if err != nil {
+		return err
+	}
+	queriedActions, err := o.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeTokenExchange, domain.TriggerTypePreTokenExchange, user.ResourceOwner, false)
+	if err != nil {
+		return err
+	}
+
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("tokenExchangeRequest", object.TokenExchangeRequestField(&object.TokenExchangeRequest{
+				TokenRequest: object.TokenRequest{
+					UserID:      request.GetSubject(),
+					ClientID:    request.GetClientID(),
+					Scopes:      request.scopes,
+					Audience:    request.audience,
+					AuthTime:    request.GetAuthTime(),
+					AuthMethods: request.GetAMR(),
+				},
+				SubjectTokenType:   string(request.subjectTokenType),
+				RequestedTokenType: string(request.requestedTokenType),
+				ActorUserID:        request.GetExchangeActor(),
+				Impersonation:      request.impersonation,
+			})),
+			actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+				return func(call goja.FunctionCall) goja.Value {
+					return object.UserFromQuery(c, user)
+				}
+			}),
+		),
+	)
+	rejection := new(object.Rejection)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("reject", rejection.RejectFunc),
+		),
+	)
+
+	for _, action := range queriedActions {
+		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			action.Script,
+			action.Name,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypeTokenExchange, domain.TriggerTypePreTokenExchange, user.ID))...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if rejection.Rejected() {
+			var lang language.Tag
+			if user.Human != nil {
+				lang = user.Human.PreferredLanguage
+			}
+			return oidc.ErrAccessDenied().WithDescription(rejection.Message(lang)).WithParent(rejection.Error(lang))
+		}
+	}
+
+	return actions.CallFunctionTargets(ctx, o.query, domain.FlowTypeTokenExchange, domain.TriggerTypePreTokenExchange, &actions.FunctionPayload{
+		ResourceOwner: user.ResourceOwner,
+		UserID:        request.GetSubject(),
+		ClientID:      request.GetClientID(),
+	})
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func removeScopes(scopes []string, remove ...string) []string {
+	newScopes := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !containsString(remove, scope) {
+			newScopes = append(newScopes, scope)
+		}
+	}
+	return newScopes
+}
@@ -0,0 +1,163 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/user/model"
+)
+
+func TestTokenExchangeRequest_actorClaim(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *TokenExchangeRequest
+		want    map[string]interface{}
+	}{
+		{
+			"actor",
+			&TokenExchangeRequest{
+				subject: &exchangeToken{userID: "user1"},
+				actor:   &exchangeToken{userID: "actor1"},
+			},
+			map[string]interface{}{
+				"iss": "https://issuer.com",
+				"sub": "actor1",
+			},
+		},
+		{
+			"nested actor of subject token",
+			&TokenExchangeRequest{
+				subject: &exchangeToken{
+					userID: "user1",
+					claims: map[string]interface{}{
+						ClaimActor: map[string]interface{}{"sub": "actor0"},
+					},
+				},
+				actor: &exchangeToken{userID: "actor1"},
+			},
+			map[string]interface{}{
+				"iss": "https://issuer.com",
+				"sub": "actor1",
+				"act": map[string]interface{}{"sub": "actor0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.request.actorClaim("https://issuer.com"))
+		})
+	}
+}
+
+func TestTokenExchangeRequest_delegation(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *TokenExchangeRequest
+		want    bool
+	}{
+		{
+			"no actor",
+			&TokenExchangeRequest{},
+			false,
+		},
+		{
+			"actor",
+			&TokenExchangeRequest{actor: &exchangeToken{userID: "actor1"}},
+			true,
+		},
+		{
+			"impersonation",
+			&TokenExchangeRequest{actor: &exchangeToken{userID: "actor1"}, impersonation: true},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.request.delegation())
+		})
+	}
+}
+
+func Test_refreshExchangeToken(t *testing.T) {
+	refreshToken := &RefreshTokenRequest{&model.RefreshTokenView{
+		UserID:   "user1",
+		ClientID: "client1",
+		Audience: []string{"project1"},
+		Scopes:   []string{"openid"},
+	}}
+	t.Run("client of refresh token", func(t *testing.T) {
+		got, err := refreshExchangeToken(refreshToken, "token", "client1")
+		require.NoError(t, err)
+		assert.Equal(t, "user1", got.userID)
+		assert.Equal(t, []string{"project1"}, got.audience)
+		assert.Equal(t, []string{"openid"}, got.scopes)
+	})
+	t.Run("other client", func(t *testing.T) {
+		_, err := refreshExchangeToken(refreshToken, "token", "client2")
+		assert.True(t, errors.IsPermissionDenied(err))
+	})
+	t.Run("dpop bound", func(t *testing.T) {
+		got, err := refreshExchangeToken(&RefreshTokenRequest{&model.RefreshTokenView{ClientID: "client1", JWKThumbprint: "thumbprint"}}, "token", "client1")
+		require.NoError(t, err)
+		assert.Equal(t, "thumbprint", got.jwkThumbprint)
+	})
+}
+
+func Test_checkExchangeTokenProofOfPossession(t *testing.T) {
+	withProof := func(thumbprint string) context.Context {
+		return context.WithValue(context.Background(), dpopThumbprintKey{}, thumbprint)
+	}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		token   *exchangeToken
+		wantErr bool
+	}{
+		{
+			name:  "bearer token without proof",
+			ctx:   context.Background(),
+			token: &exchangeToken{},
+		},
+		{
+			name:  "bearer token with proof",
+			ctx:   withProof("thumbprint"),
+			token: &exchangeToken{},
+		},
+		{
+			name:    "bound token without proof",
+			ctx:     context.Background(),
+			token:   &exchangeToken{jwkThumbprint: "thumbprint"},
+			wantErr: true,
+		},
+		{
+			name:    "bound token with proof of other key",
+			ctx:     withProof("other"),
+			token:   &exchangeToken{jwkThumbprint: "thumbprint"},
+			wantErr: true,
+		},
+		{
+			name:  "bound token with proof",
+			ctx:   withProof("thumbprint"),
+			token: &exchangeToken{jwkThumbprint: "thumbprint"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkExchangeTokenProofOfPossession(tt.ctx, tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_removeScopes(t *testing.T) {
+	got := removeScopes([]string{"openid", "profile", "email", "urn:zitadel:iam:org:project:role:admin"}, "profile", "email")
+	assert.Equal(t, []string{"openid", "urn:zitadel:iam:org:project:role:admin"}, got)
+}
@@ -0,0 +1,265 @@
+package saml
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider/models"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	AttributeNameFormatBasic = "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"
+	AttributeProjectRoles    = "urn:zitadel:iam:org:project:roles"
+	AttributeUserMetadata    = "urn:zitadel:iam:user:metadata:"
+)
+
+// customAttributeSetter is implemented by [models.AttributeSetter] which are able
+// to add arbitrary attributes to the SAML response
+type customAttributeSetter interface {
+	SetCustomAttribute(name, friendlyName, nameFormat string, attributeValue []string)
+}
+
+type customAttribute struct {
+	name           string
+	friendlyName   string
+	nameFormat     string
+	attributeValue []string
+}
+
+// customAttributes contains the additional attributes of the SAML response, the key is the name of the attribute
+type customAttributes map[string]*customAttribute
+
+func (c customAttributes) add(name, friendlyName, nameFormat string, attributeValue []string) bool {
+	if _, ok := c[name]; ok {
+		return false
+	}
+	if nameFormat == "" {
+		nameFormat = AttributeNameFormatBasic
+	}
+	c[name] = &customAttribute{
+		name:           name,
+		friendlyName:   friendlyName,
+		nameFormat:     nameFormat,
+		attributeValue: attributeValue,
+	}
+	return true
+}
+
+// setTo sets the attributes (ordered by name) to the userinfo, if it supports custom attributes
+func (c customAttributes) setTo(userinfo models.AttributeSetter) {
+	if len(c) == 0 {
+		return
+	}
+	setter, ok := userinfo.(customAttributeSetter)
+	if !ok {
+		logging.WithFields("attributes", len(c)).Warn("saml attribute setter does not support custom attributes")
+		return
+	}
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attribute := c[name]
+		setter.SetCustomAttribute(attribute.name, attribute.friendlyName, attribute.nameFormat, attribute.attributeValue)
+	}
+}
+
+// customAttributes returns the built-in role and metadata attributes (if enabled on the application)
+// and the attributes set by the actions of the [domain.FlowTypeCustomiseSAMLResponse]
+func (p *Storage) customAttributes(ctx context.Context, user *query.User, applicationID string) (customAttributes, error) {
+	app, err := p.query.AppByID(ctx, applicationID, false)
+	if err != nil {
+		return nil, err
+	}
+	if app.SAMLConfig == nil {
+		return nil, nil
+	}
+	userGrants, err := p.userGrants(ctx, user.ID, app.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(customAttributes)
+	if app.SAMLConfig.RoleAssertion {
+		groupGrants, err := p.groupGrants(ctx, user.ID, app.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if roles := grantedRoles(userGrants, groupGrants); len(roles) > 0 {
+			attributes.add(AttributeProjectRoles, "roles", AttributeNameFormatBasic, roles)
+		}
+	}
+	if app.SAMLConfig.MetadataAssertion {
+		metadata, err := p.query.SearchUserMetadata(ctx, true, user.ID, &query.UserMetadataSearchQueries{}, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, md := range metadata.Metadata {
+			attributes.add(AttributeUserMetadata+md.Key, md.Key, AttributeNameFormatBasic, []string{base64.RawURLEncoding.EncodeToString(md.Value)})
+		}
+	}
+	if err = p.customiseSAMLResponseFlow(ctx, user, userGrants, attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+func (p *Storage) userGrants(ctx context.Context, userID, projectID string) (*query.UserGrants, error) {
+	projectQuery, err := query.NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	return p.query.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{projectQuery, userIDQuery},
+	}, true, false)
+}
+
+// groupGrants returns the grants of the project the user inherits through its group memberships
+func (p *Storage) groupGrants(ctx context.Context, userID, projectID string) (*query.GroupGrants, error) {
+	projectQuery, err := query.NewGroupGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return p.query.GroupGrantsByUserID(ctx, userID, &query.GroupGrantSearchQueries{
+		Queries: []query.SearchQuery{projectQuery},
+	}, true)
+}
+
+// grantedRoles returns the distinct (ordered) role keys of all user and group grants
+func grantedRoles(userGrants *query.UserGrants, groupGrants *query.GroupGrants) []string {
+	unique := make(map[string]struct{})
+	roles := make([]string, 0)
+	add := func(granted []string) {
+		for _, role := range granted {
+			if _, ok := unique[role]; ok {
+				continue
+			}
+			unique[role] = struct{}{}
+			roles = append(roles, role)
+		}
+	}
+	for _, grant := range userGrants.UserGrants {
+		add(grant.Roles)
+	}
+	for _, grant := range groupGrants.GroupGrants {
+		add(grant.Roles)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+func (p *Storage) customiseSAMLResponseFlow(ctx context.Context, user *query.User, userGrants *query.UserGrants, attributes customAttributes) error {
+	queriedActions, err := p.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeCustomiseSAMLResponse, domain.TriggerTypePreSAMLResponseCreation, user.ResourceOwner, false)
+	if err != nil {
+		return err
+	}
+
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+				return func(call goja.FunctionCall) goja.Value {
+					return object.UserFromQuery(c, user)
+				}
+			}),
+			actions.SetFields("user",
+				actions.SetFields("getMetadata", func(c *actions.FieldConfig) interface{} {
+					return func(goja.FunctionCall) goja.Value {
+						resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(user.ResourceOwner)
+						if err != nil {
+							logging.WithError(err).Debug("unable to create search query")
+							panic(err)
+						}
+						metadata, err := p.query.SearchUserMetadata(
+							ctx,
+							true,
+							user.ID,
+							&query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}},
+							false,
+						)
+						if err != nil {
+							logging.WithError(err).Info("unable to get md in action")
+							panic(err)
+						}
+						return object.UserMetadataListFromQuery(c, metadata)
+					}
+				}),
+				actions.SetFields("grants", func(c *actions.FieldConfig) interface{} {
+					return object.UserGrantsFromQuery(c, userGrants)
+				}),
+			),
+		),
+	)
+
+	for _, action := range queriedActions {
+		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+
+		apiFields := actions.WithAPIFields(
+			actions.SetFields("v1",
+				actions.SetFields("attributes",
+					actions.SetFields("setCustomAttribute", func(name, nameFormat string, attributeValue ...string) {
+						if !attributes.add(name, "", nameFormat, attributeValue) {
+							logging.WithFields("action", action.Name, "attribute", name).Info("attribute already exists")
+						}
+					}),
+				),
+				actions.SetFields("user",
+					actions.SetFields("setMetadata", func(call goja.FunctionCall) goja.Value {
+						if len(call.Arguments) != 2 {
+							panic("exactly 2 (key, value) arguments expected")
+						}
+						key := call.Arguments[0].Export().(string)
+						val := call.Arguments[1].Export()
+
+						value, err := json.Marshal(val)
+						if err != nil {
+							logging.WithError(err).Debug("unable to marshal")
+							panic(err)
+						}
+
+						metadata := &domain.Metadata{
+							Key:   key,
+							Value: value,
+						}
+						if _, err = p.command.SetUserMetadata(ctx, metadata, user.ID, user.ResourceOwner); err != nil {
+							logging.WithError(err).Info("unable to set md in action")
+							panic(err)
+						}
+						return nil
+					}),
+				),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			action.Script,
+			action.Name,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypeCustomiseSAMLResponse, domain.TriggerTypePreSAMLResponseCreation, user.ID))...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	return actions.CallFunctionTargets(ctx, p.query, domain.FlowTypeCustomiseSAMLResponse, domain.TriggerTypePreSAMLResponseCreation, &actions.FunctionPayload{
+		ResourceOwner: user.ResourceOwner,
+		UserID:        user.ID,
+	})
+}
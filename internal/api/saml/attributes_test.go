@@ -0,0 +1,98 @@
+package saml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type testAttributeSetter struct {
+	custom []*customAttribute
+}
+
+func (t *testAttributeSetter) SetEmail(string)     {}
+func (t *testAttributeSetter) SetFullName(string)  {}
+func (t *testAttributeSetter) SetGivenName(string) {}
+func (t *testAttributeSetter) SetSurname(string)   {}
+func (t *testAttributeSetter) SetUserID(string)    {}
+func (t *testAttributeSetter) SetUsername(string)  {}
+
+func (t *testAttributeSetter) SetCustomAttribute(name, friendlyName, nameFormat string, attributeValue []string) {
+	t.custom = append(t.custom, &customAttribute{
+		name:           name,
+		friendlyName:   friendlyName,
+		nameFormat:     nameFormat,
+		attributeValue: attributeValue,
+	})
+}
+
+func Test_customAttributes(t *testing.T) {
+	attributes := make(customAttributes)
+	assert.True(t, attributes.add(AttributeProjectRoles, "roles", "", []string{"admin", "user"}))
+	assert.True(t, attributes.add("custom", "", "urn:oasis:names:tc:SAML:2.0:attrname-format:uri", []string{"value"}))
+	assert.False(t, attributes.add(AttributeProjectRoles, "", "", []string{"other"}))
+
+	setter := new(testAttributeSetter)
+	attributes.setTo(setter)
+	assert.Equal(t, []*customAttribute{
+		{
+			name:           "custom",
+			nameFormat:     "urn:oasis:names:tc:SAML:2.0:attrname-format:uri",
+			attributeValue: []string{"value"},
+		},
+		{
+			name:           AttributeProjectRoles,
+			friendlyName:   "roles",
+			nameFormat:     AttributeNameFormatBasic,
+			attributeValue: []string{"admin", "user"},
+		},
+	}, setter.custom)
+}
+
+func Test_grantedRoles(t *testing.T) {
+	tests := []struct {
+		name        string
+		grants      *query.UserGrants
+		groupGrants *query.GroupGrants
+		want        []string
+	}{
+		{
+			name:        "no grants",
+			grants:      &query.UserGrants{},
+			groupGrants: &query.GroupGrants{},
+			want:        []string{},
+		},
+		{
+			name: "distinct and ordered roles",
+			grants: &query.UserGrants{
+				UserGrants: []*query.UserGrant{
+					{Roles: []string{"user", "admin"}},
+					{Roles: []string{"admin", "viewer"}},
+				},
+			},
+			groupGrants: &query.GroupGrants{},
+			want:        []string{"admin", "user", "viewer"},
+		},
+		{
+			name: "roles of groups",
+			grants: &query.UserGrants{
+				UserGrants: []*query.UserGrant{
+					{Roles: []string{"user"}},
+				},
+			},
+			groupGrants: &query.GroupGrants{
+				GroupGrants: []*query.GroupGrant{
+					{Roles: []string{"user", "editor"}},
+				},
+			},
+			want: []string{"editor", "user"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grantedRoles(tt.grants, tt.groupGrants))
+		})
+	}
+}
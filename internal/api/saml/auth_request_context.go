@@ -0,0 +1,59 @@
+package saml
+
+import (
+	"context"
+	"net/http"
+)
+
+type authRequestContextKey struct{}
+
+// authRequestContext holds information about the auth request handled in the current http request.
+// It is set as pointer into the context, so the storage is able to provide the information
+// (e.g. the application of the auth request) to later calls of the same request,
+// since the SAML library does not pass them (e.g. to SetUserinfoWithUserID).
+type authRequestContext struct {
+	applicationID string
+	// recordSession is set if the SAML session is recorded after the response is created (see [Provider.recordSAMLSession]),
+	// the storage then only sets the session instead of adding it
+	recordSession bool
+	session       *samlSession
+}
+
+// authRequestContextHandler sets an empty [authRequestContext] into the context of every request,
+// unless there's already one
+func authRequestContextHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(authRequestContextKey{}).(*authRequestContext); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authRequestContextKey{}, new(authRequestContext))))
+	})
+}
+
+func setApplicationIDToContext(ctx context.Context, applicationID string) {
+	authReqCtx, ok := ctx.Value(authRequestContextKey{}).(*authRequestContext)
+	if !ok {
+		return
+	}
+	authReqCtx.applicationID = applicationID
+}
+
+func applicationIDFromContext(ctx context.Context) string {
+	authReqCtx, ok := ctx.Value(authRequestContextKey{}).(*authRequestContext)
+	if !ok {
+		return ""
+	}
+	return authReqCtx.applicationID
+}
+
+// setSAMLSessionToContext sets the session to be recorded after the response is created
+// and returns false if the session has to be added directly
+func setSAMLSessionToContext(ctx context.Context, session *samlSession) bool {
+	authReqCtx, ok := ctx.Value(authRequestContextKey{}).(*authRequestContext)
+	if !ok || !authReqCtx.recordSession {
+		return false
+	}
+	authReqCtx.session = session
+	return true
+}
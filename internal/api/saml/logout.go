@@ -0,0 +1,658 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+	"github.com/zitadel/saml/pkg/provider/xml/xml_dsig"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	EndpointSingleLogout    = "/" + provider.DefaultSingleLogOutEndpoint
+	EndpointPropagateLogout = EndpointSingleLogout + "/propagate"
+
+	QueryPostLogoutRedirectURI = "post_logout_redirect_uri"
+	QueryClientID              = "client_id"
+
+	querySAMLRequest  = "SAMLRequest"
+	querySAMLResponse = "SAMLResponse"
+	queryRelayState   = "RelayState"
+	querySigAlg       = "SigAlg"
+	querySignature    = "Signature"
+
+	nameIDFormatEntity       = "urn:oasis:names:tc:SAML:2.0:nameid-format:entity"
+	nameIDFormatEmailAddress = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+
+	logoutRequestLifetime = 5 * time.Minute
+)
+
+// PropagateLogoutURL returns the url of the endpoint, which propagates the logout of the user agent
+// to all SAML service providers the user signed into.
+// The user agent will be redirected to the postLogoutRedirectURI afterwards,
+// which has to be registered on the OIDC application of the clientID.
+func PropagateLogoutURL(issuer, clientID, postLogoutRedirectURI string) string {
+	logoutURL := strings.TrimSuffix(issuer, "/") + EndpointPropagateLogout
+	values := make(url.Values, 2)
+	if clientID != "" {
+		values.Set(QueryClientID, clientID)
+	}
+	if postLogoutRedirectURI != "" {
+		values.Set(QueryPostLogoutRedirectURI, postLogoutRedirectURI)
+	}
+	if len(values) == 0 {
+		return logoutURL
+	}
+	return logoutURL + "?" + values.Encode()
+}
+
+// PropagateLogoutURLFromContext returns the url of the endpoint, which propagates the logout of the user agent
+// based on the SAML issuer of the context. The user agent will be redirected to the logged out page of the login afterwards.
+func PropagateLogoutURLFromContext(ctx context.Context) string {
+	return PropagateLogoutURL(provider.IssuerFromContext(ctx), "", "")
+}
+
+// logoutMessage is a signed LogoutRequest or LogoutResponse, encoded for the binding of the service provider
+type logoutMessage struct {
+	URL        string
+	Post       bool
+	Param      string
+	Value      string
+	RelayState string
+}
+
+type logoutData struct {
+	// Requests are sent to all service providers in hidden iframes
+	Requests []*logoutMessage
+	// Response is sent (as main navigation) to the service provider initiating the logout,
+	// if not set, the user agent will be redirected to the RedirectURL
+	Response    *logoutMessage
+	RedirectURL string
+}
+
+// singleLogoutHandler handles the LogoutRequests of the service providers (SP-initiated logout)
+// and the LogoutResponses to the LogoutRequests sent on the propagation of the logout.
+func (p *Provider) singleLogoutHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// the LogoutResponse of a service provider is received in an iframe, so there's nothing left to do
+	if r.Form.Get(querySAMLRequest) == "" && r.Form.Get(querySAMLResponse) != "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	binding := provider.RedirectBinding
+	if r.Method == http.MethodPost {
+		binding = provider.PostBinding
+	}
+	data, err := p.singleLogout(r.Context(), binding, r.Form)
+	if err != nil {
+		http.Error(w, err.Error(), logoutErrorStatus(err))
+		return
+	}
+	p.renderLogout(w, data)
+}
+
+// propagateLogoutHandler sends LogoutRequests to all service providers, the user agent has a SAML session on (IdP-initiated logout)
+// and redirects to the post_logout_redirect_uri afterwards.
+func (p *Provider) propagateLogoutHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	redirectURL, err := p.postLogoutRedirectURL(ctx, r.URL.Query().Get(QueryClientID), r.URL.Query().Get(QueryPostLogoutRedirectURI))
+	if err != nil {
+		http.Error(w, err.Error(), logoutErrorStatus(err))
+		return
+	}
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+		return
+	}
+	sessions, err := p.storage.query.SAMLSessionsByUserAgentID(ctx, true, userAgentID)
+	if err != nil {
+		http.Error(w, err.Error(), logoutErrorStatus(err))
+		return
+	}
+	if len(sessions) == 0 {
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+		return
+	}
+	requests, err := p.logoutRequests(ctx, sessions, "")
+	if err != nil {
+		http.Error(w, err.Error(), logoutErrorStatus(err))
+		return
+	}
+	if err = p.storage.command.TerminateSAMLSessions(ctx, userAgentID, terminatedSessions(sessions)...); err != nil {
+		http.Error(w, err.Error(), logoutErrorStatus(err))
+		return
+	}
+	p.renderLogout(w, &logoutData{
+		Requests:    requests,
+		RedirectURL: redirectURL,
+	})
+}
+
+func (p *Provider) singleLogout(ctx context.Context, binding string, form url.Values) (_ *logoutData, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	logoutRequest, sp, err := p.verifiedLogoutRequest(ctx, binding, form)
+	if err != nil {
+		return nil, err
+	}
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil, errors.ThrowPreconditionFailed(nil, "SAML-Ks8dq", "no user agent id")
+	}
+	sessions, err := p.storage.query.SAMLSessionsByUserAgentID(ctx, true, userAgentID)
+	if err != nil {
+		return nil, err
+	}
+	session := logoutSession(sessions, sp.ID, logoutRequest)
+	if session == nil {
+		return nil, errors.ThrowNotFound(nil, "SAML-Hs5le", "Errors.SAML.LogoutRequest.SessionNotFound")
+	}
+	// only the user of the service provider's session is signed out
+	sessions = userSessions(sessions, session.UserID)
+	if err = p.signOut(ctx, userAgentID, session.UserID); err != nil {
+		return nil, err
+	}
+	requests, err := p.logoutRequests(ctx, sessions, sp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.storage.command.TerminateSAMLSessions(ctx, userAgentID, terminatedSessions(sessions)...); err != nil {
+		return nil, err
+	}
+	response, err := p.logoutResponse(ctx, sp, logoutRequest.Id, form.Get(queryRelayState))
+	if err != nil {
+		return nil, err
+	}
+	return &logoutData{
+		Requests:    requests,
+		Response:    response,
+		RedirectURL: login.DefaultLoggedOutPath,
+	}, nil
+}
+
+// verifiedLogoutRequest decodes the LogoutRequest of the binding and verifies its signature and validity
+func (p *Provider) verifiedLogoutRequest(ctx context.Context, binding string, form url.Values) (*samlp.LogoutRequestType, *serviceprovider.ServiceProvider, error) {
+	samlRequest := form.Get(querySAMLRequest)
+	logoutRequest, err := decodeLogoutRequest(binding, samlRequest)
+	if err != nil {
+		return nil, nil, errors.ThrowInvalidArgument(err, "SAML-Jd9fw", "Errors.SAML.LogoutRequest.Invalid")
+	}
+	if logoutRequest.Issuer == nil || logoutRequest.Issuer.Text == "" {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "SAML-Pw2ns", "Errors.SAML.LogoutRequest.Invalid")
+	}
+	if logoutRequest.NameID == nil || logoutRequest.NameID.Text == "" {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "SAML-Ne4lq", "Errors.SAML.LogoutRequest.Invalid")
+	}
+	sp, err := p.storage.GetEntityByID(ctx, logoutRequest.Issuer.Text)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = p.verifyLogoutRequestSignature(binding, form, logoutRequest, sp); err != nil {
+		return nil, nil, errors.ThrowPermissionDenied(err, "SAML-Qe8vd", "Errors.SAML.LogoutRequest.InvalidSignature")
+	}
+	if logoutRequest.NotOnOrAfter != "" {
+		notOnOrAfter, err := time.Parse(timeFormat, logoutRequest.NotOnOrAfter)
+		if err != nil || !time.Now().Before(notOnOrAfter) {
+			return nil, nil, errors.ThrowInvalidArgument(err, "SAML-Ux3kd", "Errors.SAML.LogoutRequest.Expired")
+		}
+	}
+	return logoutRequest, sp, nil
+}
+
+func (p *Provider) verifyLogoutRequestSignature(binding string, form url.Values, logoutRequest *samlp.LogoutRequestType, sp *serviceprovider.ServiceProvider) error {
+	signatureRequired := logoutSignatureRequired(p.conf.WantAuthRequestsSigned, sp)
+	if binding == provider.PostBinding {
+		if !signatureRequired && logoutRequest.Signature == nil {
+			return nil
+		}
+		data, err := base64.StdEncoding.DecodeString(form.Get(querySAMLRequest))
+		if err != nil {
+			return err
+		}
+		return sp.ValidatePostSignature(string(data))
+	}
+	if !signatureRequired && form.Get(querySignature) == "" {
+		return nil
+	}
+	return sp.ValidateRedirectSignature(form.Get(querySAMLRequest), form.Get(queryRelayState), form.Get(querySigAlg), form.Get(querySignature))
+}
+
+// logoutSignatureRequired returns if the LogoutRequest of the service provider must be signed.
+// Unsigned requests are only accepted if the IdP explicitly doesn't want signed requests
+// and the service provider doesn't sign its AuthnRequests either.
+// The values are parsed, because the config might be decoded as "1" instead of "true".
+func logoutSignatureRequired(wantAuthRequestsSigned string, sp *serviceprovider.ServiceProvider) bool {
+	if wanted, err := strconv.ParseBool(wantAuthRequestsSigned); err != nil || wanted {
+		return true
+	}
+	if sp.Metadata == nil || sp.Metadata.SPSSODescriptor == nil {
+		return true
+	}
+	signed, err := strconv.ParseBool(sp.Metadata.SPSSODescriptor.AuthnRequestsSigned)
+	return err == nil && signed
+}
+
+// logoutSession returns the session of the service provider matching the NameID
+// and (if both are known) the SessionIndex of the LogoutRequest
+func logoutSession(sessions []*query.SAMLSession, applicationID string, logoutRequest *samlp.LogoutRequestType) *query.SAMLSession {
+	for _, session := range sessions {
+		if session.ApplicationID != applicationID || session.NameID != logoutRequest.NameID.Text {
+			continue
+		}
+		if session.SessionIndex == "" || len(logoutRequest.SessionIndex) == 0 {
+			return session
+		}
+		for _, sessionIndex := range logoutRequest.SessionIndex {
+			if sessionIndex == session.SessionIndex {
+				return session
+			}
+		}
+	}
+	return nil
+}
+
+func userSessions(sessions []*query.SAMLSession, userID string) []*query.SAMLSession {
+	filtered := make([]*query.SAMLSession, 0, len(sessions))
+	for _, session := range sessions {
+		if session.UserID == userID {
+			filtered = append(filtered, session)
+		}
+	}
+	return filtered
+}
+
+// signOut terminates the user session of the user on the user agent,
+// the user is used as editor as well
+func (p *Provider) signOut(ctx context.Context, userAgentID, userID string) error {
+	userIDs, err := p.storage.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil {
+		return err
+	}
+	for _, id := range userIDs {
+		if id == userID {
+			return p.storage.command.HumansSignOut(authz.SetCtxData(ctx, authz.CtxData{UserID: userID}), userAgentID, []string{userID})
+		}
+	}
+	return nil
+}
+
+// logoutRequests creates the signed LogoutRequests for all service providers of the sessions,
+// except the one initiating the logout and the ones without single logout service
+func (p *Provider) logoutRequests(ctx context.Context, sessions []*query.SAMLSession, initiatorID string) ([]*logoutMessage, error) {
+	signer, err := p.logoutSigner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issuer := p.entityID(ctx)
+	now := time.Now().UTC()
+	requests := make([]*logoutMessage, 0, len(sessions))
+	for _, session := range sessions {
+		if session.ApplicationID == initiatorID {
+			continue
+		}
+		sp, err := p.storage.GetEntityByID(ctx, session.EntityID)
+		if err != nil {
+			logging.WithFields("application", session.ApplicationID).WithError(err).Warn("unable to propagate saml logout")
+			continue
+		}
+		location, _, binding := singleLogoutService(sp)
+		if location == "" {
+			continue
+		}
+		logoutRequest := &samlp.LogoutRequestType{
+			Id:           provider.NewID(),
+			Version:      "2.0",
+			IssueInstant: now.Format(timeFormat),
+			NotOnOrAfter: now.Add(logoutRequestLifetime).Format(timeFormat),
+			Destination:  location,
+			Issuer:       &saml.NameIDType{Format: nameIDFormatEntity, Text: issuer},
+			NameID:       &saml.NameIDType{Format: sessionNameIDFormat(session), Text: session.NameID},
+		}
+		if session.SessionIndex != "" {
+			logoutRequest.SessionIndex = []string{session.SessionIndex}
+		}
+		request, err := signer.logoutRequest(location, binding, logoutRequest)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// sessionNameIDFormat returns the NameID format of the assertion issued for the session,
+// sessions recorded before the format was stored were issued with the emailAddress format of the SAML library
+func sessionNameIDFormat(session *query.SAMLSession) string {
+	if session.NameIDFormat == "" {
+		return nameIDFormatEmailAddress
+	}
+	return session.NameIDFormat
+}
+
+// logoutResponse creates the signed (successful) LogoutResponse for the service provider initiating the logout
+func (p *Provider) logoutResponse(ctx context.Context, sp *serviceprovider.ServiceProvider, requestID, relayState string) (*logoutMessage, error) {
+	_, location, binding := singleLogoutService(sp)
+	if location == "" {
+		return nil, nil
+	}
+	signer, err := p.logoutSigner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return signer.logoutResponse(location, binding, relayState, &samlp.LogoutResponseType{
+		Id:           provider.NewID(),
+		InResponseTo: requestID,
+		Version:      "2.0",
+		IssueInstant: time.Now().UTC().Format(timeFormat),
+		Destination:  location,
+		Issuer:       &saml.NameIDType{Format: nameIDFormatEntity, Text: p.entityID(ctx)},
+		Status: samlp.StatusType{
+			StatusCode: samlp.StatusCodeType{
+				Value: provider.StatusCodeSuccess,
+			},
+		},
+	})
+}
+
+// postLogoutRedirectURL checks the redirectURI to be the logged out page of the login
+// or registered as post logout redirect uri on the OIDC application of the clientID
+func (p *Provider) postLogoutRedirectURL(ctx context.Context, clientID, redirectURI string) (string, error) {
+	if redirectURI == "" {
+		return login.DefaultLoggedOutPath, nil
+	}
+	redirect, err := url.Parse(redirectURI)
+	if err != nil {
+		return "", errors.ThrowInvalidArgument(err, "SAML-Ot6fs", "Errors.SAML.Logout.InvalidRedirectURI")
+	}
+	if redirect.Host == "" && redirect.Path == login.DefaultLoggedOutPath {
+		return redirectURI, nil
+	}
+	if clientID == "" {
+		return "", errors.ThrowInvalidArgument(nil, "SAML-Bv4nd", "Errors.SAML.Logout.InvalidRedirectURI")
+	}
+	app, err := p.storage.query.AppByOIDCClientID(ctx, clientID, false)
+	if err != nil {
+		return "", err
+	}
+	// the state is appended to the registered uri by the end_session endpoint
+	query := redirect.Query()
+	query.Del("state")
+	redirect.RawQuery = query.Encode()
+	for _, uri := range app.OIDCConfig.PostLogoutRedirectURIs {
+		if uri == redirect.String() {
+			return redirectURI, nil
+		}
+	}
+	return "", errors.ThrowInvalidArgument(nil, "SAML-Ye2ma", "Errors.SAML.Logout.InvalidRedirectURI")
+}
+
+func (p *Provider) entityID(ctx context.Context) string {
+	return p.metadataEndpoint.Absolute(provider.IssuerFromContext(ctx))
+}
+
+func (p *Provider) logoutSigner(ctx context.Context) (*logoutSigner, error) {
+	certAndKey, err := p.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if certAndKey == nil || len(certAndKey.Certificate) == 0 || certAndKey.Key == nil {
+		return nil, errors.ThrowInternal(nil, "SAML-Rn3ks", "Errors.SAML.SigningKeyNotFound")
+	}
+	return &logoutSigner{
+		certificate:        certAndKey.Certificate,
+		key:                certAndKey.Key,
+		signatureAlgorithm: p.conf.SignatureAlgorithm,
+	}, nil
+}
+
+func (p *Provider) renderLogout(w http.ResponseWriter, data *logoutData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := p.logoutTemplate.Execute(w, data); err != nil {
+		logging.WithError(err).Warn("unable to render saml logout")
+	}
+}
+
+func logoutErrorStatus(err error) int {
+	switch {
+	case errors.IsErrorInvalidArgument(err), errors.IsPreconditionFailed(err):
+		return http.StatusBadRequest
+	case errors.IsPermissionDenied(err):
+		return http.StatusForbidden
+	case errors.IsNotFound(err):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// singleLogoutService returns the first single logout service of the service provider
+// with a supported binding (redirect or post)
+func singleLogoutService(sp *serviceprovider.ServiceProvider) (location, responseLocation, binding string) {
+	if sp.Metadata == nil || sp.Metadata.SPSSODescriptor == nil {
+		return "", "", ""
+	}
+	for _, service := range sp.Metadata.SPSSODescriptor.SingleLogoutService {
+		if service.Binding != provider.RedirectBinding && service.Binding != provider.PostBinding {
+			continue
+		}
+		responseLocation = service.ResponseLocation
+		if responseLocation == "" {
+			responseLocation = service.Location
+		}
+		return service.Location, responseLocation, service.Binding
+	}
+	return "", "", ""
+}
+
+func terminatedSessions(sessions []*query.SAMLSession) []*command.SAMLSession {
+	terminated := make([]*command.SAMLSession, len(sessions))
+	for i, session := range sessions {
+		terminated[i] = &command.SAMLSession{
+			UserID:        session.UserID,
+			ResourceOwner: session.ResourceOwner,
+			ApplicationID: session.ApplicationID,
+		}
+	}
+	return terminated
+}
+
+// decodeLogoutRequest decodes the LogoutRequest, which is deflated in case of the redirect binding
+func decodeLogoutRequest(binding, samlRequest string) (*samlp.LogoutRequestType, error) {
+	if binding == provider.RedirectBinding {
+		return saml_xml.DecodeLogoutRequest("", samlRequest)
+	}
+	data, err := base64.StdEncoding.DecodeString(samlRequest)
+	if err != nil {
+		return nil, err
+	}
+	logoutRequest := new(samlp.LogoutRequestType)
+	if err = xml.Unmarshal(data, logoutRequest); err != nil {
+		return nil, err
+	}
+	return logoutRequest, nil
+}
+
+// deflateAndBase64 encodes the message for the redirect binding
+func deflateAndBase64(data []byte) (string, error) {
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = writer.Write(data); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+type logoutSigner struct {
+	certificate        []byte
+	key                *rsa.PrivateKey
+	signatureAlgorithm string
+}
+
+func (s *logoutSigner) logoutRequest(location, binding string, request *samlp.LogoutRequestType) (_ *logoutMessage, err error) {
+	if binding == provider.PostBinding {
+		request.Signature, err = s.postSignature(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.message(location, binding, querySAMLRequest, "", request)
+}
+
+func (s *logoutSigner) logoutResponse(location, binding, relayState string, response *samlp.LogoutResponseType) (_ *logoutMessage, err error) {
+	if binding == provider.PostBinding {
+		response.Signature, err = s.postSignature(response)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.message(location, binding, querySAMLResponse, relayState, response)
+}
+
+// message encodes the (post signed) message for the binding,
+// in case of the redirect binding the query is signed and appended to the location
+func (s *logoutSigner) message(location, binding, param, relayState string, data interface{}) (*logoutMessage, error) {
+	raw, err := saml_xml.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if binding == provider.PostBinding {
+		return &logoutMessage{
+			URL:        location,
+			Post:       true,
+			Param:      param,
+			Value:      base64.StdEncoding.EncodeToString([]byte(raw)),
+			RelayState: relayState,
+		}, nil
+	}
+	value, err := deflateAndBase64([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+	query := param + "=" + url.QueryEscape(value)
+	if relayState != "" {
+		query += "&" + queryRelayState + "=" + url.QueryEscape(relayState)
+	}
+	query += "&" + querySigAlg + "=" + url.QueryEscape(s.signatureAlgorithm)
+	sig, err := s.redirectSignature(query)
+	if err != nil {
+		return nil, err
+	}
+	query += "&" + querySignature + "=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+	separator := "?"
+	if strings.Contains(location, "?") {
+		separator = "&"
+	}
+	return &logoutMessage{
+		URL: location + separator + query,
+	}, nil
+}
+
+func (s *logoutSigner) postSignature(data interface{}) (*xml_dsig.SignatureType, error) {
+	signer, err := signature.GetSigner(s.certificate, s.key, s.signatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	return signature.Create(signer, data)
+}
+
+func (s *logoutSigner) redirectSignature(query string) ([]byte, error) {
+	tlsCert, err := signature.ParseTlsKeyPair(s.certificate, s.key)
+	if err != nil {
+		return nil, err
+	}
+	signingContext, err := signature.GetSigningContext(tlsCert, s.signatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	return signature.CreateRedirect(signingContext, query)
+}
+
+const logoutTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8"/>
+<title>Logout</title>
+</head>
+<body>
+{{- range $i, $request := .Requests }}
+{{- if $request.Post }}
+<iframe name="logout{{ $i }}" title="logout" style="display:none"></iframe>
+<form class="logout" action="{{ $request.URL }}" method="post" target="logout{{ $i }}">
+<input type="hidden" name="{{ $request.Param }}" value="{{ $request.Value }}"/>
+</form>
+{{- else }}
+<iframe src="{{ $request.URL }}" title="logout" style="display:none"></iframe>
+{{- end }}
+{{- end }}
+{{- with .Response }}
+{{- if .Post }}
+<form id="done" action="{{ .URL }}" method="post">
+<input type="hidden" name="{{ .Param }}" value="{{ .Value }}"/>
+{{- if .RelayState }}
+<input type="hidden" name="RelayState" value="{{ .RelayState }}"/>
+{{- end }}
+<noscript><input type="submit" value="Continue"/></noscript>
+</form>
+{{- else }}
+<a id="done" href="{{ .URL }}">Continue</a>
+{{- end }}
+{{- else }}
+<a id="done" href="{{ .RedirectURL }}">Continue</a>
+{{- end }}
+<script>
+var forms = document.getElementsByClassName("logout");
+for (var i = 0; i < forms.length; i++) {
+  forms[i].submit();
+}
+var finished = false;
+function finish() {
+  if (finished) {
+    return;
+  }
+  finished = true;
+  var done = document.getElementById("done");
+  if (done.tagName === "FORM") {
+    done.submit();
+    return;
+  }
+  window.location.href = done.href;
+}
+window.addEventListener("load", finish);
+setTimeout(finish, 5000);
+</script>
+</body>
+</html>`
@@ -0,0 +1,412 @@
+package saml
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"html/template"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func TestPropagateLogoutURL(t *testing.T) {
+	tests := []struct {
+		name                  string
+		issuer                string
+		clientID              string
+		postLogoutRedirectURI string
+		want                  string
+	}{
+		{
+			name:   "without redirect",
+			issuer: "https://instance.zitadel.cloud/saml/v2",
+			want:   "https://instance.zitadel.cloud/saml/v2/SLO/propagate",
+		},
+		{
+			name:                  "with redirect",
+			issuer:                "https://instance.zitadel.cloud/saml/v2/",
+			clientID:              "client",
+			postLogoutRedirectURI: "https://app.example.com/logout?state=state",
+			want:                  "https://instance.zitadel.cloud/saml/v2/SLO/propagate?client_id=client&post_logout_redirect_uri=https%3A%2F%2Fapp.example.com%2Flogout%3Fstate%3Dstate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PropagateLogoutURL(tt.issuer, tt.clientID, tt.postLogoutRedirectURI))
+		})
+	}
+}
+
+func Test_decodeLogoutRequest(t *testing.T) {
+	raw := `<samlp:LogoutRequest xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="request-id" Version="2.0" IssueInstant="2023-01-01T00:00:00Z"><saml:Issuer>https://sp.example.com/metadata</saml:Issuer><saml:NameID>username</saml:NameID></samlp:LogoutRequest>`
+	deflated, err := deflateAndBase64([]byte(raw))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		binding string
+		request string
+		wantErr bool
+	}{
+		{
+			name:    "redirect binding",
+			binding: provider.RedirectBinding,
+			request: deflated,
+		},
+		{
+			name:    "post binding",
+			binding: provider.PostBinding,
+			request: base64.StdEncoding.EncodeToString([]byte(raw)),
+		},
+		{
+			name:    "post binding, deflated",
+			binding: provider.PostBinding,
+			request: deflated,
+			wantErr: true,
+		},
+		{
+			name:    "no base64",
+			binding: provider.RedirectBinding,
+			request: "<LogoutRequest/>",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeLogoutRequest(tt.binding, tt.request)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "request-id", got.Id)
+			assert.Equal(t, "https://sp.example.com/metadata", got.Issuer.Text)
+			assert.Equal(t, "username", got.NameID.Text)
+		})
+	}
+}
+
+func Test_singleLogoutService(t *testing.T) {
+	tests := []struct {
+		name                 string
+		services             []md.EndpointType
+		wantLocation         string
+		wantResponseLocation string
+		wantBinding          string
+	}{
+		{
+			name: "no service",
+		},
+		{
+			name: "unsupported binding skipped",
+			services: []md.EndpointType{
+				{Binding: "urn:oasis:names:tc:SAML:2.0:bindings:SOAP", Location: "https://sp.example.com/soap"},
+				{Binding: provider.PostBinding, Location: "https://sp.example.com/slo", ResponseLocation: "https://sp.example.com/slo/response"},
+			},
+			wantLocation:         "https://sp.example.com/slo",
+			wantResponseLocation: "https://sp.example.com/slo/response",
+			wantBinding:          provider.PostBinding,
+		},
+		{
+			name: "response location defaults to location",
+			services: []md.EndpointType{
+				{Binding: provider.RedirectBinding, Location: "https://sp.example.com/slo"},
+			},
+			wantLocation:         "https://sp.example.com/slo",
+			wantResponseLocation: "https://sp.example.com/slo",
+			wantBinding:          provider.RedirectBinding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := &serviceprovider.ServiceProvider{
+				Metadata: &md.EntityDescriptorType{
+					SPSSODescriptor: &md.SPSSODescriptorType{
+						SingleLogoutService: tt.services,
+					},
+				},
+			}
+			location, responseLocation, binding := singleLogoutService(sp)
+			assert.Equal(t, tt.wantLocation, location)
+			assert.Equal(t, tt.wantResponseLocation, responseLocation)
+			assert.Equal(t, tt.wantBinding, binding)
+		})
+	}
+}
+
+func TestProvider_postLogoutRedirectURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		redirectURI string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name: "empty, logged out page",
+			want: login.DefaultLoggedOutPath,
+		},
+		{
+			name:        "logged out page with state",
+			redirectURI: login.DefaultLoggedOutPath + "?state=state",
+			want:        login.DefaultLoggedOutPath + "?state=state",
+		},
+		{
+			name:        "other host without client",
+			redirectURI: "https://evil.example.com" + login.DefaultLoggedOutPath,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := new(Provider).postLogoutRedirectURL(context.Background(), "", tt.redirectURI)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_logoutSignatureRequired(t *testing.T) {
+	spWithDescriptor := func(authnRequestsSigned string) *serviceprovider.ServiceProvider {
+		return &serviceprovider.ServiceProvider{
+			Metadata: &md.EntityDescriptorType{
+				SPSSODescriptor: &md.SPSSODescriptorType{AuthnRequestsSigned: authnRequestsSigned},
+			},
+		}
+	}
+	tests := []struct {
+		name                   string
+		wantAuthRequestsSigned string
+		sp                     *serviceprovider.ServiceProvider
+		want                   bool
+	}{
+		{
+			name:                   "wanted by idp",
+			wantAuthRequestsSigned: "true",
+			sp:                     spWithDescriptor("false"),
+			want:                   true,
+		},
+		{
+			name:                   "wanted by idp, weakly decoded",
+			wantAuthRequestsSigned: "1",
+			sp:                     spWithDescriptor("false"),
+			want:                   true,
+		},
+		{
+			name:                   "idp config not set",
+			wantAuthRequestsSigned: "",
+			sp:                     spWithDescriptor("false"),
+			want:                   true,
+		},
+		{
+			name:                   "not wanted, sp signs requests",
+			wantAuthRequestsSigned: "false",
+			sp:                     spWithDescriptor("true"),
+			want:                   true,
+		},
+		{
+			name:                   "not wanted, no sp descriptor",
+			wantAuthRequestsSigned: "0",
+			sp:                     &serviceprovider.ServiceProvider{Metadata: &md.EntityDescriptorType{}},
+			want:                   true,
+		},
+		{
+			name:                   "not wanted",
+			wantAuthRequestsSigned: "false",
+			sp:                     spWithDescriptor(""),
+			want:                   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, logoutSignatureRequired(tt.wantAuthRequestsSigned, tt.sp))
+		})
+	}
+}
+
+func Test_logoutSession(t *testing.T) {
+	sessions := []*query.SAMLSession{
+		{UserID: "user1", ApplicationID: "app1", NameID: "user1@example.com", SessionIndex: "index1"},
+		{UserID: "user2", ApplicationID: "app1", NameID: "user2@example.com"},
+		{UserID: "user1", ApplicationID: "app2", NameID: "user1@example.com", SessionIndex: "index2"},
+	}
+	logoutRequest := func(nameID string, sessionIndexes ...string) *samlp.LogoutRequestType {
+		return &samlp.LogoutRequestType{
+			NameID:       &saml.NameIDType{Text: nameID},
+			SessionIndex: sessionIndexes,
+		}
+	}
+	tests := []struct {
+		name          string
+		applicationID string
+		request       *samlp.LogoutRequestType
+		want          *query.SAMLSession
+	}{
+		{
+			name:          "name id and session index",
+			applicationID: "app1",
+			request:       logoutRequest("user1@example.com", "other", "index1"),
+			want:          sessions[0],
+		},
+		{
+			name:          "name id without session index",
+			applicationID: "app1",
+			request:       logoutRequest("user1@example.com"),
+			want:          sessions[0],
+		},
+		{
+			name:          "session without session index",
+			applicationID: "app1",
+			request:       logoutRequest("user2@example.com", "index"),
+			want:          sessions[1],
+		},
+		{
+			name:          "wrong session index",
+			applicationID: "app1",
+			request:       logoutRequest("user1@example.com", "index2"),
+		},
+		{
+			name:          "wrong name id",
+			applicationID: "app1",
+			request:       logoutRequest("other@example.com"),
+		},
+		{
+			name:          "session of other application",
+			applicationID: "app3",
+			request:       logoutRequest("user1@example.com"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, logoutSession(sessions, tt.applicationID, tt.request))
+		})
+	}
+}
+
+func Test_userSessions(t *testing.T) {
+	sessions := []*query.SAMLSession{
+		{UserID: "user1", ApplicationID: "app1"},
+		{UserID: "user2", ApplicationID: "app1"},
+		{UserID: "user1", ApplicationID: "app2"},
+	}
+	assert.Equal(t, []*query.SAMLSession{sessions[0], sessions[2]}, userSessions(sessions, "user1"))
+}
+
+func Test_sessionNameIDFormat(t *testing.T) {
+	const nameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	assert.Equal(t, nameIDFormatUnspecified, sessionNameIDFormat(&query.SAMLSession{NameIDFormat: nameIDFormatUnspecified}))
+	assert.Equal(t, nameIDFormatEmailAddress, sessionNameIDFormat(&query.SAMLSession{}))
+}
+
+func Test_logoutSigner_redirect(t *testing.T) {
+	signer := testLogoutSigner(t)
+	message, err := signer.logoutRequest("https://sp.example.com/slo?tenant=1", provider.RedirectBinding, &samlp.LogoutRequestType{
+		Id:           "request-id",
+		Version:      "2.0",
+		Issuer:       &saml.NameIDType{Format: nameIDFormatEntity, Text: "https://instance.zitadel.cloud/saml/v2/metadata"},
+		NameID:       &saml.NameIDType{Format: nameIDFormatEmailAddress, Text: "username"},
+		SessionIndex: []string{"session-index"},
+	})
+	require.NoError(t, err)
+	assert.False(t, message.Post)
+	require.True(t, strings.HasPrefix(message.URL, "https://sp.example.com/slo?tenant=1&SAMLRequest="))
+
+	query := strings.TrimPrefix(message.URL, "https://sp.example.com/slo?tenant=1&")
+	signed, sig, ok := strings.Cut(query, "&"+querySignature+"=")
+	require.True(t, ok)
+	sig, err = url.QueryUnescape(sig)
+	require.NoError(t, err)
+	sigValue, err := base64.StdEncoding.DecodeString(sig)
+	require.NoError(t, err)
+	assert.NoError(t, signature.ValidateRedirect(signer.signatureAlgorithm, []byte(signed), sigValue, &signer.key.PublicKey))
+
+	values, err := url.ParseQuery(query)
+	require.NoError(t, err)
+	request, err := decodeLogoutRequest(provider.RedirectBinding, values.Get(querySAMLRequest))
+	require.NoError(t, err)
+	assert.Equal(t, "request-id", request.Id)
+	assert.Equal(t, "username", request.NameID.Text)
+	assert.Equal(t, []string{"session-index"}, request.SessionIndex)
+}
+
+func Test_logoutSigner_post(t *testing.T) {
+	signer := testLogoutSigner(t)
+	message, err := signer.logoutResponse("https://sp.example.com/slo", provider.PostBinding, "relay", &samlp.LogoutResponseType{
+		Id:           "response-id",
+		InResponseTo: "request-id",
+		Version:      "2.0",
+		Issuer:       &saml.NameIDType{Format: nameIDFormatEntity, Text: "https://instance.zitadel.cloud/saml/v2/metadata"},
+		Status: samlp.StatusType{
+			StatusCode: samlp.StatusCodeType{Value: provider.StatusCodeSuccess},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, message.Post)
+	assert.Equal(t, "https://sp.example.com/slo", message.URL)
+	assert.Equal(t, querySAMLResponse, message.Param)
+	assert.Equal(t, "relay", message.RelayState)
+
+	data, err := base64.StdEncoding.DecodeString(message.Value)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `InResponseTo="request-id"`)
+	assert.Contains(t, string(data), "SignatureValue")
+}
+
+func TestProvider_renderLogout(t *testing.T) {
+	tmpl, err := template.New("logout").Parse(logoutTemplate)
+	require.NoError(t, err)
+	p := &Provider{logoutTemplate: tmpl}
+
+	recorder := httptest.NewRecorder()
+	p.renderLogout(recorder, &logoutData{
+		Requests: []*logoutMessage{
+			{URL: "https://sp1.example.com/slo?SAMLRequest=request&SigAlg=alg&Signature=sig"},
+			{URL: "https://sp2.example.com/slo", Post: true, Param: querySAMLRequest, Value: "request"},
+		},
+		RedirectURL: login.DefaultLoggedOutPath,
+	})
+	body := recorder.Body.String()
+	assert.Contains(t, body, `<iframe src="https://sp1.example.com/slo?SAMLRequest=request&amp;SigAlg=alg&amp;Signature=sig"`)
+	assert.Contains(t, body, `<form class="logout" action="https://sp2.example.com/slo" method="post" target="logout1">`)
+	assert.Contains(t, body, `<a id="done" href="/ui/login/logout/done">`)
+}
+
+func testLogoutSigner(t *testing.T) *logoutSigner {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "zitadel"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return &logoutSigner{
+		certificate:        certificate,
+		key:                key,
+		signatureAlgorithm: "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256",
+	}
+}
@@ -2,8 +2,10 @@ package saml
 
 import (
 	"fmt"
+	"html/template"
 	"net/http"
 
+	"github.com/gorilla/mux"
 	"github.com/zitadel/saml/pkg/provider"
 
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
@@ -21,12 +23,32 @@ import (
 
 const (
 	HandlerPrefix = "/saml/v2"
+
+	timeFormat = "2006-01-02T15:04:05.999Z"
 )
 
 type Config struct {
 	ProviderConfig *provider.Config
 }
 
+// Provider extends the [provider.Provider] with the handling of the single logout,
+// which is not implemented by the library
+type Provider struct {
+	*provider.Provider
+
+	storage          *Storage
+	conf             *provider.IdentityProviderConfig
+	metadataEndpoint provider.Endpoint
+	callbackEndpoint provider.Endpoint
+	logoutTemplate   *template.Template
+	httpHandler      http.Handler
+}
+
+// HttpHandler returns the handler of the library extended by the single logout endpoints
+func (p *Provider) HttpHandler() http.Handler {
+	return p.httpHandler
+}
+
 func NewProvider(
 	conf Config,
 	externalSecure bool,
@@ -40,7 +62,7 @@ func NewProvider(
 	instanceHandler,
 	userAgentCookie,
 	accessHandler func(http.Handler) http.Handler,
-) (*provider.Provider, error) {
+) (*Provider, error) {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 
 	provStorage, err := newStorage(
@@ -56,28 +78,72 @@ func NewProvider(
 		return nil, err
 	}
 
+	interceptors := []provider.HttpInterceptor{
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor().Handler,
+		instanceHandler,
+		userAgentCookie,
+		accessHandler,
+		http_utils.CopyHeadersToContext,
+		authRequestContextHandler,
+	}
 	options := []provider.Option{
-		provider.WithHttpInterceptors(
-			middleware.MetricsHandler(metricTypes),
-			middleware.TelemetryHandler(),
-			middleware.NoCacheInterceptor().Handler,
-			instanceHandler,
-			userAgentCookie,
-			accessHandler,
-			http_utils.CopyHeadersToContext,
-		),
-		provider.WithCustomTimeFormat("2006-01-02T15:04:05.999Z"),
+		provider.WithHttpInterceptors(interceptors...),
+		provider.WithCustomTimeFormat(timeFormat),
 	}
 	if !externalSecure {
 		options = append(options, provider.WithAllowInsecure())
 	}
 
-	return provider.NewProvider(
+	samlProvider, err := provider.NewProvider(
 		provStorage,
 		HandlerPrefix,
 		conf.ProviderConfig,
 		options...,
 	)
+	if err != nil {
+		return nil, err
+	}
+	logoutTemplate, err := template.New("logout").Parse(logoutTemplate)
+	if err != nil {
+		return nil, err
+	}
+	metadataEndpoint := provider.NewEndpoint(provider.DefaultMetadataEndpoint)
+	if conf.ProviderConfig.Metadata != nil {
+		metadataEndpoint = *conf.ProviderConfig.Metadata
+	}
+	callbackEndpoint := provider.NewEndpoint(provider.DefaultCallbackEndpoint)
+	if idpConfig := conf.ProviderConfig.IDPConfig; idpConfig != nil && idpConfig.Endpoints != nil && idpConfig.Endpoints.Callback != nil {
+		callbackEndpoint = *idpConfig.Endpoints.Callback
+	}
+	p := &Provider{
+		Provider:         samlProvider,
+		storage:          provStorage,
+		conf:             conf.ProviderConfig.IDPConfig,
+		metadataEndpoint: metadataEndpoint,
+		callbackEndpoint: callbackEndpoint,
+		logoutTemplate:   logoutTemplate,
+	}
+	p.httpHandler = p.createRouter(interceptors)
+	return p, nil
+}
+
+// createRouter serves the single logout endpoints (with the same interceptors as the library),
+// records the SAML sessions of the login callback and passes all other requests to the handler of the library
+func (p *Provider) createRouter(interceptors []provider.HttpInterceptor) http.Handler {
+	intercept := func(handler http.Handler) http.Handler {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			handler = interceptors[i](handler)
+		}
+		return provider.NewIssuerInterceptor(p.IssuerFromRequest).Handler(handler)
+	}
+	router := mux.NewRouter()
+	router.Handle(EndpointSingleLogout, intercept(http.HandlerFunc(p.singleLogoutHandler))).Methods(http.MethodGet, http.MethodPost)
+	router.Handle(EndpointPropagateLogout, intercept(http.HandlerFunc(p.propagateLogoutHandler))).Methods(http.MethodGet)
+	router.Handle(p.callbackEndpoint.Relative(), p.recordSAMLSession(p.Provider.HttpHandler()))
+	router.PathPrefix("/").Handler(p.Provider.HttpHandler())
+	return router
 }
 
 func newStorage(
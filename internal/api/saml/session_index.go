@@ -0,0 +1,146 @@
+package saml
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// samlResponseInput matches the SAMLResponse of the form, which is posted to the service provider
+var samlResponseInput = regexp.MustCompile(`name="SAMLResponse"\s*value="([^"]*)"`)
+
+// samlSession is the session of the user on the service provider,
+// which is recorded after the response of the login callback is created
+type samlSession struct {
+	// ctx is the context of the storage call, it contains the instance and user agent of the request
+	ctx           context.Context
+	userID        string
+	resourceOwner string
+	userAgentID   string
+	applicationID string
+	entityID      string
+	nameID        string
+	// nameIDFormat is the format of the NameID of the issued assertion, which is sent back unchanged on the logout
+	nameIDFormat string
+}
+
+// recordSAMLSession records the SAML session of the login callback with the session index and NameID format of the issued assertion.
+// The session index is generated by the SAML library, so the response is buffered until it's read from it.
+func (p *Provider) recordSAMLSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authReqCtx := &authRequestContext{recordSession: true}
+		recorder := newResponseRecorder()
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), authRequestContextKey{}, authReqCtx)))
+		if session := authReqCtx.session; session != nil {
+			sessionIndex, nameIDFormat, ok, err := sessionFromResponse(recorder.header, recorder.body.Bytes())
+			// the session is still recorded (without session index), so the logout is propagated at least by NameID
+			logging.WithFields("application", session.applicationID).OnError(err).Warn("unable to read session index of saml response")
+			if ok || err != nil {
+				if nameIDFormat != "" {
+					session.nameIDFormat = nameIDFormat
+				}
+				if err = p.storage.addSAMLSessionWithIndex(session, sessionIndex); err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+			}
+		}
+		recorder.writeTo(w)
+	})
+}
+
+// sessionFromResponse returns the session index and NameID format of the response sent by the redirect (location header) or post binding (form)
+// and if the response was successful
+func sessionFromResponse(header http.Header, body []byte) (sessionIndex, nameIDFormat string, ok bool, err error) {
+	var response *samlp.ResponseType
+	if location := header.Get("Location"); location != "" {
+		redirect, err := url.Parse(location)
+		if err != nil {
+			return "", "", false, errors.ThrowInternal(err, "SAML-Ju2ws", "Errors.SAML.ResponseInvalid")
+		}
+		data, err := base64.StdEncoding.DecodeString(redirect.Query().Get(querySAMLResponse))
+		if err != nil {
+			return "", "", false, errors.ThrowInternal(err, "SAML-Ba8qe", "Errors.SAML.ResponseInvalid")
+		}
+		if response, err = saml_xml.DecodeResponse(saml_xml.EncodingDeflate, string(data)); err != nil {
+			return "", "", false, errors.ThrowInternal(err, "SAML-Xo3nc", "Errors.SAML.ResponseInvalid")
+		}
+	} else {
+		match := samlResponseInput.FindSubmatch(body)
+		if match == nil {
+			return "", "", false, nil
+		}
+		data, err := base64.StdEncoding.DecodeString(html.UnescapeString(string(match[1])))
+		if err != nil {
+			return "", "", false, errors.ThrowInternal(err, "SAML-Ot5vb", "Errors.SAML.ResponseInvalid")
+		}
+		if response, err = saml_xml.DecodeResponse("", string(data)); err != nil {
+			return "", "", false, errors.ThrowInternal(err, "SAML-Mv7kd", "Errors.SAML.ResponseInvalid")
+		}
+	}
+	if response.Status.StatusCode.Value != provider.StatusCodeSuccess {
+		return "", "", false, nil
+	}
+	if subject := response.Assertion.Subject; subject != nil && subject.NameID != nil {
+		nameIDFormat = subject.NameID.Format
+	}
+	for _, statement := range response.Assertion.AuthnStatement {
+		if statement.SessionIndex != "" {
+			return statement.SessionIndex, nameIDFormat, true, nil
+		}
+	}
+	return "", nameIDFormat, true, nil
+}
+
+// responseRecorder buffers the response until it's written to the actual [http.ResponseWriter] by writeTo
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{
+		header: make(http.Header),
+	}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(data)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *responseRecorder) writeTo(w http.ResponseWriter) {
+	for key, values := range r.header {
+		w.Header()[key] = values
+	}
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	w.WriteHeader(r.status)
+	_, err := w.Write(r.body.Bytes())
+	logging.OnError(err).Debug("unable to write saml response")
+}
@@ -0,0 +1,149 @@
+package saml
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+)
+
+const testPostTemplate = `<form action="{{ .URL }}" method="post" id="samlpost">
+<input type="hidden" name="SAMLResponse"
+value="{{ .SAMLResponse }}"/>
+</form>`
+
+func Test_sessionFromResponse(t *testing.T) {
+	type args struct {
+		header http.Header
+		body   []byte
+	}
+	tests := []struct {
+		name         string
+		args         args
+		sessionIndex string
+		nameIDFormat string
+		ok           bool
+		wantErr      bool
+	}{
+		{
+			name: "redirect binding",
+			args: args{
+				header: http.Header{"Location": {redirectResponse(t, testSAMLResponse(provider.StatusCodeSuccess, "session-index"))}},
+			},
+			sessionIndex: "session-index",
+			nameIDFormat: nameIDFormatEmailAddress,
+			ok:           true,
+		},
+		{
+			name: "post binding",
+			args: args{
+				header: http.Header{},
+				body:   postResponse(t, testSAMLResponse(provider.StatusCodeSuccess, "session-index")),
+			},
+			sessionIndex: "session-index",
+			nameIDFormat: nameIDFormatEmailAddress,
+			ok:           true,
+		},
+		{
+			name: "failed response",
+			args: args{
+				header: http.Header{},
+				body:   postResponse(t, testSAMLResponse("urn:oasis:names:tc:SAML:2.0:status:Requester", "")),
+			},
+		},
+		{
+			name: "no response",
+			args: args{
+				header: http.Header{},
+				body:   []byte("<html></html>"),
+			},
+		},
+		{
+			name: "invalid response",
+			args: args{
+				header: http.Header{"Location": {"https://sp.example.com/acs?SAMLResponse=invalid"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionIndex, nameIDFormat, ok, err := sessionFromResponse(tt.args.header, tt.args.body)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.sessionIndex, sessionIndex)
+			assert.Equal(t, tt.nameIDFormat, nameIDFormat)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func Test_responseRecorder(t *testing.T) {
+	recorder := newResponseRecorder()
+	recorder.Header().Set("Location", "https://sp.example.com/acs")
+	recorder.WriteHeader(http.StatusFound)
+	_, err := recorder.Write([]byte("body"))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	recorder.writeTo(w)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://sp.example.com/acs", w.Header().Get("Location"))
+	assert.Equal(t, "body", w.Body.String())
+}
+
+func testSAMLResponse(status, sessionIndex string) *samlp.ResponseType {
+	response := &samlp.ResponseType{
+		Id:      "response-id",
+		Version: "2.0",
+		Status: samlp.StatusType{
+			StatusCode: samlp.StatusCodeType{Value: status},
+		},
+	}
+	if sessionIndex != "" {
+		response.Assertion = saml.AssertionType{
+			Subject: &saml.SubjectType{
+				NameID: &saml.NameIDType{Format: nameIDFormatEmailAddress, Text: "username"},
+			},
+			AuthnStatement: []saml.AuthnStatementType{{SessionIndex: sessionIndex}},
+		}
+	}
+	return response
+}
+
+func redirectResponse(t *testing.T, response *samlp.ResponseType) string {
+	raw, err := saml_xml.Marshal(response)
+	require.NoError(t, err)
+	value, err := deflateAndBase64([]byte(raw))
+	require.NoError(t, err)
+	return "https://sp.example.com/acs?SAMLResponse=" + url.QueryEscape(value)
+}
+
+// postResponse renders the response like the post binding of the SAML library,
+// which escapes the base64 characters (e.g. +) in the value
+func postResponse(t *testing.T, response *samlp.ResponseType) []byte {
+	raw, err := saml_xml.Marshal(response)
+	require.NoError(t, err)
+	tmpl, err := template.New("post").Parse(testPostTemplate)
+	require.NoError(t, err)
+	var body bytes.Buffer
+	err = tmpl.Execute(&body, map[string]string{
+		"URL":          "https://sp.example.com/acs",
+		"SAMLResponse": base64.StdEncoding.EncodeToString([]byte(raw)),
+	})
+	require.NoError(t, err)
+	return body.Bytes()
+}
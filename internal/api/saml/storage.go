@@ -121,6 +121,7 @@ func (p *Storage) AuthRequestByID(ctx context.Context, id string) (_ models.Auth
 	if err != nil {
 		return nil, err
 	}
+	setApplicationIDToContext(ctx, resp.ApplicationID)
 	return AuthRequestFromBusiness(resp)
 }
 
@@ -133,7 +134,50 @@ func (p *Storage) SetUserinfoWithUserID(ctx context.Context, userinfo models.Att
 	}
 
 	setUserinfo(user, userinfo, attributes)
-	return nil
+
+	// the application is only known if the auth request was loaded in the same request (login callback)
+	applicationID := applicationIDFromContext(ctx)
+	if applicationID == "" {
+		return nil
+	}
+	custom, err := p.customAttributes(ctx, user, applicationID)
+	if err != nil {
+		return err
+	}
+	custom.setTo(userinfo)
+	return p.addSAMLSession(ctx, user, applicationID)
+}
+
+// addSAMLSession records the session of the user on the service provider (application),
+// so the logout of the user agent can be propagated to it.
+// On the login callback the session is recorded with the session index after the response is created.
+func (p *Storage) addSAMLSession(ctx context.Context, user *query.User, applicationID string) error {
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return errors.ThrowPreconditionFailed(nil, "SAML-Hs2kw", "no user agent id")
+	}
+	entityID, err := p.GetEntityIDByAppID(ctx, applicationID)
+	if err != nil {
+		return err
+	}
+	session := &samlSession{
+		ctx:           ctx,
+		userID:        user.ID,
+		resourceOwner: user.ResourceOwner,
+		userAgentID:   userAgentID,
+		applicationID: applicationID,
+		entityID:      entityID,
+		nameID:        user.PreferredLoginName,
+		nameIDFormat:  nameIDFormatEmailAddress,
+	}
+	if setSAMLSessionToContext(ctx, session) {
+		return nil
+	}
+	return p.addSAMLSessionWithIndex(session, "")
+}
+
+func (p *Storage) addSAMLSessionWithIndex(session *samlSession, sessionIndex string) error {
+	return p.command.AddSAMLSession(session.ctx, session.userID, session.resourceOwner, session.userAgentID, session.applicationID, session.entityID, session.nameID, session.nameIDFormat, sessionIndex)
 }
 
 func (p *Storage) SetUserinfoWithLoginName(ctx context.Context, userinfo models.AttributeSetter, loginName string, attributes []int) (err error) {
@@ -0,0 +1,166 @@
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+)
+
+// bulkRequest contains multiple operations on resources (RFC 7644, section 3.7)
+type bulkRequest struct {
+	Schemas      []string         `json:"schemas"`
+	FailOnErrors int              `json:"failOnErrors"`
+	Operations   []*bulkOperation `json:"Operations"`
+}
+
+type bulkOperation struct {
+	Method  string          `json:"method"`
+	BulkID  string          `json:"bulkId"`
+	Version string          `json:"version"`
+	Path    string          `json:"path"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type bulkResponse struct {
+	Schemas    []string                 `json:"schemas"`
+	Operations []*bulkOperationResponse `json:"Operations"`
+}
+
+type bulkOperationResponse struct {
+	Method   string          `json:"method"`
+	BulkID   string          `json:"bulkId,omitempty"`
+	Version  string          `json:"version,omitempty"`
+	Location string          `json:"location,omitempty"`
+	Status   string          `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// bulkIDReference matches the references to resources created in the same request, e.g. bulkId:qwerty
+var bulkIDReference = regexp.MustCompile(`bulkId:([^"\s/]+)`)
+
+// bulk executes the operations in the order of the request.
+// Each operation is served by the bulkRouter, which checks the permission of the operation
+// with the permissions of the already authorized user.
+func (h *Handler) bulk(w http.ResponseWriter, r *http.Request) {
+	request := new(bulkRequest)
+	if err := readRequest(w, r, request); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if !containsSchema(request.Schemas, schemaBulkRequest) {
+		writeError(w, r, errInvalidValue("schema "+schemaBulkRequest+" is required"))
+		return
+	}
+	if len(request.Operations) > maxBulkOperations {
+		writeError(w, r, newSCIMError(http.StatusRequestEntityTooLarge, "", "the maximum number of operations is "+strconv.Itoa(maxBulkOperations)))
+		return
+	}
+	resourceIDs := make(map[string]string)
+	responses := make([]*bulkOperationResponse, 0, len(request.Operations))
+	errorCount := 0
+	for _, operation := range request.Operations {
+		if request.FailOnErrors > 0 && errorCount >= request.FailOnErrors {
+			break
+		}
+		response := h.bulkOperation(r, operation, resourceIDs)
+		if status, _ := strconv.Atoi(response.Status); status >= http.StatusBadRequest {
+			errorCount++
+		}
+		responses = append(responses, response)
+	}
+	writeResponse(w, http.StatusOK, &bulkResponse{
+		Schemas:    []string{schemaBulkResponse},
+		Operations: responses,
+	})
+}
+
+func (h *Handler) bulkOperation(r *http.Request, operation *bulkOperation, resourceIDs map[string]string) *bulkOperationResponse {
+	method := strings.ToUpper(operation.Method)
+	response := &bulkOperationResponse{
+		Method:  method,
+		BulkID:  operation.BulkID,
+		Version: operation.Version,
+	}
+	if method == http.MethodPost && operation.BulkID == "" {
+		return response.withError(errInvalidValue("bulkId is required for POST"))
+	}
+	path, err := resolveBulkIDs(operation.Path, resourceIDs)
+	if err != nil {
+		return response.withError(err)
+	}
+	data, err := resolveBulkIDs(string(operation.Data), resourceIDs)
+	if err != nil {
+		return response.withError(err)
+	}
+	req, err := http.NewRequestWithContext(r.Context(), method, "/"+authz.GetCtxData(r.Context()).OrgID+path, strings.NewReader(data))
+	if err != nil {
+		return response.withError(errInvalidPath("invalid path " + operation.Path))
+	}
+	recorder := &bulkResponseWriter{header: make(http.Header)}
+	h.bulkRouter.ServeHTTP(recorder, req)
+
+	response.Status = strconv.Itoa(recorder.status)
+	response.Location = recorder.header.Get("Location")
+	if recorder.status >= http.StatusBadRequest {
+		response.Response = recorder.body.Bytes()
+		return response
+	}
+	if method == http.MethodPost {
+		created := new(struct {
+			ID string `json:"id"`
+		})
+		if err = json.Unmarshal(recorder.body.Bytes(), created); err == nil {
+			resourceIDs[operation.BulkID] = created.ID
+		}
+	}
+	return response
+}
+
+func (r *bulkOperationResponse) withError(err error) *bulkOperationResponse {
+	scimErr := toSCIMError(err)
+	r.Status = scimErr.Status
+	r.Response, _ = json.Marshal(scimErr)
+	return r
+}
+
+// resolveBulkIDs replaces the references to resources created by previous operations with their ids
+func resolveBulkIDs(s string, resourceIDs map[string]string) (resolved string, err error) {
+	resolved = bulkIDReference.ReplaceAllStringFunc(s, func(reference string) string {
+		bulkID := strings.TrimPrefix(reference, "bulkId:")
+		id, ok := resourceIDs[bulkID]
+		if !ok {
+			err = newSCIMError(http.StatusConflict, scimTypeInvalidValue, "bulkId "+bulkID+" cannot be resolved")
+		}
+		return id
+	})
+	return resolved, err
+}
+
+// bulkResponseWriter records the response of an operation
+type bulkResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bulkResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bulkResponseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+func (w *bulkResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
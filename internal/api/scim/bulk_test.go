@@ -0,0 +1,53 @@
+package scim
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_resolveBulkIDs(t *testing.T) {
+	resourceIDs := map[string]string{"qwerty": "user1"}
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no reference",
+			s:    "/Users",
+			want: "/Users",
+		},
+		{
+			name: "path",
+			s:    "/Users/bulkId:qwerty",
+			want: "/Users/user1",
+		},
+		{
+			name: "data",
+			s:    `{"value":"bulkId:qwerty"}`,
+			want: `{"value":"user1"}`,
+		},
+		{
+			name:    "unresolved",
+			s:       "/Users/bulkId:ytrewq",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveBulkIDs(tt.s, resourceIDs)
+			if tt.wantErr {
+				scimErr := new(scimError)
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, http.StatusConflict, scimErr.status)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeTooMany       = "tooMany"
+	scimTypeUniqueness    = "uniqueness"
+	scimTypeMutability    = "mutability"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeNoTarget      = "noTarget"
+	scimTypeInvalidValue  = "invalidValue"
+)
+
+// scimError is the error response of the SCIM protocol (RFC 7644, section 3.12)
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	// Status is the HTTP status code as string
+	Status string `json:"status"`
+
+	status int
+}
+
+func newSCIMError(status int, scimType, detail string) *scimError {
+	return &scimError{
+		Schemas:  []string{schemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+		status:   status,
+	}
+}
+
+func (e *scimError) Error() string {
+	return e.Status + " " + e.ScimType + ": " + e.Detail
+}
+
+func errInvalidFilter(detail string) *scimError {
+	return newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, detail)
+}
+
+func errInvalidSyntax(detail string) *scimError {
+	return newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, detail)
+}
+
+func errInvalidPath(detail string) *scimError {
+	return newSCIMError(http.StatusBadRequest, scimTypeInvalidPath, detail)
+}
+
+func errInvalidValue(detail string) *scimError {
+	return newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, detail)
+}
+
+func errNoTarget(detail string) *scimError {
+	return newSCIMError(http.StatusBadRequest, scimTypeNoTarget, detail)
+}
+
+func errMutability(detail string) *scimError {
+	return newSCIMError(http.StatusBadRequest, scimTypeMutability, detail)
+}
+
+func errNotFound(detail string) *scimError {
+	return newSCIMError(http.StatusNotFound, "", detail)
+}
+
+// toSCIMError maps the errors of the command and query side to the status codes and types of SCIM
+func toSCIMError(err error) *scimError {
+	scimErr := new(scimError)
+	if errors.As(err, &scimErr) {
+		return scimErr
+	}
+	detail := err.Error()
+	var zitadelErr interface {
+		GetMessage() string
+		GetID() string
+	}
+	if errors.As(err, &zitadelErr) {
+		detail = zitadelErr.GetMessage() + " (" + zitadelErr.GetID() + ")"
+	}
+	switch {
+	case caos_errs.IsNotFound(err):
+		return newSCIMError(http.StatusNotFound, "", detail)
+	case caos_errs.IsErrorAlreadyExists(err):
+		return newSCIMError(http.StatusConflict, scimTypeUniqueness, detail)
+	case caos_errs.IsErrorInvalidArgument(err):
+		return newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, detail)
+	case caos_errs.IsPreconditionFailed(err):
+		return newSCIMError(http.StatusBadRequest, "", detail)
+	case caos_errs.IsUnauthenticated(err):
+		return newSCIMError(http.StatusUnauthorized, "", detail)
+	case caos_errs.IsPermissionDenied(err):
+		return newSCIMError(http.StatusForbidden, "", detail)
+	case caos_errs.IsResourceExhausted(err):
+		return newSCIMError(http.StatusTooManyRequests, "", detail)
+	case caos_errs.IsUnimplemented(err):
+		return newSCIMError(http.StatusNotImplemented, "", detail)
+	default:
+		return newSCIMError(http.StatusInternalServerError, "", detail)
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	scimErr := toSCIMError(err)
+	if scimErr.status >= http.StatusInternalServerError {
+		logging.WithFields("uri", r.RequestURI).WithError(err).Error("error occurred on scim api")
+	}
+	if scimErr.status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+HandlerPrefix+`"`)
+	}
+	writeResponse(w, scimErr.status, scimErr)
+}
@@ -0,0 +1,55 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_toSCIMError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *scimError
+	}{
+		{
+			name: "scim error",
+			err:  errInvalidFilter("unexpected )"),
+			want: newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "unexpected )"),
+		},
+		{
+			name: "not found",
+			err:  caos_errs.ThrowNotFound(nil, "QUERY-Dfbg2", "Errors.User.NotFound"),
+			want: newSCIMError(http.StatusNotFound, "", "Errors.User.NotFound (QUERY-Dfbg2)"),
+		},
+		{
+			name: "already exists",
+			err:  caos_errs.ThrowAlreadyExists(nil, "COMMAND-k2unb", "Errors.User.AlreadyExists"),
+			want: newSCIMError(http.StatusConflict, scimTypeUniqueness, "Errors.User.AlreadyExists (COMMAND-k2unb)"),
+		},
+		{
+			name: "invalid argument",
+			err:  caos_errs.ThrowInvalidArgument(nil, "EMAIL-599BI", "Errors.User.Email.Invalid"),
+			want: newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "Errors.User.Email.Invalid (EMAIL-599BI)"),
+		},
+		{
+			name: "permission denied",
+			err:  caos_errs.ThrowPermissionDenied(nil, "AUTH-5mWD2", "No matching permissions found"),
+			want: newSCIMError(http.StatusForbidden, "", "No matching permissions found (AUTH-5mWD2)"),
+		},
+		{
+			name: "unknown",
+			err:  errors.New("database unavailable"),
+			want: newSCIMError(http.StatusInternalServerError, "", "database unavailable"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, toSCIMError(tt.err))
+		})
+	}
+}
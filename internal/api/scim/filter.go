@@ -0,0 +1,448 @@
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+const (
+	operatorEqual          = "eq"
+	operatorNotEqual       = "ne"
+	operatorContains       = "co"
+	operatorStartsWith     = "sw"
+	operatorEndsWith       = "ew"
+	operatorPresent        = "pr"
+	operatorGreaterThan    = "gt"
+	operatorGreaterOrEqual = "ge"
+	operatorLessThan       = "lt"
+	operatorLessOrEqual    = "le"
+
+	operatorAnd = "and"
+	operatorOr  = "or"
+	operatorNot = "not"
+)
+
+// filter is a parsed filter expression (RFC 7644, section 3.4.2.2).
+// It can be compiled into a query or evaluated on a resource, e.g. to select the values of a patch operation.
+type filter interface {
+	matches(resource map[string]interface{}) bool
+}
+
+// attributeExpression compares the value of an attribute,
+// the attribute path and operator are lower case
+type attributeExpression struct {
+	attribute string
+	operator  string
+	// value is a string, bool, float64 or nil
+	value interface{}
+}
+
+type logicalExpression struct {
+	operator    string
+	left, right filter
+}
+
+type notExpression struct {
+	filter filter
+}
+
+// valuePathExpression filters the values of a multi-valued complex attribute, e.g. emails[type eq "work"]
+type valuePathExpression struct {
+	attribute string
+	filter    filter
+}
+
+// parseFilter parses the filter expression with the precedence of RFC 7644: not, and, or
+func parseFilter(expression string) (filter, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errInvalidFilter("filter is empty")
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, errInvalidFilter("unexpected " + p.tokens[p.pos].text)
+	}
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenParenOpen
+	tokenParenClose
+	tokenBracketOpen
+	tokenBracketClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenizeFilter(expression string) ([]*token, error) {
+	tokens := make([]*token, 0)
+	for i := 0; i < len(expression); {
+		switch c := expression[i]; c {
+		case ' ', '\t', '\n', '\r':
+			i++
+		case '(':
+			tokens = append(tokens, &token{kind: tokenParenOpen, text: "("})
+			i++
+		case ')':
+			tokens = append(tokens, &token{kind: tokenParenClose, text: ")"})
+			i++
+		case '[':
+			tokens = append(tokens, &token{kind: tokenBracketOpen, text: "["})
+			i++
+		case ']':
+			tokens = append(tokens, &token{kind: tokenBracketClose, text: "]"})
+			i++
+		case '"':
+			end := i + 1
+			for ; end < len(expression) && expression[end] != '"'; end++ {
+				if expression[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expression) {
+				return nil, errInvalidFilter("unterminated string")
+			}
+			var value string
+			if err := json.Unmarshal([]byte(expression[i:end+1]), &value); err != nil {
+				return nil, errInvalidFilter("invalid string " + expression[i:end+1])
+			}
+			tokens = append(tokens, &token{kind: tokenString, text: value})
+			i = end + 1
+		default:
+			end := i
+			for ; end < len(expression) && !strings.ContainsRune(" \t\n\r()[]\"", rune(expression[end])); end++ {
+			}
+			tokens = append(tokens, &token{kind: tokenWord, text: expression[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []*token
+	pos    int
+}
+
+func (p *filterParser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() *token {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (p *filterParser) expect(kind tokenKind, text string) error {
+	if t := p.next(); t == nil || t.kind != kind {
+		return errInvalidFilter("expected " + text)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword(operatorOr) {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpression{operator: operatorOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword(operatorAnd) {
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpression{operator: operatorAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseFactor() (filter, error) {
+	if p.peekKeyword(operatorNot) {
+		p.next()
+		if err := p.expect(tokenParenOpen, "( after not"); err != nil {
+			return nil, err
+		}
+		f, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpression{filter: f}, nil
+	}
+	t := p.next()
+	if t == nil {
+		return nil, errInvalidFilter("unexpected end of filter")
+	}
+	switch t.kind {
+	case tokenParenOpen:
+		return p.parseGroup()
+	case tokenWord:
+		return p.parseAttribute(normalizeAttributePath(t.text))
+	default:
+		return nil, errInvalidFilter("unexpected " + t.text)
+	}
+}
+
+// parseGroup parses the expression after an opening parenthesis
+func (p *filterParser) parseGroup() (filter, error) {
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(tokenParenClose, ")"); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (p *filterParser) parseAttribute(attribute string) (filter, error) {
+	t := p.next()
+	if t == nil {
+		return nil, errInvalidFilter("missing operator after " + attribute)
+	}
+	if t.kind == tokenBracketOpen {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(tokenBracketClose, "]"); err != nil {
+			return nil, err
+		}
+		return &valuePathExpression{attribute: attribute, filter: f}, nil
+	}
+	if t.kind != tokenWord {
+		return nil, errInvalidFilter("missing operator after " + attribute)
+	}
+	operator := strings.ToLower(t.text)
+	switch operator {
+	case operatorPresent:
+		return &attributeExpression{attribute: attribute, operator: operator}, nil
+	case operatorEqual, operatorNotEqual, operatorContains, operatorStartsWith, operatorEndsWith,
+		operatorGreaterThan, operatorGreaterOrEqual, operatorLessThan, operatorLessOrEqual:
+	default:
+		return nil, errInvalidFilter("unknown operator " + t.text)
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return &attributeExpression{attribute: attribute, operator: operator, value: value}, nil
+}
+
+func (p *filterParser) parseValue() (interface{}, error) {
+	t := p.next()
+	if t == nil {
+		return nil, errInvalidFilter("missing comparison value")
+	}
+	if t.kind == tokenString {
+		return t.text, nil
+	}
+	if t.kind != tokenWord {
+		return nil, errInvalidFilter("invalid comparison value " + t.text)
+	}
+	switch strings.ToLower(t.text) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, errInvalidFilter("invalid comparison value " + t.text)
+	}
+	return number, nil
+}
+
+// normalizeAttributePath removes the (optional) schema of the user from the attribute path and returns it in lower case
+func normalizeAttributePath(path string) string {
+	return strings.ToLower(stripSchema(path))
+}
+
+// stripSchema removes the (optional) schema of the user from the path, e.g. urn:ietf:params:scim:schemas:core:2.0:User:userName
+func stripSchema(path string) string {
+	if len(path) > len(schemaUser) && strings.EqualFold(path[:len(schemaUser)+1], schemaUser+":") {
+		return path[len(schemaUser)+1:]
+	}
+	return path
+}
+
+func (e *attributeExpression) matches(resource map[string]interface{}) bool {
+	value := lookupValue(resource, e.attribute)
+	if values, ok := value.([]interface{}); ok {
+		// a multi-valued attribute matches if any of its values match
+		for _, v := range values {
+			if e.compare(primaryValue(v)) {
+				return true
+			}
+		}
+		return false
+	}
+	return e.compare(value)
+}
+
+func (e *attributeExpression) compare(value interface{}) bool {
+	if e.operator == operatorPresent {
+		return value != nil && value != ""
+	}
+	if e.operator == operatorNotEqual {
+		return !(&attributeExpression{operator: operatorEqual, value: e.value}).compare(value)
+	}
+	switch expected := e.value.(type) {
+	case string:
+		actual, ok := value.(string)
+		if !ok {
+			return false
+		}
+		return compareStrings(e.operator, strings.ToLower(actual), strings.ToLower(expected))
+	case float64:
+		actual, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		return compareNumbers(e.operator, actual, expected)
+	case bool:
+		actual, ok := value.(bool)
+		return ok && e.operator == operatorEqual && actual == expected
+	case nil:
+		return e.operator == operatorEqual && value == nil
+	}
+	return false
+}
+
+func compareStrings(operator, actual, expected string) bool {
+	switch operator {
+	case operatorEqual:
+		return actual == expected
+	case operatorContains:
+		return strings.Contains(actual, expected)
+	case operatorStartsWith:
+		return strings.HasPrefix(actual, expected)
+	case operatorEndsWith:
+		return strings.HasSuffix(actual, expected)
+	case operatorGreaterThan:
+		return actual > expected
+	case operatorGreaterOrEqual:
+		return actual >= expected
+	case operatorLessThan:
+		return actual < expected
+	case operatorLessOrEqual:
+		return actual <= expected
+	}
+	return false
+}
+
+func compareNumbers(operator string, actual, expected float64) bool {
+	switch operator {
+	case operatorEqual:
+		return actual == expected
+	case operatorGreaterThan:
+		return actual > expected
+	case operatorGreaterOrEqual:
+		return actual >= expected
+	case operatorLessThan:
+		return actual < expected
+	case operatorLessOrEqual:
+		return actual <= expected
+	}
+	return false
+}
+
+func (e *logicalExpression) matches(resource map[string]interface{}) bool {
+	if e.operator == operatorAnd {
+		return e.left.matches(resource) && e.right.matches(resource)
+	}
+	return e.left.matches(resource) || e.right.matches(resource)
+}
+
+func (e *notExpression) matches(resource map[string]interface{}) bool {
+	return !e.filter.matches(resource)
+}
+
+func (e *valuePathExpression) matches(resource map[string]interface{}) bool {
+	values, _ := lookupValue(resource, e.attribute).([]interface{})
+	for _, value := range values {
+		if element, ok := value.(map[string]interface{}); ok && e.filter.matches(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupValue returns the value of the (case-insensitive) attribute path, e.g. name.givenname
+func lookupValue(resource map[string]interface{}, path string) interface{} {
+	name, subPath, isComplex := strings.Cut(path, ".")
+	for key, value := range resource {
+		if !strings.EqualFold(key, name) {
+			continue
+		}
+		if !isComplex {
+			return value
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			return lookupValue(v, subPath)
+		case []interface{}:
+			subValues := make([]interface{}, 0, len(v))
+			for _, element := range v {
+				if e, ok := element.(map[string]interface{}); ok {
+					subValues = append(subValues, lookupValue(e, subPath))
+				}
+			}
+			return subValues
+		}
+		return nil
+	}
+	return nil
+}
+
+// primaryValue returns the value sub-attribute of a complex value, which is compared
+// if a filter references a multi-valued attribute without a sub-attribute, e.g. emails co "@example.com"
+func primaryValue(value interface{}) interface{} {
+	if element, ok := value.(map[string]interface{}); ok {
+		return lookupValue(element, "value")
+	}
+	return value
+}
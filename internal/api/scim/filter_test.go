@@ -0,0 +1,174 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		want    filter
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			filter:  " ",
+			wantErr: true,
+		},
+		{
+			name:   "equal",
+			filter: `userName eq "bjensen"`,
+			want:   &attributeExpression{attribute: "username", operator: operatorEqual, value: "bjensen"},
+		},
+		{
+			name:   "schema prefix and escaped string",
+			filter: `urn:ietf:params:scim:schemas:core:2.0:User:name.familyName EQ "O\"Malley"`,
+			want:   &attributeExpression{attribute: "name.familyname", operator: operatorEqual, value: `O"Malley`},
+		},
+		{
+			name:   "present",
+			filter: `title pr`,
+			want:   &attributeExpression{attribute: "title", operator: operatorPresent},
+		},
+		{
+			name:   "boolean, number and null",
+			filter: `active eq true and age gt 21 and nickName eq null`,
+			want: &logicalExpression{
+				operator: operatorAnd,
+				left: &logicalExpression{
+					operator: operatorAnd,
+					left:     &attributeExpression{attribute: "active", operator: operatorEqual, value: true},
+					right:    &attributeExpression{attribute: "age", operator: operatorGreaterThan, value: float64(21)},
+				},
+				right: &attributeExpression{attribute: "nickname", operator: operatorEqual, value: nil},
+			},
+		},
+		{
+			name:   "and before or",
+			filter: `userName sw "a" or userName sw "b" and active eq false`,
+			want: &logicalExpression{
+				operator: operatorOr,
+				left:     &attributeExpression{attribute: "username", operator: operatorStartsWith, value: "a"},
+				right: &logicalExpression{
+					operator: operatorAnd,
+					left:     &attributeExpression{attribute: "username", operator: operatorStartsWith, value: "b"},
+					right:    &attributeExpression{attribute: "active", operator: operatorEqual, value: false},
+				},
+			},
+		},
+		{
+			name:   "parentheses and not",
+			filter: `not (userName sw "a" or userName sw "b") and active eq false`,
+			want: &logicalExpression{
+				operator: operatorAnd,
+				left: &notExpression{
+					filter: &logicalExpression{
+						operator: operatorOr,
+						left:     &attributeExpression{attribute: "username", operator: operatorStartsWith, value: "a"},
+						right:    &attributeExpression{attribute: "username", operator: operatorStartsWith, value: "b"},
+					},
+				},
+				right: &attributeExpression{attribute: "active", operator: operatorEqual, value: false},
+			},
+		},
+		{
+			name:   "value path",
+			filter: `emails[type eq "work" and value co "@example.com"]`,
+			want: &valuePathExpression{
+				attribute: "emails",
+				filter: &logicalExpression{
+					operator: operatorAnd,
+					left:     &attributeExpression{attribute: "type", operator: operatorEqual, value: "work"},
+					right:    &attributeExpression{attribute: "value", operator: operatorContains, value: "@example.com"},
+				},
+			},
+		},
+		{
+			name:    "unknown operator",
+			filter:  `userName is "bjensen"`,
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			filter:  `userName eq`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			filter:  `userName eq bjensen`,
+			wantErr: true,
+		},
+		{
+			name:    "unterminated string",
+			filter:  `userName eq "bjensen`,
+			wantErr: true,
+		},
+		{
+			name:    "missing parenthesis",
+			filter:  `(userName eq "bjensen"`,
+			wantErr: true,
+		},
+		{
+			name:    "not without parentheses",
+			filter:  `not userName eq "bjensen"`,
+			wantErr: true,
+		},
+		{
+			name:    "trailing tokens",
+			filter:  `userName eq "bjensen" "jsmith"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.filter)
+			if tt.wantErr {
+				scimErr := new(scimError)
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, scimTypeInvalidFilter, scimErr.ScimType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_filter_matches(t *testing.T) {
+	resource := map[string]interface{}{
+		"userName": "bjensen",
+		"name": map[string]interface{}{
+			"givenName": "Barbara",
+		},
+		"active": true,
+		"emails": []interface{}{
+			map[string]interface{}{"value": "bjensen@example.com", "type": "work"},
+			map[string]interface{}{"value": "babs@jensen.org", "type": "home"},
+		},
+	}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`userName eq "BJensen"`, true},
+		{`userName ne "bjensen"`, false},
+		{`name.givenName sw "bar"`, true},
+		{`name.familyName pr`, false},
+		{`active eq true`, true},
+		{`emails co "@jensen.org"`, true},
+		{`emails[type eq "work" and value ew "@example.com"]`, true},
+		{`emails[type eq "work" and value ew "@jensen.org"]`, false},
+		{`not (active eq true) or userName gt "a"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := parseFilter(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f.matches(resource))
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// group is the Group resource of the core schema (RFC 7643, section 4.2),
+// the externalId is accepted but not stored
+type group struct {
+	Schemas     []string       `json:"schemas"`
+	ID          string         `json:"id,omitempty"`
+	ExternalID  string         `json:"externalId,omitempty"`
+	Meta        *meta          `json:"meta,omitempty"`
+	DisplayName string         `json:"displayName"`
+	Members     []*groupMember `json:"members,omitempty"`
+}
+
+type groupMember struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+// groupToResource maps the group and its members, the location of a member is returned by memberLocation
+func groupToResource(g *query.Group, members []*query.Member, location string, memberLocation func(userID string) string) *group {
+	resource := &group{
+		Schemas: []string{schemaGroup},
+		ID:      g.ID,
+		Meta: &meta{
+			ResourceType: resourceTypeGroup,
+			Created:      g.CreationDate.UTC().Format(time.RFC3339),
+			LastModified: g.ChangeDate.UTC().Format(time.RFC3339),
+			Location:     location,
+			Version:      `W/"` + strconv.FormatUint(g.Sequence, 10) + `"`,
+		},
+		DisplayName: g.Name,
+	}
+	if len(members) == 0 {
+		return resource
+	}
+	resource.Members = make([]*groupMember, len(members))
+	for i, member := range members {
+		resource.Members[i] = &groupMember{
+			Value:   member.UserID,
+			Ref:     memberLocation(member.UserID),
+			Display: member.DisplayName,
+			Type:    resourceTypeUser,
+		}
+	}
+	return resource
+}
+
+func (g *group) validate() error {
+	if !containsSchema(g.Schemas, schemaGroup) {
+		return errInvalidValue("schema " + schemaGroup + " is required")
+	}
+	if strings.TrimSpace(g.DisplayName) == "" {
+		return errInvalidValue("displayName is required")
+	}
+	for _, member := range g.Members {
+		if member.Type != "" && !strings.EqualFold(member.Type, resourceTypeUser) {
+			return errInvalidValue("only users can be members of a group")
+		}
+	}
+	return nil
+}
+
+// memberIDs returns the ids of the members, which are never nil, so all members are removed if there are none
+func (g *group) memberIDs() []string {
+	ids := make([]string, len(g.Members))
+	for i, member := range g.Members {
+		ids[i] = strings.TrimSpace(member.Value)
+	}
+	return ids
+}
+
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request) {
+	h.writeGroup(w, r, http.StatusOK, mux.Vars(r)[varID])
+}
+
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resource := new(group)
+	if err := readRequest(w, r, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if err := resource.validate(); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	add := &command.AddGroup{
+		Name:    resource.DisplayName,
+		Members: resource.memberIDs(),
+	}
+	if _, err := h.commands.AddGroup(ctx, add, authz.GetCtxData(ctx).OrgID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.writeGroup(w, r, http.StatusCreated, add.AggregateID)
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	existing, _, err := h.groupByID(ctx, mux.Vars(r)[varID], false)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resource := new(group)
+	if err = readRequest(w, r, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if err = h.updateGroup(ctx, existing, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.writeGroup(w, r, http.StatusOK, existing.ID)
+}
+
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	existing, members, err := h.groupByID(ctx, mux.Vars(r)[varID], false)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	request := new(patchRequest)
+	if err = readRequest(w, r, request); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resource := new(group)
+	if err = request.apply(groupToResource(existing, members, "", func(string) string { return "" }), patchGroupAttributes, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if err = h.updateGroup(ctx, existing, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.writeGroup(w, r, http.StatusOK, existing.ID)
+}
+
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	existing, _, err := h.groupByID(ctx, mux.Vars(r)[varID], false)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if _, err = h.commands.RemoveGroup(ctx, existing.ID, existing.ResourceOwner); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, http.StatusNoContent, nil)
+}
+
+// updateGroup changes the name and replaces the members of the existing group at once
+func (h *Handler) updateGroup(ctx context.Context, existing *query.Group, resource *group) error {
+	if err := resource.validate(); err != nil {
+		return err
+	}
+	_, err := h.commands.ChangeGroup(ctx, &command.ChangeGroup{
+		ObjectRoot: models.ObjectRoot{AggregateID: existing.ID},
+		Name:       &resource.DisplayName,
+		Members:    resource.memberIDs(),
+	}, existing.ResourceOwner)
+	return err
+}
+
+// groupByID returns the group of the organization of the request together with its members
+func (h *Handler) groupByID(ctx context.Context, groupID string, shouldTriggerBulk bool) (*query.Group, []*query.Member, error) {
+	existing, err := h.query.GroupByID(ctx, shouldTriggerBulk, groupID, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	members, err := h.query.GroupMembers(ctx, &query.GroupMembersQuery{GroupID: existing.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return existing, members.Members, nil
+}
+
+// writeGroup writes the current state of the group, the projections are triggered to include the changes of the request
+func (h *Handler) writeGroup(w http.ResponseWriter, r *http.Request, status int, groupID string) {
+	g, members, err := h.groupByID(r.Context(), groupID, true)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	location := h.location(r, "Groups", g.ID)
+	resource := groupToResource(g, members, location, func(userID string) string {
+		return h.location(r, "Users", userID)
+	})
+	w.Header().Set("Location", location)
+	writeResponse(w, status, resource)
+}
@@ -0,0 +1,97 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request) {
+	request, err := searchRequestFromQuery(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.searchGroup(w, r, request)
+}
+
+func (h *Handler) searchGroups(w http.ResponseWriter, r *http.Request) {
+	request, err := readSearchRequest(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.searchGroup(w, r, request)
+}
+
+// searchGroup returns the groups matching the request without their members,
+// which are only returned for a single group
+func (h *Handler) searchGroup(w http.ResponseWriter, r *http.Request, request *searchRequest) {
+	ctx := r.Context()
+	queries, err := groupSearchQueries(authz.GetCtxData(ctx).OrgID, request.Filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	startIndex, count, limit := request.page()
+	groups, err := h.query.SearchGroups(ctx, &query.GroupSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        startIndex - 1,
+			Limit:         limit,
+			SortingColumn: query.GroupColumnName,
+			Asc:           true,
+		},
+		Queries: queries,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resources := make([]interface{}, 0, count)
+	for i := 0; uint64(i) < count && i < len(groups.Groups); i++ {
+		resources = append(resources, groupToResource(groups.Groups[i], nil, h.location(r, "Groups", groups.Groups[i].ID), nil))
+	}
+	writeResponse(w, http.StatusOK, newListResponse(groups.Count, startIndex, resources))
+}
+
+// groupSearchQueries returns the queries of the groups of the organization matching the filter
+func groupSearchQueries(orgID, filterExpression string) ([]query.SearchQuery, error) {
+	resourceOwnerQuery, err := query.NewGroupResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	queries := []query.SearchQuery{resourceOwnerQuery}
+	if filterExpression == "" {
+		return queries, nil
+	}
+	filterQuery, err := filterExpressionToQuery(filterExpression, groupAttributeToQuery)
+	if err != nil {
+		return nil, err
+	}
+	return append(queries, filterQuery), nil
+}
+
+// groupAttributeToQuery supports filtering by the id and displayName of the group
+// and by its members, e.g. to check the membership of a user with members[value eq "userId"]
+func groupAttributeToQuery(attribute, operator string, value interface{}) (query.SearchQuery, error) {
+	switch attribute {
+	case "id":
+		return idToQuery(operator, value, query.NewGroupIDsSearchQuery)
+	case "displayname":
+		return textToQuery(attribute, operator, value, func(name string, comparison query.TextComparison) (query.SearchQuery, error) {
+			return query.NewGroupNameSearchQuery(comparison, name)
+		})
+	case "members", "members.value":
+		userID, ok := value.(string)
+		if !ok || (operator != operatorEqual && operator != operatorNotEqual) {
+			return nil, errInvalidFilter("members only supports eq and ne with a string")
+		}
+		q, err := query.NewGroupMemberUserIDSearchQuery(userID)
+		if err != nil {
+			return nil, err
+		}
+		return negate(q, operator), nil
+	}
+	return nil, errInvalidFilter("filtering by " + attribute + " is not supported")
+}
@@ -0,0 +1,77 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_groupSearchQueries(t *testing.T) {
+	must := mustQuery(t)
+	defaultQueries := []query.SearchQuery{
+		must(query.NewGroupResourceOwnerSearchQuery("org1")),
+	}
+	tests := []struct {
+		name    string
+		filter  string
+		want    query.SearchQuery
+		wantErr bool
+	}{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "display name",
+			filter: `displayName eq "Tour Guides"`,
+			want:   must(query.NewGroupNameSearchQuery(query.TextEqualsIgnoreCase, "Tour Guides")),
+		},
+		{
+			name:   "id",
+			filter: `id ne "group1"`,
+			want:   query.Not(must(query.NewGroupIDsSearchQuery([]string{"group1"}))),
+		},
+		{
+			name:   "member",
+			filter: `displayName sw "Tour" and members[value eq "user1"]`,
+			want: query.And(
+				must(query.NewGroupNameSearchQuery(query.TextStartsWithIgnoreCase, "Tour")),
+				must(query.NewGroupMemberUserIDSearchQuery("user1")),
+			),
+		},
+		{
+			name:   "member value",
+			filter: `members.value eq "user1"`,
+			want:   must(query.NewGroupMemberUserIDSearchQuery("user1")),
+		},
+		{
+			name:    "unsupported member operator",
+			filter:  `members co "user"`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported attribute",
+			filter:  `externalId eq "external1"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := groupSearchQueries("org1", tt.filter)
+			if tt.wantErr {
+				scimErr := new(scimError)
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, scimTypeInvalidFilter, scimErr.ScimType)
+				return
+			}
+			require.NoError(t, err)
+			want := defaultQueries
+			if tt.want != nil {
+				want = append(want, tt.want)
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
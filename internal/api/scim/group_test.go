@@ -0,0 +1,100 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_groupToResource(t *testing.T) {
+	got := groupToResource(&query.Group{
+		ID:           "group1",
+		CreationDate: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		ChangeDate:   time.Date(2023, 2, 3, 4, 5, 6, 0, time.UTC),
+		Sequence:     42,
+		State:        domain.GroupStateActive,
+		Name:         "Tour Guides",
+	}, []*query.Member{
+		{UserID: "user1", DisplayName: "Babs Jensen"},
+	}, "https://zitadel.cloud/scim/v2/org1/Groups/group1", func(userID string) string {
+		return "https://zitadel.cloud/scim/v2/org1/Users/" + userID
+	})
+	assert.Equal(t, &group{
+		Schemas: []string{schemaGroup},
+		ID:      "group1",
+		Meta: &meta{
+			ResourceType: resourceTypeGroup,
+			Created:      "2023-01-02T03:04:05Z",
+			LastModified: "2023-02-03T04:05:06Z",
+			Location:     "https://zitadel.cloud/scim/v2/org1/Groups/group1",
+			Version:      `W/"42"`,
+		},
+		DisplayName: "Tour Guides",
+		Members: []*groupMember{
+			{
+				Value:   "user1",
+				Ref:     "https://zitadel.cloud/scim/v2/org1/Users/user1",
+				Display: "Babs Jensen",
+				Type:    resourceTypeUser,
+			},
+		},
+	}, got)
+}
+
+func Test_group_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   *group
+		wantErr bool
+	}{
+		{
+			name:    "missing schema",
+			group:   &group{DisplayName: "Tour Guides"},
+			wantErr: true,
+		},
+		{
+			name:    "missing display name",
+			group:   &group{Schemas: []string{schemaGroup}, DisplayName: " "},
+			wantErr: true,
+		},
+		{
+			name:    "group as member",
+			group:   &group{Schemas: []string{schemaGroup}, DisplayName: "Tour Guides", Members: []*groupMember{{Value: "group2", Type: resourceTypeGroup}}},
+			wantErr: true,
+		},
+		{
+			name:  "valid",
+			group: &group{Schemas: []string{schemaGroup}, DisplayName: "Tour Guides", Members: []*groupMember{{Value: "user1", Type: "user"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.group.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_group_memberIDs(t *testing.T) {
+	resource := new(group)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"displayName": "Tour Guides"
+	}`), resource))
+	assert.Equal(t, []string{}, resource.memberIDs())
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"members": [{"value": " user1 ", "$ref": "https://zitadel.cloud/scim/v2/org1/Users/user1"}, {"value": "user2"}]
+	}`), resource))
+	assert.Equal(t, []string{"user1", "user2"}, resource.memberIDs())
+}
@@ -0,0 +1,376 @@
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	patchAdd     = "add"
+	patchReplace = "replace"
+	patchRemove  = "remove"
+)
+
+// patchRequest modifies a resource (RFC 7644, section 3.5.2)
+type patchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// patchPath is the parsed path of an operation, e.g. emails[type eq "work"].value,
+// attribute and sub-attribute are lower case
+type patchPath struct {
+	attribute    string
+	filter       filter
+	subAttribute string
+}
+
+// patchUserAttributes and patchGroupAttributes are the attributes of the resources which can be modified
+var (
+	patchUserAttributes  = append(append(make([]*schemaAttribute, 0, len(userAttributes)+1), userAttributes...), externalIDAttribute)
+	patchGroupAttributes = append(append(make([]*schemaAttribute, 0, len(groupAttributes)+1), groupAttributes...), externalIDAttribute)
+)
+
+// apply applies the operations to the JSON representation of the resource and decodes the result into patched,
+// which is then saved like a replaced resource
+func (p *patchRequest) apply(resource interface{}, attributes []*schemaAttribute, patched interface{}) error {
+	if !containsSchema(p.Schemas, schemaPatchOp) {
+		return errInvalidValue("schema " + schemaPatchOp + " is required")
+	}
+	if len(p.Operations) == 0 {
+		return errInvalidValue("operations are missing")
+	}
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{})
+	if err = json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	for _, operation := range p.Operations {
+		if err = operation.apply(values, attributes); err != nil {
+			return err
+		}
+	}
+	if data, err = json.Marshal(values); err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, patched); err != nil {
+		return errInvalidValue("invalid value: " + err.Error())
+	}
+	return nil
+}
+
+func (o *patchOperation) apply(resource map[string]interface{}, attributes []*schemaAttribute) error {
+	op := strings.ToLower(o.Op)
+	if op != patchAdd && op != patchReplace && op != patchRemove {
+		return errInvalidSyntax("unknown operation " + o.Op)
+	}
+	var value interface{}
+	if len(o.Value) > 0 {
+		if err := json.Unmarshal(o.Value, &value); err != nil {
+			return errInvalidValue("invalid value: " + err.Error())
+		}
+	}
+	if o.Path != "" {
+		path, err := parsePath(o.Path)
+		if err != nil {
+			return err
+		}
+		if op != patchRemove && value == nil {
+			return errInvalidValue("value is required for " + op)
+		}
+		return path.apply(resource, attributes, op, value)
+	}
+	// without a path the value contains the attributes (or paths) to modify
+	if op == patchRemove {
+		return errNoTarget("path is required for remove")
+	}
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return errInvalidValue("value must be an object if no path is specified")
+	}
+	for key, v := range values {
+		if strings.EqualFold(key, "schemas") {
+			continue
+		}
+		path, err := parsePath(key)
+		if err != nil {
+			return err
+		}
+		if err = path.apply(resource, attributes, op, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parsePath(path string) (*patchPath, error) {
+	path = stripSchema(path)
+	p := new(patchPath)
+	if start := strings.IndexByte(path, '['); start >= 0 {
+		end := strings.LastIndexByte(path, ']')
+		if end < start {
+			return nil, errInvalidPath("invalid path " + path)
+		}
+		f, err := parseFilter(path[start+1 : end])
+		if err != nil {
+			return nil, err
+		}
+		p.attribute, p.filter = path[:start], f
+		if rest := path[end+1:]; rest != "" {
+			subAttribute, ok := strings.CutPrefix(rest, ".")
+			if !ok {
+				return nil, errInvalidPath("invalid path " + path)
+			}
+			p.subAttribute = subAttribute
+		}
+	} else {
+		p.attribute, p.subAttribute, _ = strings.Cut(path, ".")
+	}
+	if p.attribute == "" {
+		return nil, errInvalidPath("invalid path " + path)
+	}
+	p.attribute, p.subAttribute = strings.ToLower(p.attribute), strings.ToLower(p.subAttribute)
+	return p, nil
+}
+
+func (p *patchPath) apply(resource map[string]interface{}, attributes []*schemaAttribute, op string, value interface{}) error {
+	attribute := findAttribute(attributes, p.attribute)
+	if attribute == nil {
+		return errInvalidPath("unknown attribute " + p.attribute)
+	}
+	if p.filter == nil && p.subAttribute == "" {
+		if op == patchRemove && attribute.MultiValued && value != nil {
+			return removeValues(resource, attribute, value)
+		}
+		return applyValue(resource, attribute, op, value)
+	}
+	var subAttribute *schemaAttribute
+	if p.subAttribute != "" {
+		if subAttribute = findAttribute(attribute.SubAttributes, p.subAttribute); subAttribute == nil {
+			return errInvalidPath("unknown attribute " + p.attribute + "." + p.subAttribute)
+		}
+		if subAttribute.Mutability == mutabilityReadOnly {
+			return errMutability(attribute.Name + "." + subAttribute.Name + " is read only")
+		}
+	}
+	if !attribute.MultiValued {
+		if p.filter != nil {
+			return errInvalidFilter("value filters are only supported on multi-valued attributes")
+		}
+		complexValue, _ := lookupValue(resource, attribute.Name).(map[string]interface{})
+		if complexValue == nil {
+			if op == patchRemove {
+				return nil
+			}
+			complexValue = make(map[string]interface{})
+		}
+		if err := applyValue(complexValue, subAttribute, op, value); err != nil {
+			return err
+		}
+		setValue(resource, attribute.Name, complexValue)
+		return nil
+	}
+	return p.applyMultiValued(resource, attribute, subAttribute, op, value)
+}
+
+// applyMultiValued applies the operation to the (filtered) values of the multi-valued attribute.
+// If no value matches the filter, a value is added for add and replace operations, e.g. the work email.
+func (p *patchPath) applyMultiValued(resource map[string]interface{}, attribute, subAttribute *schemaAttribute, op string, value interface{}) error {
+	values, _ := lookupValue(resource, attribute.Name).([]interface{})
+	patched := make([]interface{}, 0, len(values)+1)
+	matched := false
+	for _, v := range values {
+		element, ok := v.(map[string]interface{})
+		if !ok || (p.filter != nil && !p.filter.matches(element)) {
+			patched = append(patched, v)
+			continue
+		}
+		matched = true
+		if subAttribute != nil {
+			if err := applyValue(element, subAttribute, op, value); err != nil {
+				return err
+			}
+			patched = append(patched, element)
+			continue
+		}
+		switch op {
+		case patchRemove:
+			continue
+		case patchReplace:
+			element = make(map[string]interface{})
+		}
+		if err := mergeValue(element, attribute, value); err != nil {
+			return err
+		}
+		patched = append(patched, element)
+	}
+	if !matched && op != patchRemove {
+		element := make(map[string]interface{})
+		if p.filter != nil && !filterValues(p.filter, attribute, element) {
+			return errNoTarget("no value matches " + p.attribute)
+		}
+		var err error
+		if subAttribute != nil {
+			err = applyValue(element, subAttribute, op, value)
+		} else {
+			err = mergeValue(element, attribute, value)
+		}
+		if err != nil {
+			return err
+		}
+		patched = append(patched, element)
+	}
+	if len(patched) == 0 {
+		setValue(resource, attribute.Name, nil)
+		return nil
+	}
+	setValue(resource, attribute.Name, patched)
+	return nil
+}
+
+// applyValue applies the operation to the attribute of the resource (or the complex value)
+func applyValue(resource map[string]interface{}, attribute *schemaAttribute, op string, value interface{}) error {
+	if op == patchRemove {
+		setValue(resource, attribute.Name, nil)
+		return nil
+	}
+	if attribute.Type == "complex" && !attribute.MultiValued {
+		complexValue, _ := lookupValue(resource, attribute.Name).(map[string]interface{})
+		if complexValue == nil {
+			complexValue = make(map[string]interface{})
+		}
+		if err := mergeValue(complexValue, attribute, value); err != nil {
+			return err
+		}
+		setValue(resource, attribute.Name, complexValue)
+		return nil
+	}
+	if !attribute.MultiValued {
+		setValue(resource, attribute.Name, value)
+		return nil
+	}
+	newValues, ok := value.([]interface{})
+	if !ok {
+		newValues = []interface{}{value}
+	}
+	if op == patchReplace {
+		setValue(resource, attribute.Name, newValues)
+		return nil
+	}
+	values, _ := lookupValue(resource, attribute.Name).([]interface{})
+	for _, newValue := range newValues {
+		if isPrimary(newValue) {
+			// only one value can be primary
+			for _, v := range values {
+				if element, ok := v.(map[string]interface{}); ok {
+					setValue(element, "primary", false)
+				}
+			}
+		}
+	}
+	setValue(resource, attribute.Name, append(values, newValues...))
+	return nil
+}
+
+// removeValues removes the values of the multi-valued attribute which are equal to one of the given values,
+// e.g. the members of a group sent as value of a remove operation without filter
+func removeValues(resource map[string]interface{}, attribute *schemaAttribute, value interface{}) error {
+	removed, ok := value.([]interface{})
+	if !ok {
+		removed = []interface{}{value}
+	}
+	values, _ := lookupValue(resource, attribute.Name).([]interface{})
+	patched := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		if !containsValue(removed, primaryValue(v)) {
+			patched = append(patched, v)
+		}
+	}
+	if len(patched) == 0 {
+		setValue(resource, attribute.Name, nil)
+		return nil
+	}
+	setValue(resource, attribute.Name, patched)
+	return nil
+}
+
+// containsValue checks if the (string) value is the value of one of the values
+func containsValue(values []interface{}, value interface{}) bool {
+	text, ok := value.(string)
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if primaryValue(v) == text {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeValue sets the sub-attributes of the value into the complex value,
+// read only sub-attributes are ignored
+func mergeValue(complexValue map[string]interface{}, attribute *schemaAttribute, value interface{}) error {
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return errInvalidValue(attribute.Name + " must be an object")
+	}
+	for key, v := range values {
+		subAttribute := findAttribute(attribute.SubAttributes, key)
+		if subAttribute == nil {
+			return errInvalidPath("unknown attribute " + attribute.Name + "." + key)
+		}
+		if subAttribute.Mutability != mutabilityReadOnly {
+			setValue(complexValue, subAttribute.Name, v)
+		}
+	}
+	return nil
+}
+
+// filterValues sets the values of the equality expressions of the filter into the new value
+// and returns false if the filter cannot be represented as value
+func filterValues(f filter, attribute *schemaAttribute, element map[string]interface{}) bool {
+	switch e := f.(type) {
+	case *attributeExpression:
+		subAttribute := findAttribute(attribute.SubAttributes, e.attribute)
+		if subAttribute == nil || e.operator != operatorEqual {
+			return false
+		}
+		setValue(element, subAttribute.Name, e.value)
+		return true
+	case *logicalExpression:
+		return e.operator == operatorAnd && filterValues(e.left, attribute, element) && filterValues(e.right, attribute, element)
+	}
+	return false
+}
+
+// setValue sets the value of the case-insensitive key, a nil value removes the key
+func setValue(resource map[string]interface{}, key string, value interface{}) {
+	for k := range resource {
+		if strings.EqualFold(k, key) {
+			delete(resource, k)
+		}
+	}
+	if value != nil {
+		resource[key] = value
+	}
+}
+
+func isPrimary(value interface{}) bool {
+	element, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	var primary boolean
+	data, err := json.Marshal(lookupValue(element, "primary"))
+	return err == nil && json.Unmarshal(data, &primary) == nil && bool(primary)
+}
@@ -0,0 +1,236 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_patchRequest_apply(t *testing.T) {
+	active := boolean(true)
+	inactive := boolean(false)
+	existing := func() *user {
+		return &user{
+			Schemas:    []string{schemaUser},
+			ID:         "user1",
+			ExternalID: "external1",
+			UserName:   "bjensen",
+			Name: &name{
+				Formatted:  "Barbara Jensen",
+				FamilyName: "Jensen",
+				GivenName:  "Barbara",
+			},
+			DisplayName: "Babs",
+			Active:      &active,
+			Emails:      []*multiValue{{Value: "bjensen@example.com", Type: emailTypeWork, Primary: true}},
+		}
+	}
+	tests := []struct {
+		name       string
+		operations string
+		want       func(u *user)
+		wantErr    string
+	}{
+		{
+			name:       "deactivate with string value",
+			operations: `[{"op":"Replace","path":"active","value":"False"}]`,
+			want: func(u *user) {
+				u.Active = &inactive
+			},
+		},
+		{
+			name:       "replace without path",
+			operations: `[{"op":"replace","value":{"active":false,"name.givenName":"Babs","urn:ietf:params:scim:schemas:core:2.0:User:nickName":"B"}}]`,
+			want: func(u *user) {
+				u.Active = &inactive
+				u.Name.GivenName = "Babs"
+				u.NickName = "B"
+			},
+		},
+		{
+			name:       "replace complex attribute",
+			operations: `[{"op":"replace","path":"name","value":{"familyName":"Smith","formatted":"ignored"}}]`,
+			want: func(u *user) {
+				u.Name.FamilyName = "Smith"
+			},
+		},
+		{
+			name:       "replace filtered value",
+			operations: `[{"op":"replace","path":"emails[type eq \"work\"].value","value":"barbara@example.com"}]`,
+			want: func(u *user) {
+				u.Emails[0].Value = "barbara@example.com"
+			},
+		},
+		{
+			name:       "add value for filter without match",
+			operations: `[{"op":"add","path":"phoneNumbers[type eq \"mobile\"].value","value":"+41791234567"}]`,
+			want: func(u *user) {
+				u.PhoneNumbers = []*multiValue{{Value: "+41791234567", Type: phoneTypeMobile}}
+			},
+		},
+		{
+			name:       "add primary email",
+			operations: `[{"op":"add","path":"emails","value":[{"value":"babs@example.com","primary":true}]}]`,
+			want: func(u *user) {
+				u.Emails[0].Primary = false
+				u.Emails = append(u.Emails, &multiValue{Value: "babs@example.com", Primary: true})
+			},
+		},
+		{
+			name:       "remove attributes",
+			operations: `[{"op":"remove","path":"externalId"},{"op":"remove","path":"name.givenName"},{"op":"remove","path":"emails[type eq \"home\"]"}]`,
+			want: func(u *user) {
+				u.ExternalID = ""
+				u.Name.GivenName = ""
+			},
+		},
+		{
+			name:       "remove filtered value",
+			operations: `[{"op":"remove","path":"emails[type eq \"work\"]"}]`,
+			want: func(u *user) {
+				u.Emails = nil
+			},
+		},
+		{
+			name:       "unknown operation",
+			operations: `[{"op":"move","path":"userName","value":"jsmith"}]`,
+			wantErr:    scimTypeInvalidSyntax,
+		},
+		{
+			name:       "unknown attribute",
+			operations: `[{"op":"replace","path":"title","value":"Tour Guide"}]`,
+			wantErr:    scimTypeInvalidPath,
+		},
+		{
+			name:       "read only attribute",
+			operations: `[{"op":"replace","path":"name.formatted","value":"Babs Jensen"}]`,
+			wantErr:    scimTypeMutability,
+		},
+		{
+			name:       "remove without path",
+			operations: `[{"op":"remove"}]`,
+			wantErr:    scimTypeNoTarget,
+		},
+		{
+			name:       "filter on single valued attribute",
+			operations: `[{"op":"replace","path":"name[givenName eq \"Barbara\"].familyName","value":"Smith"}]`,
+			wantErr:    scimTypeInvalidFilter,
+		},
+		{
+			name:       "invalid value type",
+			operations: `[{"op":"replace","path":"userName","value":42}]`,
+			wantErr:    scimTypeInvalidValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &patchRequest{Schemas: []string{schemaPatchOp}}
+			require.NoError(t, json.Unmarshal([]byte(tt.operations), &request.Operations))
+			got := new(user)
+			err := request.apply(existing(), patchUserAttributes, got)
+			if tt.wantErr != "" {
+				scimErr := new(scimError)
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, tt.wantErr, scimErr.ScimType)
+				return
+			}
+			require.NoError(t, err)
+			want := existing()
+			tt.want(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func Test_patchRequest_apply_schema(t *testing.T) {
+	err := (&patchRequest{Operations: []*patchOperation{{Op: patchRemove, Path: "nickName"}}}).apply(&user{}, patchUserAttributes, new(user))
+	scimErr := new(scimError)
+	require.ErrorAs(t, err, &scimErr)
+	assert.Equal(t, scimTypeInvalidValue, scimErr.ScimType)
+}
+
+func Test_patchRequest_apply_group(t *testing.T) {
+	existing := func() *group {
+		return &group{
+			Schemas:     []string{schemaGroup},
+			ID:          "group1",
+			DisplayName: "Tour Guides",
+			Members: []*groupMember{
+				{Value: "user1", Display: "Babs Jensen", Type: resourceTypeUser},
+				{Value: "user2", Display: "Mandy Pepperidge", Type: resourceTypeUser},
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		operations string
+		want       func(g *group)
+		wantErr    string
+	}{
+		{
+			name:       "add member",
+			operations: `[{"op":"add","path":"members","value":[{"value":"user3"}]}]`,
+			want: func(g *group) {
+				g.Members = append(g.Members, &groupMember{Value: "user3"})
+			},
+		},
+		{
+			name:       "remove member by filter",
+			operations: `[{"op":"remove","path":"members[value eq \"user1\"]"}]`,
+			want: func(g *group) {
+				g.Members = g.Members[1:]
+			},
+		},
+		{
+			name:       "remove member by value",
+			operations: `[{"op":"Remove","path":"members","value":[{"value":"user2"}]}]`,
+			want: func(g *group) {
+				g.Members = g.Members[:1]
+			},
+		},
+		{
+			name:       "remove all members",
+			operations: `[{"op":"remove","path":"members"}]`,
+			want: func(g *group) {
+				g.Members = nil
+			},
+		},
+		{
+			name:       "replace display name without path",
+			operations: `[{"op":"replace","value":{"id":"group1","displayName":"Guides"}}]`,
+			wantErr:    scimTypeInvalidPath,
+		},
+		{
+			name:       "replace display name",
+			operations: `[{"op":"replace","value":{"displayName":"Guides"}}]`,
+			want: func(g *group) {
+				g.DisplayName = "Guides"
+			},
+		},
+		{
+			name:       "user attribute",
+			operations: `[{"op":"replace","path":"userName","value":"bjensen"}]`,
+			wantErr:    scimTypeInvalidPath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &patchRequest{Schemas: []string{schemaPatchOp}}
+			require.NoError(t, json.Unmarshal([]byte(tt.operations), &request.Operations))
+			got := new(group)
+			err := request.apply(existing(), patchGroupAttributes, got)
+			if tt.wantErr != "" {
+				scimErr := new(scimError)
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, tt.wantErr, scimErr.ScimType)
+				return
+			}
+			require.NoError(t, err)
+			want := existing()
+			tt.want(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
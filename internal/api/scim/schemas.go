@@ -0,0 +1,369 @@
+package scim
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	schemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaSearchRequest         = "urn:ietf:params:scim:api:messages:2.0:SearchRequest"
+	schemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaBulkRequest           = "urn:ietf:params:scim:api:messages:2.0:BulkRequest"
+	schemaBulkResponse          = "urn:ietf:params:scim:api:messages:2.0:BulkResponse"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	resourceTypeUser  = "User"
+	resourceTypeGroup = "Group"
+
+	mutabilityReadOnly  = "readOnly"
+	mutabilityReadWrite = "readWrite"
+	mutabilityImmutable = "immutable"
+	mutabilityWriteOnly = "writeOnly"
+
+	returnedDefault = "default"
+	returnedNever   = "never"
+)
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location"`
+	Version      string `json:"version,omitempty"`
+}
+
+type schema struct {
+	Schemas     []string           `json:"schemas"`
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Attributes  []*schemaAttribute `json:"attributes"`
+	Meta        *meta              `json:"meta"`
+}
+
+type schemaAttribute struct {
+	Name            string             `json:"name"`
+	Type            string             `json:"type"`
+	SubAttributes   []*schemaAttribute `json:"subAttributes,omitempty"`
+	MultiValued     bool               `json:"multiValued"`
+	Description     string             `json:"description"`
+	Required        bool               `json:"required"`
+	CanonicalValues []string           `json:"canonicalValues,omitempty"`
+	CaseExact       bool               `json:"caseExact"`
+	Mutability      string             `json:"mutability"`
+	Returned        string             `json:"returned"`
+	Uniqueness      string             `json:"uniqueness"`
+}
+
+func stringAttribute(name, description string, required bool, mutability, returned string) *schemaAttribute {
+	return &schemaAttribute{
+		Name:        name,
+		Type:        "string",
+		Description: description,
+		Required:    required,
+		Mutability:  mutability,
+		Returned:    returned,
+		Uniqueness:  "none",
+	}
+}
+
+func multiValuedAttribute(name, description string, canonicalTypes ...string) *schemaAttribute {
+	valueType := stringAttribute("type", "A label indicating the attribute's function.", false, mutabilityReadWrite, returnedDefault)
+	valueType.CanonicalValues = canonicalTypes
+	return &schemaAttribute{
+		Name: name,
+		Type: "complex",
+		SubAttributes: []*schemaAttribute{
+			stringAttribute("value", description, false, mutabilityReadWrite, returnedDefault),
+			valueType,
+			{
+				Name:        "primary",
+				Type:        "boolean",
+				Description: "A Boolean value indicating the 'primary' or preferred attribute value. ZITADEL only stores one value, which is always primary.",
+				Mutability:  mutabilityReadWrite,
+				Returned:    returnedDefault,
+			},
+		},
+		MultiValued: true,
+		Description: description,
+		Mutability:  mutabilityReadWrite,
+		Returned:    returnedDefault,
+		Uniqueness:  "none",
+	}
+}
+
+// userAttributes are the attributes of the core user schema (RFC 7643, section 4.1) supported by ZITADEL
+var userAttributes = []*schemaAttribute{
+	{
+		Name:        "userName",
+		Type:        "string",
+		Description: "Unique identifier for the User, used to directly authenticate to ZITADEL.",
+		Required:    true,
+		Mutability:  mutabilityReadWrite,
+		Returned:    returnedDefault,
+		Uniqueness:  "server",
+	},
+	{
+		Name: "name",
+		Type: "complex",
+		SubAttributes: []*schemaAttribute{
+			stringAttribute("formatted", "The full name, ignored on changes.", false, mutabilityReadOnly, returnedDefault),
+			stringAttribute("familyName", "The family name of the User.", true, mutabilityReadWrite, returnedDefault),
+			stringAttribute("givenName", "The given name of the User.", true, mutabilityReadWrite, returnedDefault),
+		},
+		Description: "The components of the user's real name.",
+		Required:    true,
+		Mutability:  mutabilityReadWrite,
+		Returned:    returnedDefault,
+		Uniqueness:  "none",
+	},
+	stringAttribute("displayName", "The name of the User, suitable for display to end-users.", false, mutabilityReadWrite, returnedDefault),
+	stringAttribute("nickName", "The casual way to address the user in real life.", false, mutabilityReadWrite, returnedDefault),
+	stringAttribute("preferredLanguage", "Indicates the User's preferred written or spoken language.", false, mutabilityReadWrite, returnedDefault),
+	{
+		Name:        "active",
+		Type:        "boolean",
+		Description: "A Boolean value indicating the User's administrative status.",
+		Mutability:  mutabilityReadWrite,
+		Returned:    returnedDefault,
+	},
+	stringAttribute("password", "The User's cleartext password, checked against the password complexity policy.", false, mutabilityWriteOnly, returnedNever),
+	multiValuedAttribute("emails", "Email address for the User, which is verified on provisioning.", "work"),
+	multiValuedAttribute("phoneNumbers", "Phone number for the User, which is verified on provisioning.", "mobile"),
+}
+
+// groupAttributes are the attributes of the core group schema (RFC 7643, section 4.2) supported by ZITADEL
+var groupAttributes = []*schemaAttribute{
+	{
+		Name:        "displayName",
+		Type:        "string",
+		Description: "A human-readable name for the Group, which is unique in the organization.",
+		Required:    true,
+		Mutability:  mutabilityReadWrite,
+		Returned:    returnedDefault,
+		Uniqueness:  "server",
+	},
+	{
+		Name: "members",
+		Type: "complex",
+		SubAttributes: []*schemaAttribute{
+			stringAttribute("value", "Identifier of the member of this Group.", false, mutabilityImmutable, returnedDefault),
+			{
+				Name:        "$ref",
+				Type:        "reference",
+				Description: "The URI corresponding to a SCIM resource that is a member of this Group.",
+				Mutability:  mutabilityImmutable,
+				Returned:    returnedDefault,
+				Uniqueness:  "none",
+			},
+			stringAttribute("display", "The display name of the member, ignored on changes.", false, mutabilityReadOnly, returnedDefault),
+			{
+				Name:            "type",
+				Type:            "string",
+				Description:     "A label indicating the type of resource, only users can be members.",
+				CanonicalValues: []string{resourceTypeUser},
+				Mutability:      mutabilityImmutable,
+				Returned:        returnedDefault,
+				Uniqueness:      "none",
+			},
+		},
+		MultiValued: true,
+		Description: "A list of users of the organization which are members of the Group.",
+		Mutability:  mutabilityReadWrite,
+		Returned:    returnedDefault,
+		Uniqueness:  "none",
+	},
+}
+
+// externalIDAttribute is a common attribute of all resources (RFC 7643, section 3.1),
+// which is not part of the schema itself
+var externalIDAttribute = stringAttribute("externalId", "An identifier for the resource as defined by the provisioning client.", false, mutabilityReadWrite, returnedDefault)
+
+var schemas = []*schema{
+	{
+		Schemas:     []string{schemaSchema},
+		ID:          schemaUser,
+		Name:        "User",
+		Description: "User Account",
+		Attributes:  userAttributes,
+	},
+	{
+		Schemas:     []string{schemaSchema},
+		ID:          schemaGroup,
+		Name:        "Group",
+		Description: "Group",
+		Attributes:  groupAttributes,
+	},
+}
+
+type resourceType struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Description string   `json:"description"`
+	Schema      string   `json:"schema"`
+	Meta        *meta    `json:"meta"`
+}
+
+var resourceTypes = []*resourceType{
+	{
+		Schemas:     []string{schemaResourceType},
+		ID:          resourceTypeUser,
+		Name:        resourceTypeUser,
+		Endpoint:    "/Users",
+		Description: "Human users of the organization",
+		Schema:      schemaUser,
+	},
+	{
+		Schemas:     []string{schemaResourceType},
+		ID:          resourceTypeGroup,
+		Name:        resourceTypeGroup,
+		Endpoint:    "/Groups",
+		Description: "Groups of the organization, whose members receive the roles of the group grants",
+		Schema:      schemaGroup,
+	},
+}
+
+type serviceProviderConfig struct {
+	Schemas               []string                `json:"schemas"`
+	DocumentationURI      string                  `json:"documentationUri"`
+	Patch                 supported               `json:"patch"`
+	Bulk                  bulkSupported           `json:"bulk"`
+	Filter                filterSupported         `json:"filter"`
+	ChangePassword        supported               `json:"changePassword"`
+	Sort                  supported               `json:"sort"`
+	ETag                  supported               `json:"etag"`
+	AuthenticationSchemes []*authenticationScheme `json:"authenticationSchemes"`
+	Meta                  *meta                   `json:"meta"`
+}
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type bulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type filterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type authenticationScheme struct {
+	Type             string `json:"type"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	SpecURI          string `json:"specUri"`
+	DocumentationURI string `json:"documentationUri"`
+	Primary          bool   `json:"primary"`
+}
+
+func (h *Handler) getServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, &serviceProviderConfig{
+		Schemas:          []string{schemaServiceProviderConfig},
+		DocumentationURI: documentationURI,
+		Patch:            supported{Supported: true},
+		Bulk: bulkSupported{
+			Supported:      true,
+			MaxOperations:  maxBulkOperations,
+			MaxPayloadSize: maxPayloadSize,
+		},
+		Filter: filterSupported{
+			Supported:  true,
+			MaxResults: maxResults,
+		},
+		ChangePassword: supported{Supported: true},
+		AuthenticationSchemes: []*authenticationScheme{
+			{
+				Type:             "oauthbearertoken",
+				Name:             "OAuth Bearer Token",
+				Description:      "Authentication with an access token or personal access token of a user with the permissions to manage the users and groups of the organization",
+				SpecURI:          "https://www.rfc-editor.org/info/rfc6750",
+				DocumentationURI: documentationURI,
+				Primary:          true,
+			},
+		},
+		Meta: &meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     h.location(r, "ServiceProviderConfig"),
+		},
+	})
+}
+
+func (h *Handler) listSchemas(w http.ResponseWriter, r *http.Request) {
+	resources := make([]interface{}, len(schemas))
+	for i, s := range schemas {
+		resources[i] = h.schemaWithMeta(r, s)
+	}
+	writeResponse(w, http.StatusOK, newListResponse(uint64(len(resources)), 1, resources))
+}
+
+func (h *Handler) getSchema(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)[varID]
+	for _, s := range schemas {
+		if strings.EqualFold(s.ID, id) {
+			writeResponse(w, http.StatusOK, h.schemaWithMeta(r, s))
+			return
+		}
+	}
+	writeError(w, r, errNotFound("schema not found"))
+}
+
+func (h *Handler) schemaWithMeta(r *http.Request, s *schema) *schema {
+	withMeta := *s
+	withMeta.Meta = &meta{
+		ResourceType: "Schema",
+		Location:     h.location(r, "Schemas", s.ID),
+	}
+	return &withMeta
+}
+
+func (h *Handler) listResourceTypes(w http.ResponseWriter, r *http.Request) {
+	resources := make([]interface{}, len(resourceTypes))
+	for i, t := range resourceTypes {
+		resources[i] = h.resourceTypeWithMeta(r, t)
+	}
+	writeResponse(w, http.StatusOK, newListResponse(uint64(len(resources)), 1, resources))
+}
+
+func (h *Handler) getResourceType(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)[varID]
+	for _, t := range resourceTypes {
+		if strings.EqualFold(t.ID, id) {
+			writeResponse(w, http.StatusOK, h.resourceTypeWithMeta(r, t))
+			return
+		}
+	}
+	writeError(w, r, errNotFound("resource type not found"))
+}
+
+func (h *Handler) resourceTypeWithMeta(r *http.Request, t *resourceType) *resourceType {
+	withMeta := *t
+	withMeta.Meta = &meta{
+		ResourceType: "ResourceType",
+		Location:     h.location(r, "ResourceTypes", t.ID),
+	}
+	return &withMeta
+}
+
+// findAttribute returns the attribute by its case-insensitive name
+func findAttribute(attributes []*schemaAttribute, name string) *schemaAttribute {
+	for _, attribute := range attributes {
+		if strings.EqualFold(attribute.Name, name) {
+			return attribute
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	HandlerPrefix = "/scim/v2"
+
+	contentType      = "application/scim+json"
+	documentationURI = "https://zitadel.com/docs/guides/manage/user/scim"
+
+	// maxResults is the maximum (and default) number of resources returned by a query
+	maxResults        = 100
+	maxBulkOperations = 1000
+	maxPayloadSize    = 1 << 20
+
+	varOrgID = "orgId"
+	varID    = "id"
+
+	permissionUserRead    = "user.read"
+	permissionUserWrite   = "user.write"
+	permissionUserDelete  = "user.delete"
+	permissionGroupRead   = "group.read"
+	permissionGroupWrite  = "group.write"
+	permissionGroupDelete = "group.delete"
+)
+
+type Handler struct {
+	commands       *command.Commands
+	query          *query.Queries
+	verifier       *authz.TokenVerifier
+	authConfig     authz.Config
+	externalSecure bool
+
+	// bulkRouter serves the operations of bulk requests, which are already authenticated
+	bulkRouter *mux.Router
+}
+
+// NewHandler returns the SCIM 2.0 (RFC 7643, RFC 7644) service provider for the users and groups of each organization
+// served under /scim/v2/{orgId}.
+// Clients authenticate with (personal) access tokens of (machine) users with the user and group permissions on the organization.
+func NewHandler(commands *command.Commands, queries *query.Queries, verifier *authz.TokenVerifier, authConfig authz.Config, externalSecure bool, callDurationInterceptor, instanceInterceptor, accessInterceptor func(handler http.Handler) http.Handler) http.Handler {
+	h := &Handler{
+		commands:       commands,
+		query:          queries,
+		verifier:       verifier,
+		authConfig:     authConfig,
+		externalSecure: externalSecure,
+		bulkRouter:     mux.NewRouter(),
+	}
+	router := mux.NewRouter()
+	router.Use(callDurationInterceptor, instanceInterceptor, accessInterceptor)
+	orgRouter := router.PathPrefix("/{" + varOrgID + "}").Subrouter()
+	orgRouter.Handle("/ServiceProviderConfig", h.authorize("", h.getServiceProviderConfig)).Methods(http.MethodGet)
+	orgRouter.Handle("/Schemas", h.authorize("", h.listSchemas)).Methods(http.MethodGet)
+	orgRouter.Handle("/Schemas/{"+varID+"}", h.authorize("", h.getSchema)).Methods(http.MethodGet)
+	orgRouter.Handle("/ResourceTypes", h.authorize("", h.listResourceTypes)).Methods(http.MethodGet)
+	orgRouter.Handle("/ResourceTypes/{"+varID+"}", h.authorize("", h.getResourceType)).Methods(http.MethodGet)
+	orgRouter.Handle("/Bulk", h.authorize(permissionUserWrite, h.bulk)).Methods(http.MethodPost)
+	h.registerUserRoutes(orgRouter, h.authorize)
+	h.registerGroupRoutes(orgRouter, h.authorize)
+	bulkOrgRouter := h.bulkRouter.PathPrefix("/{" + varOrgID + "}").Subrouter()
+	h.registerUserRoutes(bulkOrgRouter, requirePermission)
+	h.registerGroupRoutes(bulkOrgRouter, requirePermission)
+	router.NotFoundHandler = http.HandlerFunc(notFound)
+	h.bulkRouter.NotFoundHandler = http.HandlerFunc(notFound)
+	return http_util.CopyHeadersToContext(http_mw.CORSInterceptor(router))
+}
+
+func (h *Handler) registerUserRoutes(router *mux.Router, authorize func(permission string, handler http.HandlerFunc) http.Handler) {
+	router.Handle("/Users", authorize(permissionUserRead, h.listUsers)).Methods(http.MethodGet)
+	router.Handle("/Users", authorize(permissionUserWrite, h.createUser)).Methods(http.MethodPost)
+	router.Handle("/Users/.search", authorize(permissionUserRead, h.searchUsers)).Methods(http.MethodPost)
+	router.Handle("/Users/{"+varID+"}", authorize(permissionUserRead, h.getUser)).Methods(http.MethodGet)
+	router.Handle("/Users/{"+varID+"}", authorize(permissionUserWrite, h.replaceUser)).Methods(http.MethodPut)
+	router.Handle("/Users/{"+varID+"}", authorize(permissionUserWrite, h.patchUser)).Methods(http.MethodPatch)
+	router.Handle("/Users/{"+varID+"}", authorize(permissionUserDelete, h.deleteUser)).Methods(http.MethodDelete)
+}
+
+func (h *Handler) registerGroupRoutes(router *mux.Router, authorize func(permission string, handler http.HandlerFunc) http.Handler) {
+	router.Handle("/Groups", authorize(permissionGroupRead, h.listGroups)).Methods(http.MethodGet)
+	router.Handle("/Groups", authorize(permissionGroupWrite, h.createGroup)).Methods(http.MethodPost)
+	router.Handle("/Groups/.search", authorize(permissionGroupRead, h.searchGroups)).Methods(http.MethodPost)
+	router.Handle("/Groups/{"+varID+"}", authorize(permissionGroupRead, h.getGroup)).Methods(http.MethodGet)
+	router.Handle("/Groups/{"+varID+"}", authorize(permissionGroupWrite, h.replaceGroup)).Methods(http.MethodPut)
+	router.Handle("/Groups/{"+varID+"}", authorize(permissionGroupWrite, h.patchGroup)).Methods(http.MethodPatch)
+	router.Handle("/Groups/{"+varID+"}", authorize(permissionGroupDelete, h.deleteGroup)).Methods(http.MethodDelete)
+}
+
+// authorize verifies the access token of the request and checks the permission on the organization of the path.
+// The discovery endpoints only require an authenticated user.
+func (h *Handler) authorize(permission string, handler http.HandlerFunc) http.Handler {
+	if permission == "" {
+		permission = "authenticated"
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := http_util.GetAuthorization(r)
+		if token == "" {
+			writeError(w, r, newSCIMError(http.StatusUnauthorized, "", "auth header missing"))
+			return
+		}
+		requestPath, _, _ := strings.Cut(r.RequestURI, "?")
+		ctx := authz.WithDPoPProof(r.Context(), &authz.DPoPProof{
+			Proof:  r.Header.Get(http_util.DPoP),
+			Method: r.Method,
+			Path:   requestPath,
+		})
+		ctxSetter, err := authz.CheckUserAuthorization(ctx, nil, token, mux.Vars(r)[varOrgID], "", h.verifier, h.authConfig, authz.Option{Permission: permission}, requestPath)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		handler(w, r.WithContext(ctxSetter(r.Context())))
+	})
+}
+
+// requirePermission checks the permission of the already authorized user of a bulk request
+func requirePermission(permission string, handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, userPermission := range authz.GetAllPermissionsFromCtx(r.Context()) {
+			if userPermission == permission {
+				handler(w, r)
+				return
+			}
+		}
+		writeError(w, r, newSCIMError(http.StatusForbidden, "", "No matching permissions found"))
+	})
+}
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, errNotFound("endpoint not found"))
+}
+
+// location returns the absolute URL of the path in the organization of the request
+func (h *Handler) location(r *http.Request, path ...string) string {
+	return http_util.BuildOrigin(authz.GetInstance(r.Context()).RequestedHost(), h.externalSecure) +
+		HandlerPrefix + "/" + authz.GetCtxData(r.Context()).OrgID + "/" + strings.Join(path, "/")
+}
+
+type listResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults uint64        `json:"totalResults"`
+	StartIndex   uint64        `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+func newListResponse(totalResults, startIndex uint64, resources []interface{}) *listResponse {
+	return &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+func writeResponse(w http.ResponseWriter, status int, resource interface{}) {
+	w.Header().Set(http_util.ContentType, contentType)
+	w.WriteHeader(status)
+	if resource == nil {
+		return
+	}
+	err := json.NewEncoder(w).Encode(resource)
+	logging.OnError(err).Warn("unable to write scim response")
+}
+
+// readRequest decodes the JSON body of the request, which is limited to the max payload size
+func readRequest(w http.ResponseWriter, r *http.Request, request interface{}) error {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPayloadSize)).Decode(request); err != nil {
+		return errInvalidSyntax("unable to parse request: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package scim
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// searchRequest is the query of resources (RFC 7644, section 3.4.3),
+// sent as parameters of a GET request or as body of a POST request to .search
+type searchRequest struct {
+	Schemas    []string `json:"schemas"`
+	Filter     string   `json:"filter"`
+	StartIndex int64    `json:"startIndex"`
+	Count      *int64   `json:"count"`
+}
+
+// searchRequestFromQuery reads the search request from the parameters of a GET request
+func searchRequestFromQuery(r *http.Request) (*searchRequest, error) {
+	request := &searchRequest{
+		Filter: r.URL.Query().Get("filter"),
+	}
+	if startIndex := r.URL.Query().Get("startIndex"); startIndex != "" {
+		index, err := strconv.ParseInt(startIndex, 10, 64)
+		if err != nil {
+			return nil, errInvalidValue("invalid startIndex")
+		}
+		request.StartIndex = index
+	}
+	if count := r.URL.Query().Get("count"); count != "" {
+		c, err := strconv.ParseInt(count, 10, 64)
+		if err != nil {
+			return nil, errInvalidValue("invalid count")
+		}
+		request.Count = &c
+	}
+	return request, nil
+}
+
+// readSearchRequest reads the search request from the body of a POST request to .search
+func readSearchRequest(w http.ResponseWriter, r *http.Request) (*searchRequest, error) {
+	request := new(searchRequest)
+	if err := readRequest(w, r, request); err != nil {
+		return nil, err
+	}
+	if !containsSchema(request.Schemas, schemaSearchRequest) {
+		return nil, errInvalidValue("schema " + schemaSearchRequest + " is required")
+	}
+	return request, nil
+}
+
+// page returns the 1-based start index and the number of requested resources,
+// a startIndex less than 1 and a negative count are interpreted as 1 and 0.
+// The limit of the query is at least 1, as the total results are also requested with a count of 0.
+func (s *searchRequest) page() (startIndex, count, limit uint64) {
+	startIndex = 1
+	if s.StartIndex > 1 {
+		startIndex = uint64(s.StartIndex)
+	}
+	count = maxResults
+	if s.Count != nil && *s.Count < maxResults {
+		count = 0
+		if *s.Count > 0 {
+			count = uint64(*s.Count)
+		}
+	}
+	limit = count
+	if limit == 0 {
+		limit = 1
+	}
+	return startIndex, count, limit
+}
+
+// attributeToQuery compiles an attribute expression of a filter into a query of the resources
+type attributeToQuery func(attribute, operator string, value interface{}) (query.SearchQuery, error)
+
+// filterToQuery compiles the filter into a query of the resources,
+// attributes are prefixed with the attribute of an enclosing value path expression
+func filterToQuery(f filter, prefix string, toQuery attributeToQuery) (query.SearchQuery, error) {
+	switch e := f.(type) {
+	case *logicalExpression:
+		left, err := filterToQuery(e.left, prefix, toQuery)
+		if err != nil {
+			return nil, err
+		}
+		right, err := filterToQuery(e.right, prefix, toQuery)
+		if err != nil {
+			return nil, err
+		}
+		if e.operator == operatorAnd {
+			return query.And(left, right), nil
+		}
+		return query.Or(left, right), nil
+	case *notExpression:
+		q, err := filterToQuery(e.filter, prefix, toQuery)
+		if err != nil {
+			return nil, err
+		}
+		return query.Not(q), nil
+	case *valuePathExpression:
+		return filterToQuery(e.filter, prefix+e.attribute+".", toQuery)
+	case *attributeExpression:
+		return toQuery(prefix+e.attribute, e.operator, e.value)
+	}
+	return nil, errInvalidFilter("unsupported filter")
+}
+
+// filterExpressionToQuery parses the filter expression and compiles it into a query of the resources
+func filterExpressionToQuery(expression string, toQuery attributeToQuery) (query.SearchQuery, error) {
+	f, err := parseFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	return filterToQuery(f, "", toQuery)
+}
+
+// textComparisons are the case-insensitive comparisons of the operators,
+// not equal is queried as negated equality
+var textComparisons = map[string]query.TextComparison{
+	operatorEqual:      query.TextEqualsIgnoreCase,
+	operatorNotEqual:   query.TextEqualsIgnoreCase,
+	operatorContains:   query.TextContainsIgnoreCase,
+	operatorStartsWith: query.TextStartsWithIgnoreCase,
+	operatorEndsWith:   query.TextEndsWithIgnoreCase,
+}
+
+// idToQuery returns the query of the resource id, which only supports equality
+func idToQuery(operator string, value interface{}, idQuery func(ids []string) (query.SearchQuery, error)) (query.SearchQuery, error) {
+	id, ok := value.(string)
+	if !ok || (operator != operatorEqual && operator != operatorNotEqual) {
+		return nil, errInvalidFilter("id only supports eq and ne with a string")
+	}
+	q, err := idQuery([]string{id})
+	if err != nil {
+		return nil, err
+	}
+	return negate(q, operator), nil
+}
+
+// textToQuery returns the case-insensitive query of the text attribute
+func textToQuery(attribute, operator string, value interface{}, textQuery func(string, query.TextComparison) (query.SearchQuery, error)) (query.SearchQuery, error) {
+	comparison, ok := textComparisons[operator]
+	text, isText := value.(string)
+	if !ok || !isText {
+		return nil, errInvalidFilter(attribute + " only supports eq, ne, co, sw and ew with a string")
+	}
+	q, err := textQuery(text, comparison)
+	if err != nil {
+		return nil, err
+	}
+	return negate(q, operator), nil
+}
+
+func negate(q query.SearchQuery, operator string) query.SearchQuery {
+	if operator == operatorNotEqual {
+		return query.Not(q)
+	}
+	return q
+}
@@ -0,0 +1,437 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// externalIDMetadataKey is the key of the user metadata the externalId of the provisioning client is stored in
+	externalIDMetadataKey = "scim.externalId"
+
+	emailTypeWork   = "work"
+	phoneTypeMobile = "mobile"
+)
+
+// user is the User resource of the core schema (RFC 7643, section 4.1)
+type user struct {
+	Schemas           []string      `json:"schemas"`
+	ID                string        `json:"id,omitempty"`
+	ExternalID        string        `json:"externalId,omitempty"`
+	Meta              *meta         `json:"meta,omitempty"`
+	UserName          string        `json:"userName"`
+	Name              *name         `json:"name,omitempty"`
+	DisplayName       string        `json:"displayName,omitempty"`
+	NickName          string        `json:"nickName,omitempty"`
+	PreferredLanguage string        `json:"preferredLanguage,omitempty"`
+	Active            *boolean      `json:"active,omitempty"`
+	Password          string        `json:"password,omitempty"`
+	Emails            []*multiValue `json:"emails,omitempty"`
+	PhoneNumbers      []*multiValue `json:"phoneNumbers,omitempty"`
+}
+
+type name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+type multiValue struct {
+	Value   string  `json:"value"`
+	Type    string  `json:"type,omitempty"`
+	Primary boolean `json:"primary,omitempty"`
+}
+
+// boolean also accepts the string representations of booleans some clients send, e.g. "False"
+type boolean bool
+
+func (b *boolean) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case bool:
+		*b = boolean(v)
+	case string:
+		parsed, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return err
+		}
+		*b = boolean(parsed)
+	case nil:
+		*b = false
+	default:
+		return errInvalidValue("invalid boolean " + string(data))
+	}
+	return nil
+}
+
+func userToResource(u *query.User, externalID, location string) *user {
+	active := boolean(u.State != domain.UserStateInactive)
+	resource := &user{
+		Schemas:    []string{schemaUser},
+		ID:         u.ID,
+		ExternalID: externalID,
+		Meta: &meta{
+			ResourceType: resourceTypeUser,
+			Created:      u.CreationDate.UTC().Format(time.RFC3339),
+			LastModified: u.ChangeDate.UTC().Format(time.RFC3339),
+			Location:     location,
+			Version:      `W/"` + strconv.FormatUint(u.Sequence, 10) + `"`,
+		},
+		UserName: u.Username,
+		Active:   &active,
+	}
+	if u.Human == nil {
+		return resource
+	}
+	resource.Name = &name{
+		Formatted:  strings.TrimSpace(u.Human.FirstName + " " + u.Human.LastName),
+		FamilyName: u.Human.LastName,
+		GivenName:  u.Human.FirstName,
+	}
+	resource.DisplayName = u.Human.DisplayName
+	resource.NickName = u.Human.NickName
+	if !u.Human.PreferredLanguage.IsRoot() {
+		resource.PreferredLanguage = u.Human.PreferredLanguage.String()
+	}
+	if u.Human.Email != "" {
+		resource.Emails = []*multiValue{{Value: string(u.Human.Email), Type: emailTypeWork, Primary: true}}
+	}
+	if u.Human.Phone != "" {
+		resource.PhoneNumbers = []*multiValue{{Value: string(u.Human.Phone), Type: phoneTypeMobile, Primary: true}}
+	}
+	return resource
+}
+
+// primary returns the primary value or the first value if none is marked as primary,
+// as ZITADEL only stores a single email address and phone number
+func primary(values []*multiValue) string {
+	for _, value := range values {
+		if value.Primary {
+			return strings.TrimSpace(value.Value)
+		}
+	}
+	for _, value := range values {
+		if value.Value != "" {
+			return strings.TrimSpace(value.Value)
+		}
+	}
+	return ""
+}
+
+func (u *user) givenName() string {
+	if u.Name == nil {
+		return ""
+	}
+	return strings.TrimSpace(u.Name.GivenName)
+}
+
+func (u *user) familyName() string {
+	if u.Name == nil {
+		return ""
+	}
+	return strings.TrimSpace(u.Name.FamilyName)
+}
+
+func (u *user) displayName() string {
+	if displayName := strings.TrimSpace(u.DisplayName); displayName != "" {
+		return displayName
+	}
+	return strings.TrimSpace(u.givenName() + " " + u.familyName())
+}
+
+func (u *user) preferredLanguage() language.Tag {
+	if u.PreferredLanguage == "" {
+		return language.Und
+	}
+	return language.Make(u.PreferredLanguage)
+}
+
+func (u *user) validate() error {
+	if !containsSchema(u.Schemas, schemaUser) {
+		return errInvalidValue("schema " + schemaUser + " is required")
+	}
+	if strings.TrimSpace(u.UserName) == "" {
+		return errInvalidValue("userName is required")
+	}
+	return nil
+}
+
+// toAddHuman maps the user to a human, whose email and phone are verified,
+// because the provisioning client is trusted to manage them
+func (u *user) toAddHuman() *command.AddHuman {
+	human := &command.AddHuman{
+		Username:          strings.TrimSpace(u.UserName),
+		FirstName:         u.givenName(),
+		LastName:          u.familyName(),
+		NickName:          strings.TrimSpace(u.NickName),
+		DisplayName:       u.displayName(),
+		PreferredLanguage: u.preferredLanguage(),
+		Email: command.Email{
+			Address:  domain.EmailAddress(primary(u.Emails)),
+			Verified: true,
+		},
+		Password: u.Password,
+	}
+	if phone := primary(u.PhoneNumbers); phone != "" {
+		human.Phone = command.Phone{
+			Number:   domain.PhoneNumber(phone),
+			Verified: true,
+		}
+	}
+	if u.ExternalID != "" {
+		human.Metadata = []*command.AddMetadataEntry{{Key: externalIDMetadataKey, Value: []byte(u.ExternalID)}}
+	}
+	human.Inactive = u.Active != nil && !bool(*u.Active)
+	return human
+}
+
+// toChangeHuman maps the user to the changes of the existing human,
+// the externalId is only changed if it differs from the current one
+func (u *user) toChangeHuman(existing *query.User, externalID string) *command.ChangeHuman {
+	userName := strings.TrimSpace(u.UserName)
+	email := domain.EmailAddress(primary(u.Emails))
+	phone := domain.PhoneNumber(primary(u.PhoneNumbers))
+	human := &command.ChangeHuman{
+		ID:       existing.ID,
+		Username: &userName,
+		Profile: &domain.Profile{
+			FirstName:         u.givenName(),
+			LastName:          u.familyName(),
+			NickName:          strings.TrimSpace(u.NickName),
+			DisplayName:       u.displayName(),
+			PreferredLanguage: u.preferredLanguage(),
+			Gender:            existing.Human.Gender,
+		},
+		Email: &email,
+		Phone: &phone,
+	}
+	if u.Password != "" {
+		human.Password = &u.Password
+	}
+	if u.Active != nil {
+		active := bool(*u.Active)
+		human.Active = &active
+	}
+	if u.ExternalID != externalID {
+		human.Metadata = []*domain.Metadata{{Key: externalIDMetadataKey, Value: []byte(u.ExternalID)}}
+	}
+	return human
+}
+
+func containsSchema(schemas []string, schema string) bool {
+	for _, s := range schemas {
+		if strings.EqualFold(s, schema) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
+	h.writeUser(w, r, http.StatusOK, mux.Vars(r)[varID])
+}
+
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resource := new(user)
+	if err := readRequest(w, r, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if err := resource.validate(); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	human := resource.toAddHuman()
+	if err := h.commands.AddHuman(ctx, authz.GetCtxData(ctx).OrgID, human, false); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.writeUser(w, r, http.StatusCreated, human.ID)
+}
+
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	existing, externalID, err := h.userByID(ctx, mux.Vars(r)[varID], false)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resource := new(user)
+	if err = readRequest(w, r, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if err = h.updateUser(ctx, existing, externalID, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.writeUser(w, r, http.StatusOK, existing.ID)
+}
+
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	existing, externalID, err := h.userByID(ctx, mux.Vars(r)[varID], false)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	request := new(patchRequest)
+	if err = readRequest(w, r, request); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resource := new(user)
+	if err = request.apply(userToResource(existing, externalID, ""), patchUserAttributes, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if err = h.updateUser(ctx, existing, externalID, resource); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.writeUser(w, r, http.StatusOK, existing.ID)
+}
+
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	existing, _, err := h.userByID(ctx, mux.Vars(r)[varID], false)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	memberships, grants, err := h.removeUserDependencies(ctx, existing.ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if _, err = h.commands.RemoveUser(ctx, existing.ID, existing.ResourceOwner, memberships, grants...); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, http.StatusNoContent, nil)
+}
+
+// updateUser changes the attributes of the existing user which differ from the resource at once
+func (h *Handler) updateUser(ctx context.Context, existing *query.User, externalID string, resource *user) error {
+	if err := resource.validate(); err != nil {
+		return err
+	}
+	return h.commands.ChangeHuman(ctx, existing.ResourceOwner, resource.toChangeHuman(existing, externalID))
+}
+
+// userByID returns the human user of the organization of the request together with its externalId
+func (h *Handler) userByID(ctx context.Context, userID string, shouldTriggerBulk bool) (*query.User, string, error) {
+	resourceOwnerQuery, err := query.NewUserResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID, query.TextEquals)
+	if err != nil {
+		return nil, "", err
+	}
+	typeQuery, err := query.NewUserTypeSearchQuery(int32(domain.UserTypeHuman))
+	if err != nil {
+		return nil, "", err
+	}
+	existing, err := h.query.GetUserByID(ctx, shouldTriggerBulk, userID, false, resourceOwnerQuery, typeQuery)
+	if err != nil {
+		return nil, "", err
+	}
+	externalID, err := h.externalID(ctx, userID, shouldTriggerBulk)
+	if err != nil {
+		return nil, "", err
+	}
+	return existing, externalID, nil
+}
+
+func (h *Handler) externalID(ctx context.Context, userID string, shouldTriggerBulk bool) (string, error) {
+	metadata, err := h.query.GetUserMetadataByKey(ctx, shouldTriggerBulk, userID, externalIDMetadataKey, false)
+	if caos_errs.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(metadata.Value), nil
+}
+
+// writeUser writes the current state of the user, the projections are triggered to include the changes of the request
+func (h *Handler) writeUser(w http.ResponseWriter, r *http.Request, status int, userID string) {
+	u, externalID, err := h.userByID(r.Context(), userID, true)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	location := h.location(r, "Users", u.ID)
+	resource := userToResource(u, externalID, location)
+	w.Header().Set("Location", location)
+	writeResponse(w, status, resource)
+}
+
+func (h *Handler) removeUserDependencies(ctx context.Context, userID string) ([]*command.CascadingMembership, []string, error) {
+	userGrantUserQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grants, err := h.query.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{userGrantUserQuery},
+	}, true, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	membershipsUserQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	memberships, err := h.query.Memberships(ctx, &query.MembershipSearchQuery{
+		Queries: []query.SearchQuery{membershipsUserQuery},
+	}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cascadingMemberships(memberships.Memberships), userGrantsToIDs(grants.UserGrants), nil
+}
+
+func cascadingMemberships(memberships []*query.Membership) []*command.CascadingMembership {
+	cascades := make([]*command.CascadingMembership, len(memberships))
+	for i, membership := range memberships {
+		cascades[i] = &command.CascadingMembership{
+			UserID:        membership.UserID,
+			ResourceOwner: membership.ResourceOwner,
+		}
+		if membership.IAM != nil {
+			cascades[i].IAM = &command.CascadingIAMMembership{IAMID: membership.IAM.IAMID}
+		}
+		if membership.Org != nil {
+			cascades[i].Org = &command.CascadingOrgMembership{OrgID: membership.Org.OrgID}
+		}
+		if membership.Project != nil {
+			cascades[i].Project = &command.CascadingProjectMembership{ProjectID: membership.Project.ProjectID}
+		}
+		if membership.ProjectGrant != nil {
+			cascades[i].ProjectGrant = &command.CascadingProjectGrantMembership{ProjectID: membership.ProjectGrant.ProjectID, GrantID: membership.ProjectGrant.GrantID}
+		}
+	}
+	return cascades
+}
+
+func userGrantsToIDs(userGrants []*query.UserGrant) []string {
+	converted := make([]string, len(userGrants))
+	for i, grant := range userGrants {
+		converted[i] = grant.ID
+	}
+	return converted
+}
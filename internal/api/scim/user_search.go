@@ -0,0 +1,119 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) {
+	request, err := searchRequestFromQuery(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.search(w, r, request)
+}
+
+func (h *Handler) searchUsers(w http.ResponseWriter, r *http.Request) {
+	request, err := readSearchRequest(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	h.search(w, r, request)
+}
+
+func (h *Handler) search(w http.ResponseWriter, r *http.Request, request *searchRequest) {
+	ctx := r.Context()
+	queries, err := userSearchQueries(authz.GetCtxData(ctx).OrgID, request.Filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	startIndex, count, limit := request.page()
+	users, err := h.query.SearchUsers(ctx, &query.UserSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        startIndex - 1,
+			Limit:         limit,
+			SortingColumn: query.UserUsernameCol,
+			Asc:           true,
+		},
+		Queries: queries,
+	}, false)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resources := make([]interface{}, 0, count)
+	for i := 0; uint64(i) < count && i < len(users.Users); i++ {
+		externalID, err := h.externalID(ctx, users.Users[i].ID, false)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		resources = append(resources, userToResource(users.Users[i], externalID, h.location(r, "Users", users.Users[i].ID)))
+	}
+	writeResponse(w, http.StatusOK, newListResponse(users.Count, startIndex, resources))
+}
+
+// userSearchQueries returns the queries of the human users of the organization matching the filter
+func userSearchQueries(orgID, filterExpression string) ([]query.SearchQuery, error) {
+	resourceOwnerQuery, err := query.NewUserResourceOwnerSearchQuery(orgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	typeQuery, err := query.NewUserTypeSearchQuery(int32(domain.UserTypeHuman))
+	if err != nil {
+		return nil, err
+	}
+	queries := []query.SearchQuery{resourceOwnerQuery, typeQuery}
+	if filterExpression == "" {
+		return queries, nil
+	}
+	filterQuery, err := filterExpressionToQuery(filterExpression, userAttributeToQuery)
+	if err != nil {
+		return nil, err
+	}
+	return append(queries, filterQuery), nil
+}
+
+var userTextQueries = map[string]func(string, query.TextComparison) (query.SearchQuery, error){
+	"username":           query.NewUserUsernameSearchQuery,
+	"name.givenname":     query.NewUserFirstNameSearchQuery,
+	"name.familyname":    query.NewUserLastNameSearchQuery,
+	"displayname":        query.NewUserDisplayNameSearchQuery,
+	"nickname":           query.NewUserNickNameSearchQuery,
+	"emails":             query.NewUserEmailSearchQuery,
+	"emails.value":       query.NewUserEmailSearchQuery,
+	"phonenumbers":       query.NewUserPhoneSearchQuery,
+	"phonenumbers.value": query.NewUserPhoneSearchQuery,
+}
+
+func userAttributeToQuery(attribute, operator string, value interface{}) (query.SearchQuery, error) {
+	switch attribute {
+	case "id":
+		return idToQuery(operator, value, query.NewUserInUserIdsSearchQuery)
+	case "active":
+		active, ok := value.(bool)
+		if !ok || (operator != operatorEqual && operator != operatorNotEqual) {
+			return nil, errInvalidFilter("active only supports eq and ne with a boolean")
+		}
+		q, err := query.NewUserStateSearchQuery(int32(domain.UserStateInactive))
+		if err != nil {
+			return nil, err
+		}
+		// active users are all users which are not inactive
+		if active == (operator == operatorEqual) {
+			return query.Not(q), nil
+		}
+		return q, nil
+	}
+	textQuery, ok := userTextQueries[attribute]
+	if !ok {
+		return nil, errInvalidFilter("filtering by " + attribute + " is not supported")
+	}
+	return textToQuery(attribute, operator, value, textQuery)
+}
@@ -0,0 +1,109 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func mustQuery(t *testing.T) func(query.SearchQuery, error) query.SearchQuery {
+	return func(q query.SearchQuery, err error) query.SearchQuery {
+		require.NoError(t, err)
+		return q
+	}
+}
+
+func Test_userSearchQueries(t *testing.T) {
+	must := mustQuery(t)
+	defaultQueries := []query.SearchQuery{
+		must(query.NewUserResourceOwnerSearchQuery("org1", query.TextEquals)),
+		must(query.NewUserTypeSearchQuery(int32(domain.UserTypeHuman))),
+	}
+	tests := []struct {
+		name    string
+		filter  string
+		want    query.SearchQuery
+		wantErr bool
+	}{
+		{
+			name: "no filter",
+		},
+		{
+			name:   "user name",
+			filter: `userName eq "bjensen"`,
+			want:   must(query.NewUserUsernameSearchQuery("bjensen", query.TextEqualsIgnoreCase)),
+		},
+		{
+			name:   "not equal id",
+			filter: `id ne "user1"`,
+			want:   query.Not(must(query.NewUserInUserIdsSearchQuery([]string{"user1"}))),
+		},
+		{
+			name:   "active",
+			filter: `active eq true`,
+			want:   query.Not(must(query.NewUserStateSearchQuery(int32(domain.UserStateInactive)))),
+		},
+		{
+			name:   "inactive",
+			filter: `active ne true`,
+			want:   must(query.NewUserStateSearchQuery(int32(domain.UserStateInactive))),
+		},
+		{
+			name:   "logical expressions",
+			filter: `name.givenName sw "bar" and (emails[value ew "@example.com"] or phoneNumbers co "4179")`,
+			want: query.And(
+				must(query.NewUserFirstNameSearchQuery("bar", query.TextStartsWithIgnoreCase)),
+				query.Or(
+					must(query.NewUserEmailSearchQuery("@example.com", query.TextEndsWithIgnoreCase)),
+					must(query.NewUserPhoneSearchQuery("4179", query.TextContainsIgnoreCase)),
+				),
+			),
+		},
+		{
+			name:   "not",
+			filter: `not (displayName eq "Babs")`,
+			want:   query.Not(must(query.NewUserDisplayNameSearchQuery("Babs", query.TextEqualsIgnoreCase))),
+		},
+		{
+			name:    "unsupported attribute",
+			filter:  `title eq "Tour Guide"`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported operator",
+			filter:  `userName gt "a"`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported value",
+			filter:  `active eq "true"`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid filter",
+			filter:  `userName eq`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := userSearchQueries("org1", tt.filter)
+			if tt.wantErr {
+				scimErr := new(scimError)
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, scimTypeInvalidFilter, scimErr.ScimType)
+				return
+			}
+			require.NoError(t, err)
+			want := defaultQueries
+			if tt.want != nil {
+				want = append(want, tt.want)
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_userToResource(t *testing.T) {
+	inactive := boolean(false)
+	got := userToResource(&query.User{
+		ID:           "user1",
+		CreationDate: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		ChangeDate:   time.Date(2023, 2, 3, 4, 5, 6, 0, time.UTC),
+		Sequence:     42,
+		State:        domain.UserStateInactive,
+		Username:     "bjensen",
+		Human: &query.Human{
+			FirstName:         "Barbara",
+			LastName:          "Jensen",
+			DisplayName:       "Babs",
+			PreferredLanguage: language.German,
+			Email:             "bjensen@example.com",
+			IsEmailVerified:   true,
+		},
+	}, "external1", "https://zitadel.cloud/scim/v2/org1/Users/user1")
+	assert.Equal(t, &user{
+		Schemas:    []string{schemaUser},
+		ID:         "user1",
+		ExternalID: "external1",
+		Meta: &meta{
+			ResourceType: resourceTypeUser,
+			Created:      "2023-01-02T03:04:05Z",
+			LastModified: "2023-02-03T04:05:06Z",
+			Location:     "https://zitadel.cloud/scim/v2/org1/Users/user1",
+			Version:      `W/"42"`,
+		},
+		UserName: "bjensen",
+		Name: &name{
+			Formatted:  "Barbara Jensen",
+			FamilyName: "Jensen",
+			GivenName:  "Barbara",
+		},
+		DisplayName:       "Babs",
+		PreferredLanguage: "de",
+		Active:            &inactive,
+		Emails:            []*multiValue{{Value: "bjensen@example.com", Type: emailTypeWork, Primary: true}},
+	}, got)
+}
+
+func Test_user_toAddHuman(t *testing.T) {
+	resource := new(user)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"externalId": "external1",
+		"userName": "bjensen",
+		"name": {"familyName": "Jensen", "givenName": "Barbara"},
+		"preferredLanguage": "en-US",
+		"active": "True",
+		"password": "Password1!",
+		"emails": [
+			{"value": "babs@jensen.org", "type": "home"},
+			{"value": "bjensen@example.com", "type": "work", "primary": true}
+		],
+		"phoneNumbers": [{"value": "+41 79 123 45 67", "type": "mobile"}]
+	}`), resource))
+	require.NoError(t, resource.validate())
+	assert.True(t, bool(*resource.Active))
+	assert.Equal(t, &command.AddHuman{
+		Username:          "bjensen",
+		FirstName:         "Barbara",
+		LastName:          "Jensen",
+		DisplayName:       "Barbara Jensen",
+		PreferredLanguage: language.AmericanEnglish,
+		Email:             command.Email{Address: "bjensen@example.com", Verified: true},
+		Phone:             command.Phone{Number: "+41 79 123 45 67", Verified: true},
+		Password:          "Password1!",
+		Metadata:          []*command.AddMetadataEntry{{Key: externalIDMetadataKey, Value: []byte("external1")}},
+	}, resource.toAddHuman())
+}
+
+func Test_user_toAddHuman_inactive(t *testing.T) {
+	inactive := boolean(false)
+	resource := &user{Schemas: []string{schemaUser}, UserName: "bjensen", Active: &inactive}
+	assert.True(t, resource.toAddHuman().Inactive)
+}
+
+func Test_user_toChangeHuman(t *testing.T) {
+	inactive := boolean(false)
+	existing := &query.User{
+		ID:       "user1",
+		Username: "bjensen",
+		Human: &query.Human{
+			FirstName: "Barbara",
+			LastName:  "Jensen",
+			Gender:    domain.GenderFemale,
+		},
+	}
+	tests := []struct {
+		name       string
+		resource   *user
+		externalID string
+		want       *command.ChangeHuman
+	}{
+		{
+			name: "all attributes",
+			resource: &user{
+				UserName:     " bjensen2 ",
+				Name:         &name{GivenName: "Babs", FamilyName: "Jensen"},
+				NickName:     "B",
+				Active:       &inactive,
+				Password:     "Password1!",
+				ExternalID:   "external2",
+				Emails:       []*multiValue{{Value: "bjensen@example.com"}},
+				PhoneNumbers: []*multiValue{{Value: "+41791234567"}},
+			},
+			externalID: "external1",
+			want: &command.ChangeHuman{
+				ID:       "user1",
+				Username: gu.Ptr("bjensen2"),
+				Profile: &domain.Profile{
+					FirstName:         "Babs",
+					LastName:          "Jensen",
+					NickName:          "B",
+					DisplayName:       "Babs Jensen",
+					PreferredLanguage: language.Und,
+					Gender:            domain.GenderFemale,
+				},
+				Email:    gu.Ptr(domain.EmailAddress("bjensen@example.com")),
+				Phone:    gu.Ptr(domain.PhoneNumber("+41791234567")),
+				Password: gu.Ptr("Password1!"),
+				Active:   gu.Ptr(false),
+				Metadata: []*domain.Metadata{{Key: externalIDMetadataKey, Value: []byte("external2")}},
+			},
+		},
+		{
+			name: "removed phone and externalId, unchanged state and password",
+			resource: &user{
+				UserName: "bjensen",
+				Name:     &name{GivenName: "Barbara", FamilyName: "Jensen"},
+				Emails:   []*multiValue{{Value: "bjensen@example.com"}},
+			},
+			externalID: "external1",
+			want: &command.ChangeHuman{
+				ID:       "user1",
+				Username: gu.Ptr("bjensen"),
+				Profile: &domain.Profile{
+					FirstName:         "Barbara",
+					LastName:          "Jensen",
+					DisplayName:       "Barbara Jensen",
+					PreferredLanguage: language.Und,
+					Gender:            domain.GenderFemale,
+				},
+				Email:    gu.Ptr(domain.EmailAddress("bjensen@example.com")),
+				Phone:    gu.Ptr(domain.PhoneNumber("")),
+				Metadata: []*domain.Metadata{{Key: externalIDMetadataKey, Value: []byte("")}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.resource.toChangeHuman(existing, tt.externalID))
+		})
+	}
+}
+
+func Test_user_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    *user
+		wantErr bool
+	}{
+		{
+			name:    "missing schema",
+			user:    &user{UserName: "bjensen"},
+			wantErr: true,
+		},
+		{
+			name:    "missing user name",
+			user:    &user{Schemas: []string{schemaUser}, UserName: " "},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			user: &user{Schemas: []string{schemaUser}, UserName: "bjensen"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.user.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
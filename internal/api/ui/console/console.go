@@ -116,15 +116,18 @@ func Start(config Config, externalSecure bool, issuer op.IssuerFromRequest, call
 			http.Error(w, fmt.Sprintf("unable to template instance management url for console: %v", err), http.StatusInternalServerError)
 			return
 		}
-		exhausted := limitingAccessInterceptor.Limit(ctx)
+		exhausted, degraded := limitingAccessInterceptor.Limit(ctx, r.URL.Path)
 		environmentJSON, err := createEnvironmentJSON(url, issuer(r), instance.ConsoleClientID(), customerPortal, instanceMgmtURL, exhausted)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("unable to marshal env for console: %v", err), http.StatusInternalServerError)
 			return
 		}
-		if exhausted {
+		switch {
+		case degraded:
+			// the exhausted cookie would block the paths allowed in degraded mode
+		case exhausted:
 			limitingAccessInterceptor.SetExhaustedCookie(w, r)
-		} else {
+		default:
 			limitingAccessInterceptor.DeleteExhaustedCookie(w)
 		}
 		_, err = w.Write(environmentJSON)
@@ -13,6 +13,11 @@ const (
 	tmplChangePasswordDone = "changepassworddone"
 )
 
+type changePasswordPageData struct {
+	passwordData
+	Expired bool
+}
+
 type changePasswordData struct {
 	OldPassword             string `schema:"change-old-password"`
 	NewPassword             string `schema:"change-new-password"`
@@ -41,9 +46,17 @@ func (l *Login) renderChangePassword(w http.ResponseWriter, r *http.Request, aut
 		errID, errMessage = l.getErrorMessage(r, err)
 	}
 	translator := l.getTranslator(r.Context(), authReq)
-	data := passwordData{
-		baseData:    l.getBaseData(r, authReq, "PasswordChange.Title", "PasswordChange.Description", errID, errMessage),
-		profileData: l.getProfileData(authReq),
+	expired := passwordExpired(authReq)
+	description := "PasswordChange.Description"
+	if expired {
+		description = "PasswordChange.ExpiredDescription"
+	}
+	data := changePasswordPageData{
+		passwordData: passwordData{
+			baseData:    l.getBaseData(r, authReq, "PasswordChange.Title", description, errID, errMessage),
+			profileData: l.getProfileData(authReq),
+		},
+		Expired: expired,
 	}
 	policy := l.getPasswordComplexityPolicy(r, authReq.UserOrgID)
 	if policy != nil {
@@ -70,3 +83,14 @@ func (l *Login) renderChangePasswordDone(w http.ResponseWriter, r *http.Request,
 	data := l.getUserData(r, authReq, "PasswordChange.Title", "PasswordChange.Description", errType, errMessage)
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplChangePasswordDone], data, nil)
 }
+
+// passwordExpired returns true if the password change is required because the password
+// of the user exceeded the maximum age of the password age policy
+func passwordExpired(authReq *domain.AuthRequest) bool {
+	for _, step := range authReq.PossibleSteps {
+		if changeStep, ok := step.(*domain.ChangePasswordStep); ok {
+			return changeStep.Expired
+		}
+	}
+	return false
+}
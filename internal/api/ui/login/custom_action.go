@@ -133,15 +133,24 @@ func (l *Login) runPostExternalAuthenticationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx))...,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypeExternalAuthentication, domain.TriggerTypePostAuthentication, authRequest.UserID))...,
 		)
 		cancel()
 		if err != nil {
 			return nil, false, err
 		}
 	}
+	err = actions.CallFunctionTargets(ctx, l.query, domain.FlowTypeExternalAuthentication, domain.TriggerTypePostAuthentication, &actions.FunctionPayload{
+		ResourceOwner: resourceOwner,
+		UserID:        authRequest.UserID,
+		AuthRequestID: authRequest.ID,
+		Error:         errorString(authenticationError),
+	})
+	if err != nil {
+		return nil, false, err
+	}
 	user.Metadatas = object.MetadataListToDomain(metadataList)
-	return user, userChanged, err
+	return user, userChanged, nil
 }
 
 type authMethod string
@@ -204,14 +213,23 @@ func (l *Login) runPostInternalAuthenticationActions(
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx))...,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypeInternalAuthentication, domain.TriggerTypePostAuthentication, authRequest.UserID))...,
 		)
 		cancel()
 		if err != nil {
 			return nil, err
 		}
 	}
-	return object.MetadataListToDomain(metadataList), err
+	err = actions.CallFunctionTargets(ctx, l.query, domain.FlowTypeInternalAuthentication, domain.TriggerTypePostAuthentication, &actions.FunctionPayload{
+		ResourceOwner: resourceOwner,
+		UserID:        authRequest.UserID,
+		AuthRequestID: authRequest.ID,
+		Error:         errorString(authenticationError),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return object.MetadataListToDomain(metadataList), nil
 }
 
 func (l *Login) runPreCreationActions(
@@ -231,6 +249,57 @@ func (l *Login) runPreCreationActions(
 
 	metadataList := object.MetadataListFromDomain(metadata)
 	apiFields := actions.WithAPIFields(
+		append(humanSetterFields(user),
+			actions.SetFields("metadata", func(c *actions.FieldConfig) interface{} {
+				return metadataList.MetadataListFromDomain(c.Runtime)
+			}),
+			actions.SetFields("v1",
+				actions.SetFields("user",
+					actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
+				),
+			),
+		)...,
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxOpts := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("user", func(c *actions.FieldConfig) interface{} {
+					return object.UserFromHuman(c, user)
+				}),
+				actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
+				actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxOpts,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithTrigger(flowType, domain.TriggerTypePreCreation, ""))...,
+		)
+		cancel()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	err = actions.CallFunctionTargets(ctx, l.query, flowType, domain.TriggerTypePreCreation, &actions.FunctionPayload{
+		ResourceOwner: resourceOwner,
+		AuthRequestID: idOfAuthRequest(authRequest),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, object.MetadataListToDomain(metadataList), nil
+}
+
+// humanSetterFields provides the api functions to change the human user before it is created
+func humanSetterFields(user *domain.Human) []actions.FieldOption {
+	return []actions.FieldOption{
 		actions.SetFields("setFirstName", func(firstName string) {
 			user.FirstName = firstName
 		}),
@@ -276,24 +345,128 @@ func (l *Login) runPreCreationActions(
 			}
 			user.Phone.IsPhoneVerified = verified
 		}),
-		actions.SetFields("metadata", func(c *actions.FieldConfig) interface{} {
-			return metadataList.MetadataListFromDomain(c.Runtime)
-		}),
+	}
+}
+
+func (l *Login) runPostCreationActions(
+	userID string,
+	authRequest *domain.AuthRequest,
+	httpRequest *http.Request,
+	resourceOwner string,
+	flowType domain.FlowType,
+) ([]*domain.UserGrant, error) {
+	ctx := httpRequest.Context()
+
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, flowType, domain.TriggerTypePostCreation, resourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	mutableUserGrants := &object.UserGrants{UserGrants: make([]object.UserGrant, 0)}
+
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("userGrants", &mutableUserGrants.UserGrants),
 		actions.SetFields("v1",
-			actions.SetFields("user",
-				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
-			),
+			actions.SetFields("appendUserGrant", object.AppendGrantFunc(mutableUserGrants)),
 		),
 	)
 
 	for _, a := range triggerActions {
 		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
 
-		ctxOpts := actions.SetContextFields(
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+					return func(call goja.FunctionCall) goja.Value {
+						user, err := l.query.GetUserByID(actionCtx, true, userID, false)
+						if err != nil {
+							panic(err)
+						}
+						return object.UserFromQuery(c, user)
+					}
+				}),
+				actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
+				actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithTrigger(flowType, domain.TriggerTypePostCreation, userID))...,
+		)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = actions.CallFunctionTargets(ctx, l.query, flowType, domain.TriggerTypePostCreation, &actions.FunctionPayload{
+		ResourceOwner: resourceOwner,
+		UserID:        userID,
+		AuthRequestID: idOfAuthRequest(authRequest),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return object.UserGrantsToDomain(userID, mutableUserGrants.UserGrants), nil
+}
+
+func idOfAuthRequest(authRequest *domain.AuthRequest) string {
+	if authRequest == nil {
+		return ""
+	}
+	return authRequest.ID
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// runPreRegistrationActions runs the pre creation actions of the self registration flow,
+// the actions can change the user, append metadata or reject the registration with a message
+func (l *Login) runPreRegistrationActions(
+	authRequest *domain.AuthRequest,
+	httpRequest *http.Request,
+	user *domain.Human,
+	metadata []*domain.Metadata,
+	resourceOwner string,
+	orgName string,
+) (*domain.Human, []*domain.Metadata, error) {
+	ctx := httpRequest.Context()
+
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeSelfRegistration, domain.TriggerTypePreCreation, resourceOwner, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadataList := object.MetadataListFromDomain(metadata)
+	rejection := new(object.Rejection)
+	apiFields := actions.WithAPIFields(
+		append(humanSetterFields(user),
+			actions.SetFields("v1",
+				actions.SetFields("user",
+					actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
+				),
+				actions.SetFields("reject", rejection.RejectFunc),
+			),
+		)...,
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFields := actions.SetContextFields(
 			actions.SetFields("v1",
 				actions.SetFields("user", func(c *actions.FieldConfig) interface{} {
 					return object.UserFromHuman(c, user)
 				}),
+				actions.SetFields("orgName", orgName),
 				actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
 				actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
 			),
@@ -301,43 +474,46 @@ func (l *Login) runPreCreationActions(
 
 		err = actions.Run(
 			actionCtx,
-			ctxOpts,
+			ctxFields,
 			apiFields,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx))...,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypeSelfRegistration, domain.TriggerTypePreCreation, ""))...,
 		)
 		cancel()
 		if err != nil {
 			return nil, nil, err
 		}
+		if err = rejection.Error(l.renderer.ReqLang(l.getTranslator(ctx, authRequest), httpRequest)); err != nil {
+			return nil, nil, err
+		}
 	}
-	return user, object.MetadataListToDomain(metadataList), err
+	err = actions.CallFunctionTargets(ctx, l.query, domain.FlowTypeSelfRegistration, domain.TriggerTypePreCreation, &actions.FunctionPayload{
+		ResourceOwner: resourceOwner,
+		AuthRequestID: idOfAuthRequest(authRequest),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, object.MetadataListToDomain(metadataList), nil
 }
 
-func (l *Login) runPostCreationActions(
+// runPostRegistrationActions runs the post creation actions of the self registration flow,
+// orgID is only set if a new organization was registered
+func (l *Login) runPostRegistrationActions(
 	userID string,
+	orgID string,
 	authRequest *domain.AuthRequest,
 	httpRequest *http.Request,
 	resourceOwner string,
-	flowType domain.FlowType,
-) ([]*domain.UserGrant, error) {
+) error {
 	ctx := httpRequest.Context()
 
-	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, flowType, domain.TriggerTypePostCreation, resourceOwner, false)
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeSelfRegistration, domain.TriggerTypePostCreation, resourceOwner, false)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	mutableUserGrants := &object.UserGrants{UserGrants: make([]object.UserGrant, 0)}
-
-	apiFields := actions.WithAPIFields(
-		actions.SetFields("userGrants", &mutableUserGrants.UserGrants),
-		actions.SetFields("v1",
-			actions.SetFields("appendUserGrant", object.AppendGrantFunc(mutableUserGrants)),
-		),
-	)
-
 	for _, a := range triggerActions {
 		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
 
@@ -352,6 +528,7 @@ func (l *Login) runPostCreationActions(
 						return object.UserFromQuery(c, user)
 					}
 				}),
+				actions.SetFields("orgId", orgID),
 				actions.SetFields("authRequest", object.AuthRequestField(authRequest)),
 				actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
 			),
@@ -360,17 +537,21 @@ func (l *Login) runPostCreationActions(
 		err = actions.Run(
 			actionCtx,
 			ctxFields,
-			apiFields,
+			nil,
 			a.Script,
 			a.Name,
-			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx))...,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypeSelfRegistration, domain.TriggerTypePostCreation, userID))...,
 		)
 		cancel()
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
-	return object.UserGrantsToDomain(userID, mutableUserGrants.UserGrants), err
+	return actions.CallFunctionTargets(ctx, l.query, domain.FlowTypeSelfRegistration, domain.TriggerTypePostCreation, &actions.FunctionPayload{
+		ResourceOwner: resourceOwner,
+		UserID:        userID,
+		AuthRequestID: idOfAuthRequest(authRequest),
+	})
 }
 
 func tokenCtxFields(tokens *oidc.Tokens[*oidc.IDTokenClaims]) []actions.FieldOption {
@@ -3,6 +3,7 @@ package login
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/zitadel/logging"
@@ -12,12 +13,15 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	api_idp "github.com/zitadel/zitadel/internal/api/idp"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
@@ -26,12 +30,14 @@ import (
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
 const (
 	queryIDPConfigID           = "idpConfigID"
 	tmplExternalNotFoundOption = "externalnotfoundoption"
+	tmplExternalIDPFormPost    = "externalidpformpost"
 )
 
 type externalIDPData struct {
@@ -39,8 +45,22 @@ type externalIDPData struct {
 }
 
 type externalIDPCallbackData struct {
-	State string `schema:"state"`
-	Code  string `schema:"code"`
+	State        string `schema:"state"`
+	Code         string `schema:"code"`
+	SAMLResponse string `schema:"SAMLResponse"`
+	// User is the user information Apple sends on the first authentication
+	User string `schema:"user"`
+}
+
+type externalIDPSAMLPostData struct {
+	RelayState   string `schema:"RelayState"`
+	SAMLResponse string `schema:"SAMLResponse"`
+}
+
+type externalIDPFormPostData struct {
+	baseData
+	URL    string
+	Fields map[string]string
 }
 
 type externalNotFoundOptionFormData struct {
@@ -160,6 +180,10 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		provider, err = l.googleProvider(r.Context(), identityProvider)
 	case domain.IDPTypeLDAP:
 		provider, err = l.ldapProvider(r.Context(), identityProvider)
+	case domain.IDPTypeSAML:
+		provider, err = l.samlProvider(r.Context(), identityProvider)
+	case domain.IDPTypeApple:
+		provider, err = l.appleProvider(r.Context(), identityProvider)
 	case domain.IDPTypeUnspecified:
 		fallthrough
 	default:
@@ -176,9 +200,60 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		l.renderLogin(w, r, authReq, err)
 		return
 	}
+	if formPost, ok := session.(idp.SessionSupportsFormPost); ok {
+		l.renderExternalIDPFormPost(w, r, authReq, formPost)
+		return
+	}
 	http.Redirect(w, r, session.GetAuthURL(), http.StatusFound)
 }
 
+// renderExternalIDPFormPost renders a form, which is automatically posted to the IDP
+// (e.g. the AuthnRequest of the SAML HTTP-POST binding)
+func (l *Login) renderExternalIDPFormPost(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, session idp.SessionSupportsFormPost) {
+	action, fields := session.GetAuthForm()
+	data := externalIDPFormPostData{
+		baseData: l.getBaseData(r, authReq, "ExternalIDPFormPost.Title", "ExternalIDPFormPost.Description", "", ""),
+		URL:      action,
+		Fields:   fields,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplExternalIDPFormPost], data, nil)
+}
+
+// handleExternalLoginSAMLCallback handles the SAMLResponse the IDP posts to the assertion consumer service.
+// Since the cookies of the user agent are not sent on the cross site POST request,
+// the response is passed on to the callback by redirect.
+func (l *Login) handleExternalLoginSAMLCallback(w http.ResponseWriter, r *http.Request) {
+	data := new(externalIDPSAMLPostData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderLogin(w, r, nil, err)
+		return
+	}
+	values := url.Values{}
+	values.Set("state", data.RelayState)
+	values.Set("SAMLResponse", data.SAMLResponse)
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointExternalLoginCallback+"?"+values.Encode(), http.StatusFound)
+}
+
+// handleExternalLoginFormCallback handles the response an IDP posts to the callback (response_mode=form_post, e.g. Apple).
+// Since the cookies of the user agent are not sent on the cross site POST request,
+// the response is passed on to the callback by redirect.
+func (l *Login) handleExternalLoginFormCallback(w http.ResponseWriter, r *http.Request) {
+	data := new(externalIDPCallbackData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderLogin(w, r, nil, err)
+		return
+	}
+	values := url.Values{}
+	values.Set("state", data.State)
+	values.Set("code", data.Code)
+	if data.User != "" {
+		values.Set("user", data.User)
+	}
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointExternalLoginCallback+"?"+values.Encode(), http.StatusFound)
+}
+
 // handleExternalLoginCallback handles the callback from a IDP
 // and tries to extract the user with the provided data
 func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Request) {
@@ -258,6 +333,20 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 			return
 		}
 		session = &openid.Session{Provider: provider.(*google.Provider).Provider, Code: data.Code}
+	case domain.IDPTypeSAML:
+		provider, err = l.samlProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = &saml.Session{Provider: provider.(*saml.Provider), RelayState: data.State, Response: data.SAMLResponse}
+	case domain.IDPTypeApple:
+		provider, err = l.appleProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = &apple.Session{Session: &openid.Session{Provider: provider.(*apple.Provider).Provider, Code: data.Code}, UserFormValue: data.User}
 	case domain.IDPTypeJWT,
 		domain.IDPTypeLDAP,
 		domain.IDPTypeUnspecified:
@@ -934,6 +1023,53 @@ func (l *Login) gitlabSelfHostedProvider(ctx context.Context, identityProvider *
 	)
 }
 
+// samlProvider creates the SAML provider with the assertion consumer service of the login,
+// the entityID (metadata) is the same as for the intent flow (see [api_idp.SAMLRootURL])
+func (l *Login) samlProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*saml.Provider, error) {
+	key, err := crypto.Decrypt(identityProvider.SAMLIDPTemplate.Key, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]saml.ProviderOpts, 0, 2)
+	if identityProvider.SAMLIDPTemplate.Binding != "" {
+		opts = append(opts, saml.WithBinding(identityProvider.SAMLIDPTemplate.Binding))
+	}
+	if identityProvider.SAMLIDPTemplate.WithSignedRequest {
+		opts = append(opts, saml.WithSignedRequest())
+	}
+	return saml.New(
+		identityProvider.Name,
+		api_idp.SAMLRootURL(l.externalSecure)(ctx, identityProvider.ID)+"metadata",
+		l.baseURL(ctx)+EndpointExternalLoginSAMLCallback,
+		identityProvider.SAMLIDPTemplate.Metadata,
+		identityProvider.SAMLIDPTemplate.Certificate,
+		key,
+		opts...,
+	)
+}
+
+func (l *Login) appleProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*apple.Provider, error) {
+	privateKey, err := crypto.Decrypt(identityProvider.AppleIDPTemplate.PrivateKey, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return apple.New(
+		identityProvider.AppleIDPTemplate.ClientID,
+		identityProvider.AppleIDPTemplate.TeamID,
+		identityProvider.AppleIDPTemplate.KeyID,
+		l.baseURL(ctx)+EndpointExternalLoginFormCallback,
+		privateKey,
+		identityProvider.AppleIDPTemplate.Scopes,
+	)
+}
+
+// SAMLCallbackURL generates the instance specific URL of the login UI, where SAML IDPs send their response to
+func SAMLCallbackURL(externalSecure bool) func(ctx context.Context) string {
+	return func(ctx context.Context) string {
+		return http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), externalSecure) + HandlerPrefix + EndpointExternalLoginSAMLCallback
+	}
+}
+
 func (l *Login) appendUserGrants(ctx context.Context, userGrants []*domain.UserGrant, resourceOwner string) error {
 	if len(userGrants) == 0 {
 		return nil
@@ -969,6 +1105,8 @@ func tokens(session idp.Session) *oidc.Tokens[*oidc.IDTokenClaims] {
 		return s.Tokens
 	case *azuread.Session:
 		return s.Tokens
+	case *apple.Session:
+		return s.Tokens
 	}
 	return nil
 }
@@ -38,6 +38,7 @@ type Login struct {
 	consolePath         string
 	oidcAuthCallbackURL func(context.Context, string) string
 	samlAuthCallbackURL func(context.Context, string) string
+	samlLogoutURL       func(context.Context) string
 	idpConfigAlg        crypto.EncryptionAlgorithm
 	userCodeAlg         crypto.EncryptionAlgorithm
 }
@@ -63,6 +64,7 @@ func CreateLogin(config Config,
 	consolePath string,
 	oidcAuthCallbackURL func(context.Context, string) string,
 	samlAuthCallbackURL func(context.Context, string) string,
+	samlLogoutURL func(context.Context) string,
 	externalSecure bool,
 	userAgentCookie,
 	issuerInterceptor,
@@ -77,6 +79,7 @@ func CreateLogin(config Config,
 	login := &Login{
 		oidcAuthCallbackURL: oidcAuthCallbackURL,
 		samlAuthCallbackURL: samlAuthCallbackURL,
+		samlLogoutURL:       samlLogoutURL,
 		externalSecure:      externalSecure,
 		consolePath:         consolePath,
 		command:             command,
@@ -113,7 +116,11 @@ func createCSRFInterceptor(cookieName string, csrfCookieKey []byte, externalSecu
 	path := "/"
 	return func(handler http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if strings.HasPrefix(r.URL.Path, EndpointResources) {
+			// resources need no protection and the SAMLResponse and form_post responses are posted by the IDP,
+			// which cannot provide the CSRF token
+			if strings.HasPrefix(r.URL.Path, EndpointResources) ||
+				r.URL.Path == EndpointExternalLoginSAMLCallback ||
+				r.URL.Path == EndpointExternalLoginFormCallback {
 				handler.ServeHTTP(w, r)
 				return
 			}
@@ -2,6 +2,10 @@ package login
 
 import (
 	"net/http"
+
+	"github.com/zitadel/logging"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 )
 
 const (
@@ -9,9 +13,25 @@ const (
 )
 
 func (l *Login) handleLogoutDone(w http.ResponseWriter, r *http.Request) {
+	// the logout has to be propagated to the SAML service providers the user agent signed into,
+	// their sessions are terminated by the SAML provider, which will redirect back afterwards
+	if l.hasSAMLSessions(r) {
+		http.Redirect(w, r, l.samlLogoutURL(r.Context()), http.StatusFound)
+		return
+	}
 	l.renderLogoutDone(w, r)
 }
 
+func (l *Login) hasSAMLSessions(r *http.Request) bool {
+	userAgentID, ok := http_mw.UserAgentIDFromCtx(r.Context())
+	if !ok {
+		return false
+	}
+	sessions, err := l.query.SAMLSessionsByUserAgentID(r.Context(), true, userAgentID)
+	logging.OnError(err).Warn("unable to get saml sessions of user agent")
+	return len(sessions) > 0
+}
+
 func (l *Login) renderLogoutDone(w http.ResponseWriter, r *http.Request) {
 	data := l.getUserData(r, nil, "LogoutDone.Title", "LogoutDone.Description", "", "")
 	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), nil), l.renderer.Templates[tmplLogoutDone], data, nil)
@@ -96,6 +96,11 @@ func (l *Login) handleRegisterCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderRegister(w, r, authRequest, data, err)
 		return
 	}
+	user, metadatas, err = l.runPreRegistrationActions(authRequest, r, user, metadatas, resourceOwner, "")
+	if err != nil {
+		l.renderRegister(w, r, authRequest, data, err)
+		return
+	}
 
 	user, err = l.command.RegisterHuman(setContext(r.Context(), resourceOwner), resourceOwner, user, nil, nil, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator)
 	if err != nil {
@@ -117,6 +122,10 @@ func (l *Login) handleRegisterCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderError(w, r, authRequest, err)
 		return
 	}
+	if err = l.runPostRegistrationActions(user.AggregateID, "", authRequest, r, resourceOwner); err != nil {
+		l.renderError(w, r, authRequest, err)
+		return
+	}
 
 	err = l.appendUserGrants(r.Context(), userGrants, resourceOwner)
 	if err != nil {
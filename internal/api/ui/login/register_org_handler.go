@@ -66,11 +66,27 @@ func (l *Login) handleRegisterOrgCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderRegisterOrg(w, r, authRequest, data, err)
 		return
 	}
-	_, err = l.command.SetUpOrg(ctx, data.toCommandOrg(), true, userIDs...)
+	resourceOwner := authz.GetInstance(r.Context()).DefaultOrganisationID()
+	if authRequest != nil && authRequest.RequestedOrgID != "" {
+		resourceOwner = authRequest.RequestedOrgID
+	}
+	user, metadata, err := l.runPreRegistrationActions(authRequest, r, data.toUserDomain(), make([]*domain.Metadata, 0), resourceOwner, data.RegisterOrgName)
+	if err != nil {
+		l.renderRegisterOrg(w, r, authRequest, data, err)
+		return
+	}
+	createdOrg, err := l.command.SetUpOrg(ctx, data.toCommandOrg(user, metadata), true, userIDs...)
 	if err != nil {
 		l.renderRegisterOrg(w, r, authRequest, data, err)
 		return
 	}
+	if len(createdOrg.CreatedAdmins) > 0 {
+		err = l.runPostRegistrationActions(createdOrg.CreatedAdmins[0].ID, createdOrg.ObjectDetails.ResourceOwner, authRequest, r, resourceOwner)
+		if err != nil {
+			l.renderError(w, r, authRequest, err)
+			return
+		}
+	}
 	if authRequest == nil {
 		l.defaultRedirect(w, r)
 		return
@@ -138,24 +154,40 @@ func (d registerOrgFormData) toUserDomain() *domain.Human {
 	}
 }
 
-func (d registerOrgFormData) toCommandOrg() *command.OrgSetup {
-	if d.Username == "" {
-		d.Username = string(d.Email)
+// toCommandOrg maps the form data to the org setup,
+// the admin is taken from the user and metadata returned by the self registration actions
+func (d registerOrgFormData) toCommandOrg(user *domain.Human, metadata []*domain.Metadata) *command.OrgSetup {
+	human := &command.AddHuman{
+		Username: user.Username,
+		Password: d.Password,
+		Register: true,
+		Metadata: make([]*command.AddMetadataEntry, len(metadata)),
+	}
+	if user.Profile != nil {
+		human.FirstName = user.FirstName
+		human.LastName = user.LastName
+		human.NickName = user.NickName
+		human.DisplayName = user.DisplayName
+		human.PreferredLanguage = user.PreferredLanguage
+		human.Gender = user.Gender
+	}
+	if user.Email != nil {
+		human.Email = command.Email{Address: user.EmailAddress}
+	}
+	if user.Phone != nil {
+		human.Phone = command.Phone{Number: user.PhoneNumber}
+	}
+	for i, entry := range metadata {
+		human.Metadata[i] = &command.AddMetadataEntry{
+			Key:   entry.Key,
+			Value: entry.Value,
+		}
 	}
 	return &command.OrgSetup{
 		Name: d.RegisterOrgName,
 		Admins: []*command.OrgSetupAdmin{
 			{
-				Human: &command.AddHuman{
-					Username:  d.Username,
-					FirstName: d.Firstname,
-					LastName:  d.Lastname,
-					Email: command.Email{
-						Address: d.Email,
-					},
-					Password: d.Password,
-					Register: true,
-				},
+				Human: human,
 			},
 		},
 	}
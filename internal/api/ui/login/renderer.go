@@ -69,6 +69,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplPasswordResetDone:            "password_reset_done.html",
 		tmplChangePassword:               "change_password.html",
 		tmplChangePasswordDone:           "change_password_done.html",
+		tmplPasswordExpiryWarning:        "password_expiry_warning.html",
 		tmplRegisterOption:               "register_option.html",
 		tmplRegister:                     "register.html",
 		tmplLogoutDone:                   "logout_done.html",
@@ -77,6 +78,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplChangeUsernameDone:           "change_username_done.html",
 		tmplLinkUsersDone:                "link_users_done.html",
 		tmplExternalNotFoundOption:       "external_not_found_option.html",
+		tmplExternalIDPFormPost:          "external_idp_form_post.html",
 		tmplLoginSuccess:                 "login_success.html",
 		tmplLDAPLogin:                    "ldap_login.html",
 		tmplDeviceAuthUserCode:           "device_usercode.html",
@@ -188,6 +190,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"changePasswordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointChangePassword)
 		},
+		"passwordExpiryWarningUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointPasswordExpiryWarning)
+		},
 		"registerOptionUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointRegisterOption)
 		},
@@ -301,6 +306,8 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 		l.redirectToLoginSuccess(w, r, authReq.ID)
 	case *domain.ChangePasswordStep:
 		l.renderChangePassword(w, r, authReq, err)
+	case *domain.PasswordExpiryWarningStep:
+		l.renderPasswordExpiryWarning(w, r, authReq, step, err)
 	case *domain.VerifyEMailStep:
 		l.renderMailVerification(w, r, authReq, "", err)
 	case *domain.MFAPromptStep:
@@ -7,42 +7,45 @@ import (
 )
 
 const (
-	EndpointRoot                     = "/"
-	EndpointHealthz                  = "/healthz"
-	EndpointReadiness                = "/ready"
-	EndpointLogin                    = "/login"
-	EndpointExternalLogin            = "/login/externalidp"
-	EndpointExternalLoginCallback    = "/login/externalidp/callback"
-	EndpointJWTAuthorize             = "/login/jwt/authorize"
-	EndpointJWTCallback              = "/login/jwt/callback"
-	EndpointLDAPLogin                = "/login/ldap"
-	EndpointLDAPCallback             = "/login/ldap/callback"
-	EndpointPasswordlessLogin        = "/login/passwordless"
-	EndpointPasswordlessRegistration = "/login/passwordless/init"
-	EndpointPasswordlessPrompt       = "/login/passwordless/prompt"
-	EndpointLoginName                = "/loginname"
-	EndpointUserSelection            = "/userselection"
-	EndpointChangeUsername           = "/username/change"
-	EndpointPassword                 = "/password"
-	EndpointInitPassword             = "/password/init"
-	EndpointChangePassword           = "/password/change"
-	EndpointPasswordReset            = "/password/reset"
-	EndpointInitUser                 = "/user/init"
-	EndpointMFAVerify                = "/mfa/verify"
-	EndpointMFAPrompt                = "/mfa/prompt"
-	EndpointMFAInitVerify            = "/mfa/init/verify"
-	EndpointMFAInitU2FVerify         = "/mfa/init/u2f/verify"
-	EndpointU2FVerification          = "/mfa/u2f/verify"
-	EndpointMailVerification         = "/mail/verification"
-	EndpointMailVerified             = "/mail/verified"
-	EndpointRegisterOption           = "/register/option"
-	EndpointRegister                 = "/register"
-	EndpointExternalRegister         = "/register/externalidp"
-	EndpointExternalRegisterCallback = "/register/externalidp/callback"
-	EndpointRegisterOrg              = "/register/org"
-	EndpointLogoutDone               = "/logout/done"
-	EndpointLoginSuccess             = "/login/success"
-	EndpointExternalNotFoundOption   = "/externaluser/option"
+	EndpointRoot                      = "/"
+	EndpointHealthz                   = "/healthz"
+	EndpointReadiness                 = "/ready"
+	EndpointLogin                     = "/login"
+	EndpointExternalLogin             = "/login/externalidp"
+	EndpointExternalLoginCallback     = "/login/externalidp/callback"
+	EndpointExternalLoginSAMLCallback = "/login/externalidp/saml/acs"
+	EndpointExternalLoginFormCallback = "/login/externalidp/callback/form"
+	EndpointJWTAuthorize              = "/login/jwt/authorize"
+	EndpointJWTCallback               = "/login/jwt/callback"
+	EndpointLDAPLogin                 = "/login/ldap"
+	EndpointLDAPCallback              = "/login/ldap/callback"
+	EndpointPasswordlessLogin         = "/login/passwordless"
+	EndpointPasswordlessRegistration  = "/login/passwordless/init"
+	EndpointPasswordlessPrompt        = "/login/passwordless/prompt"
+	EndpointLoginName                 = "/loginname"
+	EndpointUserSelection             = "/userselection"
+	EndpointChangeUsername            = "/username/change"
+	EndpointPassword                  = "/password"
+	EndpointInitPassword              = "/password/init"
+	EndpointChangePassword            = "/password/change"
+	EndpointPasswordReset             = "/password/reset"
+	EndpointPasswordExpiryWarning     = "/password/expiry"
+	EndpointInitUser                  = "/user/init"
+	EndpointMFAVerify                 = "/mfa/verify"
+	EndpointMFAPrompt                 = "/mfa/prompt"
+	EndpointMFAInitVerify             = "/mfa/init/verify"
+	EndpointMFAInitU2FVerify          = "/mfa/init/u2f/verify"
+	EndpointU2FVerification           = "/mfa/u2f/verify"
+	EndpointMailVerification          = "/mail/verification"
+	EndpointMailVerified              = "/mail/verified"
+	EndpointRegisterOption            = "/register/option"
+	EndpointRegister                  = "/register"
+	EndpointExternalRegister          = "/register/externalidp"
+	EndpointExternalRegisterCallback  = "/register/externalidp/callback"
+	EndpointRegisterOrg               = "/register/org"
+	EndpointLogoutDone                = "/logout/done"
+	EndpointLoginSuccess              = "/login/success"
+	EndpointExternalNotFoundOption    = "/externaluser/option"
 
 	EndpointResources        = "/resources"
 	EndpointDynamicResources = "/resources/dynamic"
@@ -69,6 +72,8 @@ func CreateRouter(login *Login, staticDir http.FileSystem, interceptors ...mux.M
 	router.HandleFunc(EndpointLogin, login.handleLogin).Methods(http.MethodGet, http.MethodPost)
 	router.HandleFunc(EndpointExternalLogin, login.handleExternalLogin).Methods(http.MethodGet)
 	router.HandleFunc(EndpointExternalLoginCallback, login.handleExternalLoginCallback).Methods(http.MethodGet)
+	router.HandleFunc(EndpointExternalLoginSAMLCallback, login.handleExternalLoginSAMLCallback).Methods(http.MethodPost)
+	router.HandleFunc(EndpointExternalLoginFormCallback, login.handleExternalLoginFormCallback).Methods(http.MethodPost)
 	router.HandleFunc(EndpointJWTAuthorize, login.handleJWTRequest).Methods(http.MethodGet)
 	router.HandleFunc(EndpointJWTCallback, login.handleJWTCallback).Methods(http.MethodGet)
 	router.HandleFunc(EndpointPasswordlessLogin, login.handlePasswordlessVerification).Methods(http.MethodPost)
@@ -94,6 +99,7 @@ func CreateRouter(login *Login, staticDir http.FileSystem, interceptors ...mux.M
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerification).Methods(http.MethodGet)
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerificationCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointChangePassword, login.handleChangePassword).Methods(http.MethodPost)
+	router.HandleFunc(EndpointPasswordExpiryWarning, login.handlePasswordExpiryWarning).Methods(http.MethodPost)
 	router.HandleFunc(EndpointRegisterOption, login.handleRegisterOption).Methods(http.MethodGet)
 	router.HandleFunc(EndpointRegisterOption, login.handleRegisterOptionCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointExternalNotFoundOption, login.handleExternalNotFoundOptionCheck).Methods(http.MethodPost)
@@ -13,6 +13,8 @@ type AuthRequestRepository interface {
 	AuthRequestByCode(ctx context.Context, code string) (*domain.AuthRequest, error)
 	SaveAuthCode(ctx context.Context, id, code, userAgentID string) error
 	DeleteAuthRequest(ctx context.Context, id string) error
+	PushAuthRequest(ctx context.Context, request *domain.PushedAuthRequest) (*domain.PushedAuthRequest, error)
+	PushedAuthRequestByURI(ctx context.Context, requestURI, clientID string) (*domain.PushedAuthRequest, error)
 
 	CheckLoginName(ctx context.Context, id, loginName, userAgentID string) error
 	CheckExternalUserLogin(ctx context.Context, authReqID, userAgentID string, user *domain.ExternalUser, info *domain.BrowserInfo, migrationCheck bool) error
@@ -36,4 +38,5 @@ type AuthRequestRepository interface {
 	AutoRegisterExternalUser(ctx context.Context, user *domain.Human, externalIDP *domain.UserIDPLink, orgMemberRoles []string, authReqID, userAgentID, resourceOwner string, metadatas []*domain.Metadata, info *domain.BrowserInfo) error
 	ResetLinkingUsers(ctx context.Context, authReqID, userAgentID string) error
 	ResetSelectedIDP(ctx context.Context, authReqID, userAgentID string) error
+	SkipPasswordExpiryWarning(ctx context.Context, authReqID, userAgentID string) error
 }
@@ -44,6 +44,7 @@ type AuthRequestRepo struct {
 	OrgViewProvider           orgViewProvider
 	LoginPolicyViewProvider   loginPolicyViewProvider
 	LockoutPolicyViewProvider lockoutPolicyViewProvider
+	PasswordAgePolicyProvider passwordAgePolicyProvider
 	PrivacyPolicyProvider     privacyPolicyProvider
 	IDPProviderViewProvider   idpProviderViewProvider
 	IDPUserLinksProvider      idpUserLinksProvider
@@ -80,6 +81,10 @@ type lockoutPolicyViewProvider interface {
 	LockoutPolicyByOrg(context.Context, bool, string, bool) (*query.LockoutPolicy, error)
 }
 
+type passwordAgePolicyProvider interface {
+	PasswordAgePolicyByOrg(context.Context, bool, string, bool) (*query.PasswordAgePolicy, error)
+}
+
 type idpProviderViewProvider interface {
 	IDPLoginPolicyLinks(context.Context, string, *query.IDPLoginPolicyLinksSearchQuery, bool) (*query.IDPLoginPolicyLinks, error)
 }
@@ -104,6 +109,7 @@ type orgViewProvider interface {
 type userGrantProvider interface {
 	ProjectByClientID(context.Context, string, bool) (*query.Project, error)
 	UserGrantsByProjectAndUserID(context.Context, string, string) ([]*query.UserGrant, error)
+	GroupGrantsByProjectAndUserID(context.Context, string, string) ([]*query.GroupGrant, error)
 }
 
 type projectProvider interface {
@@ -210,6 +216,34 @@ func (repo *AuthRequestRepo) DeleteAuthRequest(ctx context.Context, id string) (
 	return repo.AuthRequests.DeleteAuthRequest(ctx, id)
 }
 
+func (repo *AuthRequestRepo) PushAuthRequest(ctx context.Context, request *domain.PushedAuthRequest) (_ *domain.PushedAuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request.ID, err = repo.IdGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	request.InstanceID = authz.GetInstance(ctx).InstanceID()
+	if err = repo.AuthRequests.SavePushedAuthRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// PushedAuthRequestByURI returns the pushed request referenced by the request_uri.
+// As the request_uri must only be used once (RFC 9126 section 4), the pushed request is removed.
+func (repo *AuthRequestRepo) PushedAuthRequestByURI(ctx context.Context, requestURI, clientID string) (_ *domain.PushedAuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	id, ok := domain.PushedAuthRequestIDFromURI(requestURI)
+	if !ok {
+		return nil, errors.ThrowInvalidArgument(nil, "EVENT-Rq7dz", "Errors.AuthRequest.PushedRequestInvalid")
+	}
+	// the request is only removed for its client, if it's not expired, by the same statement it's read with,
+	// so concurrent calls with the same request_uri can not both redeem it and other clients can not invalidate it
+	return repo.AuthRequests.DeletePushedAuthRequest(ctx, id, clientID)
+}
+
 func (repo *AuthRequestRepo) CheckLoginName(ctx context.Context, id, loginName, userAgentID string) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -362,7 +396,11 @@ func lockoutPolicyToDomain(policy *query.LockoutPolicy) *domain.LockoutPolicy {
 		},
 		Default:             policy.IsDefault,
 		MaxPasswordAttempts: policy.MaxPasswordAttempts,
+		MaxOTPAttempts:      policy.MaxOTPAttempts,
 		ShowLockOutFailures: policy.ShowFailures,
+		LockoutDuration:     policy.LockoutDuration,
+		AttemptResetWindow:  policy.AttemptResetWindow,
+		ProgressiveLockout:  policy.ProgressiveLockout,
 	}
 }
 
@@ -373,7 +411,11 @@ func (repo *AuthRequestRepo) VerifyMFAOTP(ctx context.Context, authRequestID, us
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanCheckMFATOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckMFATOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
 }
 
 func (repo *AuthRequestRepo) BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
@@ -480,6 +522,15 @@ func (repo *AuthRequestRepo) ResetLinkingUsers(ctx context.Context, authReqID, u
 	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
+func (repo *AuthRequestRepo) SkipPasswordExpiryWarning(ctx context.Context, authReqID, userAgentID string) error {
+	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
+	if err != nil {
+		return err
+	}
+	request.PasswordExpiryWarned = true
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
 func (repo *AuthRequestRepo) ResetSelectedIDP(ctx context.Context, authReqID, userAgentID string) error {
 	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
 	if err != nil {
@@ -616,6 +667,11 @@ func (repo *AuthRequestRepo) fillPolicies(ctx context.Context, request *domain.A
 		return err
 	}
 	request.LockoutPolicy = lockoutPolicyToDomain(lockoutPolicy)
+	passwordAgePolicy, err := repo.getPasswordAgePolicy(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	request.PasswordAgePolicy = passwordAgePolicy.ToDomain()
 	privacyPolicy, err := repo.GetPrivacyPolicy(ctx, orgID)
 	if err != nil {
 		return err
@@ -690,7 +746,7 @@ func (repo *AuthRequestRepo) checkLoginName(ctx context.Context, request *domain
 		return err
 	}
 	// if there's an active (human) user, let's use it
-	if user != nil && !user.HumanView.IsZero() && domain.UserState(user.State).NotDisabled() {
+	if user != nil && !user.HumanView.IsZero() && (domain.UserState(user.State).NotDisabled() || user.LockoutExpired(time.Now())) {
 		request.SetUserInfo(user.ID, loginName, user.PreferredLoginName, "", "", user.ResourceOwner)
 		return nil
 	}
@@ -979,8 +1035,14 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return append(steps, step), nil
 	}
 
-	if user.PasswordChangeRequired {
-		steps = append(steps, &domain.ChangePasswordStep{})
+	var passwordExpiry domain.PasswordExpiryState
+	var passwordExpirationDate time.Time
+	if isInternalLogin && user.PasswordSet {
+		passwordExpirationDate, passwordExpiry = request.PasswordAgePolicy.PasswordExpiry(user.PasswordChanged, time.Now())
+	}
+	passwordExpired := passwordExpiry == domain.PasswordExpiryStateExpired
+	if user.PasswordChangeRequired || passwordExpired {
+		steps = append(steps, &domain.ChangePasswordStep{Expired: passwordExpired})
 	}
 	if !user.IsEmailVerified {
 		steps = append(steps, &domain.VerifyEMailStep{})
@@ -989,10 +1051,14 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		steps = append(steps, &domain.ChangeUsernameStep{})
 	}
 
-	if user.PasswordChangeRequired || !user.IsEmailVerified || user.UsernameChangeRequired {
+	if user.PasswordChangeRequired || passwordExpired || !user.IsEmailVerified || user.UsernameChangeRequired {
 		return steps, nil
 	}
 
+	if passwordExpiry == domain.PasswordExpiryStateWarning && !request.PasswordExpiryWarned {
+		return append(steps, &domain.PasswordExpiryWarningStep{ExpirationDate: passwordExpirationDate}), nil
+	}
+
 	if request.LinkingUsers != nil && len(request.LinkingUsers) != 0 {
 		return append(steps, &domain.LinkUsersStep{}), nil
 	}
@@ -1222,6 +1288,14 @@ func (repo *AuthRequestRepo) getLockoutPolicy(ctx context.Context, orgID string)
 	return policy, err
 }
 
+func (repo *AuthRequestRepo) getPasswordAgePolicy(ctx context.Context, orgID string) (*query.PasswordAgePolicy, error) {
+	policy, err := repo.PasswordAgePolicyProvider.PasswordAgePolicyByOrg(ctx, false, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
 func (repo *AuthRequestRepo) getLabelPolicy(ctx context.Context, orgID string) (*domain.LabelPolicy, error) {
 	policy, err := repo.LabelPolicyProvider.ActiveLabelPolicyByOrg(ctx, orgID, false)
 	if err != nil {
@@ -1432,7 +1506,6 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 }
 
 func activeUserByID(ctx context.Context, userViewProvider userViewProvider, userEventProvider userEventProvider, queries orgViewProvider, lockoutPolicyProvider lockoutPolicyViewProvider, userID string, ignoreUnknownUsernames bool) (user *user_model.UserView, err error) {
-	// PLANNED: Check LockoutPolicy
 	user, err = userByID(ctx, userViewProvider, userEventProvider, userID)
 	if err != nil {
 		if ignoreUnknownUsernames && errors.IsNotFound(err) {
@@ -1447,6 +1520,9 @@ func activeUserByID(ctx context.Context, userViewProvider userViewProvider, user
 	if user.HumanView == nil {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Lm69x", "Errors.User.NotHuman")
 	}
+	if user.LockoutExpired(time.Now()) {
+		user.State = user_model.UserStateActive
+	}
 	if user.State == user_model.UserStateLocked || user.State == user_model.UserStateSuspend {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.Locked")
 	}
@@ -1545,7 +1621,15 @@ func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *u
 	if err != nil {
 		return false, err
 	}
-	return len(grants) == 0, nil
+	if len(grants) > 0 {
+		return false, nil
+	}
+	// a grant on one of the groups of the user is sufficient as well
+	groupGrants, err := userGrantProvider.GroupGrantsByProjectAndUserID(ctx, project.ID, user.ID)
+	if err != nil {
+		return false, err
+	}
+	return len(groupGrants) == 0, nil
 }
 
 func projectRequired(ctx context.Context, request *domain.AuthRequest, projectProvider projectProvider) (missingGrant bool, err error) {
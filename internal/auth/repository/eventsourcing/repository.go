@@ -83,6 +83,7 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 			IDPProviderViewProvider:   queries,
 			IDPUserLinksProvider:      queries,
 			LockoutPolicyViewProvider: queries,
+			PasswordAgePolicyProvider: queries,
 			LoginPolicyViewProvider:   queries,
 			UserGrantProvider:         queryView,
 			ProjectProvider:           queryView,
@@ -133,6 +134,19 @@ func (q queryViewWrapper) UserGrantsByProjectAndUserID(ctx context.Context, proj
 	}
 	return grants.UserGrants, nil
 }
+
+func (q queryViewWrapper) GroupGrantsByProjectAndUserID(ctx context.Context, projectID, userID string) ([]*query.GroupGrant, error) {
+	groupGrantProjectID, err := query.NewGroupGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	queries := &query.GroupGrantSearchQueries{Queries: []query.SearchQuery{groupGrantProjectID}}
+	grants, err := q.Queries.GroupGrantsByUserID(ctx, userID, queries, true)
+	if err != nil {
+		return nil, err
+	}
+	return grants.GroupGrants, nil
+}
 func (repo *EsRepository) Health(ctx context.Context) error {
 	if err := repo.UserRepo.Health(ctx); err != nil {
 		return err
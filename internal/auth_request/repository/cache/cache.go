@@ -87,3 +87,41 @@ func (c *AuthRequestCache) saveAuthRequest(request *domain.AuthRequest, query st
 	}
 	return nil
 }
+
+// DeletePushedAuthRequest removes the pushed request of the client and returns it, if it's not expired.
+// The request is read and removed in a single statement, so it can only be returned once, even on concurrent calls.
+// Requests of other clients are not removed, so they can't be invalidated by them.
+func (c *AuthRequestCache) DeletePushedAuthRequest(ctx context.Context, id, clientID string) (*domain.PushedAuthRequest, error) {
+	request := &domain.PushedAuthRequest{ID: id, InstanceID: authz.GetInstance(ctx).InstanceID()}
+	var parameters []byte
+	err := c.client.QueryRow("DELETE FROM auth.pushed_auth_requests WHERE instance_id = $1 and id = $2 and client_id = $3 and expiration > now() RETURNING client_id, parameters, creation_date, expiration", request.InstanceID, id, clientID).
+		Scan(&request.ClientID, &parameters, &request.CreationDate, &request.Expiration)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, caos_errs.ThrowNotFound(err, "CACHE-Pq4nd", "Errors.AuthRequest.NotFound")
+		}
+		return nil, caos_errs.ThrowInternal(err, "CACHE-w2Kdl", "Errors.Internal")
+	}
+	if err = json.Unmarshal(parameters, &request.Parameters); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Mx8aS", "Errors.Internal")
+	}
+	return request, nil
+}
+
+// SavePushedAuthRequest stores the pushed request and removes the expired ones of the instance
+func (c *AuthRequestCache) SavePushedAuthRequest(_ context.Context, request *domain.PushedAuthRequest) error {
+	parameters, err := json.Marshal(request.Parameters)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-a0Zbe", "Errors.Internal")
+	}
+	_, err = c.client.Exec("DELETE FROM auth.pushed_auth_requests WHERE instance_id = $1 and expiration <= now()", request.InstanceID)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-Vb3ol", "Errors.Internal")
+	}
+	_, err = c.client.Exec("INSERT INTO auth.pushed_auth_requests (id, instance_id, client_id, parameters, creation_date, expiration) VALUES($1, $2, $3, $4, $5, $6)",
+		request.ID, request.InstanceID, request.ClientID, parameters, request.CreationDate, request.Expiration)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-g7Hsq", "Errors.Internal")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Package replay provides a cache remembering one-time values (e.g. the IDs of assertions or proofs) until they expire,
+// so they can only be used once.
+//
+// The cache is held in memory of the process. Running multiple ZITADEL processes, a value can therefore be used once per process.
+// The time a value is accepted is limited by its expiration, which keeps this window small.
+package replay
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache remembers the added keys until their expiration.
+// Expired keys are removed at most once per cleanup interval, instead of on every call.
+type Cache[K comparable] struct {
+	mux             sync.Mutex
+	keys            map[K]time.Time
+	cleanupInterval time.Duration
+	nextCleanup     time.Time
+}
+
+func New[K comparable](cleanupInterval time.Duration) *Cache[K] {
+	return &Cache[K]{
+		keys:            make(map[K]time.Time),
+		cleanupInterval: cleanupInterval,
+	}
+}
+
+// Add returns false if the key was already added and is not yet expired
+func (c *Cache[K]) Add(key K, expiration, now time.Time) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if !now.Before(c.nextCleanup) {
+		c.cleanup(now)
+	}
+	if exp, ok := c.keys[key]; ok && now.Before(exp) {
+		return false
+	}
+	c.keys[key] = expiration
+	return true
+}
+
+// Len returns the number of keys, including the expired ones not yet removed
+func (c *Cache[K]) Len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return len(c.keys)
+}
+
+func (c *Cache[K]) cleanup(now time.Time) {
+	for key, exp := range c.keys {
+		if !now.Before(exp) {
+			delete(c.keys, key)
+		}
+	}
+	c.nextCleanup = now.Add(c.cleanupInterval)
+}
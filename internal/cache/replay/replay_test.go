@@ -0,0 +1,39 @@
+package replay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testKey struct {
+	issuer string
+	id     string
+}
+
+func TestCache_Add(t *testing.T) {
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	cache := New[testKey](time.Minute)
+
+	assert.True(t, cache.Add(testKey{"issuer", "id"}, now.Add(time.Minute), now))
+	assert.False(t, cache.Add(testKey{"issuer", "id"}, now.Add(time.Minute), now.Add(time.Second)), "replay must be detected")
+	assert.True(t, cache.Add(testKey{"other", "id"}, now.Add(time.Minute), now.Add(time.Second)), "keys must be compared completely")
+
+	// expired keys can be added again, even before they're removed
+	assert.True(t, cache.Add(testKey{"issuer", "short"}, now.Add(time.Second), now))
+	assert.True(t, cache.Add(testKey{"issuer", "id2"}, now.Add(time.Minute), now.Add(2*time.Second)))
+	assert.True(t, cache.Add(testKey{"issuer", "short"}, now.Add(time.Minute), now.Add(2*time.Second)))
+	assert.Equal(t, 4, cache.Len())
+}
+
+func TestCache_cleanup(t *testing.T) {
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	cache := New[string](time.Minute)
+
+	assert.True(t, cache.Add("expired", now.Add(time.Second), now))
+	assert.True(t, cache.Add("valid", now.Add(2*time.Minute), now))
+	assert.True(t, cache.Add("new", now.Add(2*time.Minute), now.Add(time.Minute)))
+	assert.Equal(t, 2, cache.Len(), "expired keys must be removed after the cleanup interval")
+	assert.False(t, cache.Add("valid", now.Add(2*time.Minute), now.Add(time.Minute)))
+}
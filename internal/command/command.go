@@ -16,6 +16,8 @@ import (
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/repository/action"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/group"
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	instance_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
@@ -25,6 +27,7 @@ import (
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/target"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	usr_grant_repo "github.com/zitadel/zitadel/internal/repository/usergrant"
 	"github.com/zitadel/zitadel/internal/static"
@@ -56,6 +59,7 @@ type Commands struct {
 	domainVerificationAlg           crypto.EncryptionAlgorithm
 	domainVerificationGenerator     crypto.Generator
 	domainVerificationValidator     func(domain, token, verifier string, checkType api_http.CheckType) error
+	targetSigningKeyGenerator       crypto.Generator
 	sessionTokenCreator             func(sessionID string) (id string, token string, err error)
 	sessionTokenVerifier            func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
 	defaultAccessTokenLifetime      time.Duration
@@ -71,6 +75,8 @@ type Commands struct {
 	privateKeyLifetime   time.Duration
 	publicKeyLifetime    time.Duration
 	certificateLifetime  time.Duration
+
+	samlCertificateAndKeyGenerator func(id string) ([]byte, []byte, error)
 }
 
 func StartCommands(
@@ -125,6 +131,7 @@ func StartCommands(
 		defaultAccessTokenLifetime:      defaultAccessTokenLifetime,
 		defaultRefreshTokenLifetime:     defaultRefreshTokenLifetime,
 		defaultRefreshTokenIdleLifetime: defaultRefreshTokenIdleLifetime,
+		samlCertificateAndKeyGenerator:  samlCertificateAndKeyGenerator(defaults.KeyConfig.CertificateSize, defaults.KeyConfig.CertificateLifetime),
 	}
 
 	instance_repo.RegisterEventMappers(repo.eventstore)
@@ -140,6 +147,9 @@ func StartCommands(
 	authrequest.RegisterEventMappers(repo.eventstore)
 	oidcsession.RegisterEventMappers(repo.eventstore)
 	milestone.RegisterEventMappers(repo.eventstore)
+	target.RegisterEventMappers(repo.eventstore)
+	execution.RegisterEventMappers(repo.eventstore)
+	group.RegisterEventMappers(repo.eventstore)
 
 	repo.codeAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
 	repo.userPasswordHasher, err = defaults.PasswordHasher.PasswordHasher()
@@ -158,6 +168,9 @@ func StartCommands(
 
 	repo.domainVerificationGenerator = crypto.NewEncryptionGenerator(defaults.DomainVerification.VerificationGenerator, repo.domainVerificationAlg)
 	repo.domainVerificationValidator = api_http.ValidateDomain
+	// the signing keys of the targets are encrypted with the oidcEncryption (key encryption),
+	// so they can be decrypted by the queries when calling the targets
+	repo.targetSigningKeyGenerator = crypto.NewEncryptionGenerator(targetSigningKeyGeneratorConfig, oidcEncryption)
 	return repo, nil
 }
 
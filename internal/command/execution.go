@@ -0,0 +1,224 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+)
+
+// ExecutionAPICondition defines the gRPC method(s) a request or response execution is called on,
+// exactly one of the fields has to be set
+type ExecutionAPICondition struct {
+	Method  string
+	Service string
+	All     bool
+}
+
+func (e *ExecutionAPICondition) IsValid() error {
+	if !exactlyOne(e.Method != "", e.Service != "", e.All) {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-3tkej", "Errors.Execution.Invalid")
+	}
+	return nil
+}
+
+func (e *ExecutionAPICondition) ID(executionType domain.ExecutionType) string {
+	if e.Method != "" {
+		return domain.ExecutionIDForMethod(executionType, e.Method)
+	}
+	if e.Service != "" {
+		return domain.ExecutionIDForService(executionType, e.Service)
+	}
+	return domain.ExecutionIDForAll(executionType)
+}
+
+// ExecutionEventCondition defines the event(s) an event execution is called on,
+// exactly one of the fields has to be set
+type ExecutionEventCondition struct {
+	Event string
+	Group string
+	All   bool
+}
+
+func (e *ExecutionEventCondition) IsValid() error {
+	if !exactlyOne(e.Event != "", e.Group != "", e.All) {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-w5smb", "Errors.Execution.Invalid")
+	}
+	return nil
+}
+
+func (e *ExecutionEventCondition) ID() string {
+	if e.Event != "" {
+		return domain.ExecutionIDForEvent(e.Event)
+	}
+	if e.Group != "" {
+		return domain.ExecutionIDForEventGroup(e.Group)
+	}
+	return domain.ExecutionIDForAll(domain.ExecutionTypeEvent)
+}
+
+// ExecutionFunctionCondition defines the trigger of a flow a function execution is called on
+type ExecutionFunctionCondition struct {
+	FlowType    domain.FlowType
+	TriggerType domain.TriggerType
+}
+
+func (e *ExecutionFunctionCondition) IsValid() error {
+	if !e.FlowType.Valid() || !e.FlowType.HasTrigger(e.TriggerType) {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-5r5Cj", "Errors.Execution.Invalid")
+	}
+	return nil
+}
+
+func (e *ExecutionFunctionCondition) ID() string {
+	return domain.ExecutionIDForFunction(e.FlowType, e.TriggerType)
+}
+
+func exactlyOne(conditions ...bool) bool {
+	set := 0
+	for _, condition := range conditions {
+		if condition {
+			set++
+		}
+	}
+	return set == 1
+}
+
+type SetExecution struct {
+	Targets []string
+}
+
+func (s *SetExecution) IsValid() error {
+	if len(s.Targets) == 0 {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-56bte", "Errors.Execution.NoTargets")
+	}
+	for i, target := range s.Targets {
+		if target == "" {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ei8ok", "Errors.Execution.Invalid")
+		}
+		for _, other := range s.Targets[i+1:] {
+			if target == other {
+				return caos_errs.ThrowInvalidArgument(nil, "COMMAND-3fh6s", "Errors.Execution.Invalid")
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Commands) SetExecutionRequest(ctx context.Context, cond *ExecutionAPICondition, set *SetExecution, resourceOwner string) (*domain.ObjectDetails, error) {
+	if err := cond.IsValid(); err != nil {
+		return nil, err
+	}
+	return c.setExecution(ctx, cond.ID(domain.ExecutionTypeRequest), set, resourceOwner)
+}
+
+func (c *Commands) SetExecutionResponse(ctx context.Context, cond *ExecutionAPICondition, set *SetExecution, resourceOwner string) (*domain.ObjectDetails, error) {
+	if err := cond.IsValid(); err != nil {
+		return nil, err
+	}
+	return c.setExecution(ctx, cond.ID(domain.ExecutionTypeResponse), set, resourceOwner)
+}
+
+func (c *Commands) SetExecutionEvent(ctx context.Context, cond *ExecutionEventCondition, set *SetExecution, resourceOwner string) (*domain.ObjectDetails, error) {
+	if err := cond.IsValid(); err != nil {
+		return nil, err
+	}
+	return c.setExecution(ctx, cond.ID(), set, resourceOwner)
+}
+
+func (c *Commands) SetExecutionFunction(ctx context.Context, cond *ExecutionFunctionCondition, set *SetExecution, resourceOwner string) (*domain.ObjectDetails, error) {
+	if err := cond.IsValid(); err != nil {
+		return nil, err
+	}
+	return c.setExecution(ctx, cond.ID(), set, resourceOwner)
+}
+
+func (c *Commands) setExecution(ctx context.Context, id string, set *SetExecution, resourceOwner string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-gg3a6", "Errors.IDMissing")
+	}
+	if err := set.IsValid(); err != nil {
+		return nil, err
+	}
+	if err := c.existsTargetsByIDs(ctx, set.Targets, resourceOwner); err != nil {
+		return nil, err
+	}
+
+	wm := NewExecutionWriteModel(id, resourceOwner)
+	if err := c.pushAppendAndReduce(ctx, wm, execution.NewSetEvent(
+		ctx,
+		ExecutionAggregateFromWriteModel(&wm.WriteModel),
+		set.Targets,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+func (c *Commands) existsTargetsByIDs(ctx context.Context, ids []string, resourceOwner string) error {
+	wm := NewTargetsExistsWriteModel(ids, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return err
+	}
+	if !wm.AllExists() {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-17JpD", "Errors.Target.NotFound")
+	}
+	return nil
+}
+
+// DeleteExecution removes all targets of the execution, the id can be retrieved from the conditions (e.g. [ExecutionAPICondition.ID])
+func (c *Commands) DeleteExecution(ctx context.Context, id, resourceOwner string) (*domain.ObjectDetails, error) {
+	if id == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-5xjld", "Errors.IDMissing")
+	}
+
+	wm, err := c.getExecutionWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-cdfQz", "Errors.Execution.NotFound")
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, execution.NewRemovedEvent(
+		ctx,
+		ExecutionAggregateFromWriteModel(&wm.WriteModel),
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// ExecutionEventTargetsCalled records the targets of the event execution called for an event (by the notification handler),
+// so they are not called again if the event is retried
+func (c *Commands) ExecutionEventTargetsCalled(ctx context.Context, id, resourceOwner string, aggregateType eventstore.AggregateType, aggregateID string, sequence uint64, targets []string) error {
+	if id == "" || resourceOwner == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vn3qt", "Errors.IDMissing")
+	}
+	if aggregateType == "" || aggregateID == "" || len(targets) == 0 {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hq6zd", "Errors.Execution.Invalid")
+	}
+	_, err := c.eventstore.Push(ctx, execution.NewEventTargetsCalledEvent(
+		ctx,
+		&execution.NewAggregate(id, resourceOwner).Aggregate,
+		aggregateType,
+		aggregateID,
+		sequence,
+		targets,
+	))
+	return err
+}
+
+func (c *Commands) getExecutionWriteModelByID(ctx context.Context, id string, resourceOwner string) (*ExecutionWriteModel, error) {
+	wm := NewExecutionWriteModel(id, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, wm)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+func ExecutionAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, execution.AggregateType, execution.AggregateVersion)
+}
@@ -0,0 +1,637 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+func TestCommands_SetExecutionRequest(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		cond          *ExecutionAPICondition
+		set           *SetExecution
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no condition, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				cond:          &ExecutionAPICondition{},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"multiple conditions, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					Method:  "/zitadel.session.v2alpha.SessionService/CreateSession",
+					Service: "zitadel.session.v2alpha.SessionService",
+				},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no targets, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				cond:          &ExecutionAPICondition{All: true},
+				set:           &SetExecution{},
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"duplicate targets, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				cond:          &ExecutionAPICondition{All: true},
+				set:           &SetExecution{Targets: []string{"target", "target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"target not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				cond:          &ExecutionAPICondition{All: true},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"target removed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("target", "instance"),
+						),
+						eventFromEventPusher(
+							target.NewRemovedEvent(context.Background(),
+								&target.NewAggregate("target", "instance").Aggregate,
+								"name",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				cond:          &ExecutionAPICondition{All: true},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"method, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("target", "instance"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								execution.NewSetEvent(context.Background(),
+									&execution.NewAggregate("request/zitadel.session.v2alpha.SessionService/CreateSession", "instance").Aggregate,
+									[]string{"target"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					Method: "/zitadel.session.v2alpha.SessionService/CreateSession",
+				},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+		{
+			"service, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("target", "instance"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								execution.NewSetEvent(context.Background(),
+									&execution.NewAggregate("request/zitadel.session.v2alpha.SessionService", "instance").Aggregate,
+									[]string{"target"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionAPICondition{
+					Service: "zitadel.session.v2alpha.SessionService",
+				},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.SetExecutionRequest(tt.args.ctx, tt.args.cond, tt.args.set, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_SetExecutionEvent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		cond          *ExecutionEventCondition
+		set           *SetExecution
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no condition, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				cond:          &ExecutionEventCondition{},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"group, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("target", "instance"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								execution.NewSetEvent(context.Background(),
+									&execution.NewAggregate("event/user.*", "instance").Aggregate,
+									[]string{"target"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionEventCondition{
+					Group: "user",
+				},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.SetExecutionEvent(tt.args.ctx, tt.args.cond, tt.args.set, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_SetExecutionFunction(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		cond          *ExecutionFunctionCondition
+		set           *SetExecution
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"trigger not in flow, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionFunctionCondition{
+					FlowType:    domain.FlowTypeCustomiseToken,
+					TriggerType: domain.TriggerTypePostAuthentication,
+				},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"function, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							targetAddEvent("target", "instance"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								execution.NewSetEvent(context.Background(),
+									&execution.NewAggregate("function/2/4", "instance").Aggregate,
+									[]string{"target"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				cond: &ExecutionFunctionCondition{
+					FlowType:    domain.FlowTypeCustomiseToken,
+					TriggerType: domain.TriggerTypePreUserinfoCreation,
+				},
+				set:           &SetExecution{Targets: []string{"target"}},
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.SetExecutionFunction(tt.args.ctx, tt.args.cond, tt.args.set, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_DeleteExecution(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no id, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "request",
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"already removed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							execution.NewSetEvent(context.Background(),
+								&execution.NewAggregate("request", "instance").Aggregate,
+								[]string{"target"},
+							),
+						),
+						eventFromEventPusher(
+							execution.NewRemovedEvent(context.Background(),
+								&execution.NewAggregate("request", "instance").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "request",
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							execution.NewSetEvent(context.Background(),
+								&execution.NewAggregate("request", "instance").Aggregate,
+								[]string{"target"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								execution.NewRemovedEvent(context.Background(),
+									&execution.NewAggregate("request", "instance").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "request",
+				resourceOwner: "instance",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.DeleteExecution(tt.args.ctx, tt.args.id, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ExecutionEventTargetsCalled(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+		targets       []string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no id, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "instance",
+				targets:       []string{"target"},
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no targets, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "event",
+				resourceOwner: "instance",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								execution.NewEventTargetsCalledEvent(context.Background(),
+									&execution.NewAggregate("event", "instance").Aggregate,
+									"user",
+									"user1",
+									42,
+									[]string{"target"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "event",
+				resourceOwner: "instance",
+				targets:       []string{"target"},
+			},
+			res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := c.ExecutionEventTargetsCalled(tt.args.ctx, tt.args.id, tt.args.resourceOwner, "user", "user1", 42, tt.args.targets)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package command
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/group"
+)
+
+type AddGroup struct {
+	models.ObjectRoot
+
+	Name        string
+	Description string
+	// Members are the ids of the users of the organisation, which are added to the group
+	Members []string
+}
+
+func (a *AddGroup) IsValid() error {
+	a.Name = strings.TrimSpace(a.Name)
+	if a.Name == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-7d3Lq", "Errors.Group.InvalidName")
+	}
+	return nil
+}
+
+func (c *Commands) AddGroup(ctx context.Context, add *AddGroup, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Xq5wz", "Errors.IDMissing")
+	}
+	if err := add.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if add.AggregateID == "" {
+		add.AggregateID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wm, err := c.getGroupWriteModelByID(ctx, add.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if wm.State.Exists() {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-m9R2x", "Errors.Group.AlreadyExists")
+	}
+
+	groupAgg := GroupAggregateFromWriteModel(&wm.WriteModel)
+	cmds := []eventstore.Command{
+		group.NewAddedEvent(
+			ctx,
+			groupAgg,
+			add.Name,
+			add.Description,
+		),
+	}
+	memberCmds, err := c.setGroupMembers(ctx, wm, groupAgg, add.Members)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, append(cmds, memberCmds...)...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+type ChangeGroup struct {
+	models.ObjectRoot
+
+	Name        *string
+	Description *string
+	// Members replace the members of the group, if they are not nil
+	Members []string
+}
+
+func (a *ChangeGroup) IsValid() error {
+	if a.AggregateID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ue8mb", "Errors.IDMissing")
+	}
+	if a.Name != nil {
+		name := strings.TrimSpace(*a.Name)
+		if name == "" {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sb1oT", "Errors.Group.InvalidName")
+		}
+		a.Name = &name
+	}
+	return nil
+}
+
+func (c *Commands) ChangeGroup(ctx context.Context, change *ChangeGroup, resourceOwner string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-0gXtd", "Errors.IDMissing")
+	}
+	if err := change.IsValid(); err != nil {
+		return nil, err
+	}
+
+	existing, err := c.getGroupWriteModelByID(ctx, change.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Kp3sv", "Errors.Group.NotFound")
+	}
+
+	groupAgg := GroupAggregateFromWriteModel(&existing.WriteModel)
+	cmds := make([]eventstore.Command, 0, len(change.Members)+1)
+	if changedEvent := existing.NewChangedEvent(
+		ctx,
+		groupAgg,
+		change.Name,
+		change.Description,
+	); changedEvent != nil {
+		cmds = append(cmds, changedEvent)
+	}
+	if change.Members != nil {
+		memberCmds, err := c.setGroupMembers(ctx, existing, groupAgg, change.Members)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, memberCmds...)
+	}
+	if len(cmds) == 0 {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	if err := c.pushAppendAndReduce(ctx, existing, cmds...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// setGroupMembers returns the events to add the missing users and to remove the members which are not in userIDs
+func (c *Commands) setGroupMembers(ctx context.Context, existing *GroupWriteModel, groupAgg *eventstore.Aggregate, userIDs []string) ([]eventstore.Command, error) {
+	cmds := make([]eventstore.Command, 0, len(userIDs))
+	members := make(map[string]struct{}, len(userIDs))
+	for _, userID := range userIDs {
+		if userID == "" {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lq8Wd", "Errors.Group.Member.Invalid")
+		}
+		if _, ok := members[userID]; ok {
+			continue
+		}
+		members[userID] = struct{}{}
+		if _, ok := existing.Members[userID]; ok {
+			continue
+		}
+		if err := c.checkUserExists(ctx, userID, existing.ResourceOwner); err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, group.NewMemberAddedEvent(ctx, groupAgg, userID))
+	}
+	removed := make([]string, 0, len(existing.Members))
+	for userID := range existing.Members {
+		if _, ok := members[userID]; !ok {
+			removed = append(removed, userID)
+		}
+	}
+	sort.Strings(removed)
+	for _, userID := range removed {
+		cmds = append(cmds, group.NewMemberRemovedEvent(ctx, groupAgg, userID))
+	}
+	return cmds, nil
+}
+
+// RemoveGroup removes the group together with its members and grants
+func (c *Commands) RemoveGroup(ctx context.Context, id, resourceOwner string) (*domain.ObjectDetails, error) {
+	if id == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-c2Ykn", "Errors.IDMissing")
+	}
+
+	existing, err := c.getGroupWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-2Wfjq", "Errors.Group.NotFound")
+	}
+
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		group.NewRemovedEvent(ctx,
+			GroupAggregateFromWriteModel(&existing.WriteModel),
+			existing.Name,
+		),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) getGroupWriteModelByID(ctx context.Context, id string, resourceOwner string) (*GroupWriteModel, error) {
+	wm := NewGroupWriteModel(id, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, wm)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// existingGroupWriteModel returns the write model of the group and fails if the group does not exist
+func (c *Commands) existingGroupWriteModel(ctx context.Context, id string, resourceOwner string) (*GroupWriteModel, error) {
+	wm, err := c.getGroupWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-9vLmB", "Errors.Group.NotFound")
+	}
+	return wm, nil
+}
@@ -0,0 +1,124 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/group"
+)
+
+// AddGroupGrant grants roles of a project (or granted project) to the members of a group,
+// the id of the created grant is set into the GrantID
+func (c *Commands) AddGroupGrant(ctx context.Context, grant *domain.GroupGrant, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if !grant.IsValid() || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fq9oV", "Errors.Group.Grant.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, grant.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := existing.grantOfProject(grant.ProjectID, grant.ProjectGrantID); ok {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Wd3qK", "Errors.Group.Grant.AlreadyExists")
+	}
+	if err = c.checkGroupGrantPreCondition(ctx, grant, existing.ResourceOwner); err != nil {
+		return nil, err
+	}
+	grant.GrantID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.pushAppendAndReduce(ctx, existing, group.NewGrantAddedEvent(
+		ctx,
+		GroupAggregateFromWriteModel(&existing.WriteModel),
+		grant.GrantID,
+		grant.ProjectID,
+		grant.ProjectGrantID,
+		grant.RoleKeys,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// ChangeGroupGrant replaces the roles of an existing grant of a group
+func (c *Commands) ChangeGroupGrant(ctx context.Context, grant *domain.GroupGrant, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if grant.AggregateID == "" || grant.GrantID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-s8Hnx", "Errors.Group.Grant.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, grant.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	existingGrant, ok := existing.Grants[grant.GrantID]
+	if !ok {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ue2Pw", "Errors.Group.Grant.NotFound")
+	}
+	if err = checkExplicitProjectPermission(ctx, existingGrant.ProjectGrantID, existingGrant.ProjectID); err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(existingGrant.RoleKeys, grant.RoleKeys) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lx0cB", "Errors.Group.Grant.NotChanged")
+	}
+	grant.ProjectID = existingGrant.ProjectID
+	grant.ProjectGrantID = existingGrant.ProjectGrantID
+	if err = c.checkGroupGrantPreCondition(ctx, grant, existing.ResourceOwner); err != nil {
+		return nil, err
+	}
+
+	if err := c.pushAppendAndReduce(ctx, existing, group.NewGrantChangedEvent(
+		ctx,
+		GroupAggregateFromWriteModel(&existing.WriteModel),
+		grant.GrantID,
+		grant.RoleKeys,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) RemoveGroupGrant(ctx context.Context, groupID, grantID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if groupID == "" || grantID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Yh6dE", "Errors.Group.Grant.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	existingGrant, ok := existing.Grants[grantID]
+	if !ok {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-o2Mfr", "Errors.Group.Grant.NotFound")
+	}
+	if err = checkExplicitProjectPermission(ctx, existingGrant.ProjectGrantID, existingGrant.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if err := c.pushAppendAndReduce(ctx, existing, group.NewGrantRemovedEvent(
+		ctx,
+		GroupAggregateFromWriteModel(&existing.WriteModel),
+		grantID,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) checkGroupGrantPreCondition(ctx context.Context, grant *domain.GroupGrant, resourceOwner string) error {
+	preConditions := NewGroupGrantPreConditionReadModel(grant.ProjectID, grant.ProjectGrantID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, preConditions)
+	if err != nil {
+		return err
+	}
+	if grant.ProjectGrantID == "" && !preConditions.ProjectExists {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Zr8aT", "Errors.Project.NotFound")
+	}
+	if grant.ProjectGrantID != "" && !preConditions.ProjectGrantExists {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nb5vG", "Errors.Project.Grant.NotFound")
+	}
+	if grant.HasInvalidRoles(preConditions.ExistingRoleKeys) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-hY7kQ", "Errors.Project.Role.NotFound")
+	}
+	return nil
+}
@@ -0,0 +1,635 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func groupGrantAddedEvent(groupID, grantID string, roleKeys ...string) *group.GrantAddedEvent {
+	return group.NewGrantAddedEvent(context.Background(),
+		&group.NewAggregate(groupID, "org1").Aggregate,
+		grantID,
+		"project1",
+		"",
+		roleKeys,
+	)
+}
+
+func TestCommands_AddGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		grant         *domain.GroupGrant
+		resourceOwner string
+	}
+	type res struct {
+		grantID string
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid grant, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"group not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:  "project1",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"project already granted, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+						eventFromEventPusher(
+							groupGrantAddedEvent("group1", "grant1", "key1"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:  "project1",
+					RoleKeys:   []string{"key2"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			"project of other organisation, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org2").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:  "project1",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"role not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"key1",
+								"key",
+								"",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:  "project1",
+					RoleKeys:   []string{"key2"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"granted project, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org2").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewGrantAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org2").Aggregate,
+								"projectgrant1",
+								"org1",
+								[]string{"key1"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								group.NewGrantAddedEvent(context.Background(),
+									&group.NewAggregate("group1", "org1").Aggregate,
+									"grant1",
+									"project1",
+									"projectgrant1",
+									[]string{"key1"},
+								),
+							),
+						},
+					),
+				),
+				idGenerator: mock.ExpectID(t, "grant1"),
+			},
+			args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:      "project1",
+					ProjectGrantID: "projectgrant1",
+					RoleKeys:       []string{"key1"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				grantID: "grant1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"add ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"key1",
+								"key",
+								"",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								groupGrantAddedEvent("group1", "grant1", "key1"),
+							),
+						},
+					),
+				),
+				idGenerator: mock.ExpectID(t, "grant1"),
+			},
+			args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					ProjectID:  "project1",
+					RoleKeys:   []string{"key1"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				grantID: "grant1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			details, err := c.AddGroupGrant(tt.args.ctx, tt.args.grant, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.grantID, tt.args.grant.GrantID)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		grant         *domain.GroupGrant
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no grant id, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"grant not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					GrantID:    "grant1",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"no permission, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+						eventFromEventPusher(
+							groupGrantAddedEvent("group1", "grant1", "key1"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					GrantID:    "grant1",
+					RoleKeys:   []string{"key2"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPermissionDenied,
+			},
+		},
+		{
+			"not changed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+						eventFromEventPusher(
+							groupGrantAddedEvent("group1", "grant1", "key1"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					GrantID:    "grant1",
+					RoleKeys:   []string{"key1"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"change ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+						eventFromEventPusher(
+							groupGrantAddedEvent("group1", "grant1", "key1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"key1",
+								"key",
+								"",
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"key2",
+								"key",
+								"",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								group.NewGrantChangedEvent(context.Background(),
+									&group.NewAggregate("group1", "org1").Aggregate,
+									"grant1",
+									[]string{"key1", "key2"},
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				grant: &domain.GroupGrant{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					GrantID:    "grant1",
+					RoleKeys:   []string{"key1", "key2"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ChangeGroupGrant(tt.args.ctx, tt.args.grant, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		groupID       string
+		grantID       string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no grant id, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				groupID:       "group1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"grant removed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+						eventFromEventPusher(
+							groupGrantAddedEvent("group1", "grant1", "key1"),
+						),
+						eventFromEventPusher(
+							group.NewGrantRemovedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"grant1",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				groupID:       "group1",
+				grantID:       "grant1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+						eventFromEventPusher(
+							groupGrantAddedEvent("group1", "grant1", "key1"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								group.NewGrantRemovedEvent(context.Background(),
+									&group.NewAggregate("group1", "org1").Aggregate,
+									"grant1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				groupID:       "group1",
+				grantID:       "grant1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RemoveGroupGrant(tt.args.ctx, tt.args.groupID, tt.args.grantID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
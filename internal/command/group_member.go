@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/group"
+)
+
+// AddGroupMember adds a user of the organisation of the group as member,
+// so the user will receive the roles of all grants of the group
+func (c *Commands) AddGroupMember(ctx context.Context, groupID, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if groupID == "" || userID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ak3Xe", "Errors.Group.Member.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := existing.Members[userID]; ok {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Tn7cP", "Errors.Group.Member.AlreadyExists")
+	}
+	if err = c.checkUserExists(ctx, userID, existing.ResourceOwner); err != nil {
+		return nil, err
+	}
+
+	if err := c.pushAppendAndReduce(ctx, existing, group.NewMemberAddedEvent(
+		ctx,
+		GroupAggregateFromWriteModel(&existing.WriteModel),
+		userID,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) RemoveGroupMember(ctx context.Context, groupID, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if groupID == "" || userID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gq2pL", "Errors.Group.Member.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := existing.Members[userID]; !ok {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Vb4sR", "Errors.Group.Member.NotFound")
+	}
+
+	if err := c.pushAppendAndReduce(ctx, existing, group.NewMemberRemovedEvent(
+		ctx,
+		GroupAggregateFromWriteModel(&existing.WriteModel),
+		userID,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
@@ -0,0 +1,214 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type GroupWriteModel struct {
+	eventstore.WriteModel
+
+	Name        string
+	Description string
+	State       domain.GroupState
+
+	// Members contains the ids of the users in the group
+	Members map[string]struct{}
+	// Grants contains the grants of the group by their id
+	Grants map[string]*GroupGrantWriteModel
+}
+
+type GroupGrantWriteModel struct {
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+}
+
+func NewGroupWriteModel(id string, resourceOwner string) *GroupWriteModel {
+	return &GroupWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+		Members: make(map[string]struct{}),
+		Grants:  make(map[string]*GroupGrantWriteModel),
+	}
+}
+
+func (wm *GroupWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *group.AddedEvent:
+			wm.Name = e.Name
+			wm.Description = e.Description
+			wm.State = domain.GroupStateActive
+		case *group.ChangedEvent:
+			if e.Name != nil {
+				wm.Name = *e.Name
+			}
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+		case *group.RemovedEvent:
+			wm.State = domain.GroupStateRemoved
+			wm.Members = make(map[string]struct{})
+			wm.Grants = make(map[string]*GroupGrantWriteModel)
+		case *group.MemberAddedEvent:
+			wm.Members[e.UserID] = struct{}{}
+		case *group.MemberRemovedEvent:
+			delete(wm.Members, e.UserID)
+		case *group.GrantAddedEvent:
+			wm.Grants[e.GrantID] = &GroupGrantWriteModel{
+				ProjectID:      e.ProjectID,
+				ProjectGrantID: e.ProjectGrantID,
+				RoleKeys:       e.RoleKeys,
+			}
+		case *group.GrantChangedEvent:
+			if grant, ok := wm.Grants[e.GrantID]; ok {
+				grant.RoleKeys = e.RoleKeys
+			}
+		case *group.GrantRemovedEvent:
+			delete(wm.Grants, e.GrantID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *GroupWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(group.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(group.AddedEventType,
+			group.ChangedEventType,
+			group.RemovedEventType,
+			group.MemberAddedEventType,
+			group.MemberRemovedEventType,
+			group.GrantAddedEventType,
+			group.GrantChangedEventType,
+			group.GrantRemovedEventType).
+		Builder()
+}
+
+func (wm *GroupWriteModel) NewChangedEvent(
+	ctx context.Context,
+	agg *eventstore.Aggregate,
+	name,
+	description *string,
+) *group.ChangedEvent {
+	changes := make([]group.Changes, 0, 2)
+	if name != nil && wm.Name != *name {
+		changes = append(changes, group.ChangeName(wm.Name, *name))
+	}
+	if description != nil && wm.Description != *description {
+		changes = append(changes, group.ChangeDescription(*description))
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return group.NewChangedEvent(ctx, agg, changes)
+}
+
+// grantOfProject returns the id of the existing grant of the (granted) project
+func (wm *GroupWriteModel) grantOfProject(projectID, projectGrantID string) (string, bool) {
+	for id, grant := range wm.Grants {
+		if grant.ProjectID == projectID && grant.ProjectGrantID == projectGrantID {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+func GroupAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, group.AggregateType, group.AggregateVersion)
+}
+
+// GroupGrantPreConditionReadModel checks the existence of the (granted) project and its roles
+type GroupGrantPreConditionReadModel struct {
+	eventstore.WriteModel
+
+	ProjectID          string
+	ProjectGrantID     string
+	ResourceOwner      string
+	ProjectExists      bool
+	ProjectGrantExists bool
+	ExistingRoleKeys   []string
+}
+
+func NewGroupGrantPreConditionReadModel(projectID, projectGrantID, resourceOwner string) *GroupGrantPreConditionReadModel {
+	return &GroupGrantPreConditionReadModel{
+		ProjectID:      projectID,
+		ProjectGrantID: projectGrantID,
+		ResourceOwner:  resourceOwner,
+	}
+}
+
+func (wm *GroupGrantPreConditionReadModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ProjectAddedEvent:
+			if wm.ProjectGrantID == "" && wm.ResourceOwner == e.Aggregate().ResourceOwner {
+				wm.ProjectExists = true
+			}
+		case *project.ProjectRemovedEvent:
+			wm.ProjectExists = false
+			wm.ProjectGrantExists = false
+		case *project.GrantAddedEvent:
+			if wm.ProjectGrantID == e.GrantID && wm.ResourceOwner == e.GrantedOrgID {
+				wm.ProjectGrantExists = true
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantChangedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantCascadeChangedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantRemovedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ProjectGrantExists = false
+				wm.ExistingRoleKeys = nil
+			}
+		case *project.RoleAddedEvent:
+			if wm.ProjectGrantID != "" {
+				continue
+			}
+			wm.ExistingRoleKeys = append(wm.ExistingRoleKeys, e.Key)
+		case *project.RoleRemovedEvent:
+			if wm.ProjectGrantID != "" {
+				continue
+			}
+			for i, key := range wm.ExistingRoleKeys {
+				if key == e.Key {
+					wm.ExistingRoleKeys = append(wm.ExistingRoleKeys[:i], wm.ExistingRoleKeys[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *GroupGrantPreConditionReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.ProjectID).
+		EventTypes(
+			project.ProjectAddedType,
+			project.ProjectRemovedType,
+			project.GrantAddedType,
+			project.GrantChangedType,
+			project.GrantCascadeChangedType,
+			project.GrantRemovedType,
+			project.RoleAddedType,
+			project.RoleRemovedType).
+		Builder()
+}
@@ -0,0 +1,627 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func groupAddedEvent(aggID, resourceOwner string) *group.AddedEvent {
+	return group.NewAddedEvent(context.Background(),
+		&group.NewAggregate(aggID, resourceOwner).Aggregate,
+		"name",
+		"description",
+	)
+}
+
+func groupMemberUserAddedEvent(userID string) *user.HumanAddedEvent {
+	return user.NewHumanAddedEvent(context.Background(),
+		&user.NewAggregate(userID, "org1").Aggregate,
+		"username",
+		"firstname",
+		"lastname",
+		"nickname",
+		"displayname",
+		language.German,
+		domain.GenderUnspecified,
+		"email",
+		true,
+	)
+}
+
+func TestCommands_AddGroup(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		add           *AddGroup
+		resourceOwner string
+	}
+	type res struct {
+		id      string
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no resourceowner, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				add:           &AddGroup{Name: "name"},
+				resourceOwner: "",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no name, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				add:           &AddGroup{Name: " "},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"unique constraint failed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPushFailed(
+						errors.ThrowAlreadyExists(nil, "id", "name already exists"),
+						[]*repository.Event{
+							eventFromEventPusher(
+								groupAddedEvent("group1", "org1"),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(group.NewAddGroupNameUniqueConstraint("name", "org1")),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "group1"),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddGroup{
+					Name:        "name",
+					Description: "description",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			"already existing, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "group1"),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddGroup{
+					Name: "name",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								groupAddedEvent("group1", "org1"),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(group.NewAddGroupNameUniqueConstraint("name", "org1")),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "group1"),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddGroup{
+					Name:        " name ",
+					Description: "description",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				id: "group1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"with members, pushed at once",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							groupMemberUserAddedEvent("user1"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								groupAddedEvent("group1", "org1"),
+							),
+							eventFromEventPusher(
+								group.NewMemberAddedEvent(context.Background(),
+									&group.NewAggregate("group1", "org1").Aggregate,
+									"user1",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(group.NewAddGroupNameUniqueConstraint("name", "org1")),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "group1"),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddGroup{
+					Name:        "name",
+					Description: "description",
+					Members:     []string{"user1", "user1"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				id: "group1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"member not existing, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectFilter(),
+				),
+				idGenerator: mock.ExpectID(t, "group1"),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddGroup{
+					Name:    "name",
+					Members: []string{"user1"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			details, err := c.AddGroup(tt.args.ctx, tt.args.add, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, tt.args.add.AggregateID)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeGroup(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		change        *ChangeGroup
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no id, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				change:        &ChangeGroup{},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"empty name, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeGroup{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					Name:       gu.Ptr(""),
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeGroup{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					Name:       gu.Ptr("name2"),
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"no changes",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeGroup{
+					ObjectRoot:  models.ObjectRoot{AggregateID: "group1"},
+					Name:        gu.Ptr("name"),
+					Description: gu.Ptr("description"),
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"change name and description, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								group.NewChangedEvent(context.Background(),
+									&group.NewAggregate("group1", "org1").Aggregate,
+									[]group.Changes{
+										group.ChangeName("name", "name2"),
+										group.ChangeDescription("description2"),
+									},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(group.NewRemoveGroupNameUniqueConstraint("name", "org1")),
+						uniqueConstraintsFromEventConstraint(group.NewAddGroupNameUniqueConstraint("name2", "org1")),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeGroup{
+					ObjectRoot:  models.ObjectRoot{AggregateID: "group1"},
+					Name:        gu.Ptr("name2"),
+					Description: gu.Ptr("description2"),
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"replace members, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"user1",
+							),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"user2",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							groupMemberUserAddedEvent("user3"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								group.NewMemberAddedEvent(context.Background(),
+									&group.NewAggregate("group1", "org1").Aggregate,
+									"user3",
+								),
+							),
+							eventFromEventPusher(
+								group.NewMemberRemovedEvent(context.Background(),
+									&group.NewAggregate("group1", "org1").Aggregate,
+									"user2",
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeGroup{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					Members:    []string{"user1", "user3"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"remove all members, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"user1",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								group.NewMemberRemovedEvent(context.Background(),
+									&group.NewAggregate("group1", "org1").Aggregate,
+									"user1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeGroup{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1"},
+					Members:    []string{},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ChangeGroup(tt.args.ctx, tt.args.change, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveGroup(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no id, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+						eventFromEventPusher(
+							group.NewRemovedEvent(context.Background(),
+								&group.NewAggregate("group1", "org1").Aggregate,
+								"name",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "group1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							groupAddedEvent("group1", "org1"),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								group.NewRemovedEvent(context.Background(),
+									&group.NewAggregate("group1", "org1").Aggregate,
+									"name",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(group.NewRemoveGroupNameUniqueConstraint("name", "org1")),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "group1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RemoveGroup(tt.args.ctx, tt.args.id, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
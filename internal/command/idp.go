@@ -2,11 +2,22 @@ package command
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
 	"time"
 
+	"github.com/zitadel/saml/pkg/provider/xml"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 )
 
@@ -95,6 +106,17 @@ type GoogleProvider struct {
 	IDPOptions   idp.Options
 }
 
+type AppleProvider struct {
+	Name     string
+	ClientID string
+	TeamID   string
+	KeyID    string
+	// PrivateKey is the PEM encoded key (.p8 file) used to generate the client secret, it will only be updated if provided
+	PrivateKey []byte
+	Scopes     []string
+	IDPOptions idp.Options
+}
+
 type LDAPProvider struct {
 	Name              string
 	Servers           []string
@@ -110,6 +132,106 @@ type LDAPProvider struct {
 	IDPOptions        idp.Options
 }
 
+type SAMLProvider struct {
+	Name string
+	// Metadata of the identity provider, if not provided, it will be loaded from the MetadataURL
+	Metadata          []byte
+	MetadataURL       string
+	Binding           domain.SAMLBinding
+	WithSignedRequest bool
+	IDPOptions        idp.Options
+}
+
+// samlCertificateAndKeyGenerator creates a self-signed certificate and RSA key (both PEM encoded),
+// which are used to sign the requests to the SAML identity provider.
+func samlCertificateAndKeyGenerator(keySize int, lifetime time.Duration) func(id string) (certificate, key []byte, err error) {
+	return func(id string) ([]byte, []byte, error) {
+		privateKey, _, err := crypto.GenerateKeyPair(keySize)
+		if err != nil {
+			return nil, nil, err
+		}
+		serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+		if err != nil {
+			return nil, nil, err
+		}
+		now := time.Now()
+		template := &x509.Certificate{
+			SerialNumber: serialNumber,
+			Subject: pkix.Name{
+				Organization: []string{"ZITADEL"},
+				CommonName:   id,
+			},
+			NotBefore: now,
+			NotAfter:  now.Add(lifetime),
+			KeyUsage:  x509.KeyUsageDigitalSignature,
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		certificate, err := x509.ParseCertificate(certDER)
+		if err != nil {
+			return nil, nil, err
+		}
+		certPEM, err := crypto.CertificateToBytes(certificate)
+		if err != nil {
+			return nil, nil, err
+		}
+		return certPEM, crypto.PrivateKeyToBytes(privateKey), nil
+	}
+}
+
+// samlMetadata returns the metadata of the SAML provider and loads it from the MetadataURL if not provided directly.
+// The metadata is checked to contain an IDPSSODescriptor supporting the requested binding.
+func (c *Commands) samlMetadata(ctx context.Context, provider SAMLProvider) ([]byte, error) {
+	metadata := provider.Metadata
+	if len(metadata) == 0 {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.MetadataURL, nil)
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "COMMAND-9fL2k", "Errors.IDPConfig.SAMLMetadataNotLoaded")
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "COMMAND-Xq3bd", "Errors.IDPConfig.SAMLMetadataNotLoaded")
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-8Gw2s", "Errors.IDPConfig.SAMLMetadataNotLoaded")
+		}
+		metadata, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "COMMAND-Pz3nq", "Errors.IDPConfig.SAMLMetadataNotLoaded")
+		}
+	}
+	entityDescriptor, err := xml.ParseMetadataXmlIntoStruct(metadata)
+	if err != nil || entityDescriptor.IDPSSODescriptor == nil {
+		return nil, errors.ThrowInvalidArgument(err, "COMMAND-Ls91d", "Errors.IDPConfig.InvalidSAMLMetadata")
+	}
+	binding := samlBinding(provider.Binding)
+	if binding == "" {
+		return metadata, nil
+	}
+	for _, service := range entityDescriptor.IDPSSODescriptor.SingleSignOnService {
+		if service.Binding == binding {
+			return metadata, nil
+		}
+	}
+	return nil, errors.ThrowInvalidArgument(nil, "COMMAND-B2pk4", "Errors.IDPConfig.InvalidSAMLMetadata")
+}
+
+func samlBinding(binding domain.SAMLBinding) string {
+	switch binding {
+	case domain.SAMLBindingPost:
+		return saml.PostBinding
+	case domain.SAMLBindingRedirect:
+		return saml.RedirectBinding
+	case domain.SAMLBindingUnspecified:
+		fallthrough
+	default:
+		return ""
+	}
+}
+
 func ExistsIDP(ctx context.Context, filter preparation.FilterToQueryReducer, id, orgID string) (exists bool, err error) {
 	writeModel := NewOrgIDPRemoveWriteModel(orgID, id)
 	events, err := filter(ctx, writeModel.Query())
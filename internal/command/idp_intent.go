@@ -14,10 +14,12 @@ import (
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 )
 
@@ -75,24 +77,37 @@ func (c *Commands) CreateIntent(ctx context.Context, idpID, successURL, failureU
 	return id, writeModelToObjectDetails(&writeModel.WriteModel), nil
 }
 
-func (c *Commands) GetProvider(ctx context.Context, idpID, callbackURL string) (idp.Provider, error) {
+// GetProvider returns the identity provider for the callback URL.
+// SAML providers are instead created for the samlRootURL (see [SAMLIDPWriteModel.ToSAMLProvider]).
+func (c *Commands) GetProvider(ctx context.Context, idpID, idpCallback, samlRootURL string) (idp.Provider, error) {
 	writeModel, err := IDPProviderWriteModel(ctx, c.eventstore.Filter, idpID)
 	if err != nil {
 		return nil, err
 	}
-	return writeModel.ToProvider(callbackURL, c.idpConfigEncryption)
+	if writeModel.IDPType == domain.IDPTypeSAML {
+		return writeModel.ToSAMLProvider(samlRootURL, c.idpConfigEncryption)
+	}
+	return writeModel.ToProvider(idpCallback, c.idpConfigEncryption)
 }
 
-func (c *Commands) AuthURLFromProvider(ctx context.Context, idpID, state, callbackURL string) (string, error) {
-	provider, err := c.GetProvider(ctx, idpID, callbackURL)
+// GetSAMLProvider returns the SAML provider for the samlRootURL,
+// additional assertion consumer services (e.g. of the login UI) will be published in the metadata.
+func (c *Commands) GetSAMLProvider(ctx context.Context, idpID, samlRootURL string, additionalACS ...string) (*saml.Provider, error) {
+	writeModel, err := IDPProviderWriteModel(ctx, c.eventstore.Filter, idpID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	session, err := provider.BeginAuth(ctx, state)
+	return writeModel.ToSAMLProvider(samlRootURL, c.idpConfigEncryption, additionalACS...)
+}
+
+// AuthFromProvider begins the authentication on the identity provider and returns its session.
+// The session either provides the URL to redirect the user to or (see [idp.SessionSupportsFormPost]) a form to be posted.
+func (c *Commands) AuthFromProvider(ctx context.Context, idpID, state, idpCallback, samlRootURL string) (idp.Session, error) {
+	provider, err := c.GetProvider(ctx, idpID, idpCallback, samlRootURL)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return session.GetAuthURL(), nil
+	return provider.BeginAuth(ctx, state)
 }
 
 func getIDPIntentWriteModel(ctx context.Context, writeModel *IDPIntentWriteModel, filter preparation.FilterToQueryReducer) error {
@@ -168,6 +183,8 @@ func tokensForSucceededIDPIntent(session idp.Session, encryptionAlg crypto.Encry
 		tokens = s.Tokens
 	case *azuread.Session:
 		tokens = s.Tokens
+	case *apple.Session:
+		tokens = s.Tokens
 	default:
 		return nil, "", nil
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -207,7 +208,9 @@ func TestCommands_CreateIntent(t *testing.T) {
 	}
 }
 
-func TestCommands_AuthURLFromProvider(t *testing.T) {
+func TestCommands_AuthFromProvider(t *testing.T) {
+	samlCertificate, samlKey, err := samlCertificateAndKeyGenerator(2048, time.Hour)("idp")
+	require.NoError(t, err)
 	type fields struct {
 		eventstore   *eventstore.Eventstore
 		secretCrypto crypto.EncryptionAlgorithm
@@ -217,6 +220,7 @@ func TestCommands_AuthURLFromProvider(t *testing.T) {
 		idpID       string
 		state       string
 		callbackURL string
+		samlRootURL string
 	}
 	type res struct {
 		authURL string
@@ -438,6 +442,62 @@ func TestCommands_AuthURLFromProvider(t *testing.T) {
 				authURL: "https://login.microsoftonline.com/tenant/oauth2/v2.0/authorize?client_id=clientID&prompt=select_account&redirect_uri=url&response_type=code&scope=openid+profile+User.Read&state=state",
 			},
 		},
+		{
+			"saml post binding",
+			fields{
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithInstanceID(
+							"instance",
+							instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance").Aggregate,
+								"idp",
+								"name",
+								[]byte(testSAMLMetadata),
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    samlKey,
+								},
+								samlCertificate,
+								"",
+								false,
+								rep_idp.Options{},
+							)),
+					),
+					expectFilter(
+						eventFromEventPusherWithInstanceID(
+							"instance",
+							instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance").Aggregate,
+								"idp",
+								"name",
+								[]byte(testSAMLMetadata),
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    samlKey,
+								},
+								samlCertificate,
+								"",
+								false,
+								rep_idp.Options{},
+							)),
+					),
+				),
+			},
+			args{
+				ctx:         authz.SetCtxData(context.Background(), authz.CtxData{OrgID: "ro"}),
+				idpID:       "idp",
+				state:       "state",
+				callbackURL: "url",
+				samlRootURL: "https://zitadel.cloud/idps/idp/saml/",
+			},
+			res{
+				authURL: "https://idp.example.com/sso",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -445,9 +505,11 @@ func TestCommands_AuthURLFromProvider(t *testing.T) {
 				eventstore:          tt.fields.eventstore,
 				idpConfigEncryption: tt.fields.secretCrypto,
 			}
-			authURL, err := c.AuthURLFromProvider(tt.args.ctx, tt.args.idpID, tt.args.state, tt.args.callbackURL)
+			session, err := c.AuthFromProvider(tt.args.ctx, tt.args.idpID, tt.args.state, tt.args.callbackURL, tt.args.samlRootURL)
 			require.ErrorIs(t, err, tt.res.err)
-			assert.Equal(t, tt.res.authURL, authURL)
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.authURL, session.GetAuthURL())
+			}
 		})
 	}
 }
@@ -781,3 +843,9 @@ func Test_tokensForSucceededIDPIntent(t *testing.T) {
 		})
 	}
 }
+
+const testSAMLMetadata = `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com/metadata">
+	<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+		<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso"/>
+	</md:IDPSSODescriptor>
+</md:EntityDescriptor>`
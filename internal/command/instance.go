@@ -62,6 +62,9 @@ type InstanceSetup struct {
 		ExpireWarnDays uint64
 		MaxAgeDays     uint64
 	}
+	PasswordHistoryPolicy struct {
+		HistoryCount uint64
+	}
 	DomainPolicy struct {
 		UserLoginMustBeDomain                  bool
 		ValidateOrgDomains                     bool
@@ -110,7 +113,11 @@ type InstanceSetup struct {
 	}
 	LockoutPolicy struct {
 		MaxAttempts              uint64
+		MaxOTPAttempts           uint64
 		ShouldShowLockoutFailure bool
+		LockoutDuration          time.Duration
+		AttemptResetWindow       time.Duration
+		ProgressiveLockout       bool
 	}
 	EmailTemplate     []byte
 	MessageTexts      []*domain.CustomMessageText
@@ -219,6 +226,10 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.PasswordAgePolicy.ExpireWarnDays,
 			setup.PasswordAgePolicy.MaxAgeDays,
 		),
+		prepareAddDefaultPasswordHistoryPolicy(
+			instanceAgg,
+			setup.PasswordHistoryPolicy.HistoryCount,
+		),
 		prepareAddDefaultDomainPolicy(
 			instanceAgg,
 			setup.DomainPolicy.UserLoginMustBeDomain,
@@ -255,7 +266,14 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink, setup.PrivacyPolicy.SupportEmail),
 		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
-		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
+		prepareAddDefaultLockoutPolicy(instanceAgg, &domain.LockoutPolicy{
+			MaxPasswordAttempts: setup.LockoutPolicy.MaxAttempts,
+			MaxOTPAttempts:      setup.LockoutPolicy.MaxOTPAttempts,
+			ShowLockOutFailures: setup.LockoutPolicy.ShouldShowLockoutFailure,
+			LockoutDuration:     setup.LockoutPolicy.LockoutDuration,
+			AttemptResetWindow:  setup.LockoutPolicy.AttemptResetWindow,
+			ProgressiveLockout:  setup.LockoutPolicy.ProgressiveLockout,
+		}),
 
 		prepareAddDefaultLabelPolicy(
 			instanceAgg,
@@ -103,6 +103,13 @@ func writeModelToPasswordAgePolicy(wm *PasswordAgePolicyWriteModel) *domain.Pass
 	}
 }
 
+func writeModelToPasswordHistoryPolicy(wm *PasswordHistoryPolicyWriteModel) *domain.PasswordHistoryPolicy {
+	return &domain.PasswordHistoryPolicy{
+		ObjectRoot:   writeModelToObjectRoot(wm.WriteModel),
+		HistoryCount: wm.HistoryCount,
+	}
+}
+
 func writeModelToPasswordComplexityPolicy(wm *PasswordComplexityPolicyWriteModel) *domain.PasswordComplexityPolicy {
 	return &domain.PasswordComplexityPolicy{
 		ObjectRoot:   writeModelToObjectRoot(wm.WriteModel),
@@ -118,7 +125,11 @@ func writeModelToLockoutPolicy(wm *LockoutPolicyWriteModel) *domain.LockoutPolic
 	return &domain.LockoutPolicy{
 		ObjectRoot:          writeModelToObjectRoot(wm.WriteModel),
 		MaxPasswordAttempts: wm.MaxPasswordAttempts,
+		MaxOTPAttempts:      wm.MaxOTPAttempts,
 		ShowLockOutFailures: wm.ShowLockOutFailures,
+		LockoutDuration:     wm.LockoutDuration,
+		AttemptResetWindow:  wm.AttemptResetWindow,
+		ProgressiveLockout:  wm.ProgressiveLockout,
 	}
 }
 
@@ -0,0 +1,105 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+func (c *Commands) AddDefaultPasswordHistoryPolicy(ctx context.Context, historyCount uint64) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultPasswordHistoryPolicy(instanceAgg, historyCount))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) ChangeDefaultPasswordHistoryPolicy(ctx context.Context, policy *domain.PasswordHistoryPolicy) (*domain.PasswordHistoryPolicy, error) {
+	existingPolicy, err := c.defaultPasswordHistoryPolicyWriteModelByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Hs0d1", "Errors.IAM.PasswordHistoryPolicy.NotFound")
+	}
+
+	instanceAgg := InstanceAggregateFromWriteModel(&existingPolicy.PasswordHistoryPolicyWriteModel.WriteModel)
+	changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, instanceAgg, policy.HistoryCount)
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-f93Ks", "Errors.IAM.PasswordHistoryPolicy.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeModelToPasswordHistoryPolicy(&existingPolicy.PasswordHistoryPolicyWriteModel), nil
+}
+
+// getDefaultPasswordHistoryPolicy returns an empty policy if the instance has none,
+// so that no previous passwords are checked.
+func (c *Commands) getDefaultPasswordHistoryPolicy(ctx context.Context) (*domain.PasswordHistoryPolicy, error) {
+	policyWriteModel, err := c.defaultPasswordHistoryPolicyWriteModelByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !policyWriteModel.State.Exists() {
+		return &domain.PasswordHistoryPolicy{}, nil
+	}
+	return writeModelToPasswordHistoryPolicy(&policyWriteModel.PasswordHistoryPolicyWriteModel), nil
+}
+
+func (c *Commands) defaultPasswordHistoryPolicyWriteModelByID(ctx context.Context) (policy *InstancePasswordHistoryPolicyWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewInstancePasswordHistoryPolicyWriteModel(ctx)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func prepareAddDefaultPasswordHistoryPolicy(
+	a *instance.Aggregate,
+	historyCount uint64,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewInstancePasswordHistoryPolicyWriteModel(ctx)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if writeModel.State == domain.PolicyStateActive {
+				return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-Oe2m9", "Errors.IAM.PasswordHistoryPolicy.AlreadyExists")
+			}
+			return []eventstore.Command{
+				instance.NewPasswordHistoryPolicyAddedEvent(ctx, &a.Aggregate,
+					historyCount,
+				),
+			}, nil
+		}, nil
+	}
+}
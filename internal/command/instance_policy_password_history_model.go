@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type InstancePasswordHistoryPolicyWriteModel struct {
+	PasswordHistoryPolicyWriteModel
+}
+
+func NewInstancePasswordHistoryPolicyWriteModel(ctx context.Context) *InstancePasswordHistoryPolicyWriteModel {
+	return &InstancePasswordHistoryPolicyWriteModel{
+		PasswordHistoryPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+		},
+	}
+}
+
+func (wm *InstancePasswordHistoryPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.PasswordHistoryPolicyAddedEvent:
+			wm.PasswordHistoryPolicyWriteModel.AppendEvents(&e.PasswordHistoryPolicyAddedEvent)
+		case *instance.PasswordHistoryPolicyChangedEvent:
+			wm.PasswordHistoryPolicyWriteModel.AppendEvents(&e.PasswordHistoryPolicyChangedEvent)
+		}
+	}
+}
+
+func (wm *InstancePasswordHistoryPolicyWriteModel) Reduce() error {
+	return wm.PasswordHistoryPolicyWriteModel.Reduce()
+}
+
+func (wm *InstancePasswordHistoryPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.PasswordHistoryPolicyWriteModel.AggregateID).
+		EventTypes(
+			instance.PasswordHistoryPolicyAddedEventType,
+			instance.PasswordHistoryPolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstancePasswordHistoryPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	historyCount uint64) (*instance.PasswordHistoryPolicyChangedEvent, bool) {
+	changes := make([]policy.PasswordHistoryPolicyChanges, 0)
+	if wm.HistoryCount != historyCount {
+		changes = append(changes, policy.ChangeHistoryCount(historyCount))
+	}
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := instance.NewPasswordHistoryPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -4,10 +4,10 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 
 	"github.com/zitadel/zitadel/internal/repository/instance"
-	"github.com/zitadel/zitadel/internal/repository/policy"
 )
 
 type InstanceLockoutPolicyWriteModel struct {
@@ -55,15 +55,8 @@ func (wm *InstanceLockoutPolicyWriteModel) Query() *eventstore.SearchQueryBuilde
 func (wm *InstanceLockoutPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	maxAttempts uint64,
-	showLockoutFailure bool) (*instance.LockoutPolicyChangedEvent, bool) {
-	changes := make([]policy.LockoutPolicyChanges, 0)
-	if wm.MaxPasswordAttempts != maxAttempts {
-		changes = append(changes, policy.ChangeMaxAttempts(maxAttempts))
-	}
-	if wm.ShowLockOutFailures != showLockoutFailure {
-		changes = append(changes, policy.ChangeShowLockOutFailures(showLockoutFailure))
-	}
+	lockoutPolicy *domain.LockoutPolicy) (*instance.LockoutPolicyChangedEvent, bool) {
+	changes := wm.changes(lockoutPolicy)
 	if len(changes) == 0 {
 		return nil, false
 	}
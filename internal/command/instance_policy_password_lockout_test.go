@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -21,9 +22,8 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx                 context.Context
-		maxPasswordAttempts uint64
-		showLockOutFailures bool
+		ctx    context.Context
+		policy *domain.LockoutPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -45,16 +45,22 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 							instance.NewLockoutPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:                 context.Background(),
-				maxPasswordAttempts: 10,
-				showLockOutFailures: true,
+				ctx: context.Background(),
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					ShowLockOutFailures: true,
+				},
 			},
 			res: res{
 				err: caos_errs.IsErrorAlreadyExists,
@@ -73,7 +79,11 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 								instance.NewLockoutPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									10,
+									0,
 									true,
+									0,
+									0,
+									false,
 								),
 							),
 						},
@@ -81,9 +91,11 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:                 authz.WithInstanceID(context.Background(), "INSTANCE"),
-				maxPasswordAttempts: 10,
-				showLockOutFailures: true,
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					ShowLockOutFailures: true,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -91,13 +103,72 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add policy with lockout duration, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewLockoutPolicyAddedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									10,
+									5,
+									true,
+									time.Hour,
+									time.Minute*15,
+									true,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					MaxOTPAttempts:      5,
+					ShowLockOutFailures: true,
+					LockoutDuration:     time.Hour,
+					AttemptResetWindow:  time.Minute * 15,
+					ProgressiveLockout:  true,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "negative lockout duration, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					LockoutDuration:     -time.Hour,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultLockoutPolicy(tt.args.ctx, tt.args.maxPasswordAttempts, tt.args.showLockOutFailures)
+			got, err := r.AddDefaultLockoutPolicy(tt.args.ctx, tt.args.policy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -158,7 +229,11 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 							instance.NewLockoutPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
+								false,
 							),
 						),
 					),
@@ -185,7 +260,11 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 							instance.NewLockoutPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
+								false,
 							),
 						),
 					),
@@ -12,8 +12,10 @@ import (
 type InstanceSecurityPolicyWriteModel struct {
 	eventstore.WriteModel
 
-	Enabled        bool
-	AllowedOrigins []string
+	Enabled             bool
+	AllowedOrigins      []string
+	EnableDelegation    bool
+	EnableImpersonation bool
 }
 
 func NewInstanceSecurityPolicyWriteModel(ctx context.Context) *InstanceSecurityPolicyWriteModel {
@@ -34,6 +36,12 @@ func (wm *InstanceSecurityPolicyWriteModel) Reduce() error {
 			if e.AllowedOrigins != nil {
 				wm.AllowedOrigins = *e.AllowedOrigins
 			}
+			if e.EnableDelegation != nil {
+				wm.EnableDelegation = *e.EnableDelegation
+			}
+			if e.EnableImpersonation != nil {
+				wm.EnableImpersonation = *e.EnableImpersonation
+			}
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -53,17 +61,22 @@ func (wm *InstanceSecurityPolicyWriteModel) Query() *eventstore.SearchQueryBuild
 func (wm *InstanceSecurityPolicyWriteModel) NewSetEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	enabled bool,
-	allowedOrigins []string,
+	policy *SecurityPolicy,
 ) (*instance.SecurityPolicySetEvent, error) {
-	changes := make([]instance.SecurityPolicyChanges, 0, 2)
+	changes := make([]instance.SecurityPolicyChanges, 0, 4)
 	var err error
 
-	if wm.Enabled != enabled {
-		changes = append(changes, instance.ChangeSecurityPolicyEnabled(enabled))
+	if wm.Enabled != policy.EnableIframeEmbedding {
+		changes = append(changes, instance.ChangeSecurityPolicyEnabled(policy.EnableIframeEmbedding))
+	}
+	if policy.EnableIframeEmbedding && !reflect.DeepEqual(wm.AllowedOrigins, policy.AllowedOrigins) {
+		changes = append(changes, instance.ChangeSecurityPolicyAllowedOrigins(policy.AllowedOrigins))
+	}
+	if wm.EnableDelegation != policy.EnableDelegation {
+		changes = append(changes, instance.ChangeSecurityPolicyEnableDelegation(policy.EnableDelegation))
 	}
-	if enabled && !reflect.DeepEqual(wm.AllowedOrigins, allowedOrigins) {
-		changes = append(changes, instance.ChangeSecurityPolicyAllowedOrigins(allowedOrigins))
+	if wm.EnableImpersonation != policy.EnableImpersonation {
+		changes = append(changes, instance.ChangeSecurityPolicyEnableImpersonation(policy.EnableImpersonation))
 	}
 	changeEvent, err := instance.NewSecurityPolicySetEvent(ctx, aggregate, changes)
 	if err != nil {
@@ -19,6 +19,7 @@ type OIDCSessionWriteModel struct {
 	Scope                      []string
 	AuthMethods                []domain.UserAuthMethodType
 	AuthTime                   time.Time
+	JWKThumbprint              string
 	State                      domain.OIDCSessionState
 	AccessTokenID              string
 	AccessTokenCreation        time.Time
@@ -89,6 +90,7 @@ func (wm *OIDCSessionWriteModel) reduceAdded(e *oidcsession.AddedEvent) {
 	wm.Scope = e.Scope
 	wm.AuthMethods = e.AuthMethods
 	wm.AuthTime = e.AuthTime
+	wm.JWKThumbprint = e.JWKThumbprint
 	wm.State = domain.OIDCSessionStateActive
 	// the write model might be initialized without resource owner,
 	// so update the aggregate
@@ -140,6 +142,15 @@ func (wm *OIDCSessionWriteModel) CheckRefreshToken(refreshTokenID string) error
 	return nil
 }
 
+// CheckJWKThumbprint ensures that tokens of a session bound to a DPoP key (RFC 9449)
+// are only used with a proof of possession of the same key
+func (wm *OIDCSessionWriteModel) CheckJWKThumbprint(jwkThumbprint string) error {
+	if wm.JWKThumbprint != jwkThumbprint {
+		return caos_errs.ThrowPreconditionFailed(nil, "OIDCS-Fq2ms", "Errors.OIDCSession.RefreshTokenInvalid")
+	}
+	return nil
+}
+
 func (wm *OIDCSessionWriteModel) CheckAccessToken(accessTokenID string) error {
 	if wm.State != domain.OIDCSessionStateActive {
 		return caos_errs.ThrowPreconditionFailed(nil, "OIDCS-KL2pk", "Errors.OIDCSession.Token.Invalid")
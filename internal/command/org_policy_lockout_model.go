@@ -3,10 +3,10 @@ package command
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 
 	"github.com/zitadel/zitadel/internal/repository/org"
-	"github.com/zitadel/zitadel/internal/repository/policy"
 )
 
 type OrgLockoutPolicyWriteModel struct {
@@ -56,15 +56,8 @@ func (wm *OrgLockoutPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
 func (wm *OrgLockoutPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	maxAttempts uint64,
-	showLockoutFailure bool) (*org.LockoutPolicyChangedEvent, bool) {
-	changes := make([]policy.LockoutPolicyChanges, 0)
-	if wm.MaxPasswordAttempts != maxAttempts {
-		changes = append(changes, policy.ChangeMaxAttempts(maxAttempts))
-	}
-	if wm.ShowLockOutFailures != showLockoutFailure {
-		changes = append(changes, policy.ChangeShowLockOutFailures(showLockoutFailure))
-	}
+	lockoutPolicy *domain.LockoutPolicy) (*org.LockoutPolicyChangedEvent, bool) {
+	changes := wm.changes(lockoutPolicy)
 	if len(changes) == 0 {
 		return nil, false
 	}
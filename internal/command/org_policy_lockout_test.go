@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -62,7 +63,11 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
+								false,
 							),
 						),
 					),
@@ -92,7 +97,11 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 								org.NewLockoutPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									10,
+									0,
 									true,
+									0,
+									0,
+									false,
 								),
 							),
 						},
@@ -205,7 +214,11 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
+								false,
 							),
 						),
 					),
@@ -233,7 +246,11 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
+								false,
 							),
 						),
 					),
@@ -265,6 +282,90 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "change lockout duration, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLockoutPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								10,
+								0,
+								true,
+								0,
+								0,
+								false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() *org.LockoutPolicyChangedEvent {
+									event, _ := org.NewLockoutPolicyChangedEvent(context.Background(),
+										&org.NewAggregate("org1").Aggregate,
+										[]policy.LockoutPolicyChanges{
+											policy.ChangeMaxOTPAttempts(3),
+											policy.ChangeLockoutDuration(time.Hour),
+											policy.ChangeAttemptResetWindow(time.Minute * 15),
+											policy.ChangeProgressiveLockout(true),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					MaxOTPAttempts:      3,
+					ShowLockOutFailures: true,
+					LockoutDuration:     time.Hour,
+					AttemptResetWindow:  time.Minute * 15,
+					ProgressiveLockout:  true,
+				},
+			},
+			res: res{
+				want: &domain.LockoutPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "org1",
+						ResourceOwner: "org1",
+					},
+					MaxPasswordAttempts: 10,
+					MaxOTPAttempts:      3,
+					ShowLockOutFailures: true,
+					LockoutDuration:     time.Hour,
+					AttemptResetWindow:  time.Minute * 15,
+					ProgressiveLockout:  true,
+				},
+			},
+		},
+		{
+			name: "negative attempt reset window, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					AttemptResetWindow:  -time.Minute,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -343,7 +444,11 @@ func TestCommandSide_RemovePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
 								true,
+								0,
+								0,
+								false,
 							),
 						),
 					),
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func (c *Commands) getOrgPasswordHistoryPolicy(ctx context.Context, orgID string) (*domain.PasswordHistoryPolicy, error) {
+	policy := NewOrgPasswordHistoryPolicyWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	if policy.State == domain.PolicyStateActive {
+		return writeModelToPasswordHistoryPolicy(&policy.PasswordHistoryPolicyWriteModel), nil
+	}
+	return c.getDefaultPasswordHistoryPolicy(ctx)
+}
+
+func (c *Commands) AddPasswordHistoryPolicy(ctx context.Context, resourceOwner string, policy *domain.PasswordHistoryPolicy) (*domain.PasswordHistoryPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-H4f0s", "Errors.ResourceOwnerMissing")
+	}
+	addedPolicy := NewOrgPasswordHistoryPolicyWriteModel(resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, addedPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if addedPolicy.State == domain.PolicyStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "ORG-Ks8d2", "Errors.Org.PasswordHistoryPolicy.AlreadyExists")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&addedPolicy.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewPasswordHistoryPolicyAddedEvent(ctx, orgAgg, policy.HistoryCount))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToPasswordHistoryPolicy(&addedPolicy.PasswordHistoryPolicyWriteModel), nil
+}
+
+func (c *Commands) ChangePasswordHistoryPolicy(ctx context.Context, resourceOwner string, policy *domain.PasswordHistoryPolicy) (*domain.PasswordHistoryPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Pw9s3", "Errors.ResourceOwnerMissing")
+	}
+	existingPolicy := NewOrgPasswordHistoryPolicyWriteModel(resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-m2Hd8", "Errors.Org.PasswordHistoryPolicy.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.PasswordHistoryPolicyWriteModel.WriteModel)
+	changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, orgAgg, policy.HistoryCount)
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "Org-Lq2s0", "Errors.Org.PasswordHistoryPolicy.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToPasswordHistoryPolicy(&existingPolicy.PasswordHistoryPolicyWriteModel), nil
+}
+
+func (c *Commands) RemovePasswordHistoryPolicy(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Gv3d1", "Errors.ResourceOwnerMissing")
+	}
+	existingPolicy := NewOrgPasswordHistoryPolicyWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Xs9f2", "Errors.Org.PasswordHistoryPolicy.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewPasswordHistoryPolicyRemovedEvent(ctx, orgAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingPolicy.PasswordHistoryPolicyWriteModel.WriteModel), nil
+}
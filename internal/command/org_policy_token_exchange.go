@@ -0,0 +1,50 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// OrgTokenExchangePolicy restricts the token exchange enabled in the security policy of the instance
+// for the users of an organisation
+type OrgTokenExchangePolicy struct {
+	// DisableDelegation prevents actors from receiving tokens on behalf of the users of the organisation
+	DisableDelegation bool
+	// DisableImpersonation prevents actors from impersonating the users of the organisation
+	DisableImpersonation bool
+}
+
+func (c *Commands) SetOrgTokenExchangePolicy(ctx context.Context, resourceOwner string, policy *OrgTokenExchangePolicy) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "ORG-Ht6sq", "Errors.ResourceOwnerMissing")
+	}
+	if err := c.checkOrgExists(ctx, resourceOwner); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.orgTokenExchangePolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	event, err := writeModel.NewSetEvent(ctx, OrgAggregateFromWriteModel(&writeModel.WriteModel), policy)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) orgTokenExchangePolicyWriteModelByID(ctx context.Context, orgID string) (*OrgTokenExchangePolicyWriteModel, error) {
+	writeModel := NewOrgTokenExchangePolicyWriteModel(orgID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgTokenExchangePolicyWriteModel struct {
+	eventstore.WriteModel
+
+	DisableDelegation    bool
+	DisableImpersonation bool
+}
+
+func NewOrgTokenExchangePolicyWriteModel(orgID string) *OrgTokenExchangePolicyWriteModel {
+	return &OrgTokenExchangePolicyWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+	}
+}
+
+func (wm *OrgTokenExchangePolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*org.TokenExchangePolicySetEvent); ok {
+			if e.DisableDelegation != nil {
+				wm.DisableDelegation = *e.DisableDelegation
+			}
+			if e.DisableImpersonation != nil {
+				wm.DisableImpersonation = *e.DisableImpersonation
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgTokenExchangePolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.TokenExchangePolicySetEventType).
+		Builder()
+}
+
+func (wm *OrgTokenExchangePolicyWriteModel) NewSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	policy *OrgTokenExchangePolicy,
+) (*org.TokenExchangePolicySetEvent, error) {
+	changes := make([]org.TokenExchangePolicyChanges, 0, 2)
+	if wm.DisableDelegation != policy.DisableDelegation {
+		changes = append(changes, org.ChangeTokenExchangePolicyDisableDelegation(policy.DisableDelegation))
+	}
+	if wm.DisableImpersonation != policy.DisableImpersonation {
+		changes = append(changes, org.ChangeTokenExchangePolicyDisableImpersonation(policy.DisableImpersonation))
+	}
+	return org.NewTokenExchangePolicySetEvent(ctx, aggregate, changes)
+}
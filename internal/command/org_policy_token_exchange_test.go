@@ -0,0 +1,162 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestCommandSide_SetOrgTokenExchangePolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		policy        *OrgTokenExchangePolicy
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				policy: &OrgTokenExchangePolicy{DisableDelegation: true},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "org not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				policy:        &OrgTokenExchangePolicy{DisableDelegation: true},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org1",
+							),
+						),
+					),
+					expectFilter(
+						tokenExchangePolicySetEvent(t, org.ChangeTokenExchangePolicyDisableDelegation(true)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				policy:        &OrgTokenExchangePolicy{DisableDelegation: true},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set policy, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org1",
+							),
+						),
+					),
+					expectFilter(
+						tokenExchangePolicySetEvent(t, org.ChangeTokenExchangePolicyDisableDelegation(true)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								tokenExchangePolicySetEventCommand(t,
+									org.ChangeTokenExchangePolicyDisableDelegation(false),
+									org.ChangeTokenExchangePolicyDisableImpersonation(true),
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				policy:        &OrgTokenExchangePolicy{DisableImpersonation: true},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetOrgTokenExchangePolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func tokenExchangePolicySetEventCommand(t *testing.T, changes ...org.TokenExchangePolicyChanges) *org.TokenExchangePolicySetEvent {
+	event, err := org.NewTokenExchangePolicySetEvent(context.Background(),
+		&org.NewAggregate("org1").Aggregate,
+		changes,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return event
+}
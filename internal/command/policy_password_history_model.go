@@ -0,0 +1,31 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type PasswordHistoryPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	HistoryCount uint64
+	State        domain.PolicyState
+}
+
+func (wm *PasswordHistoryPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.PasswordHistoryPolicyAddedEvent:
+			wm.HistoryCount = e.HistoryCount
+			wm.State = domain.PolicyStateActive
+		case *policy.PasswordHistoryPolicyChangedEvent:
+			if e.HistoryCount != nil {
+				wm.HistoryCount = *e.HistoryCount
+			}
+		case *policy.PasswordHistoryPolicyRemovedEvent:
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
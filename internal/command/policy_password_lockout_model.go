@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/policy"
@@ -10,7 +12,11 @@ type LockoutPolicyWriteModel struct {
 	eventstore.WriteModel
 
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
 	ShowLockOutFailures bool
+	LockoutDuration     time.Duration
+	AttemptResetWindow  time.Duration
+	ProgressiveLockout  bool
 	State               domain.PolicyState
 }
 
@@ -19,18 +25,57 @@ func (wm *LockoutPolicyWriteModel) Reduce() error {
 		switch e := event.(type) {
 		case *policy.LockoutPolicyAddedEvent:
 			wm.MaxPasswordAttempts = e.MaxPasswordAttempts
+			wm.MaxOTPAttempts = e.MaxOTPAttempts
 			wm.ShowLockOutFailures = e.ShowLockOutFailures
+			wm.LockoutDuration = e.LockoutDuration
+			wm.AttemptResetWindow = e.AttemptResetWindow
+			wm.ProgressiveLockout = e.ProgressiveLockout
 			wm.State = domain.PolicyStateActive
 		case *policy.LockoutPolicyChangedEvent:
 			if e.MaxPasswordAttempts != nil {
 				wm.MaxPasswordAttempts = *e.MaxPasswordAttempts
 			}
+			if e.MaxOTPAttempts != nil {
+				wm.MaxOTPAttempts = *e.MaxOTPAttempts
+			}
 			if e.ShowLockOutFailures != nil {
 				wm.ShowLockOutFailures = *e.ShowLockOutFailures
 			}
+			if e.LockoutDuration != nil {
+				wm.LockoutDuration = *e.LockoutDuration
+			}
+			if e.AttemptResetWindow != nil {
+				wm.AttemptResetWindow = *e.AttemptResetWindow
+			}
+			if e.ProgressiveLockout != nil {
+				wm.ProgressiveLockout = *e.ProgressiveLockout
+			}
 		case *policy.LockoutPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
+
+func (wm *LockoutPolicyWriteModel) changes(lockoutPolicy *domain.LockoutPolicy) []policy.LockoutPolicyChanges {
+	changes := make([]policy.LockoutPolicyChanges, 0)
+	if wm.MaxPasswordAttempts != lockoutPolicy.MaxPasswordAttempts {
+		changes = append(changes, policy.ChangeMaxAttempts(lockoutPolicy.MaxPasswordAttempts))
+	}
+	if wm.MaxOTPAttempts != lockoutPolicy.MaxOTPAttempts {
+		changes = append(changes, policy.ChangeMaxOTPAttempts(lockoutPolicy.MaxOTPAttempts))
+	}
+	if wm.ShowLockOutFailures != lockoutPolicy.ShowLockOutFailures {
+		changes = append(changes, policy.ChangeShowLockOutFailures(lockoutPolicy.ShowLockOutFailures))
+	}
+	if wm.LockoutDuration != lockoutPolicy.LockoutDuration {
+		changes = append(changes, policy.ChangeLockoutDuration(lockoutPolicy.LockoutDuration))
+	}
+	if wm.AttemptResetWindow != lockoutPolicy.AttemptResetWindow {
+		changes = append(changes, policy.ChangeAttemptResetWindow(lockoutPolicy.AttemptResetWindow))
+	}
+	if wm.ProgressiveLockout != lockoutPolicy.ProgressiveLockout {
+		changes = append(changes, policy.ChangeProgressiveLockout(lockoutPolicy.ProgressiveLockout))
+	}
+	return changes
+}
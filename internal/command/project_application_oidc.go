@@ -34,6 +34,9 @@ type addOIDCApp struct {
 	ClockSkew                   time.Duration
 	AdditionalOrigins           []string
 	SkipSuccessPageForNativeApp bool
+	BackChannelLogoutURI        string
+	RequirePushedAuthRequests   bool
+	RequireDPoP                 bool
 
 	ClientID          string
 	ClientSecret      *crypto.CryptoValue
@@ -61,6 +64,10 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 			}
 		}
 
+		if !domain.IsBackChannelLogoutURIValid(app.BackChannelLogoutURI) {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-Bq8lo", "Errors.Invalid.Argument")
+		}
+
 		if !domain.ContainsRequiredGrantTypes(app.ResponseTypes, app.GrantTypes) {
 			return nil, errors.ThrowInvalidArgument(nil, "V2-sLpW1", "Errors.Invalid.Argument")
 		}
@@ -112,6 +119,9 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 					app.ClockSkew,
 					app.AdditionalOrigins,
 					app.SkipSuccessPageForNativeApp,
+					app.BackChannelLogoutURI,
+					app.RequirePushedAuthRequests,
+					app.RequireDPoP,
 				),
 			}, nil
 		}, nil
@@ -156,7 +166,7 @@ func (c *Commands) AddOIDCApplication(ctx context.Context, oidcApp *domain.OIDCA
 	return c.addOIDCApplicationWithID(ctx, oidcApp, resourceOwner, project, appID, appSecretGenerator)
 }
 
-func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string, project *domain.Project, appID string, appSecretGenerator crypto.Generator) (_ *domain.OIDCApp, err error) {
+func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string, project *domain.Project, appID string, appSecretGenerator crypto.Generator, additionalEvents ...eventstore.Command) (_ *domain.OIDCApp, err error) {
 
 	addedApplication := NewOIDCApplicationWriteModel(oidcApp.AggregateID, resourceOwner)
 	projectAgg := ProjectAggregateFromWriteModel(&addedApplication.WriteModel)
@@ -196,8 +206,13 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		oidcApp.ClockSkew,
 		oidcApp.AdditionalOrigins,
 		oidcApp.SkipNativeAppSuccessPage,
+		oidcApp.BackChannelLogoutURI,
+		oidcApp.RequirePushedAuthRequests,
+		oidcApp.RequireDPoP,
 	))
 
+	events = append(events, additionalEvents...)
+
 	addedApplication.AppID = oidcApp.AppID
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
@@ -248,6 +263,9 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.ClockSkew,
 		oidc.AdditionalOrigins,
 		oidc.SkipNativeAppSuccessPage,
+		oidc.BackChannelLogoutURI,
+		oidc.RequirePushedAuthRequests,
+		oidc.RequireDPoP,
 	)
 	if err != nil {
 		return nil, err
@@ -14,29 +14,33 @@ import (
 type OIDCApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	ClientSecret             *crypto.CryptoValue
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []domain.OIDCResponseType
-	GrantTypes               []domain.OIDCGrantType
-	ApplicationType          domain.OIDCApplicationType
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              domain.OIDCVersion
-	Compliance               *domain.Compliance
-	DevMode                  bool
-	AccessTokenType          domain.OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	State                    domain.AppState
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
-	oidc                     bool
+	AppID                     string
+	AppName                   string
+	ClientID                  string
+	ClientSecret              *crypto.CryptoValue
+	ClientSecretString        string
+	RedirectUris              []string
+	ResponseTypes             []domain.OIDCResponseType
+	GrantTypes                []domain.OIDCGrantType
+	ApplicationType           domain.OIDCApplicationType
+	AuthMethodType            domain.OIDCAuthMethodType
+	PostLogoutRedirectUris    []string
+	OIDCVersion               domain.OIDCVersion
+	Compliance                *domain.Compliance
+	DevMode                   bool
+	AccessTokenType           domain.OIDCTokenType
+	AccessTokenRoleAssertion  bool
+	IDTokenRoleAssertion      bool
+	IDTokenUserinfoAssertion  bool
+	ClockSkew                 time.Duration
+	State                     domain.AppState
+	AdditionalOrigins         []string
+	SkipNativeAppSuccessPage  bool
+	BackChannelLogoutURI      string
+	RequirePushedAuthRequests bool
+	RequireDPoP               bool
+	RegistrationTokenID       string
+	oidc                      bool
 }
 
 func NewOIDCApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *OIDCApplicationWriteModel {
@@ -100,6 +104,11 @@ func (wm *OIDCApplicationWriteModel) AppendEvents(events ...eventstore.Event) {
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCRegistrationTokenAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		case *project.ProjectRemovedEvent:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -132,6 +141,8 @@ func (wm *OIDCApplicationWriteModel) Reduce() error {
 			wm.appendChangeOIDCEvent(e)
 		case *project.OIDCConfigSecretChangedEvent:
 			wm.ClientSecret = e.ClientSecret
+		case *project.OIDCRegistrationTokenAddedEvent:
+			wm.RegistrationTokenID = e.TokenID
 		case *project.ProjectRemovedEvent:
 			wm.State = domain.AppStateRemoved
 		}
@@ -158,6 +169,9 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
 	wm.SkipNativeAppSuccessPage = e.SkipNativeAppSuccessPage
+	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
+	wm.RequirePushedAuthRequests = e.RequirePushedAuthRequests
+	wm.RequireDPoP = e.RequireDPoP
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -206,6 +220,15 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.SkipNativeAppSuccessPage != nil {
 		wm.SkipNativeAppSuccessPage = *e.SkipNativeAppSuccessPage
 	}
+	if e.BackChannelLogoutURI != nil {
+		wm.BackChannelLogoutURI = *e.BackChannelLogoutURI
+	}
+	if e.RequirePushedAuthRequests != nil {
+		wm.RequirePushedAuthRequests = *e.RequirePushedAuthRequests
+	}
+	if e.RequireDPoP != nil {
+		wm.RequireDPoP = *e.RequireDPoP
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -223,6 +246,7 @@ func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
 			project.OIDCConfigAddedType,
 			project.OIDCConfigChangedType,
 			project.OIDCConfigSecretChangedType,
+			project.OIDCRegistrationTokenAddedType,
 			project.ProjectRemovedType).
 		Builder()
 }
@@ -246,6 +270,9 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	backChannelLogoutURI string,
+	requirePushedAuthRequests bool,
+	requireDPoP bool,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -295,6 +322,15 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.SkipNativeAppSuccessPage != skipNativeAppSuccessPage {
 		changes = append(changes, project.ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage))
 	}
+	if wm.BackChannelLogoutURI != backChannelLogoutURI {
+		changes = append(changes, project.ChangeBackChannelLogoutURI(backChannelLogoutURI))
+	}
+	if wm.RequirePushedAuthRequests != requirePushedAuthRequests {
+		changes = append(changes, project.ChangeRequirePushedAuthRequests(requirePushedAuthRequests))
+	}
+	if wm.RequireDPoP != requireDPoP {
+		changes = append(changes, project.ChangeRequireDPoP(requireDPoP))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -82,6 +82,29 @@ func TestAddOIDCApp(t *testing.T) {
 				ValidationErr: errors.ThrowInvalidArgument(nil, "PROJE-Fef31", "Errors.Invalid.Argument"),
 			},
 		},
+		{
+			name:   "invalid back-channel logout uri",
+			fields: fields{},
+			args: args{
+				app: &addOIDCApp{
+					AddApp: AddApp{
+						Aggregate: *agg,
+						ID:        "id",
+						Name:      "name",
+					},
+					GrantTypes:           []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ResponseTypes:        []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					Version:              domain.OIDCVersionV1,
+					ApplicationType:      domain.OIDCApplicationTypeWeb,
+					AuthMethodType:       domain.OIDCAuthMethodTypeNone,
+					AccessTokenType:      domain.OIDCTokenTypeBearer,
+					BackChannelLogoutURI: "https://app.example.com/logout#fragment",
+				},
+			},
+			want: Want{
+				ValidationErr: errors.ThrowInvalidArgument(nil, "V2-Bq8lo", "Errors.Invalid.Argument"),
+			},
+		},
 		{
 			name:   "project not exists",
 			fields: fields{},
@@ -170,6 +193,9 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						"",
+						false,
+						false,
 					),
 				},
 			},
@@ -328,6 +354,9 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 									time.Second*1,
 									[]string{"https://sub.test.ch"},
 									true,
+									"",
+									false,
+									false,
 								),
 							),
 						},
@@ -565,6 +594,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								"",
+								false,
+								false,
 							),
 						),
 					),
@@ -639,6 +671,9 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								"",
+								false,
+								false,
 							),
 						),
 					),
@@ -840,6 +875,9 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								"",
+								false,
+								false,
 							),
 						),
 					),
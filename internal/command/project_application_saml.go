@@ -76,6 +76,8 @@ func (c *Commands) addSAMLApplication(ctx context.Context, projectAgg *eventstor
 			string(entity.EntityID),
 			samlApp.Metadata,
 			samlApp.MetadataURL,
+			samlApp.RoleAssertion,
+			samlApp.MetadataAssertion,
 		),
 	}, nil
 }
@@ -116,7 +118,10 @@ func (c *Commands) ChangeSAMLApplication(ctx context.Context, samlApp *domain.SA
 		samlApp.AppID,
 		string(entity.EntityID),
 		samlApp.Metadata,
-		samlApp.MetadataURL)
+		samlApp.MetadataURL,
+		samlApp.RoleAssertion,
+		samlApp.MetadataAssertion,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +18,9 @@ type SAMLApplicationWriteModel struct {
 	Metadata    []byte
 	MetadataURL string
 
+	RoleAssertion     bool
+	MetadataAssertion bool
+
 	State domain.AppState
 	saml  bool
 }
@@ -121,6 +124,8 @@ func (wm *SAMLApplicationWriteModel) appendAddSAMLEvent(e *project.SAMLConfigAdd
 	wm.Metadata = e.Metadata
 	wm.MetadataURL = e.MetadataURL
 	wm.EntityID = e.EntityID
+	wm.RoleAssertion = e.RoleAssertion
+	wm.MetadataAssertion = e.MetadataAssertion
 }
 
 func (wm *SAMLApplicationWriteModel) appendChangeSAMLEvent(e *project.SAMLConfigChangedEvent) {
@@ -134,6 +139,12 @@ func (wm *SAMLApplicationWriteModel) appendChangeSAMLEvent(e *project.SAMLConfig
 	if e.EntityID != "" {
 		wm.EntityID = e.EntityID
 	}
+	if e.RoleAssertion != nil {
+		wm.RoleAssertion = *e.RoleAssertion
+	}
+	if e.MetadataAssertion != nil {
+		wm.MetadataAssertion = *e.MetadataAssertion
+	}
 }
 
 func (wm *SAMLApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -161,6 +172,8 @@ func (wm *SAMLApplicationWriteModel) NewChangedEvent(
 	entityID string,
 	metadata []byte,
 	metadataURL string,
+	roleAssertion,
+	metadataAssertion bool,
 ) (*project.SAMLConfigChangedEvent, bool, error) {
 	changes := make([]project.SAMLConfigChanges, 0)
 	var err error
@@ -173,6 +186,12 @@ func (wm *SAMLApplicationWriteModel) NewChangedEvent(
 	if wm.EntityID != entityID {
 		changes = append(changes, project.ChangeEntityID(entityID))
 	}
+	if wm.RoleAssertion != roleAssertion {
+		changes = append(changes, project.ChangeRoleAssertion(roleAssertion))
+	}
+	if wm.MetadataAssertion != metadataAssertion {
+		changes = append(changes, project.ChangeMetadataAssertion(metadataAssertion))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
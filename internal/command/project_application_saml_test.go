@@ -200,6 +200,8 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 									"https://test.com/saml/metadata",
 									testMetadata,
 									"",
+									false,
+									false,
 								),
 							),
 						},
@@ -237,6 +239,78 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "create saml app with role and metadata assertion, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewApplicationAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									"app",
+								),
+							),
+							eventFromEventPusher(
+								project.NewSAMLConfigAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									"https://test.com/saml/metadata",
+									testMetadata,
+									"",
+									true,
+									true,
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("app", "project1")),
+						uniqueConstraintsFromEventConstraint(project.NewAddSAMLConfigEntityIDUniqueConstraint("https://test.com/saml/metadata")),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "app1"),
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppName:           "app",
+					EntityID:          "https://test.com/saml/metadata",
+					Metadata:          testMetadata,
+					MetadataURL:       "",
+					RoleAssertion:     true,
+					MetadataAssertion: true,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:             "app1",
+					AppName:           "app",
+					EntityID:          "https://test.com/saml/metadata",
+					Metadata:          testMetadata,
+					MetadataURL:       "",
+					RoleAssertion:     true,
+					MetadataAssertion: true,
+					State:             domain.AppStateActive,
+				},
+			},
+		},
 		{
 			name: "create saml app metadataURL, ok",
 			fields: fields{
@@ -266,6 +340,8 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 									"https://test.com/saml/metadata",
 									testMetadata,
 									"http://localhost:8080/saml/metadata",
+									false,
+									false,
 								),
 							),
 						},
@@ -490,6 +566,8 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"http://localhost:8080/saml/metadata",
+								false,
+								false,
 							),
 						),
 					),
@@ -535,6 +613,8 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"",
+								false,
+								false,
 							),
 						),
 					),
@@ -580,6 +660,8 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"http://localhost:8080/saml/metadata",
+								false,
+								false,
 							),
 						),
 					),
@@ -652,6 +734,8 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"",
+								false,
+								false,
 							),
 						),
 					),
@@ -704,6 +788,82 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "change saml app, ok, role and metadata assertion",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSAMLConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://test.com/saml/metadata",
+								testMetadata,
+								"",
+								false,
+								false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newSAMLAppChangedEventAssertions(context.Background(),
+									"app1",
+									"project1",
+									"org1",
+									"https://test.com/saml/metadata",
+									true,
+									true,
+								),
+							),
+						},
+					),
+				),
+				httpClient: nil,
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:             "app1",
+					AppName:           "app",
+					EntityID:          "https://test.com/saml/metadata",
+					Metadata:          testMetadata,
+					MetadataURL:       "",
+					RoleAssertion:     true,
+					MetadataAssertion: true,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:             "app1",
+					AppName:           "app",
+					EntityID:          "https://test.com/saml/metadata",
+					Metadata:          testMetadata,
+					MetadataURL:       "",
+					RoleAssertion:     true,
+					MetadataAssertion: true,
+					State:             domain.AppStateActive,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -754,6 +914,20 @@ func newSAMLAppChangedEventMetadataURL(ctx context.Context, appID, projectID, re
 	return event
 }
 
+func newSAMLAppChangedEventAssertions(ctx context.Context, appID, projectID, resourceOwner, entityID string, roleAssertion, metadataAssertion bool) *project.SAMLConfigChangedEvent {
+	changes := []project.SAMLConfigChanges{
+		project.ChangeRoleAssertion(roleAssertion),
+		project.ChangeMetadataAssertion(metadataAssertion),
+	}
+	event, _ := project.NewSAMLConfigChangedEvent(ctx,
+		&project.NewAggregate(projectID, resourceOwner).Aggregate,
+		appID,
+		entityID,
+		changes,
+	)
+	return event
+}
+
 type roundTripperFunc func(*http.Request) *http.Response
 
 // RoundTrip implements the http.RoundTripper interface.
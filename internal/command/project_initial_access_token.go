@@ -0,0 +1,219 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	project_repo "github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// InitialAccessToken authorizes the dynamic client registration (RFC 7591) of oidc applications in a project
+type InitialAccessToken struct {
+	models.ObjectRoot
+
+	ExpirationDate time.Time
+
+	TokenID string
+	Token   string
+}
+
+func NewInitialAccessToken(resourceOwner, projectID string, expirationDate time.Time) *InitialAccessToken {
+	return &InitialAccessToken{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		ExpirationDate: expirationDate,
+	}
+}
+
+func (c *Commands) AddInitialAccessToken(ctx context.Context, token *InitialAccessToken) (_ *domain.ObjectDetails, err error) {
+	if token.AggregateID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Vb3wq", "Errors.Project.ProjectIDMissing")
+	}
+	token.ExpirationDate, err = domain.ValidateExpirationDate(token.ExpirationDate)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkProjectExists(ctx, token.AggregateID, token.ResourceOwner); err != nil {
+		return nil, err
+	}
+	token.TokenID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	token.Token, err = createToken(c.keyAlgorithm, token.TokenID, token.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+	writeModel := NewInitialAccessTokenWriteModel(token.AggregateID, token.TokenID, token.ResourceOwner)
+	pushedEvents, err := c.eventstore.Push(ctx, project_repo.NewInitialAccessTokenAddedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		token.TokenID,
+		token.ExpirationDate,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveInitialAccessToken(ctx context.Context, projectID, tokenID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if projectID == "" || tokenID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ks9fe", "Errors.IDMissing")
+	}
+	writeModel, err := c.getInitialAccessTokenWriteModel(ctx, projectID, tokenID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Px4nc", "Errors.Project.InitialAccessToken.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project_repo.NewInitialAccessTokenRemovedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		tokenID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RegisterOIDCApplication adds an oidc application by dynamic client registration (RFC 7591)
+// to the project the initial access token was issued for.
+// The returned registration access token is needed to read, update and delete the application (RFC 7592).
+func (c *Commands) RegisterOIDCApplication(ctx context.Context, initialAccessToken string, oidcApp *domain.OIDCApp, appSecretGenerator crypto.Generator) (_ *domain.OIDCApp, registrationToken string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	token, err := c.verifyInitialAccessToken(ctx, initialAccessToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if oidcApp == nil {
+		return nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-Xk2vd", "Errors.Project.App.Invalid")
+	}
+	oidcApp.AggregateID = token.AggregateID
+	project, err := c.getProjectByID(ctx, oidcApp.AggregateID, token.ResourceOwner)
+	if err != nil {
+		return nil, "", errors.ThrowPreconditionFailed(err, "COMMAND-Ry5wb", "Errors.Project.NotFound")
+	}
+	if oidcApp.AppName == "" || !oidcApp.IsValid() {
+		return nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-Fe4qa", "Errors.Project.App.Invalid")
+	}
+	appID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	tokenID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	registrationToken, err = createToken(c.keyAlgorithm, tokenID, appID)
+	if err != nil {
+		return nil, "", err
+	}
+	// the registration token is pushed together with the application,
+	// so there's no application without registration token
+	app, err := c.addOIDCApplicationWithID(ctx, oidcApp, token.ResourceOwner, project, appID, appSecretGenerator,
+		project_repo.NewOIDCRegistrationTokenAddedEvent(
+			ctx,
+			&project_repo.NewAggregate(token.AggregateID, token.ResourceOwner).Aggregate,
+			appID,
+			tokenID,
+		),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return app, registrationToken, nil
+}
+
+// VerifyInitialAccessToken checks the initial access token of the dynamic client registration (RFC 7591),
+// so the request can be authenticated before its client metadata is read
+func (c *Commands) VerifyInitialAccessToken(ctx context.Context, initialAccessToken string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	_, err = c.verifyInitialAccessToken(ctx, initialAccessToken)
+	return err
+}
+
+// VerifyOIDCRegistrationToken checks the registration access token (RFC 7592)
+// of an oidc application added by dynamic client registration
+func (c *Commands) VerifyOIDCRegistrationToken(ctx context.Context, projectID, appID, registrationToken string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	tokenID, tokenAppID, err := parseToken(c.keyAlgorithm, registrationToken)
+	if err != nil || tokenAppID != appID {
+		return errors.ThrowUnauthenticated(err, "COMMAND-Ld8ea", "Errors.Project.App.RegistrationTokenInvalid")
+	}
+	app, err := c.getOIDCAppWriteModel(ctx, projectID, appID, "")
+	if err != nil {
+		return err
+	}
+	if !app.State.Exists() {
+		return errors.ThrowNotFound(nil, "COMMAND-Tg2bx", "Errors.Project.App.NotExisting")
+	}
+	if app.RegistrationTokenID == "" || app.RegistrationTokenID != tokenID {
+		return errors.ThrowUnauthenticated(nil, "COMMAND-Ch6ur", "Errors.Project.App.RegistrationTokenInvalid")
+	}
+	return nil
+}
+
+func (c *Commands) verifyInitialAccessToken(ctx context.Context, initialAccessToken string) (*InitialAccessTokenWriteModel, error) {
+	tokenID, projectID, err := parseToken(c.keyAlgorithm, initialAccessToken)
+	if err != nil {
+		return nil, errors.ThrowUnauthenticated(err, "COMMAND-Zn1ok", "Errors.Project.InitialAccessToken.Invalid")
+	}
+	writeModel, err := c.getInitialAccessTokenWriteModel(ctx, projectID, tokenID, "")
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists() || !writeModel.ExpirationDate.After(time.Now()) {
+		return nil, errors.ThrowUnauthenticated(nil, "COMMAND-Qe7yh", "Errors.Project.InitialAccessToken.Invalid")
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) getInitialAccessTokenWriteModel(ctx context.Context, projectID, tokenID, resourceOwner string) (*InitialAccessTokenWriteModel, error) {
+	writeModel := NewInitialAccessTokenWriteModel(projectID, tokenID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// parseToken returns the token and aggregate id of a token created by [createToken]
+func parseToken(algorithm crypto.EncryptionAlgorithm, token string) (tokenID, aggregateID string, err error) {
+	encrypted, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", err
+	}
+	decrypted, err := algorithm.DecryptString(encrypted, algorithm.EncryptionKeyID())
+	if err != nil {
+		return "", "", err
+	}
+	tokenID, aggregateID, ok := strings.Cut(decrypted, ":")
+	if !ok || tokenID == "" || aggregateID == "" {
+		return "", "", errors.ThrowInvalidArgument(nil, "COMMAND-Fj3ni", "invalid token")
+	}
+	return tokenID, aggregateID, nil
+}
@@ -0,0 +1,631 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommands_AddInitialAccessToken(t *testing.T) {
+	expiration := time.Now().Add(time.Hour).UTC()
+	type fields struct {
+		eventstore   *eventstore.Eventstore
+		idGenerator  id.Generator
+		keyAlgorithm crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx   context.Context
+		token *InitialAccessToken
+	}
+	type res struct {
+		want  *domain.ObjectDetails
+		token string
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing project id, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: NewInitialAccessToken("org1", "", expiration),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "expiration in the past, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: NewInitialAccessToken("org1", "project1", time.Now().Add(-time.Hour)),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: NewInitialAccessToken("org1", "project1", expiration),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "token added",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewInitialAccessTokenAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"token1",
+									expiration,
+								),
+							),
+						},
+					),
+				),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "token1"),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:   context.Background(),
+				token: NewInitialAccessToken("org1", "project1", expiration),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+				token: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore,
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: tt.fields.keyAlgorithm,
+			}
+			got, err := c.AddInitialAccessToken(tt.args.ctx, tt.args.token)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+				assert.Equal(t, tt.res.token, tt.args.token.Token)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveInitialAccessToken(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		tokenID       string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "token not existing, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "token removed",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(time.Hour),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewInitialAccessTokenRemovedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.RemoveInitialAccessToken(tt.args.ctx, tt.args.projectID, tt.args.tokenID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RegisterOIDCApplication(t *testing.T) {
+	type fields struct {
+		eventstore   *eventstore.Eventstore
+		idGenerator  id.Generator
+		keyAlgorithm crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx                context.Context
+		initialAccessToken string
+		oidcApp            *domain.OIDCApp
+	}
+	type res struct {
+		want              *domain.OIDCApp
+		registrationToken string
+		err               func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid initial access token, error",
+			fields: fields{
+				eventstore:   eventstoreExpect(t),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:                context.Background(),
+				initialAccessToken: "invalid",
+				oidcApp:            &domain.OIDCApp{},
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "initial access token removed, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(time.Hour),
+							),
+						),
+						eventFromEventPusher(
+							project.NewInitialAccessTokenRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+							),
+						),
+					),
+				),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:                context.Background(),
+				initialAccessToken: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+				oidcApp:            &domain.OIDCApp{},
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "initial access token expired, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(-time.Hour),
+							),
+						),
+					),
+				),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:                context.Background(),
+				initialAccessToken: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+				oidcApp:            &domain.OIDCApp{},
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "app registered",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								time.Now().Add(time.Hour),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewApplicationAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									"app",
+								),
+							),
+							eventFromEventPusher(
+								project.NewOIDCConfigAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									domain.OIDCVersionV1,
+									"app1",
+									"client1@project",
+									nil,
+									[]string{"https://test.ch"},
+									[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+									[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+									domain.OIDCApplicationTypeNative,
+									domain.OIDCAuthMethodTypeNone,
+									nil,
+									false,
+									domain.OIDCTokenTypeBearer,
+									false,
+									false,
+									false,
+									0,
+									nil,
+									false,
+									"",
+									false,
+									true,
+								),
+							),
+							eventFromEventPusher(
+								project.NewOIDCRegistrationTokenAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									"token2",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("app", "project1")),
+					),
+				),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "app1", "token2", "client1"),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:                context.Background(),
+				initialAccessToken: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+				oidcApp: &domain.OIDCApp{
+					AppName:         "app",
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					OIDCVersion:     domain.OIDCVersionV1,
+					RedirectUris:    []string{"https://test.ch"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType: domain.OIDCApplicationTypeNative,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+					RequireDPoP:     true,
+				},
+			},
+			res: res{
+				want: &domain.OIDCApp{
+					AppID:           "app1",
+					ClientID:        "client1@project",
+					AppName:         "app",
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					OIDCVersion:     domain.OIDCVersionV1,
+					RedirectUris:    []string{"https://test.ch"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType: domain.OIDCApplicationTypeNative,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+					RequireDPoP:     true,
+					State:           domain.AppStateActive,
+				},
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token2:app1")),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore,
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: tt.fields.keyAlgorithm,
+			}
+			got, registrationToken, err := c.RegisterOIDCApplication(tt.args.ctx, tt.args.initialAccessToken, tt.args.oidcApp, nil)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, "project1", got.AggregateID)
+				assert.Equal(t, "org1", got.ResourceOwner)
+				assert.Equal(t, tt.res.want.AppID, got.AppID)
+				assert.Equal(t, tt.res.want.ClientID, got.ClientID)
+				assert.Equal(t, tt.res.want.RequireDPoP, got.RequireDPoP)
+				assert.Equal(t, tt.res.want.State, got.State)
+				assert.Equal(t, tt.res.registrationToken, registrationToken)
+			}
+		})
+	}
+}
+
+func TestCommands_VerifyInitialAccessToken(t *testing.T) {
+	tests := []struct {
+		name               string
+		eventstore         *eventstore.Eventstore
+		initialAccessToken string
+		wantErr            func(error) bool
+	}{
+		{
+			name:               "invalid initial access token, error",
+			eventstore:         eventstoreExpect(t),
+			initialAccessToken: "invalid",
+			wantErr:            caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "initial access token not existing, error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(),
+			),
+			initialAccessToken: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+			wantErr:            caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "valid initial access token",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusher(
+						project.NewInitialAccessTokenAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"token1",
+							time.Now().Add(time.Hour),
+						),
+					),
+				),
+			),
+			initialAccessToken: base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.eventstore,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := c.VerifyInitialAccessToken(context.Background(), tt.initialAccessToken)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCommands_VerifyOIDCRegistrationToken(t *testing.T) {
+	appEvents := func(registered bool) []expect {
+		events := []*repository.Event{
+			eventFromEventPusher(
+				project.NewApplicationAddedEvent(context.Background(),
+					&project.NewAggregate("project1", "org1").Aggregate,
+					"app1",
+					"app",
+				),
+			),
+		}
+		if registered {
+			events = append(events, eventFromEventPusher(
+				project.NewOIDCRegistrationTokenAddedEvent(context.Background(),
+					&project.NewAggregate("project1", "org1").Aggregate,
+					"app1",
+					"token1",
+				),
+			))
+		}
+		return []expect{expectFilter(events...)}
+	}
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		registrationToken string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr func(error) bool
+	}{
+		{
+			name: "invalid token, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				registrationToken: "invalid",
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "token of other app, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token1:app2")),
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "app not registered, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t, appEvents(false)...),
+			},
+			args: args{
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token1:app1")),
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "other token, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t, appEvents(true)...),
+			},
+			args: args{
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token2:app1")),
+			},
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+		{
+			name: "valid token",
+			fields: fields{
+				eventstore: eventstoreExpect(t, appEvents(true)...),
+			},
+			args: args{
+				registrationToken: base64.RawURLEncoding.EncodeToString([]byte("token1:app1")),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := c.VerifyOIDCRegistrationToken(context.Background(), "project1", "app1", tt.args.registrationToken)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			if !tt.wantErr(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
@@ -19,6 +19,9 @@ type QuotaUnit string
 const (
 	QuotaRequestsAllAuthenticated QuotaUnit = "requests.all.authenticated"
 	QuotaActionsAllRunsSeconds    QuotaUnit = "actions.all.runs.seconds"
+	QuotaRequestsTokenEndpoint    QuotaUnit = "requests.token.endpoint"
+	QuotaRequestsManagementAPI    QuotaUnit = "requests.management.authenticated"
+	QuotaNotificationsAllSent     QuotaUnit = "notifications.all.sent"
 )
 
 func (q *QuotaUnit) Enum() quota.Unit {
@@ -27,6 +30,12 @@ func (q *QuotaUnit) Enum() quota.Unit {
 		return quota.RequestsAllAuthenticated
 	case QuotaActionsAllRunsSeconds:
 		return quota.ActionsAllRunsSeconds
+	case QuotaRequestsTokenEndpoint:
+		return quota.RequestsTokenEndpoint
+	case QuotaRequestsManagementAPI:
+		return quota.RequestsManagementAPI
+	case QuotaNotificationsAllSent:
+		return quota.NotificationsAllSent
 	default:
 		return quota.Unimplemented
 	}
@@ -82,9 +91,11 @@ func (c *Commands) RemoveQuota(ctx context.Context, unit QuotaUnit) (*domain.Obj
 
 	aggregate := quota.NewAggregate(wm.AggregateID, instanceId, instanceId)
 
-	events := []eventstore.Command{
-		quota.NewRemovedEvent(ctx, &aggregate.Aggregate, unit.Enum()),
+	events := make([]eventstore.Command, 0, 2)
+	if wm.degraded {
+		events = append(events, quota.NewDegradedModeLeftEvent(ctx, &aggregate.Aggregate, unit.Enum(), wm.degradedPeriodStart))
 	}
+	events = append(events, quota.NewRemovedEvent(ctx, &aggregate.Aggregate, unit.Enum()))
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
 		return nil, err
@@ -139,6 +150,8 @@ type AddQuota struct {
 	ResetInterval time.Duration
 	Amount        uint64
 	Limit         bool
+	GracePercent  uint16
+	GracePeriod   time.Duration
 	Notifications QuotaNotifications
 }
 
@@ -174,6 +187,14 @@ func (q *AddQuota) validate() error {
 		return errors.ThrowInvalidArgument(nil, "QUOTA-4Nv68", "Errors.Quota.Invalid.Noop")
 	}
 
+	if !q.Limit && (q.GracePercent > 0 || q.GracePeriod > 0) {
+		return errors.ThrowInvalidArgument(nil, "QUOTA-Yeo3a", "Errors.Quota.Invalid.Grace")
+	}
+
+	if q.GracePeriod < 0 {
+		return errors.ThrowInvalidArgument(nil, "QUOTA-ku9Ei", "Errors.Quota.Invalid.Grace")
+	}
+
 	return nil
 }
 
@@ -199,6 +220,8 @@ func (c *Commands) AddQuotaCommand(a *quota.Aggregate, q *AddQuota) preparation.
 					q.ResetInterval,
 					q.Amount,
 					q.Limit,
+					q.GracePercent,
+					q.GracePeriod,
 					notifications,
 				)}, err
 			},
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 )
@@ -9,6 +11,9 @@ type quotaWriteModel struct {
 	eventstore.WriteModel
 	unit   quota.Unit
 	active bool
+
+	degraded            bool
+	degradedPeriodStart time.Time
 }
 
 // newQuotaWriteModel aggregateId is filled by reducing unit matching events
@@ -31,6 +36,8 @@ func (wm *quotaWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(
 			quota.AddedEventType,
 			quota.RemovedEventType,
+			quota.DegradedModeEnteredEventType,
+			quota.DegradedModeLeftEventType,
 		).EventData(map[string]interface{}{"unit": wm.unit})
 
 	return query.Builder()
@@ -45,6 +52,12 @@ func (wm *quotaWriteModel) Reduce() error {
 		case *quota.RemovedEvent:
 			wm.AggregateID = e.Aggregate().ID
 			wm.active = false
+			wm.degraded = false
+		case *quota.DegradedModeEnteredEvent:
+			wm.degraded = true
+			wm.degradedPeriodStart = e.PeriodStart
+		case *quota.DegradedModeLeftEvent:
+			wm.degraded = false
 		}
 	}
 	return wm.WriteModel.Reduce()
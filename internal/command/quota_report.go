@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/quota"
@@ -29,3 +30,25 @@ func (c *Commands) UsageNotificationSent(ctx context.Context, dueEvent *quota.No
 	)
 	return err
 }
+
+// ReportQuotaDegradation pushes an event if the instance enters or leaves the degraded mode of the quota of the unit
+func (c *Commands) ReportQuotaDegradation(ctx context.Context, instanceID string, unit quota.Unit, periodStart time.Time, usage uint64, degraded bool) error {
+	wm, err := c.getQuotaWriteModel(ctx, instanceID, instanceID, unit)
+	if err != nil {
+		return err
+	}
+	if !wm.active {
+		return nil
+	}
+	aggregate := quota.NewAggregate(wm.AggregateID, instanceID, instanceID)
+	var cmd eventstore.Command
+	switch {
+	case degraded && (!wm.degraded || !wm.degradedPeriodStart.Equal(periodStart)):
+		cmd = quota.NewDegradedModeEnteredEvent(ctx, &aggregate.Aggregate, unit, periodStart, usage)
+	case !degraded && wm.degraded:
+		cmd = quota.NewDegradedModeLeftEvent(ctx, &aggregate.Aggregate, unit, wm.degradedPeriodStart)
+	default:
+		return nil
+	}
+	return c.pushAppendAndReduce(ctx, wm, cmd)
+}
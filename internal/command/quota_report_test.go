@@ -0,0 +1,140 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+func TestCommands_ReportQuotaDegradation(t *testing.T) {
+	periodStart := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	aggregate := &quota.NewAggregate("quota1", "instance1", "instance1").Aggregate
+	quotaAdded := func() *repository.Event {
+		return eventFromEventPusherWithInstanceID("instance1",
+			quota.NewAddedEvent(context.Background(), aggregate, quota.RequestsAllAuthenticated, periodStart, time.Hour, 100, true, 10, 0, nil),
+		)
+	}
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		periodStart time.Time
+		usage       uint64
+		degraded    bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "no quota, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				periodStart: periodStart,
+				usage:       101,
+				degraded:    true,
+			},
+		},
+		{
+			name: "enter degraded mode, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						quotaAdded(),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								quota.NewDegradedModeEnteredEvent(context.Background(), aggregate, quota.RequestsAllAuthenticated, periodStart, 101),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				periodStart: periodStart,
+				usage:       101,
+				degraded:    true,
+			},
+		},
+		{
+			name: "already degraded in period, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						quotaAdded(),
+						eventFromEventPusherWithInstanceID("instance1",
+							quota.NewDegradedModeEnteredEvent(context.Background(), aggregate, quota.RequestsAllAuthenticated, periodStart, 101),
+						),
+					),
+				),
+			},
+			args: args{
+				periodStart: periodStart,
+				usage:       150,
+				degraded:    true,
+			},
+		},
+		{
+			name: "leave degraded mode, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						quotaAdded(),
+						eventFromEventPusherWithInstanceID("instance1",
+							quota.NewDegradedModeEnteredEvent(context.Background(), aggregate, quota.RequestsAllAuthenticated, periodStart, 101),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								quota.NewDegradedModeLeftEvent(context.Background(), aggregate, quota.RequestsAllAuthenticated, periodStart),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				periodStart: periodStart.Add(time.Hour),
+				usage:       1,
+				degraded:    false,
+			},
+		},
+		{
+			name: "not degraded, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						quotaAdded(),
+					),
+				),
+			},
+			args: args{
+				periodStart: periodStart,
+				usage:       1,
+				degraded:    false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := c.ReportQuotaDegradation(context.Background(), "instance1", quota.RequestsAllAuthenticated, tt.args.periodStart, tt.args.usage, tt.args.degraded)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
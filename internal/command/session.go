@@ -31,8 +31,13 @@ type SessionCommands struct {
 
 	hasher      *crypto.PasswordHasher
 	intentAlg   crypto.EncryptionAlgorithm
+	totpAlg     crypto.EncryptionAlgorithm
+	otpAlg      crypto.EncryptionAlgorithm
+	createCode  cryptoCodeFunc
 	createToken func(sessionID string) (id string, token string, err error)
 	now         func() time.Time
+
+	getLockoutPolicy func(ctx context.Context, orgID string) (*domain.LockoutPolicy, error)
 }
 
 func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWriteModel) *SessionCommands {
@@ -42,8 +47,12 @@ func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWri
 		eventstore:        c.eventstore,
 		hasher:            c.userPasswordHasher,
 		intentAlg:         c.idpConfigEncryption,
+		totpAlg:           c.multifactors.OTP.CryptoMFA,
+		otpAlg:            c.userEncryption,
+		createCode:        c.newCode,
 		createToken:       c.sessionTokenCreator,
 		now:               time.Now,
+		getLockoutPolicy:  c.getLockoutPolicy,
 	}
 }
 
@@ -175,6 +184,26 @@ func (s *SessionCommands) WebAuthNChecked(ctx context.Context, checkedAt time.Ti
 	}
 }
 
+func (s *SessionCommands) TOTPChecked(ctx context.Context, checkedAt time.Time) {
+	s.eventCommands = append(s.eventCommands, session.NewTOTPCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
+}
+
+func (s *SessionCommands) OTPSMSChallenged(ctx context.Context, code *crypto.CryptoValue, expiry time.Duration, returnCode bool) {
+	s.eventCommands = append(s.eventCommands, session.NewOTPSMSChallengedEvent(ctx, s.sessionWriteModel.aggregate, code, expiry, returnCode))
+}
+
+func (s *SessionCommands) OTPSMSChecked(ctx context.Context, checkedAt time.Time) {
+	s.eventCommands = append(s.eventCommands, session.NewOTPSMSCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
+}
+
+func (s *SessionCommands) OTPEmailChallenged(ctx context.Context, code *crypto.CryptoValue, expiry time.Duration, returnCode bool, urlTmpl string) {
+	s.eventCommands = append(s.eventCommands, session.NewOTPEmailChallengedEvent(ctx, s.sessionWriteModel.aggregate, code, expiry, returnCode, urlTmpl))
+}
+
+func (s *SessionCommands) OTPEmailChecked(ctx context.Context, checkedAt time.Time) {
+	s.eventCommands = append(s.eventCommands, session.NewOTPEmailCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
+}
+
 func (s *SessionCommands) SetToken(ctx context.Context, tokenID string) {
 	s.eventCommands = append(s.eventCommands, session.NewTokenSetEvent(ctx, s.sessionWriteModel.aggregate, tokenID))
 }
@@ -292,6 +321,43 @@ func (c *Commands) terminateSession(ctx context.Context, sessionID, sessionToken
 	return writeModelToObjectDetails(&sessionWriteModel.WriteModel), nil
 }
 
+// BackChannelLogoutSent marks the back-channel logout of the terminated session as delivered to the client (by the notification handler)
+func (c *Commands) BackChannelLogoutSent(ctx context.Context, sessionID, resourceOwner, clientID string) error {
+	if clientID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lq3mv", "Errors.IDMissing")
+	}
+	sessionWriteModel := NewSessionWriteModel(sessionID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
+	if err != nil {
+		return err
+	}
+	if sessionWriteModel.State != domain.SessionStateTerminated {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Vd0ql", "Errors.Session.NotTerminated")
+	}
+	return c.pushAppendAndReduce(ctx, sessionWriteModel,
+		session.NewBackChannelLogoutSentEvent(ctx, &session.NewAggregate(sessionID, sessionWriteModel.ResourceOwner).Aggregate, clientID),
+	)
+}
+
+// BackChannelLogoutFailed marks the back-channel logout of the terminated session as failed for the client (by the notification handler),
+// so the delivery will be retried
+func (c *Commands) BackChannelLogoutFailed(ctx context.Context, sessionID, resourceOwner, clientID, userID string, attempt uint8) error {
+	if clientID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fm5xo", "Errors.IDMissing")
+	}
+	sessionWriteModel := NewSessionWriteModel(sessionID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
+	if err != nil {
+		return err
+	}
+	if sessionWriteModel.State != domain.SessionStateTerminated {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ot7eh", "Errors.Session.NotTerminated")
+	}
+	return c.pushAppendAndReduce(ctx, sessionWriteModel,
+		session.NewBackChannelLogoutFailedEvent(ctx, &session.NewAggregate(sessionID, sessionWriteModel.ResourceOwner).Aggregate, clientID, userID, attempt),
+	)
+}
+
 // updateSession execute the [SessionCommands] where new events will be created and as well as for metadata (changes)
 func (c *Commands) updateSession(ctx context.Context, checks *SessionCommands, metadata map[string][]byte) (set *SessionChanged, err error) {
 	if checks.sessionWriteModel.State == domain.SessionStateTerminated {
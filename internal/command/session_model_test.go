@@ -15,6 +15,9 @@ func TestSessionWriteModel_AuthMethodTypes(t *testing.T) {
 		IntentCheckedAt      time.Time
 		WebAuthNCheckedAt    time.Time
 		WebAuthNUserVerified bool
+		TOTPCheckedAt        time.Time
+		OTPSMSCheckedAt      time.Time
+		OTPEmailCheckedAt    time.Time
 	}
 	tests := []struct {
 		name   string
@@ -59,6 +62,44 @@ func TestSessionWriteModel_AuthMethodTypes(t *testing.T) {
 				domain.UserAuthMethodTypeIDP,
 			},
 		},
+		{
+			name: "totp",
+			fields: fields{
+				TOTPCheckedAt: testNow,
+			},
+			want: []domain.UserAuthMethodType{
+				domain.UserAuthMethodTypeTOTP,
+			},
+		},
+		{
+			name: "otp sms",
+			fields: fields{
+				OTPSMSCheckedAt: testNow,
+			},
+			want: []domain.UserAuthMethodType{
+				domain.UserAuthMethodTypeOTPSMS,
+			},
+		},
+		{
+			name: "otp email",
+			fields: fields{
+				OTPEmailCheckedAt: testNow,
+			},
+			want: []domain.UserAuthMethodType{
+				domain.UserAuthMethodTypeOTPEmail,
+			},
+		},
+		{
+			name: "password and otp email",
+			fields: fields{
+				PasswordCheckedAt: testNow,
+				OTPEmailCheckedAt: testNow,
+			},
+			want: []domain.UserAuthMethodType{
+				domain.UserAuthMethodTypePassword,
+				domain.UserAuthMethodTypeOTPEmail,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -67,6 +108,9 @@ func TestSessionWriteModel_AuthMethodTypes(t *testing.T) {
 				IntentCheckedAt:      tt.fields.IntentCheckedAt,
 				WebAuthNCheckedAt:    tt.fields.WebAuthNCheckedAt,
 				WebAuthNUserVerified: tt.fields.WebAuthNUserVerified,
+				TOTPCheckedAt:        tt.fields.TOTPCheckedAt,
+				OTPSMSCheckedAt:      tt.fields.OTPSMSCheckedAt,
+				OTPEmailCheckedAt:    tt.fields.OTPEmailCheckedAt,
 			}
 			got := wm.AuthMethodTypes()
 			assert.Equal(t, got, tt.want)
@@ -0,0 +1,240 @@
+package command
+
+import (
+	"context"
+	"io"
+
+	"github.com/zitadel/logging"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// CheckTOTP defines a TOTP code check to be executed for a session update
+func CheckTOTP(code string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Neil7", "Errors.User.UserIDMissing")
+		}
+		totpWriteModel := NewHumanTOTPWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, totpWriteModel); err != nil {
+			return err
+		}
+		if totpWriteModel.State != domain.MFAStateReady {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-eej1U", "Errors.User.MFA.OTP.NotReady")
+		}
+		if totpWriteModel.IsLocked(cmd.now()) {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hq7vk", "Errors.User.Locked")
+		}
+		userAgg := UserAggregateFromWriteModel(&totpWriteModel.WriteModel)
+		if err := domain.VerifyTOTP(code, totpWriteModel.Secret, cmd.totpAlg); err != nil {
+			return cmd.otpCheckFailed(ctx, &totpWriteModel.userLockoutState, userAgg, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, nil), err)
+		}
+		cmd.otpCheckSucceeded(&totpWriteModel.userLockoutState, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, nil))
+		cmd.TOTPChecked(ctx, cmd.now())
+		return nil
+	}
+}
+
+// CreateOTPSMSChallengeReturnCode creates an OTP SMS challenge for the session
+// and writes the plain code into dst instead of sending it to the user.
+func CreateOTPSMSChallengeReturnCode(dst *string) SessionCommand {
+	return createOTPSMSChallenge(true, dst)
+}
+
+// CreateOTPSMSChallenge creates an OTP SMS challenge for the session,
+// the code will be sent to the verified phone of the user.
+func CreateOTPSMSChallenge() SessionCommand {
+	return createOTPSMSChallenge(false, nil)
+}
+
+func createOTPSMSChallenge(returnCode bool, dst *string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-JKL3g", "Errors.User.UserIDMissing")
+		}
+		writeModel := NewHumanOTPSMSWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return err
+		}
+		if !writeModel.otpAdded {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-BJ2g3", "Errors.User.MFA.OTP.NotReady")
+		}
+		code, err := cmd.createCode(ctx, cmd.eventstore.Filter, domain.SecretGeneratorTypeOTPSMS, cmd.otpAlg)
+		if err != nil {
+			return err
+		}
+		if returnCode {
+			*dst = code.Plain
+		}
+		cmd.OTPSMSChallenged(ctx, code.Crypted, code.Expiry, returnCode)
+		return nil
+	}
+}
+
+// CreateOTPEmailChallengeURLTemplate creates an OTP Email challenge for the session,
+// the code will be sent to the verified email of the user
+// and the link in the email will be rendered using the urlTmpl.
+func CreateOTPEmailChallengeURLTemplate(urlTmpl string) (SessionCommand, error) {
+	if err := domain.RenderOTPEmailURLTemplate(io.Discard, urlTmpl, "code", "userID", "loginName", "displayName", language.English); err != nil {
+		return nil, err
+	}
+	return createOTPEmailChallenge(false, urlTmpl, nil), nil
+}
+
+// CreateOTPEmailChallengeReturnCode creates an OTP Email challenge for the session
+// and writes the plain code into dst instead of sending it to the user.
+func CreateOTPEmailChallengeReturnCode(dst *string) SessionCommand {
+	return createOTPEmailChallenge(true, "", dst)
+}
+
+// CreateOTPEmailChallenge creates an OTP Email challenge for the session,
+// the code will be sent to the verified email of the user.
+func CreateOTPEmailChallenge() SessionCommand {
+	return createOTPEmailChallenge(false, "", nil)
+}
+
+func createOTPEmailChallenge(returnCode bool, urlTmpl string, dst *string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-JK3gp", "Errors.User.UserIDMissing")
+		}
+		writeModel := NewHumanOTPEmailWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return err
+		}
+		if !writeModel.otpAdded {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-JKLJ3", "Errors.User.MFA.OTP.NotReady")
+		}
+		code, err := cmd.createCode(ctx, cmd.eventstore.Filter, domain.SecretGeneratorTypeOTPEmail, cmd.otpAlg)
+		if err != nil {
+			return err
+		}
+		if returnCode {
+			*dst = code.Plain
+		}
+		cmd.OTPEmailChallenged(ctx, code.Crypted, code.Expiry, returnCode, urlTmpl)
+		return nil
+	}
+}
+
+// CheckOTPSMS defines a check of the OTP SMS code sent on the previous challenge to be executed for a session update
+func CheckOTPSMS(code string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-VDrh3", "Errors.User.UserIDMissing")
+		}
+		challenge := cmd.sessionWriteModel.OTPSMSCodeChallenge
+		if challenge == nil {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-SF3tv", "Errors.User.Code.NotFound")
+		}
+		writeModel := NewHumanOTPSMSWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return err
+		}
+		if !writeModel.otpAdded {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ob3yd", "Errors.User.MFA.OTP.NotReady")
+		}
+		if writeModel.IsLocked(cmd.now()) {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Jz5rw", "Errors.User.Locked")
+		}
+		userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+		err := verifyCryptoCode(ctx, cmd.eventstore.Filter, domain.SecretGeneratorTypeOTPSMS, cmd.otpAlg, challenge.CreationDate, challenge.Expiry, challenge.Code, code)
+		if err != nil {
+			return cmd.otpCheckFailed(ctx, &writeModel.userLockoutState, userAgg, user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, nil), err)
+		}
+		cmd.otpCheckSucceeded(&writeModel.userLockoutState, user.NewHumanOTPSMSCheckSucceededEvent(ctx, userAgg, nil))
+		cmd.OTPSMSChecked(ctx, cmd.now())
+		return nil
+	}
+}
+
+// CheckOTPEmail defines a check of the OTP Email code sent on the previous challenge to be executed for a session update
+func CheckOTPEmail(code string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ejo2w", "Errors.User.UserIDMissing")
+		}
+		challenge := cmd.sessionWriteModel.OTPEmailCodeChallenge
+		if challenge == nil {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-zF3g3", "Errors.User.Code.NotFound")
+		}
+		writeModel := NewHumanOTPEmailWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return err
+		}
+		if !writeModel.otpAdded {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Tz8gk", "Errors.User.MFA.OTP.NotReady")
+		}
+		if writeModel.IsLocked(cmd.now()) {
+			return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Xo4tm", "Errors.User.Locked")
+		}
+		userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+		err := verifyCryptoCode(ctx, cmd.eventstore.Filter, domain.SecretGeneratorTypeOTPEmail, cmd.otpAlg, challenge.CreationDate, challenge.Expiry, challenge.Code, code)
+		if err != nil {
+			return cmd.otpCheckFailed(ctx, &writeModel.userLockoutState, userAgg, user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, nil), err)
+		}
+		cmd.otpCheckSucceeded(&writeModel.userLockoutState, user.NewHumanOTPEmailCheckSucceededEvent(ctx, userAgg, nil))
+		cmd.OTPEmailChecked(ctx, cmd.now())
+		return nil
+	}
+}
+
+// otpCheckFailed pushes the failed check of the OTP and locks the user, if the check exceeds the max OTP attempts of the lockout policy.
+// The events are pushed directly, as the session is not updated on a failed check. The error of the check is returned.
+func (s *SessionCommands) otpCheckFailed(ctx context.Context, lockout *userLockoutState, userAgg *eventstore.Aggregate, failedEvent eventstore.Command, checkErr error) error {
+	lockoutPolicy, err := s.getLockoutPolicy(ctx, userAgg.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	commands := []eventstore.Command{failedEvent}
+	if duration, lock := lockout.lockoutDuration(lockoutPolicy, lockoutPolicy.MaxOTPAttempts, s.now()); lock {
+		commands = append(commands, user.NewUserLockedEvent(ctx, userAgg, duration))
+	}
+	_, err = s.eventstore.Push(ctx, commands...)
+	logging.OnError(err).Error("error create otp check failed event")
+	return checkErr
+}
+
+// otpCheckSucceeded resets the failed checks of the OTP together with the session update,
+// the succeeded check is only recorded if there are failed checks or lockouts to reset
+func (s *SessionCommands) otpCheckSucceeded(lockout *userLockoutState, succeededEvent eventstore.Command) {
+	if len(lockout.FailedChecks) == 0 && lockout.ConsecutiveLockouts == 0 {
+		return
+	}
+	s.eventCommands = append(s.eventCommands, succeededEvent)
+}
+
+// OTPSMSSent marks the OTP SMS challenge of the session as sent (by the notification handler)
+func (c *Commands) OTPSMSSent(ctx context.Context, sessionID, resourceOwner string) error {
+	sessionWriteModel := NewSessionWriteModel(sessionID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
+	if err != nil {
+		return err
+	}
+	if sessionWriteModel.OTPSMSCodeChallenge == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-G3t31", "Errors.User.Code.NotFound")
+	}
+	return c.pushAppendAndReduce(ctx, sessionWriteModel,
+		session.NewOTPSMSSentEvent(ctx, &session.NewAggregate(sessionID, sessionWriteModel.ResourceOwner).Aggregate),
+	)
+}
+
+// OTPEmailSent marks the OTP Email challenge of the session as sent (by the notification handler)
+func (c *Commands) OTPEmailSent(ctx context.Context, sessionID, resourceOwner string) error {
+	sessionWriteModel := NewSessionWriteModel(sessionID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
+	if err != nil {
+		return err
+	}
+	if sessionWriteModel.OTPEmailCodeChallenge == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-SLr02", "Errors.User.Code.NotFound")
+	}
+	return c.pushAppendAndReduce(ctx, sessionWriteModel,
+		session.NewOTPEmailSentEvent(ctx, &session.NewAggregate(sessionID, sessionWriteModel.ResourceOwner).Aggregate),
+	)
+}
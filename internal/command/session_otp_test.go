@@ -0,0 +1,1112 @@
+package command
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCheckTOTP(t *testing.T) {
+	ctx := context.Background()
+	cryptoAlg := crypto.CreateMockEncryptionAlg(gomock.NewController(t))
+	key, secret, err := domain.NewTOTPKey("example.com", "user1", cryptoAlg)
+	require.NoError(t, err)
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	sessAgg := &session.NewAggregate("session1", "instance1").Aggregate
+
+	code, err := totp.GenerateCode(key.Secret(), testNow)
+	require.NoError(t, err)
+
+	type fields struct {
+		sessionWriteModel *SessionWriteModel
+		eventstore        *eventstore.Eventstore
+		lockoutPolicy     *domain.LockoutPolicy
+	}
+	tests := []struct {
+		name              string
+		code              string
+		fields            fields
+		wantEventCommands []eventstore.Command
+		wantErr           error
+	}{
+		{
+			name: "missing userID",
+			code: code,
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					aggregate: sessAgg,
+				},
+				eventstore: eventstoreExpect(t),
+			},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Neil7", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "filter error",
+			code: code,
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+				eventstore: eventstoreExpect(t,
+					expectFilterError(io.ErrClosedPipe),
+				),
+			},
+			wantErr: io.ErrClosedPipe,
+		},
+		{
+			name: "otp not ready error",
+			code: code,
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+					),
+				),
+			},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-eej1U", "Errors.User.MFA.OTP.NotReady"),
+		},
+		{
+			name: "otp verify error",
+			code: "foobar",
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanOTPCheckFailedEvent(ctx, userAgg, nil),
+						),
+					),
+				),
+				lockoutPolicy: &domain.LockoutPolicy{},
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "EVENT-8isk2", "Errors.User.MFA.OTP.InvalidCode"),
+		},
+		{
+			name: "user locked error",
+			code: code,
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+						eventFromEventPusher(
+							user.NewUserLockedEvent(ctx, userAgg, 0),
+						),
+					),
+				),
+			},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hq7vk", "Errors.User.Locked"),
+		},
+		{
+			name: "otp verify error, max attempts reached, locked",
+			code: "foobar",
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(ctx, userAgg, nil),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanOTPCheckFailedEvent(ctx, userAgg, nil),
+							user.NewUserLockedEvent(ctx, userAgg, 10*time.Minute),
+						),
+					),
+				),
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxOTPAttempts:  2,
+					LockoutDuration: 10 * time.Minute,
+				},
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "EVENT-8isk2", "Errors.User.MFA.OTP.InvalidCode"),
+		},
+		{
+			name: "otp verify error, expired lockout, progressively locked",
+			code: "foobar",
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+						eventFromEventPusher(
+							user.NewUserLockedEvent(ctx, userAgg, 10*time.Minute),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanOTPCheckFailedEvent(ctx, userAgg, nil),
+							user.NewUserLockedEvent(ctx, userAgg, 20*time.Minute),
+						),
+					),
+				),
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxOTPAttempts:     1,
+					LockoutDuration:    10 * time.Minute,
+					ProgressiveLockout: true,
+				},
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "EVENT-8isk2", "Errors.User.MFA.OTP.InvalidCode"),
+		},
+		{
+			name: "ok",
+			code: code,
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+					),
+				),
+			},
+			wantEventCommands: []eventstore.Command{
+				session.NewTOTPCheckedEvent(ctx, sessAgg, testNow),
+			},
+		},
+		{
+			name: "ok, failed checks reset",
+			code: code,
+			fields: fields{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(ctx, userAgg, nil),
+						),
+					),
+				),
+			},
+			wantEventCommands: []eventstore.Command{
+				user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, nil),
+				session.NewTOTPCheckedEvent(ctx, sessAgg, testNow),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &SessionCommands{
+				sessionWriteModel: tt.fields.sessionWriteModel,
+				eventstore:        tt.fields.eventstore,
+				totpAlg:           cryptoAlg,
+				now: func() time.Time {
+					return testNow
+				},
+				getLockoutPolicy: lockoutPolicyFunc(tt.fields.lockoutPolicy),
+			}
+			err := CheckTOTP(tt.code)(ctx, cmd)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantEventCommands, cmd.eventCommands)
+		})
+	}
+}
+
+func TestCreateOTPSMSChallenge(t *testing.T) {
+	ctx := context.Background()
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	sessAgg := &session.NewAggregate("session1", "instance1").Aggregate
+
+	type fields struct {
+		userID     string
+		eventstore *eventstore.Eventstore
+		createCode cryptoCodeFunc
+	}
+	type res struct {
+		returnCode string
+		commands   []eventstore.Command
+		err        error
+	}
+	tests := []struct {
+		name       string
+		returnCode bool
+		fields     fields
+		res        res
+	}{
+		{
+			name: "missing userID",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-JKL3g", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "otp sms not added",
+			fields: fields{
+				userID: "user1",
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanPhoneVerifiedEvent(ctx, userAgg),
+						),
+					),
+				),
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-BJ2g3", "Errors.User.MFA.OTP.NotReady"),
+			},
+		},
+		{
+			name: "send code",
+			fields: fields{
+				userID: "user1",
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanPhoneVerifiedEvent(ctx, userAgg),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPSMSAddedEvent(ctx, userAgg),
+						),
+					),
+				),
+				createCode: mockCode("1234567", 5*time.Minute),
+			},
+			res: res{
+				commands: []eventstore.Command{
+					session.NewOTPSMSChallengedEvent(ctx, sessAgg,
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("1234567"),
+						},
+						5*time.Minute,
+						false,
+					),
+				},
+			},
+		},
+		{
+			name:       "return code",
+			returnCode: true,
+			fields: fields{
+				userID: "user1",
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanPhoneVerifiedEvent(ctx, userAgg),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPSMSAddedEvent(ctx, userAgg),
+						),
+					),
+				),
+				createCode: mockCode("1234567", 5*time.Minute),
+			},
+			res: res{
+				returnCode: "1234567",
+				commands: []eventstore.Command{
+					session.NewOTPSMSChallengedEvent(ctx, sessAgg,
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("1234567"),
+						},
+						5*time.Minute,
+						true,
+					),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst string
+			cmd := &SessionCommands{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    tt.fields.userID,
+					aggregate: sessAgg,
+				},
+				eventstore: tt.fields.eventstore,
+				createCode: tt.fields.createCode,
+			}
+			sessionCmd := CreateOTPSMSChallenge()
+			if tt.returnCode {
+				sessionCmd = CreateOTPSMSChallengeReturnCode(&dst)
+			}
+			err := sessionCmd(ctx, cmd)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.returnCode, dst)
+			assert.Equal(t, tt.res.commands, cmd.eventCommands)
+		})
+	}
+}
+
+func TestCreateOTPEmailChallengeURLTemplate(t *testing.T) {
+	_, err := CreateOTPEmailChallengeURLTemplate("{{")
+	require.ErrorIs(t, err, caos_errs.ThrowInvalidArgument(nil, "DOMAIN-oGh5e", "Errors.User.InvalidURLTemplate"))
+
+	cmd, err := CreateOTPEmailChallengeURLTemplate("https://example.com/otp?code={{.Code}}&user={{.UserID}}&lang={{.PreferredLanguage}}")
+	require.NoError(t, err)
+	assert.NotNil(t, cmd)
+}
+
+func TestCreateOTPEmailChallenge(t *testing.T) {
+	ctx := context.Background()
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	sessAgg := &session.NewAggregate("session1", "instance1").Aggregate
+	urlTmpl := "https://example.com/otp?code={{.Code}}"
+
+	type fields struct {
+		userID     string
+		eventstore *eventstore.Eventstore
+		createCode cryptoCodeFunc
+	}
+	type res struct {
+		returnCode string
+		commands   []eventstore.Command
+		err        error
+	}
+	tests := []struct {
+		name       string
+		returnCode bool
+		urlTmpl    string
+		fields     fields
+		res        res
+	}{
+		{
+			name: "missing userID",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-JK3gp", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "otp email not added",
+			fields: fields{
+				userID: "user1",
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(ctx, userAgg),
+						),
+					),
+				),
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-JKLJ3", "Errors.User.MFA.OTP.NotReady"),
+			},
+		},
+		{
+			name:    "send code with url template",
+			urlTmpl: urlTmpl,
+			fields: fields{
+				userID: "user1",
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(ctx, userAgg),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPEmailAddedEvent(ctx, userAgg),
+						),
+					),
+				),
+				createCode: mockCode("1234567", 5*time.Minute),
+			},
+			res: res{
+				commands: []eventstore.Command{
+					session.NewOTPEmailChallengedEvent(ctx, sessAgg,
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("1234567"),
+						},
+						5*time.Minute,
+						false,
+						urlTmpl,
+					),
+				},
+			},
+		},
+		{
+			name:       "return code",
+			returnCode: true,
+			fields: fields{
+				userID: "user1",
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(ctx, userAgg),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPEmailAddedEvent(ctx, userAgg),
+						),
+					),
+				),
+				createCode: mockCode("1234567", 5*time.Minute),
+			},
+			res: res{
+				returnCode: "1234567",
+				commands: []eventstore.Command{
+					session.NewOTPEmailChallengedEvent(ctx, sessAgg,
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("1234567"),
+						},
+						5*time.Minute,
+						true,
+						"",
+					),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst string
+			cmd := &SessionCommands{
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    tt.fields.userID,
+					aggregate: sessAgg,
+				},
+				eventstore: tt.fields.eventstore,
+				createCode: tt.fields.createCode,
+			}
+			var sessionCmd SessionCommand
+			switch {
+			case tt.returnCode:
+				sessionCmd = CreateOTPEmailChallengeReturnCode(&dst)
+			case tt.urlTmpl != "":
+				var err error
+				sessionCmd, err = CreateOTPEmailChallengeURLTemplate(tt.urlTmpl)
+				require.NoError(t, err)
+			default:
+				sessionCmd = CreateOTPEmailChallenge()
+			}
+			err := sessionCmd(ctx, cmd)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.returnCode, dst)
+			assert.Equal(t, tt.res.commands, cmd.eventCommands)
+		})
+	}
+}
+
+func TestCheckOTPSMS(t *testing.T) {
+	ctx := context.Background()
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	sessAgg := &session.NewAggregate("session1", "instance1").Aggregate
+	cryptoAlg := crypto.CreateMockEncryptionAlg(gomock.NewController(t))
+	crypted, err := crypto.Encrypt([]byte("code"), cryptoAlg)
+	require.NoError(t, err)
+
+	type fields struct {
+		eventstore        *eventstore.Eventstore
+		sessionWriteModel *SessionWriteModel
+		lockoutPolicy     *domain.LockoutPolicy
+	}
+	type res struct {
+		commands []eventstore.Command
+		err      error
+	}
+	tests := []struct {
+		name   string
+		code   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "missing userID",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+				sessionWriteModel: &SessionWriteModel{
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-VDrh3", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "missing challenge",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-SF3tv", "Errors.User.Code.NotFound"),
+			},
+		},
+		{
+			name: "otp sms removed",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPSMSAddedEvent(ctx, userAgg)),
+						eventFromEventPusher(user.NewHumanOTPSMSRemovedEvent(ctx, userAgg)),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPSMSCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ob3yd", "Errors.User.MFA.OTP.NotReady"),
+			},
+		},
+		{
+			name: "user locked",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPSMSAddedEvent(ctx, userAgg)),
+						eventFromEventPusher(user.NewUserLockedEvent(ctx, userAgg, 0)),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPSMSCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Jz5rw", "Errors.User.Locked"),
+			},
+		},
+		{
+			name: "invalid code",
+			code: "invalid",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPSMSAddedEvent(ctx, userAgg)),
+					),
+					expectFilter(
+						eventFromEventPusher(testSecretGeneratorAddedEvent(domain.SecretGeneratorTypeOTPSMS)),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, nil),
+						),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPSMSCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxOTPAttempts: 2,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowInvalidArgument(nil, "CODE-woT0xc", "Errors.User.Code.Invalid"),
+			},
+		},
+		{
+			name: "invalid code, max attempts reached, locked",
+			code: "invalid",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPSMSAddedEvent(ctx, userAgg)),
+						eventFromEventPusher(user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, nil)),
+					),
+					expectFilter(
+						eventFromEventPusher(testSecretGeneratorAddedEvent(domain.SecretGeneratorTypeOTPSMS)),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, nil),
+							user.NewUserLockedEvent(ctx, userAgg, 10*time.Minute),
+						),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPSMSCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxOTPAttempts:  2,
+					LockoutDuration: 10 * time.Minute,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowInvalidArgument(nil, "CODE-woT0xc", "Errors.User.Code.Invalid"),
+			},
+		},
+		{
+			name: "check ok",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPSMSAddedEvent(ctx, userAgg)),
+					),
+					expectFilter(
+						eventFromEventPusher(testSecretGeneratorAddedEvent(domain.SecretGeneratorTypeOTPSMS)),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPSMSCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				commands: []eventstore.Command{
+					session.NewOTPSMSCheckedEvent(ctx, sessAgg, testNow),
+				},
+			},
+		},
+		{
+			name: "check ok, failed checks reset",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPSMSAddedEvent(ctx, userAgg)),
+						eventFromEventPusher(user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, nil)),
+					),
+					expectFilter(
+						eventFromEventPusher(testSecretGeneratorAddedEvent(domain.SecretGeneratorTypeOTPSMS)),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPSMSCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				commands: []eventstore.Command{
+					user.NewHumanOTPSMSCheckSucceededEvent(ctx, userAgg, nil),
+					session.NewOTPSMSCheckedEvent(ctx, sessAgg, testNow),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &SessionCommands{
+				sessionWriteModel: tt.fields.sessionWriteModel,
+				eventstore:        tt.fields.eventstore,
+				otpAlg:            cryptoAlg,
+				now: func() time.Time {
+					return testNow
+				},
+				getLockoutPolicy: lockoutPolicyFunc(tt.fields.lockoutPolicy),
+			}
+			err := CheckOTPSMS(tt.code)(ctx, cmd)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.commands, cmd.eventCommands)
+		})
+	}
+}
+
+func TestCommands_OTPSMSSent(t *testing.T) {
+	ctx := context.Background()
+	sessAgg := &session.NewAggregate("session1", "instance1").Aggregate
+
+	tests := []struct {
+		name       string
+		eventstore *eventstore.Eventstore
+		wantErr    error
+	}{
+		{
+			name: "not challenged error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusher(
+						session.NewAddedEvent(ctx, sessAgg),
+					),
+				),
+			),
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-G3t31", "Errors.User.Code.NotFound"),
+		},
+		{
+			name: "ok",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusher(
+						session.NewAddedEvent(ctx, sessAgg),
+					),
+					eventFromEventPusher(
+						session.NewOTPSMSChallengedEvent(ctx, sessAgg, nil, 5*time.Minute, false),
+					),
+				),
+				expectPush(
+					eventPusherToEvents(
+						session.NewOTPSMSSentEvent(ctx, sessAgg),
+					),
+				),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore,
+			}
+			err := c.OTPSMSSent(ctx, "session1", "instance1")
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCheckOTPEmail(t *testing.T) {
+	ctx := context.Background()
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	sessAgg := &session.NewAggregate("session1", "instance1").Aggregate
+	cryptoAlg := crypto.CreateMockEncryptionAlg(gomock.NewController(t))
+	crypted, err := crypto.Encrypt([]byte("code"), cryptoAlg)
+	require.NoError(t, err)
+
+	type fields struct {
+		eventstore        *eventstore.Eventstore
+		sessionWriteModel *SessionWriteModel
+		lockoutPolicy     *domain.LockoutPolicy
+	}
+	type res struct {
+		commands []eventstore.Command
+		err      error
+	}
+	tests := []struct {
+		name   string
+		code   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "missing userID",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+				sessionWriteModel: &SessionWriteModel{
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ejo2w", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "missing challenge",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+				sessionWriteModel: &SessionWriteModel{
+					UserID:    "user1",
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-zF3g3", "Errors.User.Code.NotFound"),
+			},
+		},
+		{
+			name: "otp email removed",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPEmailAddedEvent(ctx, userAgg)),
+						eventFromEventPusher(user.NewHumanOTPEmailRemovedEvent(ctx, userAgg)),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPEmailCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Tz8gk", "Errors.User.MFA.OTP.NotReady"),
+			},
+		},
+		{
+			name: "user locked",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPEmailAddedEvent(ctx, userAgg)),
+						eventFromEventPusher(user.NewUserLockedEvent(ctx, userAgg, 0)),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPEmailCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Xo4tm", "Errors.User.Locked"),
+			},
+		},
+		{
+			name: "invalid code",
+			code: "invalid",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPEmailAddedEvent(ctx, userAgg)),
+					),
+					expectFilter(
+						eventFromEventPusher(testSecretGeneratorAddedEvent(domain.SecretGeneratorTypeOTPEmail)),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, nil),
+						),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPEmailCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxOTPAttempts: 2,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowInvalidArgument(nil, "CODE-woT0xc", "Errors.User.Code.Invalid"),
+			},
+		},
+		{
+			name: "invalid code, max attempts reached, locked",
+			code: "invalid",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPEmailAddedEvent(ctx, userAgg)),
+						eventFromEventPusher(user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, nil)),
+					),
+					expectFilter(
+						eventFromEventPusher(testSecretGeneratorAddedEvent(domain.SecretGeneratorTypeOTPEmail)),
+					),
+					expectPush(
+						eventPusherToEvents(
+							user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, nil),
+							user.NewUserLockedEvent(ctx, userAgg, 10*time.Minute),
+						),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPEmailCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxOTPAttempts:  2,
+					LockoutDuration: 10 * time.Minute,
+				},
+			},
+			res: res{
+				err: caos_errs.ThrowInvalidArgument(nil, "CODE-woT0xc", "Errors.User.Code.Invalid"),
+			},
+		},
+		{
+			name: "check ok",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPEmailAddedEvent(ctx, userAgg)),
+					),
+					expectFilter(
+						eventFromEventPusher(testSecretGeneratorAddedEvent(domain.SecretGeneratorTypeOTPEmail)),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPEmailCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				commands: []eventstore.Command{
+					session.NewOTPEmailCheckedEvent(ctx, sessAgg, testNow),
+				},
+			},
+		},
+		{
+			name: "check ok, failed checks reset",
+			code: "code",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(user.NewHumanOTPEmailAddedEvent(ctx, userAgg)),
+						eventFromEventPusher(user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, nil)),
+					),
+					expectFilter(
+						eventFromEventPusher(testSecretGeneratorAddedEvent(domain.SecretGeneratorTypeOTPEmail)),
+					),
+				),
+				sessionWriteModel: &SessionWriteModel{
+					UserID: "user1",
+					OTPEmailCodeChallenge: &OTPCode{
+						Code:         crypted,
+						Expiry:       5 * time.Minute,
+						CreationDate: time.Now(),
+					},
+					aggregate: sessAgg,
+				},
+			},
+			res: res{
+				commands: []eventstore.Command{
+					user.NewHumanOTPEmailCheckSucceededEvent(ctx, userAgg, nil),
+					session.NewOTPEmailCheckedEvent(ctx, sessAgg, testNow),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &SessionCommands{
+				sessionWriteModel: tt.fields.sessionWriteModel,
+				eventstore:        tt.fields.eventstore,
+				otpAlg:            cryptoAlg,
+				now: func() time.Time {
+					return testNow
+				},
+				getLockoutPolicy: lockoutPolicyFunc(tt.fields.lockoutPolicy),
+			}
+			err := CheckOTPEmail(tt.code)(ctx, cmd)
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.commands, cmd.eventCommands)
+		})
+	}
+}
+
+func lockoutPolicyFunc(policy *domain.LockoutPolicy) func(context.Context, string) (*domain.LockoutPolicy, error) {
+	return func(context.Context, string) (*domain.LockoutPolicy, error) {
+		return policy, nil
+	}
+}
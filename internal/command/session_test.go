@@ -859,3 +859,119 @@ func TestCommands_TerminateSession(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_BackChannelLogoutSent(t *testing.T) {
+	ctx := context.Background()
+	sessAgg := &session.NewAggregate("session1", "instance1").Aggregate
+
+	tests := []struct {
+		name       string
+		eventstore *eventstore.Eventstore
+		clientID   string
+		wantErr    error
+	}{
+		{
+			name:       "missing client id error",
+			eventstore: eventstoreExpect(t),
+			wantErr:    caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lq3mv", "Errors.IDMissing"),
+		},
+		{
+			name: "not terminated error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusher(
+						session.NewAddedEvent(ctx, sessAgg),
+					),
+				),
+			),
+			clientID: "client1",
+			wantErr:  caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Vd0ql", "Errors.Session.NotTerminated"),
+		},
+		{
+			name: "ok",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusher(
+						session.NewAddedEvent(ctx, sessAgg),
+					),
+					eventFromEventPusher(
+						session.NewTerminateEvent(ctx, sessAgg),
+					),
+				),
+				expectPush(
+					eventPusherToEvents(
+						session.NewBackChannelLogoutSentEvent(ctx, sessAgg, "client1"),
+					),
+				),
+			),
+			clientID: "client1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore,
+			}
+			err := c.BackChannelLogoutSent(ctx, "session1", "instance1", tt.clientID)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCommands_BackChannelLogoutFailed(t *testing.T) {
+	ctx := context.Background()
+	sessAgg := &session.NewAggregate("session1", "instance1").Aggregate
+
+	tests := []struct {
+		name       string
+		eventstore *eventstore.Eventstore
+		clientID   string
+		wantErr    error
+	}{
+		{
+			name:       "missing client id error",
+			eventstore: eventstoreExpect(t),
+			wantErr:    caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fm5xo", "Errors.IDMissing"),
+		},
+		{
+			name: "not terminated error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusher(
+						session.NewAddedEvent(ctx, sessAgg),
+					),
+				),
+			),
+			clientID: "client1",
+			wantErr:  caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ot7eh", "Errors.Session.NotTerminated"),
+		},
+		{
+			name: "ok",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusher(
+						session.NewAddedEvent(ctx, sessAgg),
+					),
+					eventFromEventPusher(
+						session.NewTerminateEvent(ctx, sessAgg),
+					),
+				),
+				expectPush(
+					eventPusherToEvents(
+						session.NewBackChannelLogoutFailedEvent(ctx, sessAgg, "client1", "user1", 1),
+					),
+				),
+			),
+			clientID: "client1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore,
+			}
+			err := c.BackChannelLogoutFailed(ctx, "session1", "instance1", tt.clientID, "user1", 1)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
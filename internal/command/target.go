@@ -0,0 +1,230 @@
+package command
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+var targetSigningKeyGeneratorConfig = crypto.GeneratorConfig{
+	Length:              32,
+	IncludeLowerLetters: true,
+	IncludeUpperLetters: true,
+	IncludeDigits:       true,
+}
+
+type AddTarget struct {
+	models.ObjectRoot
+
+	Name             string
+	Endpoint         string
+	Timeout          time.Duration
+	Async            bool
+	InterruptOnError bool
+
+	// SigningKey is set on success and contains the plain key the payloads will be signed with
+	SigningKey string
+}
+
+func (a *AddTarget) IsValid() error {
+	if a.Name == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-ddqbm", "Errors.Target.InvalidName")
+	}
+	if a.Timeout <= 0 {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-39f35", "Errors.Target.InvalidTimeout")
+	}
+	if err := validateTargetEndpoint(a.Endpoint); err != nil {
+		return err
+	}
+	if a.Async && a.InterruptOnError {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hb2ln", "Errors.Target.AsyncAndInterrupt")
+	}
+	return nil
+}
+
+func validateTargetEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return caos_errs.ThrowInvalidArgument(err, "COMMAND-1r2k6", "Errors.Target.InvalidURL")
+	}
+	return nil
+}
+
+func (c *Commands) AddTarget(ctx context.Context, add *AddTarget, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-brml9", "Errors.IDMissing")
+	}
+	if err := add.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if add.AggregateID == "" {
+		add.AggregateID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wm, err := c.getTargetWriteModelByID(ctx, add.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if wm.State.Exists() {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-9axkz", "Errors.Target.AlreadyExists")
+	}
+
+	signingKey, plain, err := crypto.NewCode(c.targetSigningKeyGenerator)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.pushAppendAndReduce(ctx, wm, target.NewAddedEvent(
+		ctx,
+		TargetAggregateFromWriteModel(&wm.WriteModel),
+		add.Name,
+		add.Endpoint,
+		add.Timeout,
+		add.Async,
+		add.InterruptOnError,
+		signingKey,
+	)); err != nil {
+		return nil, err
+	}
+	add.SigningKey = plain
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+type ChangeTarget struct {
+	models.ObjectRoot
+
+	Name             *string
+	Endpoint         *string
+	Timeout          *time.Duration
+	Async            *bool
+	InterruptOnError *bool
+
+	// RegenerateSigningKey will replace the signing key,
+	// the new plain key is set into SigningKey on success
+	RegenerateSigningKey bool
+	SigningKey           *string
+}
+
+func (a *ChangeTarget) IsValid() error {
+	if a.AggregateID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-1l6ym", "Errors.IDMissing")
+	}
+	if a.Name != nil && *a.Name == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-d1wx4", "Errors.Target.InvalidName")
+	}
+	if a.Timeout != nil && *a.Timeout <= 0 {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-08b39", "Errors.Target.InvalidTimeout")
+	}
+	if a.Endpoint != nil {
+		if err := validateTargetEndpoint(*a.Endpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Commands) ChangeTarget(ctx context.Context, change *ChangeTarget, resourceOwner string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-zqibg", "Errors.IDMissing")
+	}
+	if err := change.IsValid(); err != nil {
+		return nil, err
+	}
+
+	existing, err := c.getTargetWriteModelByID(ctx, change.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-xj14f", "Errors.Target.NotFound")
+	}
+	async, interruptOnError := existing.Async, existing.InterruptOnError
+	if change.Async != nil {
+		async = *change.Async
+	}
+	if change.InterruptOnError != nil {
+		interruptOnError = *change.InterruptOnError
+	}
+	if async && interruptOnError {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fq3tl", "Errors.Target.AsyncAndInterrupt")
+	}
+
+	var signingKey *crypto.CryptoValue
+	var plain string
+	if change.RegenerateSigningKey {
+		signingKey, plain, err = crypto.NewCode(c.targetSigningKeyGenerator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changedEvent := existing.NewChangedEvent(
+		ctx,
+		TargetAggregateFromWriteModel(&existing.WriteModel),
+		change.Name,
+		change.Endpoint,
+		change.Timeout,
+		change.Async,
+		change.InterruptOnError,
+		signingKey,
+	)
+	if changedEvent == nil {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	if err := c.pushAppendAndReduce(ctx, existing, changedEvent); err != nil {
+		return nil, err
+	}
+	if change.RegenerateSigningKey {
+		change.SigningKey = &plain
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) DeleteTarget(ctx context.Context, id, resourceOwner string) (*domain.ObjectDetails, error) {
+	if id == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-obqos", "Errors.IDMissing")
+	}
+
+	existing, err := c.getTargetWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-k4s7u", "Errors.Target.NotFound")
+	}
+
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		target.NewRemovedEvent(ctx,
+			TargetAggregateFromWriteModel(&existing.WriteModel),
+			existing.Name,
+		),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) getTargetWriteModelByID(ctx context.Context, id string, resourceOwner string) (*TargetWriteModel, error) {
+	wm := NewTargetWriteModel(id, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, wm)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+func TargetAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, target.AggregateType, target.AggregateVersion)
+}
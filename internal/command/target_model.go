@@ -0,0 +1,165 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+type TargetWriteModel struct {
+	eventstore.WriteModel
+
+	Name             string
+	Endpoint         string
+	Timeout          time.Duration
+	Async            bool
+	InterruptOnError bool
+	SigningKey       *crypto.CryptoValue
+
+	State domain.TargetState
+}
+
+func NewTargetWriteModel(id string, resourceOwner string) *TargetWriteModel {
+	return &TargetWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *TargetWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *target.AddedEvent:
+			wm.Name = e.Name
+			wm.Endpoint = e.Endpoint
+			wm.Timeout = e.Timeout
+			wm.Async = e.Async
+			wm.InterruptOnError = e.InterruptOnError
+			wm.SigningKey = e.SigningKey
+			wm.State = domain.TargetStateActive
+		case *target.ChangedEvent:
+			if e.Name != nil {
+				wm.Name = *e.Name
+			}
+			if e.Endpoint != nil {
+				wm.Endpoint = *e.Endpoint
+			}
+			if e.Timeout != nil {
+				wm.Timeout = *e.Timeout
+			}
+			if e.Async != nil {
+				wm.Async = *e.Async
+			}
+			if e.InterruptOnError != nil {
+				wm.InterruptOnError = *e.InterruptOnError
+			}
+			if e.SigningKey != nil {
+				wm.SigningKey = e.SigningKey
+			}
+		case *target.RemovedEvent:
+			wm.State = domain.TargetStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *TargetWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(target.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(target.AddedEventType,
+			target.ChangedEventType,
+			target.RemovedEventType).
+		Builder()
+}
+
+func (wm *TargetWriteModel) NewChangedEvent(
+	ctx context.Context,
+	agg *eventstore.Aggregate,
+	name,
+	endpoint *string,
+	timeout *time.Duration,
+	async,
+	interruptOnError *bool,
+	signingKey *crypto.CryptoValue,
+) *target.ChangedEvent {
+	changes := make([]target.Changes, 0)
+	if name != nil && wm.Name != *name {
+		changes = append(changes, target.ChangeName(wm.Name, *name))
+	}
+	if endpoint != nil && wm.Endpoint != *endpoint {
+		changes = append(changes, target.ChangeEndpoint(*endpoint))
+	}
+	if timeout != nil && wm.Timeout != *timeout {
+		changes = append(changes, target.ChangeTimeout(*timeout))
+	}
+	if async != nil && wm.Async != *async {
+		changes = append(changes, target.ChangeAsync(*async))
+	}
+	if interruptOnError != nil && wm.InterruptOnError != *interruptOnError {
+		changes = append(changes, target.ChangeInterruptOnError(*interruptOnError))
+	}
+	if signingKey != nil {
+		changes = append(changes, target.ChangeSigningKey(signingKey))
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return target.NewChangedEvent(ctx, agg, changes)
+}
+
+type TargetsExistsWriteModel struct {
+	eventstore.WriteModel
+
+	ids         []string
+	existingIDs []string
+}
+
+func NewTargetsExistsWriteModel(ids []string, resourceOwner string) *TargetsExistsWriteModel {
+	return &TargetsExistsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		ids: ids,
+	}
+}
+
+func (wm *TargetsExistsWriteModel) AllExists() bool {
+	return len(wm.ids) == len(wm.existingIDs)
+}
+
+func (wm *TargetsExistsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *target.AddedEvent:
+			wm.existingIDs = append(wm.existingIDs, e.Aggregate().ID)
+		case *target.RemovedEvent:
+			for i := len(wm.existingIDs) - 1; i >= 0; i-- {
+				if wm.existingIDs[i] == e.Aggregate().ID {
+					wm.existingIDs = append(wm.existingIDs[:i], wm.existingIDs[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *TargetsExistsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(target.AggregateType).
+		AggregateIDs(wm.ids...).
+		EventTypes(target.AddedEventType,
+			target.RemovedEventType).
+		Builder()
+}
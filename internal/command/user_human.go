@@ -56,7 +56,9 @@ type AddHuman struct {
 	Passwordless           bool
 	ExternalIDP            bool
 	Register               bool
-	Metadata               []*AddMetadataEntry
+	// Inactive adds the user deactivated, e.g. for provisioned users which are not allowed to sign in yet
+	Inactive bool
+	Metadata []*AddMetadataEntry
 
 	// Links are optional
 	Links []*AddLink
@@ -251,6 +253,12 @@ func (c *Commands) AddHumanCommand(human *AddHuman, orgID string, hasher *crypto
 				}
 				cmds = append(cmds, cmd)
 			}
+			if human.Inactive {
+				if allowInitMail && human.shouldAddInitCode() {
+					return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Wq4vb", "Errors.User.CantDeactivateInitial")
+				}
+				cmds = append(cmds, user.NewUserDeactivatedEvent(ctx, &a.Aggregate))
+			}
 
 			return cmds, nil
 		}, nil
@@ -758,6 +766,31 @@ func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []
 	return err
 }
 
+// HumanBackChannelLogoutSent marks the back-channel logout of the user agent as delivered to the client (by the notification handler)
+func (c *Commands) HumanBackChannelLogoutSent(ctx context.Context, userID, resourceOwner, agentID, clientID string) error {
+	if userID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Hb2qa", "Errors.User.UserIDMissing")
+	}
+	if clientID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-x8Rvd", "Errors.IDMissing")
+	}
+	_, err := c.eventstore.Push(ctx, user.NewHumanBackChannelLogoutSentEvent(ctx, &user.NewAggregate(userID, resourceOwner).Aggregate, agentID, clientID))
+	return err
+}
+
+// HumanBackChannelLogoutFailed marks the back-channel logout of the user agent as failed for the client (by the notification handler),
+// so the delivery will be retried
+func (c *Commands) HumanBackChannelLogoutFailed(ctx context.Context, userID, resourceOwner, agentID, clientID string, attempt uint8) error {
+	if userID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Ue0bk", "Errors.User.UserIDMissing")
+	}
+	if clientID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Wz4fn", "Errors.IDMissing")
+	}
+	_, err := c.eventstore.Push(ctx, user.NewHumanBackChannelLogoutFailedEvent(ctx, &user.NewAggregate(userID, resourceOwner).Aggregate, agentID, clientID, attempt))
+	return err
+}
+
 func (c *Commands) getHumanWriteModelByID(ctx context.Context, userID, resourceowner string) (*HumanWriteModel, error) {
 	humanWriteModel := NewHumanWriteModel(userID, resourceowner)
 	err := c.eventstore.FilterToQueryReducer(ctx, humanWriteModel)
@@ -0,0 +1,242 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ChangeHuman contains the attributes of a human to change,
+// attributes which are nil are left untouched.
+// Email and phone are set verified, as the caller (e.g. a provisioning client) is trusted to manage them.
+type ChangeHuman struct {
+	ID string
+
+	Username *string
+	Profile  *domain.Profile
+	Email    *domain.EmailAddress
+	// Phone is removed if the number is empty
+	Phone    *domain.PhoneNumber
+	Password *string
+	Active   *bool
+	// Metadata is set, or removed if the value is empty
+	Metadata []*domain.Metadata
+
+	// Details are set after a successful execution of the command
+	Details *domain.ObjectDetails
+}
+
+// ChangeHuman pushes all changes of the human at once,
+// so either all or none of them are applied
+func (c *Commands) ChangeHuman(ctx context.Context, resourceOwner string, human *ChangeHuman) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if human.ID == "" || resourceOwner == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Zb3qt", "Errors.IDMissing")
+	}
+	existing, err := c.getHumanWriteModelByID(ctx, human.ID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existing.UserState) {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Ut6ak", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existing.WriteModel)
+
+	cmds, err := c.changeHumanUsername(ctx, existing, userAgg, human.Username)
+	if err != nil {
+		return err
+	}
+	if human.Profile != nil {
+		cmd, err := changeHumanProfile(ctx, existing, userAgg, human.Profile)
+		if err != nil {
+			return err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if human.Email != nil {
+		emailCmds, err := changeHumanEmail(ctx, existing, userAgg, *human.Email)
+		if err != nil {
+			return err
+		}
+		cmds = append(cmds, emailCmds...)
+	}
+	if human.Phone != nil {
+		phoneCmds, err := changeHumanPhone(ctx, existing, userAgg, *human.Phone)
+		if err != nil {
+			return err
+		}
+		cmds = append(cmds, phoneCmds...)
+	}
+	if human.Password != nil {
+		passwordWriteModel, err := c.passwordWriteModel(ctx, human.ID, resourceOwner)
+		if err != nil {
+			return err
+		}
+		cmd, err := c.setPasswordCommand(ctx, passwordWriteModel, *human.Password, false)
+		if err != nil {
+			return err
+		}
+		cmds = append(cmds, cmd)
+	}
+	metadataCmds, err := c.changeHumanMetadata(ctx, existing, userAgg, human.Metadata)
+	if err != nil {
+		return err
+	}
+	cmds = append(cmds, metadataCmds...)
+	if human.Active != nil {
+		cmd, err := changeHumanActive(ctx, existing, userAgg, *human.Active)
+		if err != nil {
+			return err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	if len(cmds) > 0 {
+		if err = c.pushAppendAndReduce(ctx, existing, cmds...); err != nil {
+			return err
+		}
+	}
+	human.Details = writeModelToObjectDetails(&existing.WriteModel)
+	return nil
+}
+
+func (c *Commands) changeHumanUsername(ctx context.Context, existing *HumanWriteModel, userAgg *eventstore.Aggregate, username *string) ([]eventstore.Command, error) {
+	cmds := make([]eventstore.Command, 0, 8)
+	if username == nil {
+		return cmds, nil
+	}
+	userName := strings.TrimSpace(*username)
+	if userName == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rk9vd", "Errors.User.Username.Empty")
+	}
+	if userName == existing.UserName {
+		return cmds, nil
+	}
+	domainPolicy, err := c.getOrgDomainPolicy(ctx, existing.ResourceOwner)
+	if err != nil {
+		return nil, caos_errs.ThrowPreconditionFailed(err, "COMMAND-Pm2xr", "Errors.Org.DomainPolicy.NotExisting")
+	}
+	if err = CheckDomainPolicyForUserName(userName, domainPolicy); err != nil {
+		return nil, err
+	}
+	return append(cmds, user.NewUsernameChangedEvent(ctx, userAgg, existing.UserName, userName, domainPolicy.UserLoginMustBeDomain)), nil
+}
+
+func changeHumanProfile(ctx context.Context, existing *HumanWriteModel, userAgg *eventstore.Aggregate, profile *domain.Profile) (eventstore.Command, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	changes := make([]user.ProfileChanges, 0, 6)
+	if existing.FirstName != profile.FirstName {
+		changes = append(changes, user.ChangeFirstName(profile.FirstName))
+	}
+	if existing.LastName != profile.LastName {
+		changes = append(changes, user.ChangeLastName(profile.LastName))
+	}
+	if existing.NickName != profile.NickName {
+		changes = append(changes, user.ChangeNickName(profile.NickName))
+	}
+	if existing.DisplayName != profile.DisplayName {
+		changes = append(changes, user.ChangeDisplayName(profile.DisplayName))
+	}
+	if existing.PreferredLanguage != profile.PreferredLanguage {
+		changes = append(changes, user.ChangePreferredLanguage(profile.PreferredLanguage))
+	}
+	if existing.Gender != profile.Gender {
+		changes = append(changes, user.ChangeGender(profile.Gender))
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return user.NewHumanProfileChangedEvent(ctx, userAgg, changes)
+}
+
+func changeHumanEmail(ctx context.Context, existing *HumanWriteModel, userAgg *eventstore.Aggregate, email domain.EmailAddress) ([]eventstore.Command, error) {
+	email = email.Normalize()
+	if err := email.Validate(); err != nil {
+		return nil, err
+	}
+	if email == existing.Email && existing.IsEmailVerified {
+		return nil, nil
+	}
+	if existing.UserState == domain.UserStateInitial {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hd7qe", "Errors.User.NotInitialised")
+	}
+	cmds := make([]eventstore.Command, 0, 2)
+	if email != existing.Email {
+		cmds = append(cmds, user.NewHumanEmailChangedEvent(ctx, userAgg, email))
+	}
+	return append(cmds, user.NewHumanEmailVerifiedEvent(ctx, userAgg)), nil
+}
+
+func changeHumanPhone(ctx context.Context, existing *HumanWriteModel, userAgg *eventstore.Aggregate, phone domain.PhoneNumber) ([]eventstore.Command, error) {
+	if phone == "" {
+		if existing.Phone == "" {
+			return nil, nil
+		}
+		return []eventstore.Command{user.NewHumanPhoneRemovedEvent(ctx, userAgg)}, nil
+	}
+	phone, err := phone.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if phone == existing.Phone && existing.IsPhoneVerified {
+		return nil, nil
+	}
+	cmds := make([]eventstore.Command, 0, 2)
+	if phone != existing.Phone {
+		cmds = append(cmds, user.NewHumanPhoneChangedEvent(ctx, userAgg, phone))
+	}
+	return append(cmds, user.NewHumanPhoneVerifiedEvent(ctx, userAgg)), nil
+}
+
+func (c *Commands) changeHumanMetadata(ctx context.Context, existing *HumanWriteModel, userAgg *eventstore.Aggregate, metadata []*domain.Metadata) ([]eventstore.Command, error) {
+	cmds := make([]eventstore.Command, 0, len(metadata))
+	for _, entry := range metadata {
+		if len(entry.Value) > 0 {
+			cmd, err := c.setUserMetadata(ctx, userAgg, entry)
+			if err != nil {
+				return nil, err
+			}
+			cmds = append(cmds, cmd)
+			continue
+		}
+		existingMetadata, err := c.getUserMetadataModelByID(ctx, existing.AggregateID, existing.ResourceOwner, entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		if !existingMetadata.State.Exists() {
+			continue
+		}
+		cmd, err := c.removeUserMetadata(ctx, userAgg, entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds, nil
+}
+
+func changeHumanActive(ctx context.Context, existing *HumanWriteModel, userAgg *eventstore.Aggregate, active bool) (eventstore.Command, error) {
+	if active == !isUserStateInactive(existing.UserState) {
+		return nil, nil
+	}
+	if active {
+		return user.NewUserReactivatedEvent(ctx, userAgg), nil
+	}
+	if isUserStateInitial(existing.UserState) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Vz8rp", "Errors.User.CantDeactivateInitial")
+	}
+	return user.NewUserDeactivatedEvent(ctx, userAgg), nil
+}
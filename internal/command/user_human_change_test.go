@@ -0,0 +1,235 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_ChangeHuman(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		human         *ChangeHuman
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	email := domain.EmailAddress("email2@test.ch")
+	phone := domain.PhoneNumber("")
+	username := "username2"
+	active := false
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				human:         &ChangeHuman{},
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				human:         &ChangeHuman{ID: "user1"},
+			},
+			res: res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(newAddHumanEvent("", false, "")),
+						eventFromEventPusher(user.NewHumanEmailVerifiedEvent(context.Background(), userAgg)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				human: &ChangeHuman{
+					ID: "user1",
+					Profile: &domain.Profile{
+						FirstName:         "firstname",
+						LastName:          "lastname",
+						DisplayName:       "firstname lastname",
+						PreferredLanguage: language.English,
+					},
+					Phone: &phone,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "deactivate initial user, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(newAddHumanEvent("", false, "")),
+						eventFromEventPusher(user.NewHumanInitialCodeAddedEvent(context.Background(), userAgg, nil, 0)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				human: &ChangeHuman{
+					ID:     "user1",
+					Active: &active,
+				},
+			},
+			res: res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change all attributes, pushed at once",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(newAddHumanEvent("", false, "+41791234567")),
+						eventFromEventPusher(user.NewHumanEmailVerifiedEvent(context.Background(), userAgg)),
+						eventFromEventPusher(user.NewHumanPhoneVerifiedEvent(context.Background(), userAgg)),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("instance1").Aggregate,
+								true,
+								true,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(user.NewMetadataSetEvent(context.Background(), userAgg, "key2", []byte("value"))),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewUsernameChangedEvent(context.Background(), userAgg, "username", "username2", true),
+							),
+							eventFromEventPusher(
+								func() eventstore.Command {
+									event, _ := user.NewHumanProfileChangedEvent(context.Background(), userAgg, []user.ProfileChanges{
+										user.ChangeFirstName("firstname2"),
+										user.ChangeNickName("nickname"),
+										user.ChangeDisplayName("firstname2 lastname"),
+										user.ChangePreferredLanguage(language.German),
+									})
+									return event
+								}(),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailChangedEvent(context.Background(), userAgg, "email2@test.ch"),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(), userAgg),
+							),
+							eventFromEventPusher(
+								user.NewHumanPhoneRemovedEvent(context.Background(), userAgg),
+							),
+							eventFromEventPusher(
+								user.NewMetadataSetEvent(context.Background(), userAgg, "key1", []byte("value")),
+							),
+							eventFromEventPusher(
+								user.NewMetadataRemovedEvent(context.Background(), userAgg, "key2"),
+							),
+							eventFromEventPusher(
+								user.NewUserDeactivatedEvent(context.Background(), userAgg),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewRemoveUsernameUniqueConstraint("username", "org1", true)),
+						uniqueConstraintsFromEventConstraint(user.NewAddUsernameUniqueConstraint("username2", "org1", true)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				human: &ChangeHuman{
+					ID:       "user1",
+					Username: &username,
+					Profile: &domain.Profile{
+						FirstName:         "firstname2",
+						LastName:          "lastname",
+						NickName:          "nickname",
+						DisplayName:       "firstname2 lastname",
+						PreferredLanguage: language.German,
+					},
+					Email: &email,
+					Phone: &phone,
+					Metadata: []*domain.Metadata{
+						{Key: "key1", Value: []byte("value")},
+						{Key: "key2"},
+						{Key: "key3"},
+					},
+					Active: &active,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.ChangeHuman(tt.args.ctx, tt.args.resourceOwner, tt.args.human)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, tt.args.human.Details)
+			}
+		})
+	}
+}
@@ -12,6 +12,8 @@ type HumanTOTPWriteModel struct {
 
 	State  domain.MFAState
 	Secret *crypto.CryptoValue
+
+	userLockoutState
 }
 
 func NewHumanTOTPWriteModel(userID, resourceOwner string) *HumanTOTPWriteModel {
@@ -33,6 +35,14 @@ func (wm *HumanTOTPWriteModel) Reduce() error {
 			wm.State = domain.MFAStateReady
 		case *user.HumanOTPRemovedEvent:
 			wm.State = domain.MFAStateRemoved
+		case *user.HumanOTPCheckFailedEvent:
+			wm.failed(e.CreationDate())
+		case *user.HumanOTPCheckSucceededEvent:
+			wm.succeeded()
+		case *user.UserLockedEvent:
+			wm.locked(e)
+		case *user.UserUnlockedEvent:
+			wm.unlocked()
 		case *user.UserRemovedEvent:
 			wm.State = domain.MFAStateRemoved
 		}
@@ -48,10 +58,16 @@ func (wm *HumanTOTPWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(user.HumanMFAOTPAddedType,
 			user.HumanMFAOTPVerifiedType,
 			user.HumanMFAOTPRemovedType,
+			user.HumanMFAOTPCheckFailedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.UserLockedType,
+			user.UserUnlockedType,
 			user.UserRemovedType,
 			user.UserV1MFAOTPAddedType,
 			user.UserV1MFAOTPVerifiedType,
-			user.UserV1MFAOTPRemovedType).
+			user.UserV1MFAOTPRemovedType,
+			user.UserV1MFAOTPCheckFailedType,
+			user.UserV1MFAOTPCheckSucceededType).
 		Builder()
 
 	if wm.ResourceOwner != "" {
@@ -65,6 +81,8 @@ type HumanOTPSMSWriteModel struct {
 
 	phoneVerified bool
 	otpAdded      bool
+
+	userLockoutState
 }
 
 func NewHumanOTPSMSWriteModel(userID, resourceOwner string) *HumanOTPSMSWriteModel {
@@ -78,13 +96,21 @@ func NewHumanOTPSMSWriteModel(userID, resourceOwner string) *HumanOTPSMSWriteMod
 
 func (wm *HumanOTPSMSWriteModel) Reduce() error {
 	for _, event := range wm.Events {
-		switch event.(type) {
+		switch e := event.(type) {
 		case *user.HumanPhoneVerifiedEvent:
 			wm.phoneVerified = true
 		case *user.HumanOTPSMSAddedEvent:
 			wm.otpAdded = true
 		case *user.HumanOTPSMSRemovedEvent:
 			wm.otpAdded = false
+		case *user.HumanOTPSMSCheckFailedEvent:
+			wm.failed(e.CreationDate())
+		case *user.HumanOTPSMSCheckSucceededEvent:
+			wm.succeeded()
+		case *user.UserLockedEvent:
+			wm.locked(e)
+		case *user.UserUnlockedEvent:
+			wm.unlocked()
 		case *user.HumanPhoneRemovedEvent,
 			*user.UserRemovedEvent:
 			wm.phoneVerified = false
@@ -102,6 +128,10 @@ func (wm *HumanOTPSMSWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(user.HumanPhoneVerifiedType,
 			user.HumanOTPSMSAddedType,
 			user.HumanOTPSMSRemovedType,
+			user.HumanOTPSMSCheckFailedType,
+			user.HumanOTPSMSCheckSucceededType,
+			user.UserLockedType,
+			user.UserUnlockedType,
 			user.HumanPhoneRemovedType,
 			user.UserRemovedType,
 		).
@@ -118,6 +148,8 @@ type HumanOTPEmailWriteModel struct {
 
 	emailVerified bool
 	otpAdded      bool
+
+	userLockoutState
 }
 
 func NewHumanOTPEmailWriteModel(userID, resourceOwner string) *HumanOTPEmailWriteModel {
@@ -131,13 +163,21 @@ func NewHumanOTPEmailWriteModel(userID, resourceOwner string) *HumanOTPEmailWrit
 
 func (wm *HumanOTPEmailWriteModel) Reduce() error {
 	for _, event := range wm.Events {
-		switch event.(type) {
+		switch e := event.(type) {
 		case *user.HumanEmailVerifiedEvent:
 			wm.emailVerified = true
 		case *user.HumanOTPEmailAddedEvent:
 			wm.otpAdded = true
 		case *user.HumanOTPEmailRemovedEvent:
 			wm.otpAdded = false
+		case *user.HumanOTPEmailCheckFailedEvent:
+			wm.failed(e.CreationDate())
+		case *user.HumanOTPEmailCheckSucceededEvent:
+			wm.succeeded()
+		case *user.UserLockedEvent:
+			wm.locked(e)
+		case *user.UserUnlockedEvent:
+			wm.unlocked()
 		case *user.UserRemovedEvent:
 			wm.emailVerified = false
 			wm.otpAdded = false
@@ -154,6 +194,10 @@ func (wm *HumanOTPEmailWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(user.HumanEmailVerifiedType,
 			user.HumanOTPEmailAddedType,
 			user.HumanOTPEmailRemovedType,
+			user.HumanOTPEmailCheckFailedType,
+			user.HumanOTPEmailCheckSucceededType,
+			user.UserLockedType,
+			user.UserUnlockedType,
 			user.UserRemovedType,
 		).
 		Builder()
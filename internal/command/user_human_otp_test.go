@@ -707,6 +707,176 @@ func TestCommands_HumanCheckMFATOTPSetup(t *testing.T) {
 	}
 }
 
+func TestCommands_HumanCheckMFATOTP(t *testing.T) {
+	ctx := authz.NewMockContext("", "org1", "user1")
+
+	cryptoAlg := crypto.CreateMockEncryptionAlg(gomock.NewController(t))
+	key, secret, err := domain.NewTOTPKey("example.com", "user1", cryptoAlg)
+	require.NoError(t, err)
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	authRequest := &domain.AuthRequest{
+		ID:      "request1",
+		AgentID: "agent1",
+	}
+	authRequestInfo := &user.AuthRequestInfo{
+		ID:          "request1",
+		UserAgentID: "agent1",
+	}
+
+	code, err := totp.GenerateCode(key.Secret(), time.Now())
+	require.NoError(t, err)
+
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		userID        string
+		code          string
+		resourceOwner string
+		lockoutPolicy *domain.LockoutPolicy
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "missing user id",
+			args:    args{},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-8N9ds", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "otp not ready error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+					),
+				),
+			},
+			args: args{
+				resourceOwner: "org1",
+				userID:        "user1",
+			},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3Mif9s", "Errors.User.MFA.OTP.NotReady"),
+		},
+		{
+			name: "user locked error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+						eventFromEventPusherWithCreationDateNow(
+							user.NewUserLockedEvent(ctx, userAgg, time.Hour),
+						),
+					),
+				),
+			},
+			args: args{
+				resourceOwner: "org1",
+				code:          code,
+				userID:        "user1",
+			},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Kd9s2", "Errors.User.Locked"),
+		},
+		{
+			name: "wrong code, max otp attempts reached - user locked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestInfo),
+						),
+					),
+					expectPush([]*repository.Event{
+						eventFromEventPusher(
+							user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestInfo),
+						),
+						eventFromEventPusher(
+							user.NewUserLockedEvent(ctx, userAgg, time.Hour),
+						),
+					}),
+				),
+			},
+			args: args{
+				resourceOwner: "org1",
+				code:          "wrong",
+				userID:        "user1",
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					MaxOTPAttempts:      2,
+					LockoutDuration:     time.Hour,
+				},
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "EVENT-8isk2", "Errors.User.MFA.OTP.InvalidCode"),
+		},
+		{
+			name: "lockout expired, success",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanOTPAddedEvent(ctx, userAgg, secret),
+						),
+						eventFromEventPusher(
+							user.NewHumanOTPVerifiedEvent(ctx, userAgg, "agent1"),
+						),
+						eventFromEventPusher(
+							user.NewUserLockedEvent(ctx, userAgg, time.Hour),
+						),
+					),
+					expectPush([]*repository.Event{
+						eventFromEventPusher(
+							user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, authRequestInfo),
+						),
+					}),
+				),
+			},
+			args: args{
+				resourceOwner: "org1",
+				code:          code,
+				userID:        "user1",
+				lockoutPolicy: &domain.LockoutPolicy{
+					MaxOTPAttempts:  2,
+					LockoutDuration: time.Hour,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+				multifactors: domain.MultifactorConfigs{
+					OTP: domain.OTPConfig{
+						CryptoMFA: cryptoAlg,
+					},
+				},
+			}
+			err := c.HumanCheckMFATOTP(ctx, tt.args.userID, tt.args.code, tt.args.resourceOwner, authRequest, tt.args.lockoutPolicy)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestCommandSide_RemoveHumanTOTP(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
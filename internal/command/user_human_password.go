@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/zitadel/logging"
 	"github.com/zitadel/passwap"
@@ -103,16 +104,18 @@ func (c *Commands) ChangePassword(ctx context.Context, orgID, userID, oldPasswor
 	if wm.EncodedHash == "" {
 		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Fds3s", "Errors.User.Password.Empty")
 	}
-	if err = c.canUpdatePassword(ctx, newPassword, wm); err != nil {
-		return nil, err
-	}
 
+	// the old password is verified first, as the history includes the current password
+	// and would otherwise confirm a guess of it
 	ctx, spanPasswap := tracing.NewNamedSpan(ctx, "passwap.VerifyAndUpdate")
 	updated, err := c.userPasswordHasher.VerifyAndUpdate(wm.EncodedHash, oldPassword, newPassword)
 	spanPasswap.EndWithError(err)
 	if err = convertPasswapErr(err); err != nil {
 		return nil, err
 	}
+	if err = c.canUpdatePassword(ctx, newPassword, wm); err != nil {
+		return nil, err
+	}
 	err = c.pushAppendAndReduce(ctx, wm,
 		user.NewHumanPasswordChangedEvent(ctx, UserAggregateFromWriteModel(&wm.WriteModel), updated, false, userAgentID))
 	if err != nil {
@@ -139,6 +142,29 @@ func (c *Commands) canUpdatePassword(ctx context.Context, newPassword string, wm
 	if err := policy.Check(newPassword); err != nil {
 		return err
 	}
+	return c.checkPasswordHistory(ctx, newPassword, wm)
+}
+
+// checkPasswordHistory prevents the reuse of the passwords defined by the password history policy.
+func (c *Commands) checkPasswordHistory(ctx context.Context, newPassword string, wm *HumanPasswordWriteModel) (err error) {
+	if len(wm.PasswordHistory) == 0 {
+		return nil
+	}
+	policy, err := c.getOrgPasswordHistoryPolicy(ctx, wm.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	for _, encodedHash := range wm.lastPasswordHashes(policy.HistoryCount) {
+		_, spanPasswap := tracing.NewNamedSpan(ctx, "passwap.Verify")
+		_, err = c.userPasswordHasher.Verify(encodedHash, newPassword)
+		spanPasswap.EndWithError(err)
+		if err == nil {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hw3fs", "Errors.User.Password.UsedBefore")
+		}
+		if !errors.Is(err, passwap.ErrPasswordMismatch) {
+			logging.WithFields("userID", wm.AggregateID).WithError(err).Warn("unable to verify password from history")
+		}
+	}
 	return nil
 }
 
@@ -237,6 +263,10 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	if wm.EncodedHash == "" {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3n77z", "Errors.User.Password.NotSet")
 	}
+	now := time.Now()
+	if wm.IsLocked(now) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sfw3f", "Errors.User.Locked")
+	}
 
 	userAgg := UserAggregateFromWriteModel(&wm.WriteModel)
 	ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "passwap.Verify")
@@ -255,11 +285,10 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	}
 
 	commands = append(commands, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
-	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 {
-		if wm.PasswordCheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
-			commands = append(commands, user.NewUserLockedEvent(ctx, userAgg))
+	if lockoutPolicy != nil {
+		if duration, lock := wm.lockoutDuration(lockoutPolicy, lockoutPolicy.MaxPasswordAttempts, now); lock {
+			commands = append(commands, user.NewUserLockedEvent(ctx, userAgg, duration))
 		}
-
 	}
 	_, pushErr := c.eventstore.Push(ctx, commands...)
 	logging.OnError(pushErr).Error("error create password check failed event")
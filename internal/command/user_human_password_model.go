@@ -15,11 +15,15 @@ type HumanPasswordWriteModel struct {
 
 	EncodedHash          string
 	SecretChangeRequired bool
+	// PasswordHistory contains the encoded hashes of all passwords the user ever had,
+	// the current one being the last
+	PasswordHistory []string
 
-	Code                     *crypto.CryptoValue
-	CodeCreationDate         time.Time
-	CodeExpiry               time.Duration
-	PasswordCheckFailedCount uint64
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
+
+	userLockoutState
 
 	UserState domain.UserState
 }
@@ -37,11 +41,11 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		switch e := event.(type) {
 		case *user.HumanAddedEvent:
-			wm.EncodedHash = user.SecretOrEncodedHash(e.Secret, e.EncodedHash)
+			wm.setEncodedHash(user.SecretOrEncodedHash(e.Secret, e.EncodedHash))
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.UserState = domain.UserStateActive
 		case *user.HumanRegisteredEvent:
-			wm.EncodedHash = user.SecretOrEncodedHash(e.Secret, e.EncodedHash)
+			wm.setEncodedHash(user.SecretOrEncodedHash(e.Secret, e.EncodedHash))
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.UserState = domain.UserStateActive
 		case *user.HumanInitialCodeAddedEvent:
@@ -49,10 +53,10 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 		case *user.HumanInitializedCheckSucceededEvent:
 			wm.UserState = domain.UserStateActive
 		case *user.HumanPasswordChangedEvent:
-			wm.EncodedHash = user.SecretOrEncodedHash(e.Secret, e.EncodedHash)
+			wm.setEncodedHash(user.SecretOrEncodedHash(e.Secret, e.EncodedHash))
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.Code = nil
-			wm.PasswordCheckFailedCount = 0
+			wm.resetFailedChecks()
 		case *user.HumanPasswordCodeAddedEvent:
 			wm.Code = e.Code
 			wm.CodeCreationDate = e.CreationDate()
@@ -62,20 +66,40 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 				wm.UserState = domain.UserStateActive
 			}
 		case *user.HumanPasswordCheckFailedEvent:
-			wm.PasswordCheckFailedCount += 1
+			wm.failed(e.CreationDate())
 		case *user.HumanPasswordCheckSucceededEvent:
-			wm.PasswordCheckFailedCount = 0
+			wm.succeeded()
+		case *user.UserLockedEvent:
+			wm.locked(e)
 		case *user.UserUnlockedEvent:
-			wm.PasswordCheckFailedCount = 0
+			wm.unlocked()
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
 		case *user.HumanPasswordHashUpdatedEvent:
 			wm.EncodedHash = e.EncodedHash
+			if len(wm.PasswordHistory) > 0 {
+				wm.PasswordHistory[len(wm.PasswordHistory)-1] = e.EncodedHash
+			}
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
 
+func (wm *HumanPasswordWriteModel) setEncodedHash(encodedHash string) {
+	wm.EncodedHash = encodedHash
+	if encodedHash != "" {
+		wm.PasswordHistory = append(wm.PasswordHistory, encodedHash)
+	}
+}
+
+// lastPasswordHashes returns the encoded hashes of the last count passwords, including the current one.
+func (wm *HumanPasswordWriteModel) lastPasswordHashes(count uint64) []string {
+	if uint64(len(wm.PasswordHistory)) <= count {
+		return wm.PasswordHistory
+	}
+	return wm.PasswordHistory[len(wm.PasswordHistory)-int(count):]
+}
+
 func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AddQuery().
@@ -92,6 +116,7 @@ func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanPasswordCheckSucceededType,
 			user.HumanPasswordHashUpdatedType,
 			user.UserRemovedType,
+			user.UserLockedType,
 			user.UserUnlockedType,
 			user.UserV1AddedType,
 			user.UserV1RegisteredType,
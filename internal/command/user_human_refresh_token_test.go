@@ -41,6 +41,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		authTime              time.Time
 		refreshIdleExpiration time.Duration
 		refreshExpiration     time.Duration
+		jwkThumbprint         string
 	}
 	type res struct {
 		token        *domain.Token
@@ -136,6 +137,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -174,6 +176,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							-1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 					),
 				),
@@ -238,7 +241,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//					time.Now(),
 		//					1*time.Hour,
 		//					24*time.Hour,
-		//				)),
+		//, ""				)),
 		//			),
 		//			expectPushFailed(
 		//				caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -253,7 +256,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//						[]string{"clientID1"},
 		//						[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 		//						time.Now().Add(5*time.Minute),
-		//					)),
+		//, ""					)),
 		//					eventFromEventPusher(user.NewHumanRefreshTokenRenewedEvent(
 		//						context.Background(),
 		//						&user.NewAggregate("userID", "orgID").Aggregate,
@@ -293,7 +296,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
 			got, gotRefresh, err := c.AddAccessAndRefreshToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.refreshToken,
-				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime)
+				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime, tt.args.jwkThumbprint)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -373,6 +376,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectPushFailed(caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -414,6 +418,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectPush(
@@ -511,6 +516,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectFilter(),
@@ -544,6 +550,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectFilter(
@@ -560,6 +567,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectPushFailed(caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -606,6 +614,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectFilter(
@@ -622,6 +631,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectPush(
@@ -754,6 +764,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 					authTime,
 					1*time.Hour,
 					10*time.Hour,
+					"",
 				),
 				refreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:refreshTokenID:refreshTokenID")),
 			},
@@ -791,6 +802,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 		userID         string
 		orgID          string
 		refreshToken   string
+		jwkThumbprint  string
 		idleExpiration time.Duration
 	}
 	type res struct {
@@ -865,6 +877,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -903,6 +916,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 					),
 				),
@@ -936,6 +950,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 						eventFromEventPusher(
 							user.NewUserDeactivatedEvent(
@@ -976,6 +991,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 						eventFromEventPusher(
 							user.NewHumanSignedOutEvent(
@@ -999,6 +1015,122 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				err: caos_errs.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "dpop bound token without proof, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							"jkt",
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "dpop bound token with proof of other key, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							"jkt",
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				jwkThumbprint:  "otherJKT",
+				idleExpiration: 1 * time.Hour,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "dpop bound token renewed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							"jkt",
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "refreshToken1"),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				jwkThumbprint:  "jkt",
+				idleExpiration: 1 * time.Hour,
+			},
+			res: res{
+				event: user.NewHumanRefreshTokenRenewedEvent(
+					context.Background(),
+					&user.NewAggregate("userID", "orgID").Aggregate,
+					"tokenID",
+					"refreshToken1",
+					1*time.Hour,
+				),
+				refreshTokenID:  "tokenID",
+				newRefreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:refreshToken1")),
+			},
+		},
 		{
 			name: "token renewed, ok",
 			fields: fields{
@@ -1017,6 +1149,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 					),
 				),
@@ -1050,7 +1183,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				idGenerator:  tt.fields.idGenerator,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.idleExpiration)
+			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.jwkThumbprint, tt.args.idleExpiration)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// userLockoutState is reduced by write models which count failed checks
+// and lock the user according to the lockout policy
+type userLockoutState struct {
+	// FailedChecks contains the creation dates of the failed checks since the last successful check or lockout
+	FailedChecks []time.Time
+	Locked       bool
+	LockedUntil  time.Time
+	// ConsecutiveLockouts counts the lockouts since the last successful check or manual unlock
+	ConsecutiveLockouts uint64
+}
+
+func (s *userLockoutState) failed(creationDate time.Time) {
+	s.FailedChecks = append(s.FailedChecks, creationDate)
+}
+
+func (s *userLockoutState) succeeded() {
+	s.FailedChecks = nil
+	s.ConsecutiveLockouts = 0
+}
+
+func (s *userLockoutState) resetFailedChecks() {
+	s.FailedChecks = nil
+}
+
+func (s *userLockoutState) locked(e *user.UserLockedEvent) {
+	s.Locked = true
+	s.LockedUntil = e.LockedUntil()
+	s.ConsecutiveLockouts++
+	s.FailedChecks = nil
+}
+
+func (s *userLockoutState) unlocked() {
+	s.Locked = false
+	s.LockedUntil = time.Time{}
+	s.ConsecutiveLockouts = 0
+	s.FailedChecks = nil
+}
+
+// IsLocked returns true if the user is locked and the lockout is not yet expired
+func (s *userLockoutState) IsLocked(now time.Time) bool {
+	return s.Locked && (s.LockedUntil.IsZero() || now.Before(s.LockedUntil))
+}
+
+// FailedChecksSince returns the amount of failed checks which happened at or after the given time
+func (s *userLockoutState) FailedChecksSince(since time.Time) uint64 {
+	var count uint64
+	for _, failed := range s.FailedChecks {
+		if !failed.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+// lockoutDuration returns the duration of the lockout if the current failed check exceeds the max attempts
+func (s *userLockoutState) lockoutDuration(policy *domain.LockoutPolicy, maxAttempts uint64, now time.Time) (time.Duration, bool) {
+	if policy == nil || maxAttempts == 0 {
+		return 0, false
+	}
+	if s.FailedChecksSince(policy.FailedAttemptsSince(now))+1 < maxAttempts {
+		return 0, false
+	}
+	return policy.LockoutDurationFor(s.ConsecutiveLockouts), true
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"strings"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -17,6 +18,8 @@ type UserWriteModel struct {
 	IDPLinks  []*domain.UserIDPLink
 	UserState domain.UserState
 	UserType  domain.UserType
+	// LockedUntil is set if the user is locked for a limited duration
+	LockedUntil time.Time
 }
 
 func NewUserWriteModel(userID, resourceOwner string) *UserWriteModel {
@@ -71,10 +74,12 @@ func (wm *UserWriteModel) Reduce() error {
 		case *user.UserLockedEvent:
 			if wm.UserState != domain.UserStateDeleted {
 				wm.UserState = domain.UserStateLocked
+				wm.LockedUntil = e.LockedUntil()
 			}
 		case *user.UserUnlockedEvent:
 			if wm.UserState != domain.UserStateDeleted {
 				wm.UserState = domain.UserStateActive
+				wm.LockedUntil = time.Time{}
 			}
 		case *user.UserDeactivatedEvent:
 			if wm.UserState != domain.UserStateDeleted {
@@ -165,3 +170,8 @@ func (wm *UserWriteModel) IDPLinkByID(idpID, externalUserID string) (idx int, id
 	}
 	return -1, nil
 }
+
+// lockoutExpired returns true if the user was locked for a limited duration which already passed
+func (wm *UserWriteModel) lockoutExpired(now time.Time) bool {
+	return wm.UserState == domain.UserStateLocked && !wm.LockedUntil.IsZero() && !now.Before(wm.LockedUntil)
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// SAMLSession identifies the session of a user on a SAML service provider (application)
+type SAMLSession struct {
+	UserID        string
+	ResourceOwner string
+	ApplicationID string
+}
+
+// AddSAMLSession records that a SAML response was issued to the service provider (application) for the user on the user agent,
+// so the logout can be propagated to the service provider with the NameID (and its format) and session index of the issued assertion.
+func (c *Commands) AddSAMLSession(ctx context.Context, userID, resourceOwner, userAgentID, applicationID, entityID, nameID, nameIDFormat, sessionIndex string) error {
+	if userID == "" || userAgentID == "" || applicationID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Sfj3q", "Errors.IDMissing")
+	}
+	userWriteModel := NewUserWriteModel(userID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, userWriteModel); err != nil {
+		return err
+	}
+	if userWriteModel.UserState != domain.UserStateActive {
+		return errors.ThrowNotFound(nil, "COMMAND-Bw2ls", "Errors.User.NotFound")
+	}
+	_, err := c.eventstore.Push(ctx, user.NewSAMLSessionAddedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&userWriteModel.WriteModel),
+		userAgentID,
+		applicationID,
+		entityID,
+		nameID,
+		nameIDFormat,
+		sessionIndex,
+	))
+	return err
+}
+
+// TerminateSAMLSessions marks the sessions of the user agent on the service providers (applications) as terminated,
+// after the logout was propagated or requested by the service provider.
+func (c *Commands) TerminateSAMLSessions(ctx context.Context, userAgentID string, sessions ...*SAMLSession) error {
+	if userAgentID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Hw3tk", "Errors.IDMissing")
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+	events := make([]eventstore.Command, len(sessions))
+	for i, session := range sessions {
+		if session.UserID == "" || session.ApplicationID == "" {
+			return errors.ThrowInvalidArgument(nil, "COMMAND-Fe9sl", "Errors.IDMissing")
+		}
+		events[i] = user.NewSAMLSessionTerminatedEvent(
+			ctx,
+			&user.NewAggregate(session.UserID, session.ResourceOwner).Aggregate,
+			userAgentID,
+			session.ApplicationID,
+		)
+	}
+	_, err := c.eventstore.Push(ctx, events...)
+	return err
+}
@@ -0,0 +1,261 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_AddSAMLSession(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		userAgentID   string
+		applicationID string
+		entityID      string
+		nameID        string
+		nameIDFormat  string
+		sessionIndex  string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userAgentID missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				applicationID: "app1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				userAgentID:   "agent1",
+				applicationID: "app1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "add saml session, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewSAMLSessionAddedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+									"app1",
+									"https://sp.example.com/metadata",
+									"username",
+									"urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+									"sessionIndex",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				userAgentID:   "agent1",
+				applicationID: "app1",
+				entityID:      "https://sp.example.com/metadata",
+				nameID:        "username",
+				nameIDFormat:  "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+				sessionIndex:  "sessionIndex",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.AddSAMLSession(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.userAgentID, tt.args.applicationID, tt.args.entityID, tt.args.nameID, tt.args.nameIDFormat, tt.args.sessionIndex)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommandSide_TerminateSAMLSessions(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx         context.Context
+		userAgentID string
+		sessions    []*SAMLSession
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userAgentID missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sessions: []*SAMLSession{
+					{UserID: "user1", ResourceOwner: "org1", ApplicationID: "app1"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "applicationID missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				userAgentID: "agent1",
+				sessions: []*SAMLSession{
+					{UserID: "user1", ResourceOwner: "org1"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no sessions, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				userAgentID: "agent1",
+			},
+			res: res{},
+		},
+		{
+			name: "terminate multiple sessions, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewSAMLSessionTerminatedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+									"app1",
+								),
+							),
+							eventFromEventPusher(
+								user.NewSAMLSessionTerminatedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+									"app2",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:         context.Background(),
+				userAgentID: "agent1",
+				sessions: []*SAMLSession{
+					{UserID: "user1", ResourceOwner: "org1", ApplicationID: "app1"},
+					{UserID: "user1", ResourceOwner: "org1", ApplicationID: "app2"},
+				},
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.TerminateSAMLSessions(tt.args.ctx, tt.args.userAgentID, tt.args.sessions...)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
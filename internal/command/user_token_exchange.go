@@ -0,0 +1,127 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// TokenExchange is the exchange of a token of the user (subject) for a new token (RFC 8693)
+type TokenExchange struct {
+	UserID             string
+	ResourceOwner      string
+	ClientID           string
+	SubjectTokenType   string
+	RequestedTokenType string
+	Audience           []string
+	Scopes             []string
+	// Actor is set if the exchange was requested with an actor token
+	Actor *TokenActor
+	// Impersonation states that the actor receives a token of the user without being mentioned in it
+	Impersonation bool
+}
+
+// TokenActor is the (authenticated) user acting on behalf of the subject of a token exchange
+type TokenActor struct {
+	UserID        string
+	ResourceOwner string
+}
+
+// ExchangeUserToken records the token exchange on the user.
+// Exchanges with an actor (delegation and impersonation) must be enabled in the security policy of the instance,
+// must not be disabled in the token exchange policy of the organisation of the user
+// and the actor needs the corresponding permission on the organisation of the user.
+func (c *Commands) ExchangeUserToken(ctx context.Context, exchange *TokenExchange) error {
+	if exchange.UserID == "" || exchange.ClientID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Wf3ga", "Errors.IDMissing")
+	}
+	if exchange.Impersonation && exchange.Actor == nil {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Nq8sk", "Errors.TokenExchange.ActorMissing")
+	}
+	if exchange.Actor != nil {
+		if err := c.checkTokenExchangeActor(ctx, exchange); err != nil {
+			return err
+		}
+	}
+	userWriteModel := NewUserWriteModel(exchange.UserID, exchange.ResourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, userWriteModel); err != nil {
+		return err
+	}
+	if userWriteModel.UserState != domain.UserStateActive {
+		return errors.ThrowNotFound(nil, "COMMAND-Gm2ka", "Errors.User.NotFound")
+	}
+	_, err := c.eventstore.Push(ctx, tokenExchangeEvent(ctx, UserAggregateFromWriteModel(&userWriteModel.WriteModel), exchange))
+	return err
+}
+
+func (c *Commands) checkTokenExchangeActor(ctx context.Context, exchange *TokenExchange) error {
+	if exchange.Actor.UserID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Kd9wq", "Errors.IDMissing")
+	}
+	policy, err := c.getSecurityPolicyWriteModel(ctx, c.eventstore.Filter)
+	if err != nil {
+		return err
+	}
+	permission := domain.PermissionDelegation
+	if exchange.Impersonation {
+		if !policy.EnableImpersonation {
+			return errors.ThrowPermissionDenied(nil, "COMMAND-Tz3pa", "Errors.TokenExchange.ImpersonationDisabled")
+		}
+		permission = domain.PermissionImpersonation
+	} else if !policy.EnableDelegation {
+		return errors.ThrowPermissionDenied(nil, "COMMAND-Xe5lr", "Errors.TokenExchange.DelegationDisabled")
+	}
+	orgPolicy, err := c.orgTokenExchangePolicyWriteModelByID(ctx, exchange.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	if exchange.Impersonation && orgPolicy.DisableImpersonation {
+		return errors.ThrowPermissionDenied(nil, "COMMAND-Pq7ds", "Errors.TokenExchange.ImpersonationDisabledByOrg")
+	}
+	if !exchange.Impersonation && orgPolicy.DisableDelegation {
+		return errors.ThrowPermissionDenied(nil, "COMMAND-Vm2xe", "Errors.TokenExchange.DelegationDisabledByOrg")
+	}
+	// a user can always act on behalf of itself
+	if !exchange.Impersonation && exchange.Actor.UserID == exchange.UserID {
+		return nil
+	}
+	actorCtx := authz.SetCtxData(ctx, authz.CtxData{
+		UserID: exchange.Actor.UserID,
+		OrgID:  exchange.Actor.ResourceOwner,
+	})
+	return c.checkPermission(actorCtx, permission, exchange.ResourceOwner, exchange.UserID)
+}
+
+func tokenExchangeEvent(ctx context.Context, aggregate *eventstore.Aggregate, exchange *TokenExchange) eventstore.Command {
+	if exchange.Impersonation {
+		return user.NewUserImpersonatedEvent(
+			ctx,
+			aggregate,
+			exchange.ClientID,
+			exchange.RequestedTokenType,
+			exchange.Audience,
+			exchange.Scopes,
+			exchange.Actor.UserID,
+			exchange.Actor.ResourceOwner,
+		)
+	}
+	var actorUserID, actorResourceOwner string
+	if exchange.Actor != nil {
+		actorUserID, actorResourceOwner = exchange.Actor.UserID, exchange.Actor.ResourceOwner
+	}
+	return user.NewUserTokenExchangedEvent(
+		ctx,
+		aggregate,
+		exchange.ClientID,
+		exchange.SubjectTokenType,
+		exchange.RequestedTokenType,
+		exchange.Audience,
+		exchange.Scopes,
+		actorUserID,
+		actorResourceOwner,
+	)
+}
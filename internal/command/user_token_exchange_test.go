@@ -0,0 +1,444 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_ExchangeUserToken(t *testing.T) {
+	type fields struct {
+		eventstore      *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx      context.Context
+		exchange *TokenExchange
+	}
+	type res struct {
+		err func(error) bool
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userID missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					ClientID: "client1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "impersonation without actor, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					ResourceOwner: "org1",
+					ClientID:      "client1",
+					Impersonation: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "delegation disabled, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					ResourceOwner: "org1",
+					ClientID:      "client1",
+					Actor:         &TokenActor{UserID: "actor1", ResourceOwner: "org2"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "impersonation disabled, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						securityPolicySetEvent(t, instance.ChangeSecurityPolicyEnableDelegation(true)),
+					),
+				),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					ResourceOwner: "org1",
+					ClientID:      "client1",
+					Actor:         &TokenActor{UserID: "actor1", ResourceOwner: "org2"},
+					Impersonation: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "delegation disabled by org, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						securityPolicySetEvent(t, instance.ChangeSecurityPolicyEnableDelegation(true)),
+					),
+					expectFilter(
+						tokenExchangePolicySetEvent(t, org.ChangeTokenExchangePolicyDisableDelegation(true)),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					ResourceOwner: "org1",
+					ClientID:      "client1",
+					Actor:         &TokenActor{UserID: "actor1", ResourceOwner: "org2"},
+				},
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "impersonation disabled by org, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						securityPolicySetEvent(t, instance.ChangeSecurityPolicyEnableImpersonation(true)),
+					),
+					expectFilter(
+						tokenExchangePolicySetEvent(t, org.ChangeTokenExchangePolicyDisableImpersonation(true)),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					ResourceOwner: "org1",
+					ClientID:      "client1",
+					Actor:         &TokenActor{UserID: "actor1", ResourceOwner: "org2"},
+					Impersonation: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "impersonation missing permission, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						securityPolicySetEvent(t, instance.ChangeSecurityPolicyEnableImpersonation(true)),
+					),
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					ResourceOwner: "org1",
+					ClientID:      "client1",
+					Actor:         &TokenActor{UserID: "actor1", ResourceOwner: "org2"},
+					Impersonation: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					ResourceOwner: "org1",
+					ClientID:      "client1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "exchange without actor, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						tokenExchangeUserAddedEvent(),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewUserTokenExchangedEvent(ctx,
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"client1",
+									"urn:ietf:params:oauth:token-type:access_token",
+									"urn:ietf:params:oauth:token-type:access_token",
+									[]string{"project1"},
+									[]string{"openid"},
+									"",
+									"",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:             "user1",
+					ResourceOwner:      "org1",
+					ClientID:           "client1",
+					SubjectTokenType:   "urn:ietf:params:oauth:token-type:access_token",
+					RequestedTokenType: "urn:ietf:params:oauth:token-type:access_token",
+					Audience:           []string{"project1"},
+					Scopes:             []string{"openid"},
+				},
+			},
+			res: res{},
+		},
+		{
+			name: "delegation on behalf of itself, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						securityPolicySetEvent(t, instance.ChangeSecurityPolicyEnableDelegation(true)),
+					),
+					expectFilter(),
+					expectFilter(
+						tokenExchangeUserAddedEvent(),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewUserTokenExchangedEvent(ctx,
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"client1",
+									"urn:ietf:params:oauth:token-type:access_token",
+									"urn:ietf:params:oauth:token-type:access_token",
+									nil,
+									nil,
+									"user1",
+									"org1",
+								),
+							),
+						},
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:             "user1",
+					ResourceOwner:      "org1",
+					ClientID:           "client1",
+					SubjectTokenType:   "urn:ietf:params:oauth:token-type:access_token",
+					RequestedTokenType: "urn:ietf:params:oauth:token-type:access_token",
+					Actor:              &TokenActor{UserID: "user1", ResourceOwner: "org1"},
+				},
+			},
+			res: res{},
+		},
+		{
+			name: "delegation, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						securityPolicySetEvent(t, instance.ChangeSecurityPolicyEnableDelegation(true)),
+					),
+					expectFilter(),
+					expectFilter(
+						tokenExchangeUserAddedEvent(),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewUserTokenExchangedEvent(ctx,
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"client1",
+									"urn:ietf:params:oauth:token-type:access_token",
+									"urn:ietf:params:oauth:token-type:access_token",
+									nil,
+									nil,
+									"actor1",
+									"org2",
+								),
+							),
+						},
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:             "user1",
+					ResourceOwner:      "org1",
+					ClientID:           "client1",
+					SubjectTokenType:   "urn:ietf:params:oauth:token-type:access_token",
+					RequestedTokenType: "urn:ietf:params:oauth:token-type:access_token",
+					Actor:              &TokenActor{UserID: "actor1", ResourceOwner: "org2"},
+				},
+			},
+			res: res{},
+		},
+		{
+			name: "impersonation, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						securityPolicySetEvent(t, instance.ChangeSecurityPolicyEnableImpersonation(true)),
+					),
+					expectFilter(),
+					expectFilter(
+						tokenExchangeUserAddedEvent(),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("instance1",
+								user.NewUserImpersonatedEvent(ctx,
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"client1",
+									"urn:ietf:params:oauth:token-type:id_token",
+									[]string{"project1"},
+									[]string{"openid", "profile"},
+									"actor1",
+									"org2",
+								),
+							),
+						},
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: ctx,
+				exchange: &TokenExchange{
+					UserID:             "user1",
+					ResourceOwner:      "org1",
+					ClientID:           "client1",
+					SubjectTokenType:   "urn:zitadel:params:oauth:token-type:user_id",
+					RequestedTokenType: "urn:ietf:params:oauth:token-type:id_token",
+					Audience:           []string{"project1"},
+					Scopes:             []string{"openid", "profile"},
+					Actor:              &TokenActor{UserID: "actor1", ResourceOwner: "org2"},
+					Impersonation:      true,
+				},
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:      tt.fields.eventstore,
+				checkPermission: tt.fields.checkPermission,
+			}
+			err := r.ExchangeUserToken(tt.args.ctx, tt.args.exchange)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func securityPolicySetEvent(t *testing.T, changes ...instance.SecurityPolicyChanges) *repository.Event {
+	event, err := instance.NewSecurityPolicySetEvent(context.Background(),
+		&instance.NewAggregate("instance1").Aggregate,
+		changes,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return eventFromEventPusherWithInstanceID("instance1", event)
+}
+
+func tokenExchangePolicySetEvent(t *testing.T, changes ...org.TokenExchangePolicyChanges) *repository.Event {
+	return eventFromEventPusher(tokenExchangePolicySetEventCommand(t, changes...))
+}
+
+func tokenExchangeUserAddedEvent() *repository.Event {
+	return eventFromEventPusher(
+		user.NewHumanAddedEvent(context.Background(),
+			&user.NewAggregate("user1", "org1").Aggregate,
+			"username",
+			"firstname",
+			"lastname",
+			"nickname",
+			"displayname",
+			language.German,
+			domain.GenderUnspecified,
+			"email@test.ch",
+			true,
+		),
+	)
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"net/url"
 	"strings"
 	"time"
 
@@ -25,27 +26,30 @@ const (
 type OIDCApp struct {
 	models.ObjectRoot
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	ClientSecret             *crypto.CryptoValue
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []OIDCResponseType
-	GrantTypes               []OIDCGrantType
-	ApplicationType          OIDCApplicationType
-	AuthMethodType           OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              OIDCVersion
-	Compliance               *Compliance
-	DevMode                  bool
-	AccessTokenType          OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
+	AppID                     string
+	AppName                   string
+	ClientID                  string
+	ClientSecret              *crypto.CryptoValue
+	ClientSecretString        string
+	RedirectUris              []string
+	ResponseTypes             []OIDCResponseType
+	GrantTypes                []OIDCGrantType
+	ApplicationType           OIDCApplicationType
+	AuthMethodType            OIDCAuthMethodType
+	PostLogoutRedirectUris    []string
+	OIDCVersion               OIDCVersion
+	Compliance                *Compliance
+	DevMode                   bool
+	AccessTokenType           OIDCTokenType
+	AccessTokenRoleAssertion  bool
+	IDTokenRoleAssertion      bool
+	IDTokenUserinfoAssertion  bool
+	ClockSkew                 time.Duration
+	AdditionalOrigins         []string
+	SkipNativeAppSuccessPage  bool
+	BackChannelLogoutURI      string
+	RequirePushedAuthRequests bool
+	RequireDPoP               bool
 
 	State AppState
 }
@@ -91,6 +95,7 @@ const (
 	OIDCGrantTypeImplicit
 	OIDCGrantTypeRefreshToken
 	OIDCGrantTypeDeviceCode
+	OIDCGrantTypeTokenExchange
 )
 
 type OIDCApplicationType int32
@@ -123,7 +128,7 @@ const (
 )
 
 func (a *OIDCApp) IsValid() bool {
-	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
+	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() || !IsBackChannelLogoutURIValid(a.BackChannelLogoutURI) {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
@@ -139,6 +144,43 @@ func (a *OIDCApp) IsValid() bool {
 	return true
 }
 
+// IsBackChannelLogoutURIValid checks the optional back-channel logout uri,
+// which must be an absolute http(s) url without fragment (OpenID Connect Back-Channel Logout 1.0 section 2.2)
+func IsBackChannelLogoutURIValid(uri string) bool {
+	if uri == "" {
+		return true
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" && parsed.Fragment == ""
+}
+
+// IsRedirectURIValid checks a (post logout) redirect uri against the compliance rules of the application type:
+// https, http only for web applications or on the loopback interface for native applications
+// and custom schemes only for native applications. The uri must be absolute and without fragment (RFC 6749 section 3.1.2).
+func IsRedirectURIValid(uri string, appType OIDCApplicationType) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme == "" || parsed.Fragment != "" {
+		return false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "https":
+		return parsed.Host != "" && strings.HasPrefix(uri, https)
+	case "http":
+		if parsed.Host == "" || !strings.HasPrefix(uri, http) {
+			return false
+		}
+		return appType == OIDCApplicationTypeWeb ||
+			appType == OIDCApplicationTypeNative && isHTTPLoopbackLocalhost(uri)
+	case "javascript", "data", "vbscript", "file", "blob", "about":
+		return false
+	default:
+		return appType == OIDCApplicationTypeNative
+	}
+}
+
 func (a *OIDCApp) OriginsValid() bool {
 	for _, origin := range a.AdditionalOrigins {
 		if !http_util.IsOrigin(origin) {
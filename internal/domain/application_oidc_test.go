@@ -603,6 +603,35 @@ func TestCheckRedirectUrisCode(t *testing.T) {
 	}
 }
 
+func TestIsRedirectURIValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		appType OIDCApplicationType
+		want    bool
+	}{
+		{"https", "https://client.com/callback", OIDCApplicationTypeUserAgent, true},
+		{"https without host", "https:/callback", OIDCApplicationTypeWeb, false},
+		{"fragment", "https://client.com/callback#fragment", OIDCApplicationTypeWeb, false},
+		{"relative", "/callback", OIDCApplicationTypeWeb, false},
+		{"http web", "http://client.com/callback", OIDCApplicationTypeWeb, true},
+		{"http user agent", "http://client.com/callback", OIDCApplicationTypeUserAgent, false},
+		{"http native loopback", "http://127.0.0.1:8080/callback", OIDCApplicationTypeNative, true},
+		{"http native", "http://client.com/callback", OIDCApplicationTypeNative, false},
+		{"custom native", "com.client.app:/callback", OIDCApplicationTypeNative, true},
+		{"custom web", "com.client.app:/callback", OIDCApplicationTypeWeb, false},
+		{"javascript native", "javascript:alert(1)", OIDCApplicationTypeNative, false},
+		{"data native", "data:text/html,<script>alert(1)</script>", OIDCApplicationTypeNative, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRedirectURIValid(tt.uri, tt.appType); got != tt.want {
+				t.Errorf("IsRedirectURIValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestOIDCOriginAllowList(t *testing.T) {
 	type args struct {
 		redirectUris      []string
@@ -12,6 +12,10 @@ type SAMLApp struct {
 	EntityID    string
 	Metadata    []byte
 	MetadataURL string
+	// RoleAssertion defines if the project roles of the user are asserted as attribute in the SAML response
+	RoleAssertion bool
+	// MetadataAssertion defines if the metadata of the user is asserted as attributes in the SAML response
+	MetadataAssertion bool
 
 	State AppState
 }
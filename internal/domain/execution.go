@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"strings"
+)
+
+type ExecutionType uint
+
+const (
+	ExecutionTypeUnspecified ExecutionType = iota
+	ExecutionTypeRequest
+	ExecutionTypeResponse
+	ExecutionTypeEvent
+	ExecutionTypeFunction
+	executionTypeCount
+)
+
+func (e ExecutionType) Valid() bool {
+	return e > ExecutionTypeUnspecified && e < executionTypeCount
+}
+
+func (e ExecutionType) String() string {
+	switch e {
+	case ExecutionTypeRequest:
+		return "request"
+	case ExecutionTypeResponse:
+		return "response"
+	case ExecutionTypeEvent:
+		return "event"
+	case ExecutionTypeFunction:
+		return "function"
+	default:
+		return ""
+	}
+}
+
+const (
+	executionIDSeparator = "/"
+	executionGroupSuffix = ".*"
+)
+
+// ExecutionIDForAll returns the execution id which matches all conditions of the type
+func ExecutionIDForAll(executionType ExecutionType) string {
+	return executionType.String()
+}
+
+// ExecutionIDForMethod returns the execution id for a request or response of a gRPC method (e.g. /zitadel.session.v2alpha.SessionService/CreateSession)
+func ExecutionIDForMethod(executionType ExecutionType, fullMethod string) string {
+	return executionType.String() + executionIDSeparator + strings.TrimPrefix(fullMethod, "/")
+}
+
+// ExecutionIDForService returns the execution id for a request or response of all methods of a gRPC service (e.g. zitadel.session.v2alpha.SessionService)
+func ExecutionIDForService(executionType ExecutionType, service string) string {
+	return executionType.String() + executionIDSeparator + strings.Trim(service, "/")
+}
+
+// ExecutionIDForEvent returns the execution id for a specific event type (e.g. user.human.added)
+func ExecutionIDForEvent(eventType string) string {
+	return ExecutionTypeEvent.String() + executionIDSeparator + eventType
+}
+
+// ExecutionIDForEventGroup returns the execution id for all events of an aggregate type (e.g. user)
+func ExecutionIDForEventGroup(aggregateType string) string {
+	return ExecutionTypeEvent.String() + executionIDSeparator + aggregateType + executionGroupSuffix
+}
+
+// ExecutionIDForFunction returns the execution id for a trigger of a flow
+func ExecutionIDForFunction(flowType FlowType, triggerType TriggerType) string {
+	return ExecutionTypeFunction.String() + executionIDSeparator + flowType.ID() + executionIDSeparator + triggerType.ID()
+}
+
+// ExecutionIDsForMethod returns all execution ids which have to be checked for a request or response of a gRPC method,
+// ordered from the most to the least specific
+func ExecutionIDsForMethod(executionType ExecutionType, fullMethod string) []string {
+	ids := []string{ExecutionIDForMethod(executionType, fullMethod)}
+	if service, _, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/"); ok {
+		ids = append(ids, ExecutionIDForService(executionType, service))
+	}
+	return append(ids, ExecutionIDForAll(executionType))
+}
+
+// ExecutionIDsForEvent returns all execution ids which have to be checked for an event,
+// ordered from the most to the least specific
+func ExecutionIDsForEvent(aggregateType, eventType string) []string {
+	return []string{
+		ExecutionIDForEvent(eventType),
+		ExecutionIDForEventGroup(aggregateType),
+		ExecutionIDForAll(ExecutionTypeEvent),
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionIDsForMethod(t *testing.T) {
+	tests := []struct {
+		name          string
+		executionType ExecutionType
+		fullMethod    string
+		want          []string
+	}{
+		{
+			name:          "request",
+			executionType: ExecutionTypeRequest,
+			fullMethod:    "/zitadel.session.v2alpha.SessionService/CreateSession",
+			want: []string{
+				"request/zitadel.session.v2alpha.SessionService/CreateSession",
+				"request/zitadel.session.v2alpha.SessionService",
+				"request",
+			},
+		},
+		{
+			name:          "response",
+			executionType: ExecutionTypeResponse,
+			fullMethod:    "/zitadel.session.v2alpha.SessionService/CreateSession",
+			want: []string{
+				"response/zitadel.session.v2alpha.SessionService/CreateSession",
+				"response/zitadel.session.v2alpha.SessionService",
+				"response",
+			},
+		},
+		{
+			name:          "no service",
+			executionType: ExecutionTypeRequest,
+			fullMethod:    "method",
+			want: []string{
+				"request/method",
+				"request",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExecutionIDsForMethod(tt.executionType, tt.fullMethod))
+		})
+	}
+}
+
+func TestExecutionIDsForEvent(t *testing.T) {
+	assert.Equal(t,
+		[]string{"event/user.human.added", "event/user.*", "event"},
+		ExecutionIDsForEvent("user", "user.human.added"),
+	)
+}
+
+func TestExecutionIDForFunction(t *testing.T) {
+	assert.Equal(t,
+		"function/3/1",
+		ExecutionIDForFunction(FlowTypeInternalAuthentication, TriggerTypePostAuthentication),
+	)
+}
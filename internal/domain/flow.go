@@ -21,6 +21,10 @@ const (
 	FlowTypeExternalAuthentication
 	FlowTypeCustomiseToken
 	FlowTypeInternalAuthentication
+	FlowTypePasswordReset
+	FlowTypeSelfRegistration
+	FlowTypeTokenExchange
+	FlowTypeCustomiseSAMLResponse
 	flowTypeCount
 )
 
@@ -56,6 +60,24 @@ func (s FlowType) TriggerTypes() []TriggerType {
 			TriggerTypePreCreation,
 			TriggerTypePostCreation,
 		}
+	case FlowTypePasswordReset:
+		return []TriggerType{
+			TriggerTypePreNotification,
+		}
+	case FlowTypeSelfRegistration:
+		return []TriggerType{
+			TriggerTypePreCreation,
+			TriggerTypePostCreation,
+		}
+	case FlowTypeTokenExchange:
+		return []TriggerType{
+			TriggerTypePreRefreshTokenCreation,
+			TriggerTypePreTokenExchange,
+		}
+	case FlowTypeCustomiseSAMLResponse:
+		return []TriggerType{
+			TriggerTypePreSAMLResponseCreation,
+		}
 	default:
 		return nil
 	}
@@ -80,6 +102,14 @@ func (s FlowType) LocalizationKey() string {
 		return "Action.Flow.Type.CustomiseToken"
 	case FlowTypeInternalAuthentication:
 		return "Action.Flow.Type.InternalAuthentication"
+	case FlowTypePasswordReset:
+		return "Action.Flow.Type.PasswordReset"
+	case FlowTypeSelfRegistration:
+		return "Action.Flow.Type.SelfRegistration"
+	case FlowTypeTokenExchange:
+		return "Action.Flow.Type.TokenExchange"
+	case FlowTypeCustomiseSAMLResponse:
+		return "Action.Flow.Type.CustomiseSAMLResponse"
 	default:
 		return "Action.Flow.Type.Unspecified"
 	}
@@ -94,6 +124,10 @@ const (
 	TriggerTypePostCreation
 	TriggerTypePreUserinfoCreation
 	TriggerTypePreAccessTokenCreation
+	TriggerTypePreNotification
+	TriggerTypePreRefreshTokenCreation
+	TriggerTypePreSAMLResponseCreation
+	TriggerTypePreTokenExchange
 	triggerTypeCount
 )
 
@@ -124,6 +158,14 @@ func (s TriggerType) LocalizationKey() string {
 		return "Action.TriggerType.PreUserinfoCreation"
 	case TriggerTypePreAccessTokenCreation:
 		return "Action.TriggerType.PreAccessTokenCreation"
+	case TriggerTypePreNotification:
+		return "Action.TriggerType.PreNotification"
+	case TriggerTypePreRefreshTokenCreation:
+		return "Action.TriggerType.PreRefreshTokenCreation"
+	case TriggerTypePreSAMLResponseCreation:
+		return "Action.TriggerType.PreSAMLResponseCreation"
+	case TriggerTypePreTokenExchange:
+		return "Action.TriggerType.PreTokenExchange"
 	default:
 		return "Action.TriggerType.Unspecified"
 	}
@@ -0,0 +1,44 @@
+package domain
+
+import es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+
+type GroupState int32
+
+const (
+	GroupStateUnspecified GroupState = iota
+	GroupStateActive
+	GroupStateRemoved
+	groupStateCount
+)
+
+func (s GroupState) Valid() bool {
+	return s >= 0 && s < groupStateCount
+}
+
+func (s GroupState) Exists() bool {
+	return s != GroupStateUnspecified && s != GroupStateRemoved
+}
+
+// GroupGrant grants the roles of a project to all members of a group,
+// the AggregateID of the ObjectRoot is the id of the group
+type GroupGrant struct {
+	es_models.ObjectRoot
+
+	GrantID        string
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+}
+
+func (g *GroupGrant) IsValid() bool {
+	return g.AggregateID != "" && g.ProjectID != ""
+}
+
+func (g *GroupGrant) HasInvalidRoles(validRoles []string) bool {
+	for _, roleKey := range g.RoleKeys {
+		if !containsRoleKey(roleKey, validRoles) {
+			return true
+		}
+	}
+	return false
+}
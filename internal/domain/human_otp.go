@@ -1,8 +1,11 @@
 package domain
 
 import (
+	"io"
+
 	"github.com/pquerna/otp"
 	"github.com/pquerna/otp/totp"
+	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
@@ -39,3 +42,17 @@ func VerifyTOTP(code string, secret *crypto.CryptoValue, cryptoAlg crypto.Encryp
 	}
 	return nil
 }
+
+type OTPEmailURLData struct {
+	Code              string
+	UserID            string
+	LoginName         string
+	DisplayName       string
+	PreferredLanguage language.Tag
+}
+
+// RenderOTPEmailURLTemplate parses and renders tmpl.
+// code, userID, (preferred) loginName, displayName and preferredLanguage are passed into the [OTPEmailURLData].
+func RenderOTPEmailURLTemplate(w io.Writer, tmpl, code, userID, loginName, displayName string, preferredLanguage language.Tag) error {
+	return renderURLTemplate(w, tmpl, &OTPEmailURLData{code, userID, loginName, displayName, preferredLanguage})
+}
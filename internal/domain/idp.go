@@ -36,6 +36,8 @@ const (
 	IDPTypeGitLab
 	IDPTypeGitLabSelfHosted
 	IDPTypeGoogle
+	IDPTypeSAML
+	IDPTypeApple
 )
 
 func (t IDPType) GetCSSClass() string {
@@ -54,7 +56,9 @@ func (t IDPType) GetCSSClass() string {
 		IDPTypeOIDC,
 		IDPTypeJWT,
 		IDPTypeOAuth,
-		IDPTypeLDAP:
+		IDPTypeLDAP,
+		IDPTypeSAML,
+		IDPTypeApple:
 		fallthrough
 	default:
 		return ""
@@ -78,6 +82,8 @@ func (t IDPType) DisplayName() string {
 		return "GitLab"
 	case IDPTypeGoogle:
 		return "Google"
+	case IDPTypeApple:
+		return "Apple"
 	case IDPTypeUnspecified,
 		IDPTypeOIDC,
 		IDPTypeJWT,
@@ -85,7 +91,8 @@ func (t IDPType) DisplayName() string {
 		IDPTypeLDAP,
 		IDPTypeAzureAD,
 		IDPTypeGitHubEnterprise,
-		IDPTypeGitLabSelfHosted:
+		IDPTypeGitLabSelfHosted,
+		IDPTypeSAML:
 		fallthrough
 	default:
 		// we should never get here, so log it
@@ -94,6 +101,21 @@ func (t IDPType) DisplayName() string {
 	}
 }
 
+// SAMLBinding defines how the AuthnRequest is sent to a SAML identity provider
+type SAMLBinding int32
+
+const (
+	SAMLBindingUnspecified SAMLBinding = iota
+	SAMLBindingPost
+	SAMLBindingRedirect
+
+	samlBindingCount
+)
+
+func (b SAMLBinding) Valid() bool {
+	return b > SAMLBindingUnspecified && b < samlBindingCount
+}
+
 type IDPIntentState int32
 
 const (
@@ -1,5 +1,9 @@
 package domain
 
+import (
+	"time"
+)
+
 type NextStep interface {
 	Type() NextStepType
 }
@@ -29,6 +33,7 @@ const (
 	NextStepProjectRequired
 	NextStepRedirectToExternalIDP
 	NextStepLoginSucceeded
+	NextStepPasswordExpiryWarning
 )
 
 type LoginStep struct{}
@@ -117,12 +122,22 @@ func (s *PasswordlessRegistrationPromptStep) Type() NextStepType {
 	return NextStepPasswordlessRegistrationPrompt
 }
 
-type ChangePasswordStep struct{}
+type ChangePasswordStep struct {
+	Expired bool
+}
 
 func (s *ChangePasswordStep) Type() NextStepType {
 	return NextStepChangePassword
 }
 
+type PasswordExpiryWarningStep struct {
+	ExpirationDate time.Time
+}
+
+func (s *PasswordExpiryWarningStep) Type() NextStepType {
+	return NextStepPasswordExpiryWarning
+}
+
 type InitPasswordStep struct{}
 
 func (s *InitPasswordStep) Type() NextStepType {
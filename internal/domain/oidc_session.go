@@ -7,3 +7,9 @@ const (
 	OIDCSessionStateActive
 	OIDCSessionStateTerminated
 )
+
+// UserAgentSessionID returns the session id (`sid` claim) of tokens issued on a user agent (V1 sessions).
+// As multiple users can be signed in on the same user agent, the session is identified by the user agent and the user.
+func UserAgentSessionID(userAgentID, userID string) string {
+	return userAgentID + ":" + userID
+}
@@ -31,4 +31,6 @@ const (
 	PermissionUserRead      = "user.read"
 	PermissionSessionWrite  = "session.write"
 	PermissionSessionDelete = "session.delete"
+	PermissionImpersonation = "impersonation"
+	PermissionDelegation    = "delegation"
 )
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -10,3 +12,28 @@ type PasswordAgePolicy struct {
 	MaxAgeDays     uint64
 	ExpireWarnDays uint64
 }
+
+type PasswordExpiryState int32
+
+const (
+	PasswordExpiryStateValid PasswordExpiryState = iota
+	PasswordExpiryStateWarning
+	PasswordExpiryStateExpired
+)
+
+// PasswordExpiry returns the expiration date of a password changed at the provided date
+// and its state at the time of now.
+// Passwords do not expire (zero expiration date) if the policy does not define a MaxAgeDays.
+func (p *PasswordAgePolicy) PasswordExpiry(changed, now time.Time) (time.Time, PasswordExpiryState) {
+	if p == nil || p.MaxAgeDays == 0 || changed.IsZero() {
+		return time.Time{}, PasswordExpiryStateValid
+	}
+	expirationDate := changed.AddDate(0, 0, int(p.MaxAgeDays))
+	if !now.Before(expirationDate) {
+		return expirationDate, PasswordExpiryStateExpired
+	}
+	if p.ExpireWarnDays > 0 && !now.Before(expirationDate.AddDate(0, 0, -int(p.ExpireWarnDays))) {
+		return expirationDate, PasswordExpiryStateWarning
+	}
+	return expirationDate, PasswordExpiryStateValid
+}
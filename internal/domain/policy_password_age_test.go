@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordAgePolicy_PasswordExpiry(t *testing.T) {
+	changed := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		changed time.Time
+		now     time.Time
+	}
+	type want struct {
+		expirationDate time.Time
+		state          PasswordExpiryState
+	}
+	tests := []struct {
+		name   string
+		policy *PasswordAgePolicy
+		args   args
+		want   want
+	}{
+		{
+			"no policy, valid",
+			nil,
+			args{
+				changed: changed,
+				now:     changed.AddDate(1, 0, 0),
+			},
+			want{
+				state: PasswordExpiryStateValid,
+			},
+		},
+		{
+			"no max age, valid",
+			&PasswordAgePolicy{ExpireWarnDays: 10},
+			args{
+				changed: changed,
+				now:     changed.AddDate(1, 0, 0),
+			},
+			want{
+				state: PasswordExpiryStateValid,
+			},
+		},
+		{
+			"no change date, valid",
+			&PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+			args{
+				now: changed,
+			},
+			want{
+				state: PasswordExpiryStateValid,
+			},
+		},
+		{
+			"before warn window, valid",
+			&PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+			args{
+				changed: changed,
+				now:     changed.AddDate(0, 0, 19),
+			},
+			want{
+				expirationDate: changed.AddDate(0, 0, 30),
+				state:          PasswordExpiryStateValid,
+			},
+		},
+		{
+			"within warn window, warning",
+			&PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+			args{
+				changed: changed,
+				now:     changed.AddDate(0, 0, 20),
+			},
+			want{
+				expirationDate: changed.AddDate(0, 0, 30),
+				state:          PasswordExpiryStateWarning,
+			},
+		},
+		{
+			"no warn days, valid until expired",
+			&PasswordAgePolicy{MaxAgeDays: 30},
+			args{
+				changed: changed,
+				now:     changed.AddDate(0, 0, 29),
+			},
+			want{
+				expirationDate: changed.AddDate(0, 0, 30),
+				state:          PasswordExpiryStateValid,
+			},
+		},
+		{
+			"max age reached, expired",
+			&PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+			args{
+				changed: changed,
+				now:     changed.AddDate(0, 0, 30),
+			},
+			want{
+				expirationDate: changed.AddDate(0, 0, 30),
+				state:          PasswordExpiryStateExpired,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expirationDate, state := tt.policy.PasswordExpiry(tt.args.changed, tt.args.now)
+			assert.Equal(t, tt.want.expirationDate, expirationDate)
+			assert.Equal(t, tt.want.state, state)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type PasswordHistoryPolicy struct {
+	models.ObjectRoot
+
+	// HistoryCount defines how many of the previous passwords of a user must not be reused.
+	// No previous passwords are checked if it is 0.
+	HistoryCount uint64
+}
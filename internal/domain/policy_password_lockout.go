@@ -1,13 +1,56 @@
 package domain
 
 import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
+// maxProgressiveLockoutShift caps the doubling of the lockout duration
+// so that progressive lockouts can't overflow
+const maxProgressiveLockoutShift = 10
+
 type LockoutPolicy struct {
 	models.ObjectRoot
 
 	Default             bool
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
 	ShowLockOutFailures bool
+	// LockoutDuration defines how long a user stays locked, 0 requires a manual unlock
+	LockoutDuration time.Duration
+	// AttemptResetWindow defines after which time failed attempts are no longer counted, 0 counts them until a successful check
+	AttemptResetWindow time.Duration
+	// ProgressiveLockout doubles the LockoutDuration for every consecutive lockout
+	ProgressiveLockout bool
+}
+
+func (p *LockoutPolicy) IsValid() error {
+	if p.LockoutDuration < 0 || p.AttemptResetWindow < 0 {
+		return errors.ThrowInvalidArgument(nil, "DOMAIN-3m9Sd", "Errors.Policy.Lockout.InvalidDuration")
+	}
+	return nil
+}
+
+// LockoutDurationFor returns the duration of a lockout after the given amount of consecutive previous lockouts
+func (p *LockoutPolicy) LockoutDurationFor(previousLockouts uint64) time.Duration {
+	if p == nil || p.LockoutDuration <= 0 {
+		return 0
+	}
+	if !p.ProgressiveLockout {
+		return p.LockoutDuration
+	}
+	if previousLockouts > maxProgressiveLockoutShift {
+		previousLockouts = maxProgressiveLockoutShift
+	}
+	return p.LockoutDuration << previousLockouts
+}
+
+// FailedAttemptsSince returns the earliest point in time from which failed attempts are counted
+func (p *LockoutPolicy) FailedAttemptsSince(now time.Time) time.Time {
+	if p == nil || p.AttemptResetWindow <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-p.AttemptResetWindow)
 }
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockoutPolicy_LockoutDurationFor(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           *LockoutPolicy
+		previousLockouts uint64
+		want             time.Duration
+	}{
+		{
+			"no policy, manual unlock",
+			nil,
+			0,
+			0,
+		},
+		{
+			"no duration, manual unlock",
+			&LockoutPolicy{ProgressiveLockout: true},
+			3,
+			0,
+		},
+		{
+			"not progressive, duration",
+			&LockoutPolicy{LockoutDuration: time.Minute},
+			3,
+			time.Minute,
+		},
+		{
+			"progressive, doubled duration",
+			&LockoutPolicy{LockoutDuration: time.Minute, ProgressiveLockout: true},
+			3,
+			8 * time.Minute,
+		},
+		{
+			"progressive, capped duration",
+			&LockoutPolicy{LockoutDuration: time.Minute, ProgressiveLockout: true},
+			100,
+			1024 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.LockoutDurationFor(tt.previousLockouts))
+		})
+	}
+}
+
+func TestLockoutPolicy_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *LockoutPolicy
+		wantErr bool
+	}{
+		{
+			"empty, ok",
+			&LockoutPolicy{},
+			false,
+		},
+		{
+			"durations, ok",
+			&LockoutPolicy{LockoutDuration: time.Hour, AttemptResetWindow: time.Minute},
+			false,
+		},
+		{
+			"negative lockout duration, error",
+			&LockoutPolicy{LockoutDuration: -time.Hour},
+			true,
+		},
+		{
+			"negative attempt reset window, error",
+			&LockoutPolicy{AttemptResetWindow: -time.Minute},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.IsValid()
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -47,3 +47,17 @@ func (s PrivateLabelingSetting) Valid() bool {
 func (o *Project) IsValid() bool {
 	return o.Name != ""
 }
+
+type InitialAccessTokenState int32
+
+const (
+	InitialAccessTokenStateUnspecified InitialAccessTokenState = iota
+	InitialAccessTokenStateActive
+	InitialAccessTokenStateRemoved
+
+	initialAccessTokenStateCount
+)
+
+func (s InitialAccessTokenState) Valid() bool {
+	return s >= 0 && s < initialAccessTokenStateCount
+}
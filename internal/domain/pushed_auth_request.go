@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PushedAuthRequestURIPrefix is the prefix of the request_uri returned by the
+// pushed authorization request endpoint (RFC 9126 section 2.2)
+const PushedAuthRequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+// PushedAuthRequest holds the parameters of an authorization request, which were pushed
+// by the client and are referenced in the authorization request by their request_uri
+type PushedAuthRequest struct {
+	ID           string
+	InstanceID   string
+	ClientID     string
+	Parameters   url.Values
+	CreationDate time.Time
+	Expiration   time.Time
+}
+
+func (r *PushedAuthRequest) RequestURI() string {
+	return PushedAuthRequestURIPrefix + r.ID
+}
+
+func (r *PushedAuthRequest) IsExpired(now time.Time) bool {
+	return !now.Before(r.Expiration)
+}
+
+// PushedAuthRequestIDFromURI returns the id of the pushed authorization request of the request_uri,
+// if it was issued by the pushed authorization request endpoint
+func PushedAuthRequestIDFromURI(requestURI string) (string, bool) {
+	id, ok := strings.CutPrefix(requestURI, PushedAuthRequestURIPrefix)
+	return id, ok && id != ""
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushedAuthRequestIDFromURI(t *testing.T) {
+	tests := []struct {
+		name       string
+		requestURI string
+		wantID     string
+		wantOK     bool
+	}{
+		{
+			"pushed request",
+			"urn:ietf:params:oauth:request_uri:123",
+			"123",
+			true,
+		},
+		{
+			"missing id",
+			"urn:ietf:params:oauth:request_uri:",
+			"",
+			false,
+		},
+		{
+			"other request_uri",
+			"https://client.com/request.jwt",
+			"",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := PushedAuthRequestIDFromURI(tt.requestURI)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.wantID, id)
+			}
+		})
+	}
+}
+
+func TestPushedAuthRequest_IsExpired(t *testing.T) {
+	now := time.Now()
+	request := &PushedAuthRequest{ID: "123", Expiration: now}
+	assert.Equal(t, "urn:ietf:params:oauth:request_uri:123", request.RequestURI())
+	assert.False(t, request.IsExpired(now.Add(-time.Second)))
+	assert.True(t, request.IsExpired(now))
+}
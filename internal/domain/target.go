@@ -0,0 +1,18 @@
+package domain
+
+type TargetState int32
+
+const (
+	TargetStateUnspecified TargetState = iota
+	TargetStateActive
+	TargetStateRemoved
+	targetStateCount
+)
+
+func (s TargetState) Valid() bool {
+	return s >= 0 && s < targetStateCount
+}
+
+func (s TargetState) Exists() bool {
+	return s != TargetStateUnspecified && s != TargetStateRemoved
+}
@@ -22,7 +22,9 @@ type Eventstore struct {
 	eventInterceptors map[EventType]eventTypeInterceptors
 	eventTypes        []string
 	aggregateTypes    []string
-	PushTimeout       time.Duration
+	// aggregateEventTypes are the event types registered per aggregate type
+	aggregateEventTypes map[AggregateType][]EventType
+	PushTimeout         time.Duration
 }
 
 type eventTypeInterceptors struct {
@@ -84,6 +86,11 @@ func (es *Eventstore) AggregateTypes() []string {
 	return es.aggregateTypes
 }
 
+// AggregateEventTypes returns the event types registered for the aggregate type
+func (es *Eventstore) AggregateEventTypes(aggregateType AggregateType) []EventType {
+	return es.aggregateEventTypes[aggregateType]
+}
+
 func commandsToRepository(instanceID string, cmds []Command) (events []*repository.Event, constraints []*repository.UniqueConstraint, err error) {
 	events = make([]*repository.Event, len(cmds))
 	for i, cmd := range cmds {
@@ -244,6 +251,7 @@ func (es *Eventstore) RegisterFilterEventMapper(aggregateType AggregateType, eve
 
 	es.appendEventType(eventType)
 	es.appendAggregateType(aggregateType)
+	es.appendAggregateEventType(aggregateType, eventType)
 
 	interceptor := es.eventInterceptors[eventType]
 	interceptor.eventMapper = mapper
@@ -268,6 +276,18 @@ func (es *Eventstore) appendAggregateType(typ AggregateType) {
 	es.aggregateTypes = append(es.aggregateTypes[:i], append([]string{string(typ)}, es.aggregateTypes[i:]...)...)
 }
 
+func (es *Eventstore) appendAggregateEventType(aggregateType AggregateType, eventType EventType) {
+	if es.aggregateEventTypes == nil {
+		es.aggregateEventTypes = make(map[AggregateType][]EventType)
+	}
+	for _, typ := range es.aggregateEventTypes[aggregateType] {
+		if typ == eventType {
+			return
+		}
+	}
+	es.aggregateEventTypes[aggregateType] = append(es.aggregateEventTypes[aggregateType], eventType)
+}
+
 func EventData(event Command) ([]byte, error) {
 	switch data := event.Data().(type) {
 	case nil:
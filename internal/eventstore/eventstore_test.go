@@ -179,6 +179,27 @@ func Test_eventstore_RegisterFilterEventMapper(t *testing.T) {
 	}
 }
 
+func Test_eventstore_AggregateEventTypes(t *testing.T) {
+	es := &Eventstore{
+		eventInterceptors: map[EventType]eventTypeInterceptors{},
+	}
+	es.RegisterFilterEventMapper("user", "user.added", testFilterMapper).
+		RegisterFilterEventMapper("user", "user.removed", testFilterMapper).
+		RegisterFilterEventMapper("user", "user.added", testFilterMapper).
+		RegisterFilterEventMapper("org", "org.added", testFilterMapper).
+		RegisterFilterEventMapper("org", "", testFilterMapper)
+
+	if got, want := es.AggregateEventTypes("user"), []EventType{"user.added", "user.removed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected event types of user: want %v, got %v", want, got)
+	}
+	if got, want := es.AggregateEventTypes("org"), []EventType{"org.added"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected event types of org: want %v, got %v", want, got)
+	}
+	if got := es.AggregateEventTypes("project"); len(got) != 0 {
+		t.Errorf("unexpected event types of project: %v", got)
+	}
+}
+
 func Test_eventData(t *testing.T) {
 	type args struct {
 		event Command
@@ -24,6 +24,16 @@ type instanceSequence struct {
 	sequence   uint64
 }
 
+// sequence returns the current sequence of the aggregate type on the instance or 0 if it's not projected yet
+func (sequences currentSequences) sequence(aggregateType eventstore.AggregateType, instanceID string) uint64 {
+	for _, sequence := range sequences[aggregateType] {
+		if sequence.instanceID == instanceID {
+			return sequence.sequence
+		}
+	}
+	return 0
+}
+
 func (h *StatementHandler) currentSequences(ctx context.Context, query func(context.Context, string, ...interface{}) (*sql.Rows, error), instanceIDs database.StringArray) (currentSequences, error) {
 	rows, err := query(ctx, h.currentSequenceStmt, h.ProjectionName, instanceIDs)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/zitadel/logging"
 
@@ -30,8 +31,26 @@ type StatementHandlerConfig struct {
 
 	Reducers  []handler.AggregateReducer
 	InitCheck *handler.Check
+	// EventQueries restricts the events reduced by the handler,
+	// all events of the aggregate types of the reducers are reduced if not set
+	EventQueries EventQueries
 }
 
+// EventQuery restricts the events of an aggregate type which are reduced by the handler
+type EventQuery struct {
+	AggregateType eventstore.AggregateType
+	// EventTypes restricts the events to the types, all events of the aggregate type are reduced if empty
+	EventTypes []eventstore.EventType
+	// CreationDateAfter restricts the events to the ones created after the point in time, if set
+	CreationDateAfter time.Time
+}
+
+// EventQueries returns the events of the instance which have to be reduced by the handler.
+// As only a subset of the events of an aggregate type is reduced,
+// the sequences of the reduced events are not checked for gaps
+// and the handler doesn't subscribe to the events pushed in-process.
+type EventQueries func(ctx context.Context, instanceID string) ([]*EventQuery, error)
+
 type StatementHandler struct {
 	*handler.ProjectionHandler
 	Locker
@@ -44,10 +63,11 @@ type StatementHandler struct {
 	failureCountStmt        string
 	setFailureCountStmt     string
 
-	aggregates  []eventstore.AggregateType
-	reduces     map[eventstore.EventType]handler.Reduce
-	initCheck   *handler.Check
-	initialized chan bool
+	aggregates   []eventstore.AggregateType
+	reduces      map[eventstore.EventType]handler.Reduce
+	eventQueries EventQueries
+	initCheck    *handler.Check
+	initialized  chan bool
 
 	bulkLimit uint64
 
@@ -86,6 +106,7 @@ func NewStatementHandler(
 		setFailureCountStmt:        fmt.Sprintf(setFailureCountStmtFormat, config.FailedEventsTable),
 		aggregates:                 aggregateTypes,
 		reduces:                    reduces,
+		eventQueries:               config.EventQueries,
 		bulkLimit:                  config.BulkLimit,
 		Locker:                     NewLocker(config.Client.DB, config.LockTable, config.ProjectionName),
 		initCheck:                  config.InitCheck,
@@ -101,7 +122,7 @@ func NewStatementHandler(
 func (h *StatementHandler) Start() {
 	h.initialized <- true
 	close(h.initialized)
-	if !h.reduceScheduledPseudoEvent {
+	if !h.reduceScheduledPseudoEvent && h.eventQueries == nil {
 		h.Subscribe(h.aggregates...)
 	}
 }
@@ -110,6 +131,9 @@ func (h *StatementHandler) searchQuery(ctx context.Context, instanceIDs []string
 	if h.reduceScheduledPseudoEvent {
 		return nil, 1, nil
 	}
+	if h.eventQueries != nil {
+		return h.eventSearchQuery(ctx, instanceIDs)
+	}
 	return h.dbSearchQuery(ctx, instanceIDs)
 }
 
@@ -123,23 +147,52 @@ func (h *StatementHandler) dbSearchQuery(ctx context.Context, instanceIDs []stri
 
 	for _, aggregateType := range h.aggregates {
 		for _, instanceID := range instanceIDs {
-			var seq uint64
-			for _, sequence := range sequences[aggregateType] {
-				if sequence.instanceID == instanceID {
-					seq = sequence.sequence
-					break
-				}
-			}
 			queryBuilder.
 				AddQuery().
 				AggregateTypes(aggregateType).
-				SequenceGreater(seq).
+				SequenceGreater(sequences.sequence(aggregateType, instanceID)).
 				InstanceID(instanceID)
 		}
 	}
 	return queryBuilder, h.bulkLimit, nil
 }
 
+// eventSearchQuery queries the events returned by the [EventQueries] of the instances,
+// it returns no query if there are no events to reduce
+func (h *StatementHandler) eventSearchQuery(ctx context.Context, instanceIDs []string) (*eventstore.SearchQueryBuilder, uint64, error) {
+	sequences, err := h.currentSequences(ctx, h.client.QueryContext, instanceIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	queryBuilder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).Limit(h.bulkLimit).AllowTimeTravel()
+	queriesAdded := false
+	for _, instanceID := range instanceIDs {
+		eventQueries, err := h.eventQueries(ctx, instanceID)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, eventQuery := range eventQueries {
+			query := queryBuilder.
+				AddQuery().
+				AggregateTypes(eventQuery.AggregateType).
+				SequenceGreater(sequences.sequence(eventQuery.AggregateType, instanceID)).
+				InstanceID(instanceID)
+			if len(eventQuery.EventTypes) > 0 {
+				query.EventTypes(eventQuery.EventTypes...)
+			}
+			if !eventQuery.CreationDateAfter.IsZero() {
+				query.CreationDateAfter(eventQuery.CreationDateAfter)
+			}
+			queriesAdded = true
+		}
+	}
+	if !queriesAdded {
+		return nil, h.bulkLimit, nil
+	}
+	return queryBuilder, h.bulkLimit, nil
+}
+
 // Update implements handler.Update
 func (h *StatementHandler) Update(ctx context.Context, stmts []*handler.Statement, reduce handler.Reduce) (index int, err error) {
 	if len(stmts) == 0 {
@@ -163,7 +216,7 @@ func (h *StatementHandler) Update(ctx context.Context, stmts []*handler.Statemen
 	//checks for events between create statement and current sequence
 	// because there could be events between current sequence and a creation event
 	// and we cannot check via stmt.PreviousSequence
-	if stmts[0].PreviousSequence == 0 {
+	if stmts[0].PreviousSequence == 0 && h.eventQueries == nil {
 		previousStmts, err := h.fetchPreviousStmts(ctx, tx, stmts[0].Sequence, stmts[0].InstanceID, sequences, reduce)
 		if err != nil {
 			tx.Rollback()
@@ -252,7 +305,7 @@ stmts:
 				i--
 				continue stmts
 			}
-			if stmt.PreviousSequence > 0 && stmt.PreviousSequence != sequence.sequence && stmt.InstanceID == sequence.instanceID {
+			if h.eventQueries == nil && stmt.PreviousSequence > 0 && stmt.PreviousSequence != sequence.sequence && stmt.InstanceID == sequence.instanceID {
 				logging.WithFields("projection", h.ProjectionName, "aggregateType", stmt.AggregateType, "sequence", stmt.Sequence, "prevSeq", stmt.PreviousSequence, "currentSeq", sequence.sequence).Warn("sequences do not match")
 				break stmts
 			}
@@ -63,6 +63,7 @@ func TestProjectionHandler_SearchQuery(t *testing.T) {
 		projectionName string
 		reducers       []handler.AggregateReducer
 		bulkLimit      uint64
+		eventQueries   EventQueries
 	}
 	type args struct {
 		instanceIDs []string
@@ -159,6 +160,105 @@ func TestProjectionHandler_SearchQuery(t *testing.T) {
 					Limit(5),
 			},
 		},
+		{
+			name: "event queries",
+			fields: fields{
+				sequenceTable:  "my_sequences",
+				projectionName: "my_projection",
+				reducers:       failingAggregateReducers("testAgg", "otherAgg"),
+				bulkLimit:      5,
+				eventQueries: func(_ context.Context, instanceID string) ([]*EventQuery, error) {
+					if instanceID == "instanceID2" {
+						return nil, nil
+					}
+					return []*EventQuery{
+						{
+							AggregateType:     "testAgg",
+							EventTypes:        []eventstore.EventType{"test.added"},
+							CreationDateAfter: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+						},
+						{
+							AggregateType: "otherAgg",
+						},
+					}, nil
+				},
+			},
+			args: args{
+				instanceIDs: []string{"instanceID1", "instanceID2"},
+			},
+			want: want{
+				limit: 5,
+				isErr: func(err error) bool {
+					return err == nil
+				},
+				expectations: []mockExpectation{
+					expectCurrentSequence("my_sequences", "my_projection", 5, "testAgg", []string{"instanceID1", "instanceID2"}),
+				},
+				SearchQueryBuilder: eventstore.
+					NewSearchQueryBuilder(eventstore.ColumnsEvent).
+					AllowTimeTravel().
+					AddQuery().
+					AggregateTypes("testAgg").
+					SequenceGreater(5).
+					InstanceID("instanceID1").
+					EventTypes("test.added").
+					CreationDateAfter(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)).
+					Or().
+					AggregateTypes("otherAgg").
+					SequenceGreater(0).
+					InstanceID("instanceID1").
+					Builder().
+					Limit(5),
+			},
+		},
+		{
+			name: "no event queries",
+			fields: fields{
+				sequenceTable:  "my_sequences",
+				projectionName: "my_projection",
+				reducers:       failingAggregateReducers("testAgg"),
+				bulkLimit:      5,
+				eventQueries: func(context.Context, string) ([]*EventQuery, error) {
+					return nil, nil
+				},
+			},
+			args: args{
+				instanceIDs: []string{"instanceID1"},
+			},
+			want: want{
+				limit: 5,
+				isErr: func(err error) bool {
+					return err == nil
+				},
+				expectations: []mockExpectation{
+					expectCurrentSequence("my_sequences", "my_projection", 5, "testAgg", []string{"instanceID1"}),
+				},
+			},
+		},
+		{
+			name: "error in event queries",
+			fields: fields{
+				sequenceTable:  "my_sequences",
+				projectionName: "my_projection",
+				reducers:       failingAggregateReducers("testAgg"),
+				bulkLimit:      5,
+				eventQueries: func(context.Context, string) ([]*EventQuery, error) {
+					return nil, errFilter
+				},
+			},
+			args: args{
+				instanceIDs: []string{"instanceID1"},
+			},
+			want: want{
+				limit: 0,
+				isErr: func(err error) bool {
+					return errors.Is(err, errFilter)
+				},
+				expectations: []mockExpectation{
+					expectCurrentSequence("my_sequences", "my_projection", 5, "testAgg", []string{"instanceID1"}),
+				},
+			},
+		},
 		{
 			name: "scheduled pseudo event",
 			fields: fields{
@@ -204,7 +304,8 @@ func TestProjectionHandler_SearchQuery(t *testing.T) {
 				Client: &database.DB{
 					DB: client,
 				},
-				Reducers: tt.fields.reducers,
+				Reducers:     tt.fields.reducers,
+				EventQueries: tt.fields.eventQueries,
 			})
 
 			for _, expectation := range tt.want.expectations {
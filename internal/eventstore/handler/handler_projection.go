@@ -199,7 +199,7 @@ func (h *ProjectionHandler) FetchEvents(ctx context.Context, instances ...string
 
 func (h *ProjectionHandler) fetchDBEvents(ctx context.Context, instances ...string) ([]eventstore.Event, bool, error) {
 	eventQuery, eventsLimit, err := h.searchQuery(ctx, instances)
-	if err != nil {
+	if err != nil || eventQuery == nil {
 		return nil, false, err
 	}
 	events, err := h.Eventstore.Filter(ctx, eventQuery)
@@ -0,0 +1,107 @@
+package apple
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	openid "github.com/zitadel/oidc/v2/pkg/oidc"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+const (
+	issuer = "https://appleid.apple.com"
+	name   = "Apple"
+
+	ScopeName = "name"
+
+	// clientSecretLifetime is the validity of the generated client secret,
+	// since the provider is created for every request, it only needs to be valid for the code exchange
+	clientSecretLifetime = 10 * time.Minute
+	// ResponseModeFormPost is required by Apple, if the name or email scope is requested
+	ResponseModeFormPost openid.ResponseMode = "form_post"
+)
+
+var ErrInvalidPrivateKey = errors.New("private key is not a PEM encoded PKCS8 ECDSA key")
+
+var _ idp.Provider = (*Provider)(nil)
+
+// Provider is the [idp.Provider] implementation for Sign in with Apple
+type Provider struct {
+	*oidc.Provider
+}
+
+// New creates an Apple provider using the [oidc.Provider] (OIDC generic provider).
+// The client secret is generated as JWT signed with the private key (.p8 file) of the teamID and keyID.
+// Apple sends the response as form_post to the callbackURL.
+func New(clientID, teamID, keyID, callbackURL string, key []byte, scopes []string, options ...oidc.ProviderOpts) (*Provider, error) {
+	secret, err := clientSecretFromPrivateKey(key, teamID, clientID, keyID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, oidc.WithIDTokenMapping(), oidc.WithResponseMode(ResponseModeFormPost))
+	rp, err := oidc.New(name, issuer, clientID, secret, callbackURL, setDefaultScopes(scopes), oidc.DefaultMapper, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: rp,
+	}, nil
+}
+
+// BeginAuth implements the [idp.Provider] interface.
+// It will create a [Session] with an OIDC authorization request (with form_post response mode) as AuthURL.
+func (p *Provider) BeginAuth(ctx context.Context, state string, params ...any) (idp.Session, error) {
+	session, err := p.Provider.BeginAuth(ctx, state, params...)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{Session: session.(*oidc.Session)}, nil
+}
+
+// setDefaultScopes ensures the scopes supported by Apple are requested,
+// since Apple does not support the default `profile` and `phone` scopes of the [oidc.Provider]
+func setDefaultScopes(scopes []string) []string {
+	if len(scopes) == 0 {
+		return []string{openid.ScopeOpenID, ScopeName, openid.ScopeEmail}
+	}
+	return scopes
+}
+
+// clientSecretFromPrivateKey creates the client secret as ES256 signed JWT as described in
+// https://developer.apple.com/documentation/accountorganizationaldatasharing/creating-a-client-secret
+func clientSecretFromPrivateKey(key []byte, teamID, clientID, keyID string, now time.Time) (string, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return "", ErrInvalidPrivateKey
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", ErrInvalidPrivateKey
+	}
+	privateKey, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return "", ErrInvalidPrivateKey
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: privateKey},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", keyID),
+	)
+	if err != nil {
+		return "", err
+	}
+	return jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   teamID,
+		Subject:  clientID,
+		Audience: jwt.Audience{issuer},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(clientSecretLifetime)),
+	}).CompactSerialize()
+}
@@ -0,0 +1,150 @@
+package apple
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	openid "github.com/zitadel/oidc/v2/pkg/oidc"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+func TestProvider_BeginAuth(t *testing.T) {
+	type fields struct {
+		clientID    string
+		teamID      string
+		keyID       string
+		callbackURL string
+		key         []byte
+		scopes      []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    idp.Session
+		wantErr error
+	}{
+		{
+			name: "invalid key, error",
+			fields: fields{
+				clientID:    "clientID",
+				teamID:      "teamID",
+				keyID:       "keyID",
+				callbackURL: "https://localhost/callback",
+				key:         []byte("invalid"),
+			},
+			wantErr: ErrInvalidPrivateKey,
+		},
+		{
+			name: "successful auth with default scopes",
+			fields: fields{
+				clientID:    "clientID",
+				teamID:      "teamID",
+				keyID:       "keyID",
+				callbackURL: "https://localhost/callback",
+				key:         testPrivateKey(t),
+			},
+			want: &Session{
+				Session: &oidc.Session{
+					AuthURL: "https://appleid.apple.com/auth/authorize?client_id=clientID&redirect_uri=https%3A%2F%2Flocalhost%2Fcallback&response_mode=form_post&response_type=code&scope=openid+name+email&state=testState",
+				},
+			},
+		},
+		{
+			name: "successful auth",
+			fields: fields{
+				clientID:    "clientID",
+				teamID:      "teamID",
+				keyID:       "keyID",
+				callbackURL: "https://localhost/callback",
+				key:         testPrivateKey(t),
+				scopes:      []string{"openid", "email"},
+			},
+			want: &Session{
+				Session: &oidc.Session{
+					AuthURL: "https://appleid.apple.com/auth/authorize?client_id=clientID&redirect_uri=https%3A%2F%2Flocalhost%2Fcallback&response_mode=form_post&response_type=code&scope=openid+email&state=testState",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			mockDiscovery()
+			a := assert.New(t)
+			r := require.New(t)
+
+			provider, err := New(tt.fields.clientID, tt.fields.teamID, tt.fields.keyID, tt.fields.callbackURL, tt.fields.key, tt.fields.scopes)
+			if tt.wantErr != nil {
+				a.ErrorIs(err, tt.wantErr)
+				return
+			}
+			r.NoError(err)
+
+			session, err := provider.BeginAuth(context.Background(), "testState")
+			r.NoError(err)
+
+			a.IsType(tt.want, session)
+			a.Equal(tt.want.GetAuthURL(), session.GetAuthURL())
+		})
+	}
+}
+
+func Test_clientSecretFromPrivateKey(t *testing.T) {
+	key := testPrivateKey(t)
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	secret, err := clientSecretFromPrivateKey(key, "teamID", "clientID", "keyID", now)
+	require.NoError(t, err)
+
+	token, err := jwt.ParseSigned(secret)
+	require.NoError(t, err)
+	require.Len(t, token.Headers, 1)
+	assert.Equal(t, "keyID", token.Headers[0].KeyID)
+	assert.Equal(t, "ES256", token.Headers[0].Algorithm)
+
+	block, _ := pem.Decode(key)
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	claims := new(jwt.Claims)
+	require.NoError(t, token.Claims(&privateKey.(*ecdsa.PrivateKey).PublicKey, claims))
+	assert.Equal(t, "teamID", claims.Issuer)
+	assert.Equal(t, "clientID", claims.Subject)
+	assert.Equal(t, jwt.Audience{issuer}, claims.Audience)
+	assert.Equal(t, jwt.NewNumericDate(now), claims.IssuedAt)
+	assert.Equal(t, jwt.NewNumericDate(now.Add(clientSecretLifetime)), claims.Expiry)
+}
+
+// mockDiscovery mocks the discovery endpoint of Apple
+func mockDiscovery() {
+	gock.New(issuer).
+		Get(openid.DiscoveryEndpoint).
+		Reply(200).
+		JSON(&openid.DiscoveryConfiguration{
+			Issuer:                issuer,
+			AuthorizationEndpoint: issuer + "/auth/authorize",
+			TokenEndpoint:         issuer + "/auth/token",
+			JwksURI:               issuer + "/auth/keys",
+		})
+}
+
+// testPrivateKey returns a PEM encoded PKCS8 ECDSA key (like the .p8 file provided by Apple)
+func testPrivateKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	data, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: data})
+}
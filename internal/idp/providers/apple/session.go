@@ -0,0 +1,86 @@
+package apple
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/oidc/v2/pkg/client/rp"
+	openid "github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+var _ idp.Session = (*Session)(nil)
+
+// Session extends the [oidc.Session] with the user information (name),
+// which Apple only sends on the first authentication as `user` form value.
+type Session struct {
+	*oidc.Session
+	// UserFormValue is the JSON encoded `user` sent by Apple in the form_post callback
+	UserFormValue string
+}
+
+// userFormValue is the representation of the `user` sent by Apple in the form_post callback
+// (the email is ignored, since it is also part of the id_token)
+type userFormValue struct {
+	Name userNamesFormValue `json:"name,omitempty"`
+}
+
+type userNamesFormValue struct {
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It will execute an OIDC code exchange if needed to retrieve the tokens
+// and map the claims of the id_token and the `user` form value into a [User],
+// since Apple does not provide a userinfo endpoint.
+func (s *Session) FetchUser(ctx context.Context) (_ idp.User, err error) {
+	if s.Tokens == nil {
+		if s.Code == "" {
+			return nil, oidc.ErrCodeMissing
+		}
+		s.Tokens, err = rp.CodeExchange[*openid.IDTokenClaims](ctx, s.Code, s.Provider.RelyingParty)
+		if err != nil {
+			return nil, err
+		}
+	}
+	user := new(userFormValue)
+	if s.UserFormValue != "" {
+		if err = json.Unmarshal([]byte(s.UserFormValue), user); err != nil {
+			return nil, err
+		}
+	}
+	return NewUser(s.Tokens.IDTokenClaims.GetUserInfo(), user.Name.FirstName, user.Name.LastName), nil
+}
+
+// NewUser creates a [User] from the id_token claims and the names provided in the `user` form value
+func NewUser(info *openid.UserInfo, firstName, lastName string) *User {
+	user := oidc.NewUser(info)
+	user.GivenName = firstName
+	user.FamilyName = lastName
+	return &User{User: user}
+}
+
+// User is a representation of the authenticated Apple user and implements the [idp.User] interface
+// by wrapping an [oidc.User]. It overwrites the [GetDisplayName] and [GetPreferredUsername],
+// because Apple only provides the first and last name and no username.
+type User struct {
+	*oidc.User
+}
+
+// GetDisplayName implements the [idp.User] interface.
+// It returns the combination of first and last name (if provided).
+func (u *User) GetDisplayName() string {
+	if u.GivenName == "" || u.FamilyName == "" {
+		return u.GivenName + u.FamilyName
+	}
+	return u.GivenName + " " + u.FamilyName
+}
+
+// GetPreferredUsername implements the [idp.User] interface.
+// It returns the email, because Apple does not return a username.
+func (u *User) GetPreferredUsername() string {
+	return string(u.GetEmail())
+}
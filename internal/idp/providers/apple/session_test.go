@@ -0,0 +1,154 @@
+package apple
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	openid "github.com/zitadel/oidc/v2/pkg/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+func TestSession_FetchUser(t *testing.T) {
+	type fields struct {
+		code          string
+		tokens        *openid.Tokens[*openid.IDTokenClaims]
+		userFormValue string
+	}
+	type want struct {
+		err               func(error) bool
+		id                string
+		firstName         string
+		lastName          string
+		displayName       string
+		preferredUsername string
+		email             string
+		isEmailVerified   bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name:   "unauthenticated session, error",
+			fields: fields{},
+			want: want{
+				err: func(err error) bool {
+					return errors.Is(err, oidc.ErrCodeMissing)
+				},
+			},
+		},
+		{
+			name: "invalid user form value, error",
+			fields: fields{
+				tokens:        tokens(),
+				userFormValue: "invalid",
+			},
+			want: want{
+				err: func(err error) bool {
+					syntaxErr := new(json.SyntaxError)
+					return errors.As(err, &syntaxErr)
+				},
+			},
+		},
+		{
+			name: "successful fetch without user form value",
+			fields: fields{
+				tokens: tokens(),
+			},
+			want: want{
+				id:                "sub",
+				preferredUsername: "email@example.com",
+				email:             "email@example.com",
+				isEmailVerified:   true,
+			},
+		},
+		{
+			name: "successful fetch with user form value",
+			fields: fields{
+				tokens:        tokens(),
+				userFormValue: `{"name":{"firstName":"firstname","lastName":"lastname"},"email":"email@example.com"}`,
+			},
+			want: want{
+				id:                "sub",
+				firstName:         "firstname",
+				lastName:          "lastname",
+				displayName:       "firstname lastname",
+				preferredUsername: "email@example.com",
+				email:             "email@example.com",
+				isEmailVerified:   true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			mockDiscovery()
+			a := assert.New(t)
+
+			provider, err := New("clientID", "teamID", "keyID", "https://localhost/callback", testPrivateKey(t), nil)
+			require.NoError(t, err)
+
+			session := &Session{
+				Session: &oidc.Session{
+					Provider: provider.Provider,
+					Code:     tt.fields.code,
+					Tokens:   tt.fields.tokens,
+				},
+				UserFormValue: tt.fields.userFormValue,
+			}
+
+			user, err := session.FetchUser(context.Background())
+			if tt.want.err != nil {
+				a.True(tt.want.err(err), "unexpected error: %v", err)
+				return
+			}
+			a.NoError(err)
+			a.Equal(tt.want.id, user.GetID())
+			a.Equal(tt.want.firstName, user.GetFirstName())
+			a.Equal(tt.want.lastName, user.GetLastName())
+			a.Equal(tt.want.displayName, user.GetDisplayName())
+			a.Equal("", user.GetNickname())
+			a.Equal(tt.want.preferredUsername, user.GetPreferredUsername())
+			a.Equal(domain.EmailAddress(tt.want.email), user.GetEmail())
+			a.Equal(tt.want.isEmailVerified, user.IsEmailVerified())
+			a.Equal(domain.PhoneNumber(""), user.GetPhone())
+			a.False(user.IsPhoneVerified())
+			a.Equal("", user.GetAvatarURL())
+			a.Equal("", user.GetProfile())
+		})
+	}
+}
+
+func tokens() *openid.Tokens[*openid.IDTokenClaims] {
+	claims := openid.NewIDTokenClaims(
+		issuer,
+		"sub",
+		[]string{"clientID"},
+		time.Now().Add(1*time.Hour),
+		time.Now().Add(-1*time.Second),
+		"nonce",
+		"",
+		nil,
+		"clientID",
+		0,
+	)
+	claims.Email = "email@example.com"
+	claims.EmailVerified = true
+	return &openid.Tokens[*openid.IDTokenClaims]{
+		Token: &oauth2.Token{
+			AccessToken: "accessToken",
+			TokenType:   openid.BearerToken,
+		},
+		IDTokenClaims: claims,
+	}
+}
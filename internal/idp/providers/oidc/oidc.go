@@ -77,6 +77,13 @@ func WithSelectAccount() ProviderOpts {
 	}
 }
 
+// WithResponseMode sets the response_mode of the auth request, e.g. `form_post`
+func WithResponseMode(mode oidc.ResponseMode) ProviderOpts {
+	return func(p *Provider) {
+		p.authOptions = append(p.authOptions, rp.AuthURLOpt(rp.WithResponseModeURLParam(mode)))
+	}
+}
+
 type UserInfoMapper func(info *oidc.UserInfo) idp.User
 
 var DefaultMapper UserInfoMapper = func(info *oidc.UserInfo) idp.User {
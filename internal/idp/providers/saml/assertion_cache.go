@@ -0,0 +1,25 @@
+package saml
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/cache/replay"
+)
+
+// assertionCleanupInterval is the interval the expired assertions are removed from the cache in
+const assertionCleanupInterval = time.Minute
+
+// seenAssertions contains the assertions received by all providers of the process,
+// as the providers are created for each request.
+// An assertion is only accepted during its short validity, which limits a replay against another process of ZITADEL to this time.
+var seenAssertions = newAssertionCache()
+
+// assertionKey identifies an assertion, its ID is only unique per issuer
+type assertionKey struct {
+	issuer string
+	id     string
+}
+
+func newAssertionCache() *replay.Cache[assertionKey] {
+	return replay.New[assertionKey](assertionCleanupInterval)
+}
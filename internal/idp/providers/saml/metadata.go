@@ -0,0 +1,83 @@
+package saml
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// entityDescriptor is the metadata of ZITADEL as service provider.
+// The elements are defined in the order of the schema, which is required for the metadata to be valid.
+type entityDescriptor struct {
+	XMLName         xml.Name        `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string          `xml:"entityID,attr"`
+	SPSSODescriptor spSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor"`
+}
+
+type spSSODescriptor struct {
+	AuthnRequestsSigned        bool                       `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                       `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string                     `xml:"protocolSupportEnumeration,attr"`
+	KeyDescriptor              keyDescriptor              `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	AssertionConsumerService   []assertionConsumerService `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+}
+
+type keyDescriptor struct {
+	Use     string  `xml:"use,attr"`
+	KeyInfo keyInfo `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+}
+
+type keyInfo struct {
+	X509Data x509Data `xml:"http://www.w3.org/2000/09/xmldsig# X509Data"`
+}
+
+type x509Data struct {
+	X509Certificate string `xml:"http://www.w3.org/2000/09/xmldsig# X509Certificate"`
+}
+
+type assertionConsumerService struct {
+	Index     string `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr,omitempty"`
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+}
+
+// Metadata returns the metadata of ZITADEL as service provider,
+// which needs to be registered on the identity provider.
+// It contains the certificate used for signing the requests and the assertion consumer services (callbacks).
+func (p *Provider) Metadata() ([]byte, error) {
+	services := make([]assertionConsumerService, 0, len(p.additionalACSURLs)+1)
+	services = append(services, assertionConsumerService{
+		Index:     "0",
+		IsDefault: true,
+		Binding:   PostBinding,
+		Location:  p.acsURL,
+	})
+	for i, acsURL := range p.additionalACSURLs {
+		services = append(services, assertionConsumerService{
+			Index:    strconv.Itoa(i + 1),
+			Binding:  PostBinding,
+			Location: acsURL,
+		})
+	}
+	metadata, err := xml.MarshalIndent(&entityDescriptor{
+		EntityID: p.entityID,
+		SPSSODescriptor: spSSODescriptor{
+			AuthnRequestsSigned:        p.withSignedRequest,
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: protocol,
+			KeyDescriptor: keyDescriptor{
+				Use: "signing",
+				KeyInfo: keyInfo{
+					X509Data: x509Data{
+						X509Certificate: p.certificateBase64(),
+					},
+				},
+			},
+			AssertionConsumerService: services,
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), metadata...), nil
+}
@@ -0,0 +1,145 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/xml"
+	"net/url"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+const (
+	assertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	version   = "2.0"
+	// timeFormat is the xs:dateTime format (in UTC) used for the IssueInstant
+	timeFormat = "2006-01-02T15:04:05Z"
+)
+
+// authnRequest is the SAML AuthnRequest sent to the identity provider.
+// The elements are defined in the order of the schema, which is required for the request to be valid.
+type authnRequest struct {
+	XMLName                     xml.Name     `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string       `xml:"ID,attr"`
+	Version                     string       `xml:"Version,attr"`
+	IssueInstant                string       `xml:"IssueInstant,attr"`
+	Destination                 string       `xml:"Destination,attr"`
+	ProtocolBinding             string       `xml:"ProtocolBinding,attr"`
+	AssertionConsumerServiceURL string       `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer                      nameID       `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy                nameIDPolicy `xml:"urn:oasis:names:tc:SAML:2.0:protocol NameIDPolicy"`
+}
+
+type nameID struct {
+	Text string `xml:",chardata"`
+}
+
+type nameIDPolicy struct {
+	AllowCreate bool `xml:"AllowCreate,attr"`
+}
+
+func (p *Provider) authnRequest(state string) (*authnRequest, error) {
+	return &authnRequest{
+		ID:                          requestID(state),
+		Version:                     version,
+		IssueInstant:                p.now().UTC().Format(timeFormat),
+		Destination:                 p.singleSignOnService(p.binding),
+		ProtocolBinding:             PostBinding,
+		AssertionConsumerServiceURL: p.acsURL,
+		Issuer:                      nameID{Text: p.entityID},
+		NameIDPolicy:                nameIDPolicy{AllowCreate: true},
+	}, nil
+}
+
+// postSession creates the (optionally signed) AuthnRequest for the HTTP-POST binding,
+// which needs to be sent by the user agent as form to the identity provider.
+func (p *Provider) postSession(request *authnRequest, state string) (*PostSession, error) {
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	if p.withSignedRequest {
+		data, err = p.signEnveloped(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &PostSession{
+		Session: &Session{
+			Provider:   p,
+			AuthURL:    request.Destination,
+			RelayState: state,
+		},
+		Form: map[string]string{
+			"SAMLRequest": base64.StdEncoding.EncodeToString(data),
+			"RelayState":  state,
+		},
+	}, nil
+}
+
+// redirectSession creates the deflated AuthnRequest for the HTTP-Redirect binding.
+// If signed requests are enabled, the signature is added to the query as specified by the binding.
+func (p *Provider) redirectSession(request *authnRequest, state string) (*Session, error) {
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := deflateAndBase64(data)
+	if err != nil {
+		return nil, err
+	}
+	query := "SAMLRequest=" + url.QueryEscape(encoded) + "&RelayState=" + url.QueryEscape(state)
+	if p.withSignedRequest {
+		query += "&SigAlg=" + url.QueryEscape(signatureAlgorithm)
+		signature, err := p.signingContext.SignString(query)
+		if err != nil {
+			return nil, err
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+	}
+	separator := "?"
+	if strings.Contains(request.Destination, "?") {
+		separator = "&"
+	}
+	return &Session{
+		Provider:   p,
+		AuthURL:    request.Destination + separator + query,
+		RelayState: state,
+	}, nil
+}
+
+// signEnveloped adds an enveloped signature to the request.
+// The signature is placed right after the Issuer as required by the schema.
+func (p *Provider) signEnveloped(data []byte) ([]byte, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	signed, err := p.signingContext.SignEnveloped(doc.Root())
+	if err != nil {
+		return nil, err
+	}
+	signature := signed.ChildElements()[len(signed.ChildElements())-1]
+	signed.RemoveChild(signature)
+	issuer := signed.SelectElement("Issuer")
+	signed.InsertChildAt(issuer.Index()+1, signature)
+	doc.SetRoot(signed)
+	return doc.WriteToBytes()
+}
+
+func deflateAndBase64(data []byte) (string, error) {
+	buf := new(bytes.Buffer)
+	writer, err := flate.NewWriter(buf, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = writer.Write(data); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
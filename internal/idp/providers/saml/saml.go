@@ -0,0 +1,264 @@
+package saml
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+
+	"github.com/zitadel/zitadel/internal/cache/replay"
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+const (
+	PostBinding     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	RedirectBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+
+	protocol           = "urn:oasis:names:tc:SAML:2.0:protocol"
+	signatureAlgorithm = dsig.RSASHA256SignatureMethod
+	// clockSkew is the time difference tolerated between ZITADEL and the identity provider
+	// when checking the validity of the assertion
+	clockSkew = time.Minute
+)
+
+var (
+	ErrNoIDPSSODescriptor    = errors.New("metadata contains no IDPSSODescriptor")
+	ErrNoSingleSignOnService = errors.New("identity provider has no SingleSignOnService for the binding")
+	ErrInvalidCertificate    = errors.New("certificate is not a PEM encoded x509 certificate")
+	ErrInvalidKey            = errors.New("key is not a PEM encoded RSA private key")
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// Provider is the [idp.Provider] implementation for a SAML 2.0 identity provider,
+// where ZITADEL acts as service provider.
+type Provider struct {
+	name string
+	// entityID is the ID of ZITADEL as service provider, which is also the URL of its metadata
+	entityID string
+	// acsURL is the assertion consumer service, where the identity provider sends the response to
+	acsURL            string
+	additionalACSURLs []string
+
+	idpMetadata       *md.EntityDescriptorType
+	certificate       *x509.Certificate
+	signingContext    *dsig.SigningContext
+	binding           string
+	withSignedRequest bool
+
+	isLinkingAllowed  bool
+	isCreationAllowed bool
+	isAutoCreation    bool
+	isAutoUpdate      bool
+
+	now func() time.Time
+	// assertions prevents the replay of assertions
+	assertions *replay.Cache[assertionKey]
+}
+
+type ProviderOpts func(provider *Provider)
+
+// WithLinkingAllowed allows end users to link the federated user to an existing one.
+func WithLinkingAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isLinkingAllowed = true
+	}
+}
+
+// WithCreationAllowed allows end users to create a new user using the federated information.
+func WithCreationAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isCreationAllowed = true
+	}
+}
+
+// WithAutoCreation enables that federated users are automatically created if not already existing.
+func WithAutoCreation() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoCreation = true
+	}
+}
+
+// WithAutoUpdate enables that information retrieved from the provider is automatically used to update
+// the existing user on each authentication.
+func WithAutoUpdate() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoUpdate = true
+	}
+}
+
+// WithBinding sets the binding ([PostBinding] or [RedirectBinding]) used to send the AuthnRequest.
+// If not set, the redirect binding is used, if supported by the identity provider.
+func WithBinding(binding string) ProviderOpts {
+	return func(p *Provider) {
+		p.binding = binding
+	}
+}
+
+// WithSignedRequest enables the signing of the AuthnRequest with the key of the service provider.
+func WithSignedRequest() ProviderOpts {
+	return func(p *Provider) {
+		p.withSignedRequest = true
+	}
+}
+
+// WithAdditionalACS adds assertion consumer services to the metadata of the service provider,
+// e.g. if the same provider is used from different callback endpoints.
+func WithAdditionalACS(acsURLs ...string) ProviderOpts {
+	return func(p *Provider) {
+		p.additionalACSURLs = append(p.additionalACSURLs, acsURLs...)
+	}
+}
+
+// New creates a SAML 2.0 provider for the metadata of the identity provider.
+// The entityID identifies ZITADEL as service provider and the acsURL is where the identity provider sends its response to.
+// Certificate and key (both PEM encoded) are used to sign the requests and are published in the service provider metadata.
+func New(
+	name,
+	entityID,
+	acsURL string,
+	metadata,
+	certificate,
+	key []byte,
+	options ...ProviderOpts,
+) (*Provider, error) {
+	idpMetadata, err := xml.ParseMetadataXmlIntoStruct(metadata)
+	if err != nil {
+		return nil, err
+	}
+	if idpMetadata.IDPSSODescriptor == nil {
+		return nil, ErrNoIDPSSODescriptor
+	}
+	cert, privateKey, err := parseKeyPair(certificate, key)
+	if err != nil {
+		return nil, err
+	}
+	tlsCert, err := signature.ParseTlsKeyPair(cert.Raw, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signingContext, err := signature.GetSigningContext(tlsCert, signatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	provider := &Provider{
+		name:           name,
+		entityID:       entityID,
+		acsURL:         acsURL,
+		idpMetadata:    idpMetadata,
+		certificate:    cert,
+		signingContext: signingContext,
+		now:            time.Now,
+		assertions:     seenAssertions,
+	}
+	for _, option := range options {
+		option(provider)
+	}
+	if provider.binding == "" {
+		provider.binding = PostBinding
+		if provider.singleSignOnService(RedirectBinding) != "" {
+			provider.binding = RedirectBinding
+		}
+	}
+	if provider.singleSignOnService(provider.binding) == "" {
+		return nil, ErrNoSingleSignOnService
+	}
+	return provider, nil
+}
+
+// Name implements the [idp.Provider] interface
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// BeginAuth implements the [idp.Provider] interface.
+// It creates the AuthnRequest for the configured binding, the state is sent as RelayState.
+func (p *Provider) BeginAuth(_ context.Context, state string, _ ...any) (idp.Session, error) {
+	request, err := p.authnRequest(state)
+	if err != nil {
+		return nil, err
+	}
+	if p.binding == PostBinding {
+		return p.postSession(request, state)
+	}
+	return p.redirectSession(request, state)
+}
+
+// IsLinkingAllowed implements the [idp.Provider] interface.
+func (p *Provider) IsLinkingAllowed() bool {
+	return p.isLinkingAllowed
+}
+
+// IsCreationAllowed implements the [idp.Provider] interface.
+func (p *Provider) IsCreationAllowed() bool {
+	return p.isCreationAllowed
+}
+
+// IsAutoCreation implements the [idp.Provider] interface.
+func (p *Provider) IsAutoCreation() bool {
+	return p.isAutoCreation
+}
+
+// IsAutoUpdate implements the [idp.Provider] interface.
+func (p *Provider) IsAutoUpdate() bool {
+	return p.isAutoUpdate
+}
+
+// IDPEntityID returns the entity ID of the identity provider
+func (p *Provider) IDPEntityID() string {
+	return string(p.idpMetadata.EntityID)
+}
+
+func (p *Provider) singleSignOnService(binding string) string {
+	for _, service := range p.idpMetadata.IDPSSODescriptor.SingleSignOnService {
+		if service.Binding == binding {
+			return service.Location
+		}
+	}
+	return ""
+}
+
+// requestID returns the ID of the AuthnRequest for the state,
+// so the response (InResponseTo) can be matched to the state (RelayState) of the started intent without storing the request.
+func requestID(state string) string {
+	return "id-" + state
+}
+
+func parseKeyPair(certificate, key []byte) (*x509.Certificate, *rsa.PrivateKey, error) {
+	certBlock, _ := pem.Decode(certificate)
+	if certBlock == nil {
+		return nil, nil, ErrInvalidCertificate
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, ErrInvalidCertificate
+	}
+	keyBlock, _ := pem.Decode(key)
+	if keyBlock == nil {
+		return nil, nil, ErrInvalidKey
+	}
+	if privateKey, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes); err == nil {
+		return cert, privateKey, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, ErrInvalidKey
+	}
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, ErrInvalidKey
+	}
+	return cert, privateKey, nil
+}
+
+func (p *Provider) certificateBase64() string {
+	return base64.StdEncoding.EncodeToString(p.certificate.Raw)
+}
@@ -0,0 +1,386 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEntityID = "https://zitadel.cloud/idps/idpID/saml/metadata"
+	testACSURL   = "https://zitadel.cloud/idps/idpID/saml/acs"
+	testIDPID    = "https://idp.example.com/metadata"
+	testSSOURL   = "https://idp.example.com/sso"
+)
+
+func TestProvider_New(t *testing.T) {
+	certificate, key := testKeyPair(t)
+	idpCertificate, _ := testKeyPair(t)
+	type args struct {
+		metadata    []byte
+		certificate []byte
+		key         []byte
+		options     []ProviderOpts
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantBinding string
+		wantErr     error
+	}{
+		{
+			name: "invalid metadata, error",
+			args: args{
+				metadata:    []byte("metadata"),
+				certificate: certificate,
+				key:         key,
+			},
+			wantErr: io.EOF,
+		},
+		{
+			name: "no idp descriptor, error",
+			args: args{
+				metadata:    []byte(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="id"></EntityDescriptor>`),
+				certificate: certificate,
+				key:         key,
+			},
+			wantErr: ErrNoIDPSSODescriptor,
+		},
+		{
+			name: "invalid certificate, error",
+			args: args{
+				metadata:    testIDPMetadata(idpCertificate, PostBinding),
+				certificate: []byte("certificate"),
+				key:         key,
+			},
+			wantErr: ErrInvalidCertificate,
+		},
+		{
+			name: "invalid key, error",
+			args: args{
+				metadata:    testIDPMetadata(idpCertificate, PostBinding),
+				certificate: certificate,
+				key:         []byte("key"),
+			},
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name: "binding not supported, error",
+			args: args{
+				metadata:    testIDPMetadata(idpCertificate, PostBinding),
+				certificate: certificate,
+				key:         key,
+				options:     []ProviderOpts{WithBinding(RedirectBinding)},
+			},
+			wantErr: ErrNoSingleSignOnService,
+		},
+		{
+			name: "default binding post",
+			args: args{
+				metadata:    testIDPMetadata(idpCertificate, PostBinding),
+				certificate: certificate,
+				key:         key,
+			},
+			wantBinding: PostBinding,
+		},
+		{
+			name: "default binding redirect",
+			args: args{
+				metadata:    testIDPMetadata(idpCertificate, PostBinding, RedirectBinding),
+				certificate: certificate,
+				key:         key,
+			},
+			wantBinding: RedirectBinding,
+		},
+		{
+			name: "binding post",
+			args: args{
+				metadata:    testIDPMetadata(idpCertificate, PostBinding, RedirectBinding),
+				certificate: certificate,
+				key:         key,
+				options:     []ProviderOpts{WithBinding(PostBinding)},
+			},
+			wantBinding: PostBinding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			provider, err := New("saml", testEntityID, testACSURL, tt.args.metadata, tt.args.certificate, tt.args.key, tt.args.options...)
+			if tt.wantErr != nil {
+				a.ErrorIs(err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			a.Equal(tt.wantBinding, provider.binding)
+			a.Equal(testIDPID, provider.IDPEntityID())
+		})
+	}
+}
+
+func TestProvider_BeginAuth(t *testing.T) {
+	certificate, key := testKeyPair(t)
+	idpCertificate, _ := testKeyPair(t)
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		metadata []byte
+		options  []ProviderOpts
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		wantAuthURL   string
+		wantForm      bool
+		wantSignature bool
+	}{
+		{
+			name: "redirect binding",
+			fields: fields{
+				metadata: testIDPMetadata(idpCertificate, RedirectBinding),
+			},
+			wantAuthURL: testSSOURL + "?SAMLRequest=",
+		},
+		{
+			name: "redirect binding, signed",
+			fields: fields{
+				metadata: testIDPMetadata(idpCertificate, RedirectBinding),
+				options:  []ProviderOpts{WithSignedRequest()},
+			},
+			wantAuthURL:   testSSOURL + "?SAMLRequest=",
+			wantSignature: true,
+		},
+		{
+			name: "post binding",
+			fields: fields{
+				metadata: testIDPMetadata(idpCertificate, PostBinding),
+			},
+			wantAuthURL: testSSOURL,
+			wantForm:    true,
+		},
+		{
+			name: "post binding, signed",
+			fields: fields{
+				metadata: testIDPMetadata(idpCertificate, PostBinding),
+				options:  []ProviderOpts{WithSignedRequest()},
+			},
+			wantAuthURL:   testSSOURL,
+			wantForm:      true,
+			wantSignature: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			r := require.New(t)
+
+			provider, err := New("saml", testEntityID, testACSURL, tt.fields.metadata, certificate, key, tt.fields.options...)
+			r.NoError(err)
+			provider.now = func() time.Time { return now }
+
+			session, err := provider.BeginAuth(context.Background(), "testState")
+			r.NoError(err)
+			a.True(strings.HasPrefix(session.GetAuthURL(), tt.wantAuthURL))
+
+			var request []byte
+			if tt.wantForm {
+				postSession, ok := session.(*PostSession)
+				r.True(ok)
+				action, form := postSession.GetAuthForm()
+				a.Equal(testSSOURL, action)
+				a.Equal("testState", form["RelayState"])
+				request, err = base64.StdEncoding.DecodeString(form["SAMLRequest"])
+				r.NoError(err)
+			} else {
+				authURL, err := url.Parse(session.GetAuthURL())
+				r.NoError(err)
+				query := authURL.Query()
+				a.Equal("testState", query.Get("RelayState"))
+				a.Equal(tt.wantSignature, query.Get("Signature") != "")
+				deflated, err := base64.StdEncoding.DecodeString(query.Get("SAMLRequest"))
+				r.NoError(err)
+				request, err = io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+				r.NoError(err)
+			}
+
+			doc := etree.NewDocument()
+			r.NoError(doc.ReadFromBytes(request))
+			root := doc.Root()
+			a.Equal("AuthnRequest", root.Tag)
+			a.Equal("id-testState", root.SelectAttrValue("ID", ""))
+			a.Equal("2023-04-01T12:00:00Z", root.SelectAttrValue("IssueInstant", ""))
+			a.Equal(testSSOURL, root.SelectAttrValue("Destination", ""))
+			a.Equal(testACSURL, root.SelectAttrValue("AssertionConsumerServiceURL", ""))
+			a.Equal(testEntityID, root.SelectElement("Issuer").Text())
+			if tt.wantForm {
+				a.Equal(tt.wantSignature, root.SelectElement("Signature") != nil)
+			}
+			if tt.wantForm && tt.wantSignature {
+				// the signature must directly follow the issuer
+				a.Equal("Signature", root.ChildElements()[1].Tag)
+			}
+		})
+	}
+}
+
+func TestProvider_Options(t *testing.T) {
+	certificate, key := testKeyPair(t)
+	idpCertificate, _ := testKeyPair(t)
+	type fields struct {
+		name    string
+		options []ProviderOpts
+	}
+	type want struct {
+		name              string
+		linkingAllowed    bool
+		creationAllowed   bool
+		autoCreation      bool
+		autoUpdate        bool
+		withSignedRequest bool
+		additionalACSURLs []string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "default",
+			fields: fields{
+				name: "saml",
+			},
+			want: want{
+				name: "saml",
+			},
+		},
+		{
+			name: "all true",
+			fields: fields{
+				name: "saml",
+				options: []ProviderOpts{
+					WithLinkingAllowed(),
+					WithCreationAllowed(),
+					WithAutoCreation(),
+					WithAutoUpdate(),
+					WithSignedRequest(),
+					WithAdditionalACS("https://zitadel.cloud/ui/login/login/externalidp/saml/acs"),
+				},
+			},
+			want: want{
+				name:              "saml",
+				linkingAllowed:    true,
+				creationAllowed:   true,
+				autoCreation:      true,
+				autoUpdate:        true,
+				withSignedRequest: true,
+				additionalACSURLs: []string{"https://zitadel.cloud/ui/login/login/externalidp/saml/acs"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			provider, err := New(tt.fields.name, testEntityID, testACSURL, testIDPMetadata(idpCertificate, PostBinding), certificate, key, tt.fields.options...)
+			require.NoError(t, err)
+
+			a.Equal(tt.want.name, provider.Name())
+			a.Equal(tt.want.linkingAllowed, provider.IsLinkingAllowed())
+			a.Equal(tt.want.creationAllowed, provider.IsCreationAllowed())
+			a.Equal(tt.want.autoCreation, provider.IsAutoCreation())
+			a.Equal(tt.want.autoUpdate, provider.IsAutoUpdate())
+			a.Equal(tt.want.withSignedRequest, provider.withSignedRequest)
+			a.Equal(tt.want.additionalACSURLs, provider.additionalACSURLs)
+		})
+	}
+}
+
+func TestProvider_Metadata(t *testing.T) {
+	certificate, key := testKeyPair(t)
+	idpCertificate, _ := testKeyPair(t)
+	r := require.New(t)
+	a := assert.New(t)
+
+	provider, err := New("saml", testEntityID, testACSURL, testIDPMetadata(idpCertificate, PostBinding), certificate, key,
+		WithSignedRequest(),
+		WithAdditionalACS("https://zitadel.cloud/ui/login/login/externalidp/saml/acs"),
+	)
+	r.NoError(err)
+
+	metadata, err := provider.Metadata()
+	r.NoError(err)
+
+	doc := etree.NewDocument()
+	r.NoError(doc.ReadFromBytes(metadata))
+	root := doc.Root()
+	a.Equal("EntityDescriptor", root.Tag)
+	a.Equal(testEntityID, root.SelectAttrValue("entityID", ""))
+	descriptor := root.SelectElement("SPSSODescriptor")
+	r.NotNil(descriptor)
+	a.Equal("true", descriptor.SelectAttrValue("AuthnRequestsSigned", ""))
+	a.Equal("true", descriptor.SelectAttrValue("WantAssertionsSigned", ""))
+	a.Equal(provider.certificateBase64(), descriptor.FindElement("./KeyDescriptor/KeyInfo/X509Data/X509Certificate").Text())
+	services := descriptor.SelectElements("AssertionConsumerService")
+	r.Len(services, 2)
+	a.Equal(testACSURL, services[0].SelectAttrValue("Location", ""))
+	a.Equal("true", services[0].SelectAttrValue("isDefault", ""))
+	a.Equal("https://zitadel.cloud/ui/login/login/externalidp/saml/acs", services[1].SelectAttrValue("Location", ""))
+	a.Equal("1", services[1].SelectAttrValue("index", ""))
+}
+
+// testKeyPair creates a PEM encoded self-signed certificate and RSA key
+func testKeyPair(t *testing.T) (certificate, key []byte) {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	certificate = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	key = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	return certificate, key
+}
+
+// testIDPMetadata creates the metadata of an identity provider with the certificate and single sign on services for the bindings
+func testIDPMetadata(certificate []byte, bindings ...string) []byte {
+	block, _ := pem.Decode(certificate)
+	services := ""
+	for _, binding := range bindings {
+		services += fmt.Sprintf(`<md:SingleSignOnService Binding="%s" Location="%s"/>`, binding, testSSOURL)
+	}
+	return []byte(fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="%s">
+	<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+		<md:KeyDescriptor use="signing">
+			<ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+				<ds:X509Data>
+					<ds:X509Certificate>%s</ds:X509Certificate>
+				</ds:X509Data>
+			</ds:KeyInfo>
+		</md:KeyDescriptor>
+		%s
+	</md:IDPSSODescriptor>
+</md:EntityDescriptor>`, testIDPID, base64.StdEncoding.EncodeToString(block.Bytes), services))
+}
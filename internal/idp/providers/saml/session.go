@@ -0,0 +1,300 @@
+package saml
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+const (
+	statusSuccess = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	bearer        = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+)
+
+var (
+	ErrResponseMissing     = errors.New("no SAMLResponse provided")
+	ErrInvalidResponse     = errors.New("SAMLResponse is not a valid SAML response")
+	ErrEncryptedAssertion  = errors.New("encrypted assertions are not supported")
+	ErrNoAssertion         = errors.New("SAMLResponse contains no assertion")
+	ErrMissingSignature    = errors.New("neither response nor assertion are signed")
+	ErrNoCertificates      = errors.New("metadata of the identity provider contains no signing certificate")
+	ErrUnsuccessfulStatus  = errors.New("identity provider returned an unsuccessful status")
+	ErrInvalidIssuer       = errors.New("issuer of the assertion does not match the identity provider")
+	ErrInvalidConditions   = errors.New("assertion is not valid at this time or not for this service provider")
+	ErrInvalidConfirmation = errors.New("assertion has no valid bearer subject confirmation")
+	ErrNoNameID            = errors.New("assertion contains no NameID")
+	ErrAssertionReplayed   = errors.New("assertion was already used")
+)
+
+var _ idp.Session = (*Session)(nil)
+
+// Session is the [idp.Session] implementation for the SAML 2.0 provider.
+type Session struct {
+	Provider   *Provider
+	AuthURL    string
+	RelayState string
+	// Response is the base64 encoded SAMLResponse received on the assertion consumer service
+	Response string
+
+	Assertion *saml.AssertionType
+}
+
+// GetAuthURL implements the [idp.Session] interface.
+func (s *Session) GetAuthURL() string {
+	return s.AuthURL
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It validates the signature and content of the SAMLResponse and maps the attributes of the assertion into a [User].
+func (s *Session) FetchUser(_ context.Context) (_ idp.User, err error) {
+	if s.Assertion == nil {
+		if s.Assertion, err = s.validateResponse(); err != nil {
+			return nil, err
+		}
+	}
+	return NewUser(s.Assertion), nil
+}
+
+var _ idp.SessionSupportsFormPost = (*PostSession)(nil)
+
+// PostSession is the [Session] of the HTTP-POST binding,
+// where the AuthnRequest is sent by the user agent as form to the identity provider.
+type PostSession struct {
+	*Session
+	Form map[string]string
+}
+
+// GetAuthForm implements the [idp.SessionSupportsFormPost] interface.
+func (s *PostSession) GetAuthForm() (string, map[string]string) {
+	return s.AuthURL, s.Form
+}
+
+func (s *Session) validateResponse() (*saml.AssertionType, error) {
+	if s.Response == "" {
+		return nil, ErrResponseMissing
+	}
+	data, err := base64.StdEncoding.DecodeString(s.Response)
+	if err != nil {
+		return nil, ErrInvalidResponse
+	}
+	doc := etree.NewDocument()
+	if err = doc.ReadFromBytes(data); err != nil {
+		return nil, ErrInvalidResponse
+	}
+	response := doc.Root()
+	if response == nil || response.Tag != "Response" || response.NamespaceURI() != protocol {
+		return nil, ErrInvalidResponse
+	}
+	response, assertionElement, err := s.validateSignatures(response)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkStatus(response); err != nil {
+		return nil, err
+	}
+	doc = etree.NewDocument()
+	doc.SetRoot(assertionElement)
+	data, err = doc.WriteToBytes()
+	if err != nil {
+		return nil, err
+	}
+	assertion := new(saml.AssertionType)
+	if err = xml.Unmarshal(data, assertion); err != nil {
+		return nil, ErrInvalidResponse
+	}
+	if err = s.checkAssertion(assertion); err != nil {
+		return nil, err
+	}
+	return assertion, nil
+}
+
+// validateSignatures verifies the signature of the response or (if the response is not signed) of the assertion
+// against the certificates of the identity provider metadata.
+// Only the validated (signed) elements are returned to prevent signature wrapping attacks.
+func (s *Session) validateSignatures(response *etree.Element) (_, _ *etree.Element, err error) {
+	certificates, err := s.Provider.idpCertificates()
+	if err != nil {
+		return nil, nil, err
+	}
+	validationContext := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: certificates})
+	validationContext.Clock = dsig.NewFakeClockAt(s.Provider.now())
+
+	if signature, _ := etreeutils.NSFindOneChild(response, dsig.Namespace, dsig.SignatureTag); signature != nil {
+		response, err = validationContext.Validate(response)
+		if err != nil {
+			return nil, nil, err
+		}
+		assertionElement, err := findAssertion(response)
+		return response, assertionElement, err
+	}
+	assertionElement, err := findAssertion(response)
+	if err != nil {
+		return nil, nil, err
+	}
+	if signature, _ := etreeutils.NSFindOneChild(assertionElement, dsig.Namespace, dsig.SignatureTag); signature == nil {
+		return nil, nil, ErrMissingSignature
+	}
+	assertionElement, err = validationContext.Validate(assertionElement)
+	return response, assertionElement, err
+}
+
+// findAssertion returns the assertion of the response detached with all necessary namespace declarations
+func findAssertion(response *etree.Element) (*etree.Element, error) {
+	if encrypted, _ := etreeutils.NSFindOneChild(response, assertion, "EncryptedAssertion"); encrypted != nil {
+		return nil, ErrEncryptedAssertion
+	}
+	assertionElement, err := etreeutils.NSFindOneChild(response, assertion, "Assertion")
+	if err != nil || assertionElement == nil {
+		return nil, ErrNoAssertion
+	}
+	nsContext, err := etreeutils.NSBuildParentContext(assertionElement)
+	if err != nil {
+		return nil, err
+	}
+	return etreeutils.NSDetatch(nsContext, assertionElement)
+}
+
+func checkStatus(response *etree.Element) error {
+	status, _ := etreeutils.NSFindOneChild(response, protocol, "Status")
+	if status == nil {
+		return ErrUnsuccessfulStatus
+	}
+	nsContext, err := etreeutils.NSBuildParentContext(status)
+	if err != nil {
+		return err
+	}
+	code, _ := etreeutils.NSFindOneChildCtx(nsContext, status, protocol, "StatusCode")
+	if code == nil || code.SelectAttrValue("Value", "") != statusSuccess {
+		return ErrUnsuccessfulStatus
+	}
+	return nil
+}
+
+func (s *Session) checkAssertion(assertion *saml.AssertionType) error {
+	if assertion.Issuer.Text != s.Provider.IDPEntityID() {
+		return ErrInvalidIssuer
+	}
+	now := s.Provider.now()
+	if err := s.checkConditions(assertion.Conditions, now); err != nil {
+		return err
+	}
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Text == "" {
+		return ErrNoNameID
+	}
+	for _, confirmation := range assertion.Subject.SubjectConfirmation {
+		if expiration, ok := s.validConfirmation(confirmation, now); ok {
+			return s.checkReplay(assertion, expiration, now)
+		}
+	}
+	return ErrInvalidConfirmation
+}
+
+// checkReplay ensures that the assertion is only used once during its validity
+func (s *Session) checkReplay(assertion *saml.AssertionType, expiration, now time.Time) error {
+	if assertion.Id == "" {
+		return ErrInvalidResponse
+	}
+	if !s.Provider.assertions.Add(assertionKey{issuer: assertion.Issuer.Text, id: assertion.Id}, expiration.Add(clockSkew), now) {
+		return ErrAssertionReplayed
+	}
+	return nil
+}
+
+func (s *Session) checkConditions(conditions *saml.ConditionsType, now time.Time) error {
+	if conditions == nil {
+		return nil
+	}
+	if !validTime(conditions.NotBefore, conditions.NotOnOrAfter, now) {
+		return ErrInvalidConditions
+	}
+	for _, restriction := range conditions.AudienceRestriction {
+		if !contains(restriction.Audience, s.Provider.entityID) {
+			return ErrInvalidConditions
+		}
+	}
+	return nil
+}
+
+// validConfirmation checks the bearer confirmation and returns until when it is valid.
+// Only responses to a request of ZITADEL are accepted (no IdP-initiated login),
+// so the InResponseTo must match the request of the RelayState.
+func (s *Session) validConfirmation(confirmation saml.SubjectConfirmationType, now time.Time) (time.Time, bool) {
+	data := confirmation.SubjectConfirmationData
+	if confirmation.Method != bearer || data == nil {
+		return time.Time{}, false
+	}
+	if data.NotOnOrAfter == "" || !validTime(data.NotBefore, data.NotOnOrAfter, now) {
+		return time.Time{}, false
+	}
+	if s.RelayState == "" || data.InResponseTo != requestID(s.RelayState) {
+		return time.Time{}, false
+	}
+	if data.Recipient != s.Provider.acsURL && !contains(s.Provider.additionalACSURLs, data.Recipient) {
+		return time.Time{}, false
+	}
+	expiration, err := time.Parse(time.RFC3339, data.NotOnOrAfter)
+	return expiration, err == nil
+}
+
+// validTime checks the time window of the assertion (both bounds are optional) including the tolerated [clockSkew]
+func validTime(notBefore, notOnOrAfter string, now time.Time) bool {
+	if notBefore != "" {
+		t, err := time.Parse(time.RFC3339, notBefore)
+		if err != nil || now.Add(clockSkew).Before(t) {
+			return false
+		}
+	}
+	if notOnOrAfter != "" {
+		t, err := time.Parse(time.RFC3339, notOnOrAfter)
+		if err != nil || !now.Add(-clockSkew).Before(t) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// idpCertificates returns the signing certificates of the identity provider metadata
+func (p *Provider) idpCertificates() ([]*x509.Certificate, error) {
+	certificates := make([]*x509.Certificate, 0)
+	for _, descriptor := range p.idpMetadata.IDPSSODescriptor.KeyDescriptor {
+		if descriptor.Use != "" && descriptor.Use != md.KeyTypesSigning {
+			continue
+		}
+		for _, data := range descriptor.KeyInfo.X509Data {
+			certData, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(data.X509Certificate), ""))
+			if err != nil {
+				return nil, ErrInvalidCertificate
+			}
+			certificate, err := x509.ParseCertificate(certData)
+			if err != nil {
+				return nil, ErrInvalidCertificate
+			}
+			certificates = append(certificates, certificate)
+		}
+	}
+	if len(certificates) == 0 {
+		return nil, ErrNoCertificates
+	}
+	return certificates, nil
+}
@@ -0,0 +1,367 @@
+package saml
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func TestSession_FetchUser(t *testing.T) {
+	certificate, key := testKeyPair(t)
+	idpCertificate, idpKey := testKeyPair(t)
+	otherCertificate, otherKey := testKeyPair(t)
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		response   string
+		relayState string
+		// replayed uses the response in a previous session
+		replayed bool
+	}
+	type want struct {
+		err               error
+		id                string
+		firstName         string
+		lastName          string
+		displayName       string
+		preferredUsername string
+		email             domain.EmailAddress
+		preferredLanguage language.Tag
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "no response, error",
+			args: args{
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrResponseMissing,
+			},
+		},
+		{
+			name: "invalid response, error",
+			args: args{
+				response:   base64.StdEncoding.EncodeToString([]byte("<Response/>")),
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrInvalidResponse,
+			},
+		},
+		{
+			name: "unsigned response, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{}),
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrMissingSignature,
+			},
+		},
+		{
+			name: "encrypted assertion, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{encrypted: true}),
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrEncryptedAssertion,
+			},
+		},
+		{
+			name: "signed by other certificate, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, otherCertificate, otherKey)}),
+				relayState: "testState",
+			},
+			want: want{
+				err: fmt.Errorf("Could not verify certificate against trusted certs"),
+			},
+		},
+		{
+			name: "unsuccessful status, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey), status: "urn:oasis:names:tc:SAML:2.0:status:Requester"}),
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrUnsuccessfulStatus,
+			},
+		},
+		{
+			name: "invalid issuer, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey), issuer: "https://other.example.com"}),
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrInvalidIssuer,
+			},
+		},
+		{
+			name: "expired, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey), notOnOrAfter: now.Add(-2 * time.Minute)}),
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrInvalidConditions,
+			},
+		},
+		{
+			name: "invalid audience, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey), audience: "https://other.example.com"}),
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrInvalidConditions,
+			},
+		},
+		{
+			name: "invalid in response to, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey)}),
+				relayState: "otherState",
+			},
+			want: want{
+				err: ErrInvalidConfirmation,
+			},
+		},
+		{
+			name: "unsolicited response, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey), unsolicited: true}),
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrInvalidConfirmation,
+			},
+		},
+		{
+			name: "no relay state, error",
+			args: args{
+				response: testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey), unsolicited: true}),
+			},
+			want: want{
+				err: ErrInvalidConfirmation,
+			},
+		},
+		{
+			name: "replayed assertion, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey)}),
+				relayState: "testState",
+				replayed:   true,
+			},
+			want: want{
+				err: ErrAssertionReplayed,
+			},
+		},
+		{
+			name: "invalid recipient, error",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey), recipient: "https://other.example.com/acs"}),
+				relayState: "testState",
+			},
+			want: want{
+				err: ErrInvalidConfirmation,
+			},
+		},
+		{
+			name: "signed assertion",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signAssertion: signer(t, idpCertificate, idpKey)}),
+				relayState: "testState",
+			},
+			want: want{
+				id:                "nameID",
+				firstName:         "firstname",
+				lastName:          "lastname",
+				displayName:       "firstname lastname",
+				preferredUsername: "username",
+				email:             "email@example.com",
+				preferredLanguage: language.German,
+			},
+		},
+		{
+			name: "signed response",
+			args: args{
+				response:   testResponse(t, testResponseOpts{signResponse: signer(t, idpCertificate, idpKey)}),
+				relayState: "testState",
+			},
+			want: want{
+				id:                "nameID",
+				firstName:         "firstname",
+				lastName:          "lastname",
+				displayName:       "firstname lastname",
+				preferredUsername: "username",
+				email:             "email@example.com",
+				preferredLanguage: language.German,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			r := require.New(t)
+
+			provider, err := New("saml", testEntityID, testACSURL, testIDPMetadata(idpCertificate, PostBinding), certificate, key)
+			r.NoError(err)
+			provider.now = func() time.Time { return now }
+			provider.assertions = newAssertionCache()
+
+			if tt.args.replayed {
+				previous := &Session{
+					Provider:   provider,
+					Response:   tt.args.response,
+					RelayState: tt.args.relayState,
+				}
+				_, err = previous.FetchUser(context.Background())
+				r.NoError(err)
+			}
+
+			session := &Session{
+				Provider:   provider,
+				Response:   tt.args.response,
+				RelayState: tt.args.relayState,
+			}
+
+			user, err := session.FetchUser(context.Background())
+			if tt.want.err != nil {
+				a.EqualError(err, tt.want.err.Error())
+				return
+			}
+			r.NoError(err)
+			a.Equal(tt.want.id, user.GetID())
+			a.Equal(tt.want.firstName, user.GetFirstName())
+			a.Equal(tt.want.lastName, user.GetLastName())
+			a.Equal(tt.want.displayName, user.GetDisplayName())
+			a.Equal("", user.GetNickname())
+			a.Equal(tt.want.preferredUsername, user.GetPreferredUsername())
+			a.Equal(tt.want.email, user.GetEmail())
+			a.False(user.IsEmailVerified())
+			a.Equal(domain.PhoneNumber(""), user.GetPhone())
+			a.False(user.IsPhoneVerified())
+			a.Equal(tt.want.preferredLanguage, user.GetPreferredLanguage())
+			a.Equal("", user.GetAvatarURL())
+			a.Equal("", user.GetProfile())
+		})
+	}
+}
+
+type testResponseOpts struct {
+	signResponse  func(*etree.Element) *etree.Element
+	signAssertion func(*etree.Element) *etree.Element
+	encrypted     bool
+	status        string
+	issuer        string
+	audience      string
+	recipient     string
+	notOnOrAfter  time.Time
+	// unsolicited omits the InResponseTo like in an IdP-initiated login
+	unsolicited bool
+}
+
+// testResponse creates a base64 encoded SAMLResponse (for the request of testState),
+// which can be signed on the response and / or assertion level
+func testResponse(t *testing.T, opts testResponseOpts) string {
+	t.Helper()
+	if opts.status == "" {
+		opts.status = statusSuccess
+	}
+	if opts.issuer == "" {
+		opts.issuer = testIDPID
+	}
+	if opts.audience == "" {
+		opts.audience = testEntityID
+	}
+	if opts.recipient == "" {
+		opts.recipient = testACSURL
+	}
+	if opts.notOnOrAfter.IsZero() {
+		opts.notOnOrAfter = time.Date(2023, 4, 1, 12, 5, 0, 0, time.UTC)
+	}
+	inResponseTo := ` InResponseTo="id-testState"`
+	if opts.unsolicited {
+		inResponseTo = ""
+	}
+	assertionElement := "saml:Assertion"
+	if opts.encrypted {
+		assertionElement = "saml:EncryptedAssertion"
+	}
+	response := fmt.Sprintf(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="response" Version="2.0" IssueInstant="2023-04-01T12:00:00Z" Destination="%[5]s"%[7]s>
+	<saml:Issuer>%[2]s</saml:Issuer>
+	<samlp:Status><samlp:StatusCode Value="%[1]s"/></samlp:Status>
+	<%[6]s ID="assertion" Version="2.0" IssueInstant="2023-04-01T12:00:00Z">
+		<saml:Issuer>%[2]s</saml:Issuer>
+		<saml:Subject>
+			<saml:NameID>nameID</saml:NameID>
+			<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+				<saml:SubjectConfirmationData NotOnOrAfter="%[4]s" Recipient="%[5]s"%[7]s/>
+			</saml:SubjectConfirmation>
+		</saml:Subject>
+		<saml:Conditions NotBefore="2023-04-01T11:59:00Z" NotOnOrAfter="%[4]s">
+			<saml:AudienceRestriction><saml:Audience>%[3]s</saml:Audience></saml:AudienceRestriction>
+		</saml:Conditions>
+		<saml:AttributeStatement>
+			<saml:Attribute Name="urn:oid:2.5.4.42" FriendlyName="givenName"><saml:AttributeValue>firstname</saml:AttributeValue></saml:Attribute>
+			<saml:Attribute Name="sn"><saml:AttributeValue>lastname</saml:AttributeValue></saml:Attribute>
+			<saml:Attribute Name="displayName"><saml:AttributeValue>firstname lastname</saml:AttributeValue></saml:Attribute>
+			<saml:Attribute Name="uid"><saml:AttributeValue>username</saml:AttributeValue></saml:Attribute>
+			<saml:Attribute Name="http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"><saml:AttributeValue>email@example.com</saml:AttributeValue></saml:Attribute>
+			<saml:Attribute Name="preferredLanguage"><saml:AttributeValue>de</saml:AttributeValue></saml:Attribute>
+		</saml:AttributeStatement>
+	</%[6]s>
+</samlp:Response>`, opts.status, opts.issuer, opts.audience, opts.notOnOrAfter.Format(timeFormat), opts.recipient, assertionElement, inResponseTo)
+
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromString(response))
+	root := doc.Root()
+	if opts.signAssertion != nil {
+		assertion := root.SelectElement("Assertion")
+		nsContext, err := etreeutils.NSBuildParentContext(assertion)
+		require.NoError(t, err)
+		detached, err := etreeutils.NSDetatch(nsContext, assertion)
+		require.NoError(t, err)
+		signed := opts.signAssertion(detached)
+		root.InsertChildAt(assertion.Index(), signed)
+		root.RemoveChild(assertion)
+	}
+	if opts.signResponse != nil {
+		doc.SetRoot(opts.signResponse(root))
+	}
+	data, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// signer returns a function, which adds an enveloped signature to the element using the PEM encoded certificate and key
+func signer(t *testing.T, certificate, key []byte) func(*etree.Element) *etree.Element {
+	t.Helper()
+	cert, privateKey, err := parseKeyPair(certificate, key)
+	require.NoError(t, err)
+	tlsCert, err := signature.ParseTlsKeyPair(cert.Raw, privateKey)
+	require.NoError(t, err)
+	signingContext, err := signature.GetSigningContext(tlsCert, signatureAlgorithm)
+	require.NoError(t, err)
+	return func(element *etree.Element) *etree.Element {
+		signed, err := signingContext.SignEnveloped(element)
+		require.NoError(t, err)
+		return signed
+	}
+}
@@ -0,0 +1,172 @@
+package saml
+
+import (
+	"strings"
+
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+var (
+	firstNameAttributes = []string{
+		"givenName",
+		"firstName",
+		"urn:oid:2.5.4.42",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname",
+	}
+	lastNameAttributes = []string{
+		"sn",
+		"surname",
+		"lastName",
+		"urn:oid:2.5.4.4",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname",
+	}
+	displayNameAttributes = []string{
+		"displayName",
+		"urn:oid:2.16.840.1.113730.3.1.241",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name",
+	}
+	nickNameAttributes = []string{
+		"nickName",
+	}
+	preferredUsernameAttributes = []string{
+		"uid",
+		"username",
+		"upn",
+		"eduPersonPrincipalName",
+		"urn:oid:0.9.2342.19200300.100.1.1",
+		"urn:oid:1.3.6.1.4.1.5923.1.1.1.6",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn",
+	}
+	emailAttributes = []string{
+		"mail",
+		"email",
+		"emailAddress",
+		"urn:oid:0.9.2342.19200300.100.1.3",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+	}
+	phoneAttributes = []string{
+		"telephoneNumber",
+		"mobile",
+		"phone",
+		"urn:oid:2.5.4.20",
+		"urn:oid:0.9.2342.19200300.100.1.41",
+	}
+	preferredLanguageAttributes = []string{
+		"preferredLanguage",
+		"urn:oid:2.16.840.1.113730.3.1.39",
+	}
+)
+
+var _ idp.User = (*User)(nil)
+
+// User represents the subject of a SAML assertion.
+// The ID is the NameID of the subject, the profile information is taken from well-known attributes.
+type User struct {
+	ID         string              `json:"id,omitempty"`
+	Attributes map[string][]string `json:"attributes,omitempty"`
+}
+
+// NewUser creates a [User] from the subject and attributes of the assertion.
+// Attributes are stored by their name and (if different) by their friendly name.
+func NewUser(assertion *saml.AssertionType) *User {
+	user := &User{
+		Attributes: make(map[string][]string),
+	}
+	if assertion.Subject != nil && assertion.Subject.NameID != nil {
+		user.ID = assertion.Subject.NameID.Text
+	}
+	for _, statement := range assertion.AttributeStatement {
+		for _, attribute := range statement.Attribute {
+			user.Attributes[attribute.Name] = append(user.Attributes[attribute.Name], attribute.AttributeValue...)
+			if attribute.FriendlyName != "" && attribute.FriendlyName != attribute.Name {
+				user.Attributes[attribute.FriendlyName] = append(user.Attributes[attribute.FriendlyName], attribute.AttributeValue...)
+			}
+		}
+	}
+	return user
+}
+
+// GetID is an implementation of the [idp.User] interface.
+func (u *User) GetID() string {
+	return u.ID
+}
+
+// GetFirstName is an implementation of the [idp.User] interface.
+func (u *User) GetFirstName() string {
+	return u.attribute(firstNameAttributes)
+}
+
+// GetLastName is an implementation of the [idp.User] interface.
+func (u *User) GetLastName() string {
+	return u.attribute(lastNameAttributes)
+}
+
+// GetDisplayName is an implementation of the [idp.User] interface.
+func (u *User) GetDisplayName() string {
+	return u.attribute(displayNameAttributes)
+}
+
+// GetNickname is an implementation of the [idp.User] interface.
+func (u *User) GetNickname() string {
+	return u.attribute(nickNameAttributes)
+}
+
+// GetPreferredUsername is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredUsername() string {
+	return u.attribute(preferredUsernameAttributes)
+}
+
+// GetEmail is an implementation of the [idp.User] interface.
+func (u *User) GetEmail() domain.EmailAddress {
+	return domain.EmailAddress(u.attribute(emailAttributes))
+}
+
+// IsEmailVerified is an implementation of the [idp.User] interface.
+// SAML does not provide any information about the verification, so it always returns false.
+func (u *User) IsEmailVerified() bool {
+	return false
+}
+
+// GetPhone is an implementation of the [idp.User] interface.
+func (u *User) GetPhone() domain.PhoneNumber {
+	return domain.PhoneNumber(u.attribute(phoneAttributes))
+}
+
+// IsPhoneVerified is an implementation of the [idp.User] interface.
+// SAML does not provide any information about the verification, so it always returns false.
+func (u *User) IsPhoneVerified() bool {
+	return false
+}
+
+// GetPreferredLanguage is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredLanguage() language.Tag {
+	return language.Make(u.attribute(preferredLanguageAttributes))
+}
+
+// GetAvatarURL is an implementation of the [idp.User] interface.
+// SAML does not provide an avatar, so it always returns an empty string.
+func (u *User) GetAvatarURL() string {
+	return ""
+}
+
+// GetProfile is an implementation of the [idp.User] interface.
+// SAML does not provide a profile URL, so it always returns an empty string.
+func (u *User) GetProfile() string {
+	return ""
+}
+
+// attribute returns the first value of the first attribute found by the (case-insensitive) names
+func (u *User) attribute(names []string) string {
+	for _, name := range names {
+		for key, values := range u.Attributes {
+			if strings.EqualFold(key, name) && len(values) > 0 {
+				return strings.TrimSpace(values[0])
+			}
+		}
+	}
+	return ""
+}
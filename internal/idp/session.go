@@ -18,3 +18,10 @@ type Session interface {
 type SessionSupportsMigration interface {
 	RetrievePreviousID() (previousID string, err error)
 }
+
+// SessionSupportsFormPost is an optional extension to the Session interface.
+// It is implemented by sessions where the authentication request cannot be sent as redirect to the GetAuthURL,
+// but needs to be posted by the user agent as form (e.g. the HTTP-POST binding of SAML).
+type SessionSupportsFormPost interface {
+	GetAuthForm() (action string, values map[string]string)
+}
@@ -1,11 +1,63 @@
 package logstore
 
+import (
+	"net/http"
+	"time"
+)
+
 type Configs struct {
-	Access    *Config
-	Execution *Config
+	Access       *Config
+	Execution    *Config
+	Notification *Config
 }
 
 type Config struct {
 	Database *EmitterConfig
 	Stdout   *EmitterConfig
+	File     *FileEmitterConfig
+	HTTP     *HTTPEmitterConfig
+}
+
+// FileEmitterConfig configures an emitter which writes the records as newline delimited JSON to rotating local files
+type FileEmitterConfig struct {
+	EmitterConfig `mapstructure:",squash"`
+	// Path of the file the records are written to
+	Path string
+	// MaxSize in bytes after which the file is rotated
+	MaxSize int64
+	// MaxBackups defines how many rotated files are kept, 0 keeps all files
+	MaxBackups int
+}
+
+// Emitter returns the generic emitter config or nil, if the file emitter isn't configured
+func (c *FileEmitterConfig) Emitter() *EmitterConfig {
+	if c == nil {
+		return nil
+	}
+	return &c.EmitterConfig
+}
+
+// HTTPEmitterConfig configures an emitter which posts the records as newline delimited JSON to an HTTP endpoint
+type HTTPEmitterConfig struct {
+	EmitterConfig `mapstructure:",squash"`
+	// Endpoint the records are posted to
+	Endpoint string
+	// Headers are added to each request, e.g. for authorization
+	Headers http.Header
+	// Timeout of a single request
+	Timeout time.Duration
+	// MaxRetries defines how many times a failed request is retried
+	MaxRetries uint
+	// MinBackoff is the time waited before the first retry, it is doubled for each further retry
+	MinBackoff time.Duration
+	// MaxBackoff limits the time waited between two retries
+	MaxBackoff time.Duration
+}
+
+// Emitter returns the generic emitter config or nil, if the http emitter isn't configured
+func (c *HTTPEmitterConfig) Emitter() *EmitterConfig {
+	if c == nil {
+		return nil
+	}
+	return &c.EmitterConfig
 }
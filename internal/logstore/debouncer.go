@@ -36,6 +36,8 @@ type debouncer struct {
 	storage           bulkSink
 	cache             []LogRecord
 	cacheLen          uint
+	// flush signals the shipping go routine that the max bulk size is reached
+	flush chan struct{}
 }
 
 type DebouncerConfig struct {
@@ -49,12 +51,15 @@ func newDebouncer(binarySignaledCtx context.Context, cfg DebouncerConfig, clock
 		clock:             clock,
 		cfg:               cfg,
 		storage:           ship,
+		flush:             make(chan struct{}, 1),
 	}
 
+	var ticks <-chan time.Time
 	if cfg.MinFrequency > 0 {
 		a.ticker = clock.Ticker(cfg.MinFrequency)
-		go a.shipOnTicks()
+		ticks = a.ticker.C
 	}
+	go a.shipBulks(ticks)
 	return a
 }
 
@@ -64,29 +69,41 @@ func (d *debouncer) add(item LogRecord) {
 	d.cache = append(d.cache, item)
 	d.cacheLen++
 	if d.cfg.MaxBulkSize > 0 && d.cacheLen >= d.cfg.MaxBulkSize {
-		// Add should not block and release the lock
-		go d.ship()
+		// Add should not block, so the shipping go routine is only signaled
+		select {
+		case d.flush <- struct{}{}:
+		default:
+		}
 	}
 }
 
+// ship must only be called by shipBulks, so bulks are never sent concurrently
 func (d *debouncer) ship() {
-	if d.cacheLen == 0 {
-		return
-	}
 	d.mux.Lock()
-	defer d.mux.Unlock()
-	if err := d.storage.sendBulk(d.binarySignaledCtx, d.cache); err != nil {
-		logging.WithError(err).WithField("size", len(d.cache)).Error("storing bulk failed")
-	}
+	bulk := d.cache
 	d.cache = nil
 	d.cacheLen = 0
 	if d.cfg.MinFrequency > 0 {
 		d.ticker.Reset(d.cfg.MinFrequency)
 	}
+	// the lock is released before sending, so slow sinks (e.g. retrying http requests) don't block adding new records
+	d.mux.Unlock()
+	if len(bulk) == 0 {
+		return
+	}
+	if err := d.storage.sendBulk(d.binarySignaledCtx, bulk); err != nil {
+		logging.WithError(err).WithField("size", len(bulk)).Error("storing bulk failed")
+	}
 }
 
-func (d *debouncer) shipOnTicks() {
-	for range d.ticker.C {
+// shipBulks is the only go routine sending bulks to the storage.
+// It ships the cached records on each tick and as soon as the max bulk size is reached.
+func (d *debouncer) shipBulks(ticks <-chan time.Time) {
+	for {
+		select {
+		case <-ticks:
+		case <-d.flush:
+		}
 		d.ship()
 	}
 }
@@ -0,0 +1,215 @@
+// The library github.com/benbjohnson/clock fails when race is enabled
+// https://github.com/benbjohnson/clock/issues/44
+//go:build !race
+
+package logstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/logstore"
+	emittermock "github.com/zitadel/zitadel/internal/logstore/emitters/mock"
+	quotaqueriermock "github.com/zitadel/zitadel/internal/logstore/quotaqueriers/mock"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+func TestService_LimitDegraded(t *testing.T) {
+	periodStart := time.Now().Add(-time.Minute)
+	type args struct {
+		config      quota.AddedEvent
+		degradation *quota.DegradedModeEnteredEvent
+		usage       int
+	}
+	type want struct {
+		remaining *uint64
+		degraded  bool
+		reported  *bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "below amount",
+			args: args{
+				config: quotaConfig(withAmountAndInterval(10), withLimiting(), withGracePercent(50)),
+				usage:  4,
+			},
+			want: want{
+				remaining: uint64Ptr(6),
+			},
+		},
+		{
+			name: "amount used, enters degraded mode",
+			args: args{
+				config: quotaConfig(withAmountAndInterval(10), withLimiting(), withGracePercent(50)),
+				usage:  10,
+			},
+			want: want{
+				degraded: true,
+				reported: boolPtr(true),
+			},
+		},
+		{
+			name: "grace percent used",
+			args: args{
+				config:      quotaConfig(withAmountAndInterval(10), withLimiting(), withGracePercent(50)),
+				degradation: degradation(periodStart),
+				usage:       15,
+			},
+			want: want{
+				remaining: uint64Ptr(0),
+				degraded:  true,
+			},
+		},
+		{
+			name: "grace period over",
+			args: args{
+				config: quotaConfig(withAmountAndInterval(10), withLimiting(), func(c *quota.AddedEvent) {
+					c.GracePeriod = time.Second
+				}),
+				degradation: degradation(periodStart),
+				usage:       11,
+			},
+			want: want{
+				remaining: uint64Ptr(0),
+				degraded:  true,
+			},
+		},
+		{
+			name: "new period, leaves degraded mode",
+			args: args{
+				config:      quotaConfig(withAmountAndInterval(10), withLimiting(), withGracePercent(50)),
+				degradation: degradation(periodStart.Add(-time.Hour)),
+				usage:       1,
+			},
+			want: want{
+				remaining: uint64Ptr(9),
+				reported:  boolPtr(false),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clock := clock.NewMock()
+			clock.Set(periodStart)
+			storage := emittermock.NewInMemoryStorage(clock)
+			emitter, err := logstore.NewEmitter(ctx, clock, emitterConfig(withCleanupping(0, 0)), storage)
+			require.NoError(t, err)
+			reporter := &degradationReporter{reported: make(chan bool, 1)}
+			svc := logstore.New(
+				&degradationQuerier{
+					QuotaQuerier: quotaqueriermock.NewNoopQuerier(&tt.args.config, periodStart),
+					degradation:  tt.args.degradation,
+				},
+				logstore.UsageReporterFunc(func(context.Context, []*quota.NotificationDueEvent) error { return nil }),
+				reporter,
+				emitter,
+			)
+			for i := 0; i < tt.args.usage; i++ {
+				clock.Add(time.Millisecond)
+				svc.Handle(ctx, emittermock.NewRecord(clock))
+			}
+
+			remaining, degraded := svc.LimitDegraded(ctx, "instanceID", quota.Unimplemented)
+			assert.Equal(t, tt.want.remaining, remaining)
+			assert.Equal(t, tt.want.degraded, degraded)
+			if tt.want.reported == nil {
+				return
+			}
+			select {
+			case reported := <-reporter.reported:
+				assert.Equal(t, *tt.want.reported, reported)
+			case <-time.After(time.Second):
+				t.Error("degradation not reported")
+			}
+		})
+	}
+}
+
+func TestService_LimitDegraded_reportsOnce(t *testing.T) {
+	periodStart := time.Now().Add(-time.Minute)
+	ctx := context.Background()
+	clock := clock.NewMock()
+	clock.Set(periodStart)
+	storage := emittermock.NewInMemoryStorage(clock)
+	emitter, err := logstore.NewEmitter(ctx, clock, emitterConfig(withCleanupping(0, 0)), storage)
+	require.NoError(t, err)
+	config := quotaConfig(withAmountAndInterval(10), withLimiting(), withGracePercent(50))
+	reporter := &degradationReporter{reported: make(chan bool, 10), release: make(chan struct{})}
+	svc := logstore.New(
+		&degradationQuerier{
+			QuotaQuerier: quotaqueriermock.NewNoopQuerier(&config, periodStart),
+		},
+		logstore.UsageReporterFunc(func(context.Context, []*quota.NotificationDueEvent) error { return nil }),
+		reporter,
+		emitter,
+	)
+	for i := 0; i < 10; i++ {
+		clock.Add(time.Millisecond)
+		svc.Handle(ctx, emittermock.NewRecord(clock))
+	}
+
+	// the degradation isn't projected yet, so each request reports it
+	for i := 0; i < 5; i++ {
+		_, degraded := svc.LimitDegraded(ctx, "instanceID", quota.Unimplemented)
+		assert.True(t, degraded)
+	}
+	select {
+	case reported := <-reporter.reported:
+		assert.True(t, reported)
+	case <-time.After(time.Second):
+		t.Fatal("degradation not reported")
+	}
+	close(reporter.release)
+	select {
+	case <-reporter.reported:
+		t.Error("degradation reported concurrently")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func withGracePercent(percent uint16) quotaOption {
+	return func(c *quota.AddedEvent) {
+		c.GracePercent = percent
+	}
+}
+
+func degradation(periodStart time.Time) *quota.DegradedModeEnteredEvent {
+	return &quota.DegradedModeEnteredEvent{
+		PeriodStart: periodStart,
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+type degradationQuerier struct {
+	logstore.QuotaQuerier
+	degradation *quota.DegradedModeEnteredEvent
+}
+
+func (q *degradationQuerier) GetQuotaDegradation(context.Context, string, quota.Unit) (*quota.DegradedModeEnteredEvent, error) {
+	return q.degradation, nil
+}
+
+type degradationReporter struct {
+	reported chan bool
+	// release blocks the reporting until it's closed, if set
+	release chan struct{}
+}
+
+func (r *degradationReporter) ReportQuotaDegradation(_ context.Context, _ string, _ quota.Unit, _ time.Time, _ uint64, degraded bool) error {
+	r.reported <- degraded
+	if r.release != nil {
+		<-r.release
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ type emitter struct {
 	debouncer *debouncer
 	emitter   LogEmitter
 	clock     clock.Clock
+	pruner    *pruner
 }
 
 type LogRecord interface {
@@ -46,16 +47,38 @@ func (l LogEmitterFunc) Emit(ctx context.Context, bulk []LogRecord) error {
 
 type LogCleanupper interface {
 	LogEmitter
-	Cleanup(ctx context.Context, keep time.Duration) error
+	// InstanceIDs returns the ids of the instances which have logs older than before
+	InstanceIDs(ctx context.Context, before time.Time) ([]string, error)
+	// Cleanup deletes the logs of the instance older than before and returns the amount of deleted logs
+	Cleanup(ctx context.Context, instanceID string, before time.Time) (int64, error)
+}
+
+type EmitterOption func(*emitter)
+
+// WithPruning locks the cleanup of each instance using the locker, so only one process prunes an instance at a time.
+// Logs of the current quota periods returned by the quotaQuerier are never pruned.
+// The amount of pruned logs is reported with the metric logstore_pruned_records labeled by name.
+func WithPruning(name string, locker Locker, quotaQuerier QuotaQuerier) EmitterOption {
+	return func(e *emitter) {
+		e.pruner = &pruner{
+			name:         name,
+			locker:       locker,
+			quotaQuerier: quotaQuerier,
+		}
+	}
 }
 
 // NewEmitter accepts Clock from github.com/benbjohnson/clock so we can control timers and tickers in the unit tests
-func NewEmitter(ctx context.Context, clock clock.Clock, cfg *EmitterConfig, logger LogEmitter) (*emitter, error) {
+func NewEmitter(ctx context.Context, clock clock.Clock, cfg *EmitterConfig, logger LogEmitter, opts ...EmitterOption) (*emitter, error) {
 	svc := &emitter{
 		enabled: cfg != nil && cfg.Enabled,
 		ctx:     ctx,
 		emitter: logger,
 		clock:   clock,
+		pruner:  new(pruner),
+	}
+	for _, opt := range opts {
+		opt(svc)
 	}
 
 	if !svc.enabled {
@@ -79,14 +102,21 @@ func NewEmitter(ctx context.Context, clock clock.Clock, cfg *EmitterConfig, logg
 	}
 
 	if cfg.Keep != 0 && cfg.CleanupInterval != 0 {
-		go svc.startCleanupping(cleanupper, cfg.CleanupInterval, cfg.Keep)
+		if err := registerPrunedRecordsCounter(); err != nil {
+			return nil, err
+		}
+		svc.pruner.cleanupper = cleanupper
+		svc.pruner.keep = cfg.Keep
+		svc.pruner.clock = clock
+		// the ticker is created before the go routine starts, so the cleanup interval doesn't depend on scheduling
+		go svc.startCleanupping(clock.Ticker(cfg.CleanupInterval))
 	}
 	return svc, nil
 }
 
-func (s *emitter) startCleanupping(cleanupper LogCleanupper, cleanupInterval, keep time.Duration) {
-	for range s.clock.Tick(cleanupInterval) {
-		if err := cleanupper.Cleanup(s.ctx, keep); err != nil {
+func (s *emitter) startCleanupping(ticker *clock.Ticker) {
+	for range ticker.C {
+		if err := s.pruner.prune(s.ctx); err != nil {
 			logging.WithError(err).Error("cleaning up logs failed")
 		}
 	}
@@ -44,8 +44,12 @@ func NewDatabaseLogStorage(dbClient *database.DB) *databaseLogStorage {
 	return &databaseLogStorage{dbClient: dbClient}
 }
 
-func (l *databaseLogStorage) QuotaUnit() quota.Unit {
-	return quota.RequestsAllAuthenticated
+func (l *databaseLogStorage) QuotaUnits() []quota.Unit {
+	return []quota.Unit{
+		quota.RequestsAllAuthenticated,
+		quota.RequestsTokenEndpoint,
+		quota.RequestsManagementAPI,
+	}
 }
 
 func (l *databaseLogStorage) Emit(ctx context.Context, bulk []logstore.LogRecord) error {
@@ -102,32 +106,15 @@ func (l *databaseLogStorage) Emit(ctx context.Context, bulk []logstore.LogRecord
 	return nil
 }
 
-func (l *databaseLogStorage) QueryUsage(ctx context.Context, instanceId string, start time.Time) (uint64, error) {
+func (l *databaseLogStorage) QueryUsage(ctx context.Context, instanceId string, unit quota.Unit, start time.Time) (uint64, error) {
 	stmt, args, err := squirrel.Select(
 		fmt.Sprintf("count(%s)", accessInstanceIdCol),
 	).
 		From(accessLogsTable + l.dbClient.Timetravel(call.Took(ctx))).
-		Where(squirrel.And{
+		Where(append(squirrel.And{
 			squirrel.Eq{accessInstanceIdCol: instanceId},
 			squirrel.GtOrEq{accessTimestampCol: start},
-			squirrel.Expr(fmt.Sprintf(`%s #>> '{%s,0}' = '[REDACTED]'`, accessRequestHeadersCol, strings.ToLower(zitadel_http.Authorization))),
-			squirrel.NotLike{accessRequestURLCol: "%/zitadel.system.v1.SystemService/%"},
-			squirrel.NotLike{accessRequestURLCol: "%/system/v1/%"},
-			squirrel.Or{
-				squirrel.And{
-					squirrel.Eq{accessProtocolCol: HTTP},
-					squirrel.NotEq{accessResponseStatusCol: http.StatusForbidden},
-					squirrel.NotEq{accessResponseStatusCol: http.StatusInternalServerError},
-					squirrel.NotEq{accessResponseStatusCol: http.StatusTooManyRequests},
-				},
-				squirrel.And{
-					squirrel.Eq{accessProtocolCol: GRPC},
-					squirrel.NotEq{accessResponseStatusCol: codes.PermissionDenied},
-					squirrel.NotEq{accessResponseStatusCol: codes.Internal},
-					squirrel.NotEq{accessResponseStatusCol: codes.ResourceExhausted},
-				},
-			},
-		}).
+		}, usageConditions(unit)...)).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 
@@ -145,18 +132,97 @@ func (l *databaseLogStorage) QueryUsage(ctx context.Context, instanceId string,
 	return count, nil
 }
 
-func (l *databaseLogStorage) Cleanup(ctx context.Context, keep time.Duration) error {
+// usageConditions returns the conditions for the requests which are counted for the unit,
+// the request urls are matched by the same prefixes as in [QuotaUnits]
+func usageConditions(unit quota.Unit) squirrel.And {
+	prefixes := requestURLPrefixes(unit)
+	if len(prefixes) == 0 {
+		return authenticatedConditions()
+	}
+	urlConditions := make(squirrel.Or, len(prefixes))
+	for i, prefix := range prefixes {
+		urlConditions[i] = squirrel.Like{accessRequestURLCol: prefix + "%"}
+	}
+	if unit == quota.RequestsTokenEndpoint {
+		return squirrel.And{
+			squirrel.Eq{accessProtocolCol: HTTP},
+			urlConditions,
+			squirrel.NotEq{accessResponseStatusCol: http.StatusInternalServerError},
+			squirrel.NotEq{accessResponseStatusCol: http.StatusTooManyRequests},
+		}
+	}
+	return append(authenticatedConditions(), urlConditions)
+}
+
+func authenticatedConditions() squirrel.And {
+	return squirrel.And{
+		squirrel.Expr(fmt.Sprintf(`%s #>> '{%s,0}' = '[REDACTED]'`, accessRequestHeadersCol, strings.ToLower(zitadel_http.Authorization))),
+		squirrel.NotLike{accessRequestURLCol: "%/zitadel.system.v1.SystemService/%"},
+		squirrel.NotLike{accessRequestURLCol: "%/system/v1/%"},
+		squirrel.Or{
+			squirrel.And{
+				squirrel.Eq{accessProtocolCol: HTTP},
+				squirrel.NotEq{accessResponseStatusCol: http.StatusForbidden},
+				squirrel.NotEq{accessResponseStatusCol: http.StatusInternalServerError},
+				squirrel.NotEq{accessResponseStatusCol: http.StatusTooManyRequests},
+			},
+			squirrel.And{
+				squirrel.Eq{accessProtocolCol: GRPC},
+				squirrel.NotEq{accessResponseStatusCol: codes.PermissionDenied},
+				squirrel.NotEq{accessResponseStatusCol: codes.Internal},
+				squirrel.NotEq{accessResponseStatusCol: codes.ResourceExhausted},
+			},
+		},
+	}
+}
+
+func (l *databaseLogStorage) InstanceIDs(ctx context.Context, before time.Time) ([]string, error) {
+	stmt, args, err := squirrel.Select(accessInstanceIdCol).
+		Distinct().
+		From(accessLogsTable).
+		Where(squirrel.Lt{accessTimestampCol: before}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "ACCESS-Wie3o", "Errors.Internal")
+	}
+
+	rows, err := l.dbClient.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "ACCESS-ohn8E", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	instanceIDs := make([]string, 0)
+	for rows.Next() {
+		var instanceID string
+		if err = rows.Scan(&instanceID); err != nil {
+			return nil, caos_errors.ThrowInternal(err, "ACCESS-Gu5ie", "Errors.Internal")
+		}
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+	return instanceIDs, rows.Err()
+}
+
+func (l *databaseLogStorage) Cleanup(ctx context.Context, instanceID string, before time.Time) (int64, error) {
 	stmt, args, err := squirrel.Delete(accessLogsTable).
-		Where(squirrel.LtOrEq{accessTimestampCol: time.Now().Add(-keep)}).
+		Where(squirrel.And{
+			squirrel.Eq{accessInstanceIdCol: instanceID},
+			squirrel.Lt{accessTimestampCol: before},
+		}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 
 	if err != nil {
-		return caos_errors.ThrowInternal(err, "ACCESS-2oTh6", "Errors.Internal")
+		return 0, caos_errors.ThrowInternal(err, "ACCESS-2oTh6", "Errors.Internal")
 	}
 
 	execCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	_, err = l.dbClient.ExecContext(execCtx, stmt, args...)
-	return err
+	result, err := l.dbClient.ExecContext(execCtx, stmt, args...)
+	if err != nil {
+		return 0, caos_errors.ThrowInternal(err, "ACCESS-ep6Ie", "Errors.LogStore.Access.CleanupFailed")
+	}
+	return result.RowsAffected()
 }
@@ -0,0 +1,67 @@
+package access
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+func Test_usageConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		unit       quota.Unit
+		wantURLArg []interface{}
+	}{
+		{
+			name: "all authenticated requests",
+			unit: quota.RequestsAllAuthenticated,
+		},
+		{
+			name:       "token endpoint",
+			unit:       quota.RequestsTokenEndpoint,
+			wantURLArg: []interface{}{TokenEndpointPath + "%"},
+		},
+		{
+			name:       "management api",
+			unit:       quota.RequestsManagementAPI,
+			wantURLArg: []interface{}{ManagementGRPCPath + "%", ManagementRESTPath + "%"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, args, err := usageConditions(tt.unit).ToSql()
+			require.NoError(t, err)
+			// the urls are matched by prefix, the same way as the quota is enforced
+			for _, arg := range tt.wantURLArg {
+				assert.Contains(t, args, arg)
+			}
+			for _, arg := range tt.wantURLArg {
+				assert.NotContains(t, args, "%"+arg.(string))
+			}
+		})
+	}
+}
+
+func Test_databaseLogStorage_Cleanup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	before := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	// the logs at the start of a quota period are counted by the usage, so they must not be deleted
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM logstore.access WHERE (instance_id = $1 AND log_date < $2)")).
+		WithArgs("instance", before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deleted, err := NewDatabaseLogStorage(&database.DB{DB: db}).Cleanup(context.Background(), "instance", before)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
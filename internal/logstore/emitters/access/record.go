@@ -6,6 +6,7 @@ import (
 
 	zitadel_http "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/repository/quota"
 )
 
 var _ logstore.LogRecord = (*Record)(nil)
@@ -36,6 +37,51 @@ const (
 	redacted = "[REDACTED]"
 )
 
+const (
+	// TokenEndpointPath is the default path of the OIDC token endpoint
+	TokenEndpointPath = "/oauth/v2/token"
+	// ManagementGRPCPath is the prefix of all gRPC methods of the management API
+	ManagementGRPCPath = "/zitadel.management.v1.ManagementService/"
+	// ManagementRESTPath is the prefix of all REST endpoints of the management API
+	ManagementRESTPath = "/management/v1/"
+)
+
+// quotaUnitPrefixes are the prefixes of the request urls (paths and gRPC full methods),
+// which are counted for a unit in addition to [quota.RequestsAllAuthenticated].
+// They are used for enforcing the quotas as well as for querying the usage, so both count the same requests.
+var quotaUnitPrefixes = []struct {
+	unit     quota.Unit
+	prefixes []string
+}{
+	{unit: quota.RequestsTokenEndpoint, prefixes: []string{TokenEndpointPath}},
+	{unit: quota.RequestsManagementAPI, prefixes: []string{ManagementGRPCPath, ManagementRESTPath}},
+}
+
+// QuotaUnits returns the quota units a request to the url is counted for
+func QuotaUnits(requestURL string) []quota.Unit {
+	units := []quota.Unit{quota.RequestsAllAuthenticated}
+	for _, unitPrefixes := range quotaUnitPrefixes {
+		for _, prefix := range unitPrefixes.prefixes {
+			if strings.HasPrefix(requestURL, prefix) {
+				units = append(units, unitPrefixes.unit)
+				break
+			}
+		}
+	}
+	return units
+}
+
+// requestURLPrefixes returns the prefixes of the request urls counted for the unit
+// or nil if all authenticated requests are counted
+func requestURLPrefixes(unit quota.Unit) []string {
+	for _, unitPrefixes := range quotaUnitPrefixes {
+		if unitPrefixes.unit == unit {
+			return unitPrefixes.prefixes
+		}
+	}
+	return nil
+}
+
 func (a Record) Normalize() logstore.LogRecord {
 	a.RequestedDomain = cutString(a.RequestedDomain, 200)
 	a.RequestURL = cutString(a.RequestURL, 200)
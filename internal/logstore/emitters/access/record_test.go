@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
+	"github.com/zitadel/zitadel/internal/repository/quota"
 )
 
 func TestRecord_Normalize(t *testing.T) {
@@ -77,3 +78,34 @@ func TestRecord_Normalize(t *testing.T) {
 		})
 	}
 }
+
+func TestQuotaUnits(t *testing.T) {
+	tests := []struct {
+		name       string
+		requestURL string
+		want       []quota.Unit
+	}{{
+		name:       "token endpoint",
+		requestURL: "/oauth/v2/token",
+		want:       []quota.Unit{quota.RequestsAllAuthenticated, quota.RequestsTokenEndpoint},
+	}, {
+		name:       "management grpc",
+		requestURL: "/zitadel.management.v1.ManagementService/GetMyOrg",
+		want:       []quota.Unit{quota.RequestsAllAuthenticated, quota.RequestsManagementAPI},
+	}, {
+		name:       "management rest",
+		requestURL: "/management/v1/orgs/me",
+		want:       []quota.Unit{quota.RequestsAllAuthenticated, quota.RequestsManagementAPI},
+	}, {
+		name:       "other request",
+		requestURL: "/zitadel.admin.v1.AdminService/GetMyInstance",
+		want:       []quota.Unit{quota.RequestsAllAuthenticated},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := access.QuotaUnits(tt.requestURL); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("QuotaUnits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
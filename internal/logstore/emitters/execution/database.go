@@ -2,6 +2,7 @@ package execution
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -24,6 +25,13 @@ const (
 	executionInstanceIdCol = "instance_id"
 	executionActionIdCol   = "action_id"
 	executionMetadataCol   = "metadata"
+
+	executionResourceOwnerCol = "resource_owner"
+	executionFlowTypeCol      = "flow_type"
+	executionTriggerTypeCol   = "trigger_type"
+	executionUserIDCol        = "user_id"
+	executionErrorCol         = "error_message"
+	executionLogsCol          = "logs"
 )
 
 var _ logstore.UsageQuerier = (*databaseLogStorage)(nil)
@@ -37,8 +45,8 @@ func NewDatabaseLogStorage(dbClient *database.DB) *databaseLogStorage {
 	return &databaseLogStorage{dbClient: dbClient}
 }
 
-func (l *databaseLogStorage) QuotaUnit() quota.Unit {
-	return quota.ActionsAllRunsSeconds
+func (l *databaseLogStorage) QuotaUnits() []quota.Unit {
+	return []quota.Unit{quota.ActionsAllRunsSeconds}
 }
 
 func (l *databaseLogStorage) Emit(ctx context.Context, bulk []logstore.LogRecord) error {
@@ -54,6 +62,12 @@ func (l *databaseLogStorage) Emit(ctx context.Context, bulk []logstore.LogRecord
 			executionInstanceIdCol,
 			executionActionIdCol,
 			executionMetadataCol,
+			executionResourceOwnerCol,
+			executionFlowTypeCol,
+			executionTriggerTypeCol,
+			executionUserIDCol,
+			executionErrorCol,
+			executionLogsCol,
 		).
 		PlaceholderFormat(squirrel.Dollar)
 
@@ -64,6 +78,14 @@ func (l *databaseLogStorage) Emit(ctx context.Context, bulk []logstore.LogRecord
 		if item.Took > 0 {
 			took = item.Took
 		}
+		var logs interface{}
+		if len(item.Logs) > 0 {
+			marshalled, err := json.Marshal(item.Logs)
+			if err != nil {
+				return caos_errors.ThrowInternal(err, "EXEC-ieW2u", "Errors.Internal")
+			}
+			logs = marshalled
+		}
 
 		builder = builder.Values(
 			item.LogDate,
@@ -73,6 +95,12 @@ func (l *databaseLogStorage) Emit(ctx context.Context, bulk []logstore.LogRecord
 			item.InstanceID,
 			item.ActionID,
 			item.Metadata,
+			nullIfEmpty(item.ResourceOwner),
+			nullIfEmpty(item.FlowType),
+			nullIfEmpty(item.TriggerType),
+			nullIfEmpty(item.UserID),
+			nullIfEmpty(item.Error),
+			logs,
 		)
 	}
 
@@ -95,7 +123,7 @@ func (l *databaseLogStorage) Emit(ctx context.Context, bulk []logstore.LogRecord
 	return nil
 }
 
-func (l *databaseLogStorage) QueryUsage(ctx context.Context, instanceId string, start time.Time) (uint64, error) {
+func (l *databaseLogStorage) QueryUsage(ctx context.Context, instanceId string, _ quota.Unit, start time.Time) (uint64, error) {
 	stmt, args, err := squirrel.Select(
 		fmt.Sprintf("COALESCE(SUM(%s)::INT,0)", executionTookCol),
 	).
@@ -121,18 +149,61 @@ func (l *databaseLogStorage) QueryUsage(ctx context.Context, instanceId string,
 	return durationSeconds, nil
 }
 
-func (l *databaseLogStorage) Cleanup(ctx context.Context, keep time.Duration) error {
+func (l *databaseLogStorage) InstanceIDs(ctx context.Context, before time.Time) ([]string, error) {
+	stmt, args, err := squirrel.Select(executionInstanceIdCol).
+		Distinct().
+		From(executionLogsTable).
+		Where(squirrel.Lt{executionTimestampCol: before}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "EXEC-Quo4e", "Errors.Internal")
+	}
+
+	rows, err := l.dbClient.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "EXEC-aiX0f", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	instanceIDs := make([]string, 0)
+	for rows.Next() {
+		var instanceID string
+		if err = rows.Scan(&instanceID); err != nil {
+			return nil, caos_errors.ThrowInternal(err, "EXEC-Eey0i", "Errors.Internal")
+		}
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+	return instanceIDs, rows.Err()
+}
+
+func (l *databaseLogStorage) Cleanup(ctx context.Context, instanceID string, before time.Time) (int64, error) {
 	stmt, args, err := squirrel.Delete(executionLogsTable).
-		Where(squirrel.LtOrEq{executionTimestampCol: time.Now().Add(-keep)}).
+		Where(squirrel.And{
+			squirrel.Eq{executionInstanceIdCol: instanceID},
+			squirrel.Lt{executionTimestampCol: before},
+		}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 
 	if err != nil {
-		return caos_errors.ThrowInternal(err, "EXEC-Bja8V", "Errors.Internal")
+		return 0, caos_errors.ThrowInternal(err, "EXEC-Bja8V", "Errors.Internal")
 	}
 
 	execCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	_, err = l.dbClient.ExecContext(execCtx, stmt, args...)
-	return err
+	result, err := l.dbClient.ExecContext(execCtx, stmt, args...)
+	if err != nil {
+		return 0, caos_errors.ThrowInternal(err, "EXEC-ja4Ea", "Errors.LogStore.Execution.CleanupFailed")
+	}
+	return result.RowsAffected()
+}
+
+func nullIfEmpty[T comparable](value T) interface{} {
+	var empty T
+	if value == empty {
+		return nil
+	}
+	return value
 }
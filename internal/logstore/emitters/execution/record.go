@@ -5,11 +5,17 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/logstore"
 )
 
 var _ logstore.LogRecord = (*Record)(nil)
 
+const (
+	maxMessageLength = 2000
+	maxLogs          = 100
+)
+
 type Record struct {
 	LogDate    time.Time              `json:"logDate"`
 	Took       time.Duration          `json:"took"`
@@ -18,13 +24,49 @@ type Record struct {
 	InstanceID string                 `json:"instanceId"`
 	ActionID   string                 `json:"actionId,omitempty"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+
+	// the following fields are only set on the record of a finished action run
+	ResourceOwner string             `json:"resourceOwner,omitempty"`
+	FlowType      domain.FlowType    `json:"flowType,omitempty"`
+	TriggerType   domain.TriggerType `json:"triggerType,omitempty"`
+	UserID        string             `json:"userId,omitempty"`
+	Error         string             `json:"error,omitempty"`
+	Logs          []*Log             `json:"logs,omitempty"`
+}
+
+// Log is an entry the action logged during its run using the zitadel/log module
+type Log struct {
+	LogDate  time.Time    `json:"logDate"`
+	LogLevel logrus.Level `json:"logLevel"`
+	Message  string       `json:"message"`
 }
 
 func (e Record) Normalize() logstore.LogRecord {
-	e.Message = cutString(e.Message, 2000)
+	e.Message = cutString(e.Message, maxMessageLength)
+	e.Error = cutString(e.Error, maxMessageLength)
+	e.Logs = normalizeLogs(e.Logs)
 	return &e
 }
 
+// normalizeLogs keeps the latest maxLogs entries and cuts their messages
+func normalizeLogs(logs []*Log) []*Log {
+	if len(logs) == 0 {
+		return nil
+	}
+	if len(logs) > maxLogs {
+		logs = logs[len(logs)-maxLogs:]
+	}
+	normalized := make([]*Log, len(logs))
+	for i, log := range logs {
+		normalized[i] = &Log{
+			LogDate:  log.LogDate,
+			LogLevel: log.LogLevel,
+			Message:  cutString(log.Message, maxMessageLength),
+		}
+	}
+	return normalized
+}
+
 func cutString(str string, pos int) string {
 	if len(str) <= pos {
 		return str
@@ -0,0 +1,124 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+const rotationTimeFormat = "20060102T150405.000000000"
+
+var _ logstore.LogEmitter = (*fileEmitter)(nil)
+
+type fileEmitter struct {
+	mux        sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+	now        func() time.Time
+}
+
+// NewFileEmitter writes the records as newline delimited JSON to the file of the config.
+// The file is opened on the first emitted bulk and rotated as soon as it exceeds the configured size.
+func NewFileEmitter(cfg *logstore.FileEmitterConfig) *fileEmitter {
+	emitter := &fileEmitter{now: time.Now}
+	if cfg != nil {
+		emitter.path = cfg.Path
+		emitter.maxSize = cfg.MaxSize
+		emitter.maxBackups = cfg.MaxBackups
+	}
+	return emitter
+}
+
+func (f *fileEmitter) Emit(_ context.Context, bulk []logstore.LogRecord) error {
+	if len(bulk) == 0 {
+		return nil
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if err := f.open(); err != nil {
+		return err
+	}
+	for idx := range bulk {
+		line, err := json.Marshal(bulk[idx])
+		if err != nil {
+			return caos_errors.ThrowInternal(err, "FILE-Oofe4", "Errors.Internal")
+		}
+		line = append(line, '\n')
+		if f.maxSize > 0 && f.size > 0 && f.size+int64(len(line)) > f.maxSize {
+			if err = f.rotate(); err != nil {
+				return err
+			}
+		}
+		n, err := f.file.Write(line)
+		f.size += int64(n)
+		if err != nil {
+			return caos_errors.ThrowInternal(err, "FILE-aeN2u", "Errors.LogStore.File.WriteFailed")
+		}
+	}
+	return nil
+}
+
+func (f *fileEmitter) open() error {
+	if f.file != nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return caos_errors.ThrowInternal(err, "FILE-Ahw3i", "Errors.LogStore.File.OpenFailed")
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "FILE-ooL8a", "Errors.LogStore.File.OpenFailed")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		logging.OnError(file.Close()).Warn("closing log file failed")
+		return caos_errors.ThrowInternal(err, "FILE-Yie5e", "Errors.LogStore.File.OpenFailed")
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate renames the current file by suffixing it with the current time,
+// opens a new file and removes the oldest rotated files exceeding maxBackups
+func (f *fileEmitter) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return caos_errors.ThrowInternal(err, "FILE-ieX6a", "Errors.LogStore.File.RotationFailed")
+	}
+	f.file = nil
+	if err := os.Rename(f.path, f.path+"."+f.now().UTC().Format(rotationTimeFormat)); err != nil {
+		return caos_errors.ThrowInternal(err, "FILE-Ui7ee", "Errors.LogStore.File.RotationFailed")
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	if f.maxBackups <= 0 {
+		return nil
+	}
+	backups, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "FILE-Ae3ph", "Errors.LogStore.File.RotationFailed")
+	}
+	if len(backups) <= f.maxBackups {
+		return nil
+	}
+	// the suffixes are sortable timestamps, so the oldest files come first
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-f.maxBackups] {
+		logging.WithFields("file", backup).OnError(os.Remove(backup)).Warn("removing rotated log file failed")
+	}
+	return nil
+}
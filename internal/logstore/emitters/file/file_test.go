@@ -0,0 +1,101 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+type record struct {
+	Message string `json:"message"`
+}
+
+func (r record) Normalize() logstore.LogRecord {
+	return &r
+}
+
+func TestFileEmitter_Emit(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *logstore.FileEmitterConfig
+		bulks       [][]logstore.LogRecord
+		wantLines   int
+		wantBackups int
+	}{
+		{
+			name: "no rotation",
+			cfg:  &logstore.FileEmitterConfig{},
+			bulks: [][]logstore.LogRecord{
+				{&record{Message: "first"}, &record{Message: "second"}},
+				{&record{Message: "third"}},
+			},
+			wantLines:   3,
+			wantBackups: 0,
+		},
+		{
+			name: "rotation",
+			cfg:  &logstore.FileEmitterConfig{MaxSize: 25},
+			bulks: [][]logstore.LogRecord{
+				{&record{Message: "first"}, &record{Message: "second"}},
+				{&record{Message: "third"}},
+			},
+			wantLines:   1,
+			wantBackups: 2,
+		},
+		{
+			name: "rotation, backups removed",
+			cfg:  &logstore.FileEmitterConfig{MaxSize: 25, MaxBackups: 1},
+			bulks: [][]logstore.LogRecord{
+				{&record{Message: "first"}, &record{Message: "second"}},
+				{&record{Message: "third"}},
+			},
+			wantLines:   1,
+			wantBackups: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Path = filepath.Join(t.TempDir(), "logs", "access.log")
+			emitter := NewFileEmitter(tt.cfg)
+			now := time.Now()
+			emitter.now = func() time.Time {
+				now = now.Add(time.Second)
+				return now
+			}
+			for _, bulk := range tt.bulks {
+				if err := emitter.Emit(context.Background(), bulk); err != nil {
+					t.Fatalf("Emit() unexpected error = %v", err)
+				}
+			}
+			if lines := countLines(t, tt.cfg.Path); lines != tt.wantLines {
+				t.Errorf("file has %d lines, want %d", lines, tt.wantLines)
+			}
+			backups, err := filepath.Glob(tt.cfg.Path + ".*")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(backups) != tt.wantBackups {
+				t.Errorf("got %d rotated files, want %d", len(backups), tt.wantBackups)
+			}
+		})
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var lines int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines
+}
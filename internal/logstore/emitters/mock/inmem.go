@@ -29,8 +29,8 @@ func NewInMemoryStorage(clock clock.Clock) *InmemLogStorage {
 	}
 }
 
-func (l *InmemLogStorage) QuotaUnit() quota.Unit {
-	return quota.Unimplemented
+func (l *InmemLogStorage) QuotaUnits() []quota.Unit {
+	return []quota.Unit{quota.Unimplemented}
 }
 
 func (l *InmemLogStorage) Emit(_ context.Context, bulk []logstore.LogRecord) error {
@@ -46,7 +46,7 @@ func (l *InmemLogStorage) Emit(_ context.Context, bulk []logstore.LogRecord) err
 	return nil
 }
 
-func (l *InmemLogStorage) QueryUsage(_ context.Context, _ string, start time.Time) (uint64, error) {
+func (l *InmemLogStorage) QueryUsage(_ context.Context, _ string, _ quota.Unit, start time.Time) (uint64, error) {
 	l.mux.Lock()
 	defer l.mux.Unlock()
 
@@ -59,19 +59,25 @@ func (l *InmemLogStorage) QueryUsage(_ context.Context, _ string, start time.Tim
 	return count, nil
 }
 
-func (l *InmemLogStorage) Cleanup(_ context.Context, keep time.Duration) error {
+// InstanceIDs returns a single empty instance id, as the records of the in memory storage don't belong to an instance
+func (l *InmemLogStorage) InstanceIDs(context.Context, time.Time) ([]string, error) {
+	return []string{""}, nil
+}
+
+func (l *InmemLogStorage) Cleanup(_ context.Context, _ string, before time.Time) (int64, error) {
 	l.mux.Lock()
 	defer l.mux.Unlock()
 
 	clean := make([]*record, 0)
-	from := l.clock.Now().Add(-(keep + 1))
+	from := before.Add(-1)
 	for _, r := range l.emitted {
 		if r.ts.After(from) {
 			clean = append(clean, r)
 		}
 	}
+	deleted := int64(len(l.emitted) - len(clean))
 	l.emitted = clean
-	return nil
+	return deleted, nil
 }
 
 func (l *InmemLogStorage) Bulks() []int {
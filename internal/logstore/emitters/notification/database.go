@@ -0,0 +1,158 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+const (
+	notificationLogsTable     = "logstore.notification"
+	notificationTimestampCol  = "log_date"
+	notificationChannelCol    = "channel"
+	notificationEventTypeCol  = "event_type"
+	notificationInstanceIdCol = "instance_id"
+)
+
+var _ logstore.UsageQuerier = (*databaseLogStorage)(nil)
+var _ logstore.LogCleanupper = (*databaseLogStorage)(nil)
+
+type databaseLogStorage struct {
+	dbClient *database.DB
+}
+
+func NewDatabaseLogStorage(dbClient *database.DB) *databaseLogStorage {
+	return &databaseLogStorage{dbClient: dbClient}
+}
+
+func (l *databaseLogStorage) QuotaUnits() []quota.Unit {
+	return []quota.Unit{quota.NotificationsAllSent}
+}
+
+func (l *databaseLogStorage) Emit(ctx context.Context, bulk []logstore.LogRecord) error {
+	if len(bulk) == 0 {
+		return nil
+	}
+	builder := squirrel.Insert(notificationLogsTable).
+		Columns(
+			notificationTimestampCol,
+			notificationChannelCol,
+			notificationEventTypeCol,
+			notificationInstanceIdCol,
+		).
+		PlaceholderFormat(squirrel.Dollar)
+
+	for idx := range bulk {
+		item := bulk[idx].(*Record)
+		builder = builder.Values(
+			item.LogDate,
+			item.Channel,
+			item.EventType,
+			item.InstanceID,
+		)
+	}
+
+	stmt, args, err := builder.ToSql()
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "NOTIF-Ahp1u", "Errors.Internal")
+	}
+
+	result, err := l.dbClient.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "NOTIF-Gei4o", "Errors.LogStore.Notification.StorageFailed")
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "NOTIF-eeD7j", "Errors.Internal")
+	}
+
+	logging.WithFields("rows", rows).Debug("successfully stored notification logs")
+	return nil
+}
+
+func (l *databaseLogStorage) QueryUsage(ctx context.Context, instanceId string, _ quota.Unit, start time.Time) (uint64, error) {
+	stmt, args, err := squirrel.Select(
+		fmt.Sprintf("count(%s)", notificationInstanceIdCol),
+	).
+		From(notificationLogsTable + l.dbClient.Timetravel(call.Took(ctx))).
+		Where(squirrel.And{
+			squirrel.Eq{notificationInstanceIdCol: instanceId},
+			squirrel.GtOrEq{notificationTimestampCol: start},
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+
+	if err != nil {
+		return 0, caos_errors.ThrowInternal(err, "NOTIF-Xoo6e", "Errors.Internal")
+	}
+
+	var count uint64
+	if err = l.dbClient.
+		QueryRowContext(ctx, stmt, args...).
+		Scan(&count); err != nil {
+		return 0, caos_errors.ThrowInternal(err, "NOTIF-ieT0a", "Errors.LogStore.Notification.ScanFailed")
+	}
+
+	return count, nil
+}
+
+func (l *databaseLogStorage) InstanceIDs(ctx context.Context, before time.Time) ([]string, error) {
+	stmt, args, err := squirrel.Select(notificationInstanceIdCol).
+		Distinct().
+		From(notificationLogsTable).
+		Where(squirrel.Lt{notificationTimestampCol: before}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "NOTIF-ooK4i", "Errors.Internal")
+	}
+
+	rows, err := l.dbClient.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "NOTIF-Aef6p", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	instanceIDs := make([]string, 0)
+	for rows.Next() {
+		var instanceID string
+		if err = rows.Scan(&instanceID); err != nil {
+			return nil, caos_errors.ThrowInternal(err, "NOTIF-eiK2e", "Errors.Internal")
+		}
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+	return instanceIDs, rows.Err()
+}
+
+func (l *databaseLogStorage) Cleanup(ctx context.Context, instanceID string, before time.Time) (int64, error) {
+	stmt, args, err := squirrel.Delete(notificationLogsTable).
+		Where(squirrel.And{
+			squirrel.Eq{notificationInstanceIdCol: instanceID},
+			squirrel.Lt{notificationTimestampCol: before},
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+
+	if err != nil {
+		return 0, caos_errors.ThrowInternal(err, "NOTIF-Ohr6a", "Errors.Internal")
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	result, err := l.dbClient.ExecContext(execCtx, stmt, args...)
+	if err != nil {
+		return 0, caos_errors.ThrowInternal(err, "NOTIF-Vah9u", "Errors.LogStore.Notification.CleanupFailed")
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+var _ logstore.LogRecord = (*Record)(nil)
+
+type Record struct {
+	LogDate    time.Time `json:"logDate"`
+	Channel    Channel   `json:"channel"`
+	EventType  string    `json:"eventType"`
+	InstanceID string    `json:"instanceId"`
+}
+
+type Channel uint8
+
+const (
+	Email Channel = iota
+	SMS
+)
+
+func (r Record) Normalize() logstore.LogRecord {
+	return &r
+}
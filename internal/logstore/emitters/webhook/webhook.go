@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+const (
+	contentType       = "application/x-ndjson"
+	defaultTimeout    = 10 * time.Second
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+	// queueSize is the amount of bulks which are buffered while previous bulks are posted
+	queueSize = 100
+)
+
+var _ logstore.LogEmitter = (*httpEmitter)(nil)
+
+type httpEmitter struct {
+	client     *http.Client
+	endpoint   string
+	headers    http.Header
+	maxRetries uint
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	queue      chan []byte
+	postOnce   sync.Once
+}
+
+// NewHTTPEmitter posts each bulk of records as newline delimited JSON to the endpoint of the config.
+// The bulks are posted by a single go routine, so failed requests are retried with an exponential backoff
+// without blocking the caller of Emit.
+func NewHTTPEmitter(cfg *logstore.HTTPEmitterConfig) *httpEmitter {
+	emitter := &httpEmitter{
+		client:     &http.Client{Timeout: defaultTimeout},
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		queue:      make(chan []byte, queueSize),
+	}
+	if cfg == nil {
+		return emitter
+	}
+	emitter.endpoint = cfg.Endpoint
+	emitter.headers = cfg.Headers
+	emitter.maxRetries = cfg.MaxRetries
+	if cfg.Timeout > 0 {
+		emitter.client.Timeout = cfg.Timeout
+	}
+	if cfg.MinBackoff > 0 {
+		emitter.minBackoff = cfg.MinBackoff
+	}
+	if cfg.MaxBackoff > 0 {
+		emitter.maxBackoff = cfg.MaxBackoff
+	}
+	return emitter
+}
+
+// Emit encodes the bulk and queues it for posting.
+// It returns an error if the bulk can't be encoded or the queue is full,
+// failed requests are only logged by the posting go routine.
+func (h *httpEmitter) Emit(_ context.Context, bulk []logstore.LogRecord) error {
+	if len(bulk) == 0 {
+		return nil
+	}
+	body := new(bytes.Buffer)
+	encoder := json.NewEncoder(body)
+	for idx := range bulk {
+		if err := encoder.Encode(bulk[idx]); err != nil {
+			return caos_errors.ThrowInternal(err, "WEBHO-Ohb3a", "Errors.Internal")
+		}
+	}
+	h.postOnce.Do(func() {
+		// the records are posted after the request which emitted them is done,
+		// so the posting go routine must not use its context
+		go h.postQueued(context.Background())
+	})
+	select {
+	case h.queue <- body.Bytes():
+		return nil
+	default:
+		return caos_errors.ThrowResourceExhausted(nil, "WEBHO-Wu3ie", "Errors.LogStore.HTTP.QueueFull")
+	}
+}
+
+func (h *httpEmitter) postQueued(ctx context.Context) {
+	for body := range h.queue {
+		logging.OnError(h.post(ctx, body)).Error("posting log records failed")
+	}
+}
+
+// post sends the body to the endpoint and retries failed requests with an exponential backoff
+func (h *httpEmitter) post(ctx context.Context, body []byte) error {
+	backoff := h.minBackoff
+	for attempt := uint(0); ; attempt++ {
+		retry, err := h.send(ctx, body)
+		if err == nil {
+			logging.WithFields("bytes", len(body), "attempt", attempt).Debug("successfully posted log records")
+			return nil
+		}
+		if !retry || attempt >= h.maxRetries {
+			return err
+		}
+		logging.WithFields("attempt", attempt, "backoff", backoff).WithError(err).Info("posting log records failed, retrying")
+		select {
+		case <-ctx.Done():
+			return caos_errors.ThrowInternal(ctx.Err(), "WEBHO-ooT4u", "Errors.LogStore.HTTP.RequestFailed")
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > h.maxBackoff {
+			backoff = h.maxBackoff
+		}
+	}
+}
+
+// send posts the body to the endpoint
+// and returns if the request can be retried in case of an error
+func (h *httpEmitter) send(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, caos_errors.ThrowInternal(err, "WEBHO-Eeth8", "Errors.LogStore.HTTP.RequestFailed")
+	}
+	for key, values := range h.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return true, caos_errors.ThrowUnavailable(err, "WEBHO-Thie3", "Errors.LogStore.HTTP.RequestFailed")
+	}
+	logging.OnError(resp.Body.Close()).Debug("closing response body failed")
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = caos_errors.ThrowUnavailable(fmt.Errorf("calling %s returned %s", h.endpoint, resp.Status), "WEBHO-eiL4o", "Errors.LogStore.HTTP.RequestFailed")
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+type record struct {
+	Message string `json:"message"`
+}
+
+func (r record) Normalize() logstore.LogRecord {
+	return &r
+}
+
+func TestHTTPEmitter_post(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxRetries   uint
+		wantErr      bool
+		wantRequests int32
+	}{
+		{
+			name:         "success",
+			statuses:     []int{http.StatusOK},
+			wantRequests: 1,
+		},
+		{
+			name:         "retry until success",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			maxRetries:   3,
+			wantRequests: 3,
+		},
+		{
+			name:         "retries exceeded",
+			statuses:     []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			maxRetries:   1,
+			wantErr:      true,
+			wantRequests: 2,
+		},
+		{
+			name:         "client error not retried",
+			statuses:     []int{http.StatusBadRequest, http.StatusOK},
+			maxRetries:   3,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := atomic.AddInt32(&requests, 1) - 1
+				if r.Header.Get("Content-Type") != contentType || r.Header.Get("Authorization") != "Bearer token" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				var lines int
+				scanner := bufio.NewScanner(r.Body)
+				for scanner.Scan() {
+					lines++
+				}
+				if lines != 2 {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(tt.statuses[i])
+			}))
+			defer server.Close()
+
+			emitter := NewHTTPEmitter(&logstore.HTTPEmitterConfig{
+				Endpoint:   server.URL,
+				Headers:    http.Header{"Authorization": {"Bearer token"}},
+				MaxRetries: tt.maxRetries,
+				MinBackoff: time.Millisecond,
+				MaxBackoff: 2 * time.Millisecond,
+			})
+			err := emitter.post(context.Background(), []byte("{\"message\":\"first\"}\n{\"message\":\"second\"}\n"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("post() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestHTTPEmitter_Emit(t *testing.T) {
+	posted := make(chan []byte, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		posted <- body
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	emitter := NewHTTPEmitter(&logstore.HTTPEmitterConfig{
+		Endpoint:   server.URL,
+		MaxRetries: 1,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	// the failing requests are retried asynchronously, so Emit returns without an error
+	if err := emitter.Emit(ctx, []logstore.LogRecord{&record{Message: "first"}}); err != nil {
+		t.Fatalf("Emit() error = %v", err)
+	}
+	// the records are posted even if the context of the emitting request is done
+	cancel()
+	for i := 0; i < 2; i++ {
+		select {
+		case body := <-posted:
+			if string(body) != "{\"message\":\"first\"}\n" {
+				t.Errorf("got body %q", body)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("got %d requests, want 2", i)
+		}
+	}
+}
+
+func TestHTTPEmitter_Emit_queueFull(t *testing.T) {
+	emitter := NewHTTPEmitter(nil)
+	emitter.queue = make(chan []byte)
+	// the posting go routine isn't started, so nothing can be queued
+	emitter.postOnce.Do(func() {})
+	err := emitter.Emit(context.Background(), []logstore.LogRecord{&record{Message: "first"}})
+	if !errors.IsResourceExhausted(err) {
+		t.Errorf("Emit() error = %v, want resource exhausted", err)
+	}
+}
@@ -0,0 +1,117 @@
+package logstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/zitadel/logging"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+)
+
+const (
+	prunedRecordsCounter            = "logstore_pruned_records"
+	prunedRecordsCounterDescription = "Log records deleted from the logstore after their retention"
+	prunedRecordsLabel              = "records"
+	pruneLockDuration               = time.Minute
+)
+
+// Locker is implemented by the locker of the projections
+type Locker interface {
+	Lock(ctx context.Context, lockDuration time.Duration, instanceIDs ...string) <-chan error
+	Unlock(instanceIDs ...string) error
+}
+
+type pruner struct {
+	name         string
+	cleanupper   LogCleanupper
+	keep         time.Duration
+	clock        clock.Clock
+	locker       Locker
+	quotaQuerier QuotaQuerier
+}
+
+func registerPrunedRecordsCounter() error {
+	return metrics.RegisterCounter(prunedRecordsCounter, prunedRecordsCounterDescription)
+}
+
+func (p *pruner) prune(ctx context.Context) error {
+	before := p.clock.Now().Add(-p.keep)
+	instanceIDs, err := p.cleanupper.InstanceIDs(ctx, before)
+	if err != nil {
+		return err
+	}
+	for _, instanceID := range instanceIDs {
+		err = p.pruneInstance(ctx, instanceID, before)
+		logging.WithFields("records", p.name, "instance", instanceID).OnError(err).Warn("pruning logs of instance failed")
+	}
+	return nil
+}
+
+func (p *pruner) pruneInstance(ctx context.Context, instanceID string, before time.Time) error {
+	if p.locker != nil {
+		lockCtx, cancel := context.WithCancel(ctx)
+		errs := p.locker.Lock(lockCtx, pruneLockDuration, instanceID)
+		if err, ok := <-errs; err != nil || !ok {
+			cancel()
+			if errors.IsErrorAlreadyExists(err) {
+				// another process is pruning the instance
+				return nil
+			}
+			return err
+		}
+		go cancelOnErr(lockCtx, errs, cancel)
+		defer func() {
+			cancel()
+			logging.WithFields("records", p.name, "instance", instanceID).OnError(p.locker.Unlock(instanceID)).Warn("unable to unlock pruning")
+		}()
+		ctx = lockCtx
+	}
+
+	before, err := p.retainQuotaPeriods(ctx, instanceID, before)
+	if err != nil {
+		return err
+	}
+	deleted, err := p.cleanupper.Cleanup(ctx, instanceID, before)
+	if err != nil {
+		return err
+	}
+	logging.WithFields("records", p.name, "instance", instanceID, "deleted", deleted).Debug("pruned logs")
+	return metrics.AddCount(ctx, prunedRecordsCounter, deleted, map[string]attribute.Value{prunedRecordsLabel: attribute.StringValue(p.name)})
+}
+
+// retainQuotaPeriods moves before to the start of the earliest current quota period,
+// so the logs needed for the quota usage are kept
+func (p *pruner) retainQuotaPeriods(ctx context.Context, instanceID string, before time.Time) (time.Time, error) {
+	usageQuerier, ok := p.cleanupper.(UsageQuerier)
+	if !ok || p.quotaQuerier == nil {
+		return before, nil
+	}
+	for _, unit := range usageQuerier.QuotaUnits() {
+		config, periodStart, err := p.quotaQuerier.GetCurrentQuotaPeriod(ctx, instanceID, unit)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if config != nil && periodStart.Before(before) {
+			before = periodStart
+		}
+	}
+	return before, nil
+}
+
+func cancelOnErr(ctx context.Context, errs <-chan error, cancel func()) {
+	for {
+		select {
+		case err := <-errs:
+			if err != nil {
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
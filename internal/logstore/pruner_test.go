@@ -0,0 +1,169 @@
+// The library github.com/benbjohnson/clock fails when race is enabled
+// https://github.com/benbjohnson/clock/issues/44
+//go:build !race
+
+package logstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+func Test_pruner_prune(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	keep := 24 * time.Hour
+	type fields struct {
+		locker       *testLocker
+		quotaQuerier QuotaQuerier
+	}
+	type want struct {
+		before   map[string]time.Time
+		unlocked []string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name:   "without locker and quotas",
+			fields: fields{},
+			want: want{
+				before: map[string]time.Time{
+					"instance1": now.Add(-keep),
+					"instance2": now.Add(-keep),
+				},
+			},
+		},
+		{
+			name: "locked by other process",
+			fields: fields{
+				locker: &testLocker{
+					lockErr: map[string]error{
+						"instance1": errors.ThrowAlreadyExists(nil, "TEST-Ahx2i", "projection already locked"),
+					},
+				},
+			},
+			want: want{
+				before: map[string]time.Time{
+					"instance2": now.Add(-keep),
+				},
+				unlocked: []string{"instance2"},
+			},
+		},
+		{
+			name: "current quota period retained",
+			fields: fields{
+				locker: &testLocker{},
+				quotaQuerier: &testQuotaQuerier{
+					periodStarts: map[string]time.Time{
+						"instance1": now.Add(-48 * time.Hour),
+						"instance2": now.Add(-time.Hour),
+					},
+				},
+			},
+			want: want{
+				before: map[string]time.Time{
+					"instance1": now.Add(-48 * time.Hour),
+					"instance2": now.Add(-keep),
+				},
+				unlocked: []string{"instance1", "instance2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := clock.NewMock()
+			clock.Set(now)
+			cleanupper := &testCleanupper{
+				instanceIDs: []string{"instance1", "instance2"},
+				before:      make(map[string]time.Time),
+			}
+			p := &pruner{
+				name:         "test",
+				cleanupper:   cleanupper,
+				keep:         keep,
+				clock:        clock,
+				quotaQuerier: tt.fields.quotaQuerier,
+			}
+			if tt.fields.locker != nil {
+				p.locker = tt.fields.locker
+			}
+			require.NoError(t, p.prune(context.Background()))
+			assert.Equal(t, tt.want.before, cleanupper.before)
+			if tt.fields.locker != nil {
+				assert.Equal(t, tt.want.unlocked, tt.fields.locker.unlocked)
+			}
+		})
+	}
+}
+
+type testCleanupper struct {
+	instanceIDs []string
+	before      map[string]time.Time
+}
+
+func (c *testCleanupper) Emit(context.Context, []LogRecord) error {
+	return nil
+}
+
+func (c *testCleanupper) QuotaUnits() []quota.Unit {
+	return []quota.Unit{quota.RequestsAllAuthenticated}
+}
+
+func (c *testCleanupper) QueryUsage(context.Context, string, quota.Unit, time.Time) (uint64, error) {
+	return 0, nil
+}
+
+func (c *testCleanupper) InstanceIDs(context.Context, time.Time) ([]string, error) {
+	return c.instanceIDs, nil
+}
+
+func (c *testCleanupper) Cleanup(_ context.Context, instanceID string, before time.Time) (int64, error) {
+	c.before[instanceID] = before
+	return 1, nil
+}
+
+type testLocker struct {
+	lockErr  map[string]error
+	unlocked []string
+}
+
+func (l *testLocker) Lock(ctx context.Context, _ time.Duration, instanceIDs ...string) <-chan error {
+	errs := make(chan error, 1)
+	errs <- l.lockErr[instanceIDs[0]]
+	go func() {
+		<-ctx.Done()
+		close(errs)
+	}()
+	return errs
+}
+
+func (l *testLocker) Unlock(instanceIDs ...string) error {
+	l.unlocked = append(l.unlocked, instanceIDs...)
+	return nil
+}
+
+type testQuotaQuerier struct {
+	periodStarts map[string]time.Time
+}
+
+func (q *testQuotaQuerier) GetCurrentQuotaPeriod(_ context.Context, instanceID string, _ quota.Unit) (*quota.AddedEvent, time.Time, error) {
+	return new(quota.AddedEvent), q.periodStarts[instanceID], nil
+}
+
+func (q *testQuotaQuerier) GetDueQuotaNotifications(context.Context, *quota.AddedEvent, time.Time, uint64) ([]*quota.NotificationDueEvent, error) {
+	return nil, nil
+}
+
+func (q *testQuotaQuerier) GetQuotaDegradation(context.Context, string, quota.Unit) (*quota.DegradedModeEnteredEvent, error) {
+	return nil, nil
+}
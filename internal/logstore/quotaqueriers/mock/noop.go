@@ -26,3 +26,7 @@ func (i *inmemReporter) GetCurrentQuotaPeriod(context.Context, string, quota.Uni
 func (*inmemReporter) GetDueQuotaNotifications(context.Context, *quota.AddedEvent, time.Time, uint64) ([]*quota.NotificationDueEvent, error) {
 	return nil, nil
 }
+
+func (*inmemReporter) GetQuotaDegradation(context.Context, string, quota.Unit) (*quota.DegradedModeEnteredEvent, error) {
+	return nil, nil
+}
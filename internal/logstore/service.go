@@ -3,6 +3,7 @@ package logstore
 import (
 	"context"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/zitadel/logging"
@@ -16,12 +17,16 @@ const handleThresholdTimeout = time.Minute
 type QuotaQuerier interface {
 	GetCurrentQuotaPeriod(ctx context.Context, instanceID string, unit quota.Unit) (config *quota.AddedEvent, periodStart time.Time, err error)
 	GetDueQuotaNotifications(ctx context.Context, config *quota.AddedEvent, periodStart time.Time, used uint64) ([]*quota.NotificationDueEvent, error)
+	// GetQuotaDegradation returns the event of the instance entering the degraded mode of a soft quota,
+	// nil is returned if the instance is not in degraded mode
+	GetQuotaDegradation(ctx context.Context, instanceID string, unit quota.Unit) (*quota.DegradedModeEnteredEvent, error)
 }
 
 type UsageQuerier interface {
 	LogEmitter
-	QuotaUnit() quota.Unit
-	QueryUsage(ctx context.Context, instanceId string, start time.Time) (uint64, error)
+	// QuotaUnits returns the units the usage can be queried for
+	QuotaUnits() []quota.Unit
+	QueryUsage(ctx context.Context, instanceId string, unit quota.Unit, start time.Time) (uint64, error)
 }
 
 type UsageReporter interface {
@@ -34,26 +39,41 @@ func (u UsageReporterFunc) Report(ctx context.Context, notifications []*quota.No
 	return u(ctx, notifications)
 }
 
+// DegradationReporter records the instance entering and leaving the degraded mode of a soft quota
+type DegradationReporter interface {
+	ReportQuotaDegradation(ctx context.Context, instanceID string, unit quota.Unit, periodStart time.Time, usage uint64, degraded bool) error
+}
+
 type Service struct {
-	usageQuerier     UsageQuerier
-	quotaQuerier     QuotaQuerier
-	usageReporter    UsageReporter
-	enabledSinks     []*emitter
-	sinkEnabled      bool
-	reportingEnabled bool
+	usageQuerier        UsageQuerier
+	quotaQuerier        QuotaQuerier
+	usageReporter       UsageReporter
+	degradationReporter DegradationReporter
+	enabledSinks        []*emitter
+	sinkEnabled         bool
+	reportingEnabled    bool
+	// reportingDegradations contains the degradationKey of each quota whose degradation is currently reported,
+	// so concurrent degraded requests don't report the same degradation multiple times
+	reportingDegradations sync.Map
 }
 
-func New(quotaQuerier QuotaQuerier, usageReporter UsageReporter, usageQuerierSink *emitter, additionalSink ...*emitter) *Service {
+type degradationKey struct {
+	instanceID string
+	unit       quota.Unit
+}
+
+func New(quotaQuerier QuotaQuerier, usageReporter UsageReporter, degradationReporter DegradationReporter, usageQuerierSink *emitter, additionalSink ...*emitter) *Service {
 	var usageQuerier UsageQuerier
 	if usageQuerierSink != nil {
 		usageQuerier = usageQuerierSink.emitter.(UsageQuerier)
 	}
 
 	svc := &Service{
-		reportingEnabled: usageQuerierSink != nil && usageQuerierSink.enabled,
-		usageQuerier:     usageQuerier,
-		quotaQuerier:     quotaQuerier,
-		usageReporter:    usageReporter,
+		reportingEnabled:    usageQuerierSink != nil && usageQuerierSink.enabled,
+		usageQuerier:        usageQuerier,
+		quotaQuerier:        quotaQuerier,
+		usageReporter:       usageReporter,
+		degradationReporter: degradationReporter,
 	}
 
 	for _, s := range append([]*emitter{usageQuerierSink}, additionalSink...) {
@@ -77,34 +97,97 @@ func (s *Service) Handle(ctx context.Context, record LogRecord) {
 	}
 }
 
-func (s *Service) Limit(ctx context.Context, instanceID string) *uint64 {
+// Limit returns the remaining amount of the quota of the unit,
+// nil is returned if the usage is not limited
+func (s *Service) Limit(ctx context.Context, instanceID string, unit quota.Unit) *uint64 {
+	remaining, _ := s.LimitDegraded(ctx, instanceID, unit)
+	return remaining
+}
+
+// LimitDegraded returns the remaining amount of the quota of the unit like Limit
+// and if the instance is in the degraded mode of a soft quota.
+// In degraded mode, the usage is not limited during the grace and the remaining amount is 0 after it.
+func (s *Service) LimitDegraded(ctx context.Context, instanceID string, unit quota.Unit) (_ *uint64, degraded bool) {
 	var err error
 	defer func() {
 		logging.OnError(err).Warn("failed to check is usage should be limited")
 	}()
 
-	if !s.reportingEnabled || instanceID == "" {
-		return nil
+	if instanceID == "" || !s.SupportsUnit(unit) {
+		return nil, false
 	}
 
-	quota, periodStart, err := s.quotaQuerier.GetCurrentQuotaPeriod(ctx, instanceID, s.usageQuerier.QuotaUnit())
+	quota, periodStart, err := s.quotaQuerier.GetCurrentQuotaPeriod(ctx, instanceID, unit)
 	if err != nil || quota == nil {
-		return nil
+		return nil, false
 	}
 
-	usage, err := s.usageQuerier.QueryUsage(ctx, instanceID, periodStart)
+	usage, err := s.usageQuerier.QueryUsage(ctx, instanceID, unit, periodStart)
 	if err != nil {
-		return nil
+		return nil, false
 	}
 
 	go s.handleThresholds(ctx, quota, periodStart, usage)
 
-	var remaining *uint64
-	if quota.Limit {
-		r := uint64(math.Max(0, float64(quota.Amount)-float64(usage)))
-		remaining = &r
+	if !quota.Limit {
+		return nil, false
 	}
-	return remaining
+	if quota.Soft() {
+		return s.softLimit(ctx, instanceID, quota, periodStart, usage)
+	}
+	remaining := uint64(math.Max(0, float64(quota.Amount)-float64(usage)))
+	return &remaining, false
+}
+
+// softLimit enters the degraded mode if the amount is used and leaves it as soon as the usage is below the amount
+func (s *Service) softLimit(ctx context.Context, instanceID string, config *quota.AddedEvent, periodStart time.Time, usage uint64) (_ *uint64, degraded bool) {
+	degradation, err := s.quotaQuerier.GetQuotaDegradation(ctx, instanceID, config.Unit)
+	if err != nil {
+		logging.WithError(err).Warn("failed to check if instance is degraded")
+		return nil, false
+	}
+	degraded = usage >= config.Amount
+	degradedInPeriod := degradation != nil && degradation.PeriodStart.Equal(periodStart)
+	if (degraded && !degradedInPeriod) || (!degraded && degradation != nil) {
+		s.startReportDegradation(ctx, instanceID, config.Unit, periodStart, usage, degraded)
+	}
+	if !degraded {
+		remaining := config.Amount - usage
+		return &remaining, false
+	}
+	degradedSince := time.Now()
+	if degradedInPeriod {
+		degradedSince = degradation.CreationDate()
+	}
+	if withinGrace(config, usage, degradedSince) {
+		return nil, true
+	}
+	var remaining uint64
+	return &remaining, true
+}
+
+// withinGrace returns false as soon as either the grace percentage or the grace period is exceeded
+func withinGrace(config *quota.AddedEvent, usage uint64, degradedSince time.Time) bool {
+	if config.GracePercent > 0 && usage >= config.Amount+config.Amount*uint64(config.GracePercent)/100 {
+		return false
+	}
+	if config.GracePeriod > 0 && time.Since(degradedSince) >= config.GracePeriod {
+		return false
+	}
+	return true
+}
+
+// SupportsUnit returns if the usage of the unit can be queried
+func (s *Service) SupportsUnit(unit quota.Unit) bool {
+	if !s.reportingEnabled {
+		return false
+	}
+	for _, supported := range s.usageQuerier.QuotaUnits() {
+		if supported == unit {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *Service) handleThresholds(ctx context.Context, quota *quota.AddedEvent, periodStart time.Time, usage uint64) {
@@ -123,3 +206,28 @@ func (s *Service) handleThresholds(ctx context.Context, quota *quota.AddedEvent,
 
 	err = s.usageReporter.Report(detatchedCtx, notifications)
 }
+
+// startReportDegradation reports the degradation in a separate go routine,
+// unless the degradation of the quota is already being reported.
+// The reporter checks the current state before pushing, so later reports don't duplicate the events.
+func (s *Service) startReportDegradation(ctx context.Context, instanceID string, unit quota.Unit, periodStart time.Time, usage uint64, degraded bool) {
+	key := degradationKey{instanceID: instanceID, unit: unit}
+	if _, reporting := s.reportingDegradations.LoadOrStore(key, struct{}{}); reporting {
+		return
+	}
+	go func() {
+		defer s.reportingDegradations.Delete(key)
+		s.reportDegradation(ctx, instanceID, unit, periodStart, usage, degraded)
+	}()
+}
+
+func (s *Service) reportDegradation(ctx context.Context, instanceID string, unit quota.Unit, periodStart time.Time, usage uint64, degraded bool) {
+	if s.degradationReporter == nil {
+		return
+	}
+	detatchedCtx, cancel := context.WithTimeout(authz.Detach(ctx), handleThresholdTimeout)
+	defer cancel()
+
+	err := s.degradationReporter.ReportQuotaDegradation(detatchedCtx, instanceID, unit, periodStart, usage, degraded)
+	logging.OnError(err).Warn("reporting quota degradation failed")
+}
@@ -253,6 +253,7 @@ func given(t *testing.T, args args, want want) (context.Context, *clock.Mock, *e
 	svc := logstore.New(
 		quotaqueriermock.NewNoopQuerier(&args.config, periodStart),
 		logstore.UsageReporterFunc(func(context.Context, []*quota.NotificationDueEvent) error { return nil }),
+		nil,
 		mainEmitter,
 		secondaryEmitter)
 
@@ -267,7 +268,7 @@ func when(svc *logstore.Service, ctx context.Context, clock *clock.Mock) *uint64
 	for i := 0; i < ticks; i++ {
 		svc.Handle(ctx, emittermock.NewRecord(clock))
 		runtime.Gosched()
-		remaining = svc.Limit(ctx, "non-empty-instance-id")
+		remaining = svc.Limit(ctx, "non-empty-instance-id", quota.Unimplemented)
 		clock.Add(tick)
 	}
 	time.Sleep(time.Millisecond)
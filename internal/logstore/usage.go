@@ -0,0 +1,68 @@
+package logstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+// Usage is the usage of a unit in a quota period
+type Usage struct {
+	Unit        quota.Unit
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Used        uint64
+	Amount      uint64
+	Limit       bool
+}
+
+// CurrentUsage returns the usage of the current quota period of the unit,
+// nil is returned if no quota is set for the unit
+func (s *Service) CurrentUsage(ctx context.Context, instanceID string, unit quota.Unit) (*Usage, error) {
+	history, err := s.UsageHistory(ctx, instanceID, unit, 1)
+	if err != nil || len(history) == 0 {
+		return nil, err
+	}
+	return history[0], nil
+}
+
+// UsageHistory returns the usage of the current and at most periods-1 preceding quota periods of the unit,
+// starting with the current period.
+// Periods before the start of the quota are omitted,
+// periods older than the logs kept by the usage querier report the usage of the remaining logs.
+func (s *Service) UsageHistory(ctx context.Context, instanceID string, unit quota.Unit, periods int) ([]*Usage, error) {
+	if !s.SupportsUnit(unit) {
+		return nil, errors.ThrowPreconditionFailed(nil, "LOGST-Ooc4u", "Errors.Quota.Usage.NotQueryable")
+	}
+	config, periodStart, err := s.quotaQuerier.GetCurrentQuotaPeriod(ctx, instanceID, unit)
+	if err != nil || config == nil {
+		return nil, err
+	}
+	history := make([]*Usage, 0, periods)
+	periodEnd := periodStart.Add(config.ResetInterval)
+	// the usage querier returns the usage since the start, so the usage of a period is reduced by the usage after it
+	var usedAfter uint64
+	for len(history) < periods && !periodStart.Before(config.From) {
+		usedSince, err := s.usageQuerier.QueryUsage(ctx, instanceID, unit, periodStart)
+		if err != nil {
+			return nil, err
+		}
+		if usedSince < usedAfter {
+			usedSince = usedAfter
+		}
+		history = append(history, &Usage{
+			Unit:        unit,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+			Used:        usedSince - usedAfter,
+			Amount:      config.Amount,
+			Limit:       config.Limit,
+		})
+		usedAfter = usedSince
+		periodEnd = periodStart
+		periodStart = periodStart.Add(-config.ResetInterval)
+	}
+	return history, nil
+}
@@ -0,0 +1,86 @@
+// The library github.com/benbjohnson/clock fails when race is enabled
+// https://github.com/benbjohnson/clock/issues/44
+//go:build !race
+
+package logstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/logstore"
+	emittermock "github.com/zitadel/zitadel/internal/logstore/emitters/mock"
+	quotaqueriermock "github.com/zitadel/zitadel/internal/logstore/quotaqueriers/mock"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+func TestService_UsageHistory(t *testing.T) {
+	from := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	config := quotaConfig(withLimiting(), func(c *quota.AddedEvent) {
+		c.From = from
+		c.ResetInterval = time.Hour
+	})
+	period := func(n int, used uint64) *logstore.Usage {
+		return &logstore.Usage{
+			Unit:        quota.Unimplemented,
+			PeriodStart: from.Add(time.Duration(n) * time.Hour),
+			PeriodEnd:   from.Add(time.Duration(n+1) * time.Hour),
+			Used:        used,
+			Amount:      config.Amount,
+			Limit:       true,
+		}
+	}
+	tests := []struct {
+		name    string
+		periods int
+		want    []*logstore.Usage
+	}{
+		{
+			name:    "current period",
+			periods: 1,
+			want:    []*logstore.Usage{period(2, 1)},
+		},
+		{
+			name:    "preceding periods",
+			periods: 2,
+			want:    []*logstore.Usage{period(2, 1), period(1, 3)},
+		},
+		{
+			name:    "periods before quota start omitted",
+			periods: 5,
+			want:    []*logstore.Usage{period(2, 1), period(1, 3), period(0, 2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clock := clock.NewMock()
+			storage := emittermock.NewInMemoryStorage(clock)
+			emitter, err := logstore.NewEmitter(ctx, clock, emitterConfig(withCleanupping(0, 0)), storage)
+			require.NoError(t, err)
+			svc := logstore.New(
+				quotaqueriermock.NewNoopQuerier(&config, from.Add(2*time.Hour)),
+				logstore.UsageReporterFunc(func(context.Context, []*quota.NotificationDueEvent) error { return nil }),
+				nil,
+				emitter,
+			)
+			for _, offset := range []time.Duration{
+				10 * time.Minute, 20 * time.Minute,
+				70 * time.Minute, 80 * time.Minute, 90 * time.Minute,
+				130 * time.Minute,
+			} {
+				clock.Set(from.Add(offset))
+				svc.Handle(ctx, emittermock.NewRecord(clock))
+			}
+
+			got, err := svc.UsageHistory(ctx, "instanceID", quota.Unimplemented, tt.periods)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
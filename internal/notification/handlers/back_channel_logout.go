@@ -0,0 +1,413 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	BackChannelLogoutProjectionTable = "projections.notifications_back_channel_logout"
+
+	// backChannelLogoutEvent is the event identifier of the logout token (OpenID Connect Back-Channel Logout 1.0 section 2.4)
+	backChannelLogoutEvent    = "http://schemas.openid.net/event/backchannel-logout"
+	backChannelLogoutTokenTyp = "logout+jwt"
+	backChannelLogoutLifetime = 2 * time.Minute
+	backChannelLogoutTimeout  = 10 * time.Second
+
+	// backChannelLogoutMaxAttempts is the number of deliveries of a logout token to a client, before it's given up
+	backChannelLogoutMaxAttempts = 3
+)
+
+type backChannelLogoutNotifier struct {
+	crdb.StatementHandler
+	commands    *command.Commands
+	queries     *NotificationQueries
+	client      *http.Client
+	idGenerator id.Generator
+	// defaultTokenLifetime is the maximum lifetime of tokens of instances without custom oidc settings
+	defaultTokenLifetime time.Duration
+}
+
+func NewBackChannelLogoutNotifier(
+	ctx context.Context,
+	config crdb.StatementHandlerConfig,
+	commands *command.Commands,
+	queries *NotificationQueries,
+	defaultAccessTokenLifetime,
+	defaultRefreshTokenExpiration time.Duration,
+) *backChannelLogoutNotifier {
+	p := new(backChannelLogoutNotifier)
+	config.ProjectionName = BackChannelLogoutProjectionTable
+	config.Reducers = p.reducers()
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	p.commands = commands
+	p.queries = queries
+	p.defaultTokenLifetime = maxTokenLifetime(defaultAccessTokenLifetime, defaultRefreshTokenExpiration)
+	p.client = backChannelLogoutClient()
+	p.idGenerator = id.SonyFlakeGenerator()
+	projection.NotificationsBackChannelProjection = p
+	return p
+}
+
+// backChannelLogoutClient returns the client for the requests to the back-channel logout uri.
+// The uri is provided by the client (application), so requests to denied hosts (e.g. internal networks) are rejected
+// and redirects are not followed.
+func backChannelLogoutClient() *http.Client {
+	return &http.Client{
+		Transport: actions.DenyListTransport(),
+		Timeout:   backChannelLogoutTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (u *backChannelLogoutNotifier) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: u.reduceUserSignedOut,
+				},
+				{
+					Event:  user.HumanBackChannelLogoutFailedType,
+					Reduce: u.reduceUserBackChannelLogoutFailed,
+				},
+			},
+		},
+		{
+			Aggregate: session.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  session.TerminateType,
+					Reduce: u.reduceSessionTerminated,
+				},
+				{
+					Event:  session.BackChannelLogoutFailedType,
+					Reduce: u.reduceSessionBackChannelLogoutFailed,
+				},
+			},
+		},
+	}
+}
+
+// reduceUserSignedOut notifies all clients, which received tokens for the user on the signed out user agent (V1 sessions)
+func (u *backChannelLogoutNotifier) reduceUserSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wq2bn", "reduce.wrong.event.type %s", user.HumanSignedOutType)
+	}
+	if e.UserAgentID == "" {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	tokenLifetime, err := u.tokenLifetime(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// tokens issued before the maximum token lifetime are expired and need no logout
+	events, err := u.queries.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(e.Aggregate().InstanceID).
+		OrderAsc().
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(e.Aggregate().ID).
+		SequenceLess(e.Sequence()).
+		CreationDateAfter(e.CreationDate().Add(-tokenLifetime)).
+		EventTypes(
+			user.UserTokenAddedType,
+			user.HumanRefreshTokenAddedType,
+			user.HumanSignedOutType,
+			user.UserV1SignedOutType,
+		).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, clientID := range userAgentClientIDs(events, e.UserAgentID) {
+		err = u.notifyUserAgentClient(ctx, e, e.UserAgentID, clientID, 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+// reduceUserBackChannelLogoutFailed retries the failed delivery of the logout token of a signed out user agent to the client
+func (u *backChannelLogoutNotifier) reduceUserBackChannelLogoutFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanBackChannelLogoutFailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tn4xc", "reduce.wrong.event.type %s", user.HumanBackChannelLogoutFailedType)
+	}
+	if e.Attempt >= backChannelLogoutMaxAttempts {
+		logging.WithFields("clientID", e.ClientID, "userID", e.Aggregate().ID, "attempts", e.Attempt).Warn("back-channel logout given up")
+		return crdb.NewNoOpStatement(e), nil
+	}
+	err := u.notifyUserAgentClient(HandlerContext(event.Aggregate()), e, e.UserAgentID, e.ClientID, e.Attempt+1)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+// notifyUserAgentClient delivers the logout token of the signed out user agent to the client,
+// unless it was already delivered or failed after the event.
+// A failed delivery is pushed as event, so it's retried independently of other clients.
+func (u *backChannelLogoutNotifier) notifyUserAgentClient(ctx context.Context, event eventstore.Event, userAgentID, clientID string, attempt uint8) error {
+	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"userAgentID": userAgentID, "clientID": clientID}, user.AggregateType, user.HumanBackChannelLogoutSentType, user.HumanBackChannelLogoutFailedType)
+	if err != nil || alreadyHandled {
+		return err
+	}
+	userID := event.Aggregate().ID
+	// the session id must match the `sid` claim of the id_token issued on the user agent
+	sent, err := u.sendLogoutToken(ctx, clientID, userID, domain.UserAgentSessionID(userAgentID, userID))
+	if errors.IsUnavailable(err) {
+		logging.WithFields("clientID", clientID, "userID", userID, "attempt", attempt).WithError(err).Warn("back-channel logout failed")
+		return u.commands.HumanBackChannelLogoutFailed(ctx, userID, event.Aggregate().ResourceOwner, userAgentID, clientID, attempt)
+	}
+	if err != nil || !sent {
+		return err
+	}
+	return u.commands.HumanBackChannelLogoutSent(ctx, userID, event.Aggregate().ResourceOwner, userAgentID, clientID)
+}
+
+// reduceSessionTerminated notifies all clients, which received tokens based on the terminated session (V2 sessions)
+func (u *backChannelLogoutNotifier) reduceSessionTerminated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.TerminateEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Kd82l", "reduce.wrong.event.type %s", session.TerminateType)
+	}
+	ctx := HandlerContext(event.Aggregate())
+	clients, err := u.queries.OIDCSessionClientsBySessionID(ctx, true, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, client := range clients {
+		err = u.notifySessionClient(ctx, e, client.ClientID, client.UserID, 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+// reduceSessionBackChannelLogoutFailed retries the failed delivery of the logout token of a terminated session to the client
+func (u *backChannelLogoutNotifier) reduceSessionBackChannelLogoutFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.BackChannelLogoutFailedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Jc7sy", "reduce.wrong.event.type %s", session.BackChannelLogoutFailedType)
+	}
+	if e.Attempt >= backChannelLogoutMaxAttempts {
+		logging.WithFields("clientID", e.ClientID, "sessionID", e.Aggregate().ID, "attempts", e.Attempt).Warn("back-channel logout given up")
+		return crdb.NewNoOpStatement(e), nil
+	}
+	err := u.notifySessionClient(HandlerContext(event.Aggregate()), e, e.ClientID, e.UserID, e.Attempt+1)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+// notifySessionClient delivers the logout token of the terminated session to the client,
+// unless it was already delivered or failed after the event.
+// A failed delivery is pushed as event, so it's retried independently of other clients.
+func (u *backChannelLogoutNotifier) notifySessionClient(ctx context.Context, event eventstore.Event, clientID, userID string, attempt uint8) error {
+	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"clientID": clientID}, session.AggregateType, session.BackChannelLogoutSentType, session.BackChannelLogoutFailedType)
+	if err != nil || alreadyHandled {
+		return err
+	}
+	sessionID := event.Aggregate().ID
+	sent, err := u.sendLogoutToken(ctx, clientID, userID, sessionID)
+	if errors.IsUnavailable(err) {
+		logging.WithFields("clientID", clientID, "sessionID", sessionID, "attempt", attempt).WithError(err).Warn("back-channel logout failed")
+		return u.commands.BackChannelLogoutFailed(ctx, sessionID, event.Aggregate().ResourceOwner, clientID, userID, attempt)
+	}
+	if err != nil || !sent {
+		return err
+	}
+	return u.commands.BackChannelLogoutSent(ctx, sessionID, event.Aggregate().ResourceOwner, clientID)
+}
+
+// tokenLifetime returns the maximum lifetime of access and refresh tokens of the instance
+func (u *backChannelLogoutNotifier) tokenLifetime(ctx context.Context) (time.Duration, error) {
+	settings, err := u.queries.OIDCSettingsByAggID(ctx, authz.GetInstance(ctx).InstanceID())
+	if errors.IsNotFound(err) {
+		return u.defaultTokenLifetime, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return maxTokenLifetime(settings.AccessTokenLifetime, settings.RefreshTokenExpiration), nil
+}
+
+func maxTokenLifetime(accessTokenLifetime, refreshTokenExpiration time.Duration) time.Duration {
+	if accessTokenLifetime > refreshTokenExpiration {
+		return accessTokenLifetime
+	}
+	return refreshTokenExpiration
+}
+
+// sendLogoutToken posts a signed logout token to the back-channel logout uri of the client.
+// It returns false, if the client has no (allowed) back-channel logout uri (anymore) or rejected the token,
+// where a retry would not succeed either. If the client could not be reached or failed to process the token,
+// an unavailable error is returned, so the delivery can be retried. Any other failure is returned as is, so the event will be retried.
+func (u *backChannelLogoutNotifier) sendLogoutToken(ctx context.Context, clientID, userID, sessionID string) (bool, error) {
+	app, err := u.queries.AppByOIDCClientID(ctx, clientID, false)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if app.OIDCConfig == nil || app.OIDCConfig.BackChannelLogoutURI == "" {
+		return false, nil
+	}
+	logoutURI, err := url.Parse(app.OIDCConfig.BackChannelLogoutURI)
+	if err != nil {
+		logging.WithFields("clientID", clientID).WithError(err).Warn("back-channel logout uri is invalid")
+		return false, nil
+	}
+	if actions.IsHostDenied(logoutURI) {
+		logging.WithFields("clientID", clientID, "host", logoutURI.Hostname()).Warn("back-channel logout uri is denied")
+		return false, nil
+	}
+	ctx, issuer, err := u.queries.Origin(ctx)
+	if err != nil {
+		return false, err
+	}
+	signer, err := u.signer(ctx)
+	if err != nil {
+		return false, err
+	}
+	tokenID, err := u.idGenerator.Next()
+	if err != nil {
+		return false, err
+	}
+	token, err := logoutToken(signer, issuer, clientID, userID, sessionID, tokenID, time.Now())
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, logoutURI.String(), strings.NewReader(url.Values{"logout_token": {token}}.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return false, errors.ThrowUnavailable(err, "HANDL-Ra6mf", "back-channel logout request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return false, errors.ThrowUnavailablef(nil, "HANDL-p2Vxe", "back-channel logout failed with status %d", resp.StatusCode)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		logging.WithFields("clientID", clientID, "status", resp.StatusCode).Warn("back-channel logout token rejected by client")
+		return false, nil
+	}
+	return true, nil
+}
+
+// signer returns a signer using the currently active signing key of the OpenID Provider
+func (u *backChannelLogoutNotifier) signer(ctx context.Context) (jose.Signer, error) {
+	keys, err := u.queries.ActivePrivateSigningKey(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(keys.Keys) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "HANDL-s9Rkq", "no active signing key")
+	}
+	key := keys.Keys[len(keys.Keys)-1]
+	keyData, err := crypto.Decrypt(key.Key(), u.queries.OIDCKeyCrypto)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := crypto.BytesToPrivateKey(keyData)
+	if err != nil {
+		return nil, err
+	}
+	return jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.SignatureAlgorithm(key.Algorithm()), Key: privateKey},
+		(&jose.SignerOptions{}).WithType(backChannelLogoutTokenTyp).WithHeader("kid", key.ID()),
+	)
+}
+
+type logoutTokenClaims struct {
+	Events    map[string]struct{} `json:"events"`
+	SessionID string              `json:"sid,omitempty"`
+}
+
+// logoutToken creates the logout token as defined in OpenID Connect Back-Channel Logout 1.0 section 2.4
+func logoutToken(signer jose.Signer, issuer, clientID, userID, sessionID, tokenID string, now time.Time) (string, error) {
+	return jwt.Signed(signer).
+		Claims(jwt.Claims{
+			Issuer:   issuer,
+			Subject:  userID,
+			Audience: jwt.Audience{clientID},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(backChannelLogoutLifetime)),
+			ID:       tokenID,
+		}).
+		Claims(logoutTokenClaims{
+			Events:    map[string]struct{}{backChannelLogoutEvent: {}},
+			SessionID: sessionID,
+		}).
+		CompactSerialize()
+}
+
+// userAgentClientIDs returns the ids of the clients, which received tokens on the user agent since its last sign out
+func userAgentClientIDs(events []eventstore.Event, userAgentID string) []string {
+	clientIDs := make([]string, 0)
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.UserTokenAddedEvent:
+			if e.UserAgentID == userAgentID {
+				clientIDs = appendUnique(clientIDs, e.ApplicationID)
+			}
+		case *user.HumanRefreshTokenAddedEvent:
+			if e.UserAgentID == userAgentID {
+				clientIDs = appendUnique(clientIDs, e.ClientID)
+			}
+		case *user.HumanSignedOutEvent:
+			if e.UserAgentID == userAgentID {
+				clientIDs = clientIDs[:0]
+			}
+		}
+	}
+	return clientIDs
+}
+
+func appendUnique(list []string, value string) []string {
+	if value == "" {
+		return list
+	}
+	for _, existing := range list {
+		if existing == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func Test_logoutToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: privateKey},
+		(&jose.SignerOptions{}).WithType(backChannelLogoutTokenTyp).WithHeader("kid", "key1"),
+	)
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	token, err := logoutToken(signer, "https://issuer.com", "client1", "user1", "session1", "token1", now)
+	require.NoError(t, err)
+
+	parsed, err := jwt.ParseSigned(token)
+	require.NoError(t, err)
+	require.Len(t, parsed.Headers, 1)
+	assert.Equal(t, "key1", parsed.Headers[0].KeyID)
+	assert.Equal(t, backChannelLogoutTokenTyp, parsed.Headers[0].ExtraHeaders[jose.HeaderType])
+
+	claims := jwt.Claims{}
+	private := map[string]interface{}{}
+	require.NoError(t, parsed.Claims(&privateKey.PublicKey, &claims, &private))
+	assert.Equal(t, "https://issuer.com", claims.Issuer)
+	assert.Equal(t, "user1", claims.Subject)
+	assert.Equal(t, jwt.Audience{"client1"}, claims.Audience)
+	assert.Equal(t, "token1", claims.ID)
+	assert.Equal(t, now, claims.IssuedAt.Time())
+	assert.Equal(t, now.Add(backChannelLogoutLifetime), claims.Expiry.Time())
+	assert.Equal(t, "session1", private["sid"])
+	assert.Equal(t, map[string]interface{}{backChannelLogoutEvent: map[string]interface{}{}}, private["events"])
+	assert.NotContains(t, private, "nonce")
+}
+
+func Test_backChannelLogoutClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://localhost/internal", http.StatusFound)
+	}))
+	defer server.Close()
+	client := backChannelLogoutClient()
+
+	resp, err := client.Post(server.URL, "application/x-www-form-urlencoded", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode, "redirect must not be followed")
+
+	actions.SetHTTPConfig(&actions.HTTPConfig{
+		DenyList: []actions.AddressChecker{&actions.IPChecker{IP: net.ParseIP("127.0.0.1")}},
+	})
+	defer actions.SetHTTPConfig(nil)
+	_, err = client.Post(server.URL, "application/x-www-form-urlencoded", nil)
+	assert.Error(t, err)
+}
+
+func Test_userAgentClientIDs(t *testing.T) {
+	ctx := context.Background()
+	agg := &user.NewAggregate("user1", "org1").Aggregate
+	tests := []struct {
+		name   string
+		events []eventstore.Event
+		want   []string
+	}{
+		{
+			"no tokens",
+			nil,
+			[]string{},
+		},
+		{
+			"tokens of user agent",
+			[]eventstore.Event{
+				user.NewUserTokenAddedEvent(ctx, agg, "token1", "client1", "agent1", "", "", nil, nil, time.Now(), "", nil),
+				user.NewHumanRefreshTokenAddedEvent(ctx, agg, "refresh1", "client2", "agent1", "", nil, nil, nil, time.Now(), time.Hour, time.Hour, ""),
+				user.NewUserTokenAddedEvent(ctx, agg, "token2", "client2", "agent1", "", "", nil, nil, time.Now(), "", nil),
+				user.NewUserTokenAddedEvent(ctx, agg, "token3", "client3", "agent2", "", "", nil, nil, time.Now(), "", nil),
+			},
+			[]string{"client1", "client2"},
+		},
+		{
+			"tokens since last sign out",
+			[]eventstore.Event{
+				user.NewUserTokenAddedEvent(ctx, agg, "token1", "client1", "agent1", "", "", nil, nil, time.Now(), "", nil),
+				user.NewHumanSignedOutEvent(ctx, agg, "agent1"),
+				user.NewUserTokenAddedEvent(ctx, agg, "token2", "client2", "agent1", "", "", nil, nil, time.Now(), "", nil),
+				user.NewHumanSignedOutEvent(ctx, agg, "agent2"),
+			},
+			[]string{"client2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, userAgentClientIDs(tt.events, "agent1"))
+		})
+	}
+}
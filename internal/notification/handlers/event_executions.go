@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+)
+
+const (
+	EventExecutionsProjectionTable = "projections.notifications_event_executions"
+)
+
+type eventExecutionNotifier struct {
+	crdb.StatementHandler
+	queries           eventExecutionQueries
+	recorder          actions.EventTargetsRecorder
+	aggregateReducers []handler.AggregateReducer
+}
+
+// eventExecutionQueries resolves the executions set on events and their targets
+type eventExecutionQueries interface {
+	actions.ExecutionQueries
+	SearchExecutions(ctx context.Context, queries *query.ExecutionSearchQueries) (*query.Executions, error)
+}
+
+// NewEventExecutionNotifier creates the handler calling the targets of event executions.
+// It reduces the events of all aggregates registered on the eventstore,
+// therefore it must be created after the event mappers are registered.
+// Only the events which have an execution on the instance are queried (see [eventExecutionNotifier.eventQueries]).
+func NewEventExecutionNotifier(
+	ctx context.Context,
+	config crdb.StatementHandlerConfig,
+	commands *command.Commands,
+	queries *NotificationQueries,
+) *eventExecutionNotifier {
+	p := new(eventExecutionNotifier)
+	config.ProjectionName = EventExecutionsProjectionTable
+	p.aggregateReducers = p.reducers(queries.es.AggregateTypes(), queries.es.AggregateEventTypes)
+	config.Reducers = p.aggregateReducers
+	config.EventQueries = p.eventQueries
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	p.queries = queries
+	p.recorder = &eventTargetsRecorder{commands: commands, es: queries.es}
+	projection.NotificationsEventExecutionProjection = p
+	return p
+}
+
+// reducers returns the reducers of the event types registered on each aggregate.
+// The events recording the called targets are not reduced, as they are pushed by the handler itself.
+func (n *eventExecutionNotifier) reducers(aggregateTypes []string, eventTypes func(eventstore.AggregateType) []eventstore.EventType) []handler.AggregateReducer {
+	aggregateReducers := make([]handler.AggregateReducer, 0, len(aggregateTypes))
+	for _, aggregateType := range aggregateTypes {
+		if eventstore.AggregateType(aggregateType) == pseudo.AggregateType {
+			continue
+		}
+		types := eventTypes(eventstore.AggregateType(aggregateType))
+		eventReducers := make([]handler.EventReducer, 0, len(types))
+		for _, eventType := range types {
+			if eventType == execution.EventTargetsCalledEventType {
+				continue
+			}
+			eventReducers = append(eventReducers, handler.EventReducer{
+				Event:  eventType,
+				Reduce: n.reduceEvent,
+			})
+		}
+		aggregateReducers = append(aggregateReducers, handler.AggregateReducer{
+			Aggregate:     eventstore.AggregateType(aggregateType),
+			EventRedusers: eventReducers,
+		})
+	}
+	return aggregateReducers
+}
+
+// eventQueries restricts the events of the instance to the aggregate and event types which have an execution.
+// Events created before the executions are not queried, as their targets aren't called anyway.
+func (n *eventExecutionNotifier) eventQueries(ctx context.Context, instanceID string) ([]*crdb.EventQuery, error) {
+	idQuery, err := query.NewExecutionIDPrefixSearchQuery(domain.ExecutionIDForAll(domain.ExecutionTypeEvent))
+	if err != nil {
+		return nil, err
+	}
+	executions, err := n.queries.SearchExecutions(authz.WithInstanceID(ctx, instanceID), &query.ExecutionSearchQueries{Queries: []query.SearchQuery{idQuery}})
+	if err != nil {
+		return nil, err
+	}
+	creationDates := make(map[string]time.Time, len(executions.Executions))
+	for _, e := range executions.Executions {
+		if len(e.Targets) > 0 {
+			creationDates[e.ID] = e.CreationDate
+		}
+	}
+	if len(creationDates) == 0 {
+		return nil, nil
+	}
+	eventQueries := make([]*crdb.EventQuery, 0, len(n.aggregateReducers))
+	for _, aggregateReducer := range n.aggregateReducers {
+		if eventQuery := aggregateEventQuery(aggregateReducer, creationDates); eventQuery != nil {
+			eventQueries = append(eventQueries, eventQuery)
+		}
+	}
+	return eventQueries, nil
+}
+
+// aggregateEventQuery returns the query of the events of the aggregate which have an execution,
+// the creation dates of the executions are mapped by their id.
+// If no event of the aggregate has an execution, nil is returned.
+func aggregateEventQuery(aggregateReducer handler.AggregateReducer, creationDates map[string]time.Time) *crdb.EventQuery {
+	eventQuery := &crdb.EventQuery{AggregateType: aggregateReducer.Aggregate}
+	found := false
+	setCreationDate := func(creationDate time.Time) {
+		if !found || creationDate.Before(eventQuery.CreationDateAfter) {
+			eventQuery.CreationDateAfter = creationDate
+		}
+		found = true
+	}
+	for _, eventReducer := range aggregateReducer.EventRedusers {
+		if creationDate, ok := creationDates[domain.ExecutionIDForEvent(string(eventReducer.Event))]; ok {
+			setCreationDate(creationDate)
+			eventQuery.EventTypes = append(eventQuery.EventTypes, eventReducer.Event)
+		}
+	}
+	allEvents := false
+	for _, id := range []string{
+		domain.ExecutionIDForEventGroup(string(aggregateReducer.Aggregate)),
+		domain.ExecutionIDForAll(domain.ExecutionTypeEvent),
+	} {
+		if creationDate, ok := creationDates[id]; ok {
+			setCreationDate(creationDate)
+			allEvents = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	if allEvents {
+		eventQuery.EventTypes = nil
+	}
+	return eventQuery
+}
+
+// reduceEvent calls the targets of the execution set for the event.
+// If a target with InterruptOnError fails, the error is returned so the event will be retried,
+// the targets called successfully before are recorded and not called again.
+func (n *eventExecutionNotifier) reduceEvent(event eventstore.Event) (*handler.Statement, error) {
+	if event.Aggregate().InstanceID == "" {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	if err := actions.CallEventTargets(HandlerContext(event.Aggregate()), n.queries, n.recorder, event); err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(event), nil
+}
+
+// eventTargetsRecorder records the targets called for an event on the aggregate of the execution
+type eventTargetsRecorder struct {
+	commands *command.Commands
+	es       *eventstore.Eventstore
+}
+
+// CalledEventTargets implements [actions.EventTargetsRecorder]
+func (r *eventTargetsRecorder) CalledEventTargets(ctx context.Context, event eventstore.Event) ([]string, error) {
+	events, err := r.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			AddQuery().
+			AggregateTypes(execution.AggregateType).
+			CreationDateAfter(event.CreationDate()).
+			EventTypes(execution.EventTargetsCalledEventType).
+			EventData(map[string]interface{}{
+				"aggregateType": event.Aggregate().Type,
+				"aggregateID":   event.Aggregate().ID,
+				"sequence":      event.Sequence(),
+			}).
+			Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var targetIDs []string
+	for _, e := range events {
+		if called, ok := e.(*execution.EventTargetsCalledEvent); ok {
+			targetIDs = append(targetIDs, called.Targets...)
+		}
+	}
+	return targetIDs, nil
+}
+
+// EventTargetsCalled implements [actions.EventTargetsRecorder]
+func (r *eventTargetsRecorder) EventTargetsCalled(ctx context.Context, executionID string, event eventstore.Event, targetIDs []string) error {
+	return r.commands.ExecutionEventTargetsCalled(ctx, executionID, event.Aggregate().InstanceID, event.Aggregate().Type, event.Aggregate().ID, event.Sequence(), targetIDs)
+}
@@ -0,0 +1,392 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+)
+
+type mockExecutionQueries struct {
+	targets    map[string][]*query.ExecutionTarget
+	ids        []string
+	executions []*query.Execution
+	instanceID string
+}
+
+func (m *mockExecutionQueries) SearchExecutions(ctx context.Context, _ *query.ExecutionSearchQueries) (*query.Executions, error) {
+	m.instanceID = authz.GetInstance(ctx).InstanceID()
+	return &query.Executions{Executions: m.executions}, nil
+}
+
+func (m *mockExecutionQueries) ExecutionTargetsByIDs(_ context.Context, ids []string) ([]*query.ExecutionTarget, error) {
+	m.ids = ids
+	for _, id := range ids {
+		if targets, ok := m.targets[id]; ok {
+			return targets, nil
+		}
+	}
+	return nil, nil
+}
+
+type mockEventTargetsRecorder struct {
+	called      []string
+	executionID string
+	recorded    []string
+}
+
+func (m *mockEventTargetsRecorder) CalledEventTargets(context.Context, eventstore.Event) ([]string, error) {
+	return m.called, nil
+}
+
+func (m *mockEventTargetsRecorder) EventTargetsCalled(_ context.Context, executionID string, _ eventstore.Event, targetIDs []string) error {
+	m.executionID = executionID
+	m.recorded = targetIDs
+	return nil
+}
+
+func Test_eventExecutionNotifier_reducers(t *testing.T) {
+	n := new(eventExecutionNotifier)
+	eventTypes := map[eventstore.AggregateType][]eventstore.EventType{
+		execution.AggregateType: {execution.SetEventType},
+		"org":                   {"org.added", "org.removed"},
+		"user":                  {"user.human.added"},
+	}
+	got := n.reducers([]string{execution.AggregateType, "org", string(pseudo.AggregateType), "user"}, func(aggregateType eventstore.AggregateType) []eventstore.EventType {
+		if aggregateType == execution.AggregateType {
+			return []eventstore.EventType{execution.EventTargetsCalledEventType, execution.SetEventType}
+		}
+		return eventTypes[aggregateType]
+	})
+	require.Len(t, got, 3)
+	for i, aggregateType := range []eventstore.AggregateType{execution.AggregateType, "org", "user"} {
+		assert.Equal(t, aggregateType, got[i].Aggregate)
+		require.Len(t, got[i].EventRedusers, len(eventTypes[aggregateType]))
+		for j, eventType := range eventTypes[aggregateType] {
+			assert.Equal(t, eventType, got[i].EventRedusers[j].Event)
+		}
+	}
+}
+
+func Test_eventExecutionNotifier_eventQueries(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name       string
+		executions []*query.Execution
+		want       []*crdb.EventQuery
+	}{
+		{
+			name: "no executions",
+		},
+		{
+			name: "execution without targets",
+			executions: []*query.Execution{
+				{ID: "event/user.human.added", CreationDate: now},
+			},
+		},
+		{
+			name: "execution on event types",
+			executions: []*query.Execution{
+				{ID: "event/user.human.added", CreationDate: now, Targets: []string{"target"}},
+				{ID: "event/user.human.removed", CreationDate: now.Add(-time.Hour), Targets: []string{"target"}},
+			},
+			want: []*crdb.EventQuery{
+				{
+					AggregateType:     "user",
+					EventTypes:        []eventstore.EventType{"user.human.added", "user.human.removed"},
+					CreationDateAfter: now.Add(-time.Hour),
+				},
+			},
+		},
+		{
+			name: "execution on aggregate",
+			executions: []*query.Execution{
+				{ID: "event/user.human.added", CreationDate: now.Add(-time.Hour), Targets: []string{"target"}},
+				{ID: "event/user.*", CreationDate: now, Targets: []string{"target"}},
+			},
+			want: []*crdb.EventQuery{
+				{
+					AggregateType:     "user",
+					CreationDateAfter: now.Add(-time.Hour),
+				},
+			},
+		},
+		{
+			name: "execution on all events",
+			executions: []*query.Execution{
+				{ID: "event", CreationDate: now, Targets: []string{"target"}},
+				{ID: "event/org.added", CreationDate: now.Add(-time.Hour), Targets: []string{"target"}},
+			},
+			want: []*crdb.EventQuery{
+				{
+					AggregateType:     "org",
+					CreationDateAfter: now.Add(-time.Hour),
+				},
+				{
+					AggregateType:     "user",
+					CreationDateAfter: now,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := &mockExecutionQueries{executions: tt.executions}
+			n := &eventExecutionNotifier{queries: queries}
+			n.aggregateReducers = n.reducers([]string{"org", "user"}, func(aggregateType eventstore.AggregateType) []eventstore.EventType {
+				return map[eventstore.AggregateType][]eventstore.EventType{
+					"org":  {"org.added"},
+					"user": {"user.human.added", "user.human.removed"},
+				}[aggregateType]
+			})
+
+			got, err := n.eventQueries(context.Background(), "instance1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, "instance1", queries.instanceID)
+		})
+	}
+}
+
+func Test_eventExecutionNotifier_reduceEvent(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	type res struct {
+		wantErr     bool
+		wantPayload *actions.EventPayload
+	}
+	tests := []struct {
+		name    string
+		status  int
+		targets map[string][]*query.ExecutionTarget
+		event   *repository.Event
+		res     res
+	}{
+		{
+			name:   "no execution",
+			status: http.StatusOK,
+			event:  testExecutionEvent(now),
+			res:    res{},
+		},
+		{
+			name:   "no instance",
+			status: http.StatusOK,
+			targets: map[string][]*query.ExecutionTarget{
+				"event": {{Timeout: time.Second, InterruptOnError: true, SigningKey: "key"}},
+			},
+			event: func() *repository.Event {
+				event := testExecutionEvent(now)
+				event.InstanceID = ""
+				return event
+			}(),
+			res: res{},
+		},
+		{
+			name:   "execution on event type",
+			status: http.StatusOK,
+			targets: map[string][]*query.ExecutionTarget{
+				"event/user.human.added": {{ExecutionCreationDate: now.Add(-time.Hour), Timeout: time.Second, InterruptOnError: true, SigningKey: "key"}},
+				"event":                  {{Timeout: time.Second, InterruptOnError: true, SigningKey: "other"}},
+			},
+			event: testExecutionEvent(now),
+			res: res{
+				wantPayload: &actions.EventPayload{
+					AggregateID:   "user1",
+					AggregateType: "user",
+					ResourceOwner: "org1",
+					InstanceID:    "instance1",
+					Version:       "v2",
+					Sequence:      42,
+					EventType:     "user.human.added",
+					CreatedAt:     now,
+					UserID:        "editor1",
+					EventPayload:  json.RawMessage(`{"userName":"username"}`),
+				},
+			},
+		},
+		{
+			name:   "execution on aggregate",
+			status: http.StatusOK,
+			targets: map[string][]*query.ExecutionTarget{
+				"event/user.*": {{Timeout: time.Second, InterruptOnError: true, SigningKey: "key"}},
+			},
+			event: testExecutionEvent(now),
+			res: res{
+				wantPayload: &actions.EventPayload{
+					AggregateID:   "user1",
+					AggregateType: "user",
+					ResourceOwner: "org1",
+					InstanceID:    "instance1",
+					Version:       "v2",
+					Sequence:      42,
+					EventType:     "user.human.added",
+					CreatedAt:     now,
+					UserID:        "editor1",
+					EventPayload:  json.RawMessage(`{"userName":"username"}`),
+				},
+			},
+		},
+		{
+			name:   "execution set after event, not called",
+			status: http.StatusOK,
+			targets: map[string][]*query.ExecutionTarget{
+				"event": {{ExecutionCreationDate: now.Add(time.Minute), Timeout: time.Second, InterruptOnError: true, SigningKey: "key"}},
+			},
+			event: testExecutionEvent(now),
+			res:   res{},
+		},
+		{
+			name:   "target failed, interrupt",
+			status: http.StatusInternalServerError,
+			targets: map[string][]*query.ExecutionTarget{
+				"event": {{Timeout: time.Second, InterruptOnError: true, SigningKey: "key"}},
+			},
+			event: testExecutionEvent(now),
+			res: res{
+				wantErr: true,
+				wantPayload: &actions.EventPayload{
+					AggregateID:   "user1",
+					AggregateType: "user",
+					ResourceOwner: "org1",
+					InstanceID:    "instance1",
+					Version:       "v2",
+					Sequence:      42,
+					EventType:     "user.human.added",
+					CreatedAt:     now,
+					UserID:        "editor1",
+					EventPayload:  json.RawMessage(`{"userName":"username"}`),
+				},
+			},
+		},
+		{
+			name:   "target failed, no interrupt",
+			status: http.StatusInternalServerError,
+			targets: map[string][]*query.ExecutionTarget{
+				"event": {{Timeout: time.Second, SigningKey: "key"}},
+			},
+			event: testExecutionEvent(now),
+			res: res{
+				wantPayload: &actions.EventPayload{
+					AggregateID:   "user1",
+					AggregateType: "user",
+					ResourceOwner: "org1",
+					InstanceID:    "instance1",
+					Version:       "v2",
+					Sequence:      42,
+					EventType:     "user.human.added",
+					CreatedAt:     now,
+					UserID:        "editor1",
+					EventPayload:  json.RawMessage(`{"userName":"username"}`),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := make(chan *actions.EventPayload, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.NoError(t, actions.ValidatePayload(body, r.Header.Get(actions.SigningHeader), "key", time.Minute))
+				payload := new(actions.EventPayload)
+				require.NoError(t, json.Unmarshal(body, payload))
+				calls <- payload
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			for _, targets := range tt.targets {
+				for _, target := range targets {
+					target.Endpoint = server.URL
+				}
+			}
+			queries := &mockExecutionQueries{targets: tt.targets}
+			n := &eventExecutionNotifier{queries: queries, recorder: new(mockEventTargetsRecorder)}
+
+			stmt, err := n.reduceEvent(eventstore.BaseEventFromRepo(tt.event))
+			if tt.res.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, stmt.IsNoop())
+			}
+			close(calls)
+			payload := <-calls
+			assert.Equal(t, tt.res.wantPayload, payload)
+			if tt.event.InstanceID != "" {
+				assert.Equal(t, []string{"event/user.human.added", "event/user.*", "event"}, queries.ids)
+			}
+		})
+	}
+}
+
+func Test_eventExecutionNotifier_reduceEvent_retry(t *testing.T) {
+	var calls []string
+	var failing bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		if r.URL.Path == "/failing" && failing {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	queries := &mockExecutionQueries{
+		targets: map[string][]*query.ExecutionTarget{
+			"event": {
+				{ExecutionID: "event", TargetID: "target1", Endpoint: server.URL + "/target1", Timeout: time.Second, InterruptOnError: true},
+				{ExecutionID: "event", TargetID: "target2", Endpoint: server.URL + "/target2", Timeout: time.Second},
+				{ExecutionID: "event", TargetID: "failing", Endpoint: server.URL + "/failing", Timeout: time.Second, InterruptOnError: true},
+				{ExecutionID: "event", TargetID: "target3", Endpoint: server.URL + "/target3", Timeout: time.Second},
+			},
+		},
+	}
+	recorder := new(mockEventTargetsRecorder)
+	n := &eventExecutionNotifier{queries: queries, recorder: recorder}
+	event := eventstore.BaseEventFromRepo(testExecutionEvent(time.Now()))
+
+	failing = true
+	_, err := n.reduceEvent(event)
+	require.Error(t, err)
+	assert.Equal(t, []string{"/target1", "/target2", "/failing"}, calls)
+	assert.Equal(t, "event", recorder.executionID)
+	assert.Equal(t, []string{"target1", "target2"}, recorder.recorded)
+
+	calls = nil
+	failing = false
+	recorder.called = recorder.recorded
+	recorder.recorded = nil
+	stmt, err := n.reduceEvent(event)
+	require.NoError(t, err)
+	assert.True(t, stmt.IsNoop())
+	assert.Equal(t, []string{"/failing", "/target3"}, calls)
+	assert.Nil(t, recorder.recorded)
+}
+
+func testExecutionEvent(creationDate time.Time) *repository.Event {
+	return &repository.Event{
+		AggregateID:   "user1",
+		AggregateType: "user",
+		InstanceID:    "instance1",
+		Version:       "v2",
+		Sequence:      42,
+		Type:          "user.human.added",
+		CreationDate:  creationDate,
+		EditorUser:    "editor1",
+		Data:          []byte(`{"userName":"username"}`),
+		ResourceOwner: sql.NullString{String: "org1", Valid: true},
+	}
+}
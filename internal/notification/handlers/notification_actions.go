@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/dop251/goja"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// runPasswordResetActions runs the pre notification actions of the password reset flow,
+// the actions can deny the notification or overwrite texts of the message
+func (u *userNotifier) runPasswordResetActions(ctx context.Context, notifyUser *query.NotifyUser, notificationType domain.NotificationType) (*object.NotificationChanges, error) {
+	triggerActions, err := u.queries.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypePasswordReset, domain.TriggerTypePreNotification, notifyUser.ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := new(object.NotificationChanges)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("deny", changes.DenyFunc),
+			actions.SetFields("setMessageText", changes.SetMessageTextFunc),
+		),
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1",
+				actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+					return func(call goja.FunctionCall) goja.Value {
+						user, err := u.queries.GetUserByID(actionCtx, true, notifyUser.ID, false)
+						if err != nil {
+							panic(err)
+						}
+						return object.UserFromQuery(c, user)
+					}
+				}),
+				actions.SetFields("notification", object.NotificationField(notificationType, domain.PasswordResetMessageType)),
+			),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx), actions.WithTrigger(domain.FlowTypePasswordReset, domain.TriggerTypePreNotification, notifyUser.ID))...,
+		)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if changes.Denied {
+			return changes, nil
+		}
+	}
+	err = actions.CallFunctionTargets(ctx, u.queries, domain.FlowTypePasswordReset, domain.TriggerTypePreNotification, &actions.FunctionPayload{
+		ResourceOwner: notifyUser.ResourceOwner,
+		UserID:        notifyUser.ID,
+	})
+	return changes, err
+}
+
+// applyMessageTexts overwrites the texts of the message in the language of the user
+// and the default language
+func applyMessageTexts(translator *i18n.Translator, messageType string, texts map[string]string, langs ...language.Tag) error {
+	for _, lang := range langs {
+		if lang == language.Und {
+			continue
+		}
+		for key, text := range texts {
+			if err := translator.AddMessages(lang, i18n.Message{ID: messageType + "." + key, Text: text}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
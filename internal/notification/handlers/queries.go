@@ -19,6 +19,7 @@ type NotificationQueries struct {
 	UserDataCrypto     crypto.EncryptionAlgorithm
 	SMTPPasswordCrypto crypto.EncryptionAlgorithm
 	SMSTokenCrypto     crypto.EncryptionAlgorithm
+	OIDCKeyCrypto      crypto.EncryptionAlgorithm
 	statikDir          http.FileSystem
 }
 
@@ -32,6 +33,7 @@ func NewNotificationQueries(
 	userDataCrypto crypto.EncryptionAlgorithm,
 	smtpPasswordCrypto crypto.EncryptionAlgorithm,
 	smsTokenCrypto crypto.EncryptionAlgorithm,
+	oidcKeyCrypto crypto.EncryptionAlgorithm,
 	statikDir http.FileSystem,
 ) *NotificationQueries {
 	return &NotificationQueries{
@@ -44,6 +46,7 @@ func NewNotificationQueries(
 		UserDataCrypto:     userDataCrypto,
 		SMTPPasswordCrypto: smtpPasswordCrypto,
 		SMSTokenCrypto:     smsTokenCrypto,
+		OIDCKeyCrypto:      oidcKeyCrypto,
 		statikDir:          statikDir,
 	}
 }
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -11,8 +13,11 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/session"
 	"github.com/zitadel/zitadel/internal/repository/user"
 )
 
@@ -25,6 +30,8 @@ type userNotifier struct {
 	commands     *command.Commands
 	queries      *NotificationQueries
 	assetsPrefix func(context.Context) string
+	// logstoreService limits and records the sent emails and SMS
+	logstoreService *logstore.Service
 	metricSuccessfulDeliveriesEmail,
 	metricFailedDeliveriesEmail,
 	metricSuccessfulDeliveriesSMS,
@@ -37,6 +44,7 @@ func NewUserNotifier(
 	commands *command.Commands,
 	queries *NotificationQueries,
 	assetsPrefix func(context.Context) string,
+	logstoreService *logstore.Service,
 	metricSuccessfulDeliveriesEmail,
 	metricFailedDeliveriesEmail,
 	metricSuccessfulDeliveriesSMS,
@@ -49,6 +57,7 @@ func NewUserNotifier(
 	p.commands = commands
 	p.queries = queries
 	p.assetsPrefix = assetsPrefix
+	p.logstoreService = logstoreService
 	p.metricSuccessfulDeliveriesEmail = metricSuccessfulDeliveriesEmail
 	p.metricFailedDeliveriesEmail = metricFailedDeliveriesEmail
 	p.metricSuccessfulDeliveriesSMS = metricSuccessfulDeliveriesSMS
@@ -108,6 +117,19 @@ func (u *userNotifier) reducers() []handler.AggregateReducer {
 				},
 			},
 		},
+		{
+			Aggregate: session.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  session.OTPSMSChallengedType,
+					Reduce: u.reduceSessionOTPSMSChallenged,
+				},
+				{
+					Event:  session.OTPEmailChallengedType,
+					Reduce: u.reduceSessionOTPEmailChallenged,
+				},
+			},
+		},
 	}
 }
 
@@ -164,6 +186,7 @@ func (u *userNotifier) reduceInitCodeAdded(event eventstore.Event) (*handler.Sta
 		colors,
 		u.assetsPrefix(ctx),
 		e,
+		u.logstoreService,
 		u.metricSuccessfulDeliveriesEmail,
 		u.metricFailedDeliveriesEmail,
 	).SendUserInitCode(notifyUser, origin, code)
@@ -234,6 +257,7 @@ func (u *userNotifier) reduceEmailCodeAdded(event eventstore.Event) (*handler.St
 		colors,
 		u.assetsPrefix(ctx),
 		e,
+		u.logstoreService,
 		u.metricSuccessfulDeliveriesEmail,
 		u.metricFailedDeliveriesEmail,
 	).SendEmailVerificationCode(notifyUser, origin, code, e.URLTemplate)
@@ -287,6 +311,17 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 	if err != nil {
 		return nil, err
 	}
+	changes, err := u.runPasswordResetActions(ctx, notifyUser, e.NotificationType)
+	if err != nil {
+		return nil, err
+	}
+	if changes.Denied {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	err = applyMessageTexts(translator, domain.PasswordResetMessageType, changes.Texts, notifyUser.PreferredLanguage, u.queries.GetDefaultLanguage(ctx))
+	if err != nil {
+		return nil, err
+	}
 
 	ctx, origin, err := u.queries.Origin(ctx)
 	if err != nil {
@@ -303,6 +338,7 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 		colors,
 		u.assetsPrefix(ctx),
 		e,
+		u.logstoreService,
 		u.metricSuccessfulDeliveriesEmail,
 		u.metricFailedDeliveriesEmail,
 	)
@@ -317,6 +353,7 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 			colors,
 			u.assetsPrefix(ctx),
 			e,
+			u.logstoreService,
 			u.metricSuccessfulDeliveriesSMS,
 			u.metricFailedDeliveriesSMS,
 		)
@@ -380,6 +417,7 @@ func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Sta
 		colors,
 		u.assetsPrefix(ctx),
 		e,
+		u.logstoreService,
 		u.metricSuccessfulDeliveriesEmail,
 		u.metricFailedDeliveriesEmail,
 	).SendDomainClaimed(notifyUser, origin, e.UserName)
@@ -447,6 +485,7 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 		colors,
 		u.assetsPrefix(ctx),
 		e,
+		u.logstoreService,
 		u.metricSuccessfulDeliveriesEmail,
 		u.metricFailedDeliveriesEmail,
 	).SendPasswordlessRegistrationLink(notifyUser, origin, code, e.ID, e.URLTemplate)
@@ -517,6 +556,7 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 			colors,
 			u.assetsPrefix(ctx),
 			e,
+			u.logstoreService,
 			u.metricSuccessfulDeliveriesEmail,
 			u.metricFailedDeliveriesEmail,
 		).SendPasswordChange(notifyUser, origin)
@@ -581,6 +621,7 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 		colors,
 		u.assetsPrefix(ctx),
 		e,
+		u.logstoreService,
 		u.metricSuccessfulDeliveriesSMS,
 		u.metricFailedDeliveriesSMS,
 	).SendPhoneVerificationCode(notifyUser, origin, code)
@@ -594,9 +635,164 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 	return crdb.NewNoOpStatement(e), nil
 }
 
+func (u *userNotifier) reduceSessionOTPSMSChallenged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.OTPSMSChallengedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Sk32L", "reduce.wrong.event.type %s", session.OTPSMSChallengedType)
+	}
+	if e.CodeReturned {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		session.OTPSMSChallengedType, session.OTPSMSSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, u.queries.UserDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	s, err := u.queries.SessionByID(ctx, true, e.Aggregate().ID, "")
+	if err != nil {
+		return nil, err
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, s.UserFactor.ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, s.UserFactor.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifySMSOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendSMSTwilio(
+		ctx,
+		translator,
+		notifyUser,
+		u.queries.GetTwilioConfig,
+		u.queries.GetFileSystemProvider,
+		u.queries.GetLogProvider,
+		colors,
+		u.assetsPrefix(ctx),
+		e,
+		u.logstoreService,
+		u.metricSuccessfulDeliveriesSMS,
+		u.metricFailedDeliveriesSMS,
+	).SendOTPSMSCode(authz.GetInstance(ctx).RequestedDomain(), origin, code, e.Expiry)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.OTPSMSSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func (u *userNotifier) reduceSessionOTPEmailChallenged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.OTPEmailChallengedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-zbsgt", "reduce.wrong.event.type %s", session.OTPEmailChallengedType)
+	}
+	if e.ReturnCode {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		session.OTPEmailChallengedType, session.OTPEmailSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, u.queries.UserDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	s, err := u.queries.SessionByID(ctx, true, e.Aggregate().ID, "")
+	if err != nil {
+		return nil, err
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, s.UserFactor.ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := u.queries.MailTemplateByOrg(ctx, s.UserFactor.ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, s.UserFactor.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifyEmailOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := u.queries.Origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	url, err := otpEmailURL(e.URLTmpl, code, notifyUser)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendEmail(
+		ctx,
+		string(template.Template),
+		translator,
+		notifyUser,
+		u.queries.GetSMTPConfig,
+		u.queries.GetFileSystemProvider,
+		u.queries.GetLogProvider,
+		colors,
+		u.assetsPrefix(ctx),
+		e,
+		u.logstoreService,
+		u.metricSuccessfulDeliveriesEmail,
+		u.metricFailedDeliveriesEmail,
+	).SendOTPEmailCode(url, authz.GetInstance(ctx).RequestedDomain(), origin, code, e.Expiry)
+	if err != nil {
+		return nil, err
+	}
+	err = u.commands.OTPEmailSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func otpEmailURL(urlTmpl, code string, user *query.NotifyUser) (string, error) {
+	if urlTmpl == "" {
+		return "", nil
+	}
+	var buf strings.Builder
+	if err := domain.RenderOTPEmailURLTemplate(&buf, urlTmpl, code, user.ID, user.PreferredLoginName, user.DisplayName, user.PreferredLanguage); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 func (u *userNotifier) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event eventstore.Event, expiry time.Duration, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
 	if event.CreationDate().Add(expiry).Before(time.Now().UTC()) {
 		return true, nil
 	}
-	return u.queries.IsAlreadyHandled(ctx, event, data, user.AggregateType, eventTypes...)
+	return u.queries.IsAlreadyHandled(ctx, event, data, event.Aggregate().Type, eventTypes...)
 }
@@ -2,6 +2,7 @@ package notification
 
 import (
 	"context"
+	"time"
 
 	statik_fs "github.com/rakyll/statik/fs"
 	"github.com/zitadel/logging"
@@ -9,6 +10,7 @@ import (
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
 	_ "github.com/zitadel/zitadel/internal/notification/statik"
 	"github.com/zitadel/zitadel/internal/query"
@@ -30,18 +32,24 @@ func Start(
 	userHandlerCustomConfig projection.CustomConfig,
 	quotaHandlerCustomConfig projection.CustomConfig,
 	telemetryHandlerCustomConfig projection.CustomConfig,
+	backChannelLogoutHandlerCustomConfig projection.CustomConfig,
+	eventExecutionHandlerCustomConfig projection.CustomConfig,
 	telemetryCfg handlers.TelemetryPusherConfig,
+	defaultAccessTokenLifetime,
+	defaultRefreshTokenExpiration time.Duration,
 	externalDomain string,
 	externalPort uint16,
 	externalSecure bool,
 	commands *command.Commands,
 	queries *query.Queries,
 	es *eventstore.Eventstore,
+	notificationLogstoreService *logstore.Service,
 	assetsPrefix func(context.Context) string,
 	fileSystemPath string,
 	userEncryption,
 	smtpEncryption,
-	smsEncryption crypto.EncryptionAlgorithm,
+	smsEncryption,
+	oidcKeyEncryption crypto.EncryptionAlgorithm,
 ) {
 	statikFS, err := statik_fs.NewWithNamespace("notification")
 	logging.OnError(err).Panic("unable to start listener")
@@ -57,13 +65,14 @@ func Start(
 	logging.WithFields("metric", metricSuccessfulDeliveriesJSON).OnError(err).Panic("unable to register counter")
 	err = metrics.RegisterCounter(metricFailedDeliveriesJSON, "Failed JSON message deliveries")
 	logging.WithFields("metric", metricFailedDeliveriesJSON).OnError(err).Panic("unable to register counter")
-	q := handlers.NewNotificationQueries(queries, es, externalDomain, externalPort, externalSecure, fileSystemPath, userEncryption, smtpEncryption, smsEncryption, statikFS)
+	q := handlers.NewNotificationQueries(queries, es, externalDomain, externalPort, externalSecure, fileSystemPath, userEncryption, smtpEncryption, smsEncryption, oidcKeyEncryption, statikFS)
 	handlers.NewUserNotifier(
 		ctx,
 		projection.ApplyCustomConfig(userHandlerCustomConfig),
 		commands,
 		q,
 		assetsPrefix,
+		notificationLogstoreService,
 		metricSuccessfulDeliveriesEmail,
 		metricFailedDeliveriesEmail,
 		metricSuccessfulDeliveriesSMS,
@@ -77,6 +86,20 @@ func Start(
 		metricSuccessfulDeliveriesJSON,
 		metricFailedDeliveriesJSON,
 	).Start()
+	handlers.NewBackChannelLogoutNotifier(
+		ctx,
+		projection.ApplyCustomConfig(backChannelLogoutHandlerCustomConfig),
+		commands,
+		q,
+		defaultAccessTokenLifetime,
+		defaultRefreshTokenExpiration,
+	).Start()
+	handlers.NewEventExecutionNotifier(
+		ctx,
+		projection.ApplyCustomConfig(eventExecutionHandlerCustomConfig),
+		commands,
+		q,
+	).Start()
 	if telemetryCfg.Enabled {
 		handlers.NewTelemetryPusher(
 			ctx,
@@ -6,6 +6,8 @@ import (
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/notification"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
@@ -20,6 +22,7 @@ func EmailChannels(
 	emailConfig func(ctx context.Context) (*smtp.Config, error),
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
+	logstoreService *logstore.Service,
 	successMetricName,
 	failureMetricName string,
 ) (chain *Chain, err error) {
@@ -31,12 +34,17 @@ func EmailChannels(
 	if err == nil {
 		channels = append(
 			channels,
-			instrumenting.Wrap(
+			limitMessages(
 				ctx,
-				p,
-				smtpSpanName,
-				successMetricName,
-				failureMetricName,
+				logstoreService,
+				instrumenting.Wrap(
+					ctx,
+					p,
+					smtpSpanName,
+					successMetricName,
+					failureMetricName,
+				),
+				notification.Email,
 			),
 		)
 	}
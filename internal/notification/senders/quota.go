@@ -0,0 +1,38 @@
+package senders
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/notification"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+// limitMessages only passes messages to the channel as long as the notifications quota of the instance is not exhausted
+// and records each sent message in the logstore service
+func limitMessages(ctx context.Context, logstoreService *logstore.Service, channel channels.NotificationChannel, notificationChannel notification.Channel) channels.NotificationChannel {
+	if logstoreService == nil || !logstoreService.Enabled() {
+		return channel
+	}
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		instanceID := authz.GetInstance(ctx).InstanceID()
+		remaining := logstoreService.Limit(ctx, instanceID, quota.NotificationsAllSent)
+		if remaining != nil && *remaining == 0 {
+			return errors.ThrowResourceExhausted(nil, "SENDE-Ieb4u", "Errors.Quota.Notifications.Exhausted")
+		}
+		if err := channel.HandleMessage(message); err != nil {
+			return err
+		}
+		logstoreService.Handle(ctx, &notification.Record{
+			LogDate:    time.Now(),
+			Channel:    notificationChannel,
+			EventType:  string(message.GetTriggeringEvent().Type()),
+			InstanceID: instanceID,
+		})
+		return nil
+	})
+}
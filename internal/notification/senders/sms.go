@@ -3,6 +3,8 @@ package senders
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/notification"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
@@ -17,6 +19,7 @@ func SMSChannels(
 	twilioConfig *twilio.Config,
 	getFileSystemProvider func(ctx context.Context) (*fs.Config, error),
 	getLogProvider func(ctx context.Context) (*log.Config, error),
+	logstoreService *logstore.Service,
 	successMetricName,
 	failureMetricName string,
 ) (chain *Chain, err error) {
@@ -24,12 +27,17 @@ func SMSChannels(
 	if twilioConfig != nil {
 		channels = append(
 			channels,
-			instrumenting.Wrap(
+			limitMessages(
 				ctx,
-				twilio.InitChannel(*twilioConfig),
-				twilioSpanName,
-				successMetricName,
-				failureMetricName,
+				logstoreService,
+				instrumenting.Wrap(
+					ctx,
+					twilio.InitChannel(*twilioConfig),
+					twilioSpanName,
+					successMetricName,
+					failureMetricName,
+				),
+				notification.SMS,
 			),
 		)
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
@@ -32,6 +33,7 @@ func SendEmail(
 	colors *query.LabelPolicy,
 	assetsPrefix string,
 	triggeringEvent eventstore.Event,
+	logstoreService *logstore.Service,
 	successMetricName,
 	failureMetricName string,
 ) Notify {
@@ -57,6 +59,7 @@ func SendEmail(
 			getLogProvider,
 			allowUnverifiedNotificationChannel,
 			triggeringEvent,
+			logstoreService,
 			successMetricName,
 			failureMetricName,
 		)
@@ -73,6 +76,7 @@ func SendSMSTwilio(
 	colors *query.LabelPolicy,
 	assetsPrefix string,
 	triggeringEvent eventstore.Event,
+	logstoreService *logstore.Service,
 	successMetricName,
 	failureMetricName string,
 ) Notify {
@@ -93,6 +97,7 @@ func SendSMSTwilio(
 			getLogProvider,
 			allowUnverifiedNotificationChannel,
 			triggeringEvent,
+			logstoreService,
 			successMetricName,
 			failureMetricName,
 		)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
@@ -24,6 +25,7 @@ func generateEmail(
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	lastEmail bool,
 	triggeringEvent eventstore.Event,
+	logstoreService *logstore.Service,
 	successMetricName,
 	failureMetricName string,
 ) error {
@@ -43,6 +45,7 @@ func generateEmail(
 		smtpConfig,
 		getFileSystemProvider,
 		getLogProvider,
+		logstoreService,
 		successMetricName,
 		failureMetricName,
 	)
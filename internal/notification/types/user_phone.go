@@ -7,6 +7,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
@@ -24,6 +25,7 @@ func generateSms(
 	getLogProvider func(ctx context.Context) (*log.Config, error),
 	lastPhone bool,
 	triggeringEvent eventstore.Event,
+	logstoreService *logstore.Service,
 	successMetricName,
 	failureMetricName string,
 ) error {
@@ -47,6 +49,7 @@ func generateSms(
 		twilioConfig,
 		getFileSystemProvider,
 		getLogProvider,
+		logstoreService,
 		successMetricName,
 		failureMetricName,
 	)
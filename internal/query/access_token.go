@@ -23,6 +23,7 @@ type OIDCSessionAccessTokenReadModel struct {
 	Scope                 []string
 	AuthMethods           []domain.UserAuthMethodType
 	AuthTime              time.Time
+	JWKThumbprint         string
 	State                 domain.OIDCSessionState
 	AccessTokenID         string
 	AccessTokenCreation   time.Time
@@ -75,6 +76,7 @@ func (wm *OIDCSessionAccessTokenReadModel) reduceAdded(e *oidcsession.AddedEvent
 	wm.Scope = e.Scope
 	wm.AuthMethods = e.AuthMethods
 	wm.AuthTime = e.AuthTime
+	wm.JWKThumbprint = e.JWKThumbprint
 	wm.State = domain.OIDCSessionStateActive
 }
 
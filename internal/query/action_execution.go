@@ -0,0 +1,224 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// actionExecutionTable is the table of the database logstore for action executions,
+// each finished action run is stored as a record with the time it took
+var (
+	actionExecutionTable = table{
+		name:          "logstore.execution",
+		instanceIDCol: "instance_id",
+	}
+	ActionExecutionColumnLogDate = Column{
+		name:  "log_date",
+		table: actionExecutionTable,
+	}
+	ActionExecutionColumnTook = Column{
+		name:  "took",
+		table: actionExecutionTable,
+	}
+	ActionExecutionColumnInstanceID = Column{
+		name:  "instance_id",
+		table: actionExecutionTable,
+	}
+	ActionExecutionColumnActionID = Column{
+		name:  "action_id",
+		table: actionExecutionTable,
+	}
+	ActionExecutionColumnResourceOwner = Column{
+		name:  "resource_owner",
+		table: actionExecutionTable,
+	}
+	ActionExecutionColumnFlowType = Column{
+		name:  "flow_type",
+		table: actionExecutionTable,
+	}
+	ActionExecutionColumnTriggerType = Column{
+		name:  "trigger_type",
+		table: actionExecutionTable,
+	}
+	ActionExecutionColumnUserID = Column{
+		name:  "user_id",
+		table: actionExecutionTable,
+	}
+	ActionExecutionColumnError = Column{
+		name:  "error_message",
+		table: actionExecutionTable,
+	}
+	ActionExecutionColumnLogs = Column{
+		name:  "logs",
+		table: actionExecutionTable,
+	}
+	// actionExecutionColumnTookMillis selects the interval of the took column as milliseconds
+	actionExecutionColumnTookMillis = Column{
+		name: "(EXTRACT(EPOCH FROM " + ActionExecutionColumnTook.identifier() + ") * 1000)::INT8",
+	}
+)
+
+type ActionExecutions struct {
+	SearchResponse
+	Executions []*ActionExecution
+}
+
+type ActionExecution struct {
+	ActionID      string
+	ResourceOwner string
+	FlowType      domain.FlowType
+	TriggerType   domain.TriggerType
+	UserID        string
+	// FinishDate is the time the run finished
+	FinishDate time.Time
+	Took       time.Duration
+	Succeeded  bool
+	Error      string
+	// Logs contains the messages the action logged using the zitadel/log module
+	Logs []*execution.Log
+}
+
+type ActionExecutionSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *ActionExecutionSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// SearchActionExecutions returns the finished action runs stored in the database logstore.
+// Runs are only stored if the database logstore for executions is enabled
+// and are cleaned up after its configured keep duration.
+func (q *Queries) SearchActionExecutions(ctx context.Context, queries *ActionExecutionSearchQueries) (executions *ActionExecutions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareActionExecutionsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.And{
+		sq.Eq{ActionExecutionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()},
+		sq.NotEq{ActionExecutionColumnTook.identifier(): nil},
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-aeX7o", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ohd0a", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func NewActionExecutionResourceOwnerSearchQuery(resourceOwner string) (SearchQuery, error) {
+	return NewTextQuery(ActionExecutionColumnResourceOwner, resourceOwner, TextEquals)
+}
+
+func NewActionExecutionActionIDSearchQuery(actionID string) (SearchQuery, error) {
+	return NewTextQuery(ActionExecutionColumnActionID, actionID, TextEquals)
+}
+
+func NewActionExecutionUserIDSearchQuery(userID string) (SearchQuery, error) {
+	return NewTextQuery(ActionExecutionColumnUserID, userID, TextEquals)
+}
+
+func NewActionExecutionFlowTypeSearchQuery(flowType domain.FlowType) (SearchQuery, error) {
+	return NewNumberQuery(ActionExecutionColumnFlowType, int(flowType), NumberEquals)
+}
+
+func NewActionExecutionTriggerTypeSearchQuery(triggerType domain.TriggerType) (SearchQuery, error) {
+	return NewNumberQuery(ActionExecutionColumnTriggerType, int(triggerType), NumberEquals)
+}
+
+func NewActionExecutionSucceededSearchQuery(succeeded bool) (SearchQuery, error) {
+	if succeeded {
+		return NewIsNullQuery(ActionExecutionColumnError)
+	}
+	return NewNotNullQuery(ActionExecutionColumnError)
+}
+
+func prepareActionExecutionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*ActionExecutions, error)) {
+	return sq.Select(
+			ActionExecutionColumnActionID.identifier(),
+			ActionExecutionColumnResourceOwner.identifier(),
+			ActionExecutionColumnFlowType.identifier(),
+			ActionExecutionColumnTriggerType.identifier(),
+			ActionExecutionColumnUserID.identifier(),
+			ActionExecutionColumnLogDate.identifier(),
+			actionExecutionColumnTookMillis.identifier(),
+			ActionExecutionColumnError.identifier(),
+			ActionExecutionColumnLogs.identifier(),
+			countColumn.identifier(),
+		).From(actionExecutionTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ActionExecutions, error) {
+			executions := make([]*ActionExecution, 0)
+			var count uint64
+			for rows.Next() {
+				var (
+					execution     = new(ActionExecution)
+					resourceOwner sql.NullString
+					flowType      sql.NullInt32
+					triggerType   sql.NullInt32
+					userID        sql.NullString
+					tookMillis    int64
+					errorMessage  sql.NullString
+					logs          []byte
+				)
+				err := rows.Scan(
+					&execution.ActionID,
+					&resourceOwner,
+					&flowType,
+					&triggerType,
+					&userID,
+					&execution.FinishDate,
+					&tookMillis,
+					&errorMessage,
+					&logs,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				execution.ResourceOwner = resourceOwner.String
+				execution.FlowType = domain.FlowType(flowType.Int32)
+				execution.TriggerType = domain.TriggerType(triggerType.Int32)
+				execution.UserID = userID.String
+				execution.Took = time.Duration(tookMillis) * time.Millisecond
+				execution.Succeeded = !errorMessage.Valid
+				execution.Error = errorMessage.String
+				if len(logs) > 0 {
+					if err := json.Unmarshal(logs, &execution.Logs); err != nil {
+						return nil, errors.ThrowInternal(err, "QUERY-Ieb3u", "Errors.Internal")
+					}
+				}
+				executions = append(executions, execution)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Zae5i", "Errors.Query.CloseRows")
+			}
+
+			return &ActionExecutions{
+				Executions: executions,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
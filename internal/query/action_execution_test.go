@@ -0,0 +1,158 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
+)
+
+var (
+	prepareActionExecutionsStmt = `SELECT logstore.execution.action_id,` +
+		` logstore.execution.resource_owner,` +
+		` logstore.execution.flow_type,` +
+		` logstore.execution.trigger_type,` +
+		` logstore.execution.user_id,` +
+		` logstore.execution.log_date,` +
+		` (EXTRACT(EPOCH FROM logstore.execution.took) * 1000)::INT8,` +
+		` logstore.execution.error_message,` +
+		` logstore.execution.logs,` +
+		` COUNT(*) OVER ()` +
+		` FROM logstore.execution` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareActionExecutionsCols = []string{
+		"action_id",
+		"resource_owner",
+		"flow_type",
+		"trigger_type",
+		"user_id",
+		"log_date",
+		"took",
+		"error_message",
+		"logs",
+		"count",
+	}
+)
+
+func Test_ActionExecutionPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionExecutionsQuery no result",
+			prepare: prepareActionExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionExecutionsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &ActionExecutions{Executions: []*ActionExecution{}},
+		},
+		{
+			name:    "prepareActionExecutionsQuery multiple result",
+			prepare: prepareActionExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionExecutionsStmt),
+					prepareActionExecutionsCols,
+					[][]driver.Value{
+						{
+							"action-1",
+							"ro",
+							int32(domain.FlowTypeExternalAuthentication),
+							int32(domain.TriggerTypePostAuthentication),
+							"user",
+							testNow,
+							int64(1500),
+							nil,
+							[]byte(`[{"logDate":"2023-06-01T00:00:00Z","logLevel":"info","message":"hello"}]`),
+						},
+						{
+							"action-2",
+							"ro",
+							int32(domain.FlowTypeCustomiseToken),
+							int32(domain.TriggerTypePreUserinfoCreation),
+							nil,
+							testNow,
+							int64(20),
+							"TypeError: undefined",
+							nil,
+						},
+					},
+				),
+			},
+			object: &ActionExecutions{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Executions: []*ActionExecution{
+					{
+						ActionID:      "action-1",
+						ResourceOwner: "ro",
+						FlowType:      domain.FlowTypeExternalAuthentication,
+						TriggerType:   domain.TriggerTypePostAuthentication,
+						UserID:        "user",
+						FinishDate:    testNow,
+						Took:          1500 * time.Millisecond,
+						Succeeded:     true,
+						Logs: []*execution.Log{
+							{
+								LogDate:  time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+								LogLevel: logrus.InfoLevel,
+								Message:  "hello",
+							},
+						},
+					},
+					{
+						ActionID:      "action-2",
+						ResourceOwner: "ro",
+						FlowType:      domain.FlowTypeCustomiseToken,
+						TriggerType:   domain.TriggerTypePreUserinfoCreation,
+						FinishDate:    testNow,
+						Took:          20 * time.Millisecond,
+						Error:         "TypeError: undefined",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareActionExecutionsQuery sql err",
+			prepare: prepareActionExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareActionExecutionsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"database/sql"
+	errs "errors"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -119,6 +120,28 @@ func (q *Queries) GetActiveActionsByFlowAndTriggerType(ctx context.Context, flow
 	return scan(rows)
 }
 
+// HasActiveActionsByFlowAndTriggerType returns if any organization of the instance has an active action on the trigger type of the flow,
+// so the context for the actions (e.g. the user) only needs to be queried if there are actions to run
+func (q *Queries) HasActiveActionsByFlowAndTriggerType(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType) (_ bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareHasTriggerActionsQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		FlowsTriggersColumnFlowType.identifier():    flowType,
+		FlowsTriggersColumnTriggerType.identifier(): triggerType,
+		FlowsTriggersColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+		FlowsTriggersOwnerRemovedCol.identifier():   false,
+		ActionColumnState.identifier():              domain.ActionStateActive,
+	}).Limit(1).ToSql()
+	if err != nil {
+		return false, errors.ThrowInternal(err, "QUERY-Hq3vd", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
 func (q *Queries) GetFlowTypesOfActionID(ctx context.Context, actionID string, withOwnerRemoved bool) (_ []domain.FlowType, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -167,6 +190,26 @@ func prepareFlowTypesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 
 }
 
+func prepareHasTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (bool, error)) {
+	return sq.Select(
+			FlowsTriggersColumnActionID.identifier(),
+		).
+			From(flowsTriggersTable.name).
+			LeftJoin(join(ActionColumnID, FlowsTriggersColumnActionID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (bool, error) {
+			var actionID string
+			err := row.Scan(&actionID)
+			if errs.Is(err, sql.ErrNoRows) {
+				return false, nil
+			}
+			if err != nil {
+				return false, errors.ThrowInternal(err, "QUERY-Nw8kd", "Errors.Internal")
+			}
+			return true, nil
+		}
+}
+
 func prepareTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*Action, error)) {
 	return sq.Select(
 			ActionColumnID.identifier(),
@@ -69,6 +69,11 @@ var (
 		` LEFT JOIN projections.actions3 ON projections.flow_triggers2.action_id = projections.actions3.id AND projections.flow_triggers2.instance_id = projections.actions3.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 
+	prepareHasTriggerActionStmt = `SELECT projections.flow_triggers2.action_id` +
+		` FROM projections.flow_triggers2` +
+		` LEFT JOIN projections.actions3 ON projections.flow_triggers2.action_id = projections.actions3.id AND projections.flow_triggers2.instance_id = projections.actions3.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`
+
 	prepareTriggerActionCols = []string{
 		"id",
 		"creation_date",
@@ -317,6 +322,47 @@ func Test_FlowPrepares(t *testing.T) {
 			},
 			object: nil,
 		},
+		{
+			name:    "prepareHasTriggerActionsQuery no result",
+			prepare: prepareHasTriggerActionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareHasTriggerActionStmt),
+					nil,
+					nil,
+				),
+			},
+			object: false,
+		},
+		{
+			name:    "prepareHasTriggerActionsQuery found",
+			prepare: prepareHasTriggerActionsQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareHasTriggerActionStmt),
+					[]string{"action_id"},
+					[]driver.Value{"action-id"},
+				),
+			},
+			object: true,
+		},
+		{
+			name:    "prepareHasTriggerActionsQuery sql err",
+			prepare: prepareHasTriggerActionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareHasTriggerActionStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
 		{
 			name:    "prepareTriggerActionsQuery no result",
 			prepare: prepareTriggerActionsQuery,
@@ -40,30 +40,35 @@ type App struct {
 }
 
 type OIDCApp struct {
-	RedirectURIs             database.StringArray
-	ResponseTypes            database.EnumArray[domain.OIDCResponseType]
-	GrantTypes               database.EnumArray[domain.OIDCGrantType]
-	AppType                  domain.OIDCApplicationType
-	ClientID                 string
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectURIs   database.StringArray
-	Version                  domain.OIDCVersion
-	ComplianceProblems       database.StringArray
-	IsDevMode                bool
-	AccessTokenType          domain.OIDCTokenType
-	AssertAccessTokenRole    bool
-	AssertIDTokenRole        bool
-	AssertIDTokenUserinfo    bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        database.StringArray
-	AllowedOrigins           database.StringArray
-	SkipNativeAppSuccessPage bool
+	RedirectURIs              database.StringArray
+	ResponseTypes             database.EnumArray[domain.OIDCResponseType]
+	GrantTypes                database.EnumArray[domain.OIDCGrantType]
+	AppType                   domain.OIDCApplicationType
+	ClientID                  string
+	AuthMethodType            domain.OIDCAuthMethodType
+	PostLogoutRedirectURIs    database.StringArray
+	Version                   domain.OIDCVersion
+	ComplianceProblems        database.StringArray
+	IsDevMode                 bool
+	AccessTokenType           domain.OIDCTokenType
+	AssertAccessTokenRole     bool
+	AssertIDTokenRole         bool
+	AssertIDTokenUserinfo     bool
+	ClockSkew                 time.Duration
+	AdditionalOrigins         database.StringArray
+	AllowedOrigins            database.StringArray
+	SkipNativeAppSuccessPage  bool
+	BackChannelLogoutURI      string
+	RequirePushedAuthRequests bool
+	RequireDPoP               bool
 }
 
 type SAMLApp struct {
-	Metadata    []byte
-	MetadataURL string
-	EntityID    string
+	Metadata          []byte
+	MetadataURL       string
+	EntityID          string
+	RoleAssertion     bool
+	MetadataAssertion bool
 }
 
 type APIApp struct {
@@ -152,6 +157,14 @@ var (
 		name:  projection.AppSAMLConfigColumnMetadataURL,
 		table: appSAMLConfigsTable,
 	}
+	AppSAMLConfigColumnRoleAssertion = Column{
+		name:  projection.AppSAMLConfigColumnRoleAssertion,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnMetadataAssertion = Column{
+		name:  projection.AppSAMLConfigColumnMetadataAssertion,
+		table: appSAMLConfigsTable,
+	}
 )
 
 var (
@@ -246,6 +259,18 @@ var (
 		name:  projection.AppOIDCConfigColumnSkipNativeAppSuccessPage,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnBackChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnBackChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequirePushedAuthRequests = Column{
+		name:  projection.AppOIDCConfigColumnRequirePushedAuthRequests,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequireDPoP = Column{
+		name:  projection.AppOIDCConfigColumnRequireDPoP,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string, withOwnerRemoved bool) (_ *App, err error) {
@@ -541,11 +566,16 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequests.identifier(),
+			AppOIDCConfigColumnRequireDPoP.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
 			AppSAMLConfigColumnMetadata.identifier(),
 			AppSAMLConfigColumnMetadataURL.identifier(),
+			AppSAMLConfigColumnRoleAssertion.identifier(),
+			AppSAMLConfigColumnMetadataAssertion.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
@@ -590,11 +620,16 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
 				&oidcConfig.skipNativeAppSuccessPage,
+				&oidcConfig.backChannelLogoutURI,
+				&oidcConfig.requirePushedAuthRequests,
+				&oidcConfig.requireDPoP,
 
 				&samlConfig.appID,
 				&samlConfig.entityID,
 				&samlConfig.metadata,
 				&samlConfig.metadataURL,
+				&samlConfig.roleAssertion,
+				&samlConfig.metadataAssertion,
 			)
 
 			if err != nil {
@@ -711,11 +746,16 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequests.identifier(),
+			AppOIDCConfigColumnRequireDPoP.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
 			AppSAMLConfigColumnMetadata.identifier(),
 			AppSAMLConfigColumnMetadataURL.identifier(),
+			AppSAMLConfigColumnRoleAssertion.identifier(),
+			AppSAMLConfigColumnMetadataAssertion.identifier(),
 			countColumn.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
@@ -763,11 +803,16 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
 					&oidcConfig.skipNativeAppSuccessPage,
+					&oidcConfig.backChannelLogoutURI,
+					&oidcConfig.requirePushedAuthRequests,
+					&oidcConfig.requireDPoP,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
 					&samlConfig.metadata,
 					&samlConfig.metadataURL,
+					&samlConfig.roleAssertion,
+					&samlConfig.metadataAssertion,
 
 					&apps.Count,
 				)
@@ -818,23 +863,26 @@ func prepareClientIDsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 }
 
 type sqlOIDCConfig struct {
-	appID                    sql.NullString
-	version                  sql.NullInt32
-	clientID                 sql.NullString
-	redirectUris             database.StringArray
-	applicationType          sql.NullInt16
-	authMethodType           sql.NullInt16
-	postLogoutRedirectUris   database.StringArray
-	devMode                  sql.NullBool
-	accessTokenType          sql.NullInt16
-	accessTokenRoleAssertion sql.NullBool
-	iDTokenRoleAssertion     sql.NullBool
-	iDTokenUserinfoAssertion sql.NullBool
-	clockSkew                sql.NullInt64
-	additionalOrigins        database.StringArray
-	responseTypes            database.EnumArray[domain.OIDCResponseType]
-	grantTypes               database.EnumArray[domain.OIDCGrantType]
-	skipNativeAppSuccessPage sql.NullBool
+	appID                     sql.NullString
+	version                   sql.NullInt32
+	clientID                  sql.NullString
+	redirectUris              database.StringArray
+	applicationType           sql.NullInt16
+	authMethodType            sql.NullInt16
+	postLogoutRedirectUris    database.StringArray
+	devMode                   sql.NullBool
+	accessTokenType           sql.NullInt16
+	accessTokenRoleAssertion  sql.NullBool
+	iDTokenRoleAssertion      sql.NullBool
+	iDTokenUserinfoAssertion  sql.NullBool
+	clockSkew                 sql.NullInt64
+	additionalOrigins         database.StringArray
+	responseTypes             database.EnumArray[domain.OIDCResponseType]
+	grantTypes                database.EnumArray[domain.OIDCGrantType]
+	skipNativeAppSuccessPage  sql.NullBool
+	backChannelLogoutURI      sql.NullString
+	requirePushedAuthRequests sql.NullBool
+	requireDPoP               sql.NullBool
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -842,22 +890,25 @@ func (c sqlOIDCConfig) set(app *App) {
 		return
 	}
 	app.OIDCConfig = &OIDCApp{
-		Version:                  domain.OIDCVersion(c.version.Int32),
-		ClientID:                 c.clientID.String,
-		RedirectURIs:             c.redirectUris,
-		AppType:                  domain.OIDCApplicationType(c.applicationType.Int16),
-		AuthMethodType:           domain.OIDCAuthMethodType(c.authMethodType.Int16),
-		PostLogoutRedirectURIs:   c.postLogoutRedirectUris,
-		IsDevMode:                c.devMode.Bool,
-		AccessTokenType:          domain.OIDCTokenType(c.accessTokenType.Int16),
-		AssertAccessTokenRole:    c.accessTokenRoleAssertion.Bool,
-		AssertIDTokenRole:        c.iDTokenRoleAssertion.Bool,
-		AssertIDTokenUserinfo:    c.iDTokenUserinfoAssertion.Bool,
-		ClockSkew:                time.Duration(c.clockSkew.Int64),
-		AdditionalOrigins:        c.additionalOrigins,
-		ResponseTypes:            c.responseTypes,
-		GrantTypes:               c.grantTypes,
-		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
+		Version:                   domain.OIDCVersion(c.version.Int32),
+		ClientID:                  c.clientID.String,
+		RedirectURIs:              c.redirectUris,
+		AppType:                   domain.OIDCApplicationType(c.applicationType.Int16),
+		AuthMethodType:            domain.OIDCAuthMethodType(c.authMethodType.Int16),
+		PostLogoutRedirectURIs:    c.postLogoutRedirectUris,
+		IsDevMode:                 c.devMode.Bool,
+		AccessTokenType:           domain.OIDCTokenType(c.accessTokenType.Int16),
+		AssertAccessTokenRole:     c.accessTokenRoleAssertion.Bool,
+		AssertIDTokenRole:         c.iDTokenRoleAssertion.Bool,
+		AssertIDTokenUserinfo:     c.iDTokenUserinfoAssertion.Bool,
+		ClockSkew:                 time.Duration(c.clockSkew.Int64),
+		AdditionalOrigins:         c.additionalOrigins,
+		ResponseTypes:             c.responseTypes,
+		GrantTypes:                c.grantTypes,
+		SkipNativeAppSuccessPage:  c.skipNativeAppSuccessPage.Bool,
+		BackChannelLogoutURI:      c.backChannelLogoutURI.String,
+		RequirePushedAuthRequests: c.requirePushedAuthRequests.Bool,
+		RequireDPoP:               c.requireDPoP.Bool,
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
@@ -868,10 +919,12 @@ func (c sqlOIDCConfig) set(app *App) {
 }
 
 type sqlSAMLConfig struct {
-	appID       sql.NullString
-	entityID    sql.NullString
-	metadataURL sql.NullString
-	metadata    []byte
+	appID             sql.NullString
+	entityID          sql.NullString
+	metadataURL       sql.NullString
+	metadata          []byte
+	roleAssertion     sql.NullBool
+	metadataAssertion sql.NullBool
 }
 
 func (c sqlSAMLConfig) set(app *App) {
@@ -879,9 +932,11 @@ func (c sqlSAMLConfig) set(app *App) {
 		return
 	}
 	app.SAMLConfig = &SAMLApp{
-		MetadataURL: c.metadataURL.String,
-		Metadata:    c.metadata,
-		EntityID:    c.entityID.String,
+		MetadataURL:       c.metadataURL.String,
+		Metadata:          c.metadata,
+		EntityID:          c.entityID.String,
+		RoleAssertion:     c.roleAssertion.Bool,
+		MetadataAssertion: c.metadataAssertion.Bool,
 	}
 }
 
@@ -15,98 +15,108 @@ import (
 )
 
 var (
-	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps9.id,` +
+		` projections.apps9.name,` +
+		` projections.apps9.project_id,` +
+		` projections.apps9.creation_date,` +
+		` projections.apps9.change_date,` +
+		` projections.apps9.resource_owner,` +
+		` projections.apps9.state,` +
+		` projections.apps9.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps9_api_configs.app_id,` +
+		` projections.apps9_api_configs.client_id,` +
+		` projections.apps9_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps9_oidc_configs.app_id,` +
+		` projections.apps9_oidc_configs.version,` +
+		` projections.apps9_oidc_configs.client_id,` +
+		` projections.apps9_oidc_configs.redirect_uris,` +
+		` projections.apps9_oidc_configs.response_types,` +
+		` projections.apps9_oidc_configs.grant_types,` +
+		` projections.apps9_oidc_configs.application_type,` +
+		` projections.apps9_oidc_configs.auth_method_type,` +
+		` projections.apps9_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps9_oidc_configs.is_dev_mode,` +
+		` projections.apps9_oidc_configs.access_token_type,` +
+		` projections.apps9_oidc_configs.access_token_role_assertion,` +
+		` projections.apps9_oidc_configs.id_token_role_assertion,` +
+		` projections.apps9_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps9_oidc_configs.clock_skew,` +
+		` projections.apps9_oidc_configs.additional_origins,` +
+		` projections.apps9_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps9_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps9_oidc_configs.require_pushed_auth_requests,` +
+		` projections.apps9_oidc_configs.require_dpop,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` projections.apps9_saml_configs.app_id,` +
+		` projections.apps9_saml_configs.entity_id,` +
+		` projections.apps9_saml_configs.metadata,` +
+		` projections.apps9_saml_configs.metadata_url,` +
+		` projections.apps9_saml_configs.role_assertion,` +
+		` projections.apps9_saml_configs.metadata_assertion` +
+		` FROM projections.apps9` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps9_saml_configs ON projections.apps9.id = projections.apps9_saml_configs.app_id AND projections.apps9.instance_id = projections.apps9_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps5.id,` +
-		` projections.apps5.name,` +
-		` projections.apps5.project_id,` +
-		` projections.apps5.creation_date,` +
-		` projections.apps5.change_date,` +
-		` projections.apps5.resource_owner,` +
-		` projections.apps5.state,` +
-		` projections.apps5.sequence,` +
+	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps9.id,` +
+		` projections.apps9.name,` +
+		` projections.apps9.project_id,` +
+		` projections.apps9.creation_date,` +
+		` projections.apps9.change_date,` +
+		` projections.apps9.resource_owner,` +
+		` projections.apps9.state,` +
+		` projections.apps9.sequence,` +
 		// api config
-		` projections.apps5_api_configs.app_id,` +
-		` projections.apps5_api_configs.client_id,` +
-		` projections.apps5_api_configs.auth_method,` +
+		` projections.apps9_api_configs.app_id,` +
+		` projections.apps9_api_configs.client_id,` +
+		` projections.apps9_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps5_oidc_configs.app_id,` +
-		` projections.apps5_oidc_configs.version,` +
-		` projections.apps5_oidc_configs.client_id,` +
-		` projections.apps5_oidc_configs.redirect_uris,` +
-		` projections.apps5_oidc_configs.response_types,` +
-		` projections.apps5_oidc_configs.grant_types,` +
-		` projections.apps5_oidc_configs.application_type,` +
-		` projections.apps5_oidc_configs.auth_method_type,` +
-		` projections.apps5_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps5_oidc_configs.is_dev_mode,` +
-		` projections.apps5_oidc_configs.access_token_type,` +
-		` projections.apps5_oidc_configs.access_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_role_assertion,` +
-		` projections.apps5_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps5_oidc_configs.clock_skew,` +
-		` projections.apps5_oidc_configs.additional_origins,` +
-		` projections.apps5_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps9_oidc_configs.app_id,` +
+		` projections.apps9_oidc_configs.version,` +
+		` projections.apps9_oidc_configs.client_id,` +
+		` projections.apps9_oidc_configs.redirect_uris,` +
+		` projections.apps9_oidc_configs.response_types,` +
+		` projections.apps9_oidc_configs.grant_types,` +
+		` projections.apps9_oidc_configs.application_type,` +
+		` projections.apps9_oidc_configs.auth_method_type,` +
+		` projections.apps9_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps9_oidc_configs.is_dev_mode,` +
+		` projections.apps9_oidc_configs.access_token_type,` +
+		` projections.apps9_oidc_configs.access_token_role_assertion,` +
+		` projections.apps9_oidc_configs.id_token_role_assertion,` +
+		` projections.apps9_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps9_oidc_configs.clock_skew,` +
+		` projections.apps9_oidc_configs.additional_origins,` +
+		` projections.apps9_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps9_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps9_oidc_configs.require_pushed_auth_requests,` +
+		` projections.apps9_oidc_configs.require_dpop,` +
 		//saml config
-		` projections.apps5_saml_configs.app_id,` +
-		` projections.apps5_saml_configs.entity_id,` +
-		` projections.apps5_saml_configs.metadata,` +
-		` projections.apps5_saml_configs.metadata_url,` +
+		` projections.apps9_saml_configs.app_id,` +
+		` projections.apps9_saml_configs.entity_id,` +
+		` projections.apps9_saml_configs.metadata,` +
+		` projections.apps9_saml_configs.metadata_url,` +
+		` projections.apps9_saml_configs.role_assertion,` +
+		` projections.apps9_saml_configs.metadata_assertion,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` FROM projections.apps9` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps9_saml_configs ON projections.apps9.id = projections.apps9_saml_configs.app_id AND projections.apps9.instance_id = projections.apps9_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps5_api_configs.client_id,` +
-		` projections.apps5_oidc_configs.client_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
+	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps9_api_configs.client_id,` +
+		` projections.apps9_oidc_configs.client_id` +
+		` FROM projections.apps9` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps5.project_id` +
-		` FROM projections.apps5` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps9.project_id` +
+		` FROM projections.apps9` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps9_saml_configs ON projections.apps9.id = projections.apps9_saml_configs.app_id AND projections.apps9.instance_id = projections.apps9_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects3.id,` +
 		` projections.projects3.creation_date,` +
@@ -120,10 +130,10 @@ var (
 		` projections.projects3.has_project_check,` +
 		` projections.projects3.private_labeling_setting` +
 		` FROM projections.projects3` +
-		` JOIN projections.apps5 ON projections.projects3.id = projections.apps5.project_id AND projections.projects3.instance_id = projections.apps5.instance_id` +
-		` LEFT JOIN projections.apps5_api_configs ON projections.apps5.id = projections.apps5_api_configs.app_id AND projections.apps5.instance_id = projections.apps5_api_configs.instance_id` +
-		` LEFT JOIN projections.apps5_oidc_configs ON projections.apps5.id = projections.apps5_oidc_configs.app_id AND projections.apps5.instance_id = projections.apps5_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps5_saml_configs ON projections.apps5.id = projections.apps5_saml_configs.app_id AND projections.apps5.instance_id = projections.apps5_saml_configs.instance_id` +
+		` JOIN projections.apps9 ON projections.projects3.id = projections.apps9.project_id AND projections.projects3.instance_id = projections.apps9.instance_id` +
+		` LEFT JOIN projections.apps9_api_configs ON projections.apps9.id = projections.apps9_api_configs.app_id AND projections.apps9.instance_id = projections.apps9_api_configs.instance_id` +
+		` LEFT JOIN projections.apps9_oidc_configs ON projections.apps9.id = projections.apps9_oidc_configs.app_id AND projections.apps9.instance_id = projections.apps9_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps9_saml_configs ON projections.apps9.id = projections.apps9_saml_configs.app_id AND projections.apps9.instance_id = projections.apps9_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	appCols = database.StringArray{
@@ -157,11 +167,16 @@ var (
 		"clock_skew",
 		"additional_origins",
 		"skip_native_app_success_page",
+		"back_channel_logout_uri",
+		"require_pushed_auth_requests",
+		"require_dpop",
 		//saml config
 		"app_id",
 		"entity_id",
 		"metadata",
 		"metadata_url",
+		"role_assertion",
+		"metadata_assertion",
 	}
 	appsCols = append(appCols, "count")
 )
@@ -228,11 +243,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -294,11 +314,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -363,11 +388,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							true,
+							true,
 						},
 					},
 				),
@@ -387,9 +417,11 @@ func Test_AppsPrepare(t *testing.T) {
 						Name:          "app-name",
 						ProjectID:     "project-id",
 						SAMLConfig: &SAMLApp{
-							Metadata:    []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
-							MetadataURL: "https://test.com/saml/metadata",
-							EntityID:    "https://test.com/saml/metadata",
+							Metadata:          []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
+							MetadataURL:       "https://test.com/saml/metadata",
+							EntityID:          "https://test.com/saml/metadata",
+							RoleAssertion:     true,
+							MetadataAssertion: true,
 						},
 					},
 				},
@@ -434,11 +466,16 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -520,11 +557,16 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -606,11 +648,16 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -692,11 +739,16 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -778,11 +830,16 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -864,11 +921,16 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							true,
+							"https://logout.to/backchannel",
+							true,
+							true,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -888,24 +950,27 @@ func Test_AppsPrepare(t *testing.T) {
 						Name:          "app-name",
 						ProjectID:     "project-id",
 						OIDCConfig: &OIDCApp{
-							Version:                  domain.OIDCVersionV1,
-							ClientID:                 "oidc-client-id",
-							RedirectURIs:             database.StringArray{"https://redirect.to/me"},
-							ResponseTypes:            database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
-							GrantTypes:               database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
-							AppType:                  domain.OIDCApplicationTypeNative,
-							AuthMethodType:           domain.OIDCAuthMethodTypeNone,
-							PostLogoutRedirectURIs:   database.StringArray{"post.logout.ch"},
-							IsDevMode:                false,
-							AccessTokenType:          domain.OIDCTokenTypeJWT,
-							AssertAccessTokenRole:    false,
-							AssertIDTokenRole:        false,
-							AssertIDTokenUserinfo:    true,
-							ClockSkew:                1 * time.Second,
-							AdditionalOrigins:        database.StringArray{"additional.origin"},
-							ComplianceProblems:       nil,
-							AllowedOrigins:           database.StringArray{"https://redirect.to", "additional.origin"},
-							SkipNativeAppSuccessPage: true,
+							Version:                   domain.OIDCVersionV1,
+							ClientID:                  "oidc-client-id",
+							RedirectURIs:              database.StringArray{"https://redirect.to/me"},
+							ResponseTypes:             database.EnumArray[domain.OIDCResponseType]{domain.OIDCResponseTypeIDTokenToken},
+							GrantTypes:                database.EnumArray[domain.OIDCGrantType]{domain.OIDCGrantTypeImplicit},
+							AppType:                   domain.OIDCApplicationTypeNative,
+							AuthMethodType:            domain.OIDCAuthMethodTypeNone,
+							PostLogoutRedirectURIs:    database.StringArray{"post.logout.ch"},
+							IsDevMode:                 false,
+							AccessTokenType:           domain.OIDCTokenTypeJWT,
+							AssertAccessTokenRole:     false,
+							AssertIDTokenRole:         false,
+							AssertIDTokenUserinfo:     true,
+							ClockSkew:                 1 * time.Second,
+							AdditionalOrigins:         database.StringArray{"additional.origin"},
+							ComplianceProblems:        nil,
+							AllowedOrigins:            database.StringArray{"https://redirect.to", "additional.origin"},
+							SkipNativeAppSuccessPage:  true,
+							BackChannelLogoutURI:      "https://logout.to/backchannel",
+							RequirePushedAuthRequests: true,
+							RequireDPoP:               true,
 						},
 					},
 				},
@@ -950,11 +1015,16 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 						{
 							"api-app-id",
@@ -987,11 +1057,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 						{
 							"saml-app-id",
@@ -1024,11 +1099,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							true,
+							true,
 						},
 					},
 				),
@@ -1092,9 +1172,11 @@ func Test_AppsPrepare(t *testing.T) {
 						Name:          "app-name",
 						ProjectID:     "project-id",
 						SAMLConfig: &SAMLApp{
-							Metadata:    []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
-							MetadataURL: "https://test.com/saml/metadata",
-							EntityID:    "https://test.com/saml/metadata",
+							Metadata:          []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
+							MetadataURL:       "https://test.com/saml/metadata",
+							EntityID:          "https://test.com/saml/metadata",
+							RoleAssertion:     true,
+							MetadataAssertion: true,
 						},
 					},
 				},
@@ -1192,11 +1274,16 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1250,11 +1337,16 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1313,11 +1405,16 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1391,11 +1488,16 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							true,
+							true,
 						},
 					},
 				),
@@ -1410,9 +1512,11 @@ func Test_AppPrepare(t *testing.T) {
 				Name:          "app-name",
 				ProjectID:     "project-id",
 				SAMLConfig: &SAMLApp{
-					Metadata:    []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
-					MetadataURL: "https://test.com/saml/metadata",
-					EntityID:    "https://test.com/saml/metadata",
+					Metadata:          []byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
+					MetadataURL:       "https://test.com/saml/metadata",
+					EntityID:          "https://test.com/saml/metadata",
+					RoleAssertion:     true,
+					MetadataAssertion: true,
 				},
 			},
 		},
@@ -1455,11 +1559,16 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1534,11 +1643,16 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1613,11 +1727,16 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1692,11 +1811,16 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.StringArray{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -0,0 +1,242 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	executionTable = table{
+		name:          projection.ExecutionTable,
+		instanceIDCol: projection.ExecutionInstanceIDCol,
+	}
+	ExecutionColumnID = Column{
+		name:  projection.ExecutionIDCol,
+		table: executionTable,
+	}
+	ExecutionColumnCreationDate = Column{
+		name:  projection.ExecutionCreationDateCol,
+		table: executionTable,
+	}
+	ExecutionColumnChangeDate = Column{
+		name:  projection.ExecutionChangeDateCol,
+		table: executionTable,
+	}
+	ExecutionColumnResourceOwner = Column{
+		name:  projection.ExecutionResourceOwnerCol,
+		table: executionTable,
+	}
+	ExecutionColumnInstanceID = Column{
+		name:  projection.ExecutionInstanceIDCol,
+		table: executionTable,
+	}
+	ExecutionColumnSequence = Column{
+		name:  projection.ExecutionSequenceCol,
+		table: executionTable,
+	}
+	ExecutionColumnTargets = Column{
+		name:  projection.ExecutionTargetsCol,
+		table: executionTable,
+	}
+)
+
+type Executions struct {
+	SearchResponse
+	Executions []*Execution
+}
+
+type Execution struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Targets database.StringArray
+}
+
+type ExecutionSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *ExecutionSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) SearchExecutions(ctx context.Context, queries *ExecutionSearchQueries) (executions *Executions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareExecutionsQuery(ctx, q.client)
+	eq := sq.Eq{
+		ExecutionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-btbYx", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-RN1Pw", "Errors.Internal")
+	}
+	executions, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	executions.LatestSequence, err = q.latestSequence(ctx, executionTable)
+	return executions, err
+}
+
+func NewExecutionInIDsSearchQuery(values []string) (SearchQuery, error) {
+	return NewInTextQuery(ExecutionColumnID, values)
+}
+
+func NewExecutionIDPrefixSearchQuery(prefix string) (SearchQuery, error) {
+	return NewTextQuery(ExecutionColumnID, prefix, TextStartsWith)
+}
+
+func NewExecutionTargetSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(ExecutionColumnTargets, value, TextListContains)
+}
+
+// ExecutionTarget is a target resolved for an execution, containing the decrypted signing key
+type ExecutionTarget struct {
+	ExecutionID string
+	// ExecutionCreationDate is the point in time the execution was set
+	ExecutionCreationDate time.Time
+	TargetID              string
+	Endpoint              string
+	Timeout               time.Duration
+	Async                 bool
+	InterruptOnError      bool
+	SigningKey            string
+}
+
+// ExecutionTargetsByIDs returns the targets of the first execution found by the ids,
+// therefore the ids have to be ordered from the most to the least specific (e.g. [domain.ExecutionIDsForMethod])
+func (q *Queries) ExecutionTargetsByIDs(ctx context.Context, ids []string) (_ []*ExecutionTarget, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	idQuery, err := NewExecutionInIDsSearchQuery(ids)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := q.SearchExecutions(ctx, &ExecutionSearchQueries{Queries: []SearchQuery{idQuery}})
+	if err != nil {
+		return nil, err
+	}
+	execution := firstExecutionByIDs(executions.Executions, ids)
+	if execution == nil {
+		return nil, nil
+	}
+
+	targetQuery, err := NewTargetInIDsSearchQuery(execution.Targets)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := q.SearchTargets(ctx, &TargetSearchQueries{Queries: []SearchQuery{targetQuery}})
+	if err != nil {
+		return nil, err
+	}
+	return executionTargets(execution, targets.Targets, q.targetEncryption)
+}
+
+func firstExecutionByIDs(executions []*Execution, ids []string) *Execution {
+	for _, id := range ids {
+		for _, execution := range executions {
+			if execution.ID == id && len(execution.Targets) > 0 {
+				return execution
+			}
+		}
+	}
+	return nil
+}
+
+// executionTargets returns the targets in the order they are set on the execution
+func executionTargets(execution *Execution, targets []*Target, alg crypto.EncryptionAlgorithm) ([]*ExecutionTarget, error) {
+	executionTargets := make([]*ExecutionTarget, 0, len(execution.Targets))
+	for _, targetID := range execution.Targets {
+		for _, target := range targets {
+			if target.ID != targetID {
+				continue
+			}
+			signingKey, err := crypto.DecryptString(target.signingKey, alg)
+			if err != nil {
+				return nil, err
+			}
+			executionTargets = append(executionTargets, &ExecutionTarget{
+				ExecutionID:           execution.ID,
+				ExecutionCreationDate: execution.CreationDate,
+				TargetID:              target.ID,
+				Endpoint:              target.Endpoint,
+				Timeout:               target.Timeout,
+				Async:                 target.Async,
+				InterruptOnError:      target.InterruptOnError,
+				SigningKey:            signingKey,
+			})
+		}
+	}
+	return executionTargets, nil
+}
+
+func prepareExecutionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*Executions, error)) {
+	return sq.Select(
+			ExecutionColumnID.identifier(),
+			ExecutionColumnCreationDate.identifier(),
+			ExecutionColumnChangeDate.identifier(),
+			ExecutionColumnResourceOwner.identifier(),
+			ExecutionColumnSequence.identifier(),
+			ExecutionColumnTargets.identifier(),
+			countColumn.identifier(),
+		).From(executionTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Executions, error) {
+			executions := make([]*Execution, 0)
+			var count uint64
+			for rows.Next() {
+				execution := new(Execution)
+				err := rows.Scan(
+					&execution.ID,
+					&execution.CreationDate,
+					&execution.ChangeDate,
+					&execution.ResourceOwner,
+					&execution.Sequence,
+					&execution.Targets,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				executions = append(executions, execution)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-72xfx", "Errors.Query.CloseRows")
+			}
+
+			return &Executions{
+				Executions: executions,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
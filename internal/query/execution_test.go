@@ -0,0 +1,212 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	prepareExecutionsStmt = `SELECT projections.executions.id,` +
+		` projections.executions.creation_date,` +
+		` projections.executions.change_date,` +
+		` projections.executions.resource_owner,` +
+		` projections.executions.sequence,` +
+		` projections.executions.targets,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.executions` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareExecutionsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"targets",
+		"count",
+	}
+)
+
+func Test_ExecutionPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareExecutionsQuery no result",
+			prepare: prepareExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareExecutionsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &Executions{Executions: []*Execution{}},
+		},
+		{
+			name:    "prepareExecutionsQuery one result",
+			prepare: prepareExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareExecutionsStmt),
+					prepareExecutionsCols,
+					[][]driver.Value{
+						{
+							"request",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							database.StringArray{"target1", "target2"},
+						},
+					},
+				),
+			},
+			object: &Executions{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Executions: []*Execution{
+					{
+						ID:            "request",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211109,
+						Targets:       database.StringArray{"target1", "target2"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareExecutionsQuery sql err",
+			prepare: prepareExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareExecutionsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func Test_firstExecutionByIDs(t *testing.T) {
+	executions := []*Execution{
+		{ID: "request", Targets: database.StringArray{"target1"}},
+		{ID: "request/zitadel.session.v2alpha.SessionService", Targets: database.StringArray{}},
+		{ID: "request/zitadel.session.v2alpha.SessionService/CreateSession", Targets: database.StringArray{"target2"}},
+	}
+	tests := []struct {
+		name string
+		ids  []string
+		want *Execution
+	}{
+		{
+			name: "most specific",
+			ids: []string{
+				"request/zitadel.session.v2alpha.SessionService/CreateSession",
+				"request/zitadel.session.v2alpha.SessionService",
+				"request",
+			},
+			want: executions[2],
+		},
+		{
+			name: "without targets skipped",
+			ids: []string{
+				"request/zitadel.session.v2alpha.SessionService/SetSession",
+				"request/zitadel.session.v2alpha.SessionService",
+				"request",
+			},
+			want: executions[0],
+		},
+		{
+			name: "none",
+			ids: []string{
+				"response",
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, firstExecutionByIDs(executions, tt.ids))
+		})
+	}
+}
+
+func Test_executionTargets(t *testing.T) {
+	execution := &Execution{
+		ID:           "request",
+		CreationDate: testNow,
+		Targets:      database.StringArray{"target2", "target1"},
+	}
+	targets := []*Target{
+		{
+			ID:         "target1",
+			Endpoint:   "https://example.com/1",
+			Timeout:    time.Second,
+			Async:      true,
+			signingKey: testTargetSigningKey,
+		},
+		{
+			ID:               "target2",
+			Endpoint:         "https://example.com/2",
+			Timeout:          time.Minute,
+			InterruptOnError: true,
+			signingKey:       testTargetSigningKey,
+		},
+	}
+	got, err := executionTargets(execution, targets, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	require.NoError(t, err)
+	assert.Equal(t, []*ExecutionTarget{
+		{
+			ExecutionID:           "request",
+			ExecutionCreationDate: testNow,
+			TargetID:              "target2",
+			Endpoint:              "https://example.com/2",
+			Timeout:               time.Minute,
+			InterruptOnError:      true,
+			SigningKey:            "key",
+		},
+		{
+			ExecutionID:           "request",
+			ExecutionCreationDate: testNow,
+			TargetID:              "target1",
+			Endpoint:              "https://example.com/1",
+			Timeout:               time.Second,
+			Async:                 true,
+			SigningKey:            "key",
+		},
+	}, got)
+}
@@ -0,0 +1,253 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	groupTable = table{
+		name:          projection.GroupProjectionTable,
+		instanceIDCol: projection.GroupColumnInstanceID,
+	}
+	GroupColumnID = Column{
+		name:  projection.GroupColumnID,
+		table: groupTable,
+	}
+	GroupColumnCreationDate = Column{
+		name:  projection.GroupColumnCreationDate,
+		table: groupTable,
+	}
+	GroupColumnChangeDate = Column{
+		name:  projection.GroupColumnChangeDate,
+		table: groupTable,
+	}
+	GroupColumnSequence = Column{
+		name:  projection.GroupColumnSequence,
+		table: groupTable,
+	}
+	GroupColumnState = Column{
+		name:  projection.GroupColumnState,
+		table: groupTable,
+	}
+	GroupColumnResourceOwner = Column{
+		name:  projection.GroupColumnResourceOwner,
+		table: groupTable,
+	}
+	GroupColumnInstanceID = Column{
+		name:  projection.GroupColumnInstanceID,
+		table: groupTable,
+	}
+	GroupColumnName = Column{
+		name:  projection.GroupColumnName,
+		table: groupTable,
+	}
+	GroupColumnDescription = Column{
+		name:  projection.GroupColumnDescription,
+		table: groupTable,
+	}
+	GroupColumnOwnerRemoved = Column{
+		name:  projection.GroupColumnOwnerRemoved,
+		table: groupTable,
+	}
+)
+
+type Groups struct {
+	SearchResponse
+	Groups []*Group
+}
+
+type Group struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	State         domain.GroupState
+	ResourceOwner string
+
+	Name        string
+	Description string
+}
+
+type GroupSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) GroupByID(ctx context.Context, shouldTriggerBulk bool, id, resourceOwner string) (_ *Group, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = projection.GroupProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareGroupQuery(ctx, q.client)
+	eq := sq.Eq{
+		GroupColumnID.identifier():           id,
+		GroupColumnInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+		GroupColumnOwnerRemoved.identifier(): false,
+	}
+	if resourceOwner != "" {
+		eq[GroupColumnResourceOwner.identifier()] = resourceOwner
+	}
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ko3pS", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchGroups(ctx context.Context, queries *GroupSearchQueries) (groups *Groups, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupsQuery(ctx, q.client)
+	eq := sq.Eq{
+		GroupColumnInstanceID.identifier():   authz.GetInstance(ctx).InstanceID(),
+		GroupColumnOwnerRemoved.identifier(): false,
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ef4tn", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gb9wa", "Errors.Internal")
+	}
+	groups, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	groups.LatestSequence, err = q.latestSequence(ctx, groupTable)
+	return groups, err
+}
+
+func NewGroupNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnName, value, method)
+}
+
+func NewGroupResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnResourceOwner, value, TextEquals)
+}
+
+func NewGroupIDsSearchQuery(values []string) (SearchQuery, error) {
+	return NewInTextQuery(GroupColumnID, values)
+}
+
+// NewGroupMemberUserIDSearchQuery returns the groups the user is a member of
+func NewGroupMemberUserIDSearchQuery(userID string) (SearchQuery, error) {
+	userIDQuery, err := NewTextQuery(GroupMemberColumnUserID, userID, TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	memberQuery, err := NewSubSelect(GroupMemberColumnGroupID, []SearchQuery{userIDQuery})
+	if err != nil {
+		return nil, err
+	}
+	return NewListQuery(GroupColumnID, memberQuery, ListIn)
+}
+
+func prepareGroupsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*Groups, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnState.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+			countColumn.identifier(),
+		).From(groupTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Groups, error) {
+			groups := make([]*Group, 0)
+			var count uint64
+			for rows.Next() {
+				group := new(Group)
+				err := rows.Scan(
+					&group.ID,
+					&group.CreationDate,
+					&group.ChangeDate,
+					&group.Sequence,
+					&group.State,
+					&group.ResourceOwner,
+					&group.Name,
+					&group.Description,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				groups = append(groups, group)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Pw7xq", "Errors.Query.CloseRows")
+			}
+
+			return &Groups{
+				Groups: groups,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareGroupQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*Group, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnState.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+		).From(groupTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Group, error) {
+			group := new(Group)
+			err := row.Scan(
+				&group.ID,
+				&group.CreationDate,
+				&group.ChangeDate,
+				&group.Sequence,
+				&group.State,
+				&group.ResourceOwner,
+				&group.Name,
+				&group.Description,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Xc2nV", "Errors.Group.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Hs8dj", "Errors.Internal")
+			}
+			return group, nil
+		}
+}
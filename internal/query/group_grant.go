@@ -0,0 +1,265 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	groupGrantTable = table{
+		name:          projection.GroupGrantTable,
+		instanceIDCol: projection.GroupGrantColumnInstanceID,
+	}
+	GroupGrantColumnID = Column{
+		name:  projection.GroupGrantColumnID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnGroupID = Column{
+		name:  projection.GroupGrantColumnGroupID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnCreationDate = Column{
+		name:  projection.GroupGrantColumnCreationDate,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnChangeDate = Column{
+		name:  projection.GroupGrantColumnChangeDate,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnSequence = Column{
+		name:  projection.GroupGrantColumnSequence,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnResourceOwner = Column{
+		name:  projection.GroupGrantColumnResourceOwner,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnInstanceID = Column{
+		name:  projection.GroupGrantColumnInstanceID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnProjectID = Column{
+		name:  projection.GroupGrantColumnProjectID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnGrantID = Column{
+		name:  projection.GroupGrantColumnGrantID,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnRoles = Column{
+		name:  projection.GroupGrantColumnRoles,
+		table: groupGrantTable,
+	}
+)
+
+type GroupGrant struct {
+	// ID represents the id of the grant inside the group
+	ID           string
+	GroupID      string
+	GroupName    string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+	Roles        database.StringArray
+	// GrantID represents the project grant id
+	GrantID string
+
+	ResourceOwner    string
+	OrgName          string
+	OrgPrimaryDomain string
+
+	ProjectID   string
+	ProjectName string
+}
+
+type GroupGrants struct {
+	SearchResponse
+	GroupGrants []*GroupGrant
+}
+
+type GroupGrantSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupGrantSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewGroupGrantGroupIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnGroupID, id, TextEquals)
+}
+
+func NewGroupGrantProjectIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnProjectID, id, TextEquals)
+}
+
+func NewGroupGrantProjectIDsSearchQuery(ids []string) (SearchQuery, error) {
+	return NewInTextQuery(GroupGrantColumnProjectID, ids)
+}
+
+func NewGroupGrantResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnResourceOwner, id, TextEquals)
+}
+
+func NewGroupGrantGrantIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnGrantID, id, TextEquals)
+}
+
+func NewGroupGrantRoleQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnRoles, value, TextListContains)
+}
+
+func (q *Queries) SearchGroupGrants(ctx context.Context, queries *GroupGrantSearchQueries, shouldTriggerBulk bool) (_ *GroupGrants, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = projection.GroupProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareGroupGrantsQuery(ctx, q.client)
+	eq := sq.Eq{
+		GroupGrantColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		GroupColumnOwnerRemoved.identifier():    false,
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Mi1ox", "Errors.Query.InvalidRequest")
+	}
+	return q.queryGroupGrants(ctx, stmt, args, scan)
+}
+
+// GroupGrantsByUserID returns the grants of all groups the user is a member of
+func (q *Queries) GroupGrantsByUserID(ctx context.Context, userID string, queries *GroupGrantSearchQueries, shouldTriggerBulk bool) (_ *GroupGrants, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = projection.GroupProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareGroupGrantsQuery(ctx, q.client)
+	eq := sq.Eq{
+		GroupGrantColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		GroupColumnOwnerRemoved.identifier():    false,
+		GroupMemberColumnUserID.identifier():    userID,
+	}
+	stmt, args, err := queries.toQuery(query.Join(join(GroupMemberColumnGroupID, GroupGrantColumnGroupID))).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Lz5ke", "Errors.Query.InvalidRequest")
+	}
+	return q.queryGroupGrants(ctx, stmt, args, scan)
+}
+
+func (q *Queries) queryGroupGrants(ctx context.Context, stmt string, args []interface{}, scan func(*sql.Rows) (*GroupGrants, error)) (*GroupGrants, error) {
+	latestSequence, err := q.latestSequence(ctx, groupTable)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Wk8gh", "Errors.Internal")
+	}
+	grants, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	grants.LatestSequence = latestSequence
+	return grants, nil
+}
+
+func prepareGroupGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*GroupGrants, error)) {
+	return sq.Select(
+			GroupGrantColumnID.identifier(),
+			GroupGrantColumnGroupID.identifier(),
+			GroupColumnName.identifier(),
+			GroupGrantColumnCreationDate.identifier(),
+			GroupGrantColumnChangeDate.identifier(),
+			GroupGrantColumnSequence.identifier(),
+			GroupGrantColumnGrantID.identifier(),
+			GroupGrantColumnRoles.identifier(),
+
+			GroupGrantColumnResourceOwner.identifier(),
+			OrgColumnName.identifier(),
+			OrgColumnDomain.identifier(),
+
+			GroupGrantColumnProjectID.identifier(),
+			ProjectColumnName.identifier(),
+
+			countColumn.identifier(),
+		).
+			From(groupGrantTable.identifier()).
+			Join(join(GroupColumnID, GroupGrantColumnGroupID)).
+			LeftJoin(join(OrgColumnID, GroupGrantColumnResourceOwner)).
+			LeftJoin(join(ProjectColumnID, GroupGrantColumnProjectID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupGrants, error) {
+			grants := make([]*GroupGrant, 0)
+			var count uint64
+			for rows.Next() {
+				g := new(GroupGrant)
+
+				var (
+					orgName     sql.NullString
+					orgDomain   sql.NullString
+					projectName sql.NullString
+				)
+
+				err := rows.Scan(
+					&g.ID,
+					&g.GroupID,
+					&g.GroupName,
+					&g.CreationDate,
+					&g.ChangeDate,
+					&g.Sequence,
+					&g.GrantID,
+					&g.Roles,
+
+					&g.ResourceOwner,
+					&orgName,
+					&orgDomain,
+
+					&g.ProjectID,
+					&projectName,
+
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				g.OrgName = orgName.String
+				g.OrgPrimaryDomain = orgDomain.String
+				g.ProjectName = projectName.String
+
+				grants = append(grants, g)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Cv3nf", "Errors.Query.CloseRows")
+			}
+
+			return &GroupGrants{
+				GroupGrants: grants,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
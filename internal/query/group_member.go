@@ -0,0 +1,183 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	groupMemberTable = table{
+		name:          projection.GroupMemberTable,
+		alias:         "members",
+		instanceIDCol: projection.GroupMemberColumnInstanceID,
+	}
+	GroupMemberColumnGroupID = Column{
+		name:  projection.GroupMemberColumnGroupID,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnUserID = Column{
+		name:  projection.GroupMemberColumnUserID,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnCreationDate = Column{
+		name:  projection.GroupMemberColumnCreationDate,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnChangeDate = Column{
+		name:  projection.GroupMemberColumnChangeDate,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnSequence = Column{
+		name:  projection.GroupMemberColumnSequence,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnInstanceID = Column{
+		name:  projection.GroupMemberColumnInstanceID,
+		table: groupMemberTable,
+	}
+)
+
+type GroupMembersQuery struct {
+	MembersQuery
+	GroupID string
+}
+
+func (q *GroupMembersQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return q.MembersQuery.
+		toQuery(query).
+		Where(sq.Eq{GroupMemberColumnGroupID.identifier(): q.GroupID})
+}
+
+// GroupMembers returns the users of a group,
+// the resource owner of a member is the organisation of the user
+func (q *Queries) GroupMembers(ctx context.Context, queries *GroupMembersQuery) (_ *Members, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareGroupMembersQuery(ctx, q.client)
+	eq := sq.Eq{GroupMemberColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	addLoginNameWithoutOwnerRemoved(eq)
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Rt2mc", "Errors.Query.InvalidRequest")
+	}
+
+	currentSequence, err := q.latestSequence(ctx, groupTable)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ug6bv", "Errors.Internal")
+	}
+	members, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	members.LatestSequence = currentSequence
+	return members, err
+}
+
+func prepareGroupMembersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*Members, error)) {
+	return sq.Select(
+			GroupMemberColumnCreationDate.identifier(),
+			GroupMemberColumnChangeDate.identifier(),
+			GroupMemberColumnSequence.identifier(),
+			UserResourceOwnerCol.identifier(),
+			GroupMemberColumnUserID.identifier(),
+			LoginNameNameCol.identifier(),
+			HumanEmailCol.identifier(),
+			HumanFirstNameCol.identifier(),
+			HumanLastNameCol.identifier(),
+			HumanDisplayNameCol.identifier(),
+			MachineNameCol.identifier(),
+			HumanAvatarURLCol.identifier(),
+			UserTypeCol.identifier(),
+			countColumn.identifier(),
+		).From(groupMemberTable.identifier()).
+			LeftJoin(join(HumanUserIDCol, GroupMemberColumnUserID)).
+			LeftJoin(join(MachineUserIDCol, GroupMemberColumnUserID)).
+			LeftJoin(join(UserIDCol, GroupMemberColumnUserID)).
+			LeftJoin(join(LoginNameUserIDCol, GroupMemberColumnUserID) + db.Timetravel(call.Took(ctx))).
+			Where(
+				sq.Eq{LoginNameIsPrimaryCol.identifier(): true},
+			).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Members, error) {
+			members := make([]*Member, 0)
+			var count uint64
+
+			for rows.Next() {
+				member := new(Member)
+
+				var (
+					resourceOwner      = sql.NullString{}
+					preferredLoginName = sql.NullString{}
+					email              = sql.NullString{}
+					firstName          = sql.NullString{}
+					lastName           = sql.NullString{}
+					displayName        = sql.NullString{}
+					machineName        = sql.NullString{}
+					avatarURL          = sql.NullString{}
+					userType           = sql.NullInt32{}
+				)
+
+				err := rows.Scan(
+					&member.CreationDate,
+					&member.ChangeDate,
+					&member.Sequence,
+					&resourceOwner,
+					&member.UserID,
+					&preferredLoginName,
+					&email,
+					&firstName,
+					&lastName,
+					&displayName,
+					&machineName,
+					&avatarURL,
+					&userType,
+
+					&count,
+				)
+
+				if err != nil {
+					return nil, err
+				}
+
+				member.ResourceOwner = resourceOwner.String
+				member.PreferredLoginName = preferredLoginName.String
+				member.Email = email.String
+				member.FirstName = firstName.String
+				member.LastName = lastName.String
+				member.AvatarURL = avatarURL.String
+				if displayName.Valid {
+					member.DisplayName = displayName.String
+				} else {
+					member.DisplayName = machineName.String
+				}
+				member.UserType = domain.UserType(userType.Int32)
+
+				members = append(members, member)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Oy4hd", "Errors.Query.CloseRows")
+			}
+
+			return &Members{
+				Members: members,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
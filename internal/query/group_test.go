@@ -0,0 +1,395 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareGroupsStmt = `SELECT projections.groups.id,` +
+		` projections.groups.creation_date,` +
+		` projections.groups.change_date,` +
+		` projections.groups.sequence,` +
+		` projections.groups.state,` +
+		` projections.groups.resource_owner,` +
+		` projections.groups.name,` +
+		` projections.groups.description,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.groups` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareGroupsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"state",
+		"resource_owner",
+		"name",
+		"description",
+		"count",
+	}
+
+	prepareGroupStmt = `SELECT projections.groups.id,` +
+		` projections.groups.creation_date,` +
+		` projections.groups.change_date,` +
+		` projections.groups.sequence,` +
+		` projections.groups.state,` +
+		` projections.groups.resource_owner,` +
+		` projections.groups.name,` +
+		` projections.groups.description` +
+		` FROM projections.groups` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareGroupCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"state",
+		"resource_owner",
+		"name",
+		"description",
+	}
+
+	prepareGroupMembersStmt = `SELECT members.creation_date,` +
+		` members.change_date,` +
+		` members.sequence,` +
+		` projections.users9.resource_owner,` +
+		` members.user_id,` +
+		` projections.login_names2.login_name,` +
+		` projections.users9_humans.email,` +
+		` projections.users9_humans.first_name,` +
+		` projections.users9_humans.last_name,` +
+		` projections.users9_humans.display_name,` +
+		` projections.users9_machines.name,` +
+		` projections.users9_humans.avatar_key,` +
+		` projections.users9.type,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.groups_members AS members` +
+		` LEFT JOIN projections.users9_humans ON members.user_id = projections.users9_humans.user_id AND members.instance_id = projections.users9_humans.instance_id` +
+		` LEFT JOIN projections.users9_machines ON members.user_id = projections.users9_machines.user_id AND members.instance_id = projections.users9_machines.instance_id` +
+		` LEFT JOIN projections.users9 ON members.user_id = projections.users9.id AND members.instance_id = projections.users9.instance_id` +
+		` LEFT JOIN projections.login_names2 ON members.user_id = projections.login_names2.user_id AND members.instance_id = projections.login_names2.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'` +
+		` WHERE projections.login_names2.is_primary = $1`
+	prepareGroupMembersCols = []string{
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"user_id",
+		"login_name",
+		"email",
+		"first_name",
+		"last_name",
+		"display_name",
+		"name",
+		"avatar_key",
+		"type",
+		"count",
+	}
+
+	prepareGroupGrantsStmt = `SELECT projections.groups_grants.id,` +
+		` projections.groups_grants.group_id,` +
+		` projections.groups.name,` +
+		` projections.groups_grants.creation_date,` +
+		` projections.groups_grants.change_date,` +
+		` projections.groups_grants.sequence,` +
+		` projections.groups_grants.grant_id,` +
+		` projections.groups_grants.roles,` +
+		` projections.groups_grants.resource_owner,` +
+		` projections.orgs.name,` +
+		` projections.orgs.primary_domain,` +
+		` projections.groups_grants.project_id,` +
+		` projections.projects3.name,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.groups_grants` +
+		` JOIN projections.groups ON projections.groups_grants.group_id = projections.groups.id AND projections.groups_grants.instance_id = projections.groups.instance_id` +
+		` LEFT JOIN projections.orgs ON projections.groups_grants.resource_owner = projections.orgs.id AND projections.groups_grants.instance_id = projections.orgs.instance_id` +
+		` LEFT JOIN projections.projects3 ON projections.groups_grants.project_id = projections.projects3.id AND projections.groups_grants.instance_id = projections.projects3.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareGroupGrantsCols = []string{
+		"id",
+		"group_id",
+		"name",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"grant_id",
+		"roles",
+		"resource_owner",
+		"name",
+		"primary_domain",
+		"project_id",
+		"name",
+		"count",
+	}
+)
+
+func Test_GroupPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareGroupsQuery no result",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareGroupsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &Groups{Groups: []*Group{}},
+		},
+		{
+			name:    "prepareGroupsQuery one result",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareGroupsStmt),
+					prepareGroupsCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							uint64(20211109),
+							domain.GroupStateActive,
+							"ro",
+							"group-name",
+							"description",
+						},
+					},
+				),
+			},
+			object: &Groups{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Groups: []*Group{
+					{
+						ID:            "id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211109,
+						State:         domain.GroupStateActive,
+						ResourceOwner: "ro",
+						Name:          "group-name",
+						Description:   "description",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupsQuery sql err",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareGroupsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareGroupQuery no result",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareGroupStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Group)(nil),
+		},
+		{
+			name:    "prepareGroupQuery found",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareGroupStmt),
+					prepareGroupCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.GroupStateActive,
+						"ro",
+						"group-name",
+						"description",
+					},
+				),
+			},
+			object: &Group{
+				ID:            "id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				State:         domain.GroupStateActive,
+				ResourceOwner: "ro",
+				Name:          "group-name",
+				Description:   "description",
+			},
+		},
+		{
+			name:    "prepareGroupMembersQuery one result",
+			prepare: prepareGroupMembersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareGroupMembersStmt),
+					prepareGroupMembersCols,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							uint64(20211206),
+							"ro",
+							"user-id",
+							"gigi@caos-ag.zitadel.ch",
+							"gigi@caos-ch",
+							"first-name",
+							"last-name",
+							"display name",
+							nil,
+							nil,
+							domain.UserTypeHuman,
+						},
+					},
+				),
+			},
+			object: &Members{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Members: []*Member{
+					{
+						CreationDate:       testNow,
+						ChangeDate:         testNow,
+						Sequence:           20211206,
+						ResourceOwner:      "ro",
+						UserID:             "user-id",
+						PreferredLoginName: "gigi@caos-ag.zitadel.ch",
+						Email:              "gigi@caos-ch",
+						FirstName:          "first-name",
+						LastName:           "last-name",
+						DisplayName:        "display name",
+						AvatarURL:          "",
+						UserType:           domain.UserTypeHuman,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupGrantsQuery one result",
+			prepare: prepareGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareGroupGrantsStmt),
+					prepareGroupGrantsCols,
+					[][]driver.Value{
+						{
+							"id",
+							"group-id",
+							"group-name",
+							testNow,
+							testNow,
+							uint64(20211111),
+							"",
+							database.StringArray{"role-key"},
+							"ro",
+							"org-name",
+							"primary.domain",
+							"project-id",
+							"project-name",
+						},
+					},
+				),
+			},
+			object: &GroupGrants{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				GroupGrants: []*GroupGrant{
+					{
+						ID:               "id",
+						GroupID:          "group-id",
+						GroupName:        "group-name",
+						CreationDate:     testNow,
+						ChangeDate:       testNow,
+						Sequence:         20211111,
+						Roles:            database.StringArray{"role-key"},
+						ResourceOwner:    "ro",
+						OrgName:          "org-name",
+						OrgPrimaryDomain: "primary.domain",
+						ProjectID:        "project-id",
+						ProjectName:      "project-name",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupGrantsQuery sql err",
+			prepare: prepareGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareGroupGrantsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func TestNewGroupMemberUserIDSearchQuery(t *testing.T) {
+	q, err := NewGroupMemberUserIDSearchQuery("user1")
+	require.NoError(t, err)
+	stmt, args, err := q.toQuery(sq.Select(GroupColumnID.identifier()).From(groupTable.identifier())).ToSql()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT projections.groups.id FROM projections.groups WHERE projections.groups.id IN ( SELECT members.group_id FROM projections.groups_members AS members WHERE members.user_id = ? )", stmt)
+	assert.Equal(t, []interface{}{"user1"}, args)
+}
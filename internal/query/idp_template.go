@@ -42,6 +42,8 @@ type IDPTemplate struct {
 	*GitLabIDPTemplate
 	*GitLabSelfHostedIDPTemplate
 	*GoogleIDPTemplate
+	*SAMLIDPTemplate
+	*AppleIDPTemplate
 	*LDAPIDPTemplate
 }
 
@@ -126,6 +128,24 @@ type GoogleIDPTemplate struct {
 	Scopes       database.StringArray
 }
 
+type SAMLIDPTemplate struct {
+	IDPID             string
+	Metadata          []byte
+	Key               *crypto.CryptoValue
+	Certificate       []byte
+	Binding           string
+	WithSignedRequest bool
+}
+
+type AppleIDPTemplate struct {
+	IDPID      string
+	ClientID   string
+	TeamID     string
+	KeyID      string
+	PrivateKey *crypto.CryptoValue
+	Scopes     database.StringArray
+}
+
 type LDAPIDPTemplate struct {
 	IDPID             string
 	Servers           []string
@@ -502,6 +522,76 @@ var (
 	}
 )
 
+var (
+	samlIdpTemplateTable = table{
+		name:          projection.IDPTemplateSAMLTable,
+		instanceIDCol: projection.IDPTemplateInstanceIDCol,
+	}
+	SAMLIDCol = Column{
+		name:  projection.SAMLIDCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLInstanceIDCol = Column{
+		name:  projection.SAMLInstanceIDCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLMetadataCol = Column{
+		name:  projection.SAMLMetadataCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLKeyCol = Column{
+		name:  projection.SAMLKeyCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLCertificateCol = Column{
+		name:  projection.SAMLCertificateCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLBindingCol = Column{
+		name:  projection.SAMLBindingCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLWithSignedRequestCol = Column{
+		name:  projection.SAMLWithSignedRequestCol,
+		table: samlIdpTemplateTable,
+	}
+)
+
+var (
+	appleIdpTemplateTable = table{
+		name:          projection.IDPTemplateAppleTable,
+		instanceIDCol: projection.AppleInstanceIDCol,
+	}
+	AppleIDCol = Column{
+		name:  projection.AppleIDCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleInstanceIDCol = Column{
+		name:  projection.AppleInstanceIDCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleClientIDCol = Column{
+		name:  projection.AppleClientIDCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleTeamIDCol = Column{
+		name:  projection.AppleTeamIDCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleKeyIDCol = Column{
+		name:  projection.AppleKeyIDCol,
+		table: appleIdpTemplateTable,
+	}
+	ApplePrivateKeyCol = Column{
+		name:  projection.ApplePrivateKeyCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleScopesCol = Column{
+		name:  projection.AppleScopesCol,
+		table: appleIdpTemplateTable,
+	}
+)
+
 var (
 	ldapIdpTemplateTable = table{
 		name:          projection.IDPTemplateLDAPTable,
@@ -773,6 +863,20 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			GoogleClientIDCol.identifier(),
 			GoogleClientSecretCol.identifier(),
 			GoogleScopesCol.identifier(),
+			// saml
+			SAMLIDCol.identifier(),
+			SAMLMetadataCol.identifier(),
+			SAMLKeyCol.identifier(),
+			SAMLCertificateCol.identifier(),
+			SAMLBindingCol.identifier(),
+			SAMLWithSignedRequestCol.identifier(),
+			// apple
+			AppleIDCol.identifier(),
+			AppleClientIDCol.identifier(),
+			AppleTeamIDCol.identifier(),
+			AppleKeyIDCol.identifier(),
+			ApplePrivateKeyCol.identifier(),
+			AppleScopesCol.identifier(),
 			// ldap
 			LDAPIDCol.identifier(),
 			LDAPServersCol.identifier(),
@@ -807,6 +911,8 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			LeftJoin(join(GitLabIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GitLabSelfHostedIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(AppleIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*IDPTemplate, error) {
@@ -872,6 +978,20 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			googleClientSecret := new(crypto.CryptoValue)
 			googleScopes := database.StringArray{}
 
+			samlID := sql.NullString{}
+			var samlMetadata []byte
+			samlKey := new(crypto.CryptoValue)
+			var samlCertificate []byte
+			samlBinding := sql.NullString{}
+			samlWithSignedRequest := sql.NullBool{}
+
+			appleID := sql.NullString{}
+			appleClientID := sql.NullString{}
+			appleTeamID := sql.NullString{}
+			appleKeyID := sql.NullString{}
+			applePrivateKey := new(crypto.CryptoValue)
+			appleScopes := database.StringArray{}
+
 			ldapID := sql.NullString{}
 			ldapServers := database.StringArray{}
 			ldapStartTls := sql.NullBool{}
@@ -968,6 +1088,20 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 				&googleClientID,
 				&googleClientSecret,
 				&googleScopes,
+				// saml
+				&samlID,
+				&samlMetadata,
+				&samlKey,
+				&samlCertificate,
+				&samlBinding,
+				&samlWithSignedRequest,
+				// apple
+				&appleID,
+				&appleClientID,
+				&appleTeamID,
+				&appleKeyID,
+				&applePrivateKey,
+				&appleScopes,
 				// ldap
 				&ldapID,
 				&ldapServers,
@@ -1087,6 +1221,26 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 					Scopes:       googleScopes,
 				}
 			}
+			if samlID.Valid {
+				idpTemplate.SAMLIDPTemplate = &SAMLIDPTemplate{
+					IDPID:             samlID.String,
+					Metadata:          samlMetadata,
+					Key:               samlKey,
+					Certificate:       samlCertificate,
+					Binding:           samlBinding.String,
+					WithSignedRequest: samlWithSignedRequest.Bool,
+				}
+			}
+			if appleID.Valid {
+				idpTemplate.AppleIDPTemplate = &AppleIDPTemplate{
+					IDPID:      appleID.String,
+					ClientID:   appleClientID.String,
+					TeamID:     appleTeamID.String,
+					KeyID:      appleKeyID.String,
+					PrivateKey: applePrivateKey,
+					Scopes:     appleScopes,
+				}
+			}
 			if ldapID.Valid {
 				idpTemplate.LDAPIDPTemplate = &LDAPIDPTemplate{
 					IDPID:             ldapID.String,
@@ -1194,6 +1348,20 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			GoogleClientIDCol.identifier(),
 			GoogleClientSecretCol.identifier(),
 			GoogleScopesCol.identifier(),
+			// saml
+			SAMLIDCol.identifier(),
+			SAMLMetadataCol.identifier(),
+			SAMLKeyCol.identifier(),
+			SAMLCertificateCol.identifier(),
+			SAMLBindingCol.identifier(),
+			SAMLWithSignedRequestCol.identifier(),
+			// apple
+			AppleIDCol.identifier(),
+			AppleClientIDCol.identifier(),
+			AppleTeamIDCol.identifier(),
+			AppleKeyIDCol.identifier(),
+			ApplePrivateKeyCol.identifier(),
+			AppleScopesCol.identifier(),
 			// ldap
 			LDAPIDCol.identifier(),
 			LDAPServersCol.identifier(),
@@ -1229,6 +1397,8 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			LeftJoin(join(GitLabIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GitLabSelfHostedIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(AppleIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*IDPTemplates, error) {
@@ -1297,6 +1467,20 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				googleClientSecret := new(crypto.CryptoValue)
 				googleScopes := database.StringArray{}
 
+				samlID := sql.NullString{}
+				var samlMetadata []byte
+				samlKey := new(crypto.CryptoValue)
+				var samlCertificate []byte
+				samlBinding := sql.NullString{}
+				samlWithSignedRequest := sql.NullBool{}
+
+				appleID := sql.NullString{}
+				appleClientID := sql.NullString{}
+				appleTeamID := sql.NullString{}
+				appleKeyID := sql.NullString{}
+				applePrivateKey := new(crypto.CryptoValue)
+				appleScopes := database.StringArray{}
+
 				ldapID := sql.NullString{}
 				ldapServers := database.StringArray{}
 				ldapStartTls := sql.NullBool{}
@@ -1393,6 +1577,20 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 					&googleClientID,
 					&googleClientSecret,
 					&googleScopes,
+					// saml
+					&samlID,
+					&samlMetadata,
+					&samlKey,
+					&samlCertificate,
+					&samlBinding,
+					&samlWithSignedRequest,
+					// apple
+					&appleID,
+					&appleClientID,
+					&appleTeamID,
+					&appleKeyID,
+					&applePrivateKey,
+					&appleScopes,
 					// ldap
 					&ldapID,
 					&ldapServers,
@@ -1511,6 +1709,26 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 						Scopes:       googleScopes,
 					}
 				}
+				if samlID.Valid {
+					idpTemplate.SAMLIDPTemplate = &SAMLIDPTemplate{
+						IDPID:             samlID.String,
+						Metadata:          samlMetadata,
+						Key:               samlKey,
+						Certificate:       samlCertificate,
+						Binding:           samlBinding.String,
+						WithSignedRequest: samlWithSignedRequest.Bool,
+					}
+				}
+				if appleID.Valid {
+					idpTemplate.AppleIDPTemplate = &AppleIDPTemplate{
+						IDPID:      appleID.String,
+						ClientID:   appleClientID.String,
+						TeamID:     appleTeamID.String,
+						KeyID:      appleKeyID.String,
+						PrivateKey: applePrivateKey,
+						Scopes:     appleScopes,
+					}
+				}
 				if ldapID.Valid {
 					idpTemplate.LDAPIDPTemplate = &LDAPIDPTemplate{
 						IDPID:             ldapID.String,
@@ -16,112 +16,128 @@ import (
 )
 
 var (
-	idpTemplateQuery = `SELECT projections.idp_templates5.id,` +
-		` projections.idp_templates5.resource_owner,` +
-		` projections.idp_templates5.creation_date,` +
-		` projections.idp_templates5.change_date,` +
-		` projections.idp_templates5.sequence,` +
-		` projections.idp_templates5.state,` +
-		` projections.idp_templates5.name,` +
-		` projections.idp_templates5.type,` +
-		` projections.idp_templates5.owner_type,` +
-		` projections.idp_templates5.is_creation_allowed,` +
-		` projections.idp_templates5.is_linking_allowed,` +
-		` projections.idp_templates5.is_auto_creation,` +
-		` projections.idp_templates5.is_auto_update,` +
+	idpTemplateQuery = `SELECT projections.idp_templates7.id,` +
+		` projections.idp_templates7.resource_owner,` +
+		` projections.idp_templates7.creation_date,` +
+		` projections.idp_templates7.change_date,` +
+		` projections.idp_templates7.sequence,` +
+		` projections.idp_templates7.state,` +
+		` projections.idp_templates7.name,` +
+		` projections.idp_templates7.type,` +
+		` projections.idp_templates7.owner_type,` +
+		` projections.idp_templates7.is_creation_allowed,` +
+		` projections.idp_templates7.is_linking_allowed,` +
+		` projections.idp_templates7.is_auto_creation,` +
+		` projections.idp_templates7.is_auto_update,` +
 		// oauth
-		` projections.idp_templates5_oauth2.idp_id,` +
-		` projections.idp_templates5_oauth2.client_id,` +
-		` projections.idp_templates5_oauth2.client_secret,` +
-		` projections.idp_templates5_oauth2.authorization_endpoint,` +
-		` projections.idp_templates5_oauth2.token_endpoint,` +
-		` projections.idp_templates5_oauth2.user_endpoint,` +
-		` projections.idp_templates5_oauth2.scopes,` +
-		` projections.idp_templates5_oauth2.id_attribute,` +
+		` projections.idp_templates7_oauth2.idp_id,` +
+		` projections.idp_templates7_oauth2.client_id,` +
+		` projections.idp_templates7_oauth2.client_secret,` +
+		` projections.idp_templates7_oauth2.authorization_endpoint,` +
+		` projections.idp_templates7_oauth2.token_endpoint,` +
+		` projections.idp_templates7_oauth2.user_endpoint,` +
+		` projections.idp_templates7_oauth2.scopes,` +
+		` projections.idp_templates7_oauth2.id_attribute,` +
 		// oidc
-		` projections.idp_templates5_oidc.idp_id,` +
-		` projections.idp_templates5_oidc.issuer,` +
-		` projections.idp_templates5_oidc.client_id,` +
-		` projections.idp_templates5_oidc.client_secret,` +
-		` projections.idp_templates5_oidc.scopes,` +
-		` projections.idp_templates5_oidc.id_token_mapping,` +
+		` projections.idp_templates7_oidc.idp_id,` +
+		` projections.idp_templates7_oidc.issuer,` +
+		` projections.idp_templates7_oidc.client_id,` +
+		` projections.idp_templates7_oidc.client_secret,` +
+		` projections.idp_templates7_oidc.scopes,` +
+		` projections.idp_templates7_oidc.id_token_mapping,` +
 		// jwt
-		` projections.idp_templates5_jwt.idp_id,` +
-		` projections.idp_templates5_jwt.issuer,` +
-		` projections.idp_templates5_jwt.jwt_endpoint,` +
-		` projections.idp_templates5_jwt.keys_endpoint,` +
-		` projections.idp_templates5_jwt.header_name,` +
+		` projections.idp_templates7_jwt.idp_id,` +
+		` projections.idp_templates7_jwt.issuer,` +
+		` projections.idp_templates7_jwt.jwt_endpoint,` +
+		` projections.idp_templates7_jwt.keys_endpoint,` +
+		` projections.idp_templates7_jwt.header_name,` +
 		// azure
-		` projections.idp_templates5_azure.idp_id,` +
-		` projections.idp_templates5_azure.client_id,` +
-		` projections.idp_templates5_azure.client_secret,` +
-		` projections.idp_templates5_azure.scopes,` +
-		` projections.idp_templates5_azure.tenant,` +
-		` projections.idp_templates5_azure.is_email_verified,` +
+		` projections.idp_templates7_azure.idp_id,` +
+		` projections.idp_templates7_azure.client_id,` +
+		` projections.idp_templates7_azure.client_secret,` +
+		` projections.idp_templates7_azure.scopes,` +
+		` projections.idp_templates7_azure.tenant,` +
+		` projections.idp_templates7_azure.is_email_verified,` +
 		// github
-		` projections.idp_templates5_github.idp_id,` +
-		` projections.idp_templates5_github.client_id,` +
-		` projections.idp_templates5_github.client_secret,` +
-		` projections.idp_templates5_github.scopes,` +
+		` projections.idp_templates7_github.idp_id,` +
+		` projections.idp_templates7_github.client_id,` +
+		` projections.idp_templates7_github.client_secret,` +
+		` projections.idp_templates7_github.scopes,` +
 		// github enterprise
-		` projections.idp_templates5_github_enterprise.idp_id,` +
-		` projections.idp_templates5_github_enterprise.client_id,` +
-		` projections.idp_templates5_github_enterprise.client_secret,` +
-		` projections.idp_templates5_github_enterprise.authorization_endpoint,` +
-		` projections.idp_templates5_github_enterprise.token_endpoint,` +
-		` projections.idp_templates5_github_enterprise.user_endpoint,` +
-		` projections.idp_templates5_github_enterprise.scopes,` +
+		` projections.idp_templates7_github_enterprise.idp_id,` +
+		` projections.idp_templates7_github_enterprise.client_id,` +
+		` projections.idp_templates7_github_enterprise.client_secret,` +
+		` projections.idp_templates7_github_enterprise.authorization_endpoint,` +
+		` projections.idp_templates7_github_enterprise.token_endpoint,` +
+		` projections.idp_templates7_github_enterprise.user_endpoint,` +
+		` projections.idp_templates7_github_enterprise.scopes,` +
 		// gitlab
-		` projections.idp_templates5_gitlab.idp_id,` +
-		` projections.idp_templates5_gitlab.client_id,` +
-		` projections.idp_templates5_gitlab.client_secret,` +
-		` projections.idp_templates5_gitlab.scopes,` +
+		` projections.idp_templates7_gitlab.idp_id,` +
+		` projections.idp_templates7_gitlab.client_id,` +
+		` projections.idp_templates7_gitlab.client_secret,` +
+		` projections.idp_templates7_gitlab.scopes,` +
 		// gitlab self hosted
-		` projections.idp_templates5_gitlab_self_hosted.idp_id,` +
-		` projections.idp_templates5_gitlab_self_hosted.issuer,` +
-		` projections.idp_templates5_gitlab_self_hosted.client_id,` +
-		` projections.idp_templates5_gitlab_self_hosted.client_secret,` +
-		` projections.idp_templates5_gitlab_self_hosted.scopes,` +
+		` projections.idp_templates7_gitlab_self_hosted.idp_id,` +
+		` projections.idp_templates7_gitlab_self_hosted.issuer,` +
+		` projections.idp_templates7_gitlab_self_hosted.client_id,` +
+		` projections.idp_templates7_gitlab_self_hosted.client_secret,` +
+		` projections.idp_templates7_gitlab_self_hosted.scopes,` +
 		// google
-		` projections.idp_templates5_google.idp_id,` +
-		` projections.idp_templates5_google.client_id,` +
-		` projections.idp_templates5_google.client_secret,` +
-		` projections.idp_templates5_google.scopes,` +
+		` projections.idp_templates7_google.idp_id,` +
+		` projections.idp_templates7_google.client_id,` +
+		` projections.idp_templates7_google.client_secret,` +
+		` projections.idp_templates7_google.scopes,` +
+		// saml
+		` projections.idp_templates7_saml.idp_id,` +
+		` projections.idp_templates7_saml.metadata,` +
+		` projections.idp_templates7_saml.key,` +
+		` projections.idp_templates7_saml.certificate,` +
+		` projections.idp_templates7_saml.binding,` +
+		` projections.idp_templates7_saml.with_signed_request,` +
+		// apple
+		` projections.idp_templates7_apple.idp_id,` +
+		` projections.idp_templates7_apple.client_id,` +
+		` projections.idp_templates7_apple.team_id,` +
+		` projections.idp_templates7_apple.key_id,` +
+		` projections.idp_templates7_apple.private_key,` +
+		` projections.idp_templates7_apple.scopes,` +
 		// ldap
-		` projections.idp_templates5_ldap2.idp_id,` +
-		` projections.idp_templates5_ldap2.servers,` +
-		` projections.idp_templates5_ldap2.start_tls,` +
-		` projections.idp_templates5_ldap2.base_dn,` +
-		` projections.idp_templates5_ldap2.bind_dn,` +
-		` projections.idp_templates5_ldap2.bind_password,` +
-		` projections.idp_templates5_ldap2.user_base,` +
-		` projections.idp_templates5_ldap2.user_object_classes,` +
-		` projections.idp_templates5_ldap2.user_filters,` +
-		` projections.idp_templates5_ldap2.timeout,` +
-		` projections.idp_templates5_ldap2.id_attribute,` +
-		` projections.idp_templates5_ldap2.first_name_attribute,` +
-		` projections.idp_templates5_ldap2.last_name_attribute,` +
-		` projections.idp_templates5_ldap2.display_name_attribute,` +
-		` projections.idp_templates5_ldap2.nick_name_attribute,` +
-		` projections.idp_templates5_ldap2.preferred_username_attribute,` +
-		` projections.idp_templates5_ldap2.email_attribute,` +
-		` projections.idp_templates5_ldap2.email_verified,` +
-		` projections.idp_templates5_ldap2.phone_attribute,` +
-		` projections.idp_templates5_ldap2.phone_verified_attribute,` +
-		` projections.idp_templates5_ldap2.preferred_language_attribute,` +
-		` projections.idp_templates5_ldap2.avatar_url_attribute,` +
-		` projections.idp_templates5_ldap2.profile_attribute` +
-		` FROM projections.idp_templates5` +
-		` LEFT JOIN projections.idp_templates5_oauth2 ON projections.idp_templates5.id = projections.idp_templates5_oauth2.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_oauth2.instance_id` +
-		` LEFT JOIN projections.idp_templates5_oidc ON projections.idp_templates5.id = projections.idp_templates5_oidc.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_oidc.instance_id` +
-		` LEFT JOIN projections.idp_templates5_jwt ON projections.idp_templates5.id = projections.idp_templates5_jwt.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_jwt.instance_id` +
-		` LEFT JOIN projections.idp_templates5_azure ON projections.idp_templates5.id = projections.idp_templates5_azure.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_azure.instance_id` +
-		` LEFT JOIN projections.idp_templates5_github ON projections.idp_templates5.id = projections.idp_templates5_github.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_github.instance_id` +
-		` LEFT JOIN projections.idp_templates5_github_enterprise ON projections.idp_templates5.id = projections.idp_templates5_github_enterprise.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_github_enterprise.instance_id` +
-		` LEFT JOIN projections.idp_templates5_gitlab ON projections.idp_templates5.id = projections.idp_templates5_gitlab.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_gitlab.instance_id` +
-		` LEFT JOIN projections.idp_templates5_gitlab_self_hosted ON projections.idp_templates5.id = projections.idp_templates5_gitlab_self_hosted.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_gitlab_self_hosted.instance_id` +
-		` LEFT JOIN projections.idp_templates5_google ON projections.idp_templates5.id = projections.idp_templates5_google.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_google.instance_id` +
-		` LEFT JOIN projections.idp_templates5_ldap2 ON projections.idp_templates5.id = projections.idp_templates5_ldap2.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_ldap2.instance_id` +
+		` projections.idp_templates7_ldap2.idp_id,` +
+		` projections.idp_templates7_ldap2.servers,` +
+		` projections.idp_templates7_ldap2.start_tls,` +
+		` projections.idp_templates7_ldap2.base_dn,` +
+		` projections.idp_templates7_ldap2.bind_dn,` +
+		` projections.idp_templates7_ldap2.bind_password,` +
+		` projections.idp_templates7_ldap2.user_base,` +
+		` projections.idp_templates7_ldap2.user_object_classes,` +
+		` projections.idp_templates7_ldap2.user_filters,` +
+		` projections.idp_templates7_ldap2.timeout,` +
+		` projections.idp_templates7_ldap2.id_attribute,` +
+		` projections.idp_templates7_ldap2.first_name_attribute,` +
+		` projections.idp_templates7_ldap2.last_name_attribute,` +
+		` projections.idp_templates7_ldap2.display_name_attribute,` +
+		` projections.idp_templates7_ldap2.nick_name_attribute,` +
+		` projections.idp_templates7_ldap2.preferred_username_attribute,` +
+		` projections.idp_templates7_ldap2.email_attribute,` +
+		` projections.idp_templates7_ldap2.email_verified,` +
+		` projections.idp_templates7_ldap2.phone_attribute,` +
+		` projections.idp_templates7_ldap2.phone_verified_attribute,` +
+		` projections.idp_templates7_ldap2.preferred_language_attribute,` +
+		` projections.idp_templates7_ldap2.avatar_url_attribute,` +
+		` projections.idp_templates7_ldap2.profile_attribute` +
+		` FROM projections.idp_templates7` +
+		` LEFT JOIN projections.idp_templates7_oauth2 ON projections.idp_templates7.id = projections.idp_templates7_oauth2.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_oauth2.instance_id` +
+		` LEFT JOIN projections.idp_templates7_oidc ON projections.idp_templates7.id = projections.idp_templates7_oidc.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_oidc.instance_id` +
+		` LEFT JOIN projections.idp_templates7_jwt ON projections.idp_templates7.id = projections.idp_templates7_jwt.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_jwt.instance_id` +
+		` LEFT JOIN projections.idp_templates7_azure ON projections.idp_templates7.id = projections.idp_templates7_azure.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_azure.instance_id` +
+		` LEFT JOIN projections.idp_templates7_github ON projections.idp_templates7.id = projections.idp_templates7_github.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_github.instance_id` +
+		` LEFT JOIN projections.idp_templates7_github_enterprise ON projections.idp_templates7.id = projections.idp_templates7_github_enterprise.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_github_enterprise.instance_id` +
+		` LEFT JOIN projections.idp_templates7_gitlab ON projections.idp_templates7.id = projections.idp_templates7_gitlab.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_gitlab.instance_id` +
+		` LEFT JOIN projections.idp_templates7_gitlab_self_hosted ON projections.idp_templates7.id = projections.idp_templates7_gitlab_self_hosted.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_gitlab_self_hosted.instance_id` +
+		` LEFT JOIN projections.idp_templates7_google ON projections.idp_templates7.id = projections.idp_templates7_google.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_google.instance_id` +
+		` LEFT JOIN projections.idp_templates7_saml ON projections.idp_templates7.id = projections.idp_templates7_saml.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_saml.instance_id` +
+		` LEFT JOIN projections.idp_templates7_apple ON projections.idp_templates7.id = projections.idp_templates7_apple.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_apple.instance_id` +
+		` LEFT JOIN projections.idp_templates7_ldap2 ON projections.idp_templates7.id = projections.idp_templates7_ldap2.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_ldap2.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	idpTemplateCols = []string{
 		"id",
@@ -195,6 +211,20 @@ var (
 		"client_id",
 		"client_secret",
 		"scopes",
+		// saml config
+		"idp_id",
+		"metadata",
+		"key",
+		"certificate",
+		"binding",
+		"with_signed_request",
+		// apple config
+		"idp_id",
+		"client_id",
+		"team_id",
+		"key_id",
+		"private_key",
+		"scopes",
 		// ldap config
 		"idp_id",
 		"servers",
@@ -220,113 +250,129 @@ var (
 		"avatar_url_attribute",
 		"profile_attribute",
 	}
-	idpTemplatesQuery = `SELECT projections.idp_templates5.id,` +
-		` projections.idp_templates5.resource_owner,` +
-		` projections.idp_templates5.creation_date,` +
-		` projections.idp_templates5.change_date,` +
-		` projections.idp_templates5.sequence,` +
-		` projections.idp_templates5.state,` +
-		` projections.idp_templates5.name,` +
-		` projections.idp_templates5.type,` +
-		` projections.idp_templates5.owner_type,` +
-		` projections.idp_templates5.is_creation_allowed,` +
-		` projections.idp_templates5.is_linking_allowed,` +
-		` projections.idp_templates5.is_auto_creation,` +
-		` projections.idp_templates5.is_auto_update,` +
+	idpTemplatesQuery = `SELECT projections.idp_templates7.id,` +
+		` projections.idp_templates7.resource_owner,` +
+		` projections.idp_templates7.creation_date,` +
+		` projections.idp_templates7.change_date,` +
+		` projections.idp_templates7.sequence,` +
+		` projections.idp_templates7.state,` +
+		` projections.idp_templates7.name,` +
+		` projections.idp_templates7.type,` +
+		` projections.idp_templates7.owner_type,` +
+		` projections.idp_templates7.is_creation_allowed,` +
+		` projections.idp_templates7.is_linking_allowed,` +
+		` projections.idp_templates7.is_auto_creation,` +
+		` projections.idp_templates7.is_auto_update,` +
 		// oauth
-		` projections.idp_templates5_oauth2.idp_id,` +
-		` projections.idp_templates5_oauth2.client_id,` +
-		` projections.idp_templates5_oauth2.client_secret,` +
-		` projections.idp_templates5_oauth2.authorization_endpoint,` +
-		` projections.idp_templates5_oauth2.token_endpoint,` +
-		` projections.idp_templates5_oauth2.user_endpoint,` +
-		` projections.idp_templates5_oauth2.scopes,` +
-		` projections.idp_templates5_oauth2.id_attribute,` +
+		` projections.idp_templates7_oauth2.idp_id,` +
+		` projections.idp_templates7_oauth2.client_id,` +
+		` projections.idp_templates7_oauth2.client_secret,` +
+		` projections.idp_templates7_oauth2.authorization_endpoint,` +
+		` projections.idp_templates7_oauth2.token_endpoint,` +
+		` projections.idp_templates7_oauth2.user_endpoint,` +
+		` projections.idp_templates7_oauth2.scopes,` +
+		` projections.idp_templates7_oauth2.id_attribute,` +
 		// oidc
-		` projections.idp_templates5_oidc.idp_id,` +
-		` projections.idp_templates5_oidc.issuer,` +
-		` projections.idp_templates5_oidc.client_id,` +
-		` projections.idp_templates5_oidc.client_secret,` +
-		` projections.idp_templates5_oidc.scopes,` +
-		` projections.idp_templates5_oidc.id_token_mapping,` +
+		` projections.idp_templates7_oidc.idp_id,` +
+		` projections.idp_templates7_oidc.issuer,` +
+		` projections.idp_templates7_oidc.client_id,` +
+		` projections.idp_templates7_oidc.client_secret,` +
+		` projections.idp_templates7_oidc.scopes,` +
+		` projections.idp_templates7_oidc.id_token_mapping,` +
 		// jwt
-		` projections.idp_templates5_jwt.idp_id,` +
-		` projections.idp_templates5_jwt.issuer,` +
-		` projections.idp_templates5_jwt.jwt_endpoint,` +
-		` projections.idp_templates5_jwt.keys_endpoint,` +
-		` projections.idp_templates5_jwt.header_name,` +
+		` projections.idp_templates7_jwt.idp_id,` +
+		` projections.idp_templates7_jwt.issuer,` +
+		` projections.idp_templates7_jwt.jwt_endpoint,` +
+		` projections.idp_templates7_jwt.keys_endpoint,` +
+		` projections.idp_templates7_jwt.header_name,` +
 		// azure
-		` projections.idp_templates5_azure.idp_id,` +
-		` projections.idp_templates5_azure.client_id,` +
-		` projections.idp_templates5_azure.client_secret,` +
-		` projections.idp_templates5_azure.scopes,` +
-		` projections.idp_templates5_azure.tenant,` +
-		` projections.idp_templates5_azure.is_email_verified,` +
+		` projections.idp_templates7_azure.idp_id,` +
+		` projections.idp_templates7_azure.client_id,` +
+		` projections.idp_templates7_azure.client_secret,` +
+		` projections.idp_templates7_azure.scopes,` +
+		` projections.idp_templates7_azure.tenant,` +
+		` projections.idp_templates7_azure.is_email_verified,` +
 		// github
-		` projections.idp_templates5_github.idp_id,` +
-		` projections.idp_templates5_github.client_id,` +
-		` projections.idp_templates5_github.client_secret,` +
-		` projections.idp_templates5_github.scopes,` +
+		` projections.idp_templates7_github.idp_id,` +
+		` projections.idp_templates7_github.client_id,` +
+		` projections.idp_templates7_github.client_secret,` +
+		` projections.idp_templates7_github.scopes,` +
 		// github enterprise
-		` projections.idp_templates5_github_enterprise.idp_id,` +
-		` projections.idp_templates5_github_enterprise.client_id,` +
-		` projections.idp_templates5_github_enterprise.client_secret,` +
-		` projections.idp_templates5_github_enterprise.authorization_endpoint,` +
-		` projections.idp_templates5_github_enterprise.token_endpoint,` +
-		` projections.idp_templates5_github_enterprise.user_endpoint,` +
-		` projections.idp_templates5_github_enterprise.scopes,` +
+		` projections.idp_templates7_github_enterprise.idp_id,` +
+		` projections.idp_templates7_github_enterprise.client_id,` +
+		` projections.idp_templates7_github_enterprise.client_secret,` +
+		` projections.idp_templates7_github_enterprise.authorization_endpoint,` +
+		` projections.idp_templates7_github_enterprise.token_endpoint,` +
+		` projections.idp_templates7_github_enterprise.user_endpoint,` +
+		` projections.idp_templates7_github_enterprise.scopes,` +
 		// gitlab
-		` projections.idp_templates5_gitlab.idp_id,` +
-		` projections.idp_templates5_gitlab.client_id,` +
-		` projections.idp_templates5_gitlab.client_secret,` +
-		` projections.idp_templates5_gitlab.scopes,` +
+		` projections.idp_templates7_gitlab.idp_id,` +
+		` projections.idp_templates7_gitlab.client_id,` +
+		` projections.idp_templates7_gitlab.client_secret,` +
+		` projections.idp_templates7_gitlab.scopes,` +
 		// gitlab self hosted
-		` projections.idp_templates5_gitlab_self_hosted.idp_id,` +
-		` projections.idp_templates5_gitlab_self_hosted.issuer,` +
-		` projections.idp_templates5_gitlab_self_hosted.client_id,` +
-		` projections.idp_templates5_gitlab_self_hosted.client_secret,` +
-		` projections.idp_templates5_gitlab_self_hosted.scopes,` +
+		` projections.idp_templates7_gitlab_self_hosted.idp_id,` +
+		` projections.idp_templates7_gitlab_self_hosted.issuer,` +
+		` projections.idp_templates7_gitlab_self_hosted.client_id,` +
+		` projections.idp_templates7_gitlab_self_hosted.client_secret,` +
+		` projections.idp_templates7_gitlab_self_hosted.scopes,` +
 		// google
-		` projections.idp_templates5_google.idp_id,` +
-		` projections.idp_templates5_google.client_id,` +
-		` projections.idp_templates5_google.client_secret,` +
-		` projections.idp_templates5_google.scopes,` +
+		` projections.idp_templates7_google.idp_id,` +
+		` projections.idp_templates7_google.client_id,` +
+		` projections.idp_templates7_google.client_secret,` +
+		` projections.idp_templates7_google.scopes,` +
+		// saml
+		` projections.idp_templates7_saml.idp_id,` +
+		` projections.idp_templates7_saml.metadata,` +
+		` projections.idp_templates7_saml.key,` +
+		` projections.idp_templates7_saml.certificate,` +
+		` projections.idp_templates7_saml.binding,` +
+		` projections.idp_templates7_saml.with_signed_request,` +
+		// apple
+		` projections.idp_templates7_apple.idp_id,` +
+		` projections.idp_templates7_apple.client_id,` +
+		` projections.idp_templates7_apple.team_id,` +
+		` projections.idp_templates7_apple.key_id,` +
+		` projections.idp_templates7_apple.private_key,` +
+		` projections.idp_templates7_apple.scopes,` +
 		// ldap
-		` projections.idp_templates5_ldap2.idp_id,` +
-		` projections.idp_templates5_ldap2.servers,` +
-		` projections.idp_templates5_ldap2.start_tls,` +
-		` projections.idp_templates5_ldap2.base_dn,` +
-		` projections.idp_templates5_ldap2.bind_dn,` +
-		` projections.idp_templates5_ldap2.bind_password,` +
-		` projections.idp_templates5_ldap2.user_base,` +
-		` projections.idp_templates5_ldap2.user_object_classes,` +
-		` projections.idp_templates5_ldap2.user_filters,` +
-		` projections.idp_templates5_ldap2.timeout,` +
-		` projections.idp_templates5_ldap2.id_attribute,` +
-		` projections.idp_templates5_ldap2.first_name_attribute,` +
-		` projections.idp_templates5_ldap2.last_name_attribute,` +
-		` projections.idp_templates5_ldap2.display_name_attribute,` +
-		` projections.idp_templates5_ldap2.nick_name_attribute,` +
-		` projections.idp_templates5_ldap2.preferred_username_attribute,` +
-		` projections.idp_templates5_ldap2.email_attribute,` +
-		` projections.idp_templates5_ldap2.email_verified,` +
-		` projections.idp_templates5_ldap2.phone_attribute,` +
-		` projections.idp_templates5_ldap2.phone_verified_attribute,` +
-		` projections.idp_templates5_ldap2.preferred_language_attribute,` +
-		` projections.idp_templates5_ldap2.avatar_url_attribute,` +
-		` projections.idp_templates5_ldap2.profile_attribute,` +
+		` projections.idp_templates7_ldap2.idp_id,` +
+		` projections.idp_templates7_ldap2.servers,` +
+		` projections.idp_templates7_ldap2.start_tls,` +
+		` projections.idp_templates7_ldap2.base_dn,` +
+		` projections.idp_templates7_ldap2.bind_dn,` +
+		` projections.idp_templates7_ldap2.bind_password,` +
+		` projections.idp_templates7_ldap2.user_base,` +
+		` projections.idp_templates7_ldap2.user_object_classes,` +
+		` projections.idp_templates7_ldap2.user_filters,` +
+		` projections.idp_templates7_ldap2.timeout,` +
+		` projections.idp_templates7_ldap2.id_attribute,` +
+		` projections.idp_templates7_ldap2.first_name_attribute,` +
+		` projections.idp_templates7_ldap2.last_name_attribute,` +
+		` projections.idp_templates7_ldap2.display_name_attribute,` +
+		` projections.idp_templates7_ldap2.nick_name_attribute,` +
+		` projections.idp_templates7_ldap2.preferred_username_attribute,` +
+		` projections.idp_templates7_ldap2.email_attribute,` +
+		` projections.idp_templates7_ldap2.email_verified,` +
+		` projections.idp_templates7_ldap2.phone_attribute,` +
+		` projections.idp_templates7_ldap2.phone_verified_attribute,` +
+		` projections.idp_templates7_ldap2.preferred_language_attribute,` +
+		` projections.idp_templates7_ldap2.avatar_url_attribute,` +
+		` projections.idp_templates7_ldap2.profile_attribute,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.idp_templates5` +
-		` LEFT JOIN projections.idp_templates5_oauth2 ON projections.idp_templates5.id = projections.idp_templates5_oauth2.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_oauth2.instance_id` +
-		` LEFT JOIN projections.idp_templates5_oidc ON projections.idp_templates5.id = projections.idp_templates5_oidc.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_oidc.instance_id` +
-		` LEFT JOIN projections.idp_templates5_jwt ON projections.idp_templates5.id = projections.idp_templates5_jwt.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_jwt.instance_id` +
-		` LEFT JOIN projections.idp_templates5_azure ON projections.idp_templates5.id = projections.idp_templates5_azure.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_azure.instance_id` +
-		` LEFT JOIN projections.idp_templates5_github ON projections.idp_templates5.id = projections.idp_templates5_github.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_github.instance_id` +
-		` LEFT JOIN projections.idp_templates5_github_enterprise ON projections.idp_templates5.id = projections.idp_templates5_github_enterprise.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_github_enterprise.instance_id` +
-		` LEFT JOIN projections.idp_templates5_gitlab ON projections.idp_templates5.id = projections.idp_templates5_gitlab.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_gitlab.instance_id` +
-		` LEFT JOIN projections.idp_templates5_gitlab_self_hosted ON projections.idp_templates5.id = projections.idp_templates5_gitlab_self_hosted.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_gitlab_self_hosted.instance_id` +
-		` LEFT JOIN projections.idp_templates5_google ON projections.idp_templates5.id = projections.idp_templates5_google.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_google.instance_id` +
-		` LEFT JOIN projections.idp_templates5_ldap2 ON projections.idp_templates5.id = projections.idp_templates5_ldap2.idp_id AND projections.idp_templates5.instance_id = projections.idp_templates5_ldap2.instance_id` +
+		` FROM projections.idp_templates7` +
+		` LEFT JOIN projections.idp_templates7_oauth2 ON projections.idp_templates7.id = projections.idp_templates7_oauth2.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_oauth2.instance_id` +
+		` LEFT JOIN projections.idp_templates7_oidc ON projections.idp_templates7.id = projections.idp_templates7_oidc.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_oidc.instance_id` +
+		` LEFT JOIN projections.idp_templates7_jwt ON projections.idp_templates7.id = projections.idp_templates7_jwt.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_jwt.instance_id` +
+		` LEFT JOIN projections.idp_templates7_azure ON projections.idp_templates7.id = projections.idp_templates7_azure.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_azure.instance_id` +
+		` LEFT JOIN projections.idp_templates7_github ON projections.idp_templates7.id = projections.idp_templates7_github.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_github.instance_id` +
+		` LEFT JOIN projections.idp_templates7_github_enterprise ON projections.idp_templates7.id = projections.idp_templates7_github_enterprise.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_github_enterprise.instance_id` +
+		` LEFT JOIN projections.idp_templates7_gitlab ON projections.idp_templates7.id = projections.idp_templates7_gitlab.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_gitlab.instance_id` +
+		` LEFT JOIN projections.idp_templates7_gitlab_self_hosted ON projections.idp_templates7.id = projections.idp_templates7_gitlab_self_hosted.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_gitlab_self_hosted.instance_id` +
+		` LEFT JOIN projections.idp_templates7_google ON projections.idp_templates7.id = projections.idp_templates7_google.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_google.instance_id` +
+		` LEFT JOIN projections.idp_templates7_saml ON projections.idp_templates7.id = projections.idp_templates7_saml.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_saml.instance_id` +
+		` LEFT JOIN projections.idp_templates7_apple ON projections.idp_templates7.id = projections.idp_templates7_apple.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_apple.instance_id` +
+		` LEFT JOIN projections.idp_templates7_ldap2 ON projections.idp_templates7.id = projections.idp_templates7_ldap2.idp_id AND projections.idp_templates7.instance_id = projections.idp_templates7_ldap2.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	idpTemplatesCols = []string{
 		"id",
@@ -400,6 +446,20 @@ var (
 		"client_id",
 		"client_secret",
 		"scopes",
+		// saml config
+		"idp_id",
+		"metadata",
+		"key",
+		"certificate",
+		"binding",
+		"with_signed_request",
+		// apple config
+		"idp_id",
+		"client_id",
+		"team_id",
+		"key_id",
+		"private_key",
+		"scopes",
 		// ldap config
 		"idp_id",
 		"servers",
@@ -536,6 +596,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -668,6 +742,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -798,6 +886,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -927,6 +1029,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1055,6 +1171,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1183,6 +1313,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1312,6 +1456,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						"client_id",
 						nil,
 						database.StringArray{"profile"},
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1361,6 +1519,294 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareIDPTemplateByIDQuery saml idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeSAML,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml
+						"idp-id",
+						[]byte("metadata"),
+						nil,
+						nil,
+						"binding",
+						false,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeSAML,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				SAMLIDPTemplate: &SAMLIDPTemplate{
+					IDPID:             "idp-id",
+					Metadata:          []byte("metadata"),
+					Key:               nil,
+					Certificate:       nil,
+					Binding:           "binding",
+					WithSignedRequest: false,
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery apple idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeApple,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						"idp-id",
+						"client_id",
+						"team_id",
+						"key_id",
+						nil,
+						database.StringArray{"name", "email"},
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeApple,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AppleIDPTemplate: &AppleIDPTemplate{
+					IDPID:      "idp-id",
+					ClientID:   "client_id",
+					TeamID:     "team_id",
+					KeyID:      "key_id",
+					PrivateKey: nil,
+					Scopes:     []string{"name", "email"},
+				},
+			},
+		},
 		{
 			name:    "prepareIDPTemplateByIDQuery ldap idp",
 			prepare: prepareIDPTemplateByIDQuery,
@@ -1440,6 +1886,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						"idp-id",
 						database.StringArray{"server"},
@@ -1588,6 +2048,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// ldap config
 						nil,
 						nil,
@@ -1746,6 +2220,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							"idp-id",
 							database.StringArray{"server"},
@@ -1903,6 +2391,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -2034,6 +2536,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							"idp-id-ldap",
 							database.StringArray{"server"},
@@ -2131,6 +2647,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							"client_id",
 							nil,
 							database.StringArray{"profile"},
+							// saml
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -2228,6 +2758,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -2325,6 +2869,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -2422,6 +2980,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// ldap config
 							nil,
 							nil,
@@ -25,7 +25,11 @@ type LockoutPolicy struct {
 	State         domain.PolicyState
 
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
 	ShowFailures        bool
+	LockoutDuration     time.Duration
+	AttemptResetWindow  time.Duration
+	ProgressiveLockout  bool
 
 	IsDefault bool
 }
@@ -67,6 +71,22 @@ var (
 		name:  projection.LockoutPolicyMaxPasswordAttemptsCol,
 		table: lockoutTable,
 	}
+	LockoutColMaxOTPAttempts = Column{
+		name:  projection.LockoutPolicyMaxOTPAttemptsCol,
+		table: lockoutTable,
+	}
+	LockoutColLockoutDuration = Column{
+		name:  projection.LockoutPolicyLockoutDurationCol,
+		table: lockoutTable,
+	}
+	LockoutColAttemptResetWindow = Column{
+		name:  projection.LockoutPolicyAttemptResetWindowCol,
+		table: lockoutTable,
+	}
+	LockoutColProgressiveLockout = Column{
+		name:  projection.LockoutPolicyProgressiveLockoutCol,
+		table: lockoutTable,
+	}
 	LockoutColIsDefault = Column{
 		name:  projection.LockoutPolicyIsDefaultCol,
 		table: lockoutTable,
@@ -142,6 +162,10 @@ func prepareLockoutPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Sele
 			LockoutColResourceOwner.identifier(),
 			LockoutColShowFailures.identifier(),
 			LockoutColMaxPasswordAttempts.identifier(),
+			LockoutColMaxOTPAttempts.identifier(),
+			LockoutColLockoutDuration.identifier(),
+			LockoutColAttemptResetWindow.identifier(),
+			LockoutColProgressiveLockout.identifier(),
 			LockoutColIsDefault.identifier(),
 			LockoutColState.identifier(),
 		).
@@ -157,6 +181,10 @@ func prepareLockoutPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Sele
 				&policy.ResourceOwner,
 				&policy.ShowFailures,
 				&policy.MaxPasswordAttempts,
+				&policy.MaxOTPAttempts,
+				&policy.LockoutDuration,
+				&policy.AttemptResetWindow,
+				&policy.ProgressiveLockout,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	oidcSessionsTable = table{
+		name:          projection.OIDCSessionProjectionTable,
+		instanceIDCol: projection.OIDCSessionColumnInstanceID,
+	}
+	OIDCSessionColumnInstanceID = Column{
+		name:  projection.OIDCSessionColumnInstanceID,
+		table: oidcSessionsTable,
+	}
+	OIDCSessionColumnID = Column{
+		name:  projection.OIDCSessionColumnID,
+		table: oidcSessionsTable,
+	}
+	OIDCSessionColumnSessionID = Column{
+		name:  projection.OIDCSessionColumnSessionID,
+		table: oidcSessionsTable,
+	}
+	OIDCSessionColumnClientID = Column{
+		name:  projection.OIDCSessionColumnClientID,
+		table: oidcSessionsTable,
+	}
+	OIDCSessionColumnUserID = Column{
+		name:  projection.OIDCSessionColumnUserID,
+		table: oidcSessionsTable,
+	}
+)
+
+// OIDCSessionClient is a client (application), which received tokens based on a session
+type OIDCSessionClient struct {
+	ClientID string
+	UserID   string
+}
+
+// OIDCSessionClientsBySessionID returns the distinct clients, which received tokens based on the session
+func (q *Queries) OIDCSessionClientsBySessionID(ctx context.Context, shouldTriggerBulk bool, sessionID string) (_ []*OIDCSessionClient, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = projection.OIDCSessionProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareOIDCSessionClientsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		OIDCSessionColumnSessionID.identifier():  sessionID,
+		OIDCSessionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Hq8vt", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Mb4zs", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func prepareOIDCSessionClientsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*OIDCSessionClient, error)) {
+	return sq.Select(
+			OIDCSessionColumnClientID.identifier(),
+			OIDCSessionColumnUserID.identifier(),
+		).Distinct().
+			From(oidcSessionsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*OIDCSessionClient, error) {
+			clients := make([]*OIDCSessionClient, 0)
+			for rows.Next() {
+				client := new(OIDCSessionClient)
+				err := rows.Scan(
+					&client.ClientID,
+					&client.UserID,
+				)
+				if err != nil {
+					return nil, err
+				}
+				clients = append(clients, client)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Wu6pd", "Errors.Query.CloseRows")
+			}
+			return clients, nil
+		}
+}
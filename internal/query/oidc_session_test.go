@@ -0,0 +1,99 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	prepareOIDCSessionClientsStmt = `SELECT DISTINCT projections.oidc_sessions.client_id,` +
+		` projections.oidc_sessions.user_id` +
+		` FROM projections.oidc_sessions` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareOIDCSessionClientsCols = []string{
+		"client_id",
+		"user_id",
+	}
+)
+
+func Test_OIDCSessionPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareOIDCSessionClientsQuery no result",
+			prepare: prepareOIDCSessionClientsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareOIDCSessionClientsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*OIDCSessionClient{},
+		},
+		{
+			name:    "prepareOIDCSessionClientsQuery multiple results",
+			prepare: prepareOIDCSessionClientsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareOIDCSessionClientsStmt),
+					prepareOIDCSessionClientsCols,
+					[][]driver.Value{
+						{
+							"client-id",
+							"user-id",
+						},
+						{
+							"client-id-2",
+							"user-id",
+						},
+					},
+				),
+			},
+			object: []*OIDCSessionClient{
+				{
+					ClientID: "client-id",
+					UserID:   "user-id",
+				},
+				{
+					ClientID: "client-id-2",
+					UserID:   "user-id",
+				},
+			},
+		},
+		{
+			name:    "prepareOIDCSessionClientsQuery sql err",
+			prepare: prepareOIDCSessionClientsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareOIDCSessionClientsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
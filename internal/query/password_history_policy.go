@@ -0,0 +1,178 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type PasswordHistoryPolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	HistoryCount uint64
+
+	IsDefault bool
+}
+
+var (
+	passwordHistoryTable = table{
+		name:          projection.PasswordHistoryTable,
+		instanceIDCol: projection.HistoryPolicyInstanceIDCol,
+	}
+	PasswordHistoryColID = Column{
+		name:  projection.HistoryPolicyIDCol,
+		table: passwordHistoryTable,
+	}
+	PasswordHistoryColSequence = Column{
+		name:  projection.HistoryPolicySequenceCol,
+		table: passwordHistoryTable,
+	}
+	PasswordHistoryColCreationDate = Column{
+		name:  projection.HistoryPolicyCreationDateCol,
+		table: passwordHistoryTable,
+	}
+	PasswordHistoryColChangeDate = Column{
+		name:  projection.HistoryPolicyChangeDateCol,
+		table: passwordHistoryTable,
+	}
+	PasswordHistoryColResourceOwner = Column{
+		name:  projection.HistoryPolicyResourceOwnerCol,
+		table: passwordHistoryTable,
+	}
+	PasswordHistoryColInstanceID = Column{
+		name:  projection.HistoryPolicyInstanceIDCol,
+		table: passwordHistoryTable,
+	}
+	PasswordHistoryColHistoryCount = Column{
+		name:  projection.HistoryPolicyHistoryCountCol,
+		table: passwordHistoryTable,
+	}
+	PasswordHistoryColIsDefault = Column{
+		name:  projection.HistoryPolicyIsDefaultCol,
+		table: passwordHistoryTable,
+	}
+	PasswordHistoryColState = Column{
+		name:  projection.HistoryPolicyStateCol,
+		table: passwordHistoryTable,
+	}
+	PasswordHistoryColOwnerRemoved = Column{
+		name:  projection.HistoryPolicyOwnerRemovedCol,
+		table: passwordHistoryTable,
+	}
+)
+
+func (q *Queries) PasswordHistoryPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string, withOwnerRemoved bool) (_ *PasswordHistoryPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = projection.PasswordHistoryProjection.Trigger(ctx)
+	}
+	eq := sq.Eq{PasswordHistoryColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	if !withOwnerRemoved {
+		eq[PasswordHistoryColOwnerRemoved.identifier()] = false
+	}
+	stmt, scan := preparePasswordHistoryPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(
+		sq.And{
+			eq,
+			sq.Or{
+				sq.Eq{PasswordHistoryColID.identifier(): orgID},
+				sq.Eq{PasswordHistoryColID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			},
+		}).
+		OrderBy(PasswordHistoryColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Hk2s0", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultPasswordHistoryPolicy(ctx context.Context, shouldTriggerBulk bool) (_ *PasswordHistoryPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = projection.PasswordHistoryProjection.Trigger(ctx)
+	}
+
+	stmt, scan := preparePasswordHistoryPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		PasswordHistoryColID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(PasswordHistoryColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Lw9f3", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func preparePasswordHistoryPolicyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*PasswordHistoryPolicy, error)) {
+	return sq.Select(
+			PasswordHistoryColID.identifier(),
+			PasswordHistoryColSequence.identifier(),
+			PasswordHistoryColCreationDate.identifier(),
+			PasswordHistoryColChangeDate.identifier(),
+			PasswordHistoryColResourceOwner.identifier(),
+			PasswordHistoryColHistoryCount.identifier(),
+			PasswordHistoryColIsDefault.identifier(),
+			PasswordHistoryColState.identifier(),
+		).
+			From(passwordHistoryTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*PasswordHistoryPolicy, error) {
+			policy := new(PasswordHistoryPolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.HistoryCount,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Bm3s8", "Errors.Org.PasswordHistoryPolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Tz7x2", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
+
+func (p *PasswordHistoryPolicy) ToDomain() *domain.PasswordHistoryPolicy {
+	return &domain.PasswordHistoryPolicy{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   p.ID,
+			Sequence:      p.Sequence,
+			ResourceOwner: p.ResourceOwner,
+			CreationDate:  p.CreationDate,
+			ChangeDate:    p.ChangeDate,
+		},
+		HistoryCount: p.HistoryCount,
+	}
+}
@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	AppProjectionTable = "projections.apps5"
+	AppProjectionTable = "projections.apps9"
 	AppAPITable        = AppProjectionTable + "_" + appAPITableSuffix
 	AppOIDCTable       = AppProjectionTable + "_" + appOIDCTableSuffix
 	AppSAMLTable       = AppProjectionTable + "_" + appSAMLTableSuffix
@@ -38,33 +38,38 @@ const (
 	AppAPIConfigColumnClientSecret = "client_secret"
 	AppAPIConfigColumnAuthMethod   = "auth_method"
 
-	appOIDCTableSuffix                          = "oidc_configs"
-	AppOIDCConfigColumnAppID                    = "app_id"
-	AppOIDCConfigColumnInstanceID               = "instance_id"
-	AppOIDCConfigColumnVersion                  = "version"
-	AppOIDCConfigColumnClientID                 = "client_id"
-	AppOIDCConfigColumnClientSecret             = "client_secret"
-	AppOIDCConfigColumnRedirectUris             = "redirect_uris"
-	AppOIDCConfigColumnResponseTypes            = "response_types"
-	AppOIDCConfigColumnGrantTypes               = "grant_types"
-	AppOIDCConfigColumnApplicationType          = "application_type"
-	AppOIDCConfigColumnAuthMethodType           = "auth_method_type"
-	AppOIDCConfigColumnPostLogoutRedirectUris   = "post_logout_redirect_uris"
-	AppOIDCConfigColumnDevMode                  = "is_dev_mode"
-	AppOIDCConfigColumnAccessTokenType          = "access_token_type"
-	AppOIDCConfigColumnAccessTokenRoleAssertion = "access_token_role_assertion"
-	AppOIDCConfigColumnIDTokenRoleAssertion     = "id_token_role_assertion"
-	AppOIDCConfigColumnIDTokenUserinfoAssertion = "id_token_userinfo_assertion"
-	AppOIDCConfigColumnClockSkew                = "clock_skew"
-	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
-	AppOIDCConfigColumnSkipNativeAppSuccessPage = "skip_native_app_success_page"
-
-	appSAMLTableSuffix             = "saml_configs"
-	AppSAMLConfigColumnAppID       = "app_id"
-	AppSAMLConfigColumnInstanceID  = "instance_id"
-	AppSAMLConfigColumnEntityID    = "entity_id"
-	AppSAMLConfigColumnMetadata    = "metadata"
-	AppSAMLConfigColumnMetadataURL = "metadata_url"
+	appOIDCTableSuffix                           = "oidc_configs"
+	AppOIDCConfigColumnAppID                     = "app_id"
+	AppOIDCConfigColumnInstanceID                = "instance_id"
+	AppOIDCConfigColumnVersion                   = "version"
+	AppOIDCConfigColumnClientID                  = "client_id"
+	AppOIDCConfigColumnClientSecret              = "client_secret"
+	AppOIDCConfigColumnRedirectUris              = "redirect_uris"
+	AppOIDCConfigColumnResponseTypes             = "response_types"
+	AppOIDCConfigColumnGrantTypes                = "grant_types"
+	AppOIDCConfigColumnApplicationType           = "application_type"
+	AppOIDCConfigColumnAuthMethodType            = "auth_method_type"
+	AppOIDCConfigColumnPostLogoutRedirectUris    = "post_logout_redirect_uris"
+	AppOIDCConfigColumnDevMode                   = "is_dev_mode"
+	AppOIDCConfigColumnAccessTokenType           = "access_token_type"
+	AppOIDCConfigColumnAccessTokenRoleAssertion  = "access_token_role_assertion"
+	AppOIDCConfigColumnIDTokenRoleAssertion      = "id_token_role_assertion"
+	AppOIDCConfigColumnIDTokenUserinfoAssertion  = "id_token_userinfo_assertion"
+	AppOIDCConfigColumnClockSkew                 = "clock_skew"
+	AppOIDCConfigColumnAdditionalOrigins         = "additional_origins"
+	AppOIDCConfigColumnSkipNativeAppSuccessPage  = "skip_native_app_success_page"
+	AppOIDCConfigColumnBackChannelLogoutURI      = "back_channel_logout_uri"
+	AppOIDCConfigColumnRequirePushedAuthRequests = "require_pushed_auth_requests"
+	AppOIDCConfigColumnRequireDPoP               = "require_dpop"
+
+	appSAMLTableSuffix                   = "saml_configs"
+	AppSAMLConfigColumnAppID             = "app_id"
+	AppSAMLConfigColumnInstanceID        = "instance_id"
+	AppSAMLConfigColumnEntityID          = "entity_id"
+	AppSAMLConfigColumnMetadata          = "metadata"
+	AppSAMLConfigColumnMetadataURL       = "metadata_url"
+	AppSAMLConfigColumnRoleAssertion     = "role_assertion"
+	AppSAMLConfigColumnMetadataAssertion = "metadata_assertion"
 )
 
 type appProjection struct {
@@ -124,6 +129,9 @@ func newAppProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			crdb.NewColumn(AppOIDCConfigColumnClockSkew, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(AppOIDCConfigColumnAdditionalOrigins, crdb.ColumnTypeTextArray, crdb.Nullable()),
 			crdb.NewColumn(AppOIDCConfigColumnSkipNativeAppSuccessPage, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppOIDCConfigColumnBackChannelLogoutURI, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(AppOIDCConfigColumnRequirePushedAuthRequests, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppOIDCConfigColumnRequireDPoP, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -136,6 +144,8 @@ func newAppProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			crdb.NewColumn(AppSAMLConfigColumnEntityID, crdb.ColumnTypeText),
 			crdb.NewColumn(AppSAMLConfigColumnMetadata, crdb.ColumnTypeBytes),
 			crdb.NewColumn(AppSAMLConfigColumnMetadataURL, crdb.ColumnTypeText),
+			crdb.NewColumn(AppSAMLConfigColumnRoleAssertion, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AppSAMLConfigColumnMetadataAssertion, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(AppSAMLConfigColumnInstanceID, AppSAMLConfigColumnAppID),
 			appSAMLTableSuffix,
@@ -466,6 +476,9 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnClockSkew, e.ClockSkew),
 				handler.NewCol(AppOIDCConfigColumnAdditionalOrigins, database.StringArray(e.AdditionalOrigins)),
 				handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, e.SkipNativeAppSuccessPage),
+				handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, e.BackChannelLogoutURI),
+				handler.NewCol(AppOIDCConfigColumnRequirePushedAuthRequests, e.RequirePushedAuthRequests),
+				handler.NewCol(AppOIDCConfigColumnRequireDPoP, e.RequireDPoP),
 			},
 			crdb.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -534,6 +547,15 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.SkipNativeAppSuccessPage != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, *e.SkipNativeAppSuccessPage))
 	}
+	if e.BackChannelLogoutURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, *e.BackChannelLogoutURI))
+	}
+	if e.RequirePushedAuthRequests != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequirePushedAuthRequests, *e.RequirePushedAuthRequests))
+	}
+	if e.RequireDPoP != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequireDPoP, *e.RequireDPoP))
+	}
 
 	if len(cols) == 0 {
 		return crdb.NewNoOpStatement(e), nil
@@ -626,6 +648,8 @@ func (p *appProjection) reduceSAMLConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppSAMLConfigColumnEntityID, e.EntityID),
 				handler.NewCol(AppSAMLConfigColumnMetadata, e.Metadata),
 				handler.NewCol(AppSAMLConfigColumnMetadataURL, e.MetadataURL),
+				handler.NewCol(AppSAMLConfigColumnRoleAssertion, e.RoleAssertion),
+				handler.NewCol(AppSAMLConfigColumnMetadataAssertion, e.MetadataAssertion),
 			},
 			crdb.WithTableSuffix(appSAMLTableSuffix),
 		),
@@ -648,7 +672,7 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 		return nil, errors.ThrowInvalidArgument(nil, "HANDL-GMHU2", "reduce.wrong.event.type")
 	}
 
-	cols := make([]handler.Column, 0, 3)
+	cols := make([]handler.Column, 0, 5)
 	if e.Metadata != nil {
 		cols = append(cols, handler.NewCol(AppSAMLConfigColumnMetadata, e.Metadata))
 	}
@@ -658,6 +682,12 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 	if e.EntityID != "" {
 		cols = append(cols, handler.NewCol(AppSAMLConfigColumnEntityID, e.EntityID))
 	}
+	if e.RoleAssertion != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnRoleAssertion, *e.RoleAssertion))
+	}
+	if e.MetadataAssertion != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnMetadataAssertion, *e.MetadataAssertion))
+	}
 
 	if len(cols) == 0 {
 		return crdb.NewNoOpStatement(e), nil
@@ -0,0 +1,142 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	exec "github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+const (
+	ExecutionTable            = "projections.executions"
+	ExecutionIDCol            = "id"
+	ExecutionCreationDateCol  = "creation_date"
+	ExecutionChangeDateCol    = "change_date"
+	ExecutionResourceOwnerCol = "resource_owner"
+	ExecutionInstanceIDCol    = "instance_id"
+	ExecutionSequenceCol      = "sequence"
+	ExecutionTargetsCol       = "targets"
+)
+
+type executionProjection struct {
+	crdb.StatementHandler
+}
+
+func newExecutionProjection(ctx context.Context, config crdb.StatementHandlerConfig) *executionProjection {
+	p := new(executionProjection)
+	config.ProjectionName = ExecutionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(ExecutionIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ExecutionCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ExecutionChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ExecutionResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ExecutionInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ExecutionSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ExecutionTargetsCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(ExecutionInstanceIDCol, ExecutionIDCol),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *executionProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: exec.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  exec.SetEventType,
+					Reduce: p.reduceExecutionSet,
+				},
+				{
+					Event:  exec.RemovedEventType,
+					Reduce: p.reduceExecutionRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: target.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  target.RemovedEventType,
+					Reduce: p.reduceTargetRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ExecutionInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *executionProjection) reduceExecutionSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*exec.SetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-5ZEeT", "reduce.wrong.event.type %s", exec.SetEventType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ExecutionInstanceIDCol, nil),
+			handler.NewCol(ExecutionIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(ExecutionIDCol, e.Aggregate().ID),
+			handler.NewCol(ExecutionCreationDateCol, e.CreationDate()),
+			handler.NewCol(ExecutionChangeDateCol, e.CreationDate()),
+			handler.NewCol(ExecutionResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(ExecutionInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(ExecutionSequenceCol, e.Sequence()),
+			handler.NewCol(ExecutionTargetsCol, database.StringArray(e.Targets)),
+		},
+	), nil
+}
+
+func (p *executionProjection) reduceExecutionRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*exec.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Oh5fa", "reduce.wrong.event.type %s", exec.RemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ExecutionIDCol, e.Aggregate().ID),
+			handler.NewCond(ExecutionInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *executionProjection) reduceTargetRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*target.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ca9ck", "reduce.wrong.event.type %s", target.RemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ExecutionChangeDateCol, e.CreationDate()),
+			handler.NewCol(ExecutionSequenceCol, e.Sequence()),
+			crdb.NewArrayRemoveCol(ExecutionTargetsCol, e.Aggregate().ID),
+		},
+		[]handler.Condition{
+			handler.NewCond(ExecutionInstanceIDCol, e.Aggregate().InstanceID),
+			crdb.NewTextArrayContainsCond(ExecutionTargetsCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,155 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	exec "github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+func TestExecutionProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceExecutionSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(exec.SetEventType),
+					exec.AggregateType,
+					[]byte(`{"targets": ["target1", "target2"]}`),
+				), eventstore.GenericEventMapper[exec.SetEvent]),
+			},
+			reduce: (&executionProjection{}).reduceExecutionSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("execution"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.executions (id, creation_date, change_date, resource_owner, instance_id, sequence, targets) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (instance_id, id) DO UPDATE SET (creation_date, change_date, resource_owner, sequence, targets) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.resource_owner, EXCLUDED.sequence, EXCLUDED.targets)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								database.StringArray{"target1", "target2"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceExecutionRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(exec.RemovedEventType),
+					exec.AggregateType,
+					[]byte(`{}`),
+				), eventstore.GenericEventMapper[exec.RemovedEvent]),
+			},
+			reduce: (&executionProjection{}).reduceExecutionRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("execution"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.executions WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTargetRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(target.RemovedEventType),
+					target.AggregateType,
+					[]byte(`{}`),
+				), eventstore.GenericEventMapper[target.RemovedEvent]),
+			},
+			reduce: (&executionProjection{}).reduceTargetRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("target"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.executions SET (change_date, sequence, targets) = ($1, $2, array_remove(targets, $3)) WHERE (instance_id = $4) AND (targets @> $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+								database.StringArray{"agg-id"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(ExecutionInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.executions WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, ExecutionTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,471 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	GroupProjectionTable = "projections.groups"
+	GroupMemberTable     = GroupProjectionTable + "_" + groupMemberTableSuffix
+	GroupGrantTable      = GroupProjectionTable + "_" + groupGrantTableSuffix
+
+	GroupColumnID            = "id"
+	GroupColumnCreationDate  = "creation_date"
+	GroupColumnChangeDate    = "change_date"
+	GroupColumnSequence      = "sequence"
+	GroupColumnState         = "state"
+	GroupColumnResourceOwner = "resource_owner"
+	GroupColumnInstanceID    = "instance_id"
+	GroupColumnName          = "name"
+	GroupColumnDescription   = "description"
+	GroupColumnOwnerRemoved  = "owner_removed"
+
+	groupMemberTableSuffix        = "members"
+	GroupMemberColumnGroupID      = "group_id"
+	GroupMemberColumnUserID       = "user_id"
+	GroupMemberColumnCreationDate = "creation_date"
+	GroupMemberColumnChangeDate   = "change_date"
+	GroupMemberColumnSequence     = "sequence"
+	GroupMemberColumnInstanceID   = "instance_id"
+
+	groupGrantTableSuffix         = "grants"
+	GroupGrantColumnID            = "id"
+	GroupGrantColumnGroupID       = "group_id"
+	GroupGrantColumnCreationDate  = "creation_date"
+	GroupGrantColumnChangeDate    = "change_date"
+	GroupGrantColumnSequence      = "sequence"
+	GroupGrantColumnResourceOwner = "resource_owner"
+	GroupGrantColumnInstanceID    = "instance_id"
+	GroupGrantColumnProjectID     = "project_id"
+	GroupGrantColumnGrantID       = "grant_id"
+	GroupGrantColumnRoles         = "roles"
+)
+
+type groupProjection struct {
+	crdb.StatementHandler
+}
+
+func newGroupProjection(ctx context.Context, config crdb.StatementHandlerConfig) *groupProjection {
+	p := new(groupProjection)
+	config.ProjectionName = GroupProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewMultiTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(GroupColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(GroupColumnState, crdb.ColumnTypeEnum),
+			crdb.NewColumn(GroupColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnName, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupColumnDescription, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(GroupColumnOwnerRemoved, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(GroupColumnInstanceID, GroupColumnID),
+			crdb.WithIndex(crdb.NewIndex("resource_owner", []string{GroupColumnResourceOwner})),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{GroupColumnOwnerRemoved})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(GroupMemberColumnGroupID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMemberColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupMemberColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupMemberColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupMemberColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(GroupMemberColumnInstanceID, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(GroupMemberColumnInstanceID, GroupMemberColumnGroupID, GroupMemberColumnUserID),
+			groupMemberTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("group", []string{GroupMemberColumnInstanceID, GroupMemberColumnGroupID}, []string{GroupColumnInstanceID, GroupColumnID})),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{GroupMemberColumnUserID})),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(GroupGrantColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnGroupID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupGrantColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(GroupGrantColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(GroupGrantColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnProjectID, crdb.ColumnTypeText),
+			crdb.NewColumn(GroupGrantColumnGrantID, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(GroupGrantColumnRoles, crdb.ColumnTypeTextArray, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(GroupGrantColumnInstanceID, GroupGrantColumnID),
+			groupGrantTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKey("group", []string{GroupGrantColumnInstanceID, GroupGrantColumnGroupID}, []string{GroupColumnInstanceID, GroupColumnID})),
+			crdb.WithIndex(crdb.NewIndex("group_id", []string{GroupGrantColumnGroupID})),
+			crdb.WithIndex(crdb.NewIndex("project_id", []string{GroupGrantColumnProjectID})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *groupProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: group.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  group.AddedEventType,
+					Reduce: p.reduceGroupAdded,
+				},
+				{
+					Event:  group.ChangedEventType,
+					Reduce: p.reduceGroupChanged,
+				},
+				{
+					Event:  group.RemovedEventType,
+					Reduce: p.reduceGroupRemoved,
+				},
+				{
+					Event:  group.MemberAddedEventType,
+					Reduce: p.reduceMemberAdded,
+				},
+				{
+					Event:  group.MemberRemovedEventType,
+					Reduce: p.reduceMemberRemoved,
+				},
+				{
+					Event:  group.GrantAddedEventType,
+					Reduce: p.reduceGrantAdded,
+				},
+				{
+					Event:  group.GrantChangedEventType,
+					Reduce: p.reduceGrantChanged,
+				},
+				{
+					Event:  group.GrantRemovedEventType,
+					Reduce: p.reduceGrantRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+				{
+					Event:  project.GrantRemovedType,
+					Reduce: p.reduceProjectGrantRemoved,
+				},
+				{
+					Event:  project.RoleRemovedType,
+					Reduce: p.reduceRoleRemoved,
+				},
+				{
+					Event:  project.GrantChangedType,
+					Reduce: p.reduceProjectGrantChanged,
+				},
+				{
+					Event:  project.GrantCascadeChangedType,
+					Reduce: p.reduceProjectGrantChanged,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(GroupColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *groupProjection) reduceGroupAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rb0fQ", "reduce.wrong.event.type %s", group.AddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupColumnID, e.Aggregate().ID),
+			handler.NewCol(GroupColumnCreationDate, e.CreationDate()),
+			handler.NewCol(GroupColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupColumnSequence, e.Sequence()),
+			handler.NewCol(GroupColumnState, domain.GroupStateActive),
+			handler.NewCol(GroupColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(GroupColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(GroupColumnName, e.Name),
+			handler.NewCol(GroupColumnDescription, e.Description),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceGroupChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.ChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-zD4wh", "reduce.wrong.event.type %s", group.ChangedEventType)
+	}
+	values := []handler.Column{
+		handler.NewCol(GroupColumnChangeDate, e.CreationDate()),
+		handler.NewCol(GroupColumnSequence, e.Sequence()),
+	}
+	if e.Name != nil {
+		values = append(values, handler.NewCol(GroupColumnName, *e.Name))
+	}
+	if e.Description != nil {
+		values = append(values, handler.NewCol(GroupColumnDescription, *e.Description))
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		values,
+		[]handler.Condition{
+			handler.NewCond(GroupColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+// reduceGroupRemoved deletes the group, its members and grants are deleted by the foreign keys
+func (p *groupProjection) reduceGroupRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-K8psl", "reduce.wrong.event.type %s", group.RemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceMemberAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MemberAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Hq1ne", "reduce.wrong.event.type %s", group.MemberAddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupMemberColumnGroupID, e.Aggregate().ID),
+			handler.NewCol(GroupMemberColumnUserID, e.UserID),
+			handler.NewCol(GroupMemberColumnCreationDate, e.CreationDate()),
+			handler.NewCol(GroupMemberColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupMemberColumnSequence, e.Sequence()),
+			handler.NewCol(GroupMemberColumnInstanceID, e.Aggregate().InstanceID),
+		},
+		crdb.WithTableSuffix(groupMemberTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceMemberRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.MemberRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-mT6vd", "reduce.wrong.event.type %s", group.MemberRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupMemberColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupMemberColumnGroupID, e.Aggregate().ID),
+			handler.NewCond(GroupMemberColumnUserID, e.UserID),
+		},
+		crdb.WithTableSuffix(groupMemberTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-a2Wcz", "reduce.wrong.event.type %s", group.GrantAddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupGrantColumnID, e.GrantID),
+			handler.NewCol(GroupGrantColumnGroupID, e.Aggregate().ID),
+			handler.NewCol(GroupGrantColumnCreationDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnSequence, e.Sequence()),
+			handler.NewCol(GroupGrantColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(GroupGrantColumnProjectID, e.ProjectID),
+			handler.NewCol(GroupGrantColumnGrantID, e.ProjectGrantID),
+			handler.NewCol(GroupGrantColumnRoles, database.StringArray(e.RoleKeys)),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ju9sx", "reduce.wrong.event.type %s", group.GrantChangedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupGrantColumnSequence, e.Sequence()),
+			handler.NewCol(GroupGrantColumnRoles, database.StringArray(e.RoleKeys)),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupGrantColumnID, e.GrantID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*group.GrantRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pn3ug", "reduce.wrong.event.type %s", group.GrantRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupGrantColumnID, e.GrantID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ow7ge", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupMemberColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupMemberColumnUserID, e.Aggregate().ID),
+		},
+		crdb.WithTableSuffix(groupMemberTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ProjectRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Fe1sk", "reduce.wrong.event.type %s", project.ProjectRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupGrantColumnProjectID, e.Aggregate().ID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.GrantRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Vt5mo", "reduce.wrong.event.type %s", project.GrantRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupGrantColumnGrantID, e.GrantID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceRoleRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.RoleRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-cX8rb", "reduce.wrong.event.type %s", project.RoleRemovedType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			crdb.NewArrayRemoveCol(GroupGrantColumnRoles, e.Key),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupGrantColumnProjectID, e.Aggregate().ID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectGrantChanged(event eventstore.Event) (*handler.Statement, error) {
+	var grantID string
+	var keys []string
+	switch e := event.(type) {
+	case *project.GrantChangedEvent:
+		grantID = e.GrantID
+		keys = e.RoleKeys
+	case *project.GrantCascadeChangedEvent:
+		grantID = e.GrantID
+		keys = e.RoleKeys
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-q0Kbn", "reduce.wrong.event.type %v", []eventstore.EventType{project.GrantChangedType, project.GrantCascadeChangedType})
+	}
+	return crdb.NewUpdateStatement(
+		event,
+		[]handler.Column{
+			crdb.NewArrayIntersectCol(GroupGrantColumnRoles, database.StringArray(keys)),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCond(GroupGrantColumnGrantID, grantID),
+		},
+		crdb.WithTableSuffix(groupGrantTableSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ty2pd", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupColumnChangeDate, e.CreationDate()),
+			handler.NewCol(GroupColumnSequence, e.Sequence()),
+			handler.NewCol(GroupColumnOwnerRemoved, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(GroupColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
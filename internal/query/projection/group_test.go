@@ -0,0 +1,478 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestGroupProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceGroupAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.AddedEventType),
+					group.AggregateType,
+					[]byte(`{"name": "name", "description": "description"}`),
+				), eventstore.GenericEventMapper[group.AddedEvent]),
+			},
+			reduce: (&groupProjection{}).reduceGroupAdded,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.groups (id, creation_date, change_date, sequence, state, resource_owner, instance_id, name, description) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.GroupStateActive,
+								"ro-id",
+								"instance-id",
+								"name",
+								"description",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGroupChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.ChangedEventType),
+					group.AggregateType,
+					[]byte(`{"name": "name2"}`),
+				), eventstore.GenericEventMapper[group.ChangedEvent]),
+			},
+			reduce: (&groupProjection{}).reduceGroupChanged,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups SET (change_date, sequence, name) = ($1, $2, $3) WHERE (instance_id = $4) AND (id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"name2",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGroupRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.RemovedEventType),
+					group.AggregateType,
+					[]byte(`{}`),
+				), eventstore.GenericEventMapper[group.RemovedEvent]),
+			},
+			reduce: (&groupProjection{}).reduceGroupRemoved,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceMemberAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.MemberAddedEventType),
+					group.AggregateType,
+					[]byte(`{"userId": "user-id"}`),
+				), eventstore.GenericEventMapper[group.MemberAddedEvent]),
+			},
+			reduce: (&groupProjection{}).reduceMemberAdded,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.groups_members (group_id, user_id, creation_date, change_date, sequence, instance_id) VALUES ($1, $2, $3, $4, $5, $6)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"user-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceMemberRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.MemberRemovedEventType),
+					group.AggregateType,
+					[]byte(`{"userId": "user-id"}`),
+				), eventstore.GenericEventMapper[group.MemberRemovedEvent]),
+			},
+			reduce: (&groupProjection{}).reduceMemberRemoved,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_members WHERE (instance_id = $1) AND (group_id = $2) AND (user_id = $3)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"user-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.GrantAddedEventType),
+					group.AggregateType,
+					[]byte(`{"grantId": "grant-id", "projectId": "project-id", "projectGrantId": "project-grant-id", "roleKeys": ["key"]}`),
+				), eventstore.GenericEventMapper[group.GrantAddedEvent]),
+			},
+			reduce: (&groupProjection{}).reduceGrantAdded,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.groups_grants (id, group_id, creation_date, change_date, sequence, resource_owner, instance_id, project_id, grant_id, roles) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedArgs: []interface{}{
+								"grant-id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"project-id",
+								"project-grant-id",
+								database.StringArray{"key"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.GrantChangedEventType),
+					group.AggregateType,
+					[]byte(`{"grantId": "grant-id", "roleKeys": ["key", "key2"]}`),
+				), eventstore.GenericEventMapper[group.GrantChangedEvent]),
+			},
+			reduce: (&groupProjection{}).reduceGrantChanged,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups_grants SET (change_date, sequence, roles) = ($1, $2, $3) WHERE (instance_id = $4) AND (id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								database.StringArray{"key", "key2"},
+								"instance-id",
+								"grant-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(group.GrantRemovedEventType),
+					group.AggregateType,
+					[]byte(`{"grantId": "grant-id"}`),
+				), eventstore.GenericEventMapper[group.GrantRemovedEvent]),
+			},
+			reduce: (&groupProjection{}).reduceGrantRemoved,
+			want: wantReduce{
+				aggregateType:    group.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_grants WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"grant-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "user reduceUserRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					nil,
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_members WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceProjectRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ProjectRemovedType),
+					project.AggregateType,
+					nil,
+				), project.ProjectRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_grants WHERE (instance_id = $1) AND (project_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceProjectGrantRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.GrantRemovedType),
+					project.AggregateType,
+					[]byte(`{"grantId": "grant-id"}`),
+				), project.GrantRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceProjectGrantRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_grants WHERE (instance_id = $1) AND (grant_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"grant-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceRoleRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.RoleRemovedType),
+					project.AggregateType,
+					[]byte(`{"key": "key"}`),
+				), project.RoleRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceRoleRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups_grants SET roles = array_remove(roles, $1) WHERE (instance_id = $2) AND (project_id = $3)",
+							expectedArgs: []interface{}{
+								"key",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceProjectGrantChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.GrantChangedType),
+					project.AggregateType,
+					[]byte(`{"grantId": "grant-id", "roleKeys": ["key"]}`),
+				), project.GrantChangedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceProjectGrantChanged,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups_grants SET (roles) = (SELECT ARRAY( SELECT UNNEST(roles) INTERSECT SELECT UNNEST ($1::TEXT[]))) WHERE (instance_id = $2) AND (grant_id = $3)",
+							expectedArgs: []interface{}{
+								database.StringArray{"key"},
+								"instance-id",
+								"grant-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&groupProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(GroupColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    instance.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, GroupProjectionTable, tt.want)
+		})
+	}
+}
@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	LockoutPolicyTable = "projections.lockout_policies2"
+	LockoutPolicyTable = "projections.lockout_policies3"
 
 	LockoutPolicyIDCol                  = "id"
 	LockoutPolicyCreationDateCol        = "creation_date"
@@ -25,7 +25,11 @@ const (
 	LockoutPolicyResourceOwnerCol       = "resource_owner"
 	LockoutPolicyInstanceIDCol          = "instance_id"
 	LockoutPolicyMaxPasswordAttemptsCol = "max_password_attempts"
+	LockoutPolicyMaxOTPAttemptsCol      = "max_otp_attempts"
 	LockoutPolicyShowLockOutFailuresCol = "show_failure"
+	LockoutPolicyLockoutDurationCol     = "lockout_duration"
+	LockoutPolicyAttemptResetWindowCol  = "attempt_reset_window"
+	LockoutPolicyProgressiveLockoutCol  = "progressive_lockout"
 	LockoutPolicyOwnerRemovedCol        = "owner_removed"
 )
 
@@ -48,7 +52,11 @@ func newLockoutPolicyProjection(ctx context.Context, config crdb.StatementHandle
 			crdb.NewColumn(LockoutPolicyResourceOwnerCol, crdb.ColumnTypeText),
 			crdb.NewColumn(LockoutPolicyInstanceIDCol, crdb.ColumnTypeText),
 			crdb.NewColumn(LockoutPolicyMaxPasswordAttemptsCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(LockoutPolicyMaxOTPAttemptsCol, crdb.ColumnTypeInt64, crdb.Default(0)),
 			crdb.NewColumn(LockoutPolicyShowLockOutFailuresCol, crdb.ColumnTypeBool),
+			crdb.NewColumn(LockoutPolicyLockoutDurationCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(LockoutPolicyAttemptResetWindowCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(LockoutPolicyProgressiveLockoutCol, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(LockoutPolicyOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(LockoutPolicyInstanceIDCol, LockoutPolicyIDCol),
@@ -124,7 +132,11 @@ func (p *lockoutPolicyProjection) reduceAdded(event eventstore.Event) (*handler.
 			handler.NewCol(LockoutPolicyIDCol, policyEvent.Aggregate().ID),
 			handler.NewCol(LockoutPolicyStateCol, domain.PolicyStateActive),
 			handler.NewCol(LockoutPolicyMaxPasswordAttemptsCol, policyEvent.MaxPasswordAttempts),
+			handler.NewCol(LockoutPolicyMaxOTPAttemptsCol, policyEvent.MaxOTPAttempts),
 			handler.NewCol(LockoutPolicyShowLockOutFailuresCol, policyEvent.ShowLockOutFailures),
+			handler.NewCol(LockoutPolicyLockoutDurationCol, policyEvent.LockoutDuration),
+			handler.NewCol(LockoutPolicyAttemptResetWindowCol, policyEvent.AttemptResetWindow),
+			handler.NewCol(LockoutPolicyProgressiveLockoutCol, policyEvent.ProgressiveLockout),
 			handler.NewCol(LockoutPolicyIsDefaultCol, isDefault),
 			handler.NewCol(LockoutPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(LockoutPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
@@ -148,9 +160,21 @@ func (p *lockoutPolicyProjection) reduceChanged(event eventstore.Event) (*handle
 	if policyEvent.MaxPasswordAttempts != nil {
 		cols = append(cols, handler.NewCol(LockoutPolicyMaxPasswordAttemptsCol, *policyEvent.MaxPasswordAttempts))
 	}
+	if policyEvent.MaxOTPAttempts != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyMaxOTPAttemptsCol, *policyEvent.MaxOTPAttempts))
+	}
 	if policyEvent.ShowLockOutFailures != nil {
 		cols = append(cols, handler.NewCol(LockoutPolicyShowLockOutFailuresCol, *policyEvent.ShowLockOutFailures))
 	}
+	if policyEvent.LockoutDuration != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyLockoutDurationCol, *policyEvent.LockoutDuration))
+	}
+	if policyEvent.AttemptResetWindow != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyAttemptResetWindowCol, *policyEvent.AttemptResetWindow))
+	}
+	if policyEvent.ProgressiveLockout != nil {
+		cols = append(cols, handler.NewCol(LockoutPolicyProgressiveLockoutCol, *policyEvent.ProgressiveLockout))
+	}
 	return crdb.NewUpdateStatement(
 		&policyEvent,
 		cols,
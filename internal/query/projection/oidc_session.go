@@ -0,0 +1,123 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/oidcsession"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	OIDCSessionProjectionTable = "projections.oidc_sessions"
+
+	OIDCSessionColumnInstanceID    = "instance_id"
+	OIDCSessionColumnID            = "id"
+	OIDCSessionColumnSessionID     = "session_id"
+	OIDCSessionColumnClientID      = "client_id"
+	OIDCSessionColumnUserID        = "user_id"
+	OIDCSessionColumnCreationDate  = "creation_date"
+	OIDCSessionColumnChangeDate    = "change_date"
+	OIDCSessionColumnSequence      = "sequence"
+	OIDCSessionColumnResourceOwner = "resource_owner"
+)
+
+type oidcSessionProjection struct {
+	crdb.StatementHandler
+}
+
+func newOIDCSessionProjection(ctx context.Context, config crdb.StatementHandlerConfig) *oidcSessionProjection {
+	p := new(oidcSessionProjection)
+	config.ProjectionName = OIDCSessionProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(OIDCSessionColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(OIDCSessionColumnID, crdb.ColumnTypeText),
+			crdb.NewColumn(OIDCSessionColumnSessionID, crdb.ColumnTypeText),
+			crdb.NewColumn(OIDCSessionColumnClientID, crdb.ColumnTypeText),
+			crdb.NewColumn(OIDCSessionColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(OIDCSessionColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(OIDCSessionColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(OIDCSessionColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(OIDCSessionColumnResourceOwner, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(OIDCSessionColumnInstanceID, OIDCSessionColumnID),
+			crdb.WithIndex(crdb.NewIndex("session_id", []string{OIDCSessionColumnSessionID})),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{OIDCSessionColumnUserID})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *oidcSessionProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: oidcsession.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  oidcsession.AddedType,
+					Reduce: p.reduceAdded,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(OIDCSessionColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *oidcSessionProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*oidcsession.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rb3wd", "reduce.wrong.event.type %s", oidcsession.AddedType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OIDCSessionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(OIDCSessionColumnID, e.Aggregate().ID),
+			handler.NewCol(OIDCSessionColumnSessionID, e.SessionID),
+			handler.NewCol(OIDCSessionColumnClientID, e.ClientID),
+			handler.NewCol(OIDCSessionColumnUserID, e.UserID),
+			handler.NewCol(OIDCSessionColumnCreationDate, e.CreationDate()),
+			handler.NewCol(OIDCSessionColumnChangeDate, e.CreationDate()),
+			handler.NewCol(OIDCSessionColumnSequence, e.Sequence()),
+			handler.NewCol(OIDCSessionColumnResourceOwner, e.Aggregate().ResourceOwner),
+		},
+	), nil
+}
+
+func (p *oidcSessionProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Zy8ec", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(OIDCSessionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(OIDCSessionColumnUserID, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,188 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	PasswordHistoryTable = "projections.password_history_policies"
+
+	HistoryPolicyIDCol            = "id"
+	HistoryPolicyCreationDateCol  = "creation_date"
+	HistoryPolicyChangeDateCol    = "change_date"
+	HistoryPolicySequenceCol      = "sequence"
+	HistoryPolicyStateCol         = "state"
+	HistoryPolicyIsDefaultCol     = "is_default"
+	HistoryPolicyResourceOwnerCol = "resource_owner"
+	HistoryPolicyInstanceIDCol    = "instance_id"
+	HistoryPolicyHistoryCountCol  = "history_count"
+	HistoryPolicyOwnerRemovedCol  = "owner_removed"
+)
+
+type passwordHistoryProjection struct {
+	crdb.StatementHandler
+}
+
+func newPasswordHistoryProjection(ctx context.Context, config crdb.StatementHandlerConfig) *passwordHistoryProjection {
+	p := new(passwordHistoryProjection)
+	config.ProjectionName = PasswordHistoryTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(HistoryPolicyIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(HistoryPolicyCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(HistoryPolicyChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(HistoryPolicySequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(HistoryPolicyStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(HistoryPolicyIsDefaultCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(HistoryPolicyResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(HistoryPolicyInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(HistoryPolicyHistoryCountCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(HistoryPolicyOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(HistoryPolicyInstanceIDCol, HistoryPolicyIDCol),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{HistoryPolicyOwnerRemovedCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *passwordHistoryProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.PasswordHistoryPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.PasswordHistoryPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.PasswordHistoryPolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.PasswordHistoryPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.PasswordHistoryPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(HistoryPolicyInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *passwordHistoryProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.PasswordHistoryPolicyAddedEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.PasswordHistoryPolicyAddedEvent:
+		policyEvent = e.PasswordHistoryPolicyAddedEvent
+		isDefault = false
+	case *instance.PasswordHistoryPolicyAddedEvent:
+		policyEvent = e.PasswordHistoryPolicyAddedEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Hs2m1", "reduce.wrong.event.type %v", []eventstore.EventType{org.PasswordHistoryPolicyAddedEventType, instance.PasswordHistoryPolicyAddedEventType})
+	}
+	return crdb.NewCreateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(HistoryPolicyCreationDateCol, policyEvent.CreationDate()),
+			handler.NewCol(HistoryPolicyChangeDateCol, policyEvent.CreationDate()),
+			handler.NewCol(HistoryPolicySequenceCol, policyEvent.Sequence()),
+			handler.NewCol(HistoryPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCol(HistoryPolicyStateCol, domain.PolicyStateActive),
+			handler.NewCol(HistoryPolicyHistoryCountCol, policyEvent.HistoryCount),
+			handler.NewCol(HistoryPolicyIsDefaultCol, isDefault),
+			handler.NewCol(HistoryPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
+			handler.NewCol(HistoryPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *passwordHistoryProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.PasswordHistoryPolicyChangedEvent
+	switch e := event.(type) {
+	case *org.PasswordHistoryPolicyChangedEvent:
+		policyEvent = e.PasswordHistoryPolicyChangedEvent
+	case *instance.PasswordHistoryPolicyChangedEvent:
+		policyEvent = e.PasswordHistoryPolicyChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ws3lo", "reduce.wrong.event.type %v", []eventstore.EventType{org.PasswordHistoryPolicyChangedEventType, instance.PasswordHistoryPolicyChangedEventType})
+	}
+	cols := []handler.Column{
+		handler.NewCol(HistoryPolicyChangeDateCol, policyEvent.CreationDate()),
+		handler.NewCol(HistoryPolicySequenceCol, policyEvent.Sequence()),
+	}
+	if policyEvent.HistoryCount != nil {
+		cols = append(cols, handler.NewCol(HistoryPolicyHistoryCountCol, *policyEvent.HistoryCount))
+	}
+	return crdb.NewUpdateStatement(
+		&policyEvent,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(HistoryPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCond(HistoryPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *passwordHistoryProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.PasswordHistoryPolicyRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-k1Ps8", "reduce.wrong.event.type %s", org.PasswordHistoryPolicyRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(HistoryPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCond(HistoryPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *passwordHistoryProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Gq0dk", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(HistoryPolicyChangeDateCol, e.CreationDate()),
+			handler.NewCol(HistoryPolicySequenceCol, e.Sequence()),
+			handler.NewCol(HistoryPolicyOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(HistoryPolicyInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(HistoryPolicyResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -17,58 +17,67 @@ const (
 )
 
 var (
-	projectionConfig                    crdb.StatementHandlerConfig
-	OrgProjection                       *orgProjection
-	OrgMetadataProjection               *orgMetadataProjection
-	ActionProjection                    *actionProjection
-	FlowProjection                      *flowProjection
-	ProjectProjection                   *projectProjection
-	PasswordComplexityProjection        *passwordComplexityProjection
-	PasswordAgeProjection               *passwordAgeProjection
-	LockoutPolicyProjection             *lockoutPolicyProjection
-	PrivacyPolicyProjection             *privacyPolicyProjection
-	DomainPolicyProjection              *domainPolicyProjection
-	LabelPolicyProjection               *labelPolicyProjection
-	ProjectGrantProjection              *projectGrantProjection
-	ProjectRoleProjection               *projectRoleProjection
-	OrgDomainProjection                 *orgDomainProjection
-	LoginPolicyProjection               *loginPolicyProjection
-	IDPProjection                       *idpProjection
-	AppProjection                       *appProjection
-	IDPUserLinkProjection               *idpUserLinkProjection
-	IDPLoginPolicyLinkProjection        *idpLoginPolicyLinkProjection
-	IDPTemplateProjection               *idpTemplateProjection
-	MailTemplateProjection              *mailTemplateProjection
-	MessageTextProjection               *messageTextProjection
-	CustomTextProjection                *customTextProjection
-	UserProjection                      *userProjection
-	LoginNameProjection                 *loginNameProjection
-	OrgMemberProjection                 *orgMemberProjection
-	InstanceDomainProjection            *instanceDomainProjection
-	InstanceMemberProjection            *instanceMemberProjection
-	ProjectMemberProjection             *projectMemberProjection
-	ProjectGrantMemberProjection        *projectGrantMemberProjection
-	AuthNKeyProjection                  *authNKeyProjection
-	PersonalAccessTokenProjection       *personalAccessTokenProjection
-	UserGrantProjection                 *userGrantProjection
-	UserMetadataProjection              *userMetadataProjection
-	UserAuthMethodProjection            *userAuthMethodProjection
-	InstanceProjection                  *instanceProjection
-	SecretGeneratorProjection           *secretGeneratorProjection
-	SMTPConfigProjection                *smtpConfigProjection
-	SMSConfigProjection                 *smsConfigProjection
-	OIDCSettingsProjection              *oidcSettingsProjection
-	DebugNotificationProviderProjection *debugNotificationProviderProjection
-	KeyProjection                       *keyProjection
-	SecurityPolicyProjection            *securityPolicyProjection
-	NotificationPolicyProjection        *notificationPolicyProjection
-	NotificationsProjection             interface{}
-	NotificationsQuotaProjection        interface{}
-	TelemetryPusherProjection           interface{}
-	DeviceAuthProjection                *deviceAuthProjection
-	SessionProjection                   *sessionProjection
-	AuthRequestProjection               *authRequestProjection
-	MilestoneProjection                 *milestoneProjection
+	projectionConfig                      crdb.StatementHandlerConfig
+	OrgProjection                         *orgProjection
+	OrgMetadataProjection                 *orgMetadataProjection
+	ActionProjection                      *actionProjection
+	FlowProjection                        *flowProjection
+	ProjectProjection                     *projectProjection
+	PasswordComplexityProjection          *passwordComplexityProjection
+	PasswordAgeProjection                 *passwordAgeProjection
+	PasswordHistoryProjection             *passwordHistoryProjection
+	LockoutPolicyProjection               *lockoutPolicyProjection
+	PrivacyPolicyProjection               *privacyPolicyProjection
+	DomainPolicyProjection                *domainPolicyProjection
+	LabelPolicyProjection                 *labelPolicyProjection
+	ProjectGrantProjection                *projectGrantProjection
+	ProjectRoleProjection                 *projectRoleProjection
+	OrgDomainProjection                   *orgDomainProjection
+	LoginPolicyProjection                 *loginPolicyProjection
+	IDPProjection                         *idpProjection
+	AppProjection                         *appProjection
+	IDPUserLinkProjection                 *idpUserLinkProjection
+	IDPLoginPolicyLinkProjection          *idpLoginPolicyLinkProjection
+	IDPTemplateProjection                 *idpTemplateProjection
+	MailTemplateProjection                *mailTemplateProjection
+	MessageTextProjection                 *messageTextProjection
+	CustomTextProjection                  *customTextProjection
+	UserProjection                        *userProjection
+	LoginNameProjection                   *loginNameProjection
+	OrgMemberProjection                   *orgMemberProjection
+	InstanceDomainProjection              *instanceDomainProjection
+	InstanceMemberProjection              *instanceMemberProjection
+	ProjectMemberProjection               *projectMemberProjection
+	ProjectGrantMemberProjection          *projectGrantMemberProjection
+	AuthNKeyProjection                    *authNKeyProjection
+	PersonalAccessTokenProjection         *personalAccessTokenProjection
+	UserGrantProjection                   *userGrantProjection
+	UserMetadataProjection                *userMetadataProjection
+	UserAuthMethodProjection              *userAuthMethodProjection
+	InstanceProjection                    *instanceProjection
+	SecretGeneratorProjection             *secretGeneratorProjection
+	SMTPConfigProjection                  *smtpConfigProjection
+	SMSConfigProjection                   *smsConfigProjection
+	OIDCSettingsProjection                *oidcSettingsProjection
+	DebugNotificationProviderProjection   *debugNotificationProviderProjection
+	KeyProjection                         *keyProjection
+	SecurityPolicyProjection              *securityPolicyProjection
+	TokenExchangePolicyProjection         *tokenExchangePolicyProjection
+	NotificationPolicyProjection          *notificationPolicyProjection
+	NotificationsProjection               interface{}
+	NotificationsQuotaProjection          interface{}
+	NotificationsBackChannelProjection    interface{}
+	NotificationsEventExecutionProjection interface{}
+	TelemetryPusherProjection             interface{}
+	DeviceAuthProjection                  *deviceAuthProjection
+	SessionProjection                     *sessionProjection
+	AuthRequestProjection                 *authRequestProjection
+	MilestoneProjection                   *milestoneProjection
+	TargetProjection                      *targetProjection
+	ExecutionProjection                   *executionProjection
+	SAMLSessionProjection                 *samlSessionProjection
+	OIDCSessionProjection                 *oidcSessionProjection
+	GroupProjection                       *groupProjection
 )
 
 type projection interface {
@@ -107,6 +116,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	ProjectProjection = newProjectProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["projects"]))
 	PasswordComplexityProjection = newPasswordComplexityProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_complexities"]))
 	PasswordAgeProjection = newPasswordAgeProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_age_policy"]))
+	PasswordHistoryProjection = newPasswordHistoryProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_history_policy"]))
 	LockoutPolicyProjection = newLockoutPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["lockout_policy"]))
 	PrivacyPolicyProjection = newPrivacyPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["privacy_policy"]))
 	DomainPolicyProjection = newDomainPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_iam_policy"]))
@@ -143,11 +153,17 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	DebugNotificationProviderProjection = newDebugNotificationProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_notification_provider"]))
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
+	TokenExchangePolicyProjection = newTokenExchangePolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["token_exchange_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
 	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
 	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
 	AuthRequestProjection = newAuthRequestProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["auth_requests"]))
 	MilestoneProjection = newMilestoneProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["milestones"]))
+	TargetProjection = newTargetProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["targets"]))
+	ExecutionProjection = newExecutionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["executions"]))
+	SAMLSessionProjection = newSAMLSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["saml_sessions"]))
+	OIDCSessionProjection = newOIDCSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["oidc_sessions"]))
+	GroupProjection = newGroupProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["groups"]))
 	newProjectionsList()
 	return nil
 }
@@ -196,7 +212,7 @@ func applyCustomConfig(config crdb.StatementHandlerConfig, customConfig CustomCo
 // as setup and start currently create them individually, we make sure we get the right one
 // will be refactored when changing to new id based projections
 //
-// Event handlers NotificationsProjection, NotificationsQuotaProjection and NotificationsProjection are not added here, because they do not reduce to database statements
+// Event handlers NotificationsProjection, NotificationsQuotaProjection, NotificationsBackChannelProjection, NotificationsEventExecutionProjection and NotificationsProjection are not added here, because they do not reduce to database statements
 func newProjectionsList() {
 	projections = []projection{
 		OrgProjection,
@@ -206,6 +222,7 @@ func newProjectionsList() {
 		ProjectProjection,
 		PasswordComplexityProjection,
 		PasswordAgeProjection,
+		PasswordHistoryProjection,
 		LockoutPolicyProjection,
 		PrivacyPolicyProjection,
 		DomainPolicyProjection,
@@ -242,10 +259,16 @@ func newProjectionsList() {
 		DebugNotificationProviderProjection,
 		KeyProjection,
 		SecurityPolicyProjection,
+		TokenExchangePolicyProjection,
 		NotificationPolicyProjection,
 		DeviceAuthProjection,
 		SessionProjection,
 		AuthRequestProjection,
 		MilestoneProjection,
+		TargetProjection,
+		ExecutionProjection,
+		SAMLSessionProjection,
+		OIDCSessionProjection,
+		GroupProjection,
 	}
 }
@@ -0,0 +1,175 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	SAMLSessionProjectionTable = "projections.saml_sessions"
+
+	SAMLSessionColumnInstanceID    = "instance_id"
+	SAMLSessionColumnUserAgentID   = "user_agent_id"
+	SAMLSessionColumnUserID        = "user_id"
+	SAMLSessionColumnApplicationID = "app_id"
+	SAMLSessionColumnCreationDate  = "creation_date"
+	SAMLSessionColumnChangeDate    = "change_date"
+	SAMLSessionColumnSequence      = "sequence"
+	SAMLSessionColumnResourceOwner = "resource_owner"
+	SAMLSessionColumnEntityID      = "entity_id"
+	SAMLSessionColumnNameID        = "name_id"
+	SAMLSessionColumnNameIDFormat  = "name_id_format"
+	SAMLSessionColumnSessionIndex  = "session_index"
+)
+
+type samlSessionProjection struct {
+	crdb.StatementHandler
+}
+
+func newSAMLSessionProjection(ctx context.Context, config crdb.StatementHandlerConfig) *samlSessionProjection {
+	p := new(samlSessionProjection)
+	config.ProjectionName = SAMLSessionProjectionTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(SAMLSessionColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(SAMLSessionColumnUserAgentID, crdb.ColumnTypeText),
+			crdb.NewColumn(SAMLSessionColumnUserID, crdb.ColumnTypeText),
+			crdb.NewColumn(SAMLSessionColumnApplicationID, crdb.ColumnTypeText),
+			crdb.NewColumn(SAMLSessionColumnCreationDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(SAMLSessionColumnChangeDate, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(SAMLSessionColumnSequence, crdb.ColumnTypeInt64),
+			crdb.NewColumn(SAMLSessionColumnResourceOwner, crdb.ColumnTypeText),
+			crdb.NewColumn(SAMLSessionColumnEntityID, crdb.ColumnTypeText),
+			crdb.NewColumn(SAMLSessionColumnNameID, crdb.ColumnTypeText),
+			crdb.NewColumn(SAMLSessionColumnNameIDFormat, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(SAMLSessionColumnSessionIndex, crdb.ColumnTypeText, crdb.Default("")),
+		},
+			crdb.NewPrimaryKey(SAMLSessionColumnInstanceID, SAMLSessionColumnUserAgentID, SAMLSessionColumnUserID, SAMLSessionColumnApplicationID),
+			crdb.WithIndex(crdb.NewIndex("user_id", []string{SAMLSessionColumnUserID})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *samlSessionProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.SAMLSessionAddedType,
+					Reduce: p.reduceSAMLSessionAdded,
+				},
+				{
+					Event:  user.SAMLSessionTerminatedType,
+					Reduce: p.reduceSAMLSessionTerminated,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  project.ApplicationRemovedType,
+					Reduce: p.reduceApplicationRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(SAMLSessionColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *samlSessionProjection) reduceSAMLSessionAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.SAMLSessionAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ud8sf", "reduce.wrong.event.type %s", user.SAMLSessionAddedType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SAMLSessionColumnInstanceID, nil),
+			handler.NewCol(SAMLSessionColumnUserAgentID, nil),
+			handler.NewCol(SAMLSessionColumnUserID, nil),
+			handler.NewCol(SAMLSessionColumnApplicationID, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(SAMLSessionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(SAMLSessionColumnUserAgentID, e.UserAgentID),
+			handler.NewCol(SAMLSessionColumnUserID, e.Aggregate().ID),
+			handler.NewCol(SAMLSessionColumnApplicationID, e.ApplicationID),
+			handler.NewCol(SAMLSessionColumnCreationDate, e.CreationDate()),
+			handler.NewCol(SAMLSessionColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SAMLSessionColumnSequence, e.Sequence()),
+			handler.NewCol(SAMLSessionColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(SAMLSessionColumnEntityID, e.EntityID),
+			handler.NewCol(SAMLSessionColumnNameID, e.NameID),
+			handler.NewCol(SAMLSessionColumnNameIDFormat, e.NameIDFormat),
+			handler.NewCol(SAMLSessionColumnSessionIndex, e.SessionIndex),
+		},
+	), nil
+}
+
+func (p *samlSessionProjection) reduceSAMLSessionTerminated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.SAMLSessionTerminatedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pq0ds", "reduce.wrong.event.type %s", user.SAMLSessionTerminatedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SAMLSessionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(SAMLSessionColumnUserAgentID, e.UserAgentID),
+			handler.NewCond(SAMLSessionColumnUserID, e.Aggregate().ID),
+			handler.NewCond(SAMLSessionColumnApplicationID, e.ApplicationID),
+		},
+	), nil
+}
+
+func (p *samlSessionProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Lx7ak", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SAMLSessionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(SAMLSessionColumnUserID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *samlSessionProjection) reduceApplicationRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.ApplicationRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ge3nw", "reduce.wrong.event.type %s", project.ApplicationRemovedType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SAMLSessionColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(SAMLSessionColumnApplicationID, e.AppID),
+		},
+	), nil
+}
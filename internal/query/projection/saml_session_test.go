@@ -0,0 +1,195 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestSAMLSessionProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSAMLSessionAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.SAMLSessionAddedType),
+					user.AggregateType,
+					[]byte(`{
+	"userAgentId": "agent-id",
+	"applicationId": "app-id",
+	"entityId": "https://sp.example.com/metadata",
+	"nameId": "username",
+	"nameIdFormat": "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+	"sessionIndex": "session-index"
+}`),
+				), user.SAMLSessionAddedEventMapper),
+			},
+			reduce: (&samlSessionProjection{}).reduceSAMLSessionAdded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.saml_sessions (instance_id, user_agent_id, user_id, app_id, creation_date, change_date, sequence, resource_owner, entity_id, name_id, name_id_format, session_index) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (instance_id, user_agent_id, user_id, app_id) DO UPDATE SET (creation_date, change_date, sequence, resource_owner, entity_id, name_id, name_id_format, session_index) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.resource_owner, EXCLUDED.entity_id, EXCLUDED.name_id, EXCLUDED.name_id_format, EXCLUDED.session_index)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agent-id",
+								"agg-id",
+								"app-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"https://sp.example.com/metadata",
+								"username",
+								"urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+								"session-index",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSAMLSessionTerminated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.SAMLSessionTerminatedType),
+					user.AggregateType,
+					[]byte(`{
+	"userAgentId": "agent-id",
+	"applicationId": "app-id"
+}`),
+				), user.SAMLSessionTerminatedEventMapper),
+			},
+			reduce: (&samlSessionProjection{}).reduceSAMLSessionTerminated,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.saml_sessions WHERE (instance_id = $1) AND (user_agent_id = $2) AND (user_id = $3) AND (app_id = $4)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agent-id",
+								"agg-id",
+								"app-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.UserRemovedType),
+					user.AggregateType,
+					[]byte(`{}`),
+				), user.UserRemovedEventMapper),
+			},
+			reduce: (&samlSessionProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.saml_sessions WHERE (instance_id = $1) AND (user_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceApplicationRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.ApplicationRemovedType),
+					project.AggregateType,
+					[]byte(`{"appId": "app-id"}`),
+				), project.ApplicationRemovedEventMapper),
+			},
+			reduce: (&samlSessionProjection{}).reduceApplicationRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.saml_sessions WHERE (instance_id = $1) AND (app_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(SAMLSessionColumnInstanceID),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.saml_sessions WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, SAMLSessionProjectionTable, tt.want)
+		})
+	}
+}
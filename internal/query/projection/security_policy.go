@@ -11,13 +11,15 @@ import (
 )
 
 const (
-	SecurityPolicyProjectionTable      = "projections.security_policies"
+	SecurityPolicyProjectionTable      = "projections.security_policies2"
 	SecurityPolicyColumnInstanceID     = "instance_id"
 	SecurityPolicyColumnCreationDate   = "creation_date"
 	SecurityPolicyColumnChangeDate     = "change_date"
 	SecurityPolicyColumnSequence       = "sequence"
 	SecurityPolicyColumnEnabled        = "enabled"
 	SecurityPolicyColumnAllowedOrigins = "origins"
+	SecurityPolicyColumnDelegation     = "enable_delegation"
+	SecurityPolicyColumnImpersonation  = "enable_impersonation"
 )
 
 type securityPolicyProjection struct {
@@ -36,6 +38,8 @@ func newSecurityPolicyProjection(ctx context.Context, config crdb.StatementHandl
 			crdb.NewColumn(SecurityPolicyColumnSequence, crdb.ColumnTypeInt64),
 			crdb.NewColumn(SecurityPolicyColumnEnabled, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(SecurityPolicyColumnAllowedOrigins, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(SecurityPolicyColumnDelegation, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(SecurityPolicyColumnImpersonation, crdb.ColumnTypeBool, crdb.Default(false)),
 		},
 			crdb.NewPrimaryKey(SecurityPolicyColumnInstanceID),
 		),
@@ -79,6 +83,12 @@ func (p *securityPolicyProjection) reduceSecurityPolicySet(event eventstore.Even
 	if e.AllowedOrigins != nil {
 		changes = append(changes, handler.NewCol(SecurityPolicyColumnAllowedOrigins, e.AllowedOrigins))
 	}
+	if e.EnableDelegation != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnDelegation, *e.EnableDelegation))
+	}
+	if e.EnableImpersonation != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnImpersonation, *e.EnableImpersonation))
+	}
 	return crdb.NewUpsertStatement(
 		e,
 		[]handler.Column{
@@ -0,0 +1,164 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+const (
+	TargetTable               = "projections.targets"
+	TargetIDCol               = "id"
+	TargetCreationDateCol     = "creation_date"
+	TargetChangeDateCol       = "change_date"
+	TargetResourceOwnerCol    = "resource_owner"
+	TargetInstanceIDCol       = "instance_id"
+	TargetSequenceCol         = "sequence"
+	TargetNameCol             = "name"
+	TargetEndpointCol         = "endpoint"
+	TargetTimeoutCol          = "timeout"
+	TargetAsyncCol            = "async"
+	TargetInterruptOnErrorCol = "interrupt_on_error"
+	TargetSigningKeyCol       = "signing_key"
+)
+
+type targetProjection struct {
+	crdb.StatementHandler
+}
+
+func newTargetProjection(ctx context.Context, config crdb.StatementHandlerConfig) *targetProjection {
+	p := new(targetProjection)
+	config.ProjectionName = TargetTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(TargetIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(TargetCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TargetChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TargetResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(TargetInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(TargetSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(TargetNameCol, crdb.ColumnTypeText),
+			crdb.NewColumn(TargetEndpointCol, crdb.ColumnTypeText),
+			crdb.NewColumn(TargetTimeoutCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(TargetAsyncCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(TargetInterruptOnErrorCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(TargetSigningKeyCol, crdb.ColumnTypeJSONB),
+		},
+			crdb.NewPrimaryKey(TargetInstanceIDCol, TargetIDCol),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *targetProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: target.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  target.AddedEventType,
+					Reduce: p.reduceTargetAdded,
+				},
+				{
+					Event:  target.ChangedEventType,
+					Reduce: p.reduceTargetChanged,
+				},
+				{
+					Event:  target.RemovedEventType,
+					Reduce: p.reduceTargetRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(TargetInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *targetProjection) reduceTargetAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*target.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nx8Yl", "reduce.wrong.event.type %s", target.AddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TargetIDCol, e.Aggregate().ID),
+			handler.NewCol(TargetCreationDateCol, e.CreationDate()),
+			handler.NewCol(TargetChangeDateCol, e.CreationDate()),
+			handler.NewCol(TargetResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(TargetInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(TargetSequenceCol, e.Sequence()),
+			handler.NewCol(TargetNameCol, e.Name),
+			handler.NewCol(TargetEndpointCol, e.Endpoint),
+			handler.NewCol(TargetTimeoutCol, e.Timeout),
+			handler.NewCol(TargetAsyncCol, e.Async),
+			handler.NewCol(TargetInterruptOnErrorCol, e.InterruptOnError),
+			handler.NewCol(TargetSigningKeyCol, e.SigningKey),
+		},
+	), nil
+}
+
+func (p *targetProjection) reduceTargetChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*target.ChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-4Ri9C", "reduce.wrong.event.type %s", target.ChangedEventType)
+	}
+	values := []handler.Column{
+		handler.NewCol(TargetChangeDateCol, e.CreationDate()),
+		handler.NewCol(TargetSequenceCol, e.Sequence()),
+	}
+	if e.Name != nil {
+		values = append(values, handler.NewCol(TargetNameCol, *e.Name))
+	}
+	if e.Endpoint != nil {
+		values = append(values, handler.NewCol(TargetEndpointCol, *e.Endpoint))
+	}
+	if e.Timeout != nil {
+		values = append(values, handler.NewCol(TargetTimeoutCol, *e.Timeout))
+	}
+	if e.Async != nil {
+		values = append(values, handler.NewCol(TargetAsyncCol, *e.Async))
+	}
+	if e.InterruptOnError != nil {
+		values = append(values, handler.NewCol(TargetInterruptOnErrorCol, *e.InterruptOnError))
+	}
+	if e.SigningKey != nil {
+		values = append(values, handler.NewCol(TargetSigningKeyCol, e.SigningKey))
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		values,
+		[]handler.Condition{
+			handler.NewCond(TargetIDCol, e.Aggregate().ID),
+			handler.NewCond(TargetInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *targetProjection) reduceTargetRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*target.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-AhMBp", "reduce.wrong.event.type %s", target.RemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(TargetIDCol, e.Aggregate().ID),
+			handler.NewCond(TargetInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
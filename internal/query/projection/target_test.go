@@ -0,0 +1,166 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/target"
+)
+
+func TestTargetProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceTargetAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(target.AddedEventType),
+					target.AggregateType,
+					[]byte(`{"name": "name", "endpoint":"https://example.com","timeout": 3000000000, "async": true, "signingKey": {"cryptoType": 0, "algorithm": "enc", "keyID": "id", "crypted": "c2lnbmluZ2tleQ=="}}`),
+				), eventstore.GenericEventMapper[target.AddedEvent]),
+			},
+			reduce: (&targetProjection{}).reduceTargetAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("target"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.targets (id, creation_date, change_date, resource_owner, instance_id, sequence, name, endpoint, timeout, async, interrupt_on_error, signing_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								"name",
+								"https://example.com",
+								3 * time.Second,
+								true,
+								false,
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("signingkey"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTargetChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(target.ChangedEventType),
+					target.AggregateType,
+					[]byte(`{"name": "name2", "interruptOnError": true}`),
+				), eventstore.GenericEventMapper[target.ChangedEvent]),
+			},
+			reduce: (&targetProjection{}).reduceTargetChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("target"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.targets SET (change_date, sequence, name, interrupt_on_error) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"name2",
+								true,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTargetRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(target.RemovedEventType),
+					target.AggregateType,
+					[]byte(`{}`),
+				), eventstore.GenericEventMapper[target.RemovedEvent]),
+			},
+			reduce: (&targetProjection{}).reduceTargetRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("target"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.targets WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(TargetInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.targets WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, TargetTable, tt.want)
+		})
+	}
+}
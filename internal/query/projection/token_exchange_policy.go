@@ -0,0 +1,118 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	TokenExchangePolicyTable = "projections.token_exchange_policies"
+
+	TokenExchangePolicyIDCol                   = "id"
+	TokenExchangePolicyInstanceIDCol           = "instance_id"
+	TokenExchangePolicyCreationDateCol         = "creation_date"
+	TokenExchangePolicyChangeDateCol           = "change_date"
+	TokenExchangePolicySequenceCol             = "sequence"
+	TokenExchangePolicyDisableDelegationCol    = "disable_delegation"
+	TokenExchangePolicyDisableImpersonationCol = "disable_impersonation"
+)
+
+type tokenExchangePolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func newTokenExchangePolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *tokenExchangePolicyProjection {
+	p := new(tokenExchangePolicyProjection)
+	config.ProjectionName = TokenExchangePolicyTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(TokenExchangePolicyIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenExchangePolicyInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(TokenExchangePolicyCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenExchangePolicyChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(TokenExchangePolicySequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(TokenExchangePolicyDisableDelegationCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(TokenExchangePolicyDisableImpersonationCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(TokenExchangePolicyInstanceIDCol, TokenExchangePolicyIDCol),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *tokenExchangePolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.TokenExchangePolicySetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(TokenExchangePolicyInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *tokenExchangePolicyProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.TokenExchangePolicySetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Bv8sw", "reduce.wrong.event.type %s", org.TokenExchangePolicySetEventType)
+	}
+	changes := []handler.Column{
+		handler.NewCol(TokenExchangePolicyInstanceIDCol, e.Aggregate().InstanceID),
+		handler.NewCol(TokenExchangePolicyIDCol, e.Aggregate().ID),
+		handler.NewCol(TokenExchangePolicyCreationDateCol, e.CreationDate()),
+		handler.NewCol(TokenExchangePolicyChangeDateCol, e.CreationDate()),
+		handler.NewCol(TokenExchangePolicySequenceCol, e.Sequence()),
+	}
+	if e.DisableDelegation != nil {
+		changes = append(changes, handler.NewCol(TokenExchangePolicyDisableDelegationCol, *e.DisableDelegation))
+	}
+	if e.DisableImpersonation != nil {
+		changes = append(changes, handler.NewCol(TokenExchangePolicyDisableImpersonationCol, *e.DisableImpersonation))
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(TokenExchangePolicyInstanceIDCol, nil),
+			handler.NewCol(TokenExchangePolicyIDCol, nil),
+		},
+		changes,
+	), nil
+}
+
+func (p *tokenExchangePolicyProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ke3zt", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(TokenExchangePolicyInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(TokenExchangePolicyIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
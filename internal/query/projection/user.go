@@ -19,7 +19,7 @@ type userProjection struct {
 }
 
 const (
-	UserTable        = "projections.users8"
+	UserTable        = "projections.users9"
 	UserHumanTable   = UserTable + "_" + UserHumanSuffix
 	UserMachineTable = UserTable + "_" + UserMachineSuffix
 	UserNotifyTable  = UserTable + "_" + UserNotifySuffix
@@ -34,6 +34,7 @@ const (
 	UserUsernameCol      = "username"
 	UserTypeCol          = "type"
 	UserOwnerRemovedCol  = "owner_removed"
+	UserLockedUntilCol   = "locked_until"
 
 	UserHumanSuffix        = "humans"
 	HumanUserIDCol         = "user_id"
@@ -92,6 +93,7 @@ func newUserProjection(ctx context.Context, config crdb.StatementHandlerConfig)
 			crdb.NewColumn(UserUsernameCol, crdb.ColumnTypeText),
 			crdb.NewColumn(UserTypeCol, crdb.ColumnTypeEnum),
 			crdb.NewColumn(UserOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(UserLockedUntilCol, crdb.ColumnTypeTimestamp, crdb.Nullable()),
 		},
 			crdb.NewPrimaryKey(UserInstanceIDCol, UserIDCol),
 			crdb.WithIndex(crdb.NewIndex("username", []string{UserUsernameCol})),
@@ -453,6 +455,7 @@ func (p *userProjection) reduceUserLocked(event eventstore.Event) (*handler.Stat
 			handler.NewCol(UserChangeDateCol, e.CreationDate()),
 			handler.NewCol(UserStateCol, domain.UserStateLocked),
 			handler.NewCol(UserSequenceCol, e.Sequence()),
+			handler.NewCol(UserLockedUntilCol, &sql.NullTime{Time: e.LockedUntil(), Valid: !e.LockedUntil().IsZero()}),
 		},
 		[]handler.Condition{
 			handler.NewCond(UserIDCol, e.Aggregate().ID),
@@ -473,6 +476,7 @@ func (p *userProjection) reduceUserUnlocked(event eventstore.Event) (*handler.St
 			handler.NewCol(UserChangeDateCol, e.CreationDate()),
 			handler.NewCol(UserStateCol, domain.UserStateActive),
 			handler.NewCol(UserSequenceCol, e.Sequence()),
+			handler.NewCol(UserLockedUntilCol, nil),
 		},
 		[]handler.Condition{
 			handler.NewCond(UserIDCol, e.Aggregate().ID),
@@ -20,6 +20,8 @@ import (
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/repository/action"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/execution"
+	"github.com/zitadel/zitadel/internal/repository/group"
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
@@ -27,6 +29,7 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/project"
 	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/target"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/repository/usergrant"
 )
@@ -36,6 +39,7 @@ type Queries struct {
 	client     *database.DB
 
 	idpConfigEncryption  crypto.EncryptionAlgorithm
+	targetEncryption     crypto.EncryptionAlgorithm
 	sessionTokenVerifier func(ctx context.Context, sessionToken string, sessionID string, tokenID string) (err error)
 	checkPermission      domain.PermissionCheck
 
@@ -93,8 +97,12 @@ func StartQueries(
 	idpintent.RegisterEventMappers(repo.eventstore)
 	authrequest.RegisterEventMappers(repo.eventstore)
 	oidcsession.RegisterEventMappers(repo.eventstore)
+	target.RegisterEventMappers(repo.eventstore)
+	execution.RegisterEventMappers(repo.eventstore)
+	group.RegisterEventMappers(repo.eventstore)
 
 	repo.idpConfigEncryption = idpConfigEncryption
+	repo.targetEncryption = keyEncryptionAlgorithm
 	repo.multifactors = domain.MultifactorConfigs{
 		OTP: domain.OTPConfig{
 			CryptoMFA: otpEncryption,
@@ -10,6 +10,8 @@ type quotaReadModel struct {
 	unit   quota.Unit
 	active bool
 	config *quota.AddedEvent
+	// degradation is set as long as the instance is in the degraded mode of the quota
+	degradation *quota.DegradedModeEnteredEvent
 }
 
 // newQuotaReadModel aggregateId is filled by reducing unit matching events
@@ -33,6 +35,8 @@ func (rm *quotaReadModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(
 			quota.AddedEventType,
 			quota.RemovedEventType,
+			quota.DegradedModeEnteredEventType,
+			quota.DegradedModeLeftEventType,
 		).EventData(map[string]interface{}{"unit": rm.unit})
 
 	return query.Builder()
@@ -49,6 +53,11 @@ func (rm *quotaReadModel) Reduce() error {
 			rm.AggregateID = e.Aggregate().ID
 			rm.active = false
 			rm.config = nil
+			rm.degradation = nil
+		case *quota.DegradedModeEnteredEvent:
+			rm.degradation = e
+		case *quota.DegradedModeLeftEvent:
+			rm.degradation = nil
 		}
 	}
 	return rm.ReadModel.Reduce()
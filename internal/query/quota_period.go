@@ -16,6 +16,16 @@ func (q *Queries) GetCurrentQuotaPeriod(ctx context.Context, instanceID string,
 	return rm.config, pushPeriodStart(rm.config.From, rm.config.ResetInterval, time.Now()), nil
 }
 
+// GetQuotaDegradation returns the event of the instance entering the degraded mode of the quota of the unit,
+// nil is returned if the instance is not in degraded mode
+func (q *Queries) GetQuotaDegradation(ctx context.Context, instanceID string, unit quota.Unit) (*quota.DegradedModeEnteredEvent, error) {
+	rm, err := q.getQuotaReadModel(ctx, instanceID, instanceID, unit)
+	if err != nil || !rm.active {
+		return nil, err
+	}
+	return rm.degradation, nil
+}
+
 func pushPeriodStart(from time.Time, interval time.Duration, now time.Time) time.Time {
 	next := from.Add(interval)
 	if next.After(now) {
@@ -0,0 +1,155 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	samlSessionsTable = table{
+		name:          projection.SAMLSessionProjectionTable,
+		instanceIDCol: projection.SAMLSessionColumnInstanceID,
+	}
+	SAMLSessionColumnInstanceID = Column{
+		name:  projection.SAMLSessionColumnInstanceID,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnUserAgentID = Column{
+		name:  projection.SAMLSessionColumnUserAgentID,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnUserID = Column{
+		name:  projection.SAMLSessionColumnUserID,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnApplicationID = Column{
+		name:  projection.SAMLSessionColumnApplicationID,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnCreationDate = Column{
+		name:  projection.SAMLSessionColumnCreationDate,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnChangeDate = Column{
+		name:  projection.SAMLSessionColumnChangeDate,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnResourceOwner = Column{
+		name:  projection.SAMLSessionColumnResourceOwner,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnSequence = Column{
+		name:  projection.SAMLSessionColumnSequence,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnEntityID = Column{
+		name:  projection.SAMLSessionColumnEntityID,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnNameID = Column{
+		name:  projection.SAMLSessionColumnNameID,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnNameIDFormat = Column{
+		name:  projection.SAMLSessionColumnNameIDFormat,
+		table: samlSessionsTable,
+	}
+	SAMLSessionColumnSessionIndex = Column{
+		name:  projection.SAMLSessionColumnSessionIndex,
+		table: samlSessionsTable,
+	}
+)
+
+// SAMLSession is the session of a user on a SAML service provider (application) started on a user agent
+type SAMLSession struct {
+	UserAgentID   string
+	UserID        string
+	ApplicationID string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	EntityID      string
+	NameID        string
+	NameIDFormat  string
+	SessionIndex  string
+}
+
+// SAMLSessionsByUserAgentID returns all SAML sessions of the user agent, which have not been terminated
+func (q *Queries) SAMLSessionsByUserAgentID(ctx context.Context, shouldTriggerBulk bool, userAgentID string) (_ []*SAMLSession, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = projection.SAMLSessionProjection.Trigger(ctx)
+	}
+
+	query, scan := prepareSAMLSessionsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		SAMLSessionColumnUserAgentID.identifier(): userAgentID,
+		SAMLSessionColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Wc8zr", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Vn2js", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func prepareSAMLSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*SAMLSession, error)) {
+	return sq.Select(
+			SAMLSessionColumnUserAgentID.identifier(),
+			SAMLSessionColumnUserID.identifier(),
+			SAMLSessionColumnApplicationID.identifier(),
+			SAMLSessionColumnCreationDate.identifier(),
+			SAMLSessionColumnChangeDate.identifier(),
+			SAMLSessionColumnResourceOwner.identifier(),
+			SAMLSessionColumnSequence.identifier(),
+			SAMLSessionColumnEntityID.identifier(),
+			SAMLSessionColumnNameID.identifier(),
+			SAMLSessionColumnNameIDFormat.identifier(),
+			SAMLSessionColumnSessionIndex.identifier(),
+		).From(samlSessionsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*SAMLSession, error) {
+			sessions := make([]*SAMLSession, 0)
+			for rows.Next() {
+				session := new(SAMLSession)
+				err := rows.Scan(
+					&session.UserAgentID,
+					&session.UserID,
+					&session.ApplicationID,
+					&session.CreationDate,
+					&session.ChangeDate,
+					&session.ResourceOwner,
+					&session.Sequence,
+					&session.EntityID,
+					&session.NameID,
+					&session.NameIDFormat,
+					&session.SessionIndex,
+				)
+				if err != nil {
+					return nil, err
+				}
+				sessions = append(sessions, session)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Rk4fx", "Errors.Query.CloseRows")
+			}
+			return sessions, nil
+		}
+}
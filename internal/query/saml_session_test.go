@@ -0,0 +1,151 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	prepareSAMLSessionsStmt = `SELECT projections.saml_sessions.user_agent_id,` +
+		` projections.saml_sessions.user_id,` +
+		` projections.saml_sessions.app_id,` +
+		` projections.saml_sessions.creation_date,` +
+		` projections.saml_sessions.change_date,` +
+		` projections.saml_sessions.resource_owner,` +
+		` projections.saml_sessions.sequence,` +
+		` projections.saml_sessions.entity_id,` +
+		` projections.saml_sessions.name_id,` +
+		` projections.saml_sessions.name_id_format,` +
+		` projections.saml_sessions.session_index` +
+		` FROM projections.saml_sessions` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareSAMLSessionsCols = []string{
+		"user_agent_id",
+		"user_id",
+		"app_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"entity_id",
+		"name_id",
+		"name_id_format",
+		"session_index",
+	}
+)
+
+func Test_SAMLSessionPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareSAMLSessionsQuery no result",
+			prepare: prepareSAMLSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSAMLSessionsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*SAMLSession{},
+		},
+		{
+			name:    "prepareSAMLSessionsQuery multiple results",
+			prepare: prepareSAMLSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSAMLSessionsStmt),
+					prepareSAMLSessionsCols,
+					[][]driver.Value{
+						{
+							"agent-id",
+							"user-id",
+							"app-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							"https://sp.example.com/metadata",
+							"username",
+							"urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+							"session-index",
+						},
+						{
+							"agent-id",
+							"user-id",
+							"app-id-2",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211110),
+							"https://sp2.example.com/metadata",
+							"username",
+							"",
+							"",
+						},
+					},
+				),
+			},
+			object: []*SAMLSession{
+				{
+					UserAgentID:   "agent-id",
+					UserID:        "user-id",
+					ApplicationID: "app-id",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211109,
+					EntityID:      "https://sp.example.com/metadata",
+					NameID:        "username",
+					NameIDFormat:  "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+					SessionIndex:  "session-index",
+				},
+				{
+					UserAgentID:   "agent-id",
+					UserID:        "user-id",
+					ApplicationID: "app-id-2",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211110,
+					EntityID:      "https://sp2.example.com/metadata",
+					NameID:        "username",
+				},
+			},
+		},
+		{
+			name:    "prepareSAMLSessionsQuery sql err",
+			prepare: prepareSAMLSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSAMLSessionsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
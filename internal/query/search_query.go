@@ -466,6 +466,50 @@ func (q *or) comp() sq.Sqlizer {
 	return sq.Or(queries)
 }
 
+type and struct {
+	queries []SearchQuery
+}
+
+func And(queries ...SearchQuery) *and {
+	return &and{
+		queries: queries,
+	}
+}
+
+func (q *and) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *and) comp() sq.Sqlizer {
+	queries := make([]sq.Sqlizer, 0)
+	for _, query := range q.queries {
+		queries = append(queries, query.comp())
+	}
+	return sq.And(queries)
+}
+
+type not struct {
+	query SearchQuery
+}
+
+func Not(query SearchQuery) *not {
+	return &not{
+		query: query,
+	}
+}
+
+func (q *not) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *not) comp() sq.Sqlizer {
+	stmt, args, err := q.query.comp().ToSql()
+	if err != nil {
+		return nil
+	}
+	return sq.Expr("NOT ("+stmt+")", args...)
+}
+
 type BoolQuery struct {
 	Column Column
 	Value  bool
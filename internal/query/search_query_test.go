@@ -1497,3 +1497,34 @@ func TestInTextQuery_comp(t *testing.T) {
 		})
 	}
 }
+
+func TestAnd_comp(t *testing.T) {
+	query := And(
+		&TextQuery{testCol, "horst", TextEquals},
+		Or(&TextQuery{testCol2, "peter", TextEquals}, &TextQuery{testCol2, "hans", TextEquals}),
+	)
+	stmt, args, err := query.comp().ToSql()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "(test_table.test_col = ? AND (test_table2.test_col2 = ? OR test_table2.test_col2 = ?))"; stmt != want {
+		t.Errorf("wrong query: want: %s, got: %s", want, stmt)
+	}
+	if want := []interface{}{"horst", "peter", "hans"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("wrong args: want: %v, got: %v", want, args)
+	}
+}
+
+func TestNot_comp(t *testing.T) {
+	query := Not(&TextQuery{testCol, "horst", TextEquals})
+	stmt, args, err := query.comp().ToSql()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "NOT (test_table.test_col = ?)"; stmt != want {
+		t.Errorf("wrong query: want: %s, got: %s", want, stmt)
+	}
+	if want := []interface{}{"horst"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("wrong args: want: %v, got: %v", want, args)
+	}
+}
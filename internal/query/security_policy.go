@@ -44,6 +44,14 @@ var (
 		name:  projection.SecurityPolicyColumnAllowedOrigins,
 		table: securityPolicyTable,
 	}
+	SecurityPolicyColumnDelegation = Column{
+		name:  projection.SecurityPolicyColumnDelegation,
+		table: securityPolicyTable,
+	}
+	SecurityPolicyColumnImpersonation = Column{
+		name:  projection.SecurityPolicyColumnImpersonation,
+		table: securityPolicyTable,
+	}
 )
 
 type SecurityPolicy struct {
@@ -53,8 +61,10 @@ type SecurityPolicy struct {
 	ResourceOwner string
 	Sequence      uint64
 
-	Enabled        bool
-	AllowedOrigins database.StringArray
+	Enabled             bool
+	AllowedOrigins      database.StringArray
+	EnableDelegation    bool
+	EnableImpersonation bool
 }
 
 func (q *Queries) SecurityPolicy(ctx context.Context) (*SecurityPolicy, error) {
@@ -78,7 +88,9 @@ func prepareSecurityPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 			SecurityPolicyColumnInstanceID.identifier(),
 			SecurityPolicyColumnSequence.identifier(),
 			SecurityPolicyColumnEnabled.identifier(),
-			SecurityPolicyColumnAllowedOrigins.identifier()).
+			SecurityPolicyColumnAllowedOrigins.identifier(),
+			SecurityPolicyColumnDelegation.identifier(),
+			SecurityPolicyColumnImpersonation.identifier()).
 			From(securityPolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*SecurityPolicy, error) {
@@ -91,6 +103,8 @@ func prepareSecurityPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 				&securityPolicy.Sequence,
 				&securityPolicy.Enabled,
 				&securityPolicy.AllowedOrigins,
+				&securityPolicy.EnableDelegation,
+				&securityPolicy.EnableImpersonation,
 			)
 			if err != nil && !errs.Is(err, sql.ErrNoRows) { // ignore not found errors
 				return nil, errors.ThrowInternal(err, "QUERY-Dfrt2", "Errors.Internal")
@@ -34,6 +34,9 @@ type Session struct {
 	PasswordFactor SessionPasswordFactor
 	IntentFactor   SessionIntentFactor
 	WebAuthNFactor SessionWebAuthNFactor
+	TOTPFactor     SessionTOTPFactor
+	OTPSMSFactor   SessionOTPFactor
+	OTPEmailFactor SessionOTPFactor
 	Metadata       map[string][]byte
 }
 
@@ -47,6 +50,8 @@ type SessionUserFactor struct {
 
 type SessionPasswordFactor struct {
 	PasswordCheckedAt time.Time
+	ExpirationDate    time.Time
+	ExpiryState       domain.PasswordExpiryState
 }
 
 type SessionIntentFactor struct {
@@ -58,6 +63,14 @@ type SessionWebAuthNFactor struct {
 	UserVerified      bool
 }
 
+type SessionTOTPFactor struct {
+	TOTPCheckedAt time.Time
+}
+
+type SessionOTPFactor struct {
+	OTPCheckedAt time.Time
+}
+
 type SessionsSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -132,6 +145,18 @@ var (
 		name:  projection.SessionColumnWebAuthNUserVerified,
 		table: sessionsTable,
 	}
+	SessionColumnTOTPCheckedAt = Column{
+		name:  projection.SessionColumnTOTPCheckedAt,
+		table: sessionsTable,
+	}
+	SessionColumnOTPSMSCheckedAt = Column{
+		name:  projection.SessionColumnOTPSMSCheckedAt,
+		table: sessionsTable,
+	}
+	SessionColumnOTPEmailCheckedAt = Column{
+		name:  projection.SessionColumnOTPEmailCheckedAt,
+		table: sessionsTable,
+	}
 	SessionColumnMetadata = Column{
 		name:  projection.SessionColumnMetadata,
 		table: sessionsTable,
@@ -166,11 +191,13 @@ func (q *Queries) SessionByID(ctx context.Context, shouldTriggerBulk bool, id, s
 	if err != nil {
 		return nil, err
 	}
-	if sessionToken == "" {
-		return session, nil
+	if sessionToken != "" {
+		if err := q.sessionTokenVerifier(ctx, sessionToken, session.ID, tokenID); err != nil {
+			return nil, errors.ThrowPermissionDenied(nil, "QUERY-dsfr3", "Errors.PermissionDenied")
+		}
 	}
-	if err := q.sessionTokenVerifier(ctx, sessionToken, session.ID, tokenID); err != nil {
-		return nil, errors.ThrowPermissionDenied(nil, "QUERY-dsfr3", "Errors.PermissionDenied")
+	if err = q.fillSessionPasswordExpiry(ctx, session); err != nil {
+		return nil, err
 	}
 	return session, nil
 }
@@ -196,10 +223,55 @@ func (q *Queries) SearchSessions(ctx context.Context, queries *SessionsSearchQue
 	if err != nil {
 		return nil, err
 	}
+	if err = q.fillSessionPasswordExpiry(ctx, sessions.Sessions...); err != nil {
+		return nil, err
+	}
 	sessions.LatestSequence, err = q.latestSequence(ctx, sessionsTable)
 	return sessions, err
 }
 
+// fillSessionPasswordExpiry sets the expiration date and state of the user's password
+// on sessions with a checked password.
+// The password age policy is queried once per organization and the password changes
+// of all users, whose organization defines a maximum age, are filtered at once.
+func (q *Queries) fillSessionPasswordExpiry(ctx context.Context, sessions ...*Session) error {
+	policies := make(map[string]*domain.PasswordAgePolicy)
+	userIDs := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if session.PasswordFactor.PasswordCheckedAt.IsZero() {
+			continue
+		}
+		policy, ok := policies[session.UserFactor.ResourceOwner]
+		if !ok {
+			agePolicy, err := q.PasswordAgePolicyByOrg(ctx, false, session.UserFactor.ResourceOwner, false)
+			if err != nil {
+				return err
+			}
+			policy = agePolicy.ToDomain()
+			policies[session.UserFactor.ResourceOwner] = policy
+		}
+		if policy.MaxAgeDays > 0 {
+			userIDs = append(userIDs, session.UserFactor.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	passwordsChanged, err := q.humanPasswordsChanged(ctx, userIDs...)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, session := range sessions {
+		changed, ok := passwordsChanged[session.UserFactor.UserID]
+		if !ok || session.PasswordFactor.PasswordCheckedAt.IsZero() {
+			continue
+		}
+		session.PasswordFactor.ExpirationDate, session.PasswordFactor.ExpiryState = policies[session.UserFactor.ResourceOwner].PasswordExpiry(changed, now)
+	}
+	return nil
+}
+
 func NewSessionIDsSearchQuery(ids []string) (SearchQuery, error) {
 	list := make([]interface{}, len(ids))
 	for i, value := range ids {
@@ -230,6 +302,9 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			SessionColumnIntentCheckedAt.identifier(),
 			SessionColumnWebAuthNCheckedAt.identifier(),
 			SessionColumnWebAuthNUserVerified.identifier(),
+			SessionColumnTOTPCheckedAt.identifier(),
+			SessionColumnOTPSMSCheckedAt.identifier(),
+			SessionColumnOTPEmailCheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			SessionColumnToken.identifier(),
 		).From(sessionsTable.identifier()).
@@ -249,6 +324,9 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				intentCheckedAt     sql.NullTime
 				webAuthNCheckedAt   sql.NullTime
 				webAuthNUserPresent sql.NullBool
+				totpCheckedAt       sql.NullTime
+				otpSMSCheckedAt     sql.NullTime
+				otpEmailCheckedAt   sql.NullTime
 				metadata            database.Map[[]byte]
 				token               sql.NullString
 			)
@@ -270,6 +348,9 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				&intentCheckedAt,
 				&webAuthNCheckedAt,
 				&webAuthNUserPresent,
+				&totpCheckedAt,
+				&otpSMSCheckedAt,
+				&otpEmailCheckedAt,
 				&metadata,
 				&token,
 			)
@@ -290,6 +371,9 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			session.IntentFactor.IntentCheckedAt = intentCheckedAt.Time
 			session.WebAuthNFactor.WebAuthNCheckedAt = webAuthNCheckedAt.Time
 			session.WebAuthNFactor.UserVerified = webAuthNUserPresent.Bool
+			session.TOTPFactor.TOTPCheckedAt = totpCheckedAt.Time
+			session.OTPSMSFactor.OTPCheckedAt = otpSMSCheckedAt.Time
+			session.OTPEmailFactor.OTPCheckedAt = otpEmailCheckedAt.Time
 			session.Metadata = metadata
 
 			return session, token.String, nil
@@ -314,6 +398,9 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 			SessionColumnIntentCheckedAt.identifier(),
 			SessionColumnWebAuthNCheckedAt.identifier(),
 			SessionColumnWebAuthNUserVerified.identifier(),
+			SessionColumnTOTPCheckedAt.identifier(),
+			SessionColumnOTPSMSCheckedAt.identifier(),
+			SessionColumnOTPEmailCheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			countColumn.identifier(),
 		).From(sessionsTable.identifier()).
@@ -336,6 +423,9 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					intentCheckedAt     sql.NullTime
 					webAuthNCheckedAt   sql.NullTime
 					webAuthNUserPresent sql.NullBool
+					totpCheckedAt       sql.NullTime
+					otpSMSCheckedAt     sql.NullTime
+					otpEmailCheckedAt   sql.NullTime
 					metadata            database.Map[[]byte]
 				)
 
@@ -356,6 +446,9 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					&intentCheckedAt,
 					&webAuthNCheckedAt,
 					&webAuthNUserPresent,
+					&totpCheckedAt,
+					&otpSMSCheckedAt,
+					&otpEmailCheckedAt,
 					&metadata,
 					&sessions.Count,
 				)
@@ -372,6 +465,9 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 				session.IntentFactor.IntentCheckedAt = intentCheckedAt.Time
 				session.WebAuthNFactor.WebAuthNCheckedAt = webAuthNCheckedAt.Time
 				session.WebAuthNFactor.UserVerified = webAuthNUserPresent.Bool
+				session.TOTPFactor.TOTPCheckedAt = totpCheckedAt.Time
+				session.OTPSMSFactor.OTPCheckedAt = otpSMSCheckedAt.Time
+				session.OTPEmailFactor.OTPCheckedAt = otpEmailCheckedAt.Time
 				session.Metadata = metadata
 
 				sessions.Sessions = append(sessions.Sessions, session)
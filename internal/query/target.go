@@ -0,0 +1,249 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	targetTable = table{
+		name:          projection.TargetTable,
+		instanceIDCol: projection.TargetInstanceIDCol,
+	}
+	TargetColumnID = Column{
+		name:  projection.TargetIDCol,
+		table: targetTable,
+	}
+	TargetColumnCreationDate = Column{
+		name:  projection.TargetCreationDateCol,
+		table: targetTable,
+	}
+	TargetColumnChangeDate = Column{
+		name:  projection.TargetChangeDateCol,
+		table: targetTable,
+	}
+	TargetColumnResourceOwner = Column{
+		name:  projection.TargetResourceOwnerCol,
+		table: targetTable,
+	}
+	TargetColumnInstanceID = Column{
+		name:  projection.TargetInstanceIDCol,
+		table: targetTable,
+	}
+	TargetColumnSequence = Column{
+		name:  projection.TargetSequenceCol,
+		table: targetTable,
+	}
+	TargetColumnName = Column{
+		name:  projection.TargetNameCol,
+		table: targetTable,
+	}
+	TargetColumnEndpoint = Column{
+		name:  projection.TargetEndpointCol,
+		table: targetTable,
+	}
+	TargetColumnTimeout = Column{
+		name:  projection.TargetTimeoutCol,
+		table: targetTable,
+	}
+	TargetColumnAsync = Column{
+		name:  projection.TargetAsyncCol,
+		table: targetTable,
+	}
+	TargetColumnInterruptOnError = Column{
+		name:  projection.TargetInterruptOnErrorCol,
+		table: targetTable,
+	}
+	TargetColumnSigningKey = Column{
+		name:  projection.TargetSigningKeyCol,
+		table: targetTable,
+	}
+)
+
+type Targets struct {
+	SearchResponse
+	Targets []*Target
+}
+
+type Target struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Name             string
+	Endpoint         string
+	Timeout          time.Duration
+	Async            bool
+	InterruptOnError bool
+	signingKey       *crypto.CryptoValue
+}
+
+type TargetSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *TargetSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) SearchTargets(ctx context.Context, queries *TargetSearchQueries) (targets *Targets, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareTargetsQuery(ctx, q.client)
+	eq := sq.Eq{
+		TargetColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-fyO5B", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-nFVuR", "Errors.Internal")
+	}
+	targets, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	targets.LatestSequence, err = q.latestSequence(ctx, targetTable)
+	return targets, err
+}
+
+func (q *Queries) GetTargetByID(ctx context.Context, id string) (_ *Target, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareTargetQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		TargetColumnID.identifier():         id,
+		TargetColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ZD0Ml", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func NewTargetNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(TargetColumnName, value, method)
+}
+
+func NewTargetInIDsSearchQuery(values []string) (SearchQuery, error) {
+	return NewInTextQuery(TargetColumnID, values)
+}
+
+func prepareTargetsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*Targets, error)) {
+	return sq.Select(
+			TargetColumnID.identifier(),
+			TargetColumnCreationDate.identifier(),
+			TargetColumnChangeDate.identifier(),
+			TargetColumnResourceOwner.identifier(),
+			TargetColumnSequence.identifier(),
+			TargetColumnName.identifier(),
+			TargetColumnEndpoint.identifier(),
+			TargetColumnTimeout.identifier(),
+			TargetColumnAsync.identifier(),
+			TargetColumnInterruptOnError.identifier(),
+			TargetColumnSigningKey.identifier(),
+			countColumn.identifier(),
+		).From(targetTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Targets, error) {
+			targets := make([]*Target, 0)
+			var count uint64
+			for rows.Next() {
+				target := new(Target)
+				err := rows.Scan(
+					&target.ID,
+					&target.CreationDate,
+					&target.ChangeDate,
+					&target.ResourceOwner,
+					&target.Sequence,
+					&target.Name,
+					&target.Endpoint,
+					&target.Timeout,
+					&target.Async,
+					&target.InterruptOnError,
+					&target.signingKey,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				targets = append(targets, target)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-hhXWk", "Errors.Query.CloseRows")
+			}
+
+			return &Targets{
+				Targets: targets,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareTargetQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*Target, error)) {
+	return sq.Select(
+			TargetColumnID.identifier(),
+			TargetColumnCreationDate.identifier(),
+			TargetColumnChangeDate.identifier(),
+			TargetColumnResourceOwner.identifier(),
+			TargetColumnSequence.identifier(),
+			TargetColumnName.identifier(),
+			TargetColumnEndpoint.identifier(),
+			TargetColumnTimeout.identifier(),
+			TargetColumnAsync.identifier(),
+			TargetColumnInterruptOnError.identifier(),
+			TargetColumnSigningKey.identifier(),
+		).From(targetTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Target, error) {
+			target := new(Target)
+			err := row.Scan(
+				&target.ID,
+				&target.CreationDate,
+				&target.ChangeDate,
+				&target.ResourceOwner,
+				&target.Sequence,
+				&target.Name,
+				&target.Endpoint,
+				&target.Timeout,
+				&target.Async,
+				&target.InterruptOnError,
+				&target.signingKey,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-hj9ok", "Errors.Target.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-5cJ2W", "Errors.Internal")
+			}
+			return target, nil
+		}
+}
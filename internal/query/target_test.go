@@ -0,0 +1,243 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareTargetsStmt = `SELECT projections.targets.id,` +
+		` projections.targets.creation_date,` +
+		` projections.targets.change_date,` +
+		` projections.targets.resource_owner,` +
+		` projections.targets.sequence,` +
+		` projections.targets.name,` +
+		` projections.targets.endpoint,` +
+		` projections.targets.timeout,` +
+		` projections.targets.async,` +
+		` projections.targets.interrupt_on_error,` +
+		` projections.targets.signing_key,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.targets` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareTargetsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"name",
+		"endpoint",
+		"timeout",
+		"async",
+		"interrupt_on_error",
+		"signing_key",
+		"count",
+	}
+
+	prepareTargetStmt = `SELECT projections.targets.id,` +
+		` projections.targets.creation_date,` +
+		` projections.targets.change_date,` +
+		` projections.targets.resource_owner,` +
+		` projections.targets.sequence,` +
+		` projections.targets.name,` +
+		` projections.targets.endpoint,` +
+		` projections.targets.timeout,` +
+		` projections.targets.async,` +
+		` projections.targets.interrupt_on_error,` +
+		` projections.targets.signing_key` +
+		` FROM projections.targets` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareTargetCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"name",
+		"endpoint",
+		"timeout",
+		"async",
+		"interrupt_on_error",
+		"signing_key",
+	}
+
+	testTargetSigningKey = &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("key"),
+	}
+)
+
+func Test_TargetPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareTargetsQuery no result",
+			prepare: prepareTargetsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareTargetsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &Targets{Targets: []*Target{}},
+		},
+		{
+			name:    "prepareTargetsQuery one result",
+			prepare: prepareTargetsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareTargetsStmt),
+					prepareTargetsCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							"target-name",
+							"https://example.com",
+							1 * time.Second,
+							true,
+							false,
+							[]byte(`{"cryptoType":0,"algorithm":"enc","keyID":"id","crypted":"a2V5"}`),
+						},
+					},
+				),
+			},
+			object: &Targets{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Targets: []*Target{
+					{
+						ID:               "id",
+						CreationDate:     testNow,
+						ChangeDate:       testNow,
+						ResourceOwner:    "ro",
+						Sequence:         20211109,
+						Name:             "target-name",
+						Endpoint:         "https://example.com",
+						Timeout:          1 * time.Second,
+						Async:            true,
+						InterruptOnError: false,
+						signingKey:       testTargetSigningKey,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareTargetsQuery sql err",
+			prepare: prepareTargetsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareTargetsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareTargetQuery no result",
+			prepare: prepareTargetQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareTargetStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Target)(nil),
+		},
+		{
+			name:    "prepareTargetQuery found",
+			prepare: prepareTargetQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareTargetStmt),
+					prepareTargetCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211109),
+						"target-name",
+						"https://example.com",
+						1 * time.Second,
+						false,
+						true,
+						[]byte(`{"cryptoType":0,"algorithm":"enc","keyID":"id","crypted":"a2V5"}`),
+					},
+				),
+			},
+			object: &Target{
+				ID:               "id",
+				CreationDate:     testNow,
+				ChangeDate:       testNow,
+				ResourceOwner:    "ro",
+				Sequence:         20211109,
+				Name:             "target-name",
+				Endpoint:         "https://example.com",
+				Timeout:          1 * time.Second,
+				Async:            false,
+				InterruptOnError: true,
+				signingKey:       testTargetSigningKey,
+			},
+		},
+		{
+			name:    "prepareTargetQuery sql err",
+			prepare: prepareTargetQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareTargetStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	tokenExchangePolicyTable = table{
+		name:          projection.TokenExchangePolicyTable,
+		instanceIDCol: projection.TokenExchangePolicyInstanceIDCol,
+	}
+	TokenExchangePolicyColID = Column{
+		name:  projection.TokenExchangePolicyIDCol,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColInstanceID = Column{
+		name:  projection.TokenExchangePolicyInstanceIDCol,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColCreationDate = Column{
+		name:  projection.TokenExchangePolicyCreationDateCol,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColChangeDate = Column{
+		name:  projection.TokenExchangePolicyChangeDateCol,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColSequence = Column{
+		name:  projection.TokenExchangePolicySequenceCol,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColDisableDelegation = Column{
+		name:  projection.TokenExchangePolicyDisableDelegationCol,
+		table: tokenExchangePolicyTable,
+	}
+	TokenExchangePolicyColDisableImpersonation = Column{
+		name:  projection.TokenExchangePolicyDisableImpersonationCol,
+		table: tokenExchangePolicyTable,
+	}
+)
+
+// TokenExchangePolicy restricts the token exchange of the security policy for the users of an organisation
+type TokenExchangePolicy struct {
+	ID           string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+
+	DisableDelegation    bool
+	DisableImpersonation bool
+}
+
+// TokenExchangePolicyByOrg returns the token exchange policy of the organisation,
+// if none is set, the returned policy does not restrict the security policy of the instance
+func (q *Queries) TokenExchangePolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string) (_ *TokenExchangePolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = projection.TokenExchangePolicyProjection.Trigger(ctx)
+	}
+	stmt, scan := prepareTokenExchangePolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		TokenExchangePolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		TokenExchangePolicyColID.identifier():         orgID,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Rk3dw", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	policy, err := scan(row)
+	if err != nil {
+		return nil, err
+	}
+	policy.ID = orgID
+	return policy, nil
+}
+
+func prepareTokenExchangePolicyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*TokenExchangePolicy, error)) {
+	return sq.Select(
+			TokenExchangePolicyColID.identifier(),
+			TokenExchangePolicyColCreationDate.identifier(),
+			TokenExchangePolicyColChangeDate.identifier(),
+			TokenExchangePolicyColSequence.identifier(),
+			TokenExchangePolicyColDisableDelegation.identifier(),
+			TokenExchangePolicyColDisableImpersonation.identifier()).
+			From(tokenExchangePolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*TokenExchangePolicy, error) {
+			policy := new(TokenExchangePolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.Sequence,
+				&policy.DisableDelegation,
+				&policy.DisableImpersonation,
+			)
+			if err != nil && !errs.Is(err, sql.ErrNoRows) { // ignore not found errors
+				return nil, errors.ThrowInternal(err, "QUERY-Zb5ko", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
@@ -0,0 +1,103 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	prepareTokenExchangePolicyStmt = `SELECT projections.token_exchange_policies.id,` +
+		` projections.token_exchange_policies.creation_date,` +
+		` projections.token_exchange_policies.change_date,` +
+		` projections.token_exchange_policies.sequence,` +
+		` projections.token_exchange_policies.disable_delegation,` +
+		` projections.token_exchange_policies.disable_impersonation` +
+		` FROM projections.token_exchange_policies` +
+		` AS OF SYSTEM TIME '-1 ms'`
+
+	prepareTokenExchangePolicyCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"disable_delegation",
+		"disable_impersonation",
+	}
+)
+
+func Test_TokenExchangePolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareTokenExchangePolicyQuery no result",
+			prepare: prepareTokenExchangePolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareTokenExchangePolicyStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &TokenExchangePolicy{},
+		},
+		{
+			name:    "prepareTokenExchangePolicyQuery found",
+			prepare: prepareTokenExchangePolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareTokenExchangePolicyStmt),
+					prepareTokenExchangePolicyCols,
+					[]driver.Value{
+						"org-id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						true,
+						false,
+					},
+				),
+			},
+			object: &TokenExchangePolicy{
+				ID:                "org-id",
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				DisableDelegation: true,
+			},
+		},
+		{
+			name:    "prepareTokenExchangePolicyQuery sql err",
+			prepare: prepareTokenExchangePolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareTokenExchangePolicyStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -31,6 +31,7 @@ type User struct {
 	ResourceOwner      string
 	Sequence           uint64
 	State              domain.UserState
+	LockedUntil        time.Time
 	Type               domain.UserType
 	Username           string
 	LoginNames         database.StringArray
@@ -39,6 +40,18 @@ type User struct {
 	Machine            *Machine
 }
 
+// setLockedUntil sets the end of a timed lockout and reports the user as active
+// again once it has passed.
+func (u *User) setLockedUntil(lockedUntil sql.NullTime) {
+	if !lockedUntil.Valid {
+		return
+	}
+	u.LockedUntil = lockedUntil.Time
+	if u.State == domain.UserStateLocked && !time.Now().Before(u.LockedUntil) {
+		u.State = domain.UserStateActive
+	}
+}
+
 type Human struct {
 	FirstName         string
 	LastName          string
@@ -154,6 +167,10 @@ var (
 		name:  projection.UserStateCol,
 		table: userTable,
 	}
+	UserLockedUntilCol = Column{
+		name:  projection.UserLockedUntilCol,
+		table: userTable,
+	}
 	UserSequenceCol = Column{
 		name:  projection.UserSequenceCol,
 		table: userTable,
@@ -743,6 +760,7 @@ func prepareUserQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			UserResourceOwnerCol.identifier(),
 			UserSequenceCol.identifier(),
 			UserStateCol.identifier(),
+			UserLockedUntilCol.identifier(),
 			UserTypeCol.identifier(),
 			UserUsernameCol.identifier(),
 			userLoginNamesListCol.identifier(),
@@ -796,6 +814,8 @@ func prepareUserQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			phone := sql.NullString{}
 			isPhoneVerified := sql.NullBool{}
 
+			lockedUntil := sql.NullTime{}
+
 			machineID := sql.NullString{}
 			name := sql.NullString{}
 			description := sql.NullString{}
@@ -809,6 +829,7 @@ func prepareUserQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 				&u.ResourceOwner,
 				&u.Sequence,
 				&u.State,
+				&lockedUntil,
 				&u.Type,
 				&u.Username,
 				&u.LoginNames,
@@ -841,6 +862,7 @@ func prepareUserQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			}
 
 			u.PreferredLoginName = preferredLoginName.String
+			u.setLockedUntil(lockedUntil)
 
 			if humanID.Valid {
 				u.Human = &Human{
@@ -1213,6 +1235,7 @@ func prepareUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilde
 			UserResourceOwnerCol.identifier(),
 			UserSequenceCol.identifier(),
 			UserStateCol.identifier(),
+			UserLockedUntilCol.identifier(),
 			UserTypeCol.identifier(),
 			UserUsernameCol.identifier(),
 			userLoginNamesListCol.identifier(),
@@ -1268,6 +1291,8 @@ func prepareUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilde
 				phone := sql.NullString{}
 				isPhoneVerified := sql.NullBool{}
 
+				lockedUntil := sql.NullTime{}
+
 				machineID := sql.NullString{}
 				name := sql.NullString{}
 				description := sql.NullString{}
@@ -1281,6 +1306,7 @@ func prepareUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilde
 					&u.ResourceOwner,
 					&u.Sequence,
 					&u.State,
+					&lockedUntil,
 					&u.Type,
 					&u.Username,
 					&loginNames,
@@ -1309,6 +1335,7 @@ func prepareUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilde
 				}
 
 				u.LoginNames = loginNames
+				u.setLockedUntil(lockedUntil)
 				if preferredLoginName.Valid {
 					u.PreferredLoginName = preferredLoginName.String
 				}
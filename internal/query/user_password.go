@@ -43,6 +43,19 @@ func (q *Queries) GetHumanPassword(ctx context.Context, orgID, userID string) (e
 	return existingPassword.EncodedHash, nil
 }
 
+// humanPasswordsChanged returns the date of the last password change of each of the users,
+// users without a password are omitted. The events of all users are filtered at once.
+func (q *Queries) humanPasswordsChanged(ctx context.Context, userIDs ...string) (_ map[string]time.Time, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	readModel := newHumanPasswordsChangedReadModel(userIDs...)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.PasswordChanged, nil
+}
+
 func (q *Queries) passwordReadModel(ctx context.Context, userID, resourceOwner string) (readModel *HumanPasswordReadModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -146,3 +159,60 @@ func (wm *HumanPasswordReadModel) Query() *eventstore.SearchQueryBuilder {
 	}
 	return query
 }
+
+// humanPasswordsChangedReadModel reduces the date of the last password change of multiple users
+type humanPasswordsChangedReadModel struct {
+	*eventstore.ReadModel
+
+	userIDs         []string
+	PasswordChanged map[string]time.Time
+}
+
+func newHumanPasswordsChangedReadModel(userIDs ...string) *humanPasswordsChangedReadModel {
+	return &humanPasswordsChangedReadModel{
+		ReadModel:       new(eventstore.ReadModel),
+		userIDs:         userIDs,
+		PasswordChanged: make(map[string]time.Time, len(userIDs)),
+	}
+}
+
+func (rm *humanPasswordsChangedReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			rm.setPasswordChanged(e.Aggregate().ID, user.SecretOrEncodedHash(e.Secret, e.EncodedHash), e.CreationDate())
+		case *user.HumanRegisteredEvent:
+			rm.setPasswordChanged(e.Aggregate().ID, user.SecretOrEncodedHash(e.Secret, e.EncodedHash), e.CreationDate())
+		case *user.HumanPasswordChangedEvent:
+			rm.setPasswordChanged(e.Aggregate().ID, user.SecretOrEncodedHash(e.Secret, e.EncodedHash), e.CreationDate())
+		case *user.UserRemovedEvent:
+			delete(rm.PasswordChanged, e.Aggregate().ID)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *humanPasswordsChangedReadModel) setPasswordChanged(userID, encodedHash string, changed time.Time) {
+	if encodedHash == "" {
+		delete(rm.PasswordChanged, userID)
+		return
+	}
+	rm.PasswordChanged[userID] = changed
+}
+
+func (rm *humanPasswordsChangedReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AllowTimeTravel().
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.userIDs...).
+		EventTypes(user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.HumanPasswordChangedType,
+			user.UserRemovedType,
+			user.UserV1AddedType,
+			user.UserV1RegisteredType,
+			user.UserV1PasswordChangedType,
+		).
+		Builder()
+}
@@ -22,43 +22,44 @@ var (
 	preferredLoginNameQuery = `SELECT preferred_login_name.user_id, preferred_login_name.login_name, preferred_login_name.instance_id, preferred_login_name.user_owner_removed, preferred_login_name.policy_owner_removed, preferred_login_name.domain_owner_removed` +
 		` FROM projections.login_names2 AS preferred_login_name` +
 		` WHERE  preferred_login_name.is_primary = $1`
-	userQuery = `SELECT projections.users8.id,` +
-		` projections.users8.creation_date,` +
-		` projections.users8.change_date,` +
-		` projections.users8.resource_owner,` +
-		` projections.users8.sequence,` +
-		` projections.users8.state,` +
-		` projections.users8.type,` +
-		` projections.users8.username,` +
+	userQuery = `SELECT projections.users9.id,` +
+		` projections.users9.creation_date,` +
+		` projections.users9.change_date,` +
+		` projections.users9.resource_owner,` +
+		` projections.users9.sequence,` +
+		` projections.users9.state,` +
+		` projections.users9.locked_until,` +
+		` projections.users9.type,` +
+		` projections.users9.username,` +
 		` login_names.loginnames,` +
 		` preferred_login_name.login_name,` +
-		` projections.users8_humans.user_id,` +
-		` projections.users8_humans.first_name,` +
-		` projections.users8_humans.last_name,` +
-		` projections.users8_humans.nick_name,` +
-		` projections.users8_humans.display_name,` +
-		` projections.users8_humans.preferred_language,` +
-		` projections.users8_humans.gender,` +
-		` projections.users8_humans.avatar_key,` +
-		` projections.users8_humans.email,` +
-		` projections.users8_humans.is_email_verified,` +
-		` projections.users8_humans.phone,` +
-		` projections.users8_humans.is_phone_verified,` +
-		` projections.users8_machines.user_id,` +
-		` projections.users8_machines.name,` +
-		` projections.users8_machines.description,` +
-		` projections.users8_machines.has_secret,` +
-		` projections.users8_machines.access_token_type,` +
+		` projections.users9_humans.user_id,` +
+		` projections.users9_humans.first_name,` +
+		` projections.users9_humans.last_name,` +
+		` projections.users9_humans.nick_name,` +
+		` projections.users9_humans.display_name,` +
+		` projections.users9_humans.preferred_language,` +
+		` projections.users9_humans.gender,` +
+		` projections.users9_humans.avatar_key,` +
+		` projections.users9_humans.email,` +
+		` projections.users9_humans.is_email_verified,` +
+		` projections.users9_humans.phone,` +
+		` projections.users9_humans.is_phone_verified,` +
+		` projections.users9_machines.user_id,` +
+		` projections.users9_machines.name,` +
+		` projections.users9_machines.description,` +
+		` projections.users9_machines.has_secret,` +
+		` projections.users9_machines.access_token_type,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.users8` +
-		` LEFT JOIN projections.users8_humans ON projections.users8.id = projections.users8_humans.user_id AND projections.users8.instance_id = projections.users8_humans.instance_id` +
-		` LEFT JOIN projections.users8_machines ON projections.users8.id = projections.users8_machines.user_id AND projections.users8.instance_id = projections.users8_machines.instance_id` +
+		` FROM projections.users9` +
+		` LEFT JOIN projections.users9_humans ON projections.users9.id = projections.users9_humans.user_id AND projections.users9.instance_id = projections.users9_humans.instance_id` +
+		` LEFT JOIN projections.users9_machines ON projections.users9.id = projections.users9_machines.user_id AND projections.users9.instance_id = projections.users9_machines.instance_id` +
 		` LEFT JOIN` +
 		` (` + loginNamesQuery + `) AS login_names` +
-		` ON login_names.user_id = projections.users8.id AND login_names.instance_id = projections.users8.instance_id` +
+		` ON login_names.user_id = projections.users9.id AND login_names.instance_id = projections.users9.instance_id` +
 		` LEFT JOIN` +
 		` (` + preferredLoginNameQuery + `) AS preferred_login_name` +
-		` ON preferred_login_name.user_id = projections.users8.id AND preferred_login_name.instance_id = projections.users8.instance_id` +
+		` ON preferred_login_name.user_id = projections.users9.id AND preferred_login_name.instance_id = projections.users9.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	userCols = []string{
 		"id",
@@ -67,6 +68,7 @@ var (
 		"resource_owner",
 		"sequence",
 		"state",
+		"locked_until",
 		"type",
 		"username",
 		"loginnames",
@@ -92,21 +94,21 @@ var (
 		"access_token_type",
 		"count",
 	}
-	profileQuery = `SELECT projections.users8.id,` +
-		` projections.users8.creation_date,` +
-		` projections.users8.change_date,` +
-		` projections.users8.resource_owner,` +
-		` projections.users8.sequence,` +
-		` projections.users8_humans.user_id,` +
-		` projections.users8_humans.first_name,` +
-		` projections.users8_humans.last_name,` +
-		` projections.users8_humans.nick_name,` +
-		` projections.users8_humans.display_name,` +
-		` projections.users8_humans.preferred_language,` +
-		` projections.users8_humans.gender,` +
-		` projections.users8_humans.avatar_key` +
-		` FROM projections.users8` +
-		` LEFT JOIN projections.users8_humans ON projections.users8.id = projections.users8_humans.user_id AND projections.users8.instance_id = projections.users8_humans.instance_id` +
+	profileQuery = `SELECT projections.users9.id,` +
+		` projections.users9.creation_date,` +
+		` projections.users9.change_date,` +
+		` projections.users9.resource_owner,` +
+		` projections.users9.sequence,` +
+		` projections.users9_humans.user_id,` +
+		` projections.users9_humans.first_name,` +
+		` projections.users9_humans.last_name,` +
+		` projections.users9_humans.nick_name,` +
+		` projections.users9_humans.display_name,` +
+		` projections.users9_humans.preferred_language,` +
+		` projections.users9_humans.gender,` +
+		` projections.users9_humans.avatar_key` +
+		` FROM projections.users9` +
+		` LEFT JOIN projections.users9_humans ON projections.users9.id = projections.users9_humans.user_id AND projections.users9.instance_id = projections.users9_humans.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	profileCols = []string{
 		"id",
@@ -123,16 +125,16 @@ var (
 		"gender",
 		"avatar_key",
 	}
-	emailQuery = `SELECT projections.users8.id,` +
-		` projections.users8.creation_date,` +
-		` projections.users8.change_date,` +
-		` projections.users8.resource_owner,` +
-		` projections.users8.sequence,` +
-		` projections.users8_humans.user_id,` +
-		` projections.users8_humans.email,` +
-		` projections.users8_humans.is_email_verified` +
-		` FROM projections.users8` +
-		` LEFT JOIN projections.users8_humans ON projections.users8.id = projections.users8_humans.user_id AND projections.users8.instance_id = projections.users8_humans.instance_id` +
+	emailQuery = `SELECT projections.users9.id,` +
+		` projections.users9.creation_date,` +
+		` projections.users9.change_date,` +
+		` projections.users9.resource_owner,` +
+		` projections.users9.sequence,` +
+		` projections.users9_humans.user_id,` +
+		` projections.users9_humans.email,` +
+		` projections.users9_humans.is_email_verified` +
+		` FROM projections.users9` +
+		` LEFT JOIN projections.users9_humans ON projections.users9.id = projections.users9_humans.user_id AND projections.users9.instance_id = projections.users9_humans.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	emailCols = []string{
 		"id",
@@ -144,16 +146,16 @@ var (
 		"email",
 		"is_email_verified",
 	}
-	phoneQuery = `SELECT projections.users8.id,` +
-		` projections.users8.creation_date,` +
-		` projections.users8.change_date,` +
-		` projections.users8.resource_owner,` +
-		` projections.users8.sequence,` +
-		` projections.users8_humans.user_id,` +
-		` projections.users8_humans.phone,` +
-		` projections.users8_humans.is_phone_verified` +
-		` FROM projections.users8` +
-		` LEFT JOIN projections.users8_humans ON projections.users8.id = projections.users8_humans.user_id AND projections.users8.instance_id = projections.users8_humans.instance_id` +
+	phoneQuery = `SELECT projections.users9.id,` +
+		` projections.users9.creation_date,` +
+		` projections.users9.change_date,` +
+		` projections.users9.resource_owner,` +
+		` projections.users9.sequence,` +
+		` projections.users9_humans.user_id,` +
+		` projections.users9_humans.phone,` +
+		` projections.users9_humans.is_phone_verified` +
+		` FROM projections.users9` +
+		` LEFT JOIN projections.users9_humans ON projections.users9.id = projections.users9_humans.user_id AND projections.users9.instance_id = projections.users9_humans.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	phoneCols = []string{
 		"id",
@@ -165,14 +167,14 @@ var (
 		"phone",
 		"is_phone_verified",
 	}
-	userUniqueQuery = `SELECT projections.users8.id,` +
-		` projections.users8.state,` +
-		` projections.users8.username,` +
-		` projections.users8_humans.user_id,` +
-		` projections.users8_humans.email,` +
-		` projections.users8_humans.is_email_verified` +
-		` FROM projections.users8` +
-		` LEFT JOIN projections.users8_humans ON projections.users8.id = projections.users8_humans.user_id AND projections.users8.instance_id = projections.users8_humans.instance_id` +
+	userUniqueQuery = `SELECT projections.users9.id,` +
+		` projections.users9.state,` +
+		` projections.users9.username,` +
+		` projections.users9_humans.user_id,` +
+		` projections.users9_humans.email,` +
+		` projections.users9_humans.is_email_verified` +
+		` FROM projections.users9` +
+		` LEFT JOIN projections.users9_humans ON projections.users9.id = projections.users9_humans.user_id AND projections.users9.instance_id = projections.users9_humans.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	userUniqueCols = []string{
 		"id",
@@ -182,40 +184,40 @@ var (
 		"email",
 		"is_email_verified",
 	}
-	notifyUserQuery = `SELECT projections.users8.id,` +
-		` projections.users8.creation_date,` +
-		` projections.users8.change_date,` +
-		` projections.users8.resource_owner,` +
-		` projections.users8.sequence,` +
-		` projections.users8.state,` +
-		` projections.users8.type,` +
-		` projections.users8.username,` +
+	notifyUserQuery = `SELECT projections.users9.id,` +
+		` projections.users9.creation_date,` +
+		` projections.users9.change_date,` +
+		` projections.users9.resource_owner,` +
+		` projections.users9.sequence,` +
+		` projections.users9.state,` +
+		` projections.users9.type,` +
+		` projections.users9.username,` +
 		` login_names.loginnames,` +
 		` preferred_login_name.login_name,` +
-		` projections.users8_humans.user_id,` +
-		` projections.users8_humans.first_name,` +
-		` projections.users8_humans.last_name,` +
-		` projections.users8_humans.nick_name,` +
-		` projections.users8_humans.display_name,` +
-		` projections.users8_humans.preferred_language,` +
-		` projections.users8_humans.gender,` +
-		` projections.users8_humans.avatar_key,` +
-		` projections.users8_notifications.user_id,` +
-		` projections.users8_notifications.last_email,` +
-		` projections.users8_notifications.verified_email,` +
-		` projections.users8_notifications.last_phone,` +
-		` projections.users8_notifications.verified_phone,` +
-		` projections.users8_notifications.password_set,` +
+		` projections.users9_humans.user_id,` +
+		` projections.users9_humans.first_name,` +
+		` projections.users9_humans.last_name,` +
+		` projections.users9_humans.nick_name,` +
+		` projections.users9_humans.display_name,` +
+		` projections.users9_humans.preferred_language,` +
+		` projections.users9_humans.gender,` +
+		` projections.users9_humans.avatar_key,` +
+		` projections.users9_notifications.user_id,` +
+		` projections.users9_notifications.last_email,` +
+		` projections.users9_notifications.verified_email,` +
+		` projections.users9_notifications.last_phone,` +
+		` projections.users9_notifications.verified_phone,` +
+		` projections.users9_notifications.password_set,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.users8` +
-		` LEFT JOIN projections.users8_humans ON projections.users8.id = projections.users8_humans.user_id AND projections.users8.instance_id = projections.users8_humans.instance_id` +
-		` LEFT JOIN projections.users8_notifications ON projections.users8.id = projections.users8_notifications.user_id AND projections.users8.instance_id = projections.users8_notifications.instance_id` +
+		` FROM projections.users9` +
+		` LEFT JOIN projections.users9_humans ON projections.users9.id = projections.users9_humans.user_id AND projections.users9.instance_id = projections.users9_humans.instance_id` +
+		` LEFT JOIN projections.users9_notifications ON projections.users9.id = projections.users9_notifications.user_id AND projections.users9.instance_id = projections.users9_notifications.instance_id` +
 		` LEFT JOIN` +
 		` (` + loginNamesQuery + `) AS login_names` +
-		` ON login_names.user_id = projections.users8.id AND login_names.instance_id = projections.users8.instance_id` +
+		` ON login_names.user_id = projections.users9.id AND login_names.instance_id = projections.users9.instance_id` +
 		` LEFT JOIN` +
 		` (` + preferredLoginNameQuery + `) AS preferred_login_name` +
-		` ON preferred_login_name.user_id = projections.users8.id AND preferred_login_name.instance_id = projections.users8.instance_id` +
+		` ON preferred_login_name.user_id = projections.users9.id AND preferred_login_name.instance_id = projections.users9.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	notifyUserCols = []string{
 		"id",
@@ -246,43 +248,44 @@ var (
 		"password_set",
 		"count",
 	}
-	usersQuery = `SELECT projections.users8.id,` +
-		` projections.users8.creation_date,` +
-		` projections.users8.change_date,` +
-		` projections.users8.resource_owner,` +
-		` projections.users8.sequence,` +
-		` projections.users8.state,` +
-		` projections.users8.type,` +
-		` projections.users8.username,` +
+	usersQuery = `SELECT projections.users9.id,` +
+		` projections.users9.creation_date,` +
+		` projections.users9.change_date,` +
+		` projections.users9.resource_owner,` +
+		` projections.users9.sequence,` +
+		` projections.users9.state,` +
+		` projections.users9.locked_until,` +
+		` projections.users9.type,` +
+		` projections.users9.username,` +
 		` login_names.loginnames,` +
 		` preferred_login_name.login_name,` +
-		` projections.users8_humans.user_id,` +
-		` projections.users8_humans.first_name,` +
-		` projections.users8_humans.last_name,` +
-		` projections.users8_humans.nick_name,` +
-		` projections.users8_humans.display_name,` +
-		` projections.users8_humans.preferred_language,` +
-		` projections.users8_humans.gender,` +
-		` projections.users8_humans.avatar_key,` +
-		` projections.users8_humans.email,` +
-		` projections.users8_humans.is_email_verified,` +
-		` projections.users8_humans.phone,` +
-		` projections.users8_humans.is_phone_verified,` +
-		` projections.users8_machines.user_id,` +
-		` projections.users8_machines.name,` +
-		` projections.users8_machines.description,` +
-		` projections.users8_machines.has_secret,` +
-		` projections.users8_machines.access_token_type,` +
+		` projections.users9_humans.user_id,` +
+		` projections.users9_humans.first_name,` +
+		` projections.users9_humans.last_name,` +
+		` projections.users9_humans.nick_name,` +
+		` projections.users9_humans.display_name,` +
+		` projections.users9_humans.preferred_language,` +
+		` projections.users9_humans.gender,` +
+		` projections.users9_humans.avatar_key,` +
+		` projections.users9_humans.email,` +
+		` projections.users9_humans.is_email_verified,` +
+		` projections.users9_humans.phone,` +
+		` projections.users9_humans.is_phone_verified,` +
+		` projections.users9_machines.user_id,` +
+		` projections.users9_machines.name,` +
+		` projections.users9_machines.description,` +
+		` projections.users9_machines.has_secret,` +
+		` projections.users9_machines.access_token_type,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.users8` +
-		` LEFT JOIN projections.users8_humans ON projections.users8.id = projections.users8_humans.user_id AND projections.users8.instance_id = projections.users8_humans.instance_id` +
-		` LEFT JOIN projections.users8_machines ON projections.users8.id = projections.users8_machines.user_id AND projections.users8.instance_id = projections.users8_machines.instance_id` +
+		` FROM projections.users9` +
+		` LEFT JOIN projections.users9_humans ON projections.users9.id = projections.users9_humans.user_id AND projections.users9.instance_id = projections.users9_humans.instance_id` +
+		` LEFT JOIN projections.users9_machines ON projections.users9.id = projections.users9_machines.user_id AND projections.users9.instance_id = projections.users9_machines.instance_id` +
 		` LEFT JOIN` +
 		` (` + loginNamesQuery + `) AS login_names` +
-		` ON login_names.user_id = projections.users8.id AND login_names.instance_id = projections.users8.instance_id` +
+		` ON login_names.user_id = projections.users9.id AND login_names.instance_id = projections.users9.instance_id` +
 		` LEFT JOIN` +
 		` (` + preferredLoginNameQuery + `) AS preferred_login_name` +
-		` ON preferred_login_name.user_id = projections.users8.id AND preferred_login_name.instance_id = projections.users8.instance_id` +
+		` ON preferred_login_name.user_id = projections.users9.id AND preferred_login_name.instance_id = projections.users9.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	usersCols = []string{
 		"id",
@@ -291,6 +294,7 @@ var (
 		"resource_owner",
 		"sequence",
 		"state",
+		"locked_until",
 		"type",
 		"username",
 		"loginnames",
@@ -361,6 +365,7 @@ func Test_UserPrepares(t *testing.T) {
 						"resource_owner",
 						uint64(20211108),
 						domain.UserStateActive,
+						nil,
 						domain.UserTypeHuman,
 						"username",
 						database.StringArray{"login_name1", "login_name2"},
@@ -414,6 +419,75 @@ func Test_UserPrepares(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareUserQuery lockout expired",
+			prepare: prepareUserQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(userQuery),
+					userCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						"resource_owner",
+						uint64(20211108),
+						domain.UserStateLocked,
+						testNow,
+						domain.UserTypeHuman,
+						"username",
+						database.StringArray{"login_name1", "login_name2"},
+						"login_name1",
+						//human
+						"id",
+						"first_name",
+						"last_name",
+						"nick_name",
+						"display_name",
+						"de",
+						domain.GenderUnspecified,
+						"avatar_key",
+						"email",
+						true,
+						"phone",
+						true,
+						//machine
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						1,
+					},
+				),
+			},
+			object: &User{
+				ID:                 "id",
+				CreationDate:       testNow,
+				ChangeDate:         testNow,
+				ResourceOwner:      "resource_owner",
+				Sequence:           20211108,
+				State:              domain.UserStateActive,
+				LockedUntil:        testNow,
+				Type:               domain.UserTypeHuman,
+				Username:           "username",
+				LoginNames:         database.StringArray{"login_name1", "login_name2"},
+				PreferredLoginName: "login_name1",
+				Human: &Human{
+					FirstName:         "first_name",
+					LastName:          "last_name",
+					NickName:          "nick_name",
+					DisplayName:       "display_name",
+					AvatarKey:         "avatar_key",
+					PreferredLanguage: language.German,
+					Gender:            domain.GenderUnspecified,
+					Email:             "email",
+					IsEmailVerified:   true,
+					Phone:             "phone",
+					IsPhoneVerified:   true,
+				},
+			},
+		},
 		{
 			name:    "prepareUserQuery machine found",
 			prepare: prepareUserQuery,
@@ -428,6 +502,7 @@ func Test_UserPrepares(t *testing.T) {
 						"resource_owner",
 						uint64(20211108),
 						domain.UserStateActive,
+						nil,
 						domain.UserTypeMachine,
 						"username",
 						database.StringArray{"login_name1", "login_name2"},
@@ -1015,6 +1090,7 @@ func Test_UserPrepares(t *testing.T) {
 							"resource_owner",
 							uint64(20211108),
 							domain.UserStateActive,
+							nil,
 							domain.UserTypeHuman,
 							"username",
 							database.StringArray{"login_name1", "login_name2"},
@@ -1090,6 +1166,7 @@ func Test_UserPrepares(t *testing.T) {
 							"resource_owner",
 							uint64(20211108),
 							domain.UserStateActive,
+							nil,
 							domain.UserTypeHuman,
 							"username",
 							database.StringArray{"login_name1", "login_name2"},
@@ -1121,6 +1198,7 @@ func Test_UserPrepares(t *testing.T) {
 							"resource_owner",
 							uint64(20211108),
 							domain.UserStateActive,
+							nil,
 							domain.UserTypeMachine,
 							"username",
 							database.StringArray{"login_name1", "login_name2"},
@@ -0,0 +1,9 @@
+package execution
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, SetEventType, eventstore.GenericEventMapper[SetEvent]).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, eventstore.GenericEventMapper[RemovedEvent]).
+		RegisterFilterEventMapper(AggregateType, EventTargetsCalledEventType, eventstore.GenericEventMapper[EventTargetsCalledEvent])
+}
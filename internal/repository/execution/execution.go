@@ -0,0 +1,113 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix             eventstore.EventType = "execution."
+	SetEventType                                     = eventTypePrefix + "set"
+	RemovedEventType                                 = eventTypePrefix + "removed"
+	EventTargetsCalledEventType                      = eventTypePrefix + "event.targets.called"
+)
+
+// SetEvent sets the targets which are called on the condition,
+// the condition is represented by the id of the aggregate (e.g. request/zitadel.session.v2alpha.SessionService/CreateSession)
+type SetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Targets []string `json:"targets"`
+}
+
+func (e *SetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *SetEvent) Data() interface{} {
+	return e
+}
+
+func (e *SetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	targets []string,
+) *SetEvent {
+	return &SetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, SetEventType,
+		),
+		Targets: targets,
+	}
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *RemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, RemovedEventType,
+		),
+	}
+}
+
+// EventTargetsCalledEvent records the targets of an event execution which were called for an event,
+// so that they are not called again if the event is retried after another target failed
+type EventTargetsCalledEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	EventAggregateType eventstore.AggregateType `json:"aggregateType"`
+	EventAggregateID   string                   `json:"aggregateID"`
+	EventSequence      uint64                   `json:"sequence"`
+	Targets            []string                 `json:"targets"`
+}
+
+func (e *EventTargetsCalledEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *EventTargetsCalledEvent) Data() interface{} {
+	return e
+}
+
+func (e *EventTargetsCalledEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewEventTargetsCalledEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	eventAggregateType eventstore.AggregateType,
+	eventAggregateID string,
+	eventSequence uint64,
+	targets []string,
+) *EventTargetsCalledEvent {
+	return &EventTargetsCalledEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, EventTargetsCalledEventType,
+		),
+		EventAggregateType: eventAggregateType,
+		EventAggregateID:   eventAggregateID,
+		EventSequence:      eventSequence,
+		Targets:            targets,
+	}
+}
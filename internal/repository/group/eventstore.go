@@ -0,0 +1,14 @@
+package group
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedEventType, eventstore.GenericEventMapper[AddedEvent]).
+		RegisterFilterEventMapper(AggregateType, ChangedEventType, eventstore.GenericEventMapper[ChangedEvent]).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, eventstore.GenericEventMapper[RemovedEvent]).
+		RegisterFilterEventMapper(AggregateType, MemberAddedEventType, eventstore.GenericEventMapper[MemberAddedEvent]).
+		RegisterFilterEventMapper(AggregateType, MemberRemovedEventType, eventstore.GenericEventMapper[MemberRemovedEvent]).
+		RegisterFilterEventMapper(AggregateType, GrantAddedEventType, eventstore.GenericEventMapper[GrantAddedEvent]).
+		RegisterFilterEventMapper(AggregateType, GrantChangedEventType, eventstore.GenericEventMapper[GrantChangedEvent]).
+		RegisterFilterEventMapper(AggregateType, GrantRemovedEventType, eventstore.GenericEventMapper[GrantRemovedEvent])
+}
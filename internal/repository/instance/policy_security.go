@@ -17,8 +17,10 @@ const (
 type SecurityPolicySetEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Enabled        *bool     `json:"enabled,omitempty"`
-	AllowedOrigins *[]string `json:"allowedOrigins,omitempty"`
+	Enabled             *bool     `json:"enabled,omitempty"`
+	AllowedOrigins      *[]string `json:"allowedOrigins,omitempty"`
+	EnableDelegation    *bool     `json:"enableDelegation,omitempty"`
+	EnableImpersonation *bool     `json:"enableImpersonation,omitempty"`
 }
 
 func NewSecurityPolicySetEvent(
@@ -59,6 +61,18 @@ func ChangeSecurityPolicyAllowedOrigins(allowedOrigins []string) func(event *Sec
 	}
 }
 
+func ChangeSecurityPolicyEnableDelegation(enabled bool) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		e.EnableDelegation = &enabled
+	}
+}
+
+func ChangeSecurityPolicyEnableImpersonation(enabled bool) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		e.EnableImpersonation = &enabled
+	}
+}
+
 func (e *SecurityPolicySetEvent) Data() interface{} {
 	return e
 }
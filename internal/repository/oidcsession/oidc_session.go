@@ -21,13 +21,14 @@ const (
 type AddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	UserID      string                      `json:"userID"`
-	SessionID   string                      `json:"sessionID"`
-	ClientID    string                      `json:"clientID"`
-	Audience    []string                    `json:"audience"`
-	Scope       []string                    `json:"scope"`
-	AuthMethods []domain.UserAuthMethodType `json:"authMethods"`
-	AuthTime    time.Time                   `json:"authTime"`
+	UserID        string                      `json:"userID"`
+	SessionID     string                      `json:"sessionID"`
+	ClientID      string                      `json:"clientID"`
+	Audience      []string                    `json:"audience"`
+	Scope         []string                    `json:"scope"`
+	AuthMethods   []domain.UserAuthMethodType `json:"authMethods"`
+	AuthTime      time.Time                   `json:"authTime"`
+	JWKThumbprint string                      `json:"jkt,omitempty"`
 }
 
 func (e *AddedEvent) Data() interface{} {
@@ -51,6 +52,7 @@ func NewAddedEvent(ctx context.Context,
 	scope []string,
 	authMethods []domain.UserAuthMethodType,
 	authTime time.Time,
+	jwkThumbprint string,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -58,13 +60,14 @@ func NewAddedEvent(ctx context.Context,
 			aggregate,
 			AddedType,
 		),
-		UserID:      userID,
-		SessionID:   sessionID,
-		ClientID:    clientID,
-		Audience:    audience,
-		Scope:       scope,
-		AuthMethods: authMethods,
-		AuthTime:    authTime,
+		UserID:        userID,
+		SessionID:     sessionID,
+		ClientID:      clientID,
+		Audience:      audience,
+		Scope:         scope,
+		AuthMethods:   authMethods,
+		AuthTime:      authTime,
+		JWKThumbprint: jwkThumbprint,
 	}
 }
 
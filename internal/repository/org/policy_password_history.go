@@ -0,0 +1,102 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+var (
+	PasswordHistoryPolicyAddedEventType   = orgEventTypePrefix + policy.PasswordHistoryPolicyAddedEventType
+	PasswordHistoryPolicyChangedEventType = orgEventTypePrefix + policy.PasswordHistoryPolicyChangedEventType
+	PasswordHistoryPolicyRemovedEventType = orgEventTypePrefix + policy.PasswordHistoryPolicyRemovedEventType
+)
+
+type PasswordHistoryPolicyAddedEvent struct {
+	policy.PasswordHistoryPolicyAddedEvent
+}
+
+func NewPasswordHistoryPolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	historyCount uint64,
+) *PasswordHistoryPolicyAddedEvent {
+	return &PasswordHistoryPolicyAddedEvent{
+		PasswordHistoryPolicyAddedEvent: *policy.NewPasswordHistoryPolicyAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				PasswordHistoryPolicyAddedEventType),
+			historyCount),
+	}
+}
+
+func PasswordHistoryPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.PasswordHistoryPolicyAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordHistoryPolicyAddedEvent{PasswordHistoryPolicyAddedEvent: *e.(*policy.PasswordHistoryPolicyAddedEvent)}, nil
+}
+
+type PasswordHistoryPolicyChangedEvent struct {
+	policy.PasswordHistoryPolicyChangedEvent
+}
+
+func NewPasswordHistoryPolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []policy.PasswordHistoryPolicyChanges,
+) (*PasswordHistoryPolicyChangedEvent, error) {
+	changedEvent, err := policy.NewPasswordHistoryPolicyChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PasswordHistoryPolicyChangedEventType),
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &PasswordHistoryPolicyChangedEvent{PasswordHistoryPolicyChangedEvent: *changedEvent}, nil
+}
+
+func PasswordHistoryPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.PasswordHistoryPolicyChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordHistoryPolicyChangedEvent{PasswordHistoryPolicyChangedEvent: *e.(*policy.PasswordHistoryPolicyChangedEvent)}, nil
+}
+
+type PasswordHistoryPolicyRemovedEvent struct {
+	policy.PasswordHistoryPolicyRemovedEvent
+}
+
+func NewPasswordHistoryPolicyRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *PasswordHistoryPolicyRemovedEvent {
+	return &PasswordHistoryPolicyRemovedEvent{
+		PasswordHistoryPolicyRemovedEvent: *policy.NewPasswordHistoryPolicyRemovedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				PasswordHistoryPolicyRemovedEventType),
+		),
+	}
+}
+
+func PasswordHistoryPolicyRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.PasswordHistoryPolicyRemovedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordHistoryPolicyRemovedEvent{PasswordHistoryPolicyRemovedEvent: *e.(*policy.PasswordHistoryPolicyRemovedEvent)}, nil
+}
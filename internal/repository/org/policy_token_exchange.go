@@ -0,0 +1,79 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	tokenExchangePolicyPrefix       = "policy.token_exchange."
+	TokenExchangePolicySetEventType = orgEventTypePrefix + tokenExchangePolicyPrefix + "set"
+)
+
+// TokenExchangePolicySetEvent restricts the token exchange enabled in the security policy of the instance
+// for the users of the organisation
+type TokenExchangePolicySetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DisableDelegation    *bool `json:"disableDelegation,omitempty"`
+	DisableImpersonation *bool `json:"disableImpersonation,omitempty"`
+}
+
+func NewTokenExchangePolicySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []TokenExchangePolicyChanges,
+) (*TokenExchangePolicySetEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-Wd4ka", "Errors.NoChangesFound")
+	}
+	event := &TokenExchangePolicySetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			TokenExchangePolicySetEventType,
+		),
+	}
+	for _, change := range changes {
+		change(event)
+	}
+	return event, nil
+}
+
+type TokenExchangePolicyChanges func(event *TokenExchangePolicySetEvent)
+
+func ChangeTokenExchangePolicyDisableDelegation(disabled bool) func(event *TokenExchangePolicySetEvent) {
+	return func(e *TokenExchangePolicySetEvent) {
+		e.DisableDelegation = &disabled
+	}
+}
+
+func ChangeTokenExchangePolicyDisableImpersonation(disabled bool) func(event *TokenExchangePolicySetEvent) {
+	return func(e *TokenExchangePolicySetEvent) {
+		e.DisableImpersonation = &disabled
+	}
+}
+
+func (e *TokenExchangePolicySetEvent) Data() interface{} {
+	return e
+}
+
+func (e *TokenExchangePolicySetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func TokenExchangePolicySetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	policySet := &TokenExchangePolicySetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, policySet)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Lq8vd", "unable to unmarshal token exchange policy set")
+	}
+
+	return policySet, nil
+}
@@ -37,11 +37,14 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, OIDCConfigSecretChangedType, OIDCConfigSecretChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, OIDCClientSecretCheckSucceededType, OIDCConfigSecretCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, OIDCClientSecretCheckFailedType, OIDCConfigSecretCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, OIDCRegistrationTokenAddedType, OIDCRegistrationTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, APIConfigAddedType, APIConfigAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, APIConfigChangedType, APIConfigChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, APIConfigSecretChangedType, APIConfigSecretChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, ApplicationKeyAddedEventType, ApplicationKeyAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, ApplicationKeyRemovedEventType, ApplicationKeyRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SAMLConfigAddedType, SAMLConfigAddedEventMapper).
-		RegisterFilterEventMapper(AggregateType, SAMLConfigChangedType, SAMLConfigChangedEventMapper)
+		RegisterFilterEventMapper(AggregateType, SAMLConfigChangedType, SAMLConfigChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, InitialAccessTokenAddedType, InitialAccessTokenAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, InitialAccessTokenRemovedType, InitialAccessTokenRemovedEventMapper)
 }
@@ -0,0 +1,104 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	initialAccessTokenEventPrefix = projectEventTypePrefix + "initial.access.token."
+	InitialAccessTokenAddedType   = initialAccessTokenEventPrefix + "added"
+	InitialAccessTokenRemovedType = initialAccessTokenEventPrefix + "removed"
+)
+
+type InitialAccessTokenAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID    string    `json:"tokenId"`
+	Expiration time.Time `json:"expiration"`
+}
+
+func (e *InitialAccessTokenAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *InitialAccessTokenAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewInitialAccessTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+	expiration time.Time,
+) *InitialAccessTokenAddedEvent {
+	return &InitialAccessTokenAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InitialAccessTokenAddedType,
+		),
+		TokenID:    tokenID,
+		Expiration: expiration,
+	}
+}
+
+func InitialAccessTokenAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &InitialAccessTokenAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Rk2vd", "unable to unmarshal initial access token")
+	}
+
+	return e, nil
+}
+
+type InitialAccessTokenRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func (e *InitialAccessTokenRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *InitialAccessTokenRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewInitialAccessTokenRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *InitialAccessTokenRemovedEvent {
+	return &InitialAccessTokenRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InitialAccessTokenRemovedType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func InitialAccessTokenRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &InitialAccessTokenRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Wm5xt", "unable to unmarshal initial access token")
+	}
+
+	return e, nil
+}
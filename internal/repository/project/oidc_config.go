@@ -18,29 +18,33 @@ const (
 	OIDCConfigSecretChangedType        = applicationEventTypePrefix + "config.oidc.secret.changed"
 	OIDCClientSecretCheckSucceededType = applicationEventTypePrefix + "oidc.secret.check.succeeded"
 	OIDCClientSecretCheckFailedType    = applicationEventTypePrefix + "oidc.secret.check.failed"
+	OIDCRegistrationTokenAddedType     = applicationEventTypePrefix + "config.oidc.registration.token.added"
 )
 
 type OIDCConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Version                  domain.OIDCVersion         `json:"oidcVersion,omitempty"`
-	AppID                    string                     `json:"appId"`
-	ClientID                 string                     `json:"clientId,omitempty"`
-	ClientSecret             *crypto.CryptoValue        `json:"clientSecret,omitempty"`
-	RedirectUris             []string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            []domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               []domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   []string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  bool                       `json:"devMode,omitempty"`
-	AccessTokenType          domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
-	SkipNativeAppSuccessPage bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	Version                   domain.OIDCVersion         `json:"oidcVersion,omitempty"`
+	AppID                     string                     `json:"appId"`
+	ClientID                  string                     `json:"clientId,omitempty"`
+	ClientSecret              *crypto.CryptoValue        `json:"clientSecret,omitempty"`
+	RedirectUris              []string                   `json:"redirectUris,omitempty"`
+	ResponseTypes             []domain.OIDCResponseType  `json:"responseTypes,omitempty"`
+	GrantTypes                []domain.OIDCGrantType     `json:"grantTypes,omitempty"`
+	ApplicationType           domain.OIDCApplicationType `json:"applicationType,omitempty"`
+	AuthMethodType            domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris    []string                   `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                   bool                       `json:"devMode,omitempty"`
+	AccessTokenType           domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion  bool                       `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion      bool                       `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion  bool                       `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                 time.Duration              `json:"clockSkew,omitempty"`
+	AdditionalOrigins         []string                   `json:"additionalOrigins,omitempty"`
+	SkipNativeAppSuccessPage  bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	BackChannelLogoutURI      string                     `json:"backChannelLogoutURI,omitempty"`
+	RequirePushedAuthRequests bool                       `json:"requirePushedAuthRequests,omitempty"`
+	RequireDPoP               bool                       `json:"requireDPoP,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Data() interface{} {
@@ -72,6 +76,9 @@ func NewOIDCConfigAddedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	backChannelLogoutURI string,
+	requirePushedAuthRequests bool,
+	requireDPoP bool,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -79,24 +86,27 @@ func NewOIDCConfigAddedEvent(
 			aggregate,
 			OIDCConfigAddedType,
 		),
-		Version:                  version,
-		AppID:                    appID,
-		ClientID:                 clientID,
-		ClientSecret:             clientSecret,
-		RedirectUris:             redirectUris,
-		ResponseTypes:            responseTypes,
-		GrantTypes:               grantTypes,
-		ApplicationType:          applicationType,
-		AuthMethodType:           authMethodType,
-		PostLogoutRedirectUris:   postLogoutRedirectUris,
-		DevMode:                  devMode,
-		AccessTokenType:          accessTokenType,
-		AccessTokenRoleAssertion: accessTokenRoleAssertion,
-		IDTokenRoleAssertion:     idTokenRoleAssertion,
-		IDTokenUserinfoAssertion: idTokenUserinfoAssertion,
-		ClockSkew:                clockSkew,
-		AdditionalOrigins:        additionalOrigins,
-		SkipNativeAppSuccessPage: skipNativeAppSuccessPage,
+		Version:                   version,
+		AppID:                     appID,
+		ClientID:                  clientID,
+		ClientSecret:              clientSecret,
+		RedirectUris:              redirectUris,
+		ResponseTypes:             responseTypes,
+		GrantTypes:                grantTypes,
+		ApplicationType:           applicationType,
+		AuthMethodType:            authMethodType,
+		PostLogoutRedirectUris:    postLogoutRedirectUris,
+		DevMode:                   devMode,
+		AccessTokenType:           accessTokenType,
+		AccessTokenRoleAssertion:  accessTokenRoleAssertion,
+		IDTokenRoleAssertion:      idTokenRoleAssertion,
+		IDTokenUserinfoAssertion:  idTokenUserinfoAssertion,
+		ClockSkew:                 clockSkew,
+		AdditionalOrigins:         additionalOrigins,
+		SkipNativeAppSuccessPage:  skipNativeAppSuccessPage,
+		BackChannelLogoutURI:      backChannelLogoutURI,
+		RequirePushedAuthRequests: requirePushedAuthRequests,
+		RequireDPoP:               requireDPoP,
 	}
 }
 
@@ -182,7 +192,16 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 			return false
 		}
 	}
-	return e.SkipNativeAppSuccessPage == c.SkipNativeAppSuccessPage
+	if e.SkipNativeAppSuccessPage != c.SkipNativeAppSuccessPage {
+		return false
+	}
+	if e.BackChannelLogoutURI != c.BackChannelLogoutURI {
+		return false
+	}
+	if e.RequirePushedAuthRequests != c.RequirePushedAuthRequests {
+		return false
+	}
+	return e.RequireDPoP == c.RequireDPoP
 }
 
 func OIDCConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
@@ -201,22 +220,25 @@ func OIDCConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 type OIDCConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Version                  *domain.OIDCVersion         `json:"oidcVersion,omitempty"`
-	AppID                    string                      `json:"appId"`
-	RedirectUris             *[]string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            *[]domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               *[]domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          *domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           *domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   *[]string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  *bool                       `json:"devMode,omitempty"`
-	AccessTokenType          *domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion *bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     *bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion *bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                *time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        *[]string                   `json:"additionalOrigins,omitempty"`
-	SkipNativeAppSuccessPage *bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	Version                   *domain.OIDCVersion         `json:"oidcVersion,omitempty"`
+	AppID                     string                      `json:"appId"`
+	RedirectUris              *[]string                   `json:"redirectUris,omitempty"`
+	ResponseTypes             *[]domain.OIDCResponseType  `json:"responseTypes,omitempty"`
+	GrantTypes                *[]domain.OIDCGrantType     `json:"grantTypes,omitempty"`
+	ApplicationType           *domain.OIDCApplicationType `json:"applicationType,omitempty"`
+	AuthMethodType            *domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris    *[]string                   `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                   *bool                       `json:"devMode,omitempty"`
+	AccessTokenType           *domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion  *bool                       `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion      *bool                       `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion  *bool                       `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                 *time.Duration              `json:"clockSkew,omitempty"`
+	AdditionalOrigins         *[]string                   `json:"additionalOrigins,omitempty"`
+	SkipNativeAppSuccessPage  *bool                       `json:"skipNativeAppSuccessPage,omitempty"`
+	BackChannelLogoutURI      *string                     `json:"backChannelLogoutURI,omitempty"`
+	RequirePushedAuthRequests *bool                       `json:"requirePushedAuthRequests,omitempty"`
+	RequireDPoP               *bool                       `json:"requireDPoP,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Data() interface{} {
@@ -343,6 +365,24 @@ func ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage bool) func(event *O
 	}
 }
 
+func ChangeBackChannelLogoutURI(backChannelLogoutURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.BackChannelLogoutURI = &backChannelLogoutURI
+	}
+}
+
+func ChangeRequirePushedAuthRequests(requirePushedAuthRequests bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequirePushedAuthRequests = &requirePushedAuthRequests
+	}
+}
+
+func ChangeRequireDPoP(requireDPoP bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequireDPoP = &requireDPoP
+	}
+}
+
 func OIDCConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -484,3 +524,50 @@ func OIDCConfigSecretCheckFailedEventMapper(event *repository.Event) (eventstore
 
 	return e, nil
 }
+
+// OIDCRegistrationTokenAddedEvent is pushed for applications registered by dynamic client registration (RFC 7591).
+// The id of the token is part of the registration access token, which is needed to read, update and delete the application (RFC 7592).
+type OIDCRegistrationTokenAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID   string `json:"appId"`
+	TokenID string `json:"tokenId"`
+}
+
+func (e *OIDCRegistrationTokenAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *OIDCRegistrationTokenAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewOIDCRegistrationTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	tokenID string,
+) *OIDCRegistrationTokenAddedEvent {
+	return &OIDCRegistrationTokenAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OIDCRegistrationTokenAddedType,
+		),
+		AppID:   appID,
+		TokenID: tokenID,
+	}
+}
+
+func OIDCRegistrationTokenAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &OIDCRegistrationTokenAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-Ht4qa", "unable to unmarshal oidc registration token")
+	}
+
+	return e, nil
+}
@@ -18,10 +18,12 @@ const (
 type SAMLConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID       string `json:"appId"`
-	EntityID    string `json:"entityId"`
-	Metadata    []byte `json:"metadata,omitempty"`
-	MetadataURL string `json:"metadata_url,omitempty"`
+	AppID             string `json:"appId"`
+	EntityID          string `json:"entityId"`
+	Metadata          []byte `json:"metadata,omitempty"`
+	MetadataURL       string `json:"metadata_url,omitempty"`
+	RoleAssertion     bool   `json:"roleAssertion,omitempty"`
+	MetadataAssertion bool   `json:"metadataAssertion,omitempty"`
 }
 
 func (e *SAMLConfigAddedEvent) Data() interface{} {
@@ -52,6 +54,8 @@ func NewSAMLConfigAddedEvent(
 	entityID string,
 	metadata []byte,
 	metadataURL string,
+	roleAssertion,
+	metadataAssertion bool,
 ) *SAMLConfigAddedEvent {
 	return &SAMLConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -59,10 +63,12 @@ func NewSAMLConfigAddedEvent(
 			aggregate,
 			SAMLConfigAddedType,
 		),
-		AppID:       appID,
-		EntityID:    entityID,
-		Metadata:    metadata,
-		MetadataURL: metadataURL,
+		AppID:             appID,
+		EntityID:          entityID,
+		Metadata:          metadata,
+		MetadataURL:       metadataURL,
+		RoleAssertion:     roleAssertion,
+		MetadataAssertion: metadataAssertion,
 	}
 }
 
@@ -82,11 +88,13 @@ func SAMLConfigAddedEventMapper(event *repository.Event) (eventstore.Event, erro
 type SAMLConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID       string  `json:"appId"`
-	EntityID    string  `json:"entityId"`
-	Metadata    []byte  `json:"metadata,omitempty"`
-	MetadataURL *string `json:"metadata_url,omitempty"`
-	oldEntityID string
+	AppID             string  `json:"appId"`
+	EntityID          string  `json:"entityId"`
+	Metadata          []byte  `json:"metadata,omitempty"`
+	MetadataURL       *string `json:"metadata_url,omitempty"`
+	RoleAssertion     *bool   `json:"roleAssertion,omitempty"`
+	MetadataAssertion *bool   `json:"metadataAssertion,omitempty"`
+	oldEntityID       string
 }
 
 func (e *SAMLConfigChangedEvent) Data() interface{} {
@@ -149,6 +157,18 @@ func ChangeEntityID(entityID string) func(event *SAMLConfigChangedEvent) {
 	}
 }
 
+func ChangeRoleAssertion(roleAssertion bool) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.RoleAssertion = &roleAssertion
+	}
+}
+
+func ChangeMetadataAssertion(metadataAssertion bool) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.MetadataAssertion = &metadataAssertion
+	}
+}
+
 func SAMLConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &SAMLConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
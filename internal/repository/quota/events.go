@@ -21,12 +21,17 @@ const (
 	NotifiedEventType             = eventTypePrefix + "notified"
 	NotificationDueEventType      = eventTypePrefix + "notificationdue"
 	RemovedEventType              = eventTypePrefix + "removed"
+	DegradedModeEnteredEventType  = eventTypePrefix + "degraded.entered"
+	DegradedModeLeftEventType     = eventTypePrefix + "degraded.left"
 )
 
 const (
 	Unimplemented Unit = iota
 	RequestsAllAuthenticated
 	ActionsAllRunsSeconds
+	RequestsTokenEndpoint
+	RequestsManagementAPI
+	NotificationsAllSent
 )
 
 func NewAddQuotaUnitUniqueConstraint(unit Unit) *eventstore.EventUniqueConstraint {
@@ -52,9 +57,16 @@ type AddedEvent struct {
 	ResetInterval time.Duration             `json:"interval,omitempty"`
 	Amount        uint64                    `json:"amount"`
 	Limit         bool                      `json:"limit"`
+	GracePercent  uint16                    `json:"gracePercent,omitempty"`
+	GracePeriod   time.Duration             `json:"gracePeriod,omitempty"`
 	Notifications []*AddedEventNotification `json:"notifications,omitempty"`
 }
 
+// Soft returns if the quota degrades the usage instead of blocking it
+func (e *AddedEvent) Soft() bool {
+	return e.Limit && (e.GracePercent > 0 || e.GracePeriod > 0)
+}
+
 type AddedEventNotification struct {
 	ID      string `json:"id"`
 	Percent uint16 `json:"percent"`
@@ -78,6 +90,8 @@ func NewAddedEvent(
 	resetInterval time.Duration,
 	amount uint64,
 	limit bool,
+	gracePercent uint16,
+	gracePeriod time.Duration,
 	notifications []*AddedEventNotification,
 ) *AddedEvent {
 	return &AddedEvent{
@@ -91,6 +105,8 @@ func NewAddedEvent(
 		ResetInterval: resetInterval,
 		Amount:        amount,
 		Limit:         limit,
+		GracePercent:  gracePercent,
+		GracePeriod:   gracePeriod,
 		Notifications: notifications,
 	}
 }
@@ -263,3 +279,94 @@ func RemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
 
 	return e, nil
 }
+
+type DegradedModeEnteredEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	Unit                 Unit      `json:"unit"`
+	PeriodStart          time.Time `json:"periodStart"`
+	Usage                uint64    `json:"usage"`
+}
+
+func (e *DegradedModeEnteredEvent) Data() interface{} {
+	return e
+}
+
+func (e *DegradedModeEnteredEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDegradedModeEnteredEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	unit Unit,
+	periodStart time.Time,
+	usage uint64,
+) *DegradedModeEnteredEvent {
+	return &DegradedModeEnteredEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DegradedModeEnteredEventType,
+		),
+		Unit:        unit,
+		PeriodStart: periodStart,
+		Usage:       usage,
+	}
+}
+
+func DegradedModeEnteredEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &DegradedModeEnteredEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUOTA-Ohng4", "unable to unmarshal quota degraded mode entered")
+	}
+
+	return e, nil
+}
+
+type DegradedModeLeftEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	Unit                 Unit      `json:"unit"`
+	PeriodStart          time.Time `json:"periodStart"`
+}
+
+func (e *DegradedModeLeftEvent) Data() interface{} {
+	return e
+}
+
+func (e *DegradedModeLeftEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDegradedModeLeftEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	unit Unit,
+	periodStart time.Time,
+) *DegradedModeLeftEvent {
+	return &DegradedModeLeftEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DegradedModeLeftEventType,
+		),
+		Unit:        unit,
+		PeriodStart: periodStart,
+	}
+}
+
+func DegradedModeLeftEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &DegradedModeLeftEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUOTA-eiT1a", "unable to unmarshal quota degraded mode left")
+	}
+
+	return e, nil
+}
@@ -8,5 +8,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 	es.RegisterFilterEventMapper(AggregateType, AddedEventType, AddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, NotificationDueEventType, NotificationDueEventMapper).
-		RegisterFilterEventMapper(AggregateType, NotifiedEventType, NotifiedEventMapper)
+		RegisterFilterEventMapper(AggregateType, NotifiedEventType, NotifiedEventMapper).
+		RegisterFilterEventMapper(AggregateType, DegradedModeEnteredEventType, DegradedModeEnteredEventMapper).
+		RegisterFilterEventMapper(AggregateType, DegradedModeLeftEventType, DegradedModeLeftEventMapper)
 }
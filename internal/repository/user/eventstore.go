@@ -43,6 +43,10 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, UserRemovedType, UserRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenAddedType, UserTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenRemovedType, UserTokenRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SAMLSessionAddedType, SAMLSessionAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SAMLSessionTerminatedType, SAMLSessionTerminatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserTokenExchangedType, UserTokenExchangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserImpersonatedType, UserImpersonatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedType, DomainClaimedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedSentType, DomainClaimedSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserUserNameChangedType, UsernameChangedEventMapper).
@@ -56,6 +60,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanInitializedCheckSucceededType, HumanInitializedCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanInitializedCheckFailedType, HumanInitializedCheckFailedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanSignedOutType, HumanSignedOutEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanBackChannelLogoutSentType, eventstore.GenericEventMapper[HumanBackChannelLogoutSentEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanBackChannelLogoutFailedType, eventstore.GenericEventMapper[HumanBackChannelLogoutFailedEvent]).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordChangedType, HumanPasswordChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCodeAddedType, HumanPasswordCodeAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCodeSentType, HumanPasswordCodeSentEventMapper).
@@ -23,6 +23,8 @@ const (
 	HumanInitializedCheckSucceededType = humanEventPrefix + "initialization.check.succeeded"
 	HumanInitializedCheckFailedType    = humanEventPrefix + "initialization.check.failed"
 	HumanSignedOutType                 = humanEventPrefix + "signed.out"
+	HumanBackChannelLogoutSentType     = humanEventPrefix + "backchannel.logout.sent"
+	HumanBackChannelLogoutFailedType   = humanEventPrefix + "backchannel.logout.failed"
 )
 
 type HumanAddedEvent struct {
@@ -407,3 +409,82 @@ func HumanSignedOutEventMapper(event *repository.Event) (eventstore.Event, error
 
 	return signedOut, nil
 }
+
+// HumanBackChannelLogoutSentEvent is pushed after the logout token of a signed out user agent
+// was delivered to the back-channel logout uri of a client
+type HumanBackChannelLogoutSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserAgentID string `json:"userAgentID"`
+	ClientID    string `json:"clientID"`
+}
+
+func (e *HumanBackChannelLogoutSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanBackChannelLogoutSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *HumanBackChannelLogoutSentEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewHumanBackChannelLogoutSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userAgentID,
+	clientID string,
+) *HumanBackChannelLogoutSentEvent {
+	return &HumanBackChannelLogoutSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanBackChannelLogoutSentType,
+		),
+		UserAgentID: userAgentID,
+		ClientID:    clientID,
+	}
+}
+
+// HumanBackChannelLogoutFailedEvent is pushed if the logout token of a signed out user agent
+// could not be delivered to the back-channel logout uri of a client, so the delivery can be retried
+type HumanBackChannelLogoutFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserAgentID string `json:"userAgentID"`
+	ClientID    string `json:"clientID"`
+	Attempt     uint8  `json:"attempt"`
+}
+
+func (e *HumanBackChannelLogoutFailedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanBackChannelLogoutFailedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func (e *HumanBackChannelLogoutFailedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewHumanBackChannelLogoutFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userAgentID,
+	clientID string,
+	attempt uint8,
+) *HumanBackChannelLogoutFailedEvent {
+	return &HumanBackChannelLogoutFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanBackChannelLogoutFailedType,
+		),
+		UserAgentID: userAgentID,
+		ClientID:    clientID,
+		Attempt:     attempt,
+	}
+}
@@ -31,6 +31,7 @@ type HumanRefreshTokenAddedEvent struct {
 	IdleExpiration        time.Duration `json:"idleExpiration"`
 	Expiration            time.Duration `json:"expiration"`
 	PreferredLanguage     string        `json:"preferredLanguage"`
+	JWKThumbprint         string        `json:"jkt,omitempty"`
 }
 
 func (e *HumanRefreshTokenAddedEvent) Data() interface{} {
@@ -58,6 +59,7 @@ func NewHumanRefreshTokenAddedEvent(
 	authTime time.Time,
 	idleExpiration,
 	expiration time.Duration,
+	jwkThumbprint string,
 ) *HumanRefreshTokenAddedEvent {
 	return &HumanRefreshTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -75,6 +77,7 @@ func NewHumanRefreshTokenAddedEvent(
 		IdleExpiration:        idleExpiration,
 		Expiration:            expiration,
 		PreferredLanguage:     preferredLanguage,
+		JWKThumbprint:         jwkThumbprint,
 	}
 }
 
@@ -0,0 +1,122 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	samlSessionEventPrefix    = userEventTypePrefix + "saml.session."
+	SAMLSessionAddedType      = samlSessionEventPrefix + "added"
+	SAMLSessionTerminatedType = samlSessionEventPrefix + "terminated"
+)
+
+// SAMLSessionAddedEvent is pushed when a SAML response was issued to a service provider (application)
+// for the user on the user agent. It's used to propagate a logout to the service provider.
+type SAMLSessionAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserAgentID   string `json:"userAgentId"`
+	ApplicationID string `json:"applicationId"`
+	EntityID      string `json:"entityId"`
+	NameID        string `json:"nameId"`
+	// NameIDFormat is the format of the NameID of the issued assertion
+	NameIDFormat string `json:"nameIdFormat,omitempty"`
+	// SessionIndex is the session index of the AuthnStatement of the issued assertion
+	SessionIndex string `json:"sessionIndex,omitempty"`
+}
+
+func (e *SAMLSessionAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SAMLSessionAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewSAMLSessionAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userAgentID,
+	applicationID,
+	entityID,
+	nameID,
+	nameIDFormat,
+	sessionIndex string,
+) *SAMLSessionAddedEvent {
+	return &SAMLSessionAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLSessionAddedType,
+		),
+		UserAgentID:   userAgentID,
+		ApplicationID: applicationID,
+		EntityID:      entityID,
+		NameID:        nameID,
+		NameIDFormat:  nameIDFormat,
+		SessionIndex:  sessionIndex,
+	}
+}
+
+func SAMLSessionAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	added := &SAMLSessionAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, added)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Jg7ls", "unable to unmarshal saml session added")
+	}
+
+	return added, nil
+}
+
+// SAMLSessionTerminatedEvent is pushed when the logout was propagated to the service provider (application)
+// or the service provider itself requested the logout.
+type SAMLSessionTerminatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserAgentID   string `json:"userAgentId"`
+	ApplicationID string `json:"applicationId"`
+}
+
+func (e *SAMLSessionTerminatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SAMLSessionTerminatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewSAMLSessionTerminatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userAgentID,
+	applicationID string,
+) *SAMLSessionTerminatedEvent {
+	return &SAMLSessionTerminatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLSessionTerminatedType,
+		),
+		UserAgentID:   userAgentID,
+		ApplicationID: applicationID,
+	}
+}
+
+func SAMLSessionTerminatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	terminated := &SAMLSessionTerminatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, terminated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Vb2xq", "unable to unmarshal saml session terminated")
+	}
+
+	return terminated, nil
+}
@@ -0,0 +1,131 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UserTokenExchangedType = userEventTypePrefix + "token.exchanged"
+	UserImpersonatedType   = userEventTypePrefix + "impersonated"
+)
+
+// UserTokenExchangedEvent is pushed when a token of the user was exchanged (RFC 8693) for a new token.
+// If the exchange was requested with an actor token (delegation), the actor is recorded as well.
+type UserTokenExchangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID           string   `json:"clientId"`
+	SubjectTokenType   string   `json:"subjectTokenType"`
+	RequestedTokenType string   `json:"requestedTokenType"`
+	Audience           []string `json:"audience,omitempty"`
+	Scopes             []string `json:"scopes,omitempty"`
+	ActorUserID        string   `json:"actorUserId,omitempty"`
+	ActorResourceOwner string   `json:"actorResourceOwner,omitempty"`
+}
+
+func (e *UserTokenExchangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserTokenExchangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserTokenExchangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID,
+	subjectTokenType,
+	requestedTokenType string,
+	audience,
+	scopes []string,
+	actorUserID,
+	actorResourceOwner string,
+) *UserTokenExchangedEvent {
+	return &UserTokenExchangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserTokenExchangedType,
+		),
+		ClientID:           clientID,
+		SubjectTokenType:   subjectTokenType,
+		RequestedTokenType: requestedTokenType,
+		Audience:           audience,
+		Scopes:             scopes,
+		ActorUserID:        actorUserID,
+		ActorResourceOwner: actorResourceOwner,
+	}
+}
+
+func UserTokenExchangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	exchanged := &UserTokenExchangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, exchanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Hw8ls", "unable to unmarshal token exchanged")
+	}
+	return exchanged, nil
+}
+
+// UserImpersonatedEvent is pushed when the actor received a token of the user (impersonation) by token exchange.
+type UserImpersonatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID           string   `json:"clientId"`
+	RequestedTokenType string   `json:"requestedTokenType"`
+	Audience           []string `json:"audience,omitempty"`
+	Scopes             []string `json:"scopes,omitempty"`
+	ActorUserID        string   `json:"actorUserId"`
+	ActorResourceOwner string   `json:"actorResourceOwner"`
+}
+
+func (e *UserImpersonatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserImpersonatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserImpersonatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID,
+	requestedTokenType string,
+	audience,
+	scopes []string,
+	actorUserID,
+	actorResourceOwner string,
+) *UserImpersonatedEvent {
+	return &UserImpersonatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserImpersonatedType,
+		),
+		ClientID:           clientID,
+		RequestedTokenType: requestedTokenType,
+		Audience:           audience,
+		Scopes:             scopes,
+		ActorUserID:        actorUserID,
+		ActorResourceOwner: actorResourceOwner,
+	}
+}
+
+func UserImpersonatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	impersonated := &UserImpersonatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, impersonated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ow2ka", "unable to unmarshal user impersonated")
+	}
+	return impersonated, nil
+}
@@ -50,30 +50,50 @@ func NewRemoveUsernameUniqueConstraint(userName, resourceOwner string, userLogin
 
 type UserLockedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	// LockoutDuration defines how long the user stays locked, 0 requires a manual unlock
+	LockoutDuration time.Duration `json:"lockoutDuration,omitempty"`
 }
 
 func (e *UserLockedEvent) Data() interface{} {
-	return nil
+	return e
 }
 
 func (e *UserLockedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
 	return nil
 }
 
-func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserLockedEvent {
+// LockedUntil returns the time the lockout expires or the zero time if it requires a manual unlock
+func (e *UserLockedEvent) LockedUntil() time.Time {
+	if e.LockoutDuration <= 0 {
+		return time.Time{}
+	}
+	return e.CreationDate().Add(e.LockoutDuration)
+}
+
+func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate, lockoutDuration time.Duration) *UserLockedEvent {
 	return &UserLockedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
 			ctx,
 			aggregate,
 			UserLockedType,
 		),
+		LockoutDuration: lockoutDuration,
 	}
 }
 
 func UserLockedEventMapper(event *repository.Event) (eventstore.Event, error) {
-	return &UserLockedEvent{
+	lockedEvent := &UserLockedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
-	}, nil
+	}
+	if len(event.Data) == 0 {
+		return lockedEvent, nil
+	}
+	err := json.Unmarshal(event.Data, lockedEvent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-3mSd9", "unable to unmarshal user locked")
+	}
+	return lockedEvent, nil
 }
 
 type UserUnlockedEvent struct {
@@ -219,6 +239,9 @@ type UserTokenAddedEvent struct {
 	Scopes            []string  `json:"scopes"`
 	Expiration        time.Time `json:"expiration"`
 	PreferredLanguage string    `json:"preferredLanguage"`
+	JWKThumbprint     string    `json:"jkt,omitempty"`
+	// Actor is the `act` claim of a token issued on behalf of the user by token exchange (delegation)
+	Actor map[string]interface{} `json:"act,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Data() interface{} {
@@ -240,6 +263,8 @@ func NewUserTokenAddedEvent(
 	audience,
 	scopes []string,
 	expiration time.Time,
+	jwkThumbprint string,
+	actor map[string]interface{},
 ) *UserTokenAddedEvent {
 	return &UserTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -255,6 +280,8 @@ func NewUserTokenAddedEvent(
 		Scopes:            scopes,
 		Expiration:        expiration,
 		PreferredLanguage: preferredLanguage,
+		JWKThumbprint:     jwkThumbprint,
+		Actor:             actor,
 	}
 }
 
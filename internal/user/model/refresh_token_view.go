@@ -23,6 +23,7 @@ type RefreshTokenView struct {
 	Scopes                []string
 	Sequence              uint64
 	Token                 string
+	JWKThumbprint         string
 }
 
 type RefreshTokenSearchRequest struct {
@@ -22,6 +22,8 @@ type TokenView struct {
 	PreferredLanguage string
 	RefreshTokenID    string
 	IsPAT             bool
+	JWKThumbprint     string
+	Actor             map[string]interface{}
 }
 
 type TokenSearchRequest struct {
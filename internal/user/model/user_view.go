@@ -17,6 +17,7 @@ type UserView struct {
 	CreationDate       time.Time
 	ChangeDate         time.Time
 	State              UserState
+	LockedUntil        time.Time
 	Sequence           uint64
 	ResourceOwner      string
 	LastLogin          time.Time
@@ -197,6 +198,11 @@ func (u *UserView) MFATypesAllowed(level domain.MFALevel, policy *domain.LoginPo
 	return types, required
 }
 
+// LockoutExpired returns true if the user was locked for a limited duration which already passed
+func (u *UserView) LockoutExpired(now time.Time) bool {
+	return u.State == UserStateLocked && !u.LockedUntil.IsZero() && !now.Before(u.LockedUntil)
+}
+
 func (u *UserView) IsU2FReady() bool {
 	for _, token := range u.U2FTokens {
 		if token.State == MFAStateReady {
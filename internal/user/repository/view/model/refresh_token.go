@@ -40,6 +40,7 @@ type RefreshTokenView struct {
 	IdleExpiration        time.Time            `json:"-" gorm:"column:idle_expiration"`
 	Expiration            time.Time            `json:"-" gorm:"column:expiration"`
 	Sequence              uint64               `json:"-" gorm:"column:sequence"`
+	JWKThumbprint         string               `json:"jkt,omitempty" gorm:"column:jwk_thumbprint"`
 	InstanceID            string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
 }
 
@@ -68,6 +69,7 @@ func RefreshTokenViewToModel(token *RefreshTokenView) *usr_model.RefreshTokenVie
 		IdleExpiration:        token.IdleExpiration,
 		Expiration:            token.Expiration,
 		Sequence:              token.Sequence,
+		JWKThumbprint:         token.JWKThumbprint,
 	}
 }
 
@@ -137,6 +139,7 @@ func (t *RefreshTokenView) appendAddedEvent(event *es_models.Event) error {
 	t.Scopes = e.Scopes
 	t.Token = e.TokenID
 	t.UserAgentID = e.UserAgentID
+	t.JWKThumbprint = e.JWKThumbprint
 	return nil
 }
 
@@ -40,6 +40,8 @@ type TokenView struct {
 	PreferredLanguage string               `json:"preferredLanguage" gorm:"column:preferred_language"`
 	RefreshTokenID    string               `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
 	IsPAT             bool                 `json:"-" gorm:"is_pat"`
+	JWKThumbprint     string               `json:"jkt,omitempty" gorm:"column:jwk_thumbprint"`
+	Actor             database.Map[any]    `json:"act,omitempty" gorm:"column:actor"`
 	Deactivated       bool                 `json:"-" gorm:"-"`
 	InstanceID        string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
 }
@@ -60,6 +62,8 @@ func TokenViewToModel(token *TokenView) *usr_model.TokenView {
 		PreferredLanguage: token.PreferredLanguage,
 		RefreshTokenID:    token.RefreshTokenID,
 		IsPAT:             token.IsPAT,
+		JWKThumbprint:     token.JWKThumbprint,
+		Actor:             token.Actor,
 	}
 }
 
@@ -48,6 +48,7 @@ type UserView struct {
 	ChangeDate         time.Time            `json:"-" gorm:"column:change_date"`
 	ResourceOwner      string               `json:"-" gorm:"column:resource_owner"`
 	State              int32                `json:"-" gorm:"column:user_state"`
+	LockedUntil        time.Time            `json:"-" gorm:"column:locked_until"`
 	LastLogin          time.Time            `json:"-" gorm:"column:last_login"`
 	LoginNames         database.StringArray `json:"-" gorm:"column:login_names"`
 	PreferredLoginName string               `json:"-" gorm:"column:preferred_login_name"`
@@ -148,6 +149,7 @@ func UserToModel(user *UserView) *model.UserView {
 		CreationDate:       user.CreationDate,
 		ResourceOwner:      user.ResourceOwner,
 		State:              model.UserState(user.State),
+		LockedUntil:        user.LockedUntil,
 		LastLogin:          user.LastLogin,
 		PreferredLoginName: user.PreferredLoginName,
 		LoginNames:         user.LoginNames,
@@ -306,8 +308,10 @@ func (u *UserView) AppendEvent(event *models.Event) (err error) {
 	case user.UserReactivatedType,
 		user.UserUnlockedType:
 		u.State = int32(model.UserStateActive)
+		u.LockedUntil = time.Time{}
 	case user.UserLockedType:
 		u.State = int32(model.UserStateLocked)
+		err = u.setLockedUntil(event)
 	case user.UserV1MFAOTPAddedType,
 		user.HumanMFAOTPAddedType:
 		if u.HumanView == nil {
@@ -387,6 +391,27 @@ func (u *UserView) setPasswordData(event *models.Event) error {
 	return nil
 }
 
+func (u *UserView) setLockedUntil(event *models.Event) error {
+	u.LockedUntil = time.Time{}
+	if len(event.Data) == 0 {
+		return nil
+	}
+	locked := new(user.UserLockedEvent)
+	if err := json.Unmarshal(event.Data, locked); err != nil {
+		logging.Log("MODEL-3nFs0").WithError(err).Error("could not unmarshal event data")
+		return errors.ThrowInternal(nil, "MODEL-Ks02f", "could not unmarshal data")
+	}
+	if locked.LockoutDuration > 0 {
+		u.LockedUntil = event.CreationDate.Add(locked.LockoutDuration)
+	}
+	return nil
+}
+
+// LockoutExpired returns true if the user was locked for a limited duration which already passed
+func (u *UserView) LockoutExpired(now time.Time) bool {
+	return u.State == int32(model.UserStateLocked) && !u.LockedUntil.IsZero() && !now.Before(u.LockedUntil)
+}
+
 func (u *UserView) addPasswordlessToken(event *models.Event) error {
 	token, err := webAuthNViewFromEvent(event)
 	if err != nil {